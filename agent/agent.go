@@ -44,6 +44,7 @@ import (
 	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/agent/reconnectingpty"
 	"github.com/coder/coder/v2/buildinfo"
+	"github.com/coder/coder/v2/cli/clistat"
 	"github.com/coder/coder/v2/cli/gitauth"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/codersdk"
@@ -219,6 +220,8 @@ type agent struct {
 
 	reconnectingPTYs       sync.Map
 	reconnectingPTYTimeout time.Duration
+	// reconnectingPTYSessions maps session IDs to *reconnectingPTYSession.
+	reconnectingPTYSessions sync.Map
 
 	// we track 2 contexts and associated cancel functions: "graceful" which is Done when it is time
 	// to start gracefully shutting down and "hard" which is Done when it is time to close
@@ -261,6 +264,9 @@ type agent struct {
 	// labeled in Coder with the agent + workspace.
 	metrics   *agentMetrics
 	syscaller agentproc.Syscaller
+	// statter samples the CPU usage of the workspace for idle detection. It
+	// is nil if host information is unavailable.
+	statter *clistat.Statter
 
 	// modifiedProcs is used for testing process priority management.
 	modifiedProcs chan []*agentproc.Process
@@ -286,6 +292,12 @@ func (a *agent) init() {
 		panic(err)
 	}
 	a.sshServer = sshSrv
+	statter, err := clistat.New()
+	if err != nil {
+		a.logger.Warn(a.hardCtx, "unable to sample cpu usage, workspace idle detection will rely on sessions only", slog.Error(err))
+	} else {
+		a.statter = statter
+	}
 	a.scriptRunner = agentscripts.New(agentscripts.Options{
 		LogDir:      a.logDir,
 		DataDirBase: a.scriptDataDir,
@@ -1460,15 +1472,37 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 			return xerrors.Errorf("create command: %w", err)
 		}
 
+		timeout := a.reconnectingPTYTimeout
+		if msg.Name != "" {
+			// Named sessions are meant to be reattached to later, so they keep
+			// running until they're closed.
+			timeout = -1
+		}
 		rpty = reconnectingpty.New(ctx, cmd, &reconnectingpty.Options{
-			Timeout: a.reconnectingPTYTimeout,
+			Timeout: timeout,
 			Metrics: a.metrics.reconnectingPTYErrors,
 		}, logger.With(slog.F("message_id", msg.ID)))
 
+		command := msg.Command
+		if command == "" {
+			command = cmd.Path
+		}
+		a.reconnectingPTYSessions.Store(msg.ID, &reconnectingPTYSession{
+			rpty: rpty,
+			info: workspacesdk.ReconnectingPTYSession{
+				ID:        msg.ID,
+				Name:      msg.Name,
+				Command:   command,
+				CreatedAt: time.Now(),
+			},
+		})
+
 		if err = a.trackGoroutine(func() {
 			rpty.Wait()
+			a.reconnectingPTYSessions.Delete(msg.ID)
 			a.reconnectingPTYs.Delete(msg.ID)
 		}); err != nil {
+			a.reconnectingPTYSessions.Delete(msg.ID)
 			rpty.Close(err)
 			return xerrors.Errorf("start routine: %w", err)
 		}
@@ -1476,6 +1510,11 @@ func (a *agent) handleReconnectingPTY(ctx context.Context, logger slog.Logger, m
 		connected = true
 		sendConnected <- rpty
 	}
+	if s, ok := a.reconnectingPTYSessions.Load(msg.ID); ok {
+		if s, ok := s.(*reconnectingPTYSession); ok {
+			defer s.attach()()
+		}
+	}
 	return rpty.Attach(ctx, connectionID, conn, msg.Height, msg.Width, connLogger)
 }
 
@@ -1553,6 +1592,7 @@ func (a *agent) Collect(ctx context.Context, networkStats map[netlogtype.Connect
 	metricsCtx, cancelFunc := context.WithTimeout(ctx, 5*time.Second)
 	defer cancelFunc()
 	a.logger.Debug(ctx, "collecting agent metrics for stats")
+	a.updateCPUUsage(metricsCtx)
 	stats.Metrics = a.collectMetrics(metricsCtx)
 
 	return stats
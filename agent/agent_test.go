@@ -1764,6 +1764,107 @@ func TestAgent_ReconnectingPTY(t *testing.T) {
 	}
 }
 
+func TestAgent_ReconnectingPTYSessions(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:dogsled
+	conn, _, _, _, _ := setupAgent(t, agentsdk.Manifest{}, 0)
+	res, err := conn.ReconnectingPTYSessions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, res.Sessions)
+
+	id := uuid.New()
+	netConn, err := conn.ReconnectingPTY(ctx, id, 80, 80, "sleep 300", workspacesdk.AgentReconnectingPTYInitWithName("build"))
+	require.NoError(t, err)
+	defer netConn.Close()
+
+	var session workspacesdk.ReconnectingPTYSession
+	require.Eventually(t, func() bool {
+		res, err = conn.ReconnectingPTYSessions(ctx)
+		if !assert.NoError(t, err) || len(res.Sessions) != 1 {
+			return false
+		}
+		session = res.Sessions[0]
+		return session.AttachedClients == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.Equal(t, id, session.ID)
+	require.Equal(t, "build", session.Name)
+	require.Equal(t, "sleep 300", session.Command)
+	require.False(t, session.CreatedAt.IsZero())
+	require.False(t, session.LastAttachedAt.Before(session.CreatedAt))
+
+	// Detaching keeps the session running.
+	_ = netConn.Close()
+	require.Eventually(t, func() bool {
+		res, err = conn.ReconnectingPTYSessions(ctx)
+		return assert.NoError(t, err) && len(res.Sessions) == 1 && res.Sessions[0].AttachedClients == 0
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	// Closing the session terminates it and removes it from the list.
+	err = conn.CloseReconnectingPTYSession(ctx, id)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		res, err = conn.ReconnectingPTYSessions(ctx)
+		return assert.NoError(t, err) && len(res.Sessions) == 0
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	err = conn.CloseReconnectingPTYSession(ctx, id)
+	var sdkErr *codersdk.Error
+	require.ErrorAs(t, err, &sdkErr)
+	require.Equal(t, http.StatusNotFound, sdkErr.StatusCode())
+}
+
+func TestAgent_ReconnectingPTYSessionTimeout(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:dogsled
+	conn, _, _, _, _ := setupAgent(t, agentsdk.Manifest{}, testutil.IntervalMedium)
+
+	named := uuid.New()
+	netConn, err := conn.ReconnectingPTY(ctx, named, 80, 80, "sleep 300", workspacesdk.AgentReconnectingPTYInitWithName("build"))
+	require.NoError(t, err)
+	defer netConn.Close()
+	unnamed := uuid.New()
+	unnamedConn, err := conn.ReconnectingPTY(ctx, unnamed, 80, 80, "sleep 300")
+	require.NoError(t, err)
+	defer unnamedConn.Close()
+
+	require.Eventually(t, func() bool {
+		res, err := conn.ReconnectingPTYSessions(ctx)
+		if !assert.NoError(t, err) || len(res.Sessions) != 2 {
+			return false
+		}
+		return res.Sessions[0].AttachedClients == 1 && res.Sessions[1].AttachedClients == 1
+	}, testutil.WaitShort, testutil.IntervalFast)
+
+	// Once both are detached, only the unnamed session times out.
+	_ = netConn.Close()
+	_ = unnamedConn.Close()
+	var res workspacesdk.ReconnectingPTYSessionsResponse
+	require.Eventually(t, func() bool {
+		res, err = conn.ReconnectingPTYSessions(ctx)
+		return assert.NoError(t, err) && len(res.Sessions) == 1
+	}, testutil.WaitMedium, testutil.IntervalFast)
+	require.Equal(t, named, res.Sessions[0].ID)
+
+	// The named session outlives several more timeouts.
+	time.Sleep(5 * testutil.IntervalMedium)
+	res, err = conn.ReconnectingPTYSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, res.Sessions, 1)
+	require.Equal(t, named, res.Sessions[0].ID)
+}
+
 func TestAgent_Dial(t *testing.T) {
 	t.Parallel()
 
@@ -2567,6 +2668,11 @@ func TestAgent_Metrics_SSH(t *testing.T) {
 			Type:  agentsdk.AgentMetricTypeCounter,
 			Value: 0,
 		},
+		{
+			Name:  "agent_stats_cpu_usage_percent",
+			Type:  agentsdk.AgentMetricTypeGauge,
+			Value: 0,
+		},
 		{
 			Name:  "coderd_agentstats_startup_script_seconds",
 			Type:  agentsdk.AgentMetricTypeGauge,
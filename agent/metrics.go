@@ -3,6 +3,8 @@ package agent
 import (
 	"context"
 	"fmt"
+	"math"
+	"runtime"
 	"strings"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +21,10 @@ type agentMetrics struct {
 	// startupScriptSeconds is the time in seconds that the start script(s)
 	// took to run. This is reported once per agent.
 	startupScriptSeconds *prometheus.GaugeVec
+	// cpuUsagePercent is the percentage of the CPU available to the workspace
+	// that was in use when stats were last collected. Coderd uses it to tell
+	// whether a workspace without sessions is idle.
+	cpuUsagePercent prometheus.Gauge
 }
 
 func newAgentMetrics(registerer prometheus.Registerer) *agentMetrics {
@@ -45,10 +51,19 @@ func newAgentMetrics(registerer prometheus.Registerer) *agentMetrics {
 	}, []string{"success"})
 	registerer.MustRegister(startupScriptSeconds)
 
+	cpuUsagePercent := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "agent",
+		Subsystem: "stats",
+		Name:      "cpu_usage_percent",
+		Help:      "Percentage of the CPU available to the workspace that is in use.",
+	})
+	registerer.MustRegister(cpuUsagePercent)
+
 	return &agentMetrics{
 		connectionsTotal:      connectionsTotal,
 		reconnectingPTYErrors: reconnectingPTYErrors,
 		startupScriptSeconds:  startupScriptSeconds,
+		cpuUsagePercent:       cpuUsagePercent,
 	}
 }
 
@@ -101,6 +116,32 @@ func (a *agent) collectMetrics(ctx context.Context) []*proto.Stats_Metric {
 	return collected
 }
 
+// updateCPUUsage samples the CPU usage of the workspace. The container cgroup
+// is preferred when the agent runs inside one, as the host may be shared with
+// other workspaces.
+func (a *agent) updateCPUUsage(ctx context.Context) {
+	if a.statter == nil {
+		return
+	}
+	cpu, err := a.statter.ContainerCPU()
+	if err != nil {
+		a.logger.Debug(ctx, "unable to sample container cpu usage", slog.Error(err))
+	}
+	if cpu == nil {
+		cpu, err = a.statter.HostCPU()
+		if err != nil {
+			a.logger.Debug(ctx, "unable to sample host cpu usage", slog.Error(err))
+			return
+		}
+	}
+	// Containers without a CPU limit may use every core on the host.
+	total := float64(runtime.NumCPU())
+	if cpu.Total != nil && *cpu.Total > 0 {
+		total = *cpu.Total
+	}
+	a.metrics.cpuUsagePercent.Set(math.Min(100, 100*cpu.Used/total))
+}
+
 func toAgentMetricLabels(metricLabels []*prompb.LabelPair) []*proto.Stats_Metric_Label {
 	if len(metricLabels) == 0 {
 		return nil
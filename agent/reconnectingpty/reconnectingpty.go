@@ -27,7 +27,8 @@ const attachTimeout = 30 * time.Second
 // Options allows configuring the reconnecting pty.
 type Options struct {
 	// Timeout describes how long to keep the pty alive without any connections.
-	// Once elapsed the pty will be killed.
+	// Once elapsed the pty will be killed.  A negative timeout keeps the pty
+	// alive until it is closed or the context ends.
 	Timeout time.Duration
 	// Metrics tracks various error counters.
 	Metrics *prometheus.CounterVec
@@ -81,8 +82,14 @@ func New(ctx context.Context, cmd *pty.Cmd, options *Options, logger slog.Logger
 	}
 }
 
-// heartbeat resets timer before timeout elapses and blocks until ctx ends.
+// heartbeat resets timer before timeout elapses and blocks until ctx ends.  A
+// negative timeout stops the timer for good instead.
 func heartbeat(ctx context.Context, timer *time.Timer, timeout time.Duration) {
+	if timeout < 0 {
+		timer.Stop()
+		return
+	}
+
 	// Reset now in case it is near the end.
 	timer.Reset(timeout)
 
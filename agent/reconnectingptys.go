@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/agent/reconnectingpty"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+)
+
+var errReconnectingPTYClosedByAPI = xerrors.New("reconnecting pty closed by request")
+
+// reconnectingPTYSession tracks a running reconnecting PTY so that it can be
+// listed and closed through the agent API.
+type reconnectingPTYSession struct {
+	rpty reconnectingpty.ReconnectingPTY
+
+	mu   sync.Mutex
+	info workspacesdk.ReconnectingPTYSession
+}
+
+// attach records a new client connection. The returned function must be
+// called when the client disconnects.
+func (s *reconnectingPTYSession) attach() func() {
+	s.mu.Lock()
+	s.info.AttachedClients++
+	s.info.LastAttachedAt = time.Now()
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		s.info.AttachedClients--
+		s.mu.Unlock()
+	}
+}
+
+func (s *reconnectingPTYSession) snapshot() workspacesdk.ReconnectingPTYSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.info
+}
+
+func (a *agent) handleListReconnectingPTYs(rw http.ResponseWriter, r *http.Request) {
+	sessions := make([]workspacesdk.ReconnectingPTYSession, 0)
+	a.reconnectingPTYSessions.Range(func(_, value any) bool {
+		if s, ok := value.(*reconnectingPTYSession); ok {
+			sessions = append(sessions, s.snapshot())
+		}
+		return true
+	})
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+
+	httpapi.Write(r.Context(), rw, http.StatusOK, workspacesdk.ReconnectingPTYSessionsResponse{
+		Sessions: sessions,
+	})
+}
+
+func (a *agent) handleCloseReconnectingPTY(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid reconnecting PTY ID.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	value, ok := a.reconnectingPTYSessions.Load(id)
+	if !ok {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Reconnecting PTY not found.",
+		})
+		return
+	}
+	s, ok := value.(*reconnectingPTYSession)
+	if !ok {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Invalid reconnecting PTY.",
+		})
+		return
+	}
+	s.rpty.Close(errReconnectingPTYClosedByAPI)
+	// The session is also removed once its process exits, but doing it here
+	// means it is gone from the list as soon as we respond.
+	a.reconnectingPTYSessions.Delete(id)
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: "Reconnecting PTY closed.",
+	})
+}
@@ -12,6 +12,7 @@ import (
 )
 
 func (r *RootCmd) autoupdate() *serpent.Command {
+	var channel string
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
 		Annotations: workspaceCommand,
@@ -40,11 +41,31 @@ func (r *RootCmd) autoupdate() *serpent.Command {
 				return xerrors.Errorf("update workspace automatic updates policy: %w", err)
 			}
 			_, _ = fmt.Fprintf(inv.Stdout, "Updated workspace %q auto-update policy to %q\n", workspace.Name, policy)
+
+			// An empty channel is valid, and makes the workspace follow the
+			// active version again.
+			if inv.ParsedFlags().Changed("channel") {
+				err = client.UpdateWorkspaceReleaseChannel(inv.Context(), workspace.ID, codersdk.UpdateWorkspaceReleaseChannelRequest{
+					ReleaseChannel: channel,
+				})
+				if err != nil {
+					return xerrors.Errorf("update workspace release channel: %w", err)
+				}
+				if channel == "" {
+					_, _ = fmt.Fprintf(inv.Stdout, "Workspace %q now follows the active template version\n", workspace.Name)
+				} else {
+					_, _ = fmt.Fprintf(inv.Stdout, "Workspace %q now follows release channel %q\n", workspace.Name, channel)
+				}
+			}
 			return nil
 		},
 	}
 
-	cmd.Options = append(cmd.Options, cliui.SkipPromptOption())
+	cmd.Options = append(cmd.Options, serpent.Option{
+		Flag:        "channel",
+		Description: "Follow the given template release channel instead of the active template version. Pass an empty value to follow the active version again.",
+		Value:       serpent.StringOf(&channel),
+	}, cliui.SkipPromptOption())
 	return cmd
 }
 
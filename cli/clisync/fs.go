@@ -0,0 +1,182 @@
+package clisync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+)
+
+// LocalFS is a directory on this machine.
+type LocalFS struct {
+	Root string
+}
+
+var _ FS = LocalFS{}
+
+func (l LocalFS) path(name string) string {
+	return filepath.Join(l.Root, filepath.FromSlash(name))
+}
+
+func (l LocalFS) Walk(ctx context.Context, ignore *Ignore) (map[string]Entry, error) {
+	entries := make(map[string]Entry)
+	err := filepath.WalkDir(l.Root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(l.Root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if ignore.Match(rel, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if e, ok := entryFromInfo(info); ok {
+			entries[rel] = e
+		}
+		return nil
+	})
+	return entries, err
+}
+
+func (l LocalFS) Open(name string) (io.ReadCloser, error) {
+	return os.Open(l.path(name))
+}
+
+func (l LocalFS) Create(name string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(l.path(name)), 0o755); err != nil {
+		return nil, err
+	}
+	return os.Create(l.path(name))
+}
+
+func (l LocalFS) MkdirAll(name string) error {
+	return os.MkdirAll(l.path(name), 0o755)
+}
+
+func (l LocalFS) Remove(name string) error {
+	err := os.Remove(l.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (l LocalFS) Chtimes(name string, modTime time.Time) error {
+	return os.Chtimes(l.path(name), modTime, modTime)
+}
+
+// SFTPFS is a directory in a workspace, accessed over SFTP. Relative roots are resolved from the home directory of
+// the workspace user.
+type SFTPFS struct {
+	Client *sftp.Client
+	Root   string
+}
+
+var _ FS = SFTPFS{}
+
+func (s SFTPFS) path(name string) string {
+	return path.Join(s.Root, name)
+}
+
+func (s SFTPFS) Walk(ctx context.Context, ignore *Ignore) (map[string]Entry, error) {
+	entries := make(map[string]Entry)
+	root := path.Clean(s.Root)
+	walker := s.Client.Walk(root)
+	for walker.Step() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := walker.Err(); err != nil {
+			if walker.Path() == root && errors.Is(err, fs.ErrNotExist) {
+				// The directory is created by the first upload.
+				return entries, nil
+			}
+			return nil, err
+		}
+		if walker.Path() == root {
+			continue
+		}
+		rel := relativePath(root, walker.Path())
+		info := walker.Stat()
+		if ignore.Match(rel, info.IsDir()) {
+			if info.IsDir() {
+				walker.SkipDir()
+			}
+			continue
+		}
+		if e, ok := entryFromInfo(info); ok {
+			entries[rel] = e
+		}
+	}
+	return entries, nil
+}
+
+func (s SFTPFS) Open(name string) (io.ReadCloser, error) {
+	return s.Client.Open(s.path(name))
+}
+
+func (s SFTPFS) Create(name string) (io.WriteCloser, error) {
+	// The root itself may not exist before the first upload.
+	if err := s.Client.MkdirAll(path.Dir(s.path(name))); err != nil {
+		return nil, err
+	}
+	return s.Client.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+}
+
+func (s SFTPFS) MkdirAll(name string) error {
+	return s.Client.MkdirAll(s.path(name))
+}
+
+func (s SFTPFS) Remove(name string) error {
+	err := s.Client.Remove(s.path(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s SFTPFS) Chtimes(name string, modTime time.Time) error {
+	return s.Client.Chtimes(s.path(name), modTime, modTime)
+}
+
+// relativePath returns name relative to root, both being slash-separated paths as produced by an SFTP walk.
+func relativePath(root, name string) string {
+	if root == "." {
+		return name
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(name, root), "/")
+}
+
+// entryFromInfo describes regular files and directories. Other files, such as symbolic links, are not synced.
+func entryFromInfo(info fs.FileInfo) (Entry, bool) {
+	switch {
+	case info.IsDir():
+		return Entry{Dir: true}, true
+	case info.Mode().IsRegular():
+		return Entry{Size: info.Size(), ModTime: info.ModTime().Truncate(time.Second)}, true
+	default:
+		return Entry{}, false
+	}
+}
@@ -0,0 +1,166 @@
+package clisync
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Ignore matches paths against patterns in the .gitignore format. Paths are slash-separated and relative to the root
+// of the synced directory.
+type Ignore struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// NewIgnore returns an Ignore with the given patterns. Later patterns take precedence, so "!" can re-include paths
+// excluded by an earlier pattern.
+func NewIgnore(patterns ...string) *Ignore {
+	i := &Ignore{}
+	i.Add(patterns...)
+	return i
+}
+
+// Add appends the given patterns. Blank lines and comments are skipped.
+func (i *Ignore) Add(patterns ...string) {
+	for _, line := range patterns {
+		if p, ok := parseIgnorePattern(line); ok {
+			i.patterns = append(i.patterns, p)
+		}
+	}
+}
+
+// AddFile appends the patterns in the given file, e.g. a .gitignore. Missing files are not an error.
+func (i *Ignore) AddFile(name string) error {
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return xerrors.Errorf("open ignore file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		i.Add(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return xerrors.Errorf("read %s: %w", filepath.Base(name), err)
+	}
+	return nil
+}
+
+// Match reports whether the given path, or any directory containing it, is ignored.
+func (i *Ignore) Match(name string, isDir bool) bool {
+	if i == nil {
+		return false
+	}
+	parts := strings.Split(name, "/")
+	for n := 1; n < len(parts); n++ {
+		if i.match(strings.Join(parts[:n], "/"), true) {
+			return true
+		}
+	}
+	return i.match(name, isDir)
+}
+
+func (i *Ignore) match(name string, isDir bool) bool {
+	ignored := false
+	for _, p := range i.patterns {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		if p.re.MatchString(name) {
+			ignored = !p.negate
+		}
+	}
+	return ignored
+}
+
+func parseIgnorePattern(line string) (ignorePattern, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false
+	}
+
+	var p ignorePattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignorePattern{}, false
+	}
+
+	// Patterns containing a slash are relative to the root; others match at any depth.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var re strings.Builder
+	re.WriteString("^")
+	if !anchored {
+		re.WriteString("(?:.*/)?")
+	}
+	for j := 0; j < len(line); j++ {
+		c := line[j]
+		switch {
+		case strings.HasPrefix(line[j:], "**/"):
+			re.WriteString("(?:.*/)?")
+			j += 2
+		case strings.HasPrefix(line[j:], "/**") && j+3 == len(line):
+			re.WriteString("/.*")
+			j += 2
+		case strings.HasPrefix(line[j:], "**"):
+			re.WriteString(".*")
+			j++
+		case c == '*':
+			re.WriteString("[^/]*")
+		case c == '?':
+			re.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(line[j+1:], ']')
+			if end < 0 {
+				re.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			class := line[j+1 : j+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			re.WriteString("[" + class + "]")
+			j += end + 1
+		case c == '\\' && j+1 < len(line):
+			j++
+			re.WriteString(regexp.QuoteMeta(string(line[j])))
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+
+	compiled, err := regexp.Compile(re.String())
+	if err != nil {
+		// Malformed character classes are ignored, like git does.
+		return ignorePattern{}, false
+	}
+	p.re = compiled
+	return p, true
+}
@@ -0,0 +1,43 @@
+package clisync_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clisync"
+)
+
+func TestIgnore(t *testing.T) {
+	t.Parallel()
+
+	ignore := clisync.NewIgnore(
+		"# build output",
+		"*.log",
+		"!keep.log",
+		"/dist",
+		"node_modules/",
+		"docs/**/*.tmp",
+	)
+
+	for _, tc := range []struct {
+		name    string
+		isDir   bool
+		ignored bool
+	}{
+		{name: "app.log", ignored: true},
+		{name: "sub/app.log", ignored: true},
+		{name: "keep.log", ignored: false},
+		{name: "dist", isDir: true, ignored: true},
+		{name: "dist/app.js", ignored: true},
+		{name: "sub/dist", isDir: true, ignored: false},
+		{name: "node_modules", isDir: true, ignored: true},
+		{name: "web/node_modules/pkg/index.js", ignored: true},
+		{name: "node_modules", isDir: false, ignored: false},
+		{name: "docs/a/b/c.tmp", ignored: true},
+		{name: "docs/c.tmp", ignored: true},
+		{name: "main.go", ignored: false},
+	} {
+		require.Equal(t, tc.ignored, ignore.Match(tc.name, tc.isDir), tc.name)
+	}
+}
@@ -0,0 +1,257 @@
+// Package clisync mirrors a local directory and a directory in a workspace in both directions.
+//
+// Each call to Syncer.Sync scans both sides and compares them to the state left by the previous call. Paths which
+// changed on one side only are copied to, or deleted from, the other. Paths which changed on both sides are conflicts,
+// which are reported rather than overwritten unless a ConflictMode says otherwise.
+package clisync
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"sort"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Entry describes a file or directory on one side of a sync.
+type Entry struct {
+	Dir     bool
+	Size    int64
+	ModTime time.Time
+}
+
+// same reports whether two entries describe the same content. Directories are compared by type only, since their
+// modification time changes with their contents.
+func (e Entry) same(o Entry) bool {
+	if e.Dir || o.Dir {
+		return e.Dir == o.Dir
+	}
+	return e.Size == o.Size && e.ModTime.Equal(o.ModTime)
+}
+
+// FS is one side of a sync. Names are slash-separated and relative to the root of the synced directory.
+type FS interface {
+	// Walk returns every file and directory below the root which is not ignored. Modification times are truncated
+	// to the second, which is the precision of SFTP.
+	Walk(ctx context.Context, ignore *Ignore) (map[string]Entry, error)
+	Open(name string) (io.ReadCloser, error)
+	// Create creates or truncates a file, creating its parent directories as needed.
+	Create(name string) (io.WriteCloser, error)
+	MkdirAll(name string) error
+	// Remove removes a file or an empty directory. Missing paths are not an error.
+	Remove(name string) error
+	Chtimes(name string, modTime time.Time) error
+}
+
+// ConflictMode decides what happens to paths which changed on both sides.
+type ConflictMode string
+
+const (
+	// ConflictReport leaves both copies untouched and reports the conflict. Deleting one copy resolves it.
+	ConflictReport ConflictMode = "report"
+	// ConflictLocal keeps the local copy.
+	ConflictLocal ConflictMode = "local"
+	// ConflictRemote keeps the workspace copy.
+	ConflictRemote ConflictMode = "remote"
+)
+
+// ConflictModes are the valid values of ConflictMode.
+var ConflictModes = []string{string(ConflictReport), string(ConflictLocal), string(ConflictRemote)}
+
+// Action is what a sync did to a path.
+type Action string
+
+const (
+	ActionUpload       Action = "upload"
+	ActionDownload     Action = "download"
+	ActionDeleteLocal  Action = "delete-local"
+	ActionDeleteRemote Action = "delete-remote"
+	ActionConflict     Action = "conflict"
+)
+
+// Change is a path which a sync acted upon.
+type Change struct {
+	Path   string
+	Action Action
+}
+
+// Syncer mirrors Local and Remote. Paths which exist on both sides but differ before the first sync are conflicts.
+type Syncer struct {
+	Local     FS
+	Remote    FS
+	Ignore    *Ignore
+	Conflicts ConflictMode
+
+	// base is the state of both sides after the previous sync, or nil before the first one.
+	base map[string]Entry
+	// reported holds conflicts which were already returned, so that they are only returned again once resolved and
+	// re-created.
+	reported map[string]bool
+}
+
+// Sync runs a single pass and returns what it changed, ordered by path. Conflicts are included the first time they
+// are found.
+func (s *Syncer) Sync(ctx context.Context) ([]Change, error) {
+	local, err := s.Local.Walk(ctx, s.Ignore)
+	if err != nil {
+		return nil, xerrors.Errorf("scan local directory: %w", err)
+	}
+	remote, err := s.Remote.Walk(ctx, s.Ignore)
+	if err != nil {
+		return nil, xerrors.Errorf("scan workspace directory: %w", err)
+	}
+
+	if s.base == nil {
+		s.base = make(map[string]Entry)
+	}
+	if s.reported == nil {
+		s.reported = make(map[string]bool)
+	}
+
+	paths := make(map[string]struct{}, len(local)+len(remote)+len(s.base))
+	for _, m := range []map[string]Entry{local, remote, s.base} {
+		for p := range m {
+			paths[p] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var (
+		changes []Change
+		// Directories are removed after their contents, in reverse order.
+		removeDirs []Change
+	)
+	for _, p := range sorted {
+		if err := ctx.Err(); err != nil {
+			return changes, err
+		}
+
+		l, lok := local[p]
+		r, rok := remote[p]
+		b, bok := s.base[p]
+		localChanged := lok != bok || (lok && !l.same(b))
+		remoteChanged := rok != bok || (rok && !r.same(b))
+
+		var action Action
+		switch {
+		case !localChanged && !remoteChanged:
+			continue
+		case localChanged && !remoteChanged:
+			action = ActionUpload
+			if !lok {
+				action = ActionDeleteRemote
+			}
+		case remoteChanged && !localChanged:
+			action = ActionDownload
+			if !rok {
+				action = ActionDeleteLocal
+			}
+		case !lok && !rok:
+			delete(s.base, p)
+			continue
+		case lok && rok && l.same(r):
+			s.base[p] = l
+			delete(s.reported, p)
+			continue
+		case !rok:
+			// Modifications win over deletions.
+			action = ActionUpload
+		case !lok:
+			action = ActionDownload
+		case s.Conflicts == ConflictLocal:
+			action = ActionUpload
+		case s.Conflicts == ConflictRemote:
+			action = ActionDownload
+		default:
+			if !s.reported[p] {
+				s.reported[p] = true
+				changes = append(changes, Change{Path: p, Action: ActionConflict})
+			}
+			continue
+		}
+		delete(s.reported, p)
+
+		switch action {
+		case ActionUpload:
+			err = s.copy(s.Local, s.Remote, p, l)
+		case ActionDownload:
+			err = s.copy(s.Remote, s.Local, p, r)
+		case ActionDeleteRemote, ActionDeleteLocal:
+			if b.Dir {
+				removeDirs = append(removeDirs, Change{Path: p, Action: action})
+				continue
+			}
+			err = s.remove(action, p)
+		}
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, Change{Path: p, Action: action})
+	}
+
+	for i := len(removeDirs) - 1; i >= 0; i-- {
+		change := removeDirs[i]
+		// A directory which is not empty gained new contents on the other side, which were copied back above; it
+		// is kept, and the next sync records it again.
+		if err := s.remove(change.Action, change.Path); err != nil {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes, nil
+}
+
+func (s *Syncer) copy(from, to FS, name string, e Entry) error {
+	if e.Dir {
+		if err := to.MkdirAll(name); err != nil {
+			return xerrors.Errorf("create directory %s: %w", name, err)
+		}
+		s.base[name] = e
+		return nil
+	}
+
+	in, err := from.Open(name)
+	if err != nil {
+		return xerrors.Errorf("open %s: %w", name, err)
+	}
+	defer in.Close()
+	out, err := to.Create(name)
+	if err != nil {
+		return xerrors.Errorf("create %s: %w", name, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return xerrors.Errorf("copy %s: %w", name, err)
+	}
+	if err := out.Close(); err != nil {
+		return xerrors.Errorf("close %s: %w", name, err)
+	}
+	// Matching modification times let the next sync tell that both copies are the same.
+	if err := to.Chtimes(name, e.ModTime); err != nil {
+		return xerrors.Errorf("set modification time of %s: %w", name, err)
+	}
+	s.base[name] = e
+	return nil
+}
+
+func (s *Syncer) remove(action Action, name string) error {
+	target := s.Remote
+	if action == ActionDeleteLocal {
+		target = s.Local
+	}
+	if err := target.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return xerrors.Errorf("remove %s: %w", name, err)
+	}
+	delete(s.base, name)
+	return nil
+}
@@ -0,0 +1,144 @@
+package clisync_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clisync"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSyncer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Bidirectional", func(t *testing.T) {
+		t.Parallel()
+
+		local, remote := t.TempDir(), t.TempDir()
+		writeFile(t, local, "a.txt", "a")
+		writeFile(t, remote, "nested/b.txt", "b")
+		s := &clisync.Syncer{
+			Local:  clisync.LocalFS{Root: local},
+			Remote: clisync.LocalFS{Root: remote},
+		}
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		changes := runSync(ctx, t, s)
+		require.Equal(t, []clisync.Change{
+			{Path: "a.txt", Action: clisync.ActionUpload},
+			{Path: "nested", Action: clisync.ActionDownload},
+			{Path: "nested/b.txt", Action: clisync.ActionDownload},
+		}, changes)
+		require.Equal(t, "a", readFile(t, remote, "a.txt"))
+		require.Equal(t, "b", readFile(t, local, "nested/b.txt"))
+
+		// Nothing changed, so nothing is copied.
+		require.Empty(t, runSync(ctx, t, s))
+
+		// Edits and deletions are mirrored.
+		writeFile(t, remote, "a.txt", "edited in the workspace")
+		require.NoError(t, os.RemoveAll(filepath.Join(local, "nested")))
+		changes = runSync(ctx, t, s)
+		require.Equal(t, []clisync.Change{
+			{Path: "a.txt", Action: clisync.ActionDownload},
+			{Path: "nested", Action: clisync.ActionDeleteRemote},
+			{Path: "nested/b.txt", Action: clisync.ActionDeleteRemote},
+		}, changes)
+		require.Equal(t, "edited in the workspace", readFile(t, local, "a.txt"))
+		require.NoDirExists(t, filepath.Join(remote, "nested"))
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		t.Parallel()
+
+		local, remote := t.TempDir(), t.TempDir()
+		writeFile(t, local, "a.txt", "a")
+		s := &clisync.Syncer{
+			Local:     clisync.LocalFS{Root: local},
+			Remote:    clisync.LocalFS{Root: remote},
+			Conflicts: clisync.ConflictReport,
+		}
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		_ = runSync(ctx, t, s)
+
+		writeFile(t, local, "a.txt", "local edit")
+		writeFile(t, remote, "a.txt", "workspace edit!")
+		require.Equal(t, []clisync.Change{{Path: "a.txt", Action: clisync.ActionConflict}}, runSync(ctx, t, s))
+		// Conflicts are reported once, and neither copy is overwritten.
+		require.Empty(t, runSync(ctx, t, s))
+		require.Equal(t, "local edit", readFile(t, local, "a.txt"))
+		require.Equal(t, "workspace edit!", readFile(t, remote, "a.txt"))
+
+		// Deleting one copy resolves the conflict in favor of the other.
+		require.NoError(t, os.Remove(filepath.Join(local, "a.txt")))
+		require.Equal(t, []clisync.Change{{Path: "a.txt", Action: clisync.ActionDownload}}, runSync(ctx, t, s))
+		require.Equal(t, "workspace edit!", readFile(t, local, "a.txt"))
+	})
+
+	t.Run("FirstSyncConflict", func(t *testing.T) {
+		t.Parallel()
+
+		local, remote := t.TempDir(), t.TempDir()
+		writeFile(t, local, "a.txt", "local")
+		writeFile(t, remote, "a.txt", "workspace")
+		s := &clisync.Syncer{
+			Local:  clisync.LocalFS{Root: local},
+			Remote: clisync.LocalFS{Root: remote},
+		}
+
+		// Without a previous sync, neither copy is known to be newer.
+		ctx := testutil.Context(t, testutil.WaitShort)
+		require.Equal(t, []clisync.Change{{Path: "a.txt", Action: clisync.ActionConflict}}, runSync(ctx, t, s))
+		require.Equal(t, "local", readFile(t, local, "a.txt"))
+		require.Equal(t, "workspace", readFile(t, remote, "a.txt"))
+	})
+
+	t.Run("Ignore", func(t *testing.T) {
+		t.Parallel()
+
+		local, remote := t.TempDir(), t.TempDir()
+		writeFile(t, local, "main.go", "package main")
+		writeFile(t, local, "build/out", "binary")
+		writeFile(t, remote, "debug.log", "log")
+		s := &clisync.Syncer{
+			Local:  clisync.LocalFS{Root: local},
+			Remote: clisync.LocalFS{Root: remote},
+			Ignore: clisync.NewIgnore("build/", "*.log"),
+		}
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		require.Equal(t, []clisync.Change{{Path: "main.go", Action: clisync.ActionUpload}}, runSync(ctx, t, s))
+		require.NoDirExists(t, filepath.Join(remote, "build"))
+		require.NoFileExists(t, filepath.Join(local, "debug.log"))
+	})
+}
+
+func runSync(ctx context.Context, t *testing.T, s *clisync.Syncer) []clisync.Change {
+	t.Helper()
+	changes, err := s.Sync(ctx)
+	require.NoError(t, err)
+	return changes
+}
+
+func writeFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	// Modification times are compared at second precision, so make sure that edits are visible.
+	mtime := time.Now().Add(time.Duration(len(content)) * time.Second)
+	require.NoError(t, os.Chtimes(p, mtime, mtime))
+}
+
+func readFile(t *testing.T, root, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return string(b)
+}
@@ -0,0 +1,441 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/sloghuman"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) cp() *serpent.Command {
+	var (
+		recursive bool
+		quiet     bool
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "cp <source> <destination>",
+		Short:       "Copy files to or from a workspace",
+		Long: "Files are transferred over SFTP through the same connection as \"coder ssh\", so no SSH configuration is " +
+			"required. Workspace paths are written as <workspace>:<path>, and relative paths are resolved from the " +
+			"home directory of the workspace user.\n" + FormatExamples(
+			Example{
+				Description: "Copy a file into a workspace",
+				Command:     "coder cp ./main.go my-workspace:src/main.go",
+			},
+			Example{
+				Description: "Copy a directory out of a workspace agent",
+				Command:     "coder cp -r my-workspace.main:build ./build",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			src, dst := parseCopyTarget(inv.Args[0]), parseCopyTarget(inv.Args[1])
+			switch {
+			case src.workspace != "" && dst.workspace != "":
+				return xerrors.New("copying between two workspaces is not supported; copy to a local path first")
+			case src.workspace == "" && dst.workspace == "":
+				return xerrors.New("either the source or the destination must be a workspace path, e.g. my-workspace:path/to/file")
+			}
+			remote := src
+			if dst.workspace != "" {
+				remote = dst
+			}
+
+			sftpClient, closeSFTP, err := r.dialSFTP(ctx, inv, client, remote.workspace)
+			if err != nil {
+				return err
+			}
+			defer closeSFTP()
+
+			c := &copier{
+				sftp:      sftpClient,
+				recursive: recursive,
+				progress:  io.Discard,
+			}
+			if !quiet {
+				c.progress = inv.Stderr
+				c.interactive = isTTYErr(inv)
+			}
+			if dst.workspace != "" {
+				return c.upload(ctx, src.path, dst.path)
+			}
+			return c.download(ctx, src.path, dst.path)
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:          "recursive",
+			FlagShorthand: "r",
+			Description:   "Copy directories and their contents.",
+			Value:         serpent.BoolOf(&recursive),
+		},
+		{
+			Flag:          "quiet",
+			FlagShorthand: "q",
+			Description:   "Do not print the progress of each file.",
+			Value:         serpent.BoolOf(&quiet),
+		},
+	}
+	return cmd
+}
+
+// dialSFTP connects to the agent of the given workspace and starts an SFTP session over the same connection used by
+// "coder ssh". The returned function closes the session and the connection.
+func (r *RootCmd) dialSFTP(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, workspace string) (*sftp.Client, func(), error) {
+	conn, err := r.dialWorkspaceAgent(ctx, inv, client, workspace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sshClient, err := conn.SSHClient(ctx)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, xerrors.Errorf("ssh client: %w", err)
+	}
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		_ = sshClient.Close()
+		_ = conn.Close()
+		return nil, nil, xerrors.Errorf("start sftp session (file transfers may be blocked in this workspace): %w", err)
+	}
+
+	return sftpClient, func() {
+		_ = sftpClient.Close()
+		_ = sshClient.Close()
+		_ = conn.Close()
+	}, nil
+}
+
+// dialWorkspaceAgent connects to the agent of the given workspace without starting the workspace.
+func (r *RootCmd) dialWorkspaceAgent(ctx context.Context, inv *serpent.Invocation, client *codersdk.Client, workspace string) (*workspacesdk.AgentConn, error) {
+	_, workspaceAgent, err := getWorkspaceAndAgent(ctx, inv, client, false, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cliui.Agent(ctx, inv.Stderr, workspaceAgent.ID, cliui.AgentOptions{
+		Fetch: client.WorkspaceAgent,
+		Wait:  false,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("await agent: %w", err)
+	}
+
+	opts := &workspacesdk.DialAgentOptions{}
+	if r.verbose {
+		opts.Logger = inv.Logger.AppendSinks(sloghuman.Sink(inv.Stderr)).Leveled(slog.LevelDebug)
+	}
+	if r.disableDirect {
+		_, _ = fmt.Fprintln(inv.Stderr, "Direct connections disabled.")
+		opts.BlockEndpoints = true
+	}
+	if !r.disableNetworkTelemetry {
+		opts.EnableTelemetry = true
+	}
+	conn, err := workspacesdk.New(client).DialAgent(ctx, workspaceAgent.ID, opts)
+	if err != nil {
+		return nil, xerrors.Errorf("dial agent: %w", err)
+	}
+	return conn, nil
+}
+
+// copyTarget is a source or destination of "coder cp". Local paths have no workspace.
+type copyTarget struct {
+	workspace string
+	path      string
+}
+
+// parseCopyTarget splits "workspace:path" arguments. Arguments without a colon, which start with a relative or
+// absolute path, or which begin with a Windows volume name are local paths.
+func parseCopyTarget(arg string) copyTarget {
+	if filepath.VolumeName(arg) != "" ||
+		strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, ".") || strings.HasPrefix(arg, `\`) {
+		return copyTarget{path: arg}
+	}
+	workspace, p, ok := strings.Cut(arg, ":")
+	if !ok || workspace == "" || strings.Contains(workspace, `\`) {
+		return copyTarget{path: arg}
+	}
+	// SFTP sessions start in the home directory, so relative paths and "~" are resolved from there.
+	switch {
+	case p == "~":
+		p = "."
+	case strings.HasPrefix(p, "~/"):
+		p = strings.TrimPrefix(p, "~/")
+	}
+	if p == "" {
+		p = "."
+	}
+	return copyTarget{workspace: workspace, path: p}
+}
+
+type copier struct {
+	sftp        *sftp.Client
+	recursive   bool
+	progress    io.Writer
+	interactive bool
+}
+
+// upload copies a local file or directory to the workspace. If the destination is an existing directory, the source
+// is copied into it, like cp(1).
+func (c *copier) upload(ctx context.Context, src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return xerrors.Errorf("stat source: %w", err)
+	}
+	if info.IsDir() && !c.recursive {
+		return xerrors.Errorf("%q is a directory; use --recursive to copy it", src)
+	}
+	if dstInfo, err := c.sftp.Stat(dst); err == nil && dstInfo.IsDir() {
+		dst = path.Join(dst, filepath.Base(src))
+	}
+
+	if !info.IsDir() {
+		return c.uploadFile(ctx, src, dst, info.Mode())
+	}
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := path.Join(dst, filepath.ToSlash(rel))
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		switch {
+		case d.IsDir():
+			if err := c.sftp.MkdirAll(target); err != nil {
+				return xerrors.Errorf("create directory %q: %w", target, err)
+			}
+			return nil
+		case info.Mode().IsRegular():
+			return c.uploadFile(ctx, p, target, info.Mode())
+		default:
+			_, _ = fmt.Fprintf(c.progress, "Skipping %s: not a regular file\n", p)
+			return nil
+		}
+	})
+}
+
+func (c *copier) uploadFile(ctx context.Context, src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return xerrors.Errorf("open source: %w", err)
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return xerrors.Errorf("stat source: %w", err)
+	}
+
+	out, err := c.sftp.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return xerrors.Errorf("create %q: %w", dst, err)
+	}
+	defer out.Close()
+
+	if err := c.transfer(ctx, src, out, in, info.Size()); err != nil {
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return xerrors.Errorf("close %q: %w", dst, err)
+	}
+	// Preserving the mode is best effort, since not every platform supports it.
+	_ = c.sftp.Chmod(dst, mode.Perm())
+	return nil
+}
+
+// download copies a file or directory from the workspace to a local path. If the destination is an existing
+// directory, the source is copied into it, like cp(1).
+func (c *copier) download(ctx context.Context, src, dst string) error {
+	info, err := c.sftp.Stat(src)
+	if err != nil {
+		return xerrors.Errorf("stat source: %w", err)
+	}
+	if info.IsDir() && !c.recursive {
+		return xerrors.Errorf("%q is a directory; use --recursive to copy it", src)
+	}
+	if dstInfo, err := os.Stat(dst); err == nil && dstInfo.IsDir() {
+		dst = filepath.Join(dst, path.Base(src))
+	}
+
+	if !info.IsDir() {
+		return c.downloadFile(ctx, src, dst, info.Mode())
+	}
+	src = path.Clean(src)
+	walker := c.sftp.Walk(src)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return err
+		}
+		target := filepath.Join(dst, filepath.FromSlash(relativeWalkPath(src, walker.Path())))
+		info := walker.Stat()
+		switch {
+		case info.IsDir():
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return xerrors.Errorf("create directory %q: %w", target, err)
+			}
+		case info.Mode().IsRegular():
+			if err := c.downloadFile(ctx, walker.Path(), target, info.Mode()); err != nil {
+				return err
+			}
+		default:
+			_, _ = fmt.Fprintf(c.progress, "Skipping %s: not a regular file\n", walker.Path())
+		}
+	}
+	return nil
+}
+
+// relativeWalkPath returns the path of a file found by walking root relative to root, which must be clean. Walking
+// "." yields paths that are already relative, and trimming the root from them would turn ".bashrc" into "bashrc".
+func relativeWalkPath(root, p string) string {
+	if root == "." {
+		return p
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(p, root), "/")
+}
+
+func (c *copier) downloadFile(ctx context.Context, src, dst string, mode fs.FileMode) error {
+	in, err := c.sftp.Open(src)
+	if err != nil {
+		return xerrors.Errorf("open %q: %w", src, err)
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return xerrors.Errorf("stat %q: %w", src, err)
+	}
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return xerrors.Errorf("create destination: %w", err)
+	}
+	defer out.Close()
+
+	if err := c.transfer(ctx, src, out, in, info.Size()); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// transfer copies from r to w while reporting progress. The name is only used for progress output.
+func (c *copier) transfer(ctx context.Context, name string, w io.Writer, r io.Reader, size int64) error {
+	p := &copyProgress{
+		w:           c.progress,
+		name:        name,
+		size:        size,
+		start:       time.Now(),
+		interactive: c.interactive,
+	}
+	_, err := io.Copy(w, &ctxReader{ctx: ctx, r: io.TeeReader(r, p)})
+	if err != nil {
+		return xerrors.Errorf("copy %q: %w", name, err)
+	}
+	p.done()
+	return nil
+}
+
+// ctxReader stops a copy when the context is canceled.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// copyProgress prints the progress of a file transfer. On terminals the line is redrawn as bytes arrive; otherwise a
+// single line is printed when the file is done.
+type copyProgress struct {
+	w           io.Writer
+	name        string
+	size        int64
+	start       time.Time
+	interactive bool
+
+	mu      sync.Mutex
+	written int64
+	printed time.Time
+}
+
+func (p *copyProgress) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.written += int64(len(b))
+	if p.interactive && time.Since(p.printed) > 100*time.Millisecond {
+		p.printed = time.Now()
+		_, _ = fmt.Fprintf(p.w, "\r%s", p.line())
+	}
+	return len(b), nil
+}
+
+func (p *copyProgress) done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prefix := ""
+	if p.interactive {
+		prefix = "\r"
+	}
+	_, _ = fmt.Fprintf(p.w, "%s%s\n", prefix, p.line())
+}
+
+func (p *copyProgress) line() string {
+	percent := 100
+	if p.size > 0 {
+		percent = int(p.written * 100 / p.size)
+	}
+	rate := float64(p.written) / time.Since(p.start).Seconds()
+	return fmt.Sprintf("%s  %3d%%  %s  %s/s", p.name, percent, formatCopyBytes(float64(p.written)), formatCopyBytes(rate))
+}
+
+func formatCopyBytes(b float64) string {
+	const unit = 1024
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for b >= unit && i < len(units)-1 {
+		b /= unit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", b, units[i])
+	}
+	return fmt.Sprintf("%.1f %s", b, units[i])
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopyTarget(t *testing.T) {
+	t.Parallel()
+
+	for arg, want := range map[string]copyTarget{
+		"./main.go":                   {path: "./main.go"},
+		"/tmp/main.go":                {path: "/tmp/main.go"},
+		"main.go":                     {path: "main.go"},
+		"my-workspace:src/main.go":    {workspace: "my-workspace", path: "src/main.go"},
+		"my-workspace.main:/etc/motd": {workspace: "my-workspace.main", path: "/etc/motd"},
+		"alice/my-workspace:~/build":  {workspace: "alice/my-workspace", path: "build"},
+		"my-workspace:":               {workspace: "my-workspace", path: "."},
+		"my-workspace:~":              {workspace: "my-workspace", path: "."},
+	} {
+		require.Equal(t, want, parseCopyTarget(arg), arg)
+	}
+}
+
+func TestRelativeWalkPath(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		root string
+		path string
+		want string
+	}{
+		{root: ".", path: ".", want: "."},
+		{root: ".", path: ".bashrc", want: ".bashrc"},
+		{root: ".", path: ".config/coder/config.yaml", want: ".config/coder/config.yaml"},
+		{root: ".", path: "src/main.go", want: "src/main.go"},
+		{root: "src", path: "src", want: ""},
+		{root: "src", path: "src/.env", want: ".env"},
+		{root: "/home/coder", path: "/home/coder/.profile", want: ".profile"},
+		{root: "/", path: "/etc/motd", want: "etc/motd"},
+	} {
+		require.Equal(t, tc.want, relativeWalkPath(tc.root, tc.path), "%s in %s", tc.path, tc.root)
+	}
+}
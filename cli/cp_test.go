@@ -0,0 +1,102 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestCp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UploadAndDownload", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		// The agent runs on this machine, so workspace paths are local too.
+		localDir := t.TempDir()
+		remoteDir := t.TempDir()
+		src := filepath.Join(localDir, "hello.txt")
+		require.NoError(t, os.WriteFile(src, []byte("hello world"), 0o600))
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		inv, root := clitest.New(t, "cp", src, workspace.Name+":"+filepath.ToSlash(remoteDir))
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		got, err := os.ReadFile(filepath.Join(remoteDir, "hello.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(got))
+
+		dst := filepath.Join(localDir, "copy.txt")
+		inv, root = clitest.New(t, "cp", workspace.Name+":"+filepath.ToSlash(filepath.Join(remoteDir, "hello.txt")), dst)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		got, err = os.ReadFile(dst)
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(got))
+	})
+
+	t.Run("Recursive", func(t *testing.T) {
+		t.Parallel()
+
+		client, workspace, agentToken := setupWorkspaceForAgent(t)
+		_ = agenttest.New(t, client.URL, agentToken)
+		coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+		src := filepath.Join(t.TempDir(), "project")
+		require.NoError(t, os.MkdirAll(filepath.Join(src, "nested"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "nested", "b.txt"), []byte("b"), 0o600))
+		remoteDir := t.TempDir()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// Directories require --recursive.
+		inv, root := clitest.New(t, "cp", src, workspace.Name+":"+filepath.ToSlash(remoteDir))
+		clitest.SetupConfig(t, client, root)
+		require.ErrorContains(t, inv.WithContext(ctx).Run(), "--recursive")
+
+		inv, root = clitest.New(t, "cp", "-r", src, workspace.Name+":"+filepath.ToSlash(remoteDir))
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		got, err := os.ReadFile(filepath.Join(remoteDir, "project", "nested", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(got))
+
+		dst := filepath.Join(t.TempDir(), "download")
+		inv, root = clitest.New(t, "cp", "-r", workspace.Name+":"+filepath.ToSlash(filepath.Join(remoteDir, "project")), dst)
+		clitest.SetupConfig(t, client, root)
+		require.NoError(t, inv.WithContext(ctx).Run())
+
+		got, err = os.ReadFile(filepath.Join(dst, "a.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "a", string(got))
+		got, err = os.ReadFile(filepath.Join(dst, "nested", "b.txt"))
+		require.NoError(t, err)
+		require.Equal(t, "b", string(got))
+	})
+
+	t.Run("NoWorkspace", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		inv, root := clitest.New(t, "cp", "./a.txt", "./b.txt")
+		clitest.SetupConfig(t, client, root)
+		require.ErrorContains(t, inv.Run(), "must be a workspace path")
+	})
+}
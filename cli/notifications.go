@@ -2,11 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
 	"github.com/coder/serpent"
 
+	"github.com/coder/coder/v2/cli/cliui"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -15,6 +18,10 @@ func (r *RootCmd) notifications() *serpent.Command {
 		Use:   "notifications",
 		Short: "Manage Coder notifications",
 		Long: "Administrators can use these commands to change notification settings.\n" + FormatExamples(
+			Example{
+				Description: "Show your unread notifications",
+				Command:     "coder notifications list --unread",
+			},
 			Example{
 				Description: "Pause Coder notifications. Administrators can temporarily stop notifiers from dispatching messages in case of the target outage (for example: unavailable SMTP server or Webhook not responding).",
 				Command:     "coder notifications pause",
@@ -23,19 +30,134 @@ func (r *RootCmd) notifications() *serpent.Command {
 				Description: "Resume Coder notifications",
 				Command:     "coder notifications resume",
 			},
+			Example{
+				Description: "Stop receiving a notification",
+				Command:     `coder notifications preferences set "Workspace Deleted" --disabled`,
+			},
+			Example{
+				Description: "Change the wording of a notification",
+				Command:     `coder notifications templates edit "Workspace Deleted" --title 'Workspace "{{.Labels.name}}" was removed'`,
+			},
 		),
 		Aliases: []string{"notification"},
 		Handler: func(inv *serpent.Invocation) error {
 			return inv.Command.HelpHandler(inv)
 		},
 		Children: []*serpent.Command{
+			r.listInboxNotifications(),
 			r.pauseNotifications(),
 			r.resumeNotifications(),
+			r.notificationPreferences(),
+			r.notificationTemplates(),
 		},
 	}
 	return cmd
 }
 
+type inboxNotificationRow struct {
+	// For JSON format:
+	codersdk.InboxNotification `table:"-"`
+
+	// For table format:
+	ID        string    `json:"-" table:"id,nosort"`
+	CreatedAt time.Time `json:"-" table:"created at"`
+	Title     string    `json:"-" table:"title"`
+	Read      bool      `json:"-" table:"read"`
+}
+
+func (r *RootCmd) listInboxNotifications() *serpent.Command {
+	var (
+		unreadOnly bool
+		limit      int64
+		markRead   bool
+
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]inboxNotificationRow{}, []string{"created at", "title", "read"}),
+			cliui.JSONFormat(),
+		)
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls", "inbox"},
+		Short:   "List the notifications in your inbox",
+		Long: "Notifications are delivered to your inbox when the deployment or your preferences use the \"inbox\" method.\n" + FormatExamples(
+			Example{
+				Description: "Show your unread notifications and mark them as read",
+				Command:     "coder notifications list --unread --mark-read",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			res, err := client.ListInboxNotifications(inv.Context(), codersdk.Me, codersdk.ListInboxNotificationsRequest{
+				UnreadOnly: unreadOnly,
+				Limit:      int(limit),
+			})
+			if err != nil {
+				return xerrors.Errorf("list inbox notifications: %w", err)
+			}
+
+			if len(res.Notifications) == 0 {
+				cliui.Infof(inv.Stderr, "No notifications in your inbox.\n")
+				return nil
+			}
+
+			rows := make([]inboxNotificationRow, 0, len(res.Notifications))
+			for _, notification := range res.Notifications {
+				rows = append(rows, inboxNotificationRow{
+					InboxNotification: notification,
+					ID:                notification.ID.String(),
+					CreatedAt:         notification.CreatedAt,
+					Title:             notification.Title,
+					Read:              notification.ReadAt != nil,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			if err != nil {
+				return err
+			}
+
+			if markRead && res.UnreadCount > 0 {
+				err = client.MarkAllInboxNotificationsAsRead(inv.Context(), codersdk.Me)
+				if err != nil {
+					return xerrors.Errorf("mark inbox notifications as read: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "unread",
+			Description: "Only show notifications which have not been read.",
+			Value:       serpent.BoolOf(&unreadOnly),
+		},
+		{
+			Flag:        "limit",
+			Description: "The maximum number of notifications to show.",
+			Default:     "25",
+			Value:       serpent.Int64Of(&limit),
+		},
+		{
+			Flag:        "mark-read",
+			Description: "Mark all notifications in your inbox as read after listing them.",
+			Value:       serpent.BoolOf(&markRead),
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
 func (r *RootCmd) pauseNotifications() *serpent.Command {
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
@@ -83,3 +205,162 @@ func (r *RootCmd) resumeNotifications() *serpent.Command {
 	}
 	return cmd
 }
+
+func (r *RootCmd) notificationPreferences() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "preferences",
+		Short: "Manage your notification preferences",
+		Long: "Users can disable notifications they are not interested in, and choose how each notification is delivered. " +
+			"Notifications marked as mandatory by an administrator cannot be disabled.",
+		Aliases: []string{"prefs"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listNotificationPreferences(),
+			r.setNotificationPreference(),
+		},
+	}
+	return cmd
+}
+
+type notificationPreferenceRow struct {
+	// For JSON format:
+	codersdk.NotificationTemplate `table:"-"`
+	Disabled                      bool   `json:"disabled" table:"-"`
+	Method                        string `json:"method,omitempty" table:"-"`
+
+	// For table format:
+	ID           string `json:"-" table:"id"`
+	TemplateName string `json:"-" table:"name,default_sort"`
+	Group        string `json:"-" table:"group"`
+	Mandatory    bool   `json:"-" table:"mandatory"`
+	Enabled      bool   `json:"-" table:"enabled"`
+	MethodColumn string `json:"-" table:"method"`
+}
+
+func (r *RootCmd) listNotificationPreferences() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationPreferenceRow{}, []string{"name", "group", "mandatory", "enabled", "method"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List your notification preferences",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			templates, err := client.GetSystemNotificationTemplates(inv.Context())
+			if err != nil {
+				return xerrors.Errorf("get notification templates: %w", err)
+			}
+			prefs, err := client.GetUserNotificationPreferences(inv.Context(), codersdk.Me)
+			if err != nil {
+				return xerrors.Errorf("get notification preferences: %w", err)
+			}
+			prefsByTemplate := make(map[uuid.UUID]codersdk.NotificationPreference, len(prefs))
+			for _, pref := range prefs {
+				prefsByTemplate[pref.NotificationTemplateID] = pref
+			}
+
+			rows := make([]notificationPreferenceRow, 0, len(templates))
+			for _, template := range templates {
+				pref := prefsByTemplate[template.ID]
+				method := pref.Method
+				if method == "" {
+					method = "default"
+				}
+				rows = append(rows, notificationPreferenceRow{
+					NotificationTemplate: template,
+					Disabled:             pref.Disabled,
+					Method:               pref.Method,
+					ID:                   template.ID.String(),
+					TemplateName:         template.Name,
+					Group:                template.Group,
+					Mandatory:            template.Mandatory,
+					Enabled:              template.Mandatory || !pref.Disabled,
+					MethodColumn:         method,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) setNotificationPreference() *serpent.Command {
+	var (
+		disabled bool
+		method   string
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "set <notification name or id>",
+		Short: "Update your preference for a notification",
+		Long: FormatExamples(
+			Example{
+				Description: "Disable a notification",
+				Command:     `coder notifications prefs set "Workspace Deleted" --disabled`,
+			},
+			Example{
+				Description: "Receive a notification via a webhook",
+				Command:     `coder notifications prefs set "Workspace Deleted" --method webhook`,
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			template, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = client.UpdateUserNotificationPreferences(inv.Context(), codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+				Preferences: []codersdk.UpdateNotificationPreference{
+					{
+						NotificationTemplateID: template.ID,
+						Disabled:               disabled,
+						Method:                 method,
+					},
+				},
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification preference: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "Updated your preference for %q.\n", template.Name)
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "disabled",
+			Description: "Stop receiving the notification. Mandatory notifications cannot be disabled.",
+			Value:       serpent.BoolOf(&disabled),
+		},
+		{
+			Flag:        "method",
+			Description: "Deliver the notification using the given method (smtp, webhook, slack or teams) instead of the deployment's default. Omit to use the default.",
+			Value:       serpent.StringOf(&method),
+		},
+	}
+	return cmd
+}
@@ -12,6 +12,10 @@ import (
 
 	"github.com/coder/coder/v2/cli/clitest"
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -100,3 +104,133 @@ func TestPauseNotifications_RegularUser(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, settings.NotifierPaused) // still running
 }
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only inserted by migrations")
+	}
+
+	// given
+	ownerClient := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	memberClient, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	// when
+	inv, root := clitest.New(t, "notifications", "preferences", "set", notifications.TemplateWorkspaceDeleted.String(), "--disabled")
+	clitest.SetupConfig(t, memberClient, root)
+	err := inv.Run()
+	require.NoError(t, err)
+
+	// then
+	ctx := testutil.Context(t, testutil.WaitShort)
+	prefs, err := memberClient.GetUserNotificationPreferences(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Len(t, prefs, 1)
+	require.Equal(t, notifications.TemplateWorkspaceDeleted, prefs[0].NotificationTemplateID)
+	require.True(t, prefs[0].Disabled)
+
+	// when
+	inv, root = clitest.New(t, "notifications", "preferences", "list", "--output", "json")
+	clitest.SetupConfig(t, memberClient, root)
+	var buf bytes.Buffer
+	inv.Stdout = &buf
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	var rows []struct {
+		ID       string `json:"id"`
+		Disabled bool   `json:"disabled"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	require.NotEmpty(t, rows)
+	for _, row := range rows {
+		require.Equal(t, row.ID == notifications.TemplateWorkspaceDeleted.String(), row.Disabled)
+	}
+}
+
+func TestNotificationsList(t *testing.T) {
+	t.Parallel()
+
+	// given
+	db, ps := dbtestutil.NewDB(t)
+	ownerClient := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	memberClient, member := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	notif := dbgen.InboxNotification(t, db, database.InboxNotification{
+		UserID: member.ID,
+		Title:  "Workspace bob deleted",
+	})
+
+	// when
+	inv, root := clitest.New(t, "notifications", "list", "--unread", "--mark-read", "--output", "json")
+	clitest.SetupConfig(t, memberClient, root)
+	var buf bytes.Buffer
+	inv.Stdout = &buf
+	err := inv.Run()
+	require.NoError(t, err)
+
+	// then
+	var rows []codersdk.InboxNotification
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rows))
+	require.Len(t, rows, 1)
+	require.Equal(t, notif.ID, rows[0].ID)
+	require.Equal(t, "Workspace bob deleted", rows[0].Title)
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	res, err := memberClient.ListInboxNotifications(ctx, codersdk.Me, codersdk.ListInboxNotificationsRequest{UnreadOnly: true})
+	require.NoError(t, err)
+	require.Zero(t, res.UnreadCount)
+}
+
+func TestNotificationTemplates(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only inserted by migrations")
+	}
+
+	// given
+	ownerClient := coderdtest.New(t, nil)
+	_ = coderdtest.CreateFirstUser(t, ownerClient)
+
+	// when
+	inv, root := clitest.New(t, "notifications", "templates", "edit", "Workspace Deleted",
+		"--title", "Workspace {{.Labels.name}} removed",
+		"--actions", `[{"label": "Runbook", "url": "https://runbooks.example.com/workspaces"}]`)
+	clitest.SetupConfig(t, ownerClient, root)
+	err := inv.Run()
+	require.NoError(t, err)
+
+	// then
+	ctx := testutil.Context(t, testutil.WaitShort)
+	template, err := ownerClient.GetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+	require.NoError(t, err)
+	require.True(t, template.Customized)
+	require.Equal(t, "Workspace {{.Labels.name}} removed", template.TitleTemplate)
+
+	// when
+	inv, root = clitest.New(t, "notifications", "templates", "test", "Workspace Deleted", "--label", "name=bobby-workspace")
+	clitest.SetupConfig(t, ownerClient, root)
+	var buf bytes.Buffer
+	inv.Stdout = &buf
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	require.Contains(t, buf.String(), "Workspace bobby-workspace removed")
+	require.Contains(t, buf.String(), "Runbook: https://runbooks.example.com/workspaces")
+
+	// when
+	inv, root = clitest.New(t, "notifications", "templates", "reset", notifications.TemplateWorkspaceDeleted.String())
+	clitest.SetupConfig(t, ownerClient, root)
+	err = inv.Run()
+	require.NoError(t, err)
+
+	// then
+	template, err = ownerClient.GetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+	require.NoError(t, err)
+	require.False(t, template.Customized)
+}
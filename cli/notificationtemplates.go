@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+func (r *RootCmd) notificationTemplates() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "templates",
+		Short: "Manage the wording of notifications",
+		Long: "Administrators can override the title, body and actions of each notification, for example to link to " +
+			"internal runbooks. Templates use Go's templating syntax, and may refer to the recipient (e.g. {{.UserName}}) " +
+			"and to the labels of the notification (e.g. {{.Labels.name}}).\n" + FormatExamples(
+			Example{
+				Description: "Add a link to a runbook to a notification",
+				Command:     `coder notifications templates edit "Workspace Deleted" --actions '[{"label": "Runbook", "url": "https://wiki.example.com/runbooks/workspaces"}]'`,
+			},
+			Example{
+				Description: "Preview a notification and send it to yourself",
+				Command:     `coder notifications templates test "Workspace Deleted" --label name=my-workspace`,
+			},
+		),
+		Aliases: []string{"template"},
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.listNotificationTemplates(),
+			r.showNotificationTemplate(),
+			r.editNotificationTemplate(),
+			r.resetNotificationTemplate(),
+			r.testNotificationTemplate(),
+		},
+	}
+	return cmd
+}
+
+type notificationTemplateRow struct {
+	// For JSON format:
+	codersdk.NotificationTemplate `table:"-"`
+
+	// For table format:
+	ID         string `json:"-" table:"id"`
+	Name       string `json:"-" table:"name,default_sort"`
+	Group      string `json:"-" table:"group"`
+	Mandatory  bool   `json:"-" table:"mandatory"`
+	Digest     bool   `json:"-" table:"digest"`
+	Customized bool   `json:"-" table:"customized"`
+}
+
+func (r *RootCmd) listNotificationTemplates() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]notificationTemplateRow{}, []string{"name", "group", "mandatory", "customized"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List notification templates",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			templates, err := client.GetSystemNotificationTemplates(inv.Context())
+			if err != nil {
+				return xerrors.Errorf("get notification templates: %w", err)
+			}
+
+			rows := make([]notificationTemplateRow, 0, len(templates))
+			for _, template := range templates {
+				rows = append(rows, notificationTemplateRow{
+					NotificationTemplate: template,
+					ID:                   template.ID.String(),
+					Name:                 template.Name,
+					Group:                template.Group,
+					Mandatory:            template.Mandatory,
+					Digest:               template.Digest,
+					Customized:           template.Customized,
+				})
+			}
+
+			out, err := formatter.Format(inv.Context(), rows)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) showNotificationTemplate() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "show <notification name or id>",
+		Short: "Show the title, body and actions of a notification template",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			template, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			customized := ""
+			if template.Customized {
+				customized = " (customized)"
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "%s%s\n\n", cliui.Bold(template.Name), customized)
+			_, _ = fmt.Fprintf(inv.Stdout, "%s\n%s\n\n", cliui.Bold("Title:"), template.TitleTemplate)
+			_, _ = fmt.Fprintf(inv.Stdout, "%s\n%s\n\n", cliui.Bold("Body:"), template.BodyTemplate)
+			_, _ = fmt.Fprintf(inv.Stdout, "%s\n%s\n", cliui.Bold("Actions:"), strings.TrimSpace(template.Actions))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) editNotificationTemplate() *serpent.Command {
+	var (
+		title    string
+		body     string
+		bodyFile string
+		actions  string
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "edit <notification name or id>",
+		Short: "Override the title, body or actions of a notification template",
+		Long: "The given values replace any previous override. Values which are not given use the default.\n" + FormatExamples(
+			Example{
+				Description: "Change the title of a notification",
+				Command:     `coder notifications templates edit "Workspace Deleted" --title 'Workspace "{{.Labels.name}}" was removed'`,
+			},
+			Example{
+				Description: "Read the body of a notification from a Markdown file",
+				Command:     `coder notifications templates edit "Workspace Deleted" --body-file workspace-deleted.md`,
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			if bodyFile != "" {
+				if body != "" {
+					return xerrors.New("--body and --body-file cannot be used together")
+				}
+				data, err := os.ReadFile(bodyFile)
+				if err != nil {
+					return xerrors.Errorf("read body file: %w", err)
+				}
+				body = string(data)
+			}
+			if title == "" && body == "" && actions == "" {
+				return xerrors.New("at least one of --title, --body, --body-file or --actions is required; use \"reset\" to restore the default")
+			}
+
+			template, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = client.UpdateNotificationTemplate(inv.Context(), template.ID, codersdk.UpdateNotificationTemplateRequest{
+				TitleTemplate: title,
+				BodyTemplate:  body,
+				Actions:       actions,
+			})
+			if err != nil {
+				return xerrors.Errorf("update notification template: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "Updated the template of %q.\n", template.Name)
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "title",
+			Description: "The title of the notification.",
+			Value:       serpent.StringOf(&title),
+		},
+		{
+			Flag:        "body",
+			Description: "The body of the notification, formatted as Markdown.",
+			Value:       serpent.StringOf(&body),
+		},
+		{
+			Flag:        "body-file",
+			Description: "Read the body of the notification from the given file.",
+			Value:       serpent.StringOf(&bodyFile),
+		},
+		{
+			Flag:        "actions",
+			Description: `The actions of the notification, as a JSON array of objects with "label" and "url" fields. Actions may use {{ base_url }} to refer to the deployment's access URL.`,
+			Value:       serpent.StringOf(&actions),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) resetNotificationTemplate() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "reset <notification name or id>",
+		Short: "Restore the default title, body and actions of a notification template",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			template, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = client.ResetNotificationTemplate(inv.Context(), template.ID)
+			if err != nil {
+				return xerrors.Errorf("reset notification template: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stderr, "Restored the default template of %q.\n", template.Name)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) testNotificationTemplate() *serpent.Command {
+	var labels []string
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "test <notification name or id>",
+		Short: "Render a notification template and send the result to yourself",
+		Long:  "Labels which the template refers to but which are not given are filled with sample values.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			req := codersdk.TestNotificationTemplateRequest{
+				Labels: make(map[string]string, len(labels)),
+			}
+			for _, label := range labels {
+				k, v, ok := strings.Cut(label, "=")
+				if !ok || k == "" {
+					return xerrors.Errorf("invalid label %q: must be in the form key=value", label)
+				}
+				req.Labels[k] = v
+			}
+
+			template, err := findNotificationTemplate(inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+
+			preview, err := client.TestNotificationTemplate(inv.Context(), template.ID, req)
+			if err != nil {
+				return xerrors.Errorf("test notification template: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "%s\n\n%s\n", cliui.Bold(preview.Title), preview.Body)
+			if len(preview.Actions) > 0 {
+				_, _ = fmt.Fprintln(inv.Stdout)
+				for _, action := range preview.Actions {
+					_, _ = fmt.Fprintf(inv.Stdout, "- %s: %s\n", action.Label, action.URL)
+				}
+			}
+
+			cliui.Infof(inv.Stderr, "A test notification was sent to you.\n")
+			return nil
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:          "label",
+			FlagShorthand: "l",
+			Description:   "A label to render the template with, in the form key=value. Can be specified multiple times.",
+			Value:         serpent.StringArrayOf(&labels),
+		},
+	}
+	return cmd
+}
+
+// findNotificationTemplate returns the system notification template with the given name or ID.
+func findNotificationTemplate(inv *serpent.Invocation, client *codersdk.Client, nameOrID string) (*codersdk.NotificationTemplate, error) {
+	templates, err := client.GetSystemNotificationTemplates(inv.Context())
+	if err != nil {
+		return nil, xerrors.Errorf("get notification templates: %w", err)
+	}
+
+	for i, t := range templates {
+		if strings.EqualFold(t.Name, nameOrID) || t.ID.String() == nameOrID {
+			return &templates[i], nil
+		}
+	}
+	return nil, xerrors.Errorf("notification %q not found", nameOrID)
+}
@@ -101,6 +101,7 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		// Workspace Commands
 		r.autoupdate(),
 		r.configSSH(),
+		r.cp(),
 		r.create(),
 		r.deleteWorkspace(),
 		r.favorite(),
@@ -110,12 +111,17 @@ func (r *RootCmd) CoreSubcommands() []*serpent.Command {
 		r.rename(),
 		r.restart(),
 		r.schedules(),
+		r.sessions(),
+		r.sharedPort(),
+		r.sharing(),
 		r.show(),
+		r.snapshot(),
 		r.speedtest(),
 		r.ssh(),
 		r.start(),
 		r.stat(),
 		r.stop(),
+		r.fileSync(),
 		r.unfavorite(),
 		r.update(),
 		r.whoami(),
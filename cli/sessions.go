@@ -0,0 +1,334 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/codersdk/workspacesdk"
+	"github.com/coder/coder/v2/pty"
+	"github.com/coder/serpent"
+)
+
+// sessionDetachKey detaches from a session without terminating it. It is the
+// same key as telnet's escape character, Ctrl+].
+const sessionDetachKey = 0x1d
+
+// sessionNamespace is used to derive stable session IDs from session names,
+// so that any client attaching to a name reaches the same session.
+var sessionNamespace = uuid.MustParse("3c4d7a0e-6f2b-4e51-9a8c-1b0d5e7f2a64")
+
+// sessionID returns the ID of the session with the given name. Names which are
+// UUIDs are used as is.
+func sessionID(name string) uuid.UUID {
+	if id, err := uuid.Parse(name); err == nil {
+		return id
+	}
+	return uuid.NewSHA1(sessionNamespace, []byte(name))
+}
+
+func (r *RootCmd) sessions() *serpent.Command {
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "sessions",
+		Short:       "List, attach to and terminate persistent terminal sessions in a workspace",
+		Long: "Persistent sessions keep running when you disconnect, so you can reattach to them later, " +
+			"from this or another machine. Start one with \"coder ssh --session <name>\".\n" + FormatExamples(
+			Example{
+				Description: "Start a named session, then detach with Ctrl+]",
+				Command:     "coder ssh my-workspace --session build",
+			},
+			Example{
+				Description: "List the sessions running in a workspace",
+				Command:     "coder sessions ls my-workspace",
+			},
+			Example{
+				Description: "Reattach to a session",
+				Command:     "coder sessions attach my-workspace build",
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.sessionsList(),
+			r.sessionsAttach(),
+			r.sessionsKill(),
+		},
+	}
+	return cmd
+}
+
+// sessionListRow is the type provided to the OutputFormatter.
+type sessionListRow struct {
+	// For JSON format:
+	workspacesdk.ReconnectingPTYSession `table:"-"`
+
+	// For table format:
+	ID              string    `json:"-" table:"id"`
+	Name            string    `json:"-" table:"name"`
+	Command         string    `json:"-" table:"command"`
+	CreatedAt       time.Time `json:"-" table:"created at,default_sort"`
+	LastAttachedAt  time.Time `json:"-" table:"last attached at"`
+	AttachedClients int       `json:"-" table:"attached clients"`
+}
+
+func sessionListRowFromSession(session workspacesdk.ReconnectingPTYSession) sessionListRow {
+	return sessionListRow{
+		ReconnectingPTYSession: session,
+		ID:                     session.ID.String(),
+		Name:                   session.Name,
+		Command:                session.Command,
+		CreatedAt:              session.CreatedAt,
+		LastAttachedAt:         session.LastAttachedAt,
+		AttachedClients:        session.AttachedClients,
+	}
+}
+
+func (r *RootCmd) sessionsList() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]sessionListRow{}, []string{"id", "name", "command", "created at", "last attached at", "attached clients"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the persistent sessions running in a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			res, err := conn.ReconnectingPTYSessions(ctx)
+			if err != nil {
+				return xerrors.Errorf("list sessions: %w", err)
+			}
+			if len(res.Sessions) == 0 {
+				cliui.Infof(inv.Stderr, "No sessions found.")
+			}
+
+			rows := make([]sessionListRow, len(res.Sessions))
+			for i, session := range res.Sessions {
+				rows[i] = sessionListRowFromSession(session)
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) sessionsAttach() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "attach <workspace> <session>",
+		Short: "Attach to a persistent session by name or ID. Press Ctrl+] to detach",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			session, err := findSession(ctx, conn, inv.Args[1])
+			if err != nil {
+				return err
+			}
+			return attachSession(ctx, inv, conn, session.ID)
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) sessionsKill() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "kill <workspace> <session>",
+		Short: "Terminate a persistent session by name or ID",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			conn, err := r.dialWorkspaceAgent(ctx, inv, client, inv.Args[0])
+			if err != nil {
+				return err
+			}
+			defer conn.Close()
+
+			session, err := findSession(ctx, conn, inv.Args[1])
+			if err != nil {
+				return err
+			}
+			if err := conn.CloseReconnectingPTYSession(ctx, session.ID); err != nil {
+				return xerrors.Errorf("terminate session: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Terminated session %s\n", cliui.Keyword(inv.Args[1]))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// findSession returns the running session with the given name or ID.
+func findSession(ctx context.Context, conn *workspacesdk.AgentConn, nameOrID string) (workspacesdk.ReconnectingPTYSession, error) {
+	res, err := conn.ReconnectingPTYSessions(ctx)
+	if err != nil {
+		return workspacesdk.ReconnectingPTYSession{}, xerrors.Errorf("list sessions: %w", err)
+	}
+	id := sessionID(nameOrID)
+	for _, session := range res.Sessions {
+		if session.ID == id || (session.Name != "" && session.Name == nameOrID) {
+			return session, nil
+		}
+	}
+	return workspacesdk.ReconnectingPTYSession{}, xerrors.Errorf("no session named %q is running, start one with \"coder ssh <workspace> --session %s\"", nameOrID, nameOrID)
+}
+
+// attachSession connects the terminal to a reconnecting PTY session until the
+// session ends or the user detaches with sessionDetachKey. The session is
+// created if it does not exist.
+func attachSession(ctx context.Context, inv *serpent.Invocation, conn *workspacesdk.AgentConn, id uuid.UUID, initOpts ...workspacesdk.AgentReconnectingPTYInitOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	height, width := uint16(24), uint16(80)
+	stdinFile, validIn := inv.Stdin.(*os.File)
+	stdoutFile, validOut := inv.Stdout.(*os.File)
+	isTTY := validIn && validOut && isatty.IsTerminal(stdinFile.Fd()) && isatty.IsTerminal(stdoutFile.Fd())
+	if isTTY {
+		if w, h, err := term.GetSize(int(stdoutFile.Fd())); err == nil {
+			height, width = uint16(h), uint16(w)
+		}
+	}
+
+	rptyConn, err := conn.ReconnectingPTY(ctx, id, height, width, "", initOpts...)
+	if err != nil {
+		return xerrors.Errorf("connect to session: %w", err)
+	}
+	defer rptyConn.Close()
+
+	// Input and window size changes are both sent as JSON messages, possibly
+	// from different goroutines.
+	var sendMu sync.Mutex
+	encoder := json.NewEncoder(rptyConn)
+	send := func(req workspacesdk.ReconnectingPTYRequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return encoder.Encode(req)
+	}
+
+	if isTTY {
+		inState, err := pty.MakeInputRaw(stdinFile.Fd())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = pty.RestoreTerminal(stdinFile.Fd(), inState)
+		}()
+		outState, err := pty.MakeOutputRaw(stdoutFile.Fd())
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = pty.RestoreTerminal(stdoutFile.Fd(), outState)
+		}()
+
+		windowChange := listenWindowSize(ctx)
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-windowChange:
+				}
+				w, h, err := term.GetSize(int(stdoutFile.Fd()))
+				if err != nil {
+					continue
+				}
+				_ = send(workspacesdk.ReconnectingPTYRequest{Height: uint16(h), Width: uint16(w)})
+			}
+		}()
+	}
+
+	detached := make(chan struct{})
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := inv.Stdin.Read(buf)
+			if n > 0 {
+				data := buf[:n]
+				i := bytes.IndexByte(data, sessionDetachKey)
+				if i >= 0 {
+					data = data[:i]
+				}
+				if len(data) > 0 {
+					if err := send(workspacesdk.ReconnectingPTYRequest{Data: string(data)}); err != nil {
+						return
+					}
+				}
+				if i >= 0 {
+					close(detached)
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	outputDone := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(inv.Stdout, rptyConn)
+		outputDone <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-detached:
+		_ = rptyConn.Close()
+		_, _ = fmt.Fprint(inv.Stderr, "\r\nDetached from session. It keeps running in the workspace.\r\n")
+		return nil
+	case err := <-outputDone:
+		if err != nil && ctx.Err() == nil {
+			return xerrors.Errorf("session ended: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package cli_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/pty/ptytest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSessions(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("ConPTY appears to be inconsistent on Windows.")
+	}
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t)
+	_ = agenttest.New(t, client.URL, agentToken)
+	coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// Start a named session, run a command in it and detach.
+	inv, root := clitest.New(t, "ssh", workspace.Name, "--session", "build")
+	clitest.SetupConfig(t, client, root)
+	pty := ptytest.New(t).Attach(inv)
+	cmdDone := tGo(t, func() {
+		err := inv.WithContext(ctx).Run()
+		assert.NoError(t, err)
+	})
+	pty.WriteLine("echo session-$((40 + 2))")
+	pty.ExpectMatchContext(ctx, "session-42")
+	pty.Write(0x1d)
+	pty.ExpectMatchContext(ctx, "Detached from session")
+	<-cmdDone
+
+	// The session is still running.
+	var out bytes.Buffer
+	inv, root = clitest.New(t, "sessions", "ls", workspace.Name, "--column", "name,attached clients")
+	clitest.SetupConfig(t, client, root)
+	inv.Stdout = &out
+	require.NoError(t, inv.WithContext(ctx).Run())
+	require.Contains(t, out.String(), "build")
+
+	// Reattaching shows the earlier output.
+	inv, root = clitest.New(t, "sessions", "attach", workspace.Name, "build")
+	clitest.SetupConfig(t, client, root)
+	pty = ptytest.New(t).Attach(inv)
+	cmdDone = tGo(t, func() {
+		err := inv.WithContext(ctx).Run()
+		assert.NoError(t, err)
+	})
+	pty.ExpectMatchContext(ctx, "session-42")
+	pty.Write(0x1d)
+	<-cmdDone
+
+	inv, root = clitest.New(t, "sessions", "kill", workspace.Name, "build")
+	clitest.SetupConfig(t, client, root)
+	pty = ptytest.New(t).Attach(inv)
+	require.NoError(t, inv.WithContext(ctx).Run())
+	pty.ExpectMatchContext(ctx, "Terminated session")
+
+	inv, root = clitest.New(t, "sessions", "attach", workspace.Name, "build")
+	clitest.SetupConfig(t, client, root)
+	err := inv.WithContext(ctx).Run()
+	require.ErrorContains(t, err, `no session named "build"`)
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/agent/agentssh"
+	"github.com/coder/coder/v2/coderd/workspaceapps/appurl"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) sharedPort() *serpent.Command {
+	var listenAddress string
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "shared-port <[owner/]workspace[.agent]> <port>",
+		Short:       `Connect to a workspace port shared over TCP. Forward your own ports with "coder port-forward".`,
+		Long: "Ports shared with the \"tcp\" protocol are tunneled through the Coder " +
+			"deployment, so they can be reached by anyone the port is shared with " +
+			"without access to the workspace itself.\n" + FormatExamples(
+			Example{
+				Description: "Connect to a database shared by another user on port 5432",
+				Command:     "coder shared-port alice/dev.main 5432",
+			},
+			Example{
+				Description: "Listen on a different local address",
+				Command:     "coder shared-port alice/dev.main 5432 --listen 127.0.0.1:15432",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			port, err := parsePort(inv.Args[1])
+			if err != nil {
+				return err
+			}
+			if listenAddress == "" {
+				listenAddress = fmt.Sprintf("127.0.0.1:%d", port)
+			}
+
+			portURL, err := sharedPortURL(ctx, client, inv.Args[0], port)
+			if err != nil {
+				return err
+			}
+			logger := inv.Logger.With(slog.F("url", portURL.String()))
+
+			// Dial once up front so that authorization errors are reported
+			// before we start listening.
+			conn, err := client.DialWorkspaceAgentPortShareTCP(ctx, portURL)
+			if err != nil {
+				return xerrors.Errorf("dial shared port: %w", err)
+			}
+			_ = conn.Close()
+
+			l, err := inv.Net.Listen("tcp", listenAddress)
+			if err != nil {
+				return xerrors.Errorf("listen 'tcp://%s': %w", listenAddress, err)
+			}
+			defer l.Close()
+			_, _ = fmt.Fprintf(inv.Stderr, "Forwarding 'tcp://%s' locally to port %d in %s\n", listenAddress, port, inv.Args[0])
+
+			var closeErr error
+			go func() {
+				sigs := make(chan os.Signal, 1)
+				signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+				defer signal.Stop(sigs)
+
+				select {
+				case <-ctx.Done():
+					closeErr = ctx.Err()
+				case <-sigs:
+					_, _ = fmt.Fprintln(inv.Stderr, "\nReceived signal, closing listener and active connections")
+				}
+				cancel()
+				_ = l.Close()
+			}()
+
+			var wg sync.WaitGroup
+			defer wg.Wait()
+			for {
+				netConn, err := l.Accept()
+				if err != nil {
+					if xerrors.Is(err, net.ErrClosed) {
+						return closeErr
+					}
+					return xerrors.Errorf("accept connection: %w", err)
+				}
+				logger.Debug(ctx, "accepted connection", slog.F("remote_addr", netConn.RemoteAddr()))
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer netConn.Close()
+					remoteConn, err := client.DialWorkspaceAgentPortShareTCP(ctx, portURL)
+					if err != nil {
+						_, _ = fmt.Fprintf(inv.Stderr, "Failed to dial shared port %d: %s\n", port, err)
+						return
+					}
+					defer remoteConn.Close()
+					agentssh.Bicopy(ctx, netConn, remoteConn)
+				}()
+			}
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "listen",
+			Env:         "CODER_SHARED_PORT_LISTEN",
+			Description: "The local address to listen on. Defaults to 127.0.0.1 and the shared port.",
+			Value:       serpent.StringOf(&listenAddress),
+		},
+	}
+	return cmd
+}
+
+// sharedPortURL returns the WebSocket URL of a port shared over TCP. The
+// caller may not be able to read the workspace, so it is only consulted
+// when the agent name is omitted.
+func sharedPortURL(ctx context.Context, client *codersdk.Client, identifier string, port uint16) (*url.URL, error) {
+	workspaceAndOwner, agentName, _ := strings.Cut(identifier, ".")
+	owner, workspaceName, err := splitNamedWorkspace(workspaceAndOwner)
+	if err != nil {
+		return nil, err
+	}
+	if owner == codersdk.Me {
+		me, err := client.User(ctx, codersdk.Me)
+		if err != nil {
+			return nil, xerrors.Errorf("get current user: %w", err)
+		}
+		owner = me.Username
+	}
+	if agentName == "" {
+		workspace, err := client.WorkspaceByOwnerAndName(ctx, owner, workspaceName, codersdk.WorkspaceOptions{})
+		if err != nil {
+			return nil, xerrors.Errorf("the agent name must be specified as %s.<agent>: %w", workspaceAndOwner, err)
+		}
+		var agents []codersdk.WorkspaceAgent
+		for _, resource := range workspace.LatestBuild.Resources {
+			agents = append(agents, resource.Agents...)
+		}
+		if len(agents) != 1 {
+			return nil, xerrors.Errorf("workspace %q has %d agents, the agent name must be specified as %s.<agent>", workspaceName, len(agents), workspaceAndOwner)
+		}
+		agentName = agents[0].Name
+	}
+
+	appHost, err := client.AppHost(ctx)
+	if err != nil {
+		return nil, xerrors.Errorf("get app host: %w", err)
+	}
+	if appHost.Host == "" {
+		return nil, xerrors.New("the deployment does not have a wildcard access URL configured, which is required to share ports")
+	}
+
+	scheme := "wss"
+	if client.URL.Scheme == "http" {
+		scheme = "ws"
+	}
+	subdomain := appurl.ApplicationURL{
+		AppSlugOrPort: fmt.Sprint(port),
+		AgentName:     agentName,
+		WorkspaceName: workspaceName,
+		Username:      owner,
+	}.String()
+	return &url.URL{
+		Scheme: scheme,
+		Host:   subdomain + strings.TrimPrefix(appurl.SubdomainAppHost(appHost.Host, client.URL), "*"),
+		Path:   "/",
+	}, nil
+}
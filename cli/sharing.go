@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) sharing() *serpent.Command {
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "sharing",
+		Short:       "Share a workspace with other users and groups",
+		Long: "Users and groups a workspace is shared with are granted one of two roles. " +
+			"\"use\" allows connecting to the workspace over SSH, apps and port forwarding. " +
+			"\"admin\" also allows starting, stopping and updating it.\n" + FormatExamples(
+			Example{
+				Description: "Allow a user to connect to your workspace",
+				Command:     "coder sharing add my-workspace --user alice",
+			},
+			Example{
+				Description: "Allow a group to start, stop and update your workspace",
+				Command:     "coder sharing add my-workspace --group developers:admin",
+			},
+			Example{
+				Description: "Stop sharing your workspace with a user",
+				Command:     "coder sharing remove my-workspace --user alice",
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.sharingAdd(),
+			r.sharingRemove(),
+			r.sharingList(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) sharingAdd() *serpent.Command {
+	var (
+		users  []string
+		groups []string
+		client = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "add <workspace>",
+		Short: "Share a workspace with users and groups",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "user",
+				Description: "Users to share the workspace with, as username[:role]. The role is use or admin and defaults to use.",
+				Value:       serpent.StringArrayOf(&users),
+			},
+			{
+				Flag:        "group",
+				Description: "Groups to share the workspace with, as name[:role]. The role is use or admin and defaults to use.",
+				Value:       serpent.StringArrayOf(&groups),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			if len(users) == 0 && len(groups) == 0 {
+				return xerrors.New("at least one of --user or --group must be specified")
+			}
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+
+			req := codersdk.UpdateWorkspaceACL{
+				UserRoles:  map[string]codersdk.WorkspaceRole{},
+				GroupRoles: map[string]codersdk.WorkspaceRole{},
+			}
+			for _, arg := range users {
+				name, role, err := parseSharingTarget(arg)
+				if err != nil {
+					return err
+				}
+				user, err := client.User(ctx, name)
+				if err != nil {
+					return xerrors.Errorf("get user %q: %w", name, err)
+				}
+				req.UserRoles[user.ID.String()] = role
+			}
+			for _, arg := range groups {
+				name, role, err := parseSharingTarget(arg)
+				if err != nil {
+					return err
+				}
+				group, err := client.GroupByOrgAndName(ctx, workspace.OrganizationID, name)
+				if err != nil {
+					return xerrors.Errorf("get group %q: %w", name, err)
+				}
+				req.GroupRoles[group.ID.String()] = role
+			}
+
+			err = client.UpdateWorkspaceACL(ctx, workspace.ID, req)
+			if err != nil {
+				return xerrors.Errorf("update workspace ACL: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Shared workspace %s\n", cliui.Keyword(workspace.Name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) sharingRemove() *serpent.Command {
+	var (
+		users  []string
+		groups []string
+		client = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:     "remove <workspace>",
+		Aliases: []string{"rm"},
+		Short:   "Stop sharing a workspace with users and groups",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "user",
+				Description: "Usernames to stop sharing the workspace with.",
+				Value:       serpent.StringArrayOf(&users),
+			},
+			{
+				Flag:        "group",
+				Description: "Group names to stop sharing the workspace with.",
+				Value:       serpent.StringArrayOf(&groups),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			if len(users) == 0 && len(groups) == 0 {
+				return xerrors.New("at least one of --user or --group must be specified")
+			}
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			// Look the names up in the ACL rather than by name, so users and
+			// groups which can no longer be found can still be removed.
+			acl, err := client.WorkspaceACL(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get workspace ACL: %w", err)
+			}
+
+			req := codersdk.UpdateWorkspaceACL{
+				UserRoles:  map[string]codersdk.WorkspaceRole{},
+				GroupRoles: map[string]codersdk.WorkspaceRole{},
+			}
+		users:
+			for _, name := range users {
+				for _, user := range acl.Users {
+					if user.Username == name {
+						req.UserRoles[user.ID.String()] = codersdk.WorkspaceRoleDeleted
+						continue users
+					}
+				}
+				return xerrors.Errorf("workspace %q is not shared with user %q", workspace.Name, name)
+			}
+		groups:
+			for _, name := range groups {
+				for _, group := range acl.Groups {
+					if group.Name == name {
+						req.GroupRoles[group.ID.String()] = codersdk.WorkspaceRoleDeleted
+						continue groups
+					}
+				}
+				return xerrors.Errorf("workspace %q is not shared with group %q", workspace.Name, name)
+			}
+
+			err = client.UpdateWorkspaceACL(ctx, workspace.ID, req)
+			if err != nil {
+				return xerrors.Errorf("update workspace ACL: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Stopped sharing workspace %s\n", cliui.Keyword(workspace.Name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// sharingListRow is the type provided to the OutputFormatter.
+type sharingListRow struct {
+	Type string                 `json:"type" table:"type"`
+	ID   string                 `json:"id" table:"id"`
+	Name string                 `json:"name" table:"name,default_sort"`
+	Role codersdk.WorkspaceRole `json:"role" table:"role"`
+}
+
+func (r *RootCmd) sharingList() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]sharingListRow{}, []string{"type", "name", "role"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the users and groups a workspace is shared with",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			acl, err := client.WorkspaceACL(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get workspace ACL: %w", err)
+			}
+			if len(acl.Users) == 0 && len(acl.Groups) == 0 {
+				cliui.Infof(inv.Stderr, "Workspace %s is not shared with anyone.", workspace.Name)
+			}
+
+			rows := make([]sharingListRow, 0, len(acl.Users)+len(acl.Groups))
+			for _, user := range acl.Users {
+				rows = append(rows, sharingListRow{
+					Type: "user",
+					ID:   user.ID.String(),
+					Name: user.Username,
+					Role: user.Role,
+				})
+			}
+			for _, group := range acl.Groups {
+				rows = append(rows, sharingListRow{
+					Type: "group",
+					ID:   group.ID.String(),
+					Name: group.Name,
+					Role: group.Role,
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+// parseSharingTarget parses a name[:role] argument.
+func parseSharingTarget(arg string) (string, codersdk.WorkspaceRole, error) {
+	name, role, ok := strings.Cut(arg, ":")
+	if !ok {
+		return name, codersdk.WorkspaceRoleUse, nil
+	}
+	switch r := codersdk.WorkspaceRole(role); r {
+	case codersdk.WorkspaceRoleUse, codersdk.WorkspaceRoleAdmin:
+		return name, r, nil
+	default:
+		return "", "", xerrors.Errorf("invalid role %q in %q, must be %q or %q", role, arg, codersdk.WorkspaceRoleUse, codersdk.WorkspaceRoleAdmin)
+	}
+}
@@ -0,0 +1,58 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSharing(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client, db           = coderdtest.NewWithDatabase(t, nil)
+		owner                = coderdtest.CreateFirstUser(t, client)
+		memberClient, member = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		_, other             = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		ws                   = dbfake.WorkspaceBuild(t, db, database.Workspace{OwnerID: member.ID, OrganizationID: owner.OrganizationID}).Do()
+	)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	inv, root := clitest.New(t, "sharing", "add", ws.Workspace.Name, "--user", other.Username+":admin")
+	clitest.SetupConfig(t, memberClient, root)
+	require.NoError(t, inv.WithContext(ctx).Run())
+
+	acl, err := memberClient.WorkspaceACL(ctx, ws.Workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, acl.Users, 1)
+	require.Equal(t, other.ID, acl.Users[0].ID)
+	require.Equal(t, codersdk.WorkspaceRoleAdmin, acl.Users[0].Role)
+
+	var buf bytes.Buffer
+	inv, root = clitest.New(t, "sharing", "list", ws.Workspace.Name)
+	clitest.SetupConfig(t, memberClient, root)
+	inv.Stdout = &buf
+	require.NoError(t, inv.WithContext(ctx).Run())
+	require.Contains(t, buf.String(), other.Username)
+	require.Contains(t, buf.String(), "admin")
+
+	inv, root = clitest.New(t, "sharing", "add", ws.Workspace.Name, "--user", other.Username+":owner")
+	clitest.SetupConfig(t, memberClient, root)
+	require.ErrorContains(t, inv.WithContext(ctx).Run(), `invalid role "owner"`)
+
+	inv, root = clitest.New(t, "sharing", "remove", ws.Workspace.Name, "--user", other.Username)
+	clitest.SetupConfig(t, memberClient, root)
+	require.NoError(t, inv.WithContext(ctx).Run())
+
+	acl, err = memberClient.WorkspaceACL(ctx, ws.Workspace.ID)
+	require.NoError(t, err)
+	require.Empty(t, acl.Users)
+}
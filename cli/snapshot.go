@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) snapshot() *serpent.Command {
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "snapshot",
+		Short:       "Snapshot a workspace build and restore it later",
+		Long: "Snapshots record the template version, Terraform state and parameter values " +
+			"of a workspace build. Restoring a snapshot rebuilds the workspace with them, " +
+			"reattaching the volumes it had when the snapshot was taken. Only template " +
+			"administrators may create and restore snapshots.\n" + FormatExamples(
+			Example{
+				Description: "Snapshot a workspace before updating it",
+				Command:     "coder snapshot create my-workspace --name before-update",
+			},
+			Example{
+				Description: "Roll a workspace back to the snapshot",
+				Command:     "coder snapshot restore my-workspace before-update",
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.snapshotCreate(),
+			r.snapshotList(),
+			r.snapshotRestore(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) snapshotCreate() *serpent.Command {
+	var (
+		name        string
+		buildNumber int64
+		client      = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "create <workspace>",
+		Short: "Snapshot a workspace build",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "name",
+				Description: "Name of the snapshot. A random name is generated if not specified.",
+				Value:       serpent.StringOf(&name),
+			},
+			buildNumberOption(&buildNumber),
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			snapshot, err := client.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+				Name:        name,
+				BuildNumber: int32(buildNumber),
+			})
+			if err != nil {
+				return xerrors.Errorf("create snapshot: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Created snapshot %s of build #%d of workspace %s\n",
+				cliui.Keyword(snapshot.Name), snapshot.BuildNumber, cliui.Keyword(workspace.Name))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// snapshotListRow is the type provided to the OutputFormatter.
+type snapshotListRow struct {
+	codersdk.WorkspaceSnapshot `table:"-"`
+
+	Name            string    `json:"-" table:"name"`
+	Build           int32     `json:"-" table:"build"`
+	TemplateVersion string    `json:"-" table:"template version"`
+	Transition      string    `json:"-" table:"transition"`
+	CreatedAt       time.Time `json:"-" table:"created at,default_sort"`
+}
+
+func (r *RootCmd) snapshotList() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.TableFormat([]snapshotListRow{}, []string{"name", "build", "template version", "transition", "created at"}),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:     "list <workspace>",
+		Aliases: []string{"ls"},
+		Short:   "List the snapshots of a workspace",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			snapshots, err := client.WorkspaceSnapshots(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get snapshots: %w", err)
+			}
+			if len(snapshots) == 0 {
+				cliui.Infof(inv.Stderr, "Workspace %s has no snapshots.", workspace.Name)
+			}
+
+			rows := make([]snapshotListRow, 0, len(snapshots))
+			for _, snapshot := range snapshots {
+				rows = append(rows, snapshotListRow{
+					WorkspaceSnapshot: snapshot,
+					Name:              snapshot.Name,
+					Build:             snapshot.BuildNumber,
+					TemplateVersion:   snapshot.TemplateVersionName,
+					Transition:        string(snapshot.Transition),
+					CreatedAt:         snapshot.CreatedAt,
+				})
+			}
+			out, err := formatter.Format(ctx, rows)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) snapshotRestore() *serpent.Command {
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "restore <workspace> <snapshot>",
+		Short: "Rebuild a workspace from a snapshot",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{cliui.SkipPromptOption()},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			workspace, err := namedWorkspace(ctx, client, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get workspace: %w", err)
+			}
+			snapshots, err := client.WorkspaceSnapshots(ctx, workspace.ID)
+			if err != nil {
+				return xerrors.Errorf("get snapshots: %w", err)
+			}
+			var snapshot *codersdk.WorkspaceSnapshot
+			for i := range snapshots {
+				if strings.EqualFold(snapshots[i].Name, inv.Args[1]) {
+					snapshot = &snapshots[i]
+					break
+				}
+			}
+			if snapshot == nil {
+				return xerrors.Errorf("workspace %q has no snapshot named %q", workspace.Name, inv.Args[1])
+			}
+
+			_, err = cliui.Prompt(inv, cliui.PromptOptions{
+				Text: fmt.Sprintf("Restore workspace %s to build #%d on template version %s?",
+					cliui.Keyword(workspace.Name), snapshot.BuildNumber, cliui.Keyword(snapshot.TemplateVersionName)),
+				IsConfirm: true,
+			})
+			if err != nil {
+				return err
+			}
+
+			build, err := client.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+			if err != nil {
+				return xerrors.Errorf("restore snapshot: %w", err)
+			}
+			err = cliui.WorkspaceBuild(ctx, inv.Stdout, client, build.ID)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "\nThe %s workspace has been restored from snapshot %s!\n",
+				cliui.Keyword(workspace.Name), cliui.Keyword(snapshot.Name))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,51 @@
+package cli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		client, db                  = coderdtest.NewWithDatabase(t, nil)
+		owner                       = coderdtest.CreateFirstUser(t, client)
+		templateAdmin, templateUser = coderdtest.CreateAnotherUser(t, client, owner.OrganizationID, rbac.RoleTemplateAdmin())
+	)
+	ws := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OwnerID:        templateUser.ID,
+		OrganizationID: owner.OrganizationID,
+	}).Seed(database.WorkspaceBuild{ProvisionerState: []byte("some state")}).Do()
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	inv, root := clitest.New(t, "snapshot", "create", ws.Workspace.Name, "--name", "before-update")
+	clitest.SetupConfig(t, templateAdmin, root)
+	require.NoError(t, inv.WithContext(ctx).Run())
+
+	snapshots, err := templateAdmin.WorkspaceSnapshots(ctx, ws.Workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, "before-update", snapshots[0].Name)
+	require.Equal(t, ws.Build.BuildNumber, snapshots[0].BuildNumber)
+
+	var buf bytes.Buffer
+	inv, root = clitest.New(t, "snapshot", "list", ws.Workspace.Name)
+	clitest.SetupConfig(t, templateAdmin, root)
+	inv.Stdout = &buf
+	require.NoError(t, inv.WithContext(ctx).Run())
+	require.Contains(t, buf.String(), "before-update")
+
+	inv, root = clitest.New(t, "snapshot", "restore", ws.Workspace.Name, "missing", "--yes")
+	clitest.SetupConfig(t, templateAdmin, root)
+	require.ErrorContains(t, inv.WithContext(ctx).Run(), `has no snapshot named "missing"`)
+}
@@ -64,6 +64,7 @@ func (r *RootCmd) ssh() *serpent.Command {
 		env              []string
 		usageApp         string
 		disableAutostart bool
+		session          string
 	)
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
@@ -166,6 +167,10 @@ func (r *RootCmd) ssh() *serpent.Command {
 				}
 			}
 
+			if session != "" && (stdio || len(remoteForwards) > 0 || forwardAgent || forwardGPG) {
+				return xerrors.New("--session can't be combined with --stdio or forwarding")
+			}
+
 			var parsedEnv [][2]string
 			for _, e := range env {
 				k, v, ok := strings.Cut(e, "=")
@@ -267,6 +272,10 @@ func (r *RootCmd) ssh() *serpent.Command {
 				defer closeUsage()
 			}
 
+			if session != "" {
+				return attachSession(ctx, inv, conn, sessionID(session), workspacesdk.AgentReconnectingPTYInitWithName(session))
+			}
+
 			if stdio {
 				rawSSH, err := conn.SSH(ctx)
 				if err != nil {
@@ -533,6 +542,12 @@ func (r *RootCmd) ssh() *serpent.Command {
 			Value:       serpent.StringOf(&usageApp),
 			Hidden:      true,
 		},
+		{
+			Flag:        "session",
+			Description: "Attach to the persistent session with this name, starting it if needed. The session keeps running when you disconnect; press Ctrl+] to detach, and see \"coder sessions\" to manage sessions.",
+			Env:         "CODER_SSH_SESSION",
+			Value:       serpent.StringOf(&session),
+		},
 		sshDisableAutostartOption(serpent.BoolOf(&disableAutostart)),
 	}
 	return cmd
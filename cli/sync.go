@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/clisync"
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) fileSync() *serpent.Command {
+	var (
+		interval    time.Duration
+		ignore      []string
+		noGitignore bool
+		conflicts   string
+		once        bool
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Annotations: workspaceCommand,
+		Use:         "sync <local directory> <workspace>:<path>",
+		Short:       "Continuously mirror a local directory into a workspace",
+		Long: "Changes on either side are copied to the other over SFTP, through the same connection as \"coder ssh\". " +
+			"When a file changes on both sides between two syncs, the conflict is reported and neither copy is " +
+			"overwritten; delete the copy you do not want to resolve it. On start, local files take precedence over " +
+			"different files at the same path in the workspace. Paths matching the .gitignore at the root of the local " +
+			"directory, and the .git directory, are not synced.\n" + FormatExamples(
+			Example{
+				Description: "Edit locally and build in a workspace",
+				Command:     "coder sync ./my-project my-workspace:my-project",
+			},
+			Example{
+				Description: "Sync once, for example in CI",
+				Command:     "coder sync --once --ignore 'node_modules/' . my-workspace:src",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(2),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx, cancel := context.WithCancel(inv.Context())
+			defer cancel()
+
+			local := parseCopyTarget(inv.Args[0])
+			remote := parseCopyTarget(inv.Args[1])
+			if local.workspace != "" || remote.workspace == "" {
+				return xerrors.New("the first argument must be a local directory and the second a workspace path, e.g. my-workspace:path/to/dir")
+			}
+			info, err := os.Stat(local.path)
+			if err != nil {
+				return xerrors.Errorf("stat local directory: %w", err)
+			}
+			if !info.IsDir() {
+				return xerrors.Errorf("%q is not a directory", local.path)
+			}
+
+			ignorer := clisync.NewIgnore(".git/")
+			if !noGitignore {
+				if err := ignorer.AddFile(filepath.Join(local.path, ".gitignore")); err != nil {
+					return err
+				}
+			}
+			ignorer.Add(ignore...)
+
+			sftpClient, closeSFTP, err := r.dialSFTP(ctx, inv, client, remote.workspace)
+			if err != nil {
+				return err
+			}
+			defer closeSFTP()
+
+			syncer := &clisync.Syncer{
+				Local:     clisync.LocalFS{Root: local.path},
+				Remote:    clisync.SFTPFS{Client: sftpClient, Root: remote.path},
+				Ignore:    ignorer,
+				Conflicts: clisync.ConflictMode(conflicts),
+			}
+			if !once {
+				cliui.Infof(inv.Stderr, "Syncing %s with %s:%s every %s. Press Ctrl+C to stop.", local.path, remote.workspace, remote.path, interval)
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				changes, err := syncer.Sync(ctx)
+				for _, change := range changes {
+					printSyncChange(inv, change)
+				}
+				if err != nil {
+					if once || ctx.Err() != nil {
+						return xerrors.Errorf("sync: %w", err)
+					}
+					// The connection or a file may recover, so keep going.
+					cliui.Warnf(inv.Stderr, "Sync failed, retrying in %s: %s", interval, err)
+				}
+				if once {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Options = serpent.OptionSet{
+		{
+			Flag:        "interval",
+			Description: "How often to look for changes.",
+			Default:     "2s",
+			Value:       serpent.DurationOf(&interval),
+		},
+		{
+			Flag:        "ignore",
+			Description: "A path pattern, in the .gitignore format, which is not synced. Can be specified multiple times.",
+			Value:       serpent.StringArrayOf(&ignore),
+		},
+		{
+			Flag:        "no-gitignore",
+			Description: "Sync paths matched by the .gitignore at the root of the local directory.",
+			Value:       serpent.BoolOf(&noGitignore),
+		},
+		{
+			Flag:        "conflict",
+			Description: "What to do with files which changed on both sides: report the conflict and leave both copies, or keep the local or the workspace copy.",
+			Default:     string(clisync.ConflictReport),
+			Value:       serpent.EnumOf(&conflicts, clisync.ConflictModes...),
+		},
+		{
+			Flag:        "once",
+			Description: "Sync once and exit, instead of watching for changes.",
+			Value:       serpent.BoolOf(&once),
+		},
+	}
+	return cmd
+}
+
+func printSyncChange(inv *serpent.Invocation, change clisync.Change) {
+	switch change.Action {
+	case clisync.ActionUpload:
+		_, _ = fmt.Fprintf(inv.Stderr, "Uploaded %s\n", change.Path)
+	case clisync.ActionDownload:
+		_, _ = fmt.Fprintf(inv.Stderr, "Downloaded %s\n", change.Path)
+	case clisync.ActionDeleteLocal:
+		_, _ = fmt.Fprintf(inv.Stderr, "Deleted %s locally\n", change.Path)
+	case clisync.ActionDeleteRemote:
+		_, _ = fmt.Fprintf(inv.Stderr, "Deleted %s in the workspace\n", change.Path)
+	case clisync.ActionConflict:
+		cliui.Warnf(inv.Stderr, "Conflict: %s changed locally and in the workspace; delete the copy you do not want to keep", change.Path)
+	}
+}
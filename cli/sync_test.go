@@ -0,0 +1,40 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/agent/agenttest"
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	client, workspace, agentToken := setupWorkspaceForAgent(t)
+	_ = agenttest.New(t, client.URL, agentToken)
+	coderdtest.AwaitWorkspaceAgents(t, client, workspace.ID)
+
+	// The agent runs on this machine, so workspace paths are local too.
+	localDir := t.TempDir()
+	remoteDir := filepath.Join(t.TempDir(), "project")
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, ".gitignore"), []byte("*.log\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "main.go"), []byte("package main"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, "debug.log"), []byte("noise"), 0o600))
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	inv, root := clitest.New(t, "sync", "--once", localDir, workspace.Name+":"+filepath.ToSlash(remoteDir))
+	clitest.SetupConfig(t, client, root)
+	require.NoError(t, inv.WithContext(ctx).Run())
+
+	got, err := os.ReadFile(filepath.Join(remoteDir, "main.go"))
+	require.NoError(t, err)
+	require.Equal(t, "package main", string(got))
+	require.FileExists(t, filepath.Join(remoteDir, ".gitignore"))
+	require.NoFileExists(t, filepath.Join(remoteDir, "debug.log"))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/coder/serpent"
 
 	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/coderd/util/ptr"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -30,6 +31,8 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 		failureTTL                     time.Duration
 		dormancyThreshold              time.Duration
 		dormancyAutoDeletion           time.Duration
+		idleTimeout                    time.Duration
+		idleCPUThreshold               int64
 		allowUserCancelWorkspaceJobs   bool
 		allowUserAutostart             bool
 		allowUserAutostop              bool
@@ -56,6 +59,8 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				failureTTL != 0 ||
 				dormancyThreshold != 0 ||
 				dormancyAutoDeletion != 0 ||
+				idleTimeout != 0 ||
+				idleCPUThreshold != 0 ||
 				len(autostartRequirementDaysOfWeek) > 0
 
 			requiresEntitlement := requiresScheduling || requireActiveVersion
@@ -162,6 +167,19 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				deprecated = &deprecationMessage
 			}
 
+			// Idle stop settings are left unchanged unless provided.
+			var idleTimeoutMillis *int64
+			if userSetOption(inv, "idle-timeout") {
+				idleTimeoutMillis = ptr.Ref(idleTimeout.Milliseconds())
+			}
+			var idleCPUThresholdPercent *int32
+			if userSetOption(inv, "idle-cpu-threshold") {
+				if idleCPUThreshold < 0 || idleCPUThreshold > 100 {
+					return xerrors.Errorf("--idle-cpu-threshold must be between 0 and 100")
+				}
+				idleCPUThresholdPercent = ptr.Ref(int32(idleCPUThreshold))
+			}
+
 			var disableEveryoneGroup bool
 			if userSetOption(inv, "private") {
 				disableEveryoneGroup = disableEveryone
@@ -184,6 +202,8 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 				FailureTTLMillis:               failureTTL.Milliseconds(),
 				TimeTilDormantMillis:           dormancyThreshold.Milliseconds(),
 				TimeTilDormantAutoDeleteMillis: dormancyAutoDeletion.Milliseconds(),
+				IdleTimeoutMillis:              idleTimeoutMillis,
+				IdleCPUThreshold:               idleCPUThresholdPercent,
 				AllowUserCancelWorkspaceJobs:   allowUserCancelWorkspaceJobs,
 				AllowUserAutostart:             allowUserAutostart,
 				AllowUserAutostop:              allowUserAutostop,
@@ -292,6 +312,18 @@ func (r *RootCmd) templateEdit() *serpent.Command {
 			Default:     "0h",
 			Value:       serpent.DurationOf(&dormancyAutoDeletion),
 		},
+		{
+			Flag:        "idle-timeout",
+			Description: "Specify a duration running workspaces may be idle prior to being stopped. A workspace is idle while it has no open sessions and its CPU usage is below the idle CPU threshold. This licensed feature's default is 0h (off).",
+			Default:     "0h",
+			Value:       serpent.DurationOf(&idleTimeout),
+		},
+		{
+			Flag:        "idle-cpu-threshold",
+			Description: "Specify the CPU usage percentage below which a workspace without open sessions is considered idle. 0 ignores CPU usage, so workspaces are idle whenever they have no open sessions. This is a licensed feature.",
+			Default:     "0",
+			Value:       serpent.Int64Of(&idleCPUThreshold),
+		},
 		{
 			Flag:        "allow-user-cancel-workspace-jobs",
 			Description: "Allow users to cancel in-progress workspace jobs.",
@@ -0,0 +1,250 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) templateGitSync() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "git-sync",
+		Short: "Create template versions from commits to a Git repository",
+		Long: "A synced template gets a new version for every commit to a branch of the repository. " +
+			"The repository is polled every minute, and a push webhook can be configured to sync immediately.\n" + FormatExamples(
+			Example{
+				Description: "Sync the docker template from a directory of a repository, activating new versions",
+				Command:     "coder templates git-sync set docker --repository https://github.com/acme/templates.git --directory docker --activate",
+			},
+			Example{
+				Description: "Sync the template now",
+				Command:     "coder templates git-sync sync docker",
+			},
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.templateGitSyncSet(),
+			r.templateGitSyncShow(),
+			r.templateGitSyncSync(),
+			r.templateGitSyncUnset(),
+		},
+	}
+	return cmd
+}
+
+func (r *RootCmd) templateGitSyncSet() *serpent.Command {
+	var (
+		req        codersdk.UpdateTemplateGitSyncRequest
+		orgContext = NewOrganizationContext()
+		client     = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "set <template>",
+		Short: "Sync a template from a Git repository",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:        "repository",
+				Description: "HTTP or HTTPS clone URL of the repository. Must be HTTPS when an external auth provider is used.",
+				Value:       serpent.StringOf(&req.RepositoryURL),
+			},
+			{
+				Flag:        "branch",
+				Description: "Branch to create template versions from.",
+				Default:     "main",
+				Value:       serpent.StringOf(&req.Branch),
+			},
+			{
+				Flag:        "directory",
+				Description: "Path of the template in the repository. Defaults to the root of the repository.",
+				Value:       serpent.StringOf(&req.Directory),
+			},
+			{
+				Flag:        "external-auth",
+				Description: "ID of the external auth provider used to fetch a private repository, with your linked account.",
+				Value:       serpent.StringOf(&req.ExternalAuthProviderID),
+			},
+			{
+				Flag:        "activate",
+				Description: "Promote synced versions to the active version when they are imported successfully.",
+				Value:       serpent.BoolOf(&req.AutoActivate),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			if req.RepositoryURL == "" {
+				return xerrors.Errorf("missing repository, specify one with --repository")
+			}
+			template, err := gitSyncTemplate(inv, client, orgContext)
+			if err != nil {
+				return err
+			}
+			gitSync, err := client.UpdateTemplateGitSync(ctx, template.ID, req)
+			if err != nil {
+				return xerrors.Errorf("update git sync: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(inv.Stdout, "Template %s is synced from branch %s of %s.\n\n",
+				cliui.Keyword(template.Name), cliui.Keyword(gitSync.Branch), cliui.Keyword(gitSync.RepositoryURL))
+			_, _ = fmt.Fprintf(inv.Stdout, "To sync on every push, add a webhook to the repository with:\n\n")
+			_, _ = fmt.Fprintf(inv.Stdout, "  URL:    %s\n", gitSync.WebhookURL)
+			_, _ = fmt.Fprintf(inv.Stdout, "  Secret: %s\n\n", gitSync.WebhookSecret)
+			cliui.Infof(inv.Stdout, "The secret is not shown again. Run this command again to generate a new webhook secret.")
+			return nil
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	return cmd
+}
+
+// templateGitSyncRow is the type provided to the OutputFormatter.
+type templateGitSyncRow struct {
+	codersdk.TemplateGitSync `table:"-"`
+
+	Repository   string    `json:"-" table:"repository,default_sort"`
+	Branch       string    `json:"-" table:"branch"`
+	Directory    string    `json:"-" table:"directory"`
+	AutoActivate bool      `json:"-" table:"auto activate"`
+	LastCommit   string    `json:"-" table:"last commit"`
+	LastSyncedAt time.Time `json:"-" table:"last synced at"`
+	LastError    string    `json:"-" table:"last error"`
+}
+
+func (r *RootCmd) templateGitSyncShow() *serpent.Command {
+	var (
+		formatter = cliui.NewOutputFormatter(
+			cliui.TableFormat([]templateGitSyncRow{}, []string{"repository", "branch", "directory", "auto activate", "last commit", "last synced at", "last error"}),
+			cliui.JSONFormat(),
+		)
+		orgContext = NewOrganizationContext()
+		client     = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "show <template>",
+		Short: "Show the Git repository a template is synced from",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			template, err := gitSyncTemplate(inv, client, orgContext)
+			if err != nil {
+				return err
+			}
+			gitSync, err := client.TemplateGitSync(ctx, template.ID)
+			if err != nil {
+				return xerrors.Errorf("get git sync: %w", err)
+			}
+
+			row := templateGitSyncRow{
+				TemplateGitSync: gitSync,
+				Repository:      gitSync.RepositoryURL,
+				Branch:          gitSync.Branch,
+				Directory:       gitSync.Directory,
+				AutoActivate:    gitSync.AutoActivate,
+				LastCommit:      gitSync.LastCommitSHA,
+				LastError:       gitSync.LastError,
+			}
+			if gitSync.LastSyncedAt != nil {
+				row.LastSyncedAt = *gitSync.LastSyncedAt
+			}
+			out, err := formatter.Format(ctx, []templateGitSyncRow{row})
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	formatter.AttachOptions(&cmd.Options)
+	return cmd
+}
+
+func (r *RootCmd) templateGitSyncSync() *serpent.Command {
+	var (
+		orgContext = NewOrganizationContext()
+		client     = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "sync <template>",
+		Short: "Sync a template from its Git repository now",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			template, err := gitSyncTemplate(inv, client, orgContext)
+			if err != nil {
+				return err
+			}
+			gitSync, err := client.SyncTemplateGitSync(ctx, template.ID)
+			if err != nil {
+				return xerrors.Errorf("sync template: %w", err)
+			}
+			if gitSync.LastError != "" {
+				return xerrors.Errorf("sync template: %s", gitSync.LastError)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Template %s is synced to commit %s.\n",
+				cliui.Keyword(template.Name), cliui.Keyword(gitSync.LastCommitSHA))
+			return nil
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	return cmd
+}
+
+func (r *RootCmd) templateGitSyncUnset() *serpent.Command {
+	var (
+		orgContext = NewOrganizationContext()
+		client     = new(codersdk.Client)
+	)
+	cmd := &serpent.Command{
+		Use:   "unset <template>",
+		Short: "Stop syncing a template from its Git repository",
+		Long:  "Versions that were already created from the repository are kept.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			template, err := gitSyncTemplate(inv, client, orgContext)
+			if err != nil {
+				return err
+			}
+			err = client.DeleteTemplateGitSync(ctx, template.ID)
+			if err != nil {
+				return xerrors.Errorf("delete git sync: %w", err)
+			}
+			_, _ = fmt.Fprintf(inv.Stdout, "Template %s is no longer synced from Git.\n", cliui.Keyword(template.Name))
+			return nil
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	return cmd
+}
+
+func gitSyncTemplate(inv *serpent.Invocation, client *codersdk.Client, orgContext *OrganizationContext) (codersdk.Template, error) {
+	organization, err := orgContext.Selected(inv, client)
+	if err != nil {
+		return codersdk.Template{}, err
+	}
+	template, err := client.TemplateByName(inv.Context(), organization.ID, inv.Args[0])
+	if err != nil {
+		return codersdk.Template{}, xerrors.Errorf("get template by name: %w", err)
+	}
+	return template, nil
+}
@@ -0,0 +1,77 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/templategitsync"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+type unreachableGitFetcher struct{}
+
+func (unreachableGitFetcher) Resolve(context.Context, templategitsync.Repository) (string, error) {
+	return "", xerrors.New("repository is unreachable")
+}
+
+func (unreachableGitFetcher) Fetch(context.Context, templategitsync.Repository) (string, []byte, error) {
+	return "", nil, xerrors.New("repository is unreachable")
+}
+
+func TestTemplateGitSync(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, &coderdtest.Options{
+		IncludeProvisionerDaemon: true,
+		TemplateGitFetcher:       unreachableGitFetcher{},
+	})
+	owner := coderdtest.CreateFirstUser(t, client)
+	templateAdmin, _ := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID, rbac.RoleTemplateAdmin())
+	version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+	template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	var buf bytes.Buffer
+	inv, root := clitest.New(t, "templates", "git-sync", "set", template.Name,
+		"--repository", "https://example.com/templates.git", "--directory", "docker", "--activate")
+	clitest.SetupConfig(t, templateAdmin, root)
+	inv.Stdout = &buf
+	require.NoError(t, inv.WithContext(ctx).Run())
+	require.Contains(t, buf.String(), "/git-sync/webhook")
+
+	gitSync, err := templateAdmin.TemplateGitSync(ctx, template.ID)
+	require.NoError(t, err)
+	require.Equal(t, "main", gitSync.Branch)
+	require.Equal(t, "docker", gitSync.Directory)
+	require.True(t, gitSync.AutoActivate)
+
+	buf.Reset()
+	inv, root = clitest.New(t, "templates", "git-sync", "show", template.Name)
+	clitest.SetupConfig(t, templateAdmin, root)
+	inv.Stdout = &buf
+	require.NoError(t, inv.WithContext(ctx).Run())
+	require.Contains(t, buf.String(), "https://example.com/templates.git")
+
+	inv, root = clitest.New(t, "templates", "git-sync", "sync", template.Name)
+	clitest.SetupConfig(t, templateAdmin, root)
+	require.ErrorContains(t, inv.WithContext(ctx).Run(), "repository is unreachable")
+
+	inv, root = clitest.New(t, "templates", "git-sync", "unset", template.Name)
+	clitest.SetupConfig(t, templateAdmin, root)
+	require.NoError(t, inv.WithContext(ctx).Run())
+
+	_, err = templateAdmin.TemplateGitSync(ctx, template.ID)
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+}
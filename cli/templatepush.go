@@ -416,6 +416,9 @@ func createValidTemplateVersion(inv *serpent.Invocation, args createValidTemplat
 	})
 	if err != nil {
 		var jobErr *cliui.ProvisionerJobError
+		if errors.As(err, &jobErr) && jobErr.Code == codersdk.TemplatePolicyViolation {
+			return nil, xerrors.Errorf("%w: fix the violations listed above and push again", err)
+		}
 		if errors.As(err, &jobErr) && !codersdk.JobIsMissingParameterErrorCode(jobErr.Code) {
 			return nil, err
 		}
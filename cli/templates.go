@@ -29,6 +29,7 @@ func (r *RootCmd) templates() *serpent.Command {
 		Children: []*serpent.Command{
 			r.templateCreate(),
 			r.templateEdit(),
+			r.templateGitSync(),
 			r.templateInit(),
 			r.templateList(),
 			r.templatePush(),
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) templateVersionsDiff() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(cliui.TextFormat(), func(data any) (any, error) {
+			diff, ok := data.(codersdk.TemplateVersionDiff)
+			if !ok {
+				return nil, xerrors.Errorf("expected type %T, got %T", diff, data)
+			}
+			return renderTemplateVersionDiff(diff), nil
+		}),
+		cliui.JSONFormat(),
+	)
+	orgContext := NewOrganizationContext()
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "diff <template> <from-version> <to-version>",
+		Short: "Compare the source files, parameters, variables and resources of two template versions.",
+		Long: FormatExamples(
+			Example{
+				Description: "Review what changed before promoting a version",
+				Command:     "coder templates versions diff my-template v1 v2",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(3),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			organization, err := orgContext.Selected(inv, client)
+			if err != nil {
+				return err
+			}
+
+			template, err := client.TemplateByName(ctx, organization.ID, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get template by name: %w", err)
+			}
+			from, err := client.TemplateVersionByOrganizationAndName(ctx, organization.ID, template.Name, inv.Args[1])
+			if err != nil {
+				return xerrors.Errorf("get template version by name %q: %w", inv.Args[1], err)
+			}
+			to, err := client.TemplateVersionByOrganizationAndName(ctx, organization.ID, template.Name, inv.Args[2])
+			if err != nil {
+				return xerrors.Errorf("get template version by name %q: %w", inv.Args[2], err)
+			}
+
+			diff, err := client.TemplateVersionDiff(ctx, from.ID, to.ID)
+			if err != nil {
+				return xerrors.Errorf("compare template versions: %w", err)
+			}
+
+			out, err := formatter.Format(ctx, diff)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(inv.Stdout, out)
+			return err
+		},
+	}
+	orgContext.AttachOptions(cmd)
+	formatter.AttachOptions(&cmd.Options)
+
+	return cmd
+}
+
+// renderTemplateVersionDiff lists the changed entries of each section,
+// followed by the unified diffs of the changed files.
+func renderTemplateVersionDiff(diff codersdk.TemplateVersionDiff) string {
+	if diff.Empty() {
+		return "No differences."
+	}
+
+	var sb strings.Builder
+	writeEntry := func(status codersdk.TemplateVersionDiffStatus, name string, changes []string) {
+		_, _ = fmt.Fprintf(&sb, "  %-9s %s", status, name)
+		if len(changes) > 0 {
+			_, _ = fmt.Fprintf(&sb, " (%s)", strings.Join(changes, ", "))
+		}
+		_, _ = sb.WriteString("\n")
+	}
+	writeHeader := func(header string) {
+		if sb.Len() > 0 {
+			_, _ = sb.WriteString("\n")
+		}
+		_, _ = sb.WriteString(cliui.Bold(header) + "\n")
+	}
+
+	if len(diff.Files) > 0 {
+		writeHeader("Files")
+		for _, file := range diff.Files {
+			var changes []string
+			if file.Binary {
+				changes = append(changes, "binary")
+			}
+			writeEntry(file.Status, file.Path, changes)
+		}
+	}
+	if len(diff.Parameters) > 0 {
+		writeHeader("Parameters")
+		for _, parameter := range diff.Parameters {
+			writeEntry(parameter.Status, parameter.Name, parameter.Changes)
+		}
+	}
+	if len(diff.Variables) > 0 {
+		writeHeader("Variables")
+		for _, variable := range diff.Variables {
+			writeEntry(variable.Status, variable.Name, variable.Changes)
+		}
+	}
+	if len(diff.Resources) > 0 {
+		writeHeader("Resources")
+		for _, resource := range diff.Resources {
+			writeEntry(resource.Status, resource.Type+"."+resource.Name, resource.Changes)
+		}
+	}
+
+	for _, file := range diff.Files {
+		if file.Diff == "" {
+			continue
+		}
+		_, _ = sb.WriteString("\n")
+		_, _ = sb.WriteString(file.Diff)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
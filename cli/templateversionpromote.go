@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/pretty"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) promoteTemplateVersion() *serpent.Command {
+	var (
+		templateName string
+		channel      string
+	)
+	orgContext := NewOrganizationContext()
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "promote <template-version-name>",
+		Short: "Point a release channel of a template at a template version.",
+		Long: "Workspaces that follow the channel are updated to the version the next time they are updated or automatically updated. " +
+			"The channel is created if it does not exist.\n\n" + FormatExamples(
+			Example{
+				Description: "Roll out a version to workspaces that follow the canary channel",
+				Command:     "coder templates versions promote v2 --template docker --channel canary",
+			},
+		),
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(1),
+			r.InitClient(client),
+		),
+		Options: serpent.OptionSet{
+			{
+				Flag:          "template",
+				FlagShorthand: "t",
+				Description:   "Name of the template.",
+				Value:         serpent.StringOf(&templateName),
+			},
+			{
+				Flag:          "channel",
+				FlagShorthand: "c",
+				Description:   "Name of the release channel to promote the version to.",
+				Value:         serpent.StringOf(&channel),
+			},
+		},
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+			if templateName == "" {
+				return xerrors.Errorf("missing template name, specify one with --template")
+			}
+			if channel == "" {
+				return xerrors.Errorf("missing release channel, specify one with --channel")
+			}
+
+			organization, err := orgContext.Selected(inv, client)
+			if err != nil {
+				return err
+			}
+			template, err := client.TemplateByName(ctx, organization.ID, templateName)
+			if err != nil {
+				return xerrors.Errorf("get template by name: %w", err)
+			}
+			version, err := client.TemplateVersionByOrganizationAndName(ctx, organization.ID, template.Name, inv.Args[0])
+			if err != nil {
+				return xerrors.Errorf("get template version by name %q: %w", inv.Args[0], err)
+			}
+
+			releaseChannel, err := client.PromoteTemplateVersion(ctx, template.ID, channel, codersdk.PromoteTemplateVersionRequest{
+				TemplateVersionID: version.ID,
+			})
+			if err != nil {
+				return xerrors.Errorf("promote template version: %w", err)
+			}
+
+			_, _ = fmt.Fprintln(inv.Stdout, fmt.Sprintf(
+				"Version %s promoted to channel %s at %s",
+				pretty.Sprint(cliui.DefaultStyles.Keyword, version.Name),
+				pretty.Sprint(cliui.DefaultStyles.Keyword, releaseChannel.Name),
+				cliui.Timestamp(time.Now()),
+			))
+			return nil
+		},
+	}
+	orgContext.AttachOptions(cmd)
+
+	return cmd
+}
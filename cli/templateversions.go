@@ -30,7 +30,9 @@ func (r *RootCmd) templateVersions() *serpent.Command {
 		},
 		Children: []*serpent.Command{
 			r.templateVersionsList(),
+			r.templateVersionsDiff(),
 			r.archiveTemplateVersion(),
+			r.promoteTemplateVersion(),
 			r.unarchiveTemplateVersion(),
 		},
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/coder/coder/v2/cli/clitest"
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/pty/ptytest"
+	"github.com/coder/coder/v2/testutil"
 )
 
 func TestTemplateVersions(t *testing.T) {
@@ -37,4 +38,27 @@ func TestTemplateVersions(t *testing.T) {
 		pty.ExpectMatch(version.CreatedBy.Username)
 		pty.ExpectMatch("Active")
 	})
+
+	t.Run("Promote", func(t *testing.T) {
+		t.Parallel()
+		client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		owner := coderdtest.CreateFirstUser(t, client)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+		inv, root := clitest.New(t, "templates", "versions", "promote", version.Name, "--template", template.Name, "--channel", "canary")
+		clitest.SetupConfig(t, client, root)
+
+		pty := ptytest.New(t).Attach(inv)
+		clitest.Start(t, inv)
+		pty.ExpectMatch("promoted to channel")
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		channels, err := client.TemplateReleaseChannels(ctx, template.ID)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		require.Equal(t, "canary", channels[0].Name)
+		require.Equal(t, version.ID, channels[0].TemplateVersionID)
+	})
 }
@@ -3,8 +3,10 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/exp/slices"
 	"golang.org/x/xerrors"
 
@@ -22,6 +24,10 @@ func (r *RootCmd) tokens() *serpent.Command {
 				Description: "Create a token for automation",
 				Command:     "coder tokens create",
 			},
+			Example{
+				Description: "Create a token that can only read and start a single workspace",
+				Command:     "coder tokens create --scope workspace:read --scope workspace:start --resource-id 8d59b2e0-0b5e-4a0e-9b0a-1c2f3d4e5f60",
+			},
 			Example{
 				Description: "List your tokens",
 				Command:     "coder tokens ls",
@@ -48,6 +54,8 @@ func (r *RootCmd) createToken() *serpent.Command {
 	var (
 		tokenLifetime time.Duration
 		name          string
+		scopes        []string
+		resourceIDs   []string
 	)
 	client := new(codersdk.Client)
 	cmd := &serpent.Command{
@@ -58,9 +66,19 @@ func (r *RootCmd) createToken() *serpent.Command {
 			r.InitClient(client),
 		),
 		Handler: func(inv *serpent.Invocation) error {
+			allowList := make([]uuid.UUID, 0, len(resourceIDs))
+			for _, id := range resourceIDs {
+				parsed, err := uuid.Parse(id)
+				if err != nil {
+					return xerrors.Errorf("parse resource ID %q: %w", id, err)
+				}
+				allowList = append(allowList, parsed)
+			}
 			res, err := client.CreateToken(inv.Context(), codersdk.Me, codersdk.CreateTokenRequest{
 				Lifetime:  tokenLifetime,
 				TokenName: name,
+				Scopes:    scopes,
+				AllowList: allowList,
 			})
 			if err != nil {
 				return xerrors.Errorf("create tokens: %w", err)
@@ -87,6 +105,17 @@ func (r *RootCmd) createToken() *serpent.Command {
 			Description:   "Specify a human-readable name.",
 			Value:         serpent.StringOf(&name),
 		},
+		{
+			Flag: "scope",
+			Description: "Restrict the token to a permission of the form resource:action, e.g. workspace:read, workspace:start " +
+				"or template:push. Use * as the action to allow every action on the resource. Can be specified multiple times.",
+			Value: serpent.StringArrayOf(&scopes),
+		},
+		{
+			Flag:        "resource-id",
+			Description: "Restrict a token with scopes to the workspace or template with this ID. Can be specified multiple times.",
+			Value:       serpent.StringArrayOf(&resourceIDs),
+		},
 	}
 
 	return cmd
@@ -100,6 +129,7 @@ type tokenListRow struct {
 	// For table format:
 	ID        string    `json:"-" table:"id,default_sort"`
 	TokenName string    `json:"token_name" table:"name"`
+	Scopes    string    `json:"-" table:"scopes"`
 	LastUsed  time.Time `json:"-" table:"last used"`
 	ExpiresAt time.Time `json:"-" table:"expires at"`
 	CreatedAt time.Time `json:"-" table:"created at"`
@@ -107,10 +137,18 @@ type tokenListRow struct {
 }
 
 func tokenListRowFromToken(token codersdk.APIKeyWithOwner) tokenListRow {
+	scopes := string(token.Scope)
+	if len(token.Scopes) > 0 {
+		scopes = strings.Join(token.Scopes, ",")
+	}
+	if len(token.AllowList) > 0 {
+		scopes += fmt.Sprintf(" (%d resources)", len(token.AllowList))
+	}
 	return tokenListRow{
 		APIKey:    token.APIKey,
 		ID:        token.ID,
 		TokenName: token.TokenName,
+		Scopes:    scopes,
 		LastUsed:  token.LastUsed,
 		ExpiresAt: token.ExpiresAt,
 		CreatedAt: token.CreatedAt,
@@ -120,7 +158,7 @@ func tokenListRowFromToken(token codersdk.APIKeyWithOwner) tokenListRow {
 
 func (r *RootCmd) listTokens() *serpent.Command {
 	// we only display the 'owner' column if the --all argument is passed in
-	defaultCols := []string{"id", "name", "last used", "expires at", "created at"}
+	defaultCols := []string{"id", "name", "scopes", "last used", "expires at", "created at"}
 	if slices.Contains(os.Args, "-a") || slices.Contains(os.Args, "--all") {
 		defaultCols = append(defaultCols, "owner")
 	}
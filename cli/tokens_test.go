@@ -32,7 +32,7 @@ func TestTokens(t *testing.T) {
 	res := buf.String()
 	require.Contains(t, res, "tokens found")
 
-	inv, root = clitest.New(t, "tokens", "create", "--name", "token-one")
+	inv, root = clitest.New(t, "tokens", "create", "--name", "token-one", "--scope", "template:push")
 	clitest.SetupConfig(t, client, root)
 	buf = new(bytes.Buffer)
 	inv.Stdout = buf
@@ -54,6 +54,8 @@ func TestTokens(t *testing.T) {
 	require.Contains(t, res, "EXPIRES AT")
 	require.Contains(t, res, "CREATED AT")
 	require.Contains(t, res, "LAST USED")
+	require.Contains(t, res, "SCOPES")
+	require.Contains(t, res, "template:push")
 	require.Contains(t, res, id)
 
 	inv, root = clitest.New(t, "tokens", "ls", "--output=json")
@@ -67,6 +69,7 @@ func TestTokens(t *testing.T) {
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &tokens))
 	require.Len(t, tokens, 1)
 	require.Equal(t, id, tokens[0].ID)
+	require.Equal(t, []string{"template:push"}, tokens[0].Scopes)
 
 	inv, root = clitest.New(t, "tokens", "rm", "token-one")
 	clitest.SetupConfig(t, client, root)
@@ -2,17 +2,34 @@ package agentapi
 
 import (
 	"context"
+	"fmt"
 	"sync/atomic"
+	"time"
 
+	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
 	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/coderd/appearance"
+	"github.com/coder/coder/v2/coderd/autobuild"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 )
 
+// autostopBannerColor is the background color of the autostop warning banner.
+const autostopBannerColor = "#F59E0B"
+
 type AnnouncementBannerAPI struct {
 	appearanceFetcher *atomic.Pointer[appearance.Fetcher]
+
+	// Optional: when autostopWarning is set, a banner is added while the
+	// workspace is within that duration of being automatically stopped.
+	autostopWarning time.Duration
+	workspaceIDFn   func(context.Context, *database.WorkspaceAgent) (uuid.UUID, error)
+	database        database.Store
+	log             slog.Logger
 }
 
 // Deprecated: GetServiceBanner has been deprecated in favor of GetAnnouncementBanners.
@@ -33,7 +50,49 @@ func (a *AnnouncementBannerAPI) GetAnnouncementBanners(ctx context.Context, _ *p
 	for _, banner := range cfg.AnnouncementBanners {
 		banners = append(banners, agentsdk.ProtoFromBannerConfig(banner))
 	}
+	if banner := a.autostopBanner(ctx); banner != nil {
+		banners = append(banners, agentsdk.ProtoFromBannerConfig(*banner))
+	}
 	return &proto.GetAnnouncementBannersResponse{
 		AnnouncementBanners: banners,
 	}, nil
 }
+
+// autostopBanner returns a banner warning that the workspace is about to be
+// automatically stopped, or nil if it isn't. Errors are only logged, as they
+// must not prevent the other banners from being shown.
+func (a *AnnouncementBannerAPI) autostopBanner(ctx context.Context) *codersdk.BannerConfig {
+	if a.autostopWarning <= 0 {
+		return nil
+	}
+
+	workspaceID, err := a.workspaceIDFn(ctx, nil)
+	if err != nil {
+		a.log.Warn(ctx, "failed to get workspace ID for autostop banner", slog.Error(err))
+		return nil
+	}
+	build, err := a.database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspaceID)
+	if err != nil {
+		a.log.Warn(ctx, "failed to get latest workspace build for autostop banner", slog.Error(err))
+		return nil
+	}
+	if build.Transition != database.WorkspaceTransitionStart || build.Deadline.IsZero() {
+		return nil
+	}
+	remaining := time.Until(build.Deadline)
+	if remaining <= 0 || remaining > a.autostopWarning {
+		return nil
+	}
+	workspace, err := a.database.GetWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		a.log.Warn(ctx, "failed to get workspace for autostop banner", slog.Error(err))
+		return nil
+	}
+
+	return &codersdk.BannerConfig{
+		Enabled: true,
+		Message: fmt.Sprintf("This workspace will be stopped automatically in %s (at %s). Run `coder schedule override-stop %s <duration>` to keep it running.",
+			autobuild.HumanizeDuration(remaining), build.Deadline.UTC().Format(time.Kitchen+" MST"), workspace.Name),
+		BackgroundColor: autostopBannerColor,
+	}
+}
@@ -4,12 +4,20 @@ import (
 	"context"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog/sloggers/slogtest"
+
 	agentproto "github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/coderd/appearance"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
 )
@@ -51,6 +59,68 @@ func TestGetAnnouncementBanners(t *testing.T) {
 		require.ErrorIs(t, err, expectedErr)
 		require.Nil(t, resp)
 	})
+
+	t.Run("AutostopWarning", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmem.New()
+		user := dbgen.User(t, db, database.User{})
+		org := dbgen.Organization(t, db, database.Organization{})
+		tpl := dbgen.Template(t, db, database.Template{OrganizationID: org.ID, CreatedBy: user.ID})
+		tv := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+			OrganizationID: org.ID,
+			TemplateID:     uuid.NullUUID{UUID: tpl.ID, Valid: true},
+			CreatedBy:      user.ID,
+		})
+		ws := dbgen.Workspace(t, db, database.Workspace{
+			OwnerID:        user.ID,
+			OrganizationID: org.ID,
+			TemplateID:     tpl.ID,
+			Name:           "my-workspace",
+		})
+
+		var ff appearance.Fetcher = fakeFetcher{}
+		ptr := atomic.Pointer[appearance.Fetcher]{}
+		ptr.Store(&ff)
+
+		api := &AnnouncementBannerAPI{
+			appearanceFetcher: &ptr,
+			autostopWarning:   30 * time.Minute,
+			workspaceIDFn: func(context.Context, *database.WorkspaceAgent) (uuid.UUID, error) {
+				return ws.ID, nil
+			},
+			database: db,
+			log:      slogtest.Make(t, nil),
+		}
+
+		// No banner while the deadline is further away than the warning.
+		dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:       ws.ID,
+			TemplateVersionID: tv.ID,
+			BuildNumber:       1,
+			Transition:        database.WorkspaceTransitionStart,
+			Deadline:          dbtime.Now().Add(time.Hour),
+		})
+		resp, err := api.GetAnnouncementBanners(context.Background(), &agentproto.GetAnnouncementBannersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.AnnouncementBanners, 0)
+
+		// A banner is shown once the deadline is within the warning.
+		dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:       ws.ID,
+			TemplateVersionID: tv.ID,
+			BuildNumber:       2,
+			Transition:        database.WorkspaceTransitionStart,
+			Deadline:          dbtime.Now().Add(10 * time.Minute),
+		})
+		resp, err = api.GetAnnouncementBanners(context.Background(), &agentproto.GetAnnouncementBannersRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.AnnouncementBanners, 1)
+		banner := agentsdk.BannerConfigFromProto(resp.AnnouncementBanners[0])
+		require.True(t, banner.Enabled)
+		require.Contains(t, banner.Message, "will be stopped automatically in 10 minutes")
+		require.Contains(t, banner.Message, "coder schedule override-stop my-workspace")
+	})
 }
 
 type fakeFetcher struct {
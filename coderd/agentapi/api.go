@@ -73,6 +73,9 @@ type Options struct {
 	DerpMapUpdateFrequency    time.Duration
 	ExternalAuthConfigs       []*externalauth.Config
 	Experiments               codersdk.Experiments
+	// AutostopWarning is how long before the workspace's deadline to show a
+	// banner warning of its autostop. Zero disables the banner.
+	AutostopWarning time.Duration
 
 	// Optional:
 	// WorkspaceID avoids a future lookup to find the workspace ID by setting
@@ -111,6 +114,10 @@ func New(opts Options) *API {
 
 	api.AnnouncementBannerAPI = &AnnouncementBannerAPI{
 		appearanceFetcher: opts.AppearanceFetcher,
+		autostopWarning:   opts.AutostopWarning,
+		workspaceIDFn:     api.workspaceID,
+		database:          opts.Database,
+		log:               opts.Log,
 	}
 
 	api.StatsAPI = &StatsAPI{
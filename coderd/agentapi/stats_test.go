@@ -144,6 +144,12 @@ func TestUpdateStates(t *testing.T) {
 			LastUsedAt: now,
 		}).Return(nil)
 
+		// Workspace last active at gets bumped because there are sessions.
+		dbM.EXPECT().UpdateWorkspaceLastActiveAt(gomock.Any(), database.UpdateWorkspaceLastActiveAtParams{
+			ID:           workspace.ID,
+			LastActiveAt: now,
+		}).Return(nil)
+
 		// User gets fetched to hit the UpdateAgentMetricsFn.
 		dbM.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
 
@@ -240,6 +246,141 @@ func TestUpdateStates(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("IdleCPUThreshold", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			now                   = dbtime.Now()
+			dbM                   = dbmock.NewMockStore(gomock.NewController(t))
+			ps                    = pubsub.NewInMemory()
+			templateScheduleStore = schedule.MockTemplateScheduleStore{
+				GetFn: func(context.Context, database.Store, uuid.UUID) (schedule.TemplateScheduleOptions, error) {
+					return schedule.TemplateScheduleOptions{
+						IdleTimeout:      time.Hour,
+						IdleCPUThreshold: 20,
+					}, nil
+				},
+				SetFn: func(context.Context, database.Store, database.Template, schedule.TemplateScheduleOptions) (database.Template, error) {
+					panic("not implemented")
+				},
+			}
+			batcher = &workspacestatstest.StatsBatcher{}
+
+			req = &agentproto.UpdateStatsRequest{
+				Stats: &agentproto.Stats{
+					ConnectionsByProto: map[string]int64{},
+					Metrics: []*agentproto.Stats_Metric{
+						{
+							Name:  workspacestats.AgentCPUUsagePercentMetric,
+							Type:  agentproto.Stats_Metric_GAUGE,
+							Value: 45,
+						},
+					},
+				},
+			}
+		)
+		api := agentapi.StatsAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Database: dbM,
+			StatsReporter: workspacestats.NewReporter(workspacestats.ReporterOptions{
+				Database:              dbM,
+				Pubsub:                ps,
+				StatsBatcher:          batcher,
+				TemplateScheduleStore: templateScheduleStorePtr(templateScheduleStore),
+			}),
+			AgentStatsRefreshInterval: 10 * time.Second,
+			TimeNowFn: func() time.Time {
+				return now
+			},
+		}
+
+		dbM.EXPECT().GetWorkspaceByAgentID(gomock.Any(), agent.ID).Return(database.GetWorkspaceByAgentIDRow{
+			Workspace:    workspace,
+			TemplateName: template.Name,
+		}, nil)
+		dbM.EXPECT().UpdateWorkspaceLastUsedAt(gomock.Any(), database.UpdateWorkspaceLastUsedAtParams{
+			ID:         workspace.ID,
+			LastUsedAt: now,
+		}).Return(nil)
+
+		// There are no sessions, but CPU usage is above the threshold of the
+		// template so the workspace is still active.
+		dbM.EXPECT().UpdateWorkspaceLastActiveAt(gomock.Any(), database.UpdateWorkspaceLastActiveAtParams{
+			ID:           workspace.ID,
+			LastActiveAt: now,
+		}).Return(nil)
+
+		_, err := api.UpdateStats(context.Background(), req)
+		require.NoError(t, err)
+	})
+
+	t.Run("NetworkTraffic", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			now                   = dbtime.Now()
+			dbM                   = dbmock.NewMockStore(gomock.NewController(t))
+			ps                    = pubsub.NewInMemory()
+			templateScheduleStore = schedule.MockTemplateScheduleStore{
+				GetFn: func(context.Context, database.Store, uuid.UUID) (schedule.TemplateScheduleOptions, error) {
+					return schedule.TemplateScheduleOptions{
+						IdleTimeout:      time.Hour,
+						IdleCPUThreshold: 20,
+					}, nil
+				},
+				SetFn: func(context.Context, database.Store, database.Template, schedule.TemplateScheduleOptions) (database.Template, error) {
+					panic("not implemented")
+				},
+			}
+			batcher = &workspacestatstest.StatsBatcher{}
+
+			req = &agentproto.UpdateStatsRequest{
+				Stats: &agentproto.Stats{
+					ConnectionsByProto: map[string]int64{},
+					RxBytes:            2 << 20,
+					TxBytes:            2 << 20,
+				},
+			}
+		)
+		api := agentapi.StatsAPI{
+			AgentFn: func(context.Context) (database.WorkspaceAgent, error) {
+				return agent, nil
+			},
+			Database: dbM,
+			StatsReporter: workspacestats.NewReporter(workspacestats.ReporterOptions{
+				Database:              dbM,
+				Pubsub:                ps,
+				StatsBatcher:          batcher,
+				TemplateScheduleStore: templateScheduleStorePtr(templateScheduleStore),
+			}),
+			AgentStatsRefreshInterval: 10 * time.Second,
+			TimeNowFn: func() time.Time {
+				return now
+			},
+		}
+
+		dbM.EXPECT().GetWorkspaceByAgentID(gomock.Any(), agent.ID).Return(database.GetWorkspaceByAgentIDRow{
+			Workspace:    workspace,
+			TemplateName: template.Name,
+		}, nil)
+		dbM.EXPECT().UpdateWorkspaceLastUsedAt(gomock.Any(), database.UpdateWorkspaceLastUsedAtParams{
+			ID:         workspace.ID,
+			LastUsedAt: now,
+		}).Return(nil)
+
+		// There are no sessions and CPU usage is unknown, but the workspace is
+		// transferring data so it is still active.
+		dbM.EXPECT().UpdateWorkspaceLastActiveAt(gomock.Any(), database.UpdateWorkspaceLastActiveAtParams{
+			ID:           workspace.ID,
+			LastActiveAt: now,
+		}).Return(nil)
+
+		_, err := api.UpdateStats(context.Background(), req)
+		require.NoError(t, err)
+	})
+
 	t.Run("NoStats", func(t *testing.T) {
 		t.Parallel()
 
@@ -284,6 +284,39 @@ const docTemplate = `{
                 }
             }
         },
+        "/audit/verify": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Verify audit log hash chains",
+                "operationId": "verify-audit-log-hash-chains",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Chain index after which entries are verified",
+                        "name": "after_index",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.AuditLogVerification"
+                        }
+                    }
+                }
+            }
+        },
         "/authcheck": {
             "post": {
                 "security": [
@@ -809,6 +842,59 @@ const docTemplate = `{
                 }
             }
         },
+        "/extend-workspace": {
+            "get": {
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Confirm workspace deadline extension by signed token",
+                "operationId": "confirm-workspace-deadline-extension-by-signed-token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed extend token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            },
+            "post": {
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Extend workspace deadline by signed token",
+                "operationId": "extend-workspace-deadline-by-signed-token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed extend token",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "See Other"
+                    }
+                },
+                "x-apidocgen": {
+                    "skip": true
+                }
+            }
+        },
         "/external-auth": {
             "get": {
                 "security": [
@@ -1612,7 +1698,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/oauth2-provider/apps": {
+        "/notifications/templates/system": {
             "get": {
                 "security": [
                     {
@@ -1623,101 +1709,104 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
-                ],
-                "summary": "Get OAuth2 applications.",
-                "operationId": "get-oauth2-applications",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by applications authorized for a user",
-                        "name": "user_id",
-                        "in": "query"
-                    }
+                    "Notifications"
                 ],
+                "summary": "Get system notification templates",
+                "operationId": "get-system-notification-templates",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                                "$ref": "#/definitions/codersdk.NotificationTemplate"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/notifications/templates/{notification_template}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Create OAuth2 application.",
-                "operationId": "create-oauth2-application",
+                "summary": "Get notification template",
+                "operationId": "get-notification-template",
                 "parameters": [
                     {
-                        "description": "The OAuth2 application to create.",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.PostOAuth2ProviderAppRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Notification template ID",
+                        "name": "notification_template",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                            "$ref": "#/definitions/codersdk.NotificationTemplate"
                         }
                     }
                 }
-            }
-        },
-        "/oauth2-provider/apps/{app}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Get OAuth2 application.",
-                "operationId": "get-oauth2-application",
+                "summary": "Update notification template",
+                "operationId": "update-notification-template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "App ID",
-                        "name": "app",
+                        "format": "uuid",
+                        "description": "Notification template ID",
+                        "name": "notification_template",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update notification template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateNotificationTemplateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                            "$ref": "#/definitions/codersdk.NotificationTemplate"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/notifications/templates/{notification_template}/mandatory": {
             "put": {
                 "security": [
                     {
@@ -1731,25 +1820,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Update OAuth2 application.",
-                "operationId": "update-oauth2-application",
+                "summary": "Update notification template mandatory flag",
+                "operationId": "update-notification-template-mandatory-flag",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "App ID",
-                        "name": "app",
+                        "format": "uuid",
+                        "description": "Notification template ID",
+                        "name": "notification_template",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update an OAuth2 application.",
+                        "description": "Mandatory request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.PutOAuth2ProviderAppRequest"
+                            "$ref": "#/definitions/codersdk.UpdateNotificationTemplateMandatory"
                         }
                     }
                 ],
@@ -1757,75 +1847,96 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                            "$ref": "#/definitions/codersdk.NotificationTemplate"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/notifications/templates/{notification_template}/override": {
             "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Delete OAuth2 application.",
-                "operationId": "delete-oauth2-application",
+                "summary": "Reset notification template to its default",
+                "operationId": "reset-notification-template-to-its-default",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "App ID",
-                        "name": "app",
+                        "format": "uuid",
+                        "description": "Notification template ID",
+                        "name": "notification_template",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.NotificationTemplate"
+                        }
                     }
                 }
             }
         },
-        "/oauth2-provider/apps/{app}/secrets": {
-            "get": {
+        "/notifications/templates/{notification_template}/test": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Notifications"
                 ],
-                "summary": "Get OAuth2 application secrets.",
-                "operationId": "get-oauth2-application-secrets",
+                "summary": "Send test notification from template",
+                "operationId": "send-test-notification-from-template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "App ID",
-                        "name": "app",
+                        "format": "uuid",
+                        "description": "Notification template ID",
+                        "name": "notification_template",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Test notification template request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TestNotificationTemplateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.OAuth2ProviderAppSecret"
-                            }
+                            "$ref": "#/definitions/codersdk.NotificationTemplatePreview"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/oauth2-provider/apps": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -1837,15 +1948,14 @@ const docTemplate = `{
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Create OAuth2 application secret.",
-                "operationId": "create-oauth2-application-secret",
+                "summary": "Get OAuth2 applications.",
+                "operationId": "get-oauth2-applications",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "App ID",
-                        "name": "app",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by applications authorized for a user",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1854,189 +1964,1121 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.OAuth2ProviderAppSecretFull"
+                                "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/oauth2-provider/apps/{app}/secrets/{secretID}": {
-            "delete": {
+            },
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Delete OAuth2 application secret.",
-                "operationId": "delete-oauth2-application-secret",
+                "summary": "Create OAuth2 application.",
+                "operationId": "create-oauth2-application",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "App ID",
-                        "name": "app",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Secret ID",
-                        "name": "secretID",
-                        "in": "path",
-                        "required": true
+                        "description": "The OAuth2 application to create.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.PostOAuth2ProviderAppRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                        }
                     }
                 }
             }
         },
-        "/oauth2/authorize": {
-            "post": {
+        "/oauth2-provider/apps/{app}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "OAuth2 authorization request.",
-                "operationId": "oauth2-authorization-request",
+                "summary": "Get OAuth2 application.",
+                "operationId": "get-oauth2-application",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID",
-                        "name": "client_id",
-                        "in": "query",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Update OAuth2 application.",
+                "operationId": "update-oauth2-application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update an OAuth2 application.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.PutOAuth2ProviderAppRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderApp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Delete OAuth2 application.",
+                "operationId": "delete-oauth2-application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/oauth2-provider/apps/{app}/secrets": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get OAuth2 application secrets.",
+                "operationId": "get-oauth2-application-secrets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.OAuth2ProviderAppSecret"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Create OAuth2 application secret.",
+                "operationId": "create-oauth2-application-secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.OAuth2ProviderAppSecretFull"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth2-provider/apps/{app}/secrets/{secretID}": {
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Delete OAuth2 application secret.",
+                "operationId": "delete-oauth2-application-secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "App ID",
+                        "name": "app",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret ID",
+                        "name": "secretID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/oauth2/authorize": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "OAuth2 authorization request.",
+                "operationId": "oauth2-authorization-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "A random unguessable string",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "code"
+                        ],
+                        "type": "string",
+                        "description": "Response type",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Redirect here after authorization",
+                        "name": "redirect_uri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space-delimited token scopes, for example \\",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code challenge, required for public clients",
+                        "name": "code_challenge",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code challenge method, must be S256",
+                        "name": "code_challenge_method",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/oauth2/device": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "OAuth2 device verification page.",
+                "operationId": "oauth2-device-verification-page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User code shown by the device",
+                        "name": "user_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/oauth2/device/authorize": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "OAuth2 device authorization request.",
+                "operationId": "oauth2-device-authorization-request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Space-delimited token scopes. Omit for full access.",
+                        "name": "scope",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2DeviceAuthorizationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth2/register": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Register OAuth2 client.",
+                "operationId": "register-oauth2-client",
+                "parameters": [
+                    {
+                        "description": "Client metadata",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2ClientRegistrationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OAuth2ClientRegistrationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth2/tokens": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "OAuth2 token exchange.",
+                "operationId": "oauth2-token-exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID, required if grant_type=authorization_code",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client secret, required for confidential clients if grant_type=authorization_code or device_code",
+                        "name": "client_secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code, required if grant_type=authorization_code",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE code verifier, required if the authorization request had a code challenge",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device code, required if grant_type=urn:ietf:params:oauth:grant-type:device_code",
+                        "name": "device_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Refresh token, required if grant_type=refresh_token",
+                        "name": "refresh_token",
+                        "in": "formData"
+                    },
+                    {
+                        "enum": [
+                            "authorization_code",
+                            "refresh_token",
+                            "urn:ietf:params:oauth:grant-type:device_code"
+                        ],
+                        "type": "string",
+                        "description": "Grant type",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/oauth2.Token"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Delete OAuth2 application tokens.",
+                "operationId": "delete-oauth2-application-tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client ID",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get organizations",
+                "operationId": "get-organizations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.Organization"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Create organization",
+                "operationId": "create-organization",
+                "parameters": [
+                    {
+                        "description": "Create organization request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Organization"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Get organization by ID",
+                "operationId": "get-organization-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Organization"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Delete organization",
+                "operationId": "delete-organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID or name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Update organization",
+                "operationId": "update-organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID or name",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch organization request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateOrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Organization"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/groups": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get groups by organization",
+                "operationId": "get-groups-by-organization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.Group"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Create group for organization",
+                "operationId": "create-group-for-organization",
+                "parameters": [
+                    {
+                        "description": "Create group request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateGroupRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Group"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/groups/{groupName}": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Get group by organization and group name",
+                "operationId": "get-group-by-organization-and-group-name",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "A random unguessable string",
-                        "name": "state",
-                        "in": "query",
+                        "description": "Group name",
+                        "name": "groupName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Group"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/members": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "List organization members",
+                "operationId": "list-organization-members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.OrganizationMemberWithUserData"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/members/roles": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Get member roles by organization",
+                "operationId": "get-member-roles-by-organization",
+                "parameters": [
                     {
-                        "enum": [
-                            "code"
-                        ],
                         "type": "string",
-                        "description": "Response type",
-                        "name": "response_type",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
                         "required": true
-                    },
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.AssignableRoles"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Redirect here after authorization",
-                        "name": "redirect_uri",
-                        "in": "query"
-                    },
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Upsert a custom organization role",
+                "operationId": "upsert-a-custom-organization-role",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Token scopes (currently ignored)",
-                        "name": "scope",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "302": {
-                        "description": "Found"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.Role"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/oauth2/tokens": {
+        "/organizations/{organization}/members/{user}": {
             "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
+                    "Members"
                 ],
-                "summary": "OAuth2 token exchange.",
-                "operationId": "oauth2-token-exchange",
+                "summary": "Add organization member",
+                "operationId": "add-organization-member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID, required if grant_type=authorization_code",
-                        "name": "client_id",
-                        "in": "formData"
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Client secret, required if grant_type=authorization_code",
-                        "name": "client_secret",
-                        "in": "formData"
-                    },
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.OrganizationMember"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Remove organization member",
+                "operationId": "remove-organization-member",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization code, required if grant_type=authorization_code",
-                        "name": "code",
-                        "in": "formData"
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Refresh token, required if grant_type=refresh_token",
-                        "name": "refresh_token",
-                        "in": "formData"
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/organizations/{organization}/members/{user}/roles": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Members"
+                ],
+                "summary": "Assign role to organization member",
+                "operationId": "assign-role-to-organization-member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "authorization_code",
-                            "refresh_token"
-                        ],
                         "type": "string",
-                        "description": "Grant type",
-                        "name": "grant_type",
-                        "in": "formData",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update roles request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateRoles"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/oauth2.Token"
+                            "$ref": "#/definitions/codersdk.OrganizationMember"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/organizations/{organization}/members/{user}/workspaces": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "description": "Create a new workspace using a template. The request must\nspecify either the Template ID or the Template Version ID,\nnot both. If the Template ID is specified, the active version\nof the template will be used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Enterprise"
+                    "Workspaces"
                 ],
-                "summary": "Delete OAuth2 application tokens.",
-                "operationId": "delete-oauth2-application-tokens",
+                "summary": "Create user workspace by organization",
+                "operationId": "create-user-workspace-by-organization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Client ID",
-                        "name": "client_id",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username, UUID, or me",
+                        "name": "user",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Create workspace request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Workspace"
+                        }
                     }
                 }
             }
         },
-        "/organizations": {
+        "/organizations/{organization}/provisionerdaemons": {
             "get": {
                 "security": [
                     {
@@ -2047,61 +3089,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Enterprise"
+                ],
+                "summary": "Get provisioner daemons",
+                "operationId": "get-provisioner-daemons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get organizations",
-                "operationId": "get-organizations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.Organization"
+                                "$ref": "#/definitions/codersdk.ProvisionerDaemon"
                             }
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/organizations/{organization}/provisionerdaemons/serve": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Organizations"
+                    "Enterprise"
                 ],
-                "summary": "Create organization",
-                "operationId": "create-organization",
+                "summary": "Serve provisioner daemon",
+                "operationId": "serve-provisioner-daemon",
                 "parameters": [
                     {
-                        "description": "Create organization request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.CreateOrganizationRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.Organization"
-                        }
+                    "101": {
+                        "description": "Switching Protocols"
                     }
                 }
             }
         },
-        "/organizations/{organization}": {
+        "/organizations/{organization}/provisionerkeys": {
             "get": {
                 "security": [
                     {
@@ -2112,14 +3156,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Enterprise"
                 ],
-                "summary": "Get organization by ID",
-                "operationId": "get-organization-by-id",
+                "summary": "List provisioner key",
+                "operationId": "list-provisioner-key",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2130,12 +3173,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Organization"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.ProvisionerKey"
+                            }
                         }
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -2145,74 +3191,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Organizations"
+                    "Enterprise"
                 ],
-                "summary": "Delete organization",
-                "operationId": "delete-organization",
+                "summary": "Create provisioner key",
+                "operationId": "create-provisioner-key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID or name",
+                        "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Response"
+                            "$ref": "#/definitions/codersdk.CreateProvisionerKeyResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/organizations/{organization}/provisionerkeys/{provisionerkey}": {
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Organizations"
+                    "Enterprise"
                 ],
-                "summary": "Update organization",
-                "operationId": "update-organization",
+                "summary": "Delete provisioner key",
+                "operationId": "delete-provisioner-key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Organization ID or name",
+                        "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Patch organization request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateOrganizationRequest"
-                        }
+                        "type": "string",
+                        "description": "Provisioner key name",
+                        "name": "provisionerkey",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.Organization"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/organizations/{organization}/groups": {
+        "/organizations/{organization}/quota-budgets": {
             "get": {
                 "security": [
                     {
@@ -2225,8 +3262,8 @@ const docTemplate = `{
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Get groups by organization",
-                "operationId": "get-groups-by-organization",
+                "summary": "Get quota budgets by organization",
+                "operationId": "get-quota-budgets-by-organization",
                 "parameters": [
                     {
                         "type": "string",
@@ -2243,7 +3280,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.Group"
+                                "$ref": "#/definitions/codersdk.QuotaBudgetStatus"
                             }
                         }
                     }
@@ -2264,20 +3301,21 @@ const docTemplate = `{
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Create group for organization",
-                "operationId": "create-group-for-organization",
+                "summary": "Create quota budget for organization",
+                "operationId": "create-quota-budget-for-organization",
                 "parameters": [
                     {
-                        "description": "Create group request",
+                        "description": "Create quota budget request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.CreateGroupRequest"
+                            "$ref": "#/definitions/codersdk.CreateQuotaBudgetRequest"
                         }
                     },
                     {
                         "type": "string",
+                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2288,27 +3326,65 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Group"
+                            "$ref": "#/definitions/codersdk.QuotaBudget"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/groups/{groupName}": {
-            "get": {
+        "/organizations/{organization}/quota-budgets/{budget}": {
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "Delete quota budget",
+                "operationId": "delete-quota-budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Organization ID",
+                        "name": "organization",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Budget ID",
+                        "name": "budget",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            },
+            "patch": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Get group by organization and group name",
-                "operationId": "get-group-by-organization-and-group-name",
+                "summary": "Update quota budget",
+                "operationId": "update-quota-budget",
                 "parameters": [
                     {
                         "type": "string",
@@ -2320,23 +3396,33 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Group name",
-                        "name": "groupName",
+                        "format": "uuid",
+                        "description": "Budget ID",
+                        "name": "budget",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update quota budget request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateQuotaBudgetRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Group"
+                            "$ref": "#/definitions/codersdk.QuotaBudget"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/members": {
+        "/organizations/{organization}/templates": {
             "get": {
                 "security": [
                     {
@@ -2347,13 +3433,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "List organization members",
-                "operationId": "list-organization-members",
+                "summary": "Get templates by organization",
+                "operationId": "get-templates-by-organization",
                 "parameters": [
                     {
                         "type": "string",
+                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2366,32 +3453,41 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.OrganizationMemberWithUserData"
+                                "$ref": "#/definitions/codersdk.Template"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/organizations/{organization}/members/roles": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "Get member roles by organization",
-                "operationId": "get-member-roles-by-organization",
+                "summary": "Create template by organization",
+                "operationId": "create-template-by-organization",
                 "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.CreateTemplateRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2402,15 +3498,14 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.AssignableRoles"
-                            }
+                            "$ref": "#/definitions/codersdk.Template"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/organizations/{organization}/templates/examples": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -2420,10 +3515,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "Upsert a custom organization role",
-                "operationId": "upsert-a-custom-organization-role",
+                "summary": "Get template examples by organization",
+                "operationId": "get-template-examples-by-organization",
                 "parameters": [
                     {
                         "type": "string",
@@ -2440,15 +3535,15 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.Role"
+                                "$ref": "#/definitions/codersdk.TemplateExample"
                             }
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/members/{user}": {
-            "post": {
+        "/organizations/{organization}/templates/{templatename}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -2458,13 +3553,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "Add organization member",
-                "operationId": "add-organization-member",
+                "summary": "Get templates by organization and template name",
+                "operationId": "get-templates-by-organization-and-template-name",
                 "parameters": [
                     {
                         "type": "string",
+                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2472,8 +3568,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID, name, or me",
-                        "name": "user",
+                        "description": "Template name",
+                        "name": "templatename",
                         "in": "path",
                         "required": true
                     }
@@ -2482,25 +3578,31 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.OrganizationMember"
+                            "$ref": "#/definitions/codersdk.Template"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/organizations/{organization}/templates/{templatename}/versions/{templateversionname}": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "Remove organization member",
-                "operationId": "remove-organization-member",
+                "summary": "Get template version by organization, template, and name",
+                "operationId": "get-template-version-by-organization-template-and-name",
                 "parameters": [
                     {
                         "type": "string",
+                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2508,40 +3610,48 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID, name, or me",
-                        "name": "user",
+                        "description": "Template name",
+                        "name": "templatename",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template version name",
+                        "name": "templateversionname",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateVersion"
+                        }
                     }
                 }
             }
         },
-        "/organizations/{organization}/members/{user}/roles": {
-            "put": {
+        "/organizations/{organization}/templates/{templatename}/versions/{templateversionname}/previous": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Members"
+                    "Templates"
                 ],
-                "summary": "Assign role to organization member",
-                "operationId": "assign-role-to-organization-member",
+                "summary": "Get previous template version by organization, template, and name",
+                "operationId": "get-previous-template-version-by-organization-template-and-name",
                 "parameters": [
                     {
                         "type": "string",
+                        "format": "uuid",
                         "description": "Organization ID",
                         "name": "organization",
                         "in": "path",
@@ -2549,39 +3659,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID, name, or me",
-                        "name": "user",
+                        "description": "Template name",
+                        "name": "templatename",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update roles request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.UpdateRoles"
-                        }
+                        "type": "string",
+                        "description": "Template version name",
+                        "name": "templateversionname",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.OrganizationMember"
+                            "$ref": "#/definitions/codersdk.TemplateVersion"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/members/{user}/workspaces": {
+        "/organizations/{organization}/templateversions": {
             "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "description": "Create a new workspace using a template. The request must\nspecify either the Template ID or the Template Version ID,\nnot both. If the Template ID is specified, the active version\nof the template will be used.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2589,10 +3696,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Workspaces"
+                    "Templates"
                 ],
-                "summary": "Create user workspace by organization",
-                "operationId": "create-user-workspace-by-organization",
+                "summary": "Create template version by organization",
+                "operationId": "create-template-version-by-organization",
                 "parameters": [
                     {
                         "type": "string",
@@ -2603,33 +3710,26 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Username, UUID, or me",
-                        "name": "user",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Create workspace request",
+                        "description": "Create template version request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.CreateWorkspaceRequest"
+                            "$ref": "#/definitions/codersdk.CreateTemplateVersionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Workspace"
+                            "$ref": "#/definitions/codersdk.TemplateVersion"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/provisionerdaemons": {
+        "/regions": {
             "get": {
                 "security": [
                     {
@@ -2640,63 +3740,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Enterprise"
-                ],
-                "summary": "Get provisioner daemons",
-                "operationId": "get-provisioner-daemons",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    }
+                    "WorkspaceProxies"
                 ],
+                "summary": "Get site-wide regions for workspace connections",
+                "operationId": "get-site-wide-regions-for-workspace-connections",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.ProvisionerDaemon"
-                            }
+                            "$ref": "#/definitions/codersdk.RegionsResponse-codersdk_Region"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/provisionerdaemons/serve": {
-            "get": {
-                "security": [
-                    {
-                        "CoderSessionToken": []
-                    }
-                ],
-                "tags": [
-                    "Enterprise"
-                ],
-                "summary": "Serve provisioner daemon",
-                "operationId": "serve-provisioner-daemon",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "101": {
-                        "description": "Switching Protocols"
-                    }
-                }
-            }
-        },
-        "/organizations/{organization}/provisionerkeys": {
+        "/replicas": {
             "get": {
                 "security": [
                     {
@@ -2709,98 +3767,22 @@ const docTemplate = `{
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "List provisioner key",
-                "operationId": "list-provisioner-key",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
+                "summary": "Get active replicas",
+                "operationId": "get-active-replicas",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/codersdk.ProvisionerKey"
+                                "$ref": "#/definitions/codersdk.Replica"
                             }
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "CoderSessionToken": []
-                    }
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Enterprise"
-                ],
-                "summary": "Create provisioner key",
-                "operationId": "create-provisioner-key",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.CreateProvisionerKeyResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/organizations/{organization}/provisionerkeys/{provisionerkey}": {
-            "delete": {
-                "security": [
-                    {
-                        "CoderSessionToken": []
-                    }
-                ],
-                "tags": [
-                    "Enterprise"
-                ],
-                "summary": "Delete provisioner key",
-                "operationId": "delete-provisioner-key",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Provisioner key name",
-                        "name": "provisionerkey",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    }
-                }
             }
         },
-        "/organizations/{organization}/templates": {
+        "/scim/v2/Groups": {
             "get": {
                 "security": [
                     {
@@ -2808,32 +3790,42 @@ const docTemplate = `{
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Get templates by organization",
-                "operationId": "get-templates-by-organization",
+                "summary": "SCIM 2.0: Get groups",
+                "operationId": "scim-get-groups",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
+                        "description": "SCIM filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based index of the first result",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to members to omit group members",
+                        "name": "excludedAttributes",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.Template"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             },
@@ -2843,46 +3835,36 @@ const docTemplate = `{
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Create template by organization",
-                "operationId": "create-template-by-organization",
+                "summary": "SCIM 2.0: Create new group",
+                "operationId": "scim-create-new-group",
                 "parameters": [
                     {
-                        "description": "Request body",
+                        "description": "New group",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.CreateTemplateRequest"
+                            "$ref": "#/definitions/coderd.SCIMGroup"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Template"
+                            "$ref": "#/definitions/coderd.SCIMGroup"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/templates/examples": {
+        "/scim/v2/Groups/{id}": {
             "get": {
                 "security": [
                     {
@@ -2890,19 +3872,19 @@ const docTemplate = `{
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Get template examples by organization",
-                "operationId": "get-template-examples-by-organization",
+                "summary": "SCIM 2.0: Get group by ID",
+                "operationId": "scim-get-group-by-id",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
+                        "description": "Group ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -2911,203 +3893,149 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.TemplateExample"
-                            }
+                            "$ref": "#/definitions/coderd.SCIMGroup"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found"
                     }
                 }
-            }
-        },
-        "/organizations/{organization}/templates/{templatename}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Get templates by organization and template name",
-                "operationId": "get-templates-by-organization-and-template-name",
+                "summary": "SCIM 2.0: Replace group",
+                "operationId": "scim-replace-group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
+                        "description": "Group ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Template name",
-                        "name": "templatename",
-                        "in": "path",
-                        "required": true
+                        "description": "Replace group request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/coderd.SCIMGroup"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.Template"
+                            "$ref": "#/definitions/coderd.SCIMGroup"
                         }
                     }
                 }
-            }
-        },
-        "/organizations/{organization}/templates/{templatename}/versions/{templateversionname}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Get template version by organization, template, and name",
-                "operationId": "get-template-version-by-organization-template-and-name",
+                "summary": "SCIM 2.0: Delete group",
+                "operationId": "scim-delete-group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Template name",
-                        "name": "templatename",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Template version name",
-                        "name": "templateversionname",
+                        "description": "Group ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.TemplateVersion"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            }
-        },
-        "/organizations/{organization}/templates/{templatename}/versions/{templateversionname}/previous": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
+                    "Enterprise"
                 ],
-                "summary": "Get previous template version by organization, template, and name",
-                "operationId": "get-previous-template-version-by-organization-template-and-name",
+                "summary": "SCIM 2.0: Update group",
+                "operationId": "scim-update-group",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Template name",
-                        "name": "templatename",
+                        "description": "Group ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Template version name",
-                        "name": "templateversionname",
-                        "in": "path",
-                        "required": true
+                        "description": "Patch group request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/coderd.SCIMPatchOp"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.TemplateVersion"
+                            "$ref": "#/definitions/coderd.SCIMGroup"
                         }
                     }
                 }
             }
         },
-        "/organizations/{organization}/templateversions": {
-            "post": {
+        "/scim/v2/Schemas": {
+            "get": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "Templates"
-                ],
-                "summary": "Create template version by organization",
-                "operationId": "create-template-version-by-organization",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Organization ID",
-                        "name": "organization",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Create template version request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.CreateTemplateVersionRequest"
-                        }
-                    }
+                    "Enterprise"
                 ],
+                "summary": "SCIM 2.0: Get schemas",
+                "operationId": "scim-get-schemas",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.TemplateVersion"
-                        }
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/regions": {
+        "/scim/v2/Schemas/{id}": {
             "get": {
                 "security": [
                     {
@@ -3115,24 +4043,33 @@ const docTemplate = `{
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
-                    "WorkspaceProxies"
+                    "Enterprise"
+                ],
+                "summary": "SCIM 2.0: Get schema by ID",
+                "operationId": "scim-get-schema-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Schema URN",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get site-wide regions for workspace connections",
-                "operationId": "get-site-wide-regions-for-workspace-connections",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/codersdk.RegionsResponse-codersdk_Region"
-                        }
+                        "description": "OK"
+                    },
+                    "404": {
+                        "description": "Not Found"
                     }
                 }
             }
         },
-        "/replicas": {
+        "/scim/v2/ServiceProviderConfig": {
             "get": {
                 "security": [
                     {
@@ -3140,22 +4077,16 @@ const docTemplate = `{
                     }
                 ],
                 "produces": [
-                    "application/json"
+                    "application/scim+json"
                 ],
                 "tags": [
                     "Enterprise"
                 ],
-                "summary": "Get active replicas",
-                "operationId": "get-active-replicas",
+                "summary": "SCIM 2.0: Service provider config",
+                "operationId": "scim-get-service-provider-config",
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/codersdk.Replica"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
@@ -3164,17 +4095,37 @@ const docTemplate = `{
             "get": {
                 "security": [
                     {
-                        "CoderSessionToken": []
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/scim+json"
+                ],
+                "tags": [
+                    "Enterprise"
+                ],
+                "summary": "SCIM 2.0: Get users",
+                "operationId": "scim-get-users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SCIM filter",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "1-based index of the first result",
+                        "name": "startIndex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results",
+                        "name": "count",
+                        "in": "query"
                     }
                 ],
-                "produces": [
-                    "application/scim+json"
-                ],
-                "tags": [
-                    "Enterprise"
-                ],
-                "summary": "SCIM 2.0: Get users",
-                "operationId": "scim-get-users",
                 "responses": {
                     "200": {
                         "description": "OK"
@@ -3242,6 +4193,12 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/coderd.SCIMUser"
+                        }
+                    },
                     "404": {
                         "description": "Not Found"
                     }
@@ -3540,6 +4497,98 @@ const docTemplate = `{
                 }
             }
         },
+        "/templates/{template}/channels": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get template release channels",
+                "operationId": "get-template-release-channels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.TemplateReleaseChannel"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/channels/{channel}": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Promote template version to release channel",
+                "operationId": "promote-template-version-to-release-channel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release channel name",
+                        "name": "channel",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promote template version request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.PromoteTemplateVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateReleaseChannel"
+                        }
+                    }
+                }
+            }
+        },
         "/templates/{template}/daus": {
             "get": {
                 "security": [
@@ -3553,8 +4602,183 @@ const docTemplate = `{
                 "tags": [
                     "Templates"
                 ],
-                "summary": "Get template DAUs by ID",
-                "operationId": "get-template-daus-by-id",
+                "summary": "Get template DAUs by ID",
+                "operationId": "get-template-daus-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.DAUsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/git-sync": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get template Git sync",
+                "operationId": "get-template-git-sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateGitSync"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Update template Git sync",
+                "operationId": "update-template-git-sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update template Git sync request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateTemplateGitSyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateGitSync"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Delete template Git sync",
+                "operationId": "delete-template-git-sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/templates/{template}/git-sync/sync": {
+            "post": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Sync template from Git",
+                "operationId": "sync-template-from-git",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template ID",
+                        "name": "template",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateGitSync"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates/{template}/git-sync/webhook": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Receive template Git sync webhook",
+                "operationId": "receive-template-git-sync-webhook",
                 "parameters": [
                     {
                         "type": "string",
@@ -3566,10 +4790,10 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.DAUsResponse"
+                            "$ref": "#/definitions/codersdk.Response"
                         }
                     }
                 }
@@ -3925,6 +5149,49 @@ const docTemplate = `{
                 }
             }
         },
+        "/templateversions/{templateversion}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Compare template versions",
+                "operationId": "compare-template-versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID",
+                        "name": "templateversion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Template version ID to compare against",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiff"
+                        }
+                    }
+                }
+            }
+        },
         "/templateversions/{templateversion}/dry-run": {
             "post": {
                 "security": [
@@ -5302,39 +6569,289 @@ const docTemplate = `{
                         "name": "user",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "format": "uuid",
-                        "description": "Key ID",
-                        "name": "keyid",
-                        "in": "path",
-                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Key ID",
+                        "name": "keyid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/users/{user}/login-type": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user login type",
+                "operationId": "get-user-login-type",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UserLoginType"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/inbox": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "List user inbox notifications",
+                "operationId": "list-user-inbox-notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return unread notifications",
+                        "name": "unread_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only return notifications created before this time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ListInboxNotificationsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/inbox/mark-all-read": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all inbox notifications as read",
+                "operationId": "mark-all-inbox-notifications-as-read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/inbox/watch": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Watch user inbox notifications",
+                "operationId": "watch-user-inbox-notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/inbox/{inbox_notification}/read-status": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update inbox notification read status",
+                "operationId": "update-inbox-notification-read-status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Inbox notification ID",
+                        "name": "inbox_notification",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Read status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateInboxNotificationReadStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.InboxNotification"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{user}/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get user notification preferences",
+                "operationId": "get-user-notification-preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID, name, or me",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
+                        }
                     }
                 }
-            }
-        },
-        "/users/{user}/login-type": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "CoderSessionToken": []
                     }
                 ],
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Notifications"
                 ],
-                "summary": "Get user login type",
-                "operationId": "get-user-login-type",
+                "summary": "Update user notification preferences",
+                "operationId": "update-user-notification-preferences",
                 "parameters": [
+                    {
+                        "description": "Preferences",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateUserNotificationPreferences"
+                        }
+                    },
                     {
                         "type": "string",
                         "description": "User ID, name, or me",
@@ -5347,7 +6864,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.UserLoginType"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.NotificationPreference"
+                            }
                         }
                     }
                 }
@@ -7275,6 +8795,86 @@ const docTemplate = `{
                 }
             }
         },
+        "/workspaces/{workspace}/acl": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get workspace ACL",
+                "operationId": "get-workspace-acl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceACL"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Update workspace ACL",
+                "operationId": "update-workspace-acl",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update workspace ACL request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceACL"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.Response"
+                        }
+                    }
+                }
+            }
+        },
         "/workspaces/{workspace}/autostart": {
             "put": {
                 "security": [
@@ -7695,7 +9295,160 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "delete": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PortSharing"
+                ],
+                "summary": "Get workspace agent port shares",
+                "operationId": "get-workspace-agent-port-shares",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delete port sharing level request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspace}/release-channel": {
+            "put": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Update workspace release channel by ID",
+                "operationId": "update-workspace-release-channel-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release channel request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.UpdateWorkspaceReleaseChannelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspace}/resolve-autostart": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Resolve workspace autostart by id.",
+                "operationId": "resolve-workspace-autostart-by-id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{workspace}/snapshots": {
+            "get": {
+                "security": [
+                    {
+                        "CoderSessionToken": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Workspaces"
+                ],
+                "summary": "Get workspace snapshots",
+                "operationId": "get-workspace-snapshots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Workspace ID",
+                        "name": "workspace",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/codersdk.WorkspaceSnapshot"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7704,11 +9457,14 @@ const docTemplate = `{
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "PortSharing"
+                    "Workspaces"
                 ],
-                "summary": "Get workspace agent port shares",
-                "operationId": "get-workspace-agent-port-shares",
+                "summary": "Create workspace snapshot",
+                "operationId": "create-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -7719,24 +9475,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Delete port sharing level request",
+                        "description": "Create workspace snapshot request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/codersdk.DeleteWorkspaceAgentPortShareRequest"
+                            "$ref": "#/definitions/codersdk.CreateWorkspaceSnapshotRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/codersdk.WorkspaceSnapshot"
+                        }
                     }
                 }
             }
         },
-        "/workspaces/{workspace}/resolve-autostart": {
-            "get": {
+        "/workspaces/{workspace}/snapshots/{snapshot}/restore": {
+            "post": {
                 "security": [
                     {
                         "CoderSessionToken": []
@@ -7748,8 +9507,8 @@ const docTemplate = `{
                 "tags": [
                     "Workspaces"
                 ],
-                "summary": "Resolve workspace autostart by id.",
-                "operationId": "resolve-workspace-autostart-by-id",
+                "summary": "Restore workspace snapshot",
+                "operationId": "restore-workspace-snapshot",
                 "parameters": [
                     {
                         "type": "string",
@@ -7758,13 +9517,21 @@ const docTemplate = `{
                         "name": "workspace",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Snapshot ID",
+                        "name": "snapshot",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/codersdk.ResolveAutostartResponse"
+                            "$ref": "#/definitions/codersdk.WorkspaceBuild"
                         }
                     }
                 }
@@ -8016,6 +9783,84 @@ const docTemplate = `{
                 }
             }
         },
+        "coderd.SCIMGroup": {
+            "type": "object",
+            "properties": {
+                "displayName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/coderd.SCIMGroupMember"
+                    }
+                },
+                "meta": {
+                    "type": "object",
+                    "properties": {
+                        "resourceType": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "coderd.SCIMGroupMember": {
+            "type": "object",
+            "properties": {
+                "display": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value is the ID of the user.",
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "coderd.SCIMPatchOp": {
+            "type": "object",
+            "properties": {
+                "Operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/coderd.SCIMPatchOperation"
+                    }
+                },
+                "schemas": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "coderd.SCIMPatchOperation": {
+            "type": "object",
+            "properties": {
+                "op": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "coderd.SCIMUser": {
             "type": "object",
             "properties": {
@@ -8121,6 +9966,14 @@ const docTemplate = `{
                 "user_id"
             ],
             "properties": {
+                "allow_list": {
+                    "description": "AllowList are the IDs of the resources the key is restricted to.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
                 "created_at": {
                     "type": "string",
                     "format": "date-time"
@@ -8163,6 +10016,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "scopes": {
+                    "description": "Scopes are the permissions the key is restricted to. See\nCreateTokenRequest.Scopes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "token_name": {
                     "type": "string"
                 },
@@ -8412,6 +10272,75 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.AuditLogChain": {
+            "type": "object",
+            "properties": {
+                "from_index": {
+                    "description": "FromIndex and ToIndex are the inclusive range of chain indexes that\nwere verified.",
+                    "type": "integer"
+                },
+                "hash": {
+                    "description": "Hash is the hex encoded hash of the last entry in the chain.",
+                    "type": "string"
+                },
+                "length": {
+                    "description": "Length is the number of entries in the chain.",
+                    "type": "integer"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.AuditLogChainProblem"
+                    }
+                },
+                "to_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "codersdk.AuditLogChainProblem": {
+            "type": "object",
+            "properties": {
+                "audit_log_id": {
+                    "description": "AuditLogID is the modified entry. It's empty for gaps.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "from_index": {
+                    "description": "FromIndex and ToIndex are the inclusive range of chain indexes the\nproblem applies to.",
+                    "type": "integer"
+                },
+                "to_index": {
+                    "type": "integer"
+                },
+                "type": {
+                    "enum": [
+                        "gap",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.AuditLogChainProblemType"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.AuditLogChainProblemType": {
+            "type": "string",
+            "enum": [
+                "gap",
+                "modified"
+            ],
+            "x-enum-varnames": [
+                "AuditLogChainProblemGap",
+                "AuditLogChainProblemModified"
+            ]
+        },
         "codersdk.AuditLogResponse": {
             "type": "object",
             "properties": {
@@ -8426,6 +10355,100 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.AuditLogVerification": {
+            "type": "object",
+            "properties": {
+                "chains": {
+                    "description": "Chains are the chains with entries after the requested index.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.AuditLogChain"
+                    }
+                },
+                "valid": {
+                    "description": "Valid is true if no chain has a problem in the verified range.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.AuditLoggingConfig": {
+            "type": "object",
+            "properties": {
+                "chain_key": {
+                    "description": "The secret used to sign the hash chain of the audit logs.",
+                    "type": "string"
+                },
+                "http": {
+                    "description": "HTTP settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.AuditLoggingHTTPConfig"
+                        }
+                    ]
+                },
+                "syslog": {
+                    "description": "Syslog settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.AuditLoggingSyslogConfig"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.AuditLoggingHTTPConfig": {
+            "type": "object",
+            "properties": {
+                "batch_size": {
+                    "description": "The maximum number of audit logs sent in a request.",
+                    "type": "integer"
+                },
+                "buffer_directory": {
+                    "description": "The directory in which audit logs are buffered until they are sent.",
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "The endpoint to which batches of audit logs are posted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                },
+                "flush_interval": {
+                    "description": "How often buffered audit logs are sent.",
+                    "type": "integer"
+                },
+                "headers": {
+                    "description": "Headers added to every request, in the \"Name: Value\" format.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "codersdk.AuditLoggingSyslogConfig": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "The address of the syslog server, with a udp, tcp or tls scheme.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                },
+                "ca_file": {
+                    "description": "The CA certificate used to verify the server with the tls scheme.",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "The format of the message of each syslog entry.",
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.AuthMethod": {
             "type": "object",
             "properties": {
@@ -8588,12 +10611,14 @@ const docTemplate = `{
             "enum": [
                 "initiator",
                 "autostart",
-                "autostop"
+                "autostop",
+                "idle"
             ],
             "x-enum-varnames": [
                 "BuildReasonInitiator",
                 "BuildReasonAutostart",
-                "BuildReasonAutostop"
+                "BuildReasonAutostop",
+                "BuildReasonIdle"
             ]
         },
         "codersdk.ConnectionLatency": {
@@ -8745,6 +10770,45 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateQuotaBudgetRequest": {
+            "type": "object",
+            "required": [
+                "period"
+            ],
+            "properties": {
+                "group_id": {
+                    "description": "Exactly one of GroupID and UserID must be set.",
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "hard_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "period": {
+                    "enum": [
+                        "day",
+                        "month"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.QuotaBudgetPeriod"
+                        }
+                    ]
+                },
+                "soft_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stop_workspaces": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.CreateTemplateRequest": {
             "type": "object",
             "required": [
@@ -8975,6 +11039,14 @@ const docTemplate = `{
         "codersdk.CreateTokenRequest": {
             "type": "object",
             "properties": {
+                "allow_list": {
+                    "description": "AllowList restricts a token with scopes to the resources with these\nIDs, such as a workspace or a template.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
                 "lifetime": {
                     "type": "integer"
                 },
@@ -8989,6 +11061,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "scopes": {
+                    "description": "Scopes restrict the token to permissions of the form \"resource:action\",\ne.g. \"workspace:read\" or \"workspace:start\". The action may be \"*\" to\nallow every action on the resource, and \"template:push\" allows\ncreating and updating templates. Scopes cannot be combined with the\napplication_connect scope.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "token_name": {
                     "type": "string"
                 }
@@ -9143,6 +11222,21 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.CreateWorkspaceSnapshotRequest": {
+            "type": "object",
+            "properties": {
+                "build_number": {
+                    "description": "BuildNumber is the workspace build to snapshot. Defaults to the latest\nbuild.",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "description": "Name must be unique within the workspace. A random name is generated if\nit is empty.",
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
         "codersdk.DAUEntry": {
             "type": "object",
             "properties": {
@@ -9322,6 +11416,9 @@ const docTemplate = `{
                 "allow_workspace_renames": {
                     "type": "boolean"
                 },
+                "audit_logging": {
+                    "$ref": "#/definitions/codersdk.AuditLoggingConfig"
+                },
                 "autobuild_poll_interval": {
                     "type": "integer"
                 },
@@ -9470,6 +11567,9 @@ const docTemplate = `{
                 "telemetry": {
                     "$ref": "#/definitions/codersdk.TelemetryConfig"
                 },
+                "template_policy_rules": {
+                    "$ref": "#/definitions/serpent.Struct-array_codersdk_TemplatePolicyRule"
+                },
                 "terms_of_service_url": {
                     "type": "string"
                 },
@@ -9902,6 +12002,51 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.InboxNotification": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.InboxNotificationAction"
+                    }
+                },
+                "content": {
+                    "description": "Content is the body of the notification, formatted as Markdown.",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "read_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.InboxNotificationAction": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.InsightsReportInterval": {
             "type": "string",
             "enum": [
@@ -9966,10 +12111,12 @@ const docTemplate = `{
         "codersdk.JobErrorCode": {
             "type": "string",
             "enum": [
-                "REQUIRED_TEMPLATE_VARIABLES"
+                "REQUIRED_TEMPLATE_VARIABLES",
+                "TEMPLATE_POLICY_VIOLATION"
             ],
             "x-enum-varnames": [
-                "RequiredTemplateVariables"
+                "RequiredTemplateVariables",
+                "TemplatePolicyViolation"
             ]
         },
         "codersdk.License": {
@@ -10012,6 +12159,20 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.ListInboxNotificationsResponse": {
+            "type": "object",
+            "properties": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.InboxNotification"
+                    }
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
         "codersdk.LogLevel": {
             "type": "string",
             "enum": [
@@ -10147,9 +12308,113 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.NotificationPreference": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "method": {
+                    "description": "Method is the delivery method chosen by the user. If empty, the deployment's default method is used.",
+                    "type": "string"
+                },
+                "notification_template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "codersdk.NotificationTemplate": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "string"
+                },
+                "body_template": {
+                    "type": "string"
+                },
+                "customized": {
+                    "description": "Customized is true when an administrator has overridden the template's title, body, or actions. The template\nfields always hold the templates in use.",
+                    "type": "boolean"
+                },
+                "digest": {
+                    "description": "Digest templates are delivered as a single message per user when the deployment has a digest window configured.",
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "mandatory": {
+                    "description": "Mandatory templates cannot be disabled by users.",
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "title_template": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.NotificationTemplateAction": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.NotificationTemplatePreview": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.NotificationTemplateAction"
+                    }
+                },
+                "body": {
+                    "description": "Body is formatted as Markdown.",
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "Labels are the labels the template was rendered with, including sample values.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.NotificationsConfig": {
             "type": "object",
             "properties": {
+                "autostop_warning": {
+                    "description": "How long before a workspace's autostop deadline to warn its owner. Zero disables the warning.",
+                    "type": "integer"
+                },
+                "dedupe_window": {
+                    "description": "Identical messages enqueued for the same user within this window are only delivered once. Zero disables deduplication.",
+                    "type": "integer"
+                },
+                "digest_window": {
+                    "description": "How long messages of digest templates are held back so that they can be delivered to each user as a single\nmessage. Zero disables digests.",
+                    "type": "integer"
+                },
                 "dispatch_timeout": {
                     "description": "How long to wait while a notification is being sent before giving up.",
                     "type": "integer"
@@ -10179,13 +12444,25 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "method": {
-                    "description": "Which delivery method to use (available options: 'smtp', 'webhook').",
+                    "description": "Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').",
                     "type": "string"
                 },
+                "per_user_rate_limit": {
+                    "description": "The maximum number of notifications delivered to a single user per hour. Zero disables the limit.",
+                    "type": "integer"
+                },
                 "retry_interval": {
                     "description": "The minimum time between retries.",
                     "type": "integer"
                 },
+                "slack": {
+                    "description": "Slack settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.NotificationsSlackConfig"
+                        }
+                    ]
+                },
                 "sync_buffer_size": {
                     "description": "The notifications system buffers message updates in memory to ease pressure on the database.\nThis option controls how many updates are kept in memory. The lower this value the\nlower the change of state inconsistency in a non-graceful shutdown - but it also increases load on the\ndatabase. It is recommended to keep this option at its default value.",
                     "type": "integer"
@@ -10194,6 +12471,14 @@ const docTemplate = `{
                     "description": "The notifications system buffers message updates in memory to ease pressure on the database.\nThis option controls how often it synchronizes its state with the database. The shorter this value the\nlower the change of state inconsistency in a non-graceful shutdown - but it also increases load on the\ndatabase. It is recommended to keep this option at its default value.",
                     "type": "integer"
                 },
+                "teams": {
+                    "description": "Microsoft Teams settings.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.NotificationsTeamsConfig"
+                        }
+                    ]
+                },
                 "webhook": {
                     "description": "Webhook settings.",
                     "allOf": [
@@ -10285,57 +12570,182 @@ const docTemplate = `{
                     "description": "KeyFile specifies the location of the key to use.",
                     "type": "string"
                 },
-                "server_name": {
-                    "description": "ServerName to verify the hostname for the targets.",
+                "server_name": {
+                    "description": "ServerName to verify the hostname for the targets.",
+                    "type": "string"
+                },
+                "start_tls": {
+                    "description": "StartTLS attempts to upgrade plain connections to TLS.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.NotificationsSettings": {
+            "type": "object",
+            "properties": {
+                "notifier_paused": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.NotificationsSlackConfig": {
+            "type": "object",
+            "properties": {
+                "bot_token": {
+                    "description": "The Slack bot token used to send direct messages to users, who are looked up by their email address.\nIf set, this takes precedence over the incoming webhook.",
+                    "type": "string"
+                },
+                "endpoint": {
+                    "description": "The Slack incoming webhook URL to which messages will be posted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.NotificationsTeamsConfig": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "The Microsoft Teams incoming webhook URL to which Adaptive Cards will be posted.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.NotificationsWebhookConfig": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "description": "The URL to which the payload will be sent with an HTTP POST request.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/serpent.URL"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.OAuth2AppEndpoints": {
+            "type": "object",
+            "properties": {
+                "authorization": {
+                    "type": "string"
+                },
+                "device_authorization": {
+                    "description": "DeviceAuth is optional.",
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.OAuth2ClientRegistrationRequest": {
+            "type": "object",
+            "required": [
+                "client_name"
+            ],
+            "properties": {
+                "client_name": {
+                    "type": "string"
+                },
+                "grant_types": {
+                    "description": "GrantTypes defaults to authorization_code and refresh_token. The client\ncan only use the grant types it registered with.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.OAuth2ProviderGrantType"
+                    }
+                },
+                "logo_uri": {
+                    "type": "string"
+                },
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_endpoint_auth_method": {
+                    "description": "TokenEndpointAuthMethod is \"none\" for public clients, or\n\"client_secret_post\" (the default) for confidential clients.",
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.OAuth2ClientRegistrationResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "client_id_issued_at": {
+                    "type": "integer"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "type": "string"
+                },
+                "client_secret_expires_at": {
+                    "type": "integer"
+                },
+                "grant_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.OAuth2ProviderGrantType"
+                    }
+                },
+                "logo_uri": {
                     "type": "string"
                 },
-                "start_tls": {
-                    "description": "StartTLS attempts to upgrade plain connections to TLS.",
-                    "type": "boolean"
-                }
-            }
-        },
-        "codersdk.NotificationsSettings": {
-            "type": "object",
-            "properties": {
-                "notifier_paused": {
-                    "type": "boolean"
+                "redirect_uris": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token_endpoint_auth_method": {
+                    "type": "string"
                 }
             }
         },
-        "codersdk.NotificationsWebhookConfig": {
+        "codersdk.OAuth2Config": {
             "type": "object",
             "properties": {
-                "endpoint": {
-                    "description": "The URL to which the payload will be sent with an HTTP POST request.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/serpent.URL"
-                        }
-                    ]
+                "github": {
+                    "$ref": "#/definitions/codersdk.OAuth2GithubConfig"
                 }
             }
         },
-        "codersdk.OAuth2AppEndpoints": {
+        "codersdk.OAuth2DeviceAuthorizationResponse": {
             "type": "object",
             "properties": {
-                "authorization": {
+                "device_code": {
                     "type": "string"
                 },
-                "device_authorization": {
-                    "description": "DeviceAuth is optional.",
+                "expires_in": {
+                    "description": "ExpiresIn is the lifetime of the device code in seconds.",
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "Interval is the minimum number of seconds between polls.",
+                    "type": "integer"
+                },
+                "user_code": {
                     "type": "string"
                 },
-                "token": {
+                "verification_uri": {
+                    "type": "string"
+                },
+                "verification_uri_complete": {
                     "type": "string"
-                }
-            }
-        },
-        "codersdk.OAuth2Config": {
-            "type": "object",
-            "properties": {
-                "github": {
-                    "$ref": "#/definitions/codersdk.OAuth2GithubConfig"
                 }
             }
         },
@@ -10377,6 +12787,14 @@ const docTemplate = `{
                 "callback_url": {
                     "type": "string"
                 },
+                "client_type": {
+                    "description": "ClientType is \"public\" for apps that cannot keep a secret, like\nnative and command-line tools. Public apps must use PKCE.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderAppClientType"
+                        }
+                    ]
+                },
                 "endpoints": {
                     "description": "Endpoints are included in the app response for easier discovery. The OAuth2\nspec does not have a defined place to find these (for comparison, OIDC has\na '/.well-known/openid-configuration' endpoint).",
                     "allOf": [
@@ -10397,6 +12815,17 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.OAuth2ProviderAppClientType": {
+            "type": "string",
+            "enum": [
+                "confidential",
+                "public"
+            ],
+            "x-enum-varnames": [
+                "OAuth2ProviderAppClientTypeConfidential",
+                "OAuth2ProviderAppClientTypePublic"
+            ]
+        },
         "codersdk.OAuth2ProviderAppSecret": {
             "type": "object",
             "properties": {
@@ -10424,6 +12853,19 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.OAuth2ProviderGrantType": {
+            "type": "string",
+            "enum": [
+                "authorization_code",
+                "refresh_token",
+                "urn:ietf:params:oauth:grant-type:device_code"
+            ],
+            "x-enum-varnames": [
+                "OAuth2ProviderGrantTypeAuthorizationCode",
+                "OAuth2ProviderGrantTypeRefreshToken",
+                "OAuth2ProviderGrantTypeDeviceCode"
+            ]
+        },
         "codersdk.OAuthConversionResponse": {
             "type": "object",
             "properties": {
@@ -10755,6 +13197,17 @@ const docTemplate = `{
                 "callback_url": {
                     "type": "string"
                 },
+                "client_type": {
+                    "enum": [
+                        "confidential",
+                        "public"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderAppClientType"
+                        }
+                    ]
+                },
                 "icon": {
                     "type": "string"
                 },
@@ -10809,6 +13262,18 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.PromoteTemplateVersionRequest": {
+            "type": "object",
+            "required": [
+                "template_version_id"
+            ],
+            "properties": {
+                "template_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.ProvisionerConfig": {
             "type": "object",
             "properties": {
@@ -10898,7 +13363,8 @@ const docTemplate = `{
                 },
                 "error_code": {
                     "enum": [
-                        "REQUIRED_TEMPLATE_VARIABLES"
+                        "REQUIRED_TEMPLATE_VARIABLES",
+                        "TEMPLATE_POLICY_VIOLATION"
                     ],
                     "allOf": [
                         {
@@ -11107,6 +13573,17 @@ const docTemplate = `{
                 "callback_url": {
                     "type": "string"
                 },
+                "client_type": {
+                    "enum": [
+                        "confidential",
+                        "public"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.OAuth2ProviderAppClientType"
+                        }
+                    ]
+                },
                 "icon": {
                     "type": "string"
                 },
@@ -11115,6 +13592,132 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.QuotaBudget": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "group_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "hard_limit": {
+                    "description": "HardLimit is the accrued cost at which workspace starts are rejected. 0\ndisables the hard limit.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "period": {
+                    "enum": [
+                        "day",
+                        "month"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.QuotaBudgetPeriod"
+                        }
+                    ]
+                },
+                "soft_limit": {
+                    "description": "SoftLimit is the accrued cost at which users are notified. 0 disables\nthe soft limit.",
+                    "type": "integer"
+                },
+                "stop_workspaces": {
+                    "description": "StopWorkspaces stops running workspaces once the hard limit is reached.",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.QuotaBudgetPeriod": {
+            "type": "string",
+            "enum": [
+                "day",
+                "month"
+            ],
+            "x-enum-varnames": [
+                "QuotaBudgetPeriodDay",
+                "QuotaBudgetPeriodMonth"
+            ]
+        },
+        "codersdk.QuotaBudgetStatus": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "group_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "hard_limit": {
+                    "description": "HardLimit is the accrued cost at which workspace starts are rejected. 0\ndisables the hard limit.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "period": {
+                    "enum": [
+                        "day",
+                        "month"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.QuotaBudgetPeriod"
+                        }
+                    ]
+                },
+                "period_end": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "period_start": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "soft_limit": {
+                    "description": "SoftLimit is the accrued cost at which users are notified. 0 disables\nthe soft limit.",
+                    "type": "integer"
+                },
+                "spend": {
+                    "type": "integer"
+                },
+                "stop_workspaces": {
+                    "description": "StopWorkspaces stops running workspaces once the hard limit is reached.",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "user_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.RBACAction": {
             "type": "string",
             "enum": [
@@ -11389,7 +13992,11 @@ const docTemplate = `{
                 "organization",
                 "oauth2_provider_app",
                 "oauth2_provider_app_secret",
-                "custom_role"
+                "custom_role",
+                "notification_template",
+                "quota_budget",
+                "template_release_channel",
+                "template_git_sync"
             ],
             "x-enum-varnames": [
                 "ResourceTypeTemplate",
@@ -11408,7 +14015,11 @@ const docTemplate = `{
                 "ResourceTypeOrganization",
                 "ResourceTypeOAuth2ProviderApp",
                 "ResourceTypeOAuth2ProviderAppSecret",
-                "ResourceTypeCustomRole"
+                "ResourceTypeCustomRole",
+                "ResourceTypeNotificationTemplate",
+                "ResourceTypeQuotaBudget",
+                "ResourceTypeTemplateReleaseChannel",
+                "ResourceTypeTemplateGitSync"
             ]
         },
         "codersdk.Response": {
@@ -11697,6 +14308,13 @@ const docTemplate = `{
                     "type": "string",
                     "format": "uuid"
                 },
+                "idle_cpu_threshold": {
+                    "type": "integer"
+                },
+                "idle_timeout_ms": {
+                    "description": "IdleTimeoutMillis and IdleCPUThreshold are enterprise-only. Running\nworkspaces with no open sessions and CPU usage below IdleCPUThreshold\npercent for IdleTimeoutMillis are stopped. A timeout of 0 disables idle\nstop, and a threshold of 0 ignores CPU usage.",
+                    "type": "integer"
+                },
                 "max_port_share_level": {
                     "$ref": "#/definitions/codersdk.WorkspaceAgentPortShareLevel"
                 },
@@ -11847,26 +14465,86 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
-                "icon": {
+                "icon": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "markdown": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.TemplateGitSync": {
+            "type": "object",
+            "properties": {
+                "auto_activate": {
+                    "description": "AutoActivate promotes synced versions to the active version when\ntheir import succeeds.",
+                    "type": "boolean"
+                },
+                "branch": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "created_by": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "directory": {
+                    "type": "string"
+                },
+                "external_auth_provider_id": {
+                    "description": "ExternalAuthProviderID is the external auth provider whose token, of\nthe user that configured the sync, is used to fetch the repository.",
+                    "type": "string"
+                },
+                "last_commit_sha": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_synced_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "last_template_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "repository_url": {
                     "type": "string"
                 },
-                "id": {
+                "template_id": {
                     "type": "string",
                     "format": "uuid"
                 },
-                "markdown": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
                 },
-                "name": {
+                "webhook_secret": {
+                    "description": "WebhookSecret signs or authenticates push events. It is only\nreturned when the sync is created or updated.",
                     "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "url": {
+                "webhook_url": {
+                    "description": "WebhookURL receives push events from the Git provider to sync\nwithout waiting for the next poll.",
                     "type": "string"
                 }
             }
@@ -12000,6 +14678,95 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplatePolicyRule": {
+            "type": "object",
+            "properties": {
+                "instance_types": {
+                    "description": "InstanceTypes are glob patterns, e.g. \"t3.*\", that instance types must\nmatch.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "metadata_keys": {
+                    "description": "MetadataKeys are the metadata items that are required. If empty, any\nmetadata satisfies the rule.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name identifies the rule in diagnostics. Defaults to the type.",
+                    "type": "string"
+                },
+                "providers": {
+                    "description": "Providers are the forbidden providers, by local name (e.g. \"aws\") or\nsource address (e.g. \"hashicorp/aws\").",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "enum": [
+                        "required_metadata",
+                        "forbidden_providers",
+                        "allowed_instance_types",
+                        "required_shutdown_script"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplatePolicyRuleType"
+                        }
+                    ]
+                }
+            }
+        },
+        "codersdk.TemplatePolicyRuleType": {
+            "type": "string",
+            "enum": [
+                "required_metadata",
+                "forbidden_providers",
+                "allowed_instance_types",
+                "required_shutdown_script"
+            ],
+            "x-enum-varnames": [
+                "TemplatePolicyRuleRequiredMetadata",
+                "TemplatePolicyRuleForbiddenProviders",
+                "TemplatePolicyRuleAllowedInstanceTypes",
+                "TemplatePolicyRuleRequiredShutdownScript"
+            ]
+        },
+        "codersdk.TemplateReleaseChannel": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "template_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "template_version_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
         "codersdk.TemplateRole": {
             "type": "string",
             "enum": [
@@ -12147,6 +14914,56 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionDiff": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionFileDiff"
+                    }
+                },
+                "from_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionParameterDiff"
+                    }
+                },
+                "resources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionResourceDiff"
+                    }
+                },
+                "to_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplateVersionVariableDiff"
+                    }
+                }
+            }
+        },
+        "codersdk.TemplateVersionDiffStatus": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "modified"
+            ],
+            "x-enum-varnames": [
+                "TemplateVersionDiffStatusAdded",
+                "TemplateVersionDiffStatusRemoved",
+                "TemplateVersionDiffStatusModified"
+            ]
+        },
         "codersdk.TemplateVersionExternalAuth": {
             "type": "object",
             "properties": {
@@ -12173,6 +14990,34 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionFileDiff": {
+            "type": "object",
+            "properties": {
+                "binary": {
+                    "description": "Binary is true if either side of the file is not text. Binary files do\nnot have a diff.",
+                    "type": "boolean"
+                },
+                "diff": {
+                    "description": "Diff is a unified diff of the file contents.",
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffStatus"
+                        }
+                    ]
+                }
+            }
+        },
         "codersdk.TemplateVersionParameter": {
             "type": "object",
             "properties": {
@@ -12243,6 +15088,39 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionParameterDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Changes describes what changed for modified parameters, e.g.\n\"default_value\".",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/codersdk.TemplateVersionParameter"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffStatus"
+                        }
+                    ]
+                },
+                "to": {
+                    "$ref": "#/definitions/codersdk.TemplateVersionParameter"
+                }
+            }
+        },
         "codersdk.TemplateVersionParameterOption": {
             "type": "object",
             "properties": {
@@ -12260,6 +15138,35 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionResourceDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffStatus"
+                        }
+                    ]
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.TemplateVersionVariable": {
             "type": "object",
             "properties": {
@@ -12291,6 +15198,38 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.TemplateVersionVariableDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/codersdk.TemplateVersionVariable"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.TemplateVersionDiffStatus"
+                        }
+                    ]
+                },
+                "to": {
+                    "$ref": "#/definitions/codersdk.TemplateVersionVariable"
+                }
+            }
+        },
         "codersdk.TemplateVersionWarning": {
             "type": "string",
             "enum": [
@@ -12300,6 +15239,18 @@ const docTemplate = `{
                 "TemplateVersionWarningUnsupportedWorkspaces"
             ]
         },
+        "codersdk.TestNotificationTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "labels": {
+                    "description": "Labels are substituted into the template. Labels which the template refers to but which are not given are\nfilled with sample values.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "codersdk.TokenConfig": {
             "type": "object",
             "properties": {
@@ -12378,16 +15329,66 @@ const docTemplate = `{
         "codersdk.UpdateCheckResponse": {
             "type": "object",
             "properties": {
-                "current": {
-                    "description": "Current indicates whether the server version is the same as the latest.",
-                    "type": "boolean"
+                "current": {
+                    "description": "Current indicates whether the server version is the same as the latest.",
+                    "type": "boolean"
+                },
+                "url": {
+                    "description": "URL to download the latest release of Coder.",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Version is the semantic version for the latest release of Coder.",
+                    "type": "string"
+                }
+            }
+        },
+        "codersdk.UpdateInboxNotificationReadStatusRequest": {
+            "type": "object",
+            "properties": {
+                "is_read": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.UpdateNotificationPreference": {
+            "type": "object",
+            "required": [
+                "notification_template_id"
+            ],
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "method": {
+                    "description": "Method is the delivery method to use for the template. An empty value resets it to the deployment's default.",
+                    "type": "string"
+                },
+                "notification_template_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
+        "codersdk.UpdateNotificationTemplateMandatory": {
+            "type": "object",
+            "properties": {
+                "mandatory": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "codersdk.UpdateNotificationTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "description": "Actions is a JSON array of objects with \"label\" and \"url\" fields, e.g. to link to internal runbooks.",
+                    "type": "string"
                 },
-                "url": {
-                    "description": "URL to download the latest release of Coder.",
+                "body_template": {
                     "type": "string"
                 },
-                "version": {
-                    "description": "Version is the semantic version for the latest release of Coder.",
+                "title_template": {
                     "type": "string"
                 }
             }
@@ -12409,6 +15410,22 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateQuotaBudgetRequest": {
+            "type": "object",
+            "properties": {
+                "hard_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "soft_limit": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "stop_workspaces": {
+                    "type": "boolean"
+                }
+            }
+        },
         "codersdk.UpdateRoles": {
             "type": "object",
             "properties": {
@@ -12447,6 +15464,32 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateTemplateGitSyncRequest": {
+            "type": "object",
+            "required": [
+                "repository_url"
+            ],
+            "properties": {
+                "auto_activate": {
+                    "type": "boolean"
+                },
+                "branch": {
+                    "description": "Branch defaults to \"main\".",
+                    "type": "string"
+                },
+                "directory": {
+                    "description": "Directory is the path of the template in the repository. Defaults to\nthe root of the repository.",
+                    "type": "string"
+                },
+                "external_auth_provider_id": {
+                    "type": "string"
+                },
+                "repository_url": {
+                    "description": "RepositoryURL is the HTTP or HTTPS clone URL of the repository. It must\nbe HTTPS when an external auth provider is used.",
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.UpdateUserAppearanceSettingsRequest": {
             "type": "object",
             "required": [
@@ -12458,6 +15501,20 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateUserNotificationPreferences": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.UpdateNotificationPreference"
+                    }
+                }
+            }
+        },
         "codersdk.UpdateUserPasswordRequest": {
             "type": "object",
             "required": [
@@ -12498,6 +15555,31 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateWorkspaceACL": {
+            "type": "object",
+            "properties": {
+                "group_roles": {
+                    "description": "GroupRoles is a mapping of group IDs to roles. An empty role removes\nthe group.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/codersdk.WorkspaceRole"
+                    },
+                    "example": {
+                        "8bd26b20-f3e8-48be-a903-46bb920cf671": "admin"
+                    }
+                },
+                "user_roles": {
+                    "description": "UserRoles is a mapping of user IDs to roles. An empty role removes the\nuser.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/codersdk.WorkspaceRole"
+                    },
+                    "example": {
+                        "4df59e74-c027-470b-ab4d-cbba8963a5e9": "use"
+                    }
+                }
+            }
+        },
         "codersdk.UpdateWorkspaceAutomaticUpdatesRequest": {
             "type": "object",
             "properties": {
@@ -12523,6 +15605,14 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.UpdateWorkspaceReleaseChannelRequest": {
+            "type": "object",
+            "properties": {
+                "release_channel": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.UpdateWorkspaceRequest": {
             "type": "object",
             "properties": {
@@ -12554,13 +15644,30 @@ const docTemplate = `{
                 "agent_name": {
                     "type": "string"
                 },
+                "allowed_group_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "allowed_user_ids": {
+                    "description": "AllowedUserIDs and AllowedGroupIDs are the users and groups that can\naccess the port. They must only be set with the \"allowlist\" level.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
                 "port": {
                     "type": "integer"
                 },
                 "protocol": {
                     "enum": [
                         "http",
-                        "https"
+                        "https",
+                        "h2c",
+                        "tcp"
                     ],
                     "allOf": [
                         {
@@ -12571,6 +15678,8 @@ const docTemplate = `{
                 "share_level": {
                     "enum": [
                         "owner",
+                        "allowlist",
+                        "organization",
                         "authenticated",
                         "public"
                     ],
@@ -12977,6 +16086,10 @@ const docTemplate = `{
                 "owner_name": {
                     "type": "string"
                 },
+                "release_channel": {
+                    "description": "ReleaseChannel is the name of the template release channel the\nworkspace follows. If set, TemplateActiveVersionID and Outdated refer\nto the version of the channel.",
+                    "type": "string"
+                },
                 "template_active_version_id": {
                     "type": "string",
                     "format": "uuid"
@@ -13009,6 +16122,23 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceACL": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceGroup"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceUser"
+                    }
+                }
+            }
+        },
         "codersdk.WorkspaceAgent": {
             "type": "object",
             "properties": {
@@ -13270,13 +16400,29 @@ const docTemplate = `{
                 "agent_name": {
                     "type": "string"
                 },
+                "allowed_group_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
+                "allowed_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "format": "uuid"
+                    }
+                },
                 "port": {
                     "type": "integer"
                 },
                 "protocol": {
                     "enum": [
                         "http",
-                        "https"
+                        "https",
+                        "h2c",
+                        "tcp"
                     ],
                     "allOf": [
                         {
@@ -13287,6 +16433,8 @@ const docTemplate = `{
                 "share_level": {
                     "enum": [
                         "owner",
+                        "allowlist",
+                        "organization",
                         "authenticated",
                         "public"
                     ],
@@ -13306,11 +16454,15 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "owner",
+                "allowlist",
+                "organization",
                 "authenticated",
                 "public"
             ],
             "x-enum-varnames": [
                 "WorkspaceAgentPortShareLevelOwner",
+                "WorkspaceAgentPortShareLevelAllowlist",
+                "WorkspaceAgentPortShareLevelOrganization",
                 "WorkspaceAgentPortShareLevelAuthenticated",
                 "WorkspaceAgentPortShareLevelPublic"
             ]
@@ -13319,11 +16471,15 @@ const docTemplate = `{
             "type": "string",
             "enum": [
                 "http",
-                "https"
+                "https",
+                "h2c",
+                "tcp"
             ],
             "x-enum-varnames": [
                 "WorkspaceAgentPortShareProtocolHTTP",
-                "WorkspaceAgentPortShareProtocolHTTPS"
+                "WorkspaceAgentPortShareProtocolHTTPS",
+                "WorkspaceAgentPortShareProtocolH2C",
+                "WorkspaceAgentPortShareProtocolTCP"
             ]
         },
         "codersdk.WorkspaceAgentPortShares": {
@@ -13523,7 +16679,8 @@ const docTemplate = `{
                     "enum": [
                         "initiator",
                         "autostart",
-                        "autostop"
+                        "autostop",
+                        "idle"
                     ],
                     "allOf": [
                         {
@@ -13649,6 +16806,51 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceGroup": {
+            "type": "object",
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "display_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.ReducedUser"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "quota_allowance": {
+                    "type": "integer"
+                },
+                "role": {
+                    "enum": [
+                        "admin",
+                        "use"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceRole"
+                        }
+                    ]
+                },
+                "source": {
+                    "$ref": "#/definitions/codersdk.GroupSource"
+                }
+            }
+        },
         "codersdk.WorkspaceHealth": {
             "type": "object",
             "properties": {
@@ -13750,6 +16952,13 @@ const docTemplate = `{
                 "budget": {
                     "type": "integer"
                 },
+                "budgets": {
+                    "description": "Budgets are the cost-based budgets which apply to the user, across all\nof their organizations.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.QuotaBudgetStatus"
+                    }
+                },
                 "credits_consumed": {
                     "type": "integer"
                 }
@@ -13825,6 +17034,74 @@ const docTemplate = `{
                 }
             }
         },
+        "codersdk.WorkspaceRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "use",
+                ""
+            ],
+            "x-enum-varnames": [
+                "WorkspaceRoleAdmin",
+                "WorkspaceRoleUse",
+                "WorkspaceRoleDeleted"
+            ]
+        },
+        "codersdk.WorkspaceSnapshot": {
+            "type": "object",
+            "properties": {
+                "build_number": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "created_by": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rich_parameter_values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.WorkspaceBuildParameter"
+                    }
+                },
+                "template_version_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "template_version_name": {
+                    "type": "string"
+                },
+                "transition": {
+                    "enum": [
+                        "start",
+                        "stop"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceTransition"
+                        }
+                    ]
+                },
+                "workspace_build_id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "workspace_id": {
+                    "type": "string",
+                    "format": "uuid"
+                }
+            }
+        },
         "codersdk.WorkspaceStatus": {
             "type": "string",
             "enum": [
@@ -13865,6 +17142,37 @@ const docTemplate = `{
                 "WorkspaceTransitionDelete"
             ]
         },
+        "codersdk.WorkspaceUser": {
+            "type": "object",
+            "required": [
+                "id",
+                "username"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string",
+                    "format": "uri"
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "role": {
+                    "enum": [
+                        "admin",
+                        "use"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/codersdk.WorkspaceRole"
+                        }
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
         "codersdk.WorkspacesResponse": {
             "type": "object",
             "properties": {
@@ -14695,6 +18003,17 @@ const docTemplate = `{
                 }
             }
         },
+        "serpent.Struct-array_codersdk_TemplatePolicyRule": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/codersdk.TemplatePolicyRule"
+                    }
+                }
+            }
+        },
         "serpent.URL": {
             "type": "object",
             "properties": {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/telemetry"
 	"github.com/coder/coder/v2/codersdk"
@@ -56,9 +58,59 @@ func (api *API) postToken(rw http.ResponseWriter, r *http.Request) {
 	}
 
 	scope := database.APIKeyScopeAll
-	if scope != "" {
+	if createToken.Scope != "" {
 		scope = database.APIKeyScope(createToken.Scope)
 	}
+	if len(createToken.Scopes) > 0 && scope != database.APIKeyScopeAll {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Scopes cannot be combined with the %q scope.", scope),
+		})
+		return
+	}
+	if len(createToken.AllowList) > 0 && len(createToken.Scopes) == 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "An allow list requires scopes.",
+		})
+		return
+	}
+	_, err := rbac.TokenScope{Permissions: createToken.Scopes}.Expand()
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid token scopes.",
+			Detail:  err.Error(),
+			Validations: []codersdk.ValidationError{{
+				Field:  "scopes",
+				Detail: err.Error(),
+			}},
+		})
+		return
+	}
+
+	// A token with scopes could otherwise be exchanged for a token with more
+	// permissions, or for an unrestricted one.
+	if callerScope, ok := httpmw.APIKey(r).RBACScope().(rbac.TokenScope); ok {
+		if len(createToken.Scopes) == 0 {
+			httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+				Message: "A token with scopes can only create tokens with scopes.",
+			})
+			return
+		}
+		requested := rbac.TokenScope{Permissions: createToken.Scopes}
+		for _, id := range createToken.AllowList {
+			requested.AllowIDList = append(requested.AllowIDList, id.String())
+		}
+		covered, err := callerScope.Covers(requested)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		if !covered {
+			httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+				Message: "Token scopes and allow list must be within those of the token used to create it.",
+			})
+			return
+		}
+	}
 
 	// default lifetime is 30 days
 	lifeTime := 30 * 24 * time.Hour
@@ -72,7 +124,7 @@ func (api *API) postToken(rw http.ResponseWriter, r *http.Request) {
 		tokenName = createToken.TokenName
 	}
 
-	err := api.validateAPIKeyLifetime(lifeTime)
+	err = api.validateAPIKeyLifetime(lifeTime)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 			Message: "Failed to validate create API key request.",
@@ -81,12 +133,31 @@ func (api *API) postToken(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Workspaces cannot be read without their template, so a token restricted
+	// to a workspace may also read its template.
+	allowList := slices.Clone(createToken.AllowList)
+	for _, id := range createToken.AllowList {
+		workspace, err := api.Database.GetWorkspaceByID(ctx, id)
+		if httpapi.Is404Error(err) {
+			continue
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		if !slices.Contains(allowList, workspace.TemplateID) {
+			allowList = append(allowList, workspace.TemplateID)
+		}
+	}
+
 	cookie, key, err := api.createAPIKey(ctx, apikey.CreateParams{
 		UserID:          user.ID,
 		LoginType:       database.LoginTypeToken,
 		DefaultLifetime: api.DeploymentValues.Sessions.DefaultDuration.Value(),
 		ExpiresAt:       dbtime.Now().Add(lifeTime),
 		Scope:           scope,
+		Scopes:          createToken.Scopes,
+		AllowList:       allowList,
 		LifetimeSeconds: int64(lifeTime.Seconds()),
 		TokenName:       tokenName,
 	})
@@ -26,6 +26,8 @@ type CreateParams struct {
 	ExpiresAt       time.Time
 	LifetimeSeconds int64
 	Scope           database.APIKeyScope
+	Scopes          []string
+	AllowList       []uuid.UUID
 	TokenName       string
 	RemoteAddr      string
 }
@@ -93,6 +95,8 @@ func Generate(params CreateParams) (database.InsertAPIKeyParams, string, error)
 		HashedSecret: hashed[:],
 		LoginType:    params.LoginType,
 		Scope:        scope,
+		Scopes:       params.Scopes,
+		AllowList:    params.AllowList,
 		TokenName:    params.TokenName,
 	}, token, nil
 }
@@ -7,12 +7,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
 	"github.com/coder/coder/v2/coderd/database/dbtestutil"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/codersdk"
@@ -85,6 +87,106 @@ func TestTokenScoped(t *testing.T) {
 	require.Equal(t, keys[0].Scope, codersdk.APIKeyScopeApplicationConnect)
 }
 
+func TestTokenScopes(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+	member, memberUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+	allowed := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OwnerID:        memberUser.ID,
+		OrganizationID: owner.OrganizationID,
+	}).Do()
+	other := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OwnerID:        memberUser.ID,
+		OrganizationID: owner.OrganizationID,
+	}).Do()
+
+	var apiErr *codersdk.Error
+	_, err := member.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		Scopes: []string{"workspace:fly"},
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+	res, err := member.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		TokenName: "ci",
+		Scopes:    []string{"workspace:read", "workspace:start"},
+		AllowList: []uuid.UUID{allowed.Workspace.ID},
+	})
+	require.NoError(t, err)
+	token, err := member.APIKeyByName(ctx, codersdk.Me, "ci")
+	require.NoError(t, err)
+	require.Equal(t, []string{"workspace:read", "workspace:start"}, token.Scopes)
+	// The template of the workspace is added so the workspace can be read.
+	require.ElementsMatch(t, []uuid.UUID{allowed.Workspace.ID, allowed.Workspace.TemplateID}, token.AllowList)
+
+	scoped := codersdk.New(client.URL)
+	scoped.SetSessionToken(res.Key)
+
+	workspace, err := scoped.Workspace(ctx, allowed.Workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, allowed.Workspace.ID, workspace.ID)
+
+	_, err = scoped.Workspace(ctx, other.Workspace.ID)
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+	// Stopping is not in the token's scopes.
+	_, err = scoped.CreateWorkspaceBuild(ctx, allowed.Workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+		Transition: codersdk.WorkspaceTransitionStop,
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+}
+
+func TestTokenScopesEscalation(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	client, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, client)
+	member, memberUser := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+	allowed := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OwnerID:        memberUser.ID,
+		OrganizationID: owner.OrganizationID,
+	}).Do()
+	other := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OwnerID:        memberUser.ID,
+		OrganizationID: owner.OrganizationID,
+	}).Do()
+
+	res, err := member.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		TokenName: "ci",
+		Scopes:    []string{"api_key:create", "workspace:read"},
+		AllowList: []uuid.UUID{allowed.Workspace.ID},
+	})
+	require.NoError(t, err)
+	scoped := codersdk.New(client.URL)
+	scoped.SetSessionToken(res.Key)
+
+	for name, req := range map[string]codersdk.CreateTokenRequest{
+		"Unscoped":     {},
+		"Scope":        {Scope: codersdk.APIKeyScopeApplicationConnect},
+		"Permissions":  {Scopes: []string{"workspace:read", "workspace:update"}, AllowList: []uuid.UUID{allowed.Workspace.ID}},
+		"NoAllowList":  {Scopes: []string{"workspace:read"}},
+		"AllowListOut": {Scopes: []string{"workspace:read"}, AllowList: []uuid.UUID{other.Workspace.ID}},
+	} {
+		var apiErr *codersdk.Error
+		_, err := scoped.CreateToken(ctx, codersdk.Me, req)
+		require.ErrorAs(t, err, &apiErr, name)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode(), name)
+	}
+
+	_, err = scoped.CreateToken(ctx, codersdk.Me, codersdk.CreateTokenRequest{
+		TokenName: "narrower",
+		Scopes:    []string{"workspace:read"},
+		AllowList: []uuid.UUID{allowed.Workspace.ID},
+	})
+	require.NoError(t, err)
+}
+
 func TestUserSetTokenDuration(t *testing.T) {
 	t.Parallel()
 
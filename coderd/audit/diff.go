@@ -25,7 +25,11 @@ type Auditable interface {
 		database.OAuth2ProviderAppSecret |
 		database.CustomRole |
 		database.AuditableOrganizationMember |
-		database.Organization
+		database.Organization |
+		database.NotificationTemplate |
+		database.QuotaBudget |
+		database.TemplateReleaseChannel |
+		database.TemplateGitSync
 }
 
 // Map is a map of changed fields in an audited resource. It maps field names to
@@ -111,6 +111,14 @@ func ResourceTarget[T Auditable](tgt T) string {
 		return typed.Username
 	case database.Organization:
 		return typed.Name
+	case database.NotificationTemplate:
+		return typed.Name
+	case database.QuotaBudget:
+		return string(typed.Period)
+	case database.TemplateReleaseChannel:
+		return typed.Name
+	case database.TemplateGitSync:
+		return typed.RepositoryURL
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceTarget", tgt))
 	}
@@ -157,6 +165,14 @@ func ResourceID[T Auditable](tgt T) uuid.UUID {
 		return typed.UserID
 	case database.Organization:
 		return typed.ID
+	case database.NotificationTemplate:
+		return typed.ID
+	case database.QuotaBudget:
+		return typed.ID
+	case database.TemplateReleaseChannel:
+		return typed.ID
+	case database.TemplateGitSync:
+		return typed.TemplateID
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceID", tgt))
 	}
@@ -200,6 +216,14 @@ func ResourceType[T Auditable](tgt T) database.ResourceType {
 		return database.ResourceTypeOrganizationMember
 	case database.Organization:
 		return database.ResourceTypeOrganization
+	case database.NotificationTemplate:
+		return database.ResourceTypeNotificationTemplate
+	case database.QuotaBudget:
+		return database.ResourceTypeQuotaBudget
+	case database.TemplateReleaseChannel:
+		return database.ResourceTypeTemplateReleaseChannel
+	case database.TemplateGitSync:
+		return database.ResourceTypeTemplateGitSync
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceType", typed))
 	}
@@ -245,6 +269,14 @@ func ResourceRequiresOrgID[T Auditable]() bool {
 		return true
 	case database.Organization:
 		return true
+	case database.NotificationTemplate:
+		return false
+	case database.QuotaBudget:
+		return true
+	case database.TemplateReleaseChannel:
+		return true
+	case database.TemplateGitSync:
+		return true
 	default:
 		panic(fmt.Sprintf("unknown resource %T for ResourceRequiresOrgID", tgt))
 	}
@@ -0,0 +1,146 @@
+package autobuild
+
+import (
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/workspaceapps"
+)
+
+// AutostopWarningExtension is how long a workspace's deadline is extended by
+// when its owner follows the link in an autostop warning.
+const AutostopWarningExtension = time.Hour
+
+const extendTokenSigningAlgorithm = jose.HS512
+
+// ExtendToken is the payload of a signed token which allows the deadline of a
+// workspace to be extended without an API key. These tokens are embedded in
+// autostop warnings so that owners can keep their workspace running from any
+// device.
+type ExtendToken struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// PreviousDeadline is the deadline the warning was sent for. The token can
+	// only be used while the workspace still has this deadline, so it can't be
+	// used more than once.
+	PreviousDeadline time.Time `json:"previous_deadline"`
+	// Deadline is the new deadline of the workspace.
+	Deadline time.Time `json:"deadline"`
+	// Expiry is when the token stops being valid, usually the deadline the
+	// warning was sent for.
+	Expiry time.Time `json:"expiry"`
+}
+
+// extendTokenKeyInfo binds keys derived by extendTokenKey to extend tokens.
+const extendTokenKeyInfo = "coder workspace deadline extension token"
+
+// extendTokenKey derives the key used to sign extend tokens from the
+// deployment's security key with HKDF, so that the key material used to sign
+// workspace app tokens is never used directly, and these tokens can never be
+// mistaken for workspace app tokens.
+func extendTokenKey(key workspaceapps.SecurityKey) ([]byte, error) {
+	derived := make([]byte, sha512.Size)
+	_, err := io.ReadFull(hkdf.New(sha512.New, key[:], nil, []byte(extendTokenKeyInfo)), derived)
+	if err != nil {
+		return nil, xerrors.Errorf("derive key: %w", err)
+	}
+	return derived, nil
+}
+
+// SignExtendToken returns a compact JWS for the given extend token.
+func SignExtendToken(key workspaceapps.SecurityKey, payload ExtendToken) (string, error) {
+	if payload.Expiry.IsZero() {
+		return "", xerrors.New("extend token expiry must be set")
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", xerrors.Errorf("marshal payload to JSON: %w", err)
+	}
+
+	signingKey, err := extendTokenKey(key)
+	if err != nil {
+		return "", err
+	}
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: extendTokenSigningAlgorithm,
+		Key:       signingKey,
+	}, nil)
+	if err != nil {
+		return "", xerrors.Errorf("create signer: %w", err)
+	}
+
+	signedObject, err := signer.Sign(payloadBytes)
+	if err != nil {
+		return "", xerrors.Errorf("sign payload: %w", err)
+	}
+
+	serialized, err := signedObject.CompactSerialize()
+	if err != nil {
+		return "", xerrors.Errorf("serialize JWS: %w", err)
+	}
+	return serialized, nil
+}
+
+// VerifyExtendToken parses and verifies a token created by SignExtendToken. If
+// the token is invalid or expired, an error is returned.
+func VerifyExtendToken(key workspaceapps.SecurityKey, str string) (ExtendToken, error) {
+	object, err := jose.ParseSigned(str)
+	if err != nil {
+		return ExtendToken{}, xerrors.Errorf("parse JWS: %w", err)
+	}
+	if len(object.Signatures) != 1 {
+		return ExtendToken{}, xerrors.New("expected 1 signature")
+	}
+	if object.Signatures[0].Header.Algorithm != string(extendTokenSigningAlgorithm) {
+		return ExtendToken{}, xerrors.Errorf("expected token signing algorithm to be %q, got %q", extendTokenSigningAlgorithm, object.Signatures[0].Header.Algorithm)
+	}
+
+	verifyKey, err := extendTokenKey(key)
+	if err != nil {
+		return ExtendToken{}, err
+	}
+	output, err := object.Verify(verifyKey)
+	if err != nil {
+		return ExtendToken{}, xerrors.Errorf("verify JWS: %w", err)
+	}
+
+	var tok ExtendToken
+	err = json.Unmarshal(output, &tok)
+	if err != nil {
+		return ExtendToken{}, xerrors.Errorf("unmarshal payload: %w", err)
+	}
+	if tok.Expiry.Before(time.Now()) {
+		return ExtendToken{}, xerrors.New("extend token expired")
+	}
+	return tok, nil
+}
+
+// HumanizeDuration formats d rounded to the minute for use in user-facing
+// messages, e.g. "1 hour 30 minutes".
+func HumanizeDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case hours > 0 && minutes > 0:
+		return plural(hours, "hour") + " " + plural(minutes, "minute")
+	case hours > 0:
+		return plural(hours, "hour")
+	default:
+		return plural(minutes, "minute")
+	}
+}
@@ -0,0 +1,107 @@
+package autobuild_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/autobuild"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/workspaceapps"
+)
+
+func TestExtendToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		payload := autobuild.ExtendToken{
+			WorkspaceID:      uuid.New(),
+			PreviousDeadline: time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+			Deadline:         time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second),
+			Expiry:           time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		}
+		token, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, payload)
+		require.NoError(t, err)
+
+		got, err := autobuild.VerifyExtendToken(coderdtest.AppSecurityKey, token)
+		require.NoError(t, err)
+		require.Equal(t, payload, got)
+	})
+
+	t.Run("NoExpiry", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, autobuild.ExtendToken{
+			WorkspaceID: uuid.New(),
+			Deadline:    time.Now().Add(time.Hour),
+		})
+		require.ErrorContains(t, err, "expiry must be set")
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, autobuild.ExtendToken{
+			WorkspaceID: uuid.New(),
+			Deadline:    time.Now().Add(time.Hour),
+			Expiry:      time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		_, err = autobuild.VerifyExtendToken(coderdtest.AppSecurityKey, token)
+		require.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("WrongKey", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, autobuild.ExtendToken{
+			WorkspaceID: uuid.New(),
+			Deadline:    time.Now().Add(time.Hour),
+			Expiry:      time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		var otherKey workspaceapps.SecurityKey
+		copy(otherKey[:], "some other key")
+		_, err = autobuild.VerifyExtendToken(otherKey, token)
+		require.ErrorContains(t, err, "verify JWS")
+	})
+
+	t.Run("NotAnAppToken", func(t *testing.T) {
+		t.Parallel()
+
+		// App tokens are signed with the same security key, but must not be
+		// accepted as extend tokens.
+		appToken, err := coderdtest.AppSecurityKey.SignToken(workspaceapps.SignedToken{
+			WorkspaceID: uuid.New(),
+		})
+		require.NoError(t, err)
+
+		_, err = autobuild.VerifyExtendToken(coderdtest.AppSecurityKey, appToken)
+		require.ErrorContains(t, err, "verify JWS")
+	})
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		duration time.Duration
+		expected string
+	}{
+		{duration: 0, expected: "0 minutes"},
+		{duration: time.Minute, expected: "1 minute"},
+		{duration: 30 * time.Minute, expected: "30 minutes"},
+		{duration: 29*time.Minute + 40*time.Second, expected: "30 minutes"},
+		{duration: time.Hour, expected: "1 hour"},
+		{duration: 90 * time.Minute, expected: "1 hour 30 minutes"},
+		{duration: 2*time.Hour + time.Minute, expected: "2 hours 1 minute"},
+	} {
+		require.Equal(t, tc.expected, autobuild.HumanizeDuration(tc.duration), tc.duration.String())
+	}
+}
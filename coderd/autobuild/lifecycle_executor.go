@@ -22,6 +22,7 @@ import (
 	"github.com/coder/coder/v2/coderd/dormancy"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/schedule"
+	"github.com/coder/coder/v2/coderd/workspaceapps"
 	"github.com/coder/coder/v2/coderd/wsbuilder"
 )
 
@@ -38,6 +39,8 @@ type Executor struct {
 	statsCh               chan<- Stats
 	// NotificationsEnqueuer handles enqueueing notifications for delivery by SMTP, webhook, etc.
 	notificationsEnqueuer notifications.Enqueuer
+	autostopWarning       time.Duration
+	extendTokenKey        workspaceapps.SecurityKey
 }
 
 // Stats contains information about one run of Executor.
@@ -71,6 +74,15 @@ func (e *Executor) WithStatsChannel(ch chan<- Stats) *Executor {
 	return e
 }
 
+// WithAutostopWarning will cause Executor to warn workspace owners the given
+// duration before their workspace is automatically stopped. The warning
+// contains a link to extend the deadline, signed with key.
+func (e *Executor) WithAutostopWarning(warning time.Duration, key workspaceapps.SecurityKey) *Executor {
+	e.autostopWarning = warning
+	e.extendTokenKey = key
+	return e
+}
+
 // Run will cause executor to start or stop workspaces on every
 // tick from its channel. It will stop when its context is Done, or when
 // its channel is closed.
@@ -187,7 +199,14 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						return xerrors.Errorf("get template by ID: %w", err)
 					}
 
-					activeTemplateVersion, err = tx.GetTemplateVersionByID(e.ctx, template.ActiveVersionID)
+					// Workspaces that follow a release channel are updated to
+					// the channel's version instead of the active version.
+					activeVersionID, err := wsbuilder.ActiveVersionID(e.ctx, tx, ws, template)
+					if err != nil {
+						return xerrors.Errorf("get active version ID: %w", err)
+					}
+
+					activeTemplateVersion, err = tx.GetTemplateVersionByID(e.ctx, activeVersionID)
 					if err != nil {
 						return xerrors.Errorf("get active template version by ID: %w", err)
 					}
@@ -215,7 +234,7 @@ func (e *Executor) runOnce(t time.Time) Stats {
 							log.Debug(e.ctx, "autostarting with active version")
 							builder = builder.ActiveVersion()
 
-							if latestBuild.TemplateVersionID != template.ActiveVersionID {
+							if latestBuild.TemplateVersionID != activeVersionID {
 								// control flag to know if the workspace was auto-updated,
 								// so the lifecycle executor can notify the user
 								didAutoUpdate = true
@@ -269,6 +288,13 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						)
 					}
 
+					if reason == database.BuildReasonIdle {
+						log.Info(e.ctx, "stopped idle workspace",
+							slog.F("last_active_at", ws.LastActiveAt),
+							slog.F("idle_timeout", templateSchedule.IdleTimeout),
+						)
+					}
+
 					if nextTransition == "" {
 						return nil
 					}
@@ -309,7 +335,7 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						// Associate this notification with all the related entities.
 						ws.ID, ws.OwnerID, ws.TemplateID, ws.OrganizationID,
 					); err != nil {
-						log.Warn(e.ctx, "failed to notify of autoupdated workspace", slog.Error(err))
+						notifications.LogEnqueueError(e.ctx, log, "failed to notify of autoupdated workspace", err)
 					}
 				}
 				if err != nil {
@@ -332,7 +358,7 @@ func (e *Executor) runOnce(t time.Time) Stats {
 						*dormantNotification,
 					)
 					if err != nil {
-						log.Warn(e.ctx, "failed to notify of workspace marked as dormant", slog.Error(err), slog.F("workspace_id", dormantNotification.Workspace.ID))
+						notifications.LogEnqueueError(e.ctx, log, "failed to notify of workspace marked as dormant", err, slog.F("workspace_id", dormantNotification.Workspace.ID))
 					}
 				}
 				return nil
@@ -355,9 +381,59 @@ func (e *Executor) runOnce(t time.Time) Stats {
 		e.log.Error(e.ctx, "workspace scheduling errgroup failed", slog.Error(err))
 	}
 
+	e.notifyApproachingAutostop(currentTick)
+
 	return stats
 }
 
+// notifyApproachingAutostop warns the owners of workspaces whose deadline is
+// within autostopWarning of currentTick. Each deadline is claimed in the
+// database before its owner is warned, so owners are warned once even if the
+// executor ticks late or runs on several replicas.
+func (e *Executor) notifyApproachingAutostop(currentTick time.Time) {
+	if e.autostopWarning <= 0 {
+		return
+	}
+
+	workspaces, err := e.db.ClaimWorkspacesApproachingAutostop(e.ctx, database.ClaimWorkspacesApproachingAutostopParams{
+		Now:        currentTick,
+		WarnBefore: currentTick.Add(e.autostopWarning),
+	})
+	if err != nil {
+		e.log.Error(e.ctx, "claim workspaces approaching autostop", slog.Error(err))
+		return
+	}
+
+	for _, ws := range workspaces {
+		log := e.log.With(slog.F("workspace_id", ws.ID))
+
+		extendToken, err := SignExtendToken(e.extendTokenKey, ExtendToken{
+			WorkspaceID:      ws.ID,
+			PreviousDeadline: ws.Deadline,
+			Deadline:         ws.Deadline.Add(AutostopWarningExtension),
+			Expiry:           ws.Deadline,
+		})
+		if err != nil {
+			log.Error(e.ctx, "failed to sign extend token", slog.Error(err))
+			continue
+		}
+
+		if _, err := e.notificationsEnqueuer.Enqueue(e.ctx, ws.OwnerID, notifications.TemplateWorkspaceAutostopApproaching,
+			map[string]string{
+				"name":           ws.Name,
+				"time_remaining": HumanizeDuration(ws.Deadline.Sub(currentTick)),
+				"deadline":       ws.Deadline.UTC().Format(time.RFC1123),
+				"extension":      HumanizeDuration(AutostopWarningExtension),
+				"extend_token":   extendToken,
+			}, "autobuild",
+			// Associate this notification with all the related entities.
+			ws.ID, ws.OwnerID, ws.TemplateID, ws.OrganizationID,
+		); err != nil {
+			notifications.LogEnqueueError(e.ctx, log, "failed to notify of approaching autostop", err)
+		}
+	}
+}
+
 // getNextTransition returns the next eligible transition for the workspace
 // as well as the reason for why it is transitioning. It is possible
 // for this function to return a nil error as well as an empty transition.
@@ -394,6 +470,8 @@ func getNextTransition(
 
 	case isEligibleForDelete(ws, templateSchedule, latestBuild, latestJob, currentTick):
 		return database.WorkspaceTransitionDelete, database.BuildReasonAutodelete, nil
+	case isEligibleForIdleStop(ws, latestBuild, latestJob, templateSchedule, currentTick):
+		return database.WorkspaceTransitionStop, database.BuildReasonIdle, nil
 	default:
 		return "", "", xerrors.Errorf("last transition not valid for autostart or autostop")
 	}
@@ -487,6 +565,30 @@ func isEligibleForDelete(ws database.Workspace, templateSchedule schedule.Templa
 	return eligible
 }
 
+// isEligibleForIdleStop returns true if the workspace has had no open sessions
+// and CPU usage below the template's threshold for longer than the template's
+// idle timeout.
+func isEligibleForIdleStop(ws database.Workspace, build database.WorkspaceBuild, job database.ProvisionerJob, templateSchedule schedule.TemplateScheduleOptions, currentTick time.Time) bool {
+	// The template must specify an idle_timeout value.
+	if templateSchedule.IdleTimeout <= 0 {
+		return false
+	}
+
+	// Only running workspaces that are not dormant can be stopped.
+	if ws.DormantAt.Valid ||
+		build.Transition != database.WorkspaceTransitionStart ||
+		job.JobStatus != database.ProvisionerJobStatusSucceeded {
+		return false
+	}
+
+	// A workspace that was just started has not had a chance to be used yet.
+	idleSince := ws.LastActiveAt
+	if job.CompletedAt.Valid && job.CompletedAt.Time.After(idleSince) {
+		idleSince = job.CompletedAt.Time
+	}
+	return !currentTick.Before(idleSince.Add(templateSchedule.IdleTimeout))
+}
+
 // isEligibleForFailedStop returns true if the workspace is eligible to be stopped
 // due to a failed build.
 func isEligibleForFailedStop(build database.WorkspaceBuild, job database.ProvisionerJob, templateSchedule schedule.TemplateScheduleOptions, currentTick time.Time) bool {
@@ -18,6 +18,7 @@ import (
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
@@ -905,6 +906,66 @@ func TestExecutorAutostopTemplateDisabled(t *testing.T) {
 	assert.Equal(t, database.WorkspaceTransitionStop, stats.Transitions[workspace.ID])
 }
 
+func TestExecutorIdleStop(t *testing.T) {
+	t.Parallel()
+
+	// Given: we have a workspace built from a template with an idle timeout
+	var (
+		idleTimeout = time.Hour
+		tickCh      = make(chan time.Time)
+		statsCh     = make(chan autobuild.Stats)
+
+		client, db = coderdtest.NewWithDatabase(t, &coderdtest.Options{
+			AutobuildTicker:          tickCh,
+			IncludeProvisionerDaemon: true,
+			AutobuildStats:           statsCh,
+			// We are using a mock store here as the AGPL store does not implement this.
+			TemplateScheduleStore: schedule.MockTemplateScheduleStore{
+				GetFn: func(_ context.Context, _ database.Store, _ uuid.UUID) (schedule.TemplateScheduleOptions, error) {
+					return schedule.TemplateScheduleOptions{
+						UserAutostopEnabled: true,
+						IdleTimeout:         idleTimeout,
+					}, nil
+				},
+			},
+		})
+		workspace = mustProvisionWorkspace(t, client)
+	)
+	ctx := testutil.Context(t, testutil.WaitShort)
+	//nolint:gocritic // The idle timeout is only stored by the enterprise schedule store.
+	err := db.UpdateTemplateScheduleByID(dbauthz.AsSystemRestricted(ctx), database.UpdateTemplateScheduleByIDParams{
+		ID:          workspace.TemplateID,
+		UpdatedAt:   dbtime.Now(),
+		IdleTimeout: int64(idleTimeout),
+	})
+	require.NoError(t, err)
+
+	// When: the autobuild executor ticks before the idle timeout
+	go func() {
+		tickCh <- workspace.LatestBuild.Job.CompletedAt.Add(idleTimeout / 2)
+	}()
+
+	// Then: nothing should happen
+	stats := <-statsCh
+	assert.Len(t, stats.Errors, 0)
+	assert.Len(t, stats.Transitions, 0)
+
+	// When: the autobuild executor ticks after the idle timeout
+	go func() {
+		tickCh <- workspace.LatestBuild.Job.CompletedAt.Add(idleTimeout + time.Minute)
+		close(tickCh)
+	}()
+
+	// Then: the workspace should be stopped for being idle
+	stats = <-statsCh
+	assert.Len(t, stats.Errors, 0)
+	assert.Len(t, stats.Transitions, 1)
+	assert.Equal(t, database.WorkspaceTransitionStop, stats.Transitions[workspace.ID])
+
+	workspace = coderdtest.MustWorkspace(t, client, workspace.ID)
+	assert.Equal(t, codersdk.BuildReasonIdle, workspace.LatestBuild.Reason)
+}
+
 // Test that an AGPL AccessControlStore properly disables
 // functionality.
 func TestExecutorRequireActiveVersion(t *testing.T) {
@@ -1124,6 +1185,63 @@ func TestNotifications(t *testing.T) {
 		require.Contains(t, notifyEnq.Sent[0].Targets, workspace.OwnerID)
 		require.Equal(t, notifyEnq.Sent[0].Labels["initiator"], "autobuild")
 	})
+
+	t.Run("AutostopApproaching", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ticker    = make(chan time.Time)
+			statCh    = make(chan autobuild.Stats)
+			notifyEnq = testutil.FakeNotificationsEnqueuer{}
+			client    = coderdtest.New(t, &coderdtest.Options{
+				AutobuildTicker:          ticker,
+				AutobuildStats:           statCh,
+				IncludeProvisionerDaemon: true,
+				NotificationsEnqueuer:    &notifyEnq,
+			})
+			// Given: we have a user with a running workspace
+			workspace = mustProvisionWorkspace(t, client)
+		)
+		require.Equal(t, codersdk.WorkspaceTransitionStart, workspace.LatestBuild.Transition)
+		require.NotZero(t, workspace.LatestBuild.Deadline)
+		deadline := workspace.LatestBuild.Deadline.Time
+
+		// When: the executor ticks well before the deadline
+		ticker <- deadline.Add(-time.Hour)
+		_ = testutil.RequireRecvCtx(testutil.Context(t, testutil.WaitShort), t, statCh)
+
+		// Then: no notification is enqueued
+		require.Len(t, notifyEnq.Sent, 0)
+
+		// When: the executor ticks late, 25 minutes before the deadline
+		ticker <- deadline.Add(-25 * time.Minute)
+		stats := testutil.RequireRecvCtx(testutil.Context(t, testutil.WaitShort), t, statCh)
+
+		// Then: the workspace is not stopped, but its owner is warned
+		require.Len(t, stats.Transitions, 0)
+		require.Len(t, notifyEnq.Sent, 1)
+		require.Equal(t, workspace.OwnerID, notifyEnq.Sent[0].UserID)
+		require.Equal(t, notifications.TemplateWorkspaceAutostopApproaching, notifyEnq.Sent[0].TemplateID)
+		require.Contains(t, notifyEnq.Sent[0].Targets, workspace.ID)
+		require.Contains(t, notifyEnq.Sent[0].Targets, workspace.TemplateID)
+		require.Equal(t, workspace.Name, notifyEnq.Sent[0].Labels["name"])
+		require.Equal(t, "25 minutes", notifyEnq.Sent[0].Labels["time_remaining"])
+
+		// And: the warning contains a token to extend the deadline by an hour
+		tok, err := autobuild.VerifyExtendToken(coderdtest.AppSecurityKey, notifyEnq.Sent[0].Labels["extend_token"])
+		require.NoError(t, err)
+		require.Equal(t, workspace.ID, tok.WorkspaceID)
+		require.WithinDuration(t, deadline, tok.PreviousDeadline, time.Second)
+		require.WithinDuration(t, deadline.Add(autobuild.AutostopWarningExtension), tok.Deadline, time.Second)
+
+		// When: the executor ticks again a minute later
+		ticker <- deadline.Add(-24 * time.Minute)
+		_ = testutil.RequireRecvCtx(testutil.Context(t, testutil.WaitShort), t, statCh)
+
+		// Then: the owner is not warned twice
+		require.Len(t, notifyEnq.Sent, 1)
+		close(ticker)
+	})
 }
 
 func mustProvisionWorkspace(t *testing.T, client *codersdk.Client, mut ...func(*codersdk.CreateWorkspaceRequest)) codersdk.Workspace {
@@ -67,6 +67,7 @@ import (
 	"github.com/coder/coder/v2/coderd/rbac/rolestore"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
+	"github.com/coder/coder/v2/coderd/templategitsync"
 	"github.com/coder/coder/v2/coderd/tracing"
 	"github.com/coder/coder/v2/coderd/updatecheck"
 	"github.com/coder/coder/v2/coderd/util/slice"
@@ -232,6 +233,9 @@ type Options struct {
 	// DatabaseRolluper rolls up template usage stats from raw agent and app
 	// stats. This is used to provide insights in the WebUI.
 	DatabaseRolluper *dbrollup.Rolluper
+	// TemplateGitSyncer creates template versions from the Git repositories
+	// that templates are linked to.
+	TemplateGitSyncer *templategitsync.Syncer
 	// WorkspaceUsageTracker tracks workspace usage by the CLI.
 	WorkspaceUsageTracker *workspacestats.UsageTracker
 	// NotificationsEnqueuer handles enqueueing notifications for delivery by SMTP, webhook, etc.
@@ -417,6 +421,9 @@ func New(options *Options) *API {
 	if options.DatabaseRolluper == nil {
 		options.DatabaseRolluper = dbrollup.New(options.Logger.Named("dbrollup"), options.Database)
 	}
+	if options.TemplateGitSyncer == nil {
+		options.TemplateGitSyncer = templategitsync.New(options.Logger.Named("templategitsync"), options.Database, options.Pubsub, options.ExternalAuthConfigs)
+	}
 
 	if options.WorkspaceUsageTracker == nil {
 		options.WorkspaceUsageTracker = workspacestats.NewTracker(options.Database,
@@ -473,7 +480,8 @@ func New(options *Options) *API {
 			options.Database,
 			options.Pubsub,
 		),
-		dbRolluper: options.DatabaseRolluper,
+		dbRolluper:        options.DatabaseRolluper,
+		templateGitSyncer: options.TemplateGitSyncer,
 	}
 
 	var customRoleHandler CustomRoleHandler = &agplCustomRoleHandler{}
@@ -768,28 +776,34 @@ func New(options *Options) *API {
 	// for an external application to use Coder as an OAuth2 provider, not for
 	// logging into Coder with an external OAuth2 provider.
 	r.Route("/oauth2", func(r chi.Router) {
-		r.Use(
-			api.oAuth2ProviderMiddleware,
+		r.Use(api.oAuth2ProviderMiddleware)
+		r.Group(func(r chi.Router) {
 			// Fetch the app as system because in the /tokens route there will be no
 			// authenticated user.
-			httpmw.AsAuthzSystem(httpmw.ExtractOAuth2ProviderApp(options.Database)),
-		)
-		r.Route("/authorize", func(r chi.Router) {
-			r.Use(apiKeyMiddlewareRedirect)
-			r.Get("/", api.getOAuth2ProviderAppAuthorize())
-		})
-		r.Route("/tokens", func(r chi.Router) {
-			r.Group(func(r chi.Router) {
-				r.Use(apiKeyMiddleware)
-				// DELETE on /tokens is not part of the OAuth2 spec.  It is our own
-				// route used to revoke permissions from an application.  It is here for
-				// parity with POST on /tokens.
-				r.Delete("/", api.deleteOAuth2ProviderAppTokens())
+			r.Use(httpmw.AsAuthzSystem(httpmw.ExtractOAuth2ProviderApp(options.Database)))
+			r.Route("/authorize", func(r chi.Router) {
+				r.Use(apiKeyMiddlewareRedirect)
+				r.Get("/", api.getOAuth2ProviderAppAuthorize())
+			})
+			r.Route("/tokens", func(r chi.Router) {
+				r.Group(func(r chi.Router) {
+					r.Use(apiKeyMiddleware)
+					// DELETE on /tokens is not part of the OAuth2 spec.  It is our own
+					// route used to revoke permissions from an application.  It is here for
+					// parity with POST on /tokens.
+					r.Delete("/", api.deleteOAuth2ProviderAppTokens())
+				})
+				// The POST /tokens endpoint will be called from an unauthorized client so
+				// we cannot require an API key.
+				r.Post("/", api.postOAuth2ProviderAppToken())
 			})
-			// The POST /tokens endpoint will be called from an unauthorized client so
-			// we cannot require an API key.
-			r.Post("/", api.postOAuth2ProviderAppToken())
+			// Devices start the device authorization grant without a user.
+			r.Post("/device/authorize", api.postOAuth2ProviderDeviceAuthorization())
 		})
+		// The user enters the code shown by the device here, which identifies the
+		// app, so there is no client ID.
+		r.With(apiKeyMiddlewareRedirect).Get("/device", api.getOAuth2ProviderDeviceVerification())
+		r.With(apiKeyMiddleware).Post("/register", api.postOAuth2ClientRegistration)
 	})
 
 	r.Route("/api/v2", func(r chi.Router) {
@@ -916,23 +930,38 @@ func New(options *Options) *API {
 			})
 		})
 		r.Route("/templates", func(r chi.Router) {
-			r.Use(
-				apiKeyMiddleware,
-			)
-			r.Get("/", api.fetchTemplates(nil))
-			r.Route("/{template}", func(r chi.Router) {
+			// Git providers call the webhook without a session token. It is
+			// authenticated with the webhook secret of the sync instead.
+			r.Post("/{template}/git-sync/webhook", api.templateGitSyncWebhook)
+			r.Group(func(r chi.Router) {
 				r.Use(
-					httpmw.ExtractTemplateParam(options.Database),
+					apiKeyMiddleware,
 				)
-				r.Get("/daus", api.templateDAUs)
-				r.Get("/", api.template)
-				r.Delete("/", api.deleteTemplate)
-				r.Patch("/", api.patchTemplateMeta)
-				r.Route("/versions", func(r chi.Router) {
-					r.Post("/archive", api.postArchiveTemplateVersions)
-					r.Get("/", api.templateVersionsByTemplate)
-					r.Patch("/", api.patchActiveTemplateVersion)
-					r.Get("/{templateversionname}", api.templateVersionByName)
+				r.Get("/", api.fetchTemplates(nil))
+				r.Route("/{template}", func(r chi.Router) {
+					r.Use(
+						httpmw.ExtractTemplateParam(options.Database),
+					)
+					r.Get("/daus", api.templateDAUs)
+					r.Get("/", api.template)
+					r.Delete("/", api.deleteTemplate)
+					r.Patch("/", api.patchTemplateMeta)
+					r.Route("/versions", func(r chi.Router) {
+						r.Post("/archive", api.postArchiveTemplateVersions)
+						r.Get("/", api.templateVersionsByTemplate)
+						r.Patch("/", api.patchActiveTemplateVersion)
+						r.Get("/{templateversionname}", api.templateVersionByName)
+					})
+					r.Route("/channels", func(r chi.Router) {
+						r.Get("/", api.templateReleaseChannels)
+						r.Put("/{channel}", api.putTemplateReleaseChannel)
+					})
+					r.Route("/git-sync", func(r chi.Router) {
+						r.Get("/", api.templateGitSync)
+						r.Put("/", api.putTemplateGitSync)
+						r.Delete("/", api.deleteTemplateGitSync)
+						r.Post("/sync", api.postTemplateGitSyncSync)
+					})
 				})
 			})
 		})
@@ -954,6 +983,7 @@ func New(options *Options) *API {
 			r.Get("/external-auth", api.templateVersionExternalAuth)
 			r.Get("/variables", api.templateVersionVariables)
 			r.Get("/resources", api.templateVersionResources)
+			r.Get("/diff", api.templateVersionDiff)
 			r.Get("/logs", api.templateVersionLogs)
 			r.Route("/dry-run", func(r chi.Router) {
 				r.Post("/", api.postTemplateVersionDryRun)
@@ -1049,6 +1079,16 @@ func New(options *Options) *API {
 					})
 					r.Get("/gitsshkey", api.gitSSHKey)
 					r.Put("/gitsshkey", api.regenerateGitSSHKey)
+					r.Route("/notifications", func(r chi.Router) {
+						r.Get("/preferences", api.userNotificationPreferences)
+						r.Put("/preferences", api.putUserNotificationPreferences)
+						r.Route("/inbox", func(r chi.Router) {
+							r.Get("/", api.userInboxNotifications)
+							r.Get("/watch", api.watchInboxNotifications)
+							r.Put("/mark-all-read", api.putMarkAllInboxNotificationsAsRead)
+							r.Put("/{inbox_notification}/read-status", api.putInboxNotificationReadStatus)
+						})
+					})
 				})
 			})
 		})
@@ -1129,14 +1169,30 @@ func New(options *Options) *API {
 				r.Put("/favorite", api.putFavoriteWorkspace)
 				r.Delete("/favorite", api.deleteFavoriteWorkspace)
 				r.Put("/autoupdates", api.putWorkspaceAutoupdates)
+				r.Put("/release-channel", api.putWorkspaceReleaseChannel)
 				r.Get("/resolve-autostart", api.resolveAutostart)
+				r.Route("/acl", func(r chi.Router) {
+					r.Get("/", api.workspaceACL)
+					r.Patch("/", api.patchWorkspaceACL)
+				})
 				r.Route("/port-share", func(r chi.Router) {
 					r.Get("/", api.workspaceAgentPortShares)
 					r.Post("/", api.postWorkspaceAgentPortShare)
 					r.Delete("/", api.deleteWorkspaceAgentPortShare)
 				})
+				r.Route("/snapshots", func(r chi.Router) {
+					r.Get("/", api.workspaceSnapshots)
+					r.Post("/", api.postWorkspaceSnapshot)
+					r.Post("/{snapshot}/restore", api.postWorkspaceSnapshotRestore)
+				})
 			})
 		})
+		r.Route("/extend-workspace", func(r chi.Router) {
+			// Authenticated by a signed token rather than an API key, as this
+			// is linked to from autostop warnings.
+			r.Get("/", api.extendWorkspaceByTokenPage)
+			r.Post("/", api.extendWorkspaceByToken)
+		})
 		r.Route("/workspacebuilds/{workspacebuild}", func(r chi.Router) {
 			r.Use(
 				apiKeyMiddleware,
@@ -1245,6 +1301,16 @@ func New(options *Options) *API {
 			r.Use(apiKeyMiddleware)
 			r.Get("/settings", api.notificationsSettings)
 			r.Put("/settings", api.putNotificationsSettings)
+			r.Route("/templates", func(r chi.Router) {
+				r.Get("/system", api.systemNotificationTemplates)
+				r.Route("/{notification_template}", func(r chi.Router) {
+					r.Get("/", api.notificationTemplate)
+					r.Put("/", api.putNotificationTemplate)
+					r.Put("/mandatory", api.putNotificationTemplateMandatory)
+					r.Delete("/override", api.deleteNotificationTemplateOverride)
+					r.Post("/test", api.postTestNotificationTemplate)
+				})
+			})
 		})
 	})
 
@@ -1362,6 +1428,9 @@ type API struct {
 	// dbRolluper rolls up template usage stats from raw agent and app
 	// stats. This is used to provide insights in the WebUI.
 	dbRolluper *dbrollup.Rolluper
+	// templateGitSyncer creates template versions from the Git repositories
+	// that templates are linked to.
+	templateGitSyncer *templategitsync.Syncer
 }
 
 // Close waits for all WebSocket connections to drain before returning.
@@ -1395,6 +1464,7 @@ func (api *API) Close() error {
 	}
 
 	api.dbRolluper.Close()
+	api.templateGitSyncer.Close()
 	api.metricsCache.Close()
 	if api.updateChecker != nil {
 		api.updateChecker.Close()
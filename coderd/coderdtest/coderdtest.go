@@ -68,6 +68,7 @@ import (
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
+	"github.com/coder/coder/v2/coderd/templategitsync"
 	"github.com/coder/coder/v2/coderd/unhanger"
 	"github.com/coder/coder/v2/coderd/updatecheck"
 	"github.com/coder/coder/v2/coderd/util/ptr"
@@ -153,6 +154,7 @@ type Options struct {
 	AllowWorkspaceRenames              bool
 	NewTicker                          func(duration time.Duration) (<-chan time.Time, func())
 	DatabaseRolluper                   *dbrollup.Rolluper
+	TemplateGitFetcher                 templategitsync.Fetcher
 	WorkspaceUsageTrackerFlush         chan int
 	WorkspaceUsageTrackerTick          chan time.Time
 
@@ -316,7 +318,8 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 		*options.Logger,
 		options.AutobuildTicker,
 		options.NotificationsEnqueuer,
-	).WithStatsChannel(options.AutobuildStats)
+	).WithStatsChannel(options.AutobuildStats).
+		WithAutostopWarning(options.DeploymentValues.Notifications.AutostopWarning.Value(), AppSecurityKey)
 	lifecycleExecutor.Run()
 
 	hangDetectorTicker := time.NewTicker(options.DeploymentValues.JobHangDetectorInterval.Value())
@@ -325,6 +328,15 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 	hangDetector.Start()
 	t.Cleanup(hangDetector.Close)
 
+	var templateGitSyncer *templategitsync.Syncer
+	if options.TemplateGitFetcher != nil {
+		templateGitSyncer = templategitsync.New(options.Logger.Named("templategitsync"), options.Database, options.Pubsub, options.ExternalAuthConfigs,
+			templategitsync.WithFetcher(options.TemplateGitFetcher))
+		t.Cleanup(func() {
+			_ = templateGitSyncer.Close()
+		})
+	}
+
 	// Did last_used_at not update? Scratching your noggin? Here's why.
 	// Workspace usage tracking must be triggered manually in tests.
 	// The vast majority of existing tests do not depend on last_used_at
@@ -508,6 +520,7 @@ func NewOptions(t testing.TB, options *Options) (func(http.Handler), context.Can
 			AllowWorkspaceRenames:              options.AllowWorkspaceRenames,
 			NewTicker:                          options.NewTicker,
 			DatabaseRolluper:                   options.DatabaseRolluper,
+			TemplateGitSyncer:                  templateGitSyncer,
 			WorkspaceUsageTracker:              wuTracker,
 			NotificationsEnqueuer:              options.NotificationsEnqueuer,
 		}
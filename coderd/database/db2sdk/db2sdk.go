@@ -277,6 +277,7 @@ func OAuth2ProviderApp(accessURL *url.URL, dbApp database.OAuth2ProviderApp) cod
 		Name:        dbApp.Name,
 		CallbackURL: dbApp.CallbackURL,
 		Icon:        dbApp.Icon,
+		ClientType:  codersdk.OAuth2ProviderAppClientType(dbApp.ClientType),
 		Endpoints: codersdk.OAuth2AppEndpoints{
 			Authorization: accessURL.ResolveReference(&url.URL{
 				Path: "/oauth2/authorize",
@@ -284,8 +285,9 @@ func OAuth2ProviderApp(accessURL *url.URL, dbApp database.OAuth2ProviderApp) cod
 			Token: accessURL.ResolveReference(&url.URL{
 				Path: "/oauth2/tokens",
 			}).String(),
-			// We do not currently support DeviceAuth.
-			DeviceAuth: "",
+			DeviceAuth: accessURL.ResolveReference(&url.URL{
+				Path: "/oauth2/device/authorize",
+			}).String(),
 		},
 	}
 }
@@ -601,3 +603,74 @@ func Organization(organization database.Organization) codersdk.Organization {
 		IsDefault:   organization.IsDefault,
 	}
 }
+
+func NotificationTemplate(template database.NotificationTemplate) codersdk.NotificationTemplate {
+	effective := template.Effective()
+	return codersdk.NotificationTemplate{
+		ID:            template.ID,
+		Name:          template.Name,
+		TitleTemplate: effective.TitleTemplate,
+		BodyTemplate:  effective.BodyTemplate,
+		Actions:       string(effective.Actions),
+		Group:         template.Group.String,
+		Mandatory:     template.Mandatory,
+		Digest:        template.Digest,
+		Customized:    template.Customized(),
+	}
+}
+
+func NotificationPreference(pref database.NotificationPreference) codersdk.NotificationPreference {
+	var method string
+	if pref.Method.Valid {
+		method = string(pref.Method.NotificationMethod)
+	}
+	return codersdk.NotificationPreference{
+		NotificationTemplateID: pref.NotificationTemplateID,
+		Disabled:               pref.Disabled,
+		Method:                 method,
+		UpdatedAt:              pref.UpdatedAt,
+	}
+}
+
+func InboxNotification(notification database.InboxNotification) codersdk.InboxNotification {
+	// Actions are written by the notifications subsystem, so they are always valid JSON.
+	actions := []codersdk.InboxNotificationAction{}
+	_ = json.Unmarshal(notification.Actions, &actions)
+
+	var readAt *time.Time
+	if notification.ReadAt.Valid {
+		readAt = &notification.ReadAt.Time
+	}
+
+	return codersdk.InboxNotification{
+		ID:        notification.ID,
+		UserID:    notification.UserID,
+		Title:     notification.Title,
+		Content:   notification.Content,
+		Actions:   actions,
+		ReadAt:    readAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func QuotaBudget(budget database.QuotaBudget) codersdk.QuotaBudget {
+	var groupID, userID *uuid.UUID
+	if budget.GroupID.Valid {
+		groupID = &budget.GroupID.UUID
+	}
+	if budget.UserID.Valid {
+		userID = &budget.UserID.UUID
+	}
+	return codersdk.QuotaBudget{
+		ID:             budget.ID,
+		OrganizationID: budget.OrganizationID,
+		GroupID:        groupID,
+		UserID:         userID,
+		Period:         codersdk.QuotaBudgetPeriod(budget.Period),
+		SoftLimit:      budget.SoftLimit,
+		HardLimit:      budget.HardLimit,
+		StopWorkspaces: budget.StopWorkspaces,
+		CreatedAt:      budget.CreatedAt,
+		UpdatedAt:      budget.UpdatedAt,
+	}
+}
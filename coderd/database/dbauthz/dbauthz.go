@@ -238,7 +238,7 @@ var (
 				Site: rbac.Permissions(map[string][]policy.Action{
 					rbac.ResourceWildcard.Type:           {policy.ActionRead},
 					rbac.ResourceApiKey.Type:             rbac.ResourceApiKey.AvailableActions(),
-					rbac.ResourceGroup.Type:              {policy.ActionCreate, policy.ActionUpdate},
+					rbac.ResourceGroup.Type:              {policy.ActionCreate, policy.ActionUpdate, policy.ActionDelete},
 					rbac.ResourceAssignRole.Type:         rbac.ResourceAssignRole.AvailableActions(),
 					rbac.ResourceAssignOrgRole.Type:      rbac.ResourceAssignOrgRole.AvailableActions(),
 					rbac.ResourceSystem.Type:             {policy.WildcardSymbol},
@@ -815,6 +815,14 @@ func (q *querier) customRoleEscalationCheck(ctx context.Context, actor rbac.Subj
 	return nil
 }
 
+func (q *querier) AcquireAuditLogChainHead(ctx context.Context, organizationID uuid.UUID) (database.AuditLogChainHead, error) {
+	// Chain heads are only acquired to insert an audit log.
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceAuditLog); err != nil {
+		return database.AuditLogChainHead{}, err
+	}
+	return q.db.AcquireAuditLogChainHead(ctx, organizationID)
+}
+
 func (q *querier) AcquireLock(ctx context.Context, id int64) error {
 	return q.db.AcquireLock(ctx, id)
 }
@@ -884,6 +892,13 @@ func (q *querier) BulkMarkNotificationMessagesSent(ctx context.Context, arg data
 	return q.db.BulkMarkNotificationMessagesSent(ctx, arg)
 }
 
+func (q *querier) ClaimWorkspacesApproachingAutostop(ctx context.Context, arg database.ClaimWorkspacesApproachingAutostopParams) ([]database.ClaimWorkspacesApproachingAutostopRow, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.ClaimWorkspacesApproachingAutostop(ctx, arg)
+}
+
 func (q *querier) CleanTailnetCoordinators(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceTailnetCoordinator); err != nil {
 		return err
@@ -905,6 +920,24 @@ func (q *querier) CleanTailnetTunnels(ctx context.Context) error {
 	return q.db.CleanTailnetTunnels(ctx)
 }
 
+func (q *querier) ClearNotificationMessageDedupeHash(ctx context.Context, arg database.ClearNotificationMessageDedupeHashParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.ClearNotificationMessageDedupeHash(ctx, arg)
+}
+
+func (q *querier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	u, err := q.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, u); err != nil {
+		return 0, err
+	}
+	return q.db.CountUnreadInboxNotificationsByUserID(ctx, userID)
+}
+
 // TODO: Handle org scoped lookups
 func (q *querier) CustomRoles(ctx context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceAssignRole); err != nil {
@@ -1033,6 +1066,15 @@ func (q *querier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Contex
 	return q.db.DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx, arg)
 }
 
+func (q *querier) DeleteOAuth2ProviderDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	// Device codes are created and exchanged by the system on behalf of
+	// unauthenticated devices.
+	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.DeleteOAuth2ProviderDeviceCodeByID(ctx, id)
+}
+
 func (q *querier) DeleteOldNotificationMessages(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -1079,6 +1121,10 @@ func (q *querier) DeleteProvisionerKey(ctx context.Context, id uuid.UUID) error
 	return deleteQ(q.log, q.auth, q.db.GetProvisionerKeyByID, q.db.DeleteProvisionerKey)(ctx, id)
 }
 
+func (q *querier) DeleteQuotaBudgetByID(ctx context.Context, id uuid.UUID) error {
+	return deleteQ(q.log, q.auth, q.db.GetQuotaBudgetByID, q.db.DeleteQuotaBudgetByID)(ctx, id)
+}
+
 func (q *querier) DeleteReplicasUpdatedBefore(ctx context.Context, updatedAt time.Time) error {
 	if err := q.authorizeContext(ctx, policy.ActionDelete, rbac.ResourceSystem); err != nil {
 		return err
@@ -1121,6 +1167,17 @@ func (q *querier) DeleteTailnetTunnel(ctx context.Context, arg database.DeleteTa
 	return q.db.DeleteTailnetTunnel(ctx, arg)
 }
 
+func (q *querier) DeleteTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) error {
+	template, err := q.db.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return err
+	}
+	return q.db.DeleteTemplateGitSyncByTemplateID(ctx, templateID)
+}
+
 func (q *querier) DeleteWorkspaceAgentPortShare(ctx context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
@@ -1247,6 +1304,13 @@ func (q *querier) GetApplicationName(ctx context.Context) (string, error) {
 	return q.db.GetApplicationName(ctx)
 }
 
+func (q *querier) GetAuditLogChainHeads(ctx context.Context) ([]database.AuditLogChainHead, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceAuditLog); err != nil {
+		return nil, err
+	}
+	return q.db.GetAuditLogChainHeads(ctx)
+}
+
 func (q *querier) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	// To optimize the authz checks for audit logs, do not run an authorize
 	// check on each individual audit log row. In practice, audit logs are either
@@ -1273,6 +1337,18 @@ func (q *querier) GetAuthorizationUserRoles(ctx context.Context, userID uuid.UUI
 	return q.db.GetAuthorizationUserRoles(ctx, userID)
 }
 
+func (q *querier) GetChainedAuditLogs(ctx context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	// Like GetAuditLogsOffset, authorize the scope instead of each row.
+	object := rbac.ResourceAuditLog
+	if arg.OrganizationID != uuid.Nil {
+		object = object.InOrg(arg.OrganizationID)
+	}
+	if err := q.authorizeContext(ctx, policy.ActionRead, object); err != nil {
+		return nil, err
+	}
+	return q.db.GetChainedAuditLogs(ctx, arg)
+}
+
 func (q *querier) GetDBCryptKeys(ctx context.Context) ([]database.DBCryptKey, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -1420,6 +1496,33 @@ func (q *querier) GetHungProvisionerJobs(ctx context.Context, hungSince time.Tim
 	return q.db.GetHungProvisionerJobs(ctx, hungSince)
 }
 
+func (q *querier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	notification, err := q.db.GetInboxNotificationByID(ctx, id)
+	if err != nil {
+		return database.InboxNotification{}, err
+	}
+	// Inbox notifications are personal data of the user they were delivered to.
+	u, err := q.db.GetUserByID(ctx, notification.UserID)
+	if err != nil {
+		return database.InboxNotification{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, u); err != nil {
+		return database.InboxNotification{}, err
+	}
+	return notification, nil
+}
+
+func (q *querier) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	u, err := q.db.GetUserByID(ctx, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, u); err != nil {
+		return nil, err
+	}
+	return q.db.GetInboxNotificationsByUserID(ctx, arg)
+}
+
 func (q *querier) GetJFrogXrayScanByWorkspaceAndAgentID(ctx context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	if _, err := fetch(q.log, q.auth, q.db.GetWorkspaceByID)(ctx, arg.WorkspaceID); err != nil {
 		return database.JfrogXrayScan{}, err
@@ -1484,6 +1587,16 @@ func (q *querier) GetNotificationMessagesByStatus(ctx context.Context, arg datab
 	return q.db.GetNotificationMessagesByStatus(ctx, arg)
 }
 
+func (q *querier) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	// Anyone can read the system notification templates.
+	return q.db.GetNotificationTemplateByID(ctx, id)
+}
+
+func (q *querier) GetNotificationTemplates(ctx context.Context) ([]database.NotificationTemplate, error) {
+	// Anyone can read the system notification templates.
+	return q.db.GetNotificationTemplates(ctx)
+}
+
 func (q *querier) GetNotificationsSettings(ctx context.Context) (string, error) {
 	// No authz checks
 	return q.db.GetNotificationsSettings(ctx)
@@ -1554,6 +1667,14 @@ func (q *querier) GetOAuth2ProviderAppsByUserID(ctx context.Context, userID uuid
 	return q.db.GetOAuth2ProviderAppsByUserID(ctx, userID)
 }
 
+func (q *querier) GetOAuth2ProviderDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (database.OAuth2ProviderDeviceCode, error) {
+	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderDeviceCodeByPrefix)(ctx, secretPrefix)
+}
+
+func (q *querier) GetOAuth2ProviderDeviceCodeByUserCode(ctx context.Context, userCode string) (database.OAuth2ProviderDeviceCode, error) {
+	return fetch(q.log, q.auth, q.db.GetOAuth2ProviderDeviceCodeByUserCode)(ctx, userCode)
+}
+
 func (q *querier) GetOAuthSigningKey(ctx context.Context) (string, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return "", err
@@ -1709,6 +1830,29 @@ func (q *querier) GetQuotaAllowanceForUser(ctx context.Context, userID uuid.UUID
 	return q.db.GetQuotaAllowanceForUser(ctx, userID)
 }
 
+func (q *querier) GetQuotaBudgetByID(ctx context.Context, id uuid.UUID) (database.QuotaBudget, error) {
+	return fetch(q.log, q.auth, q.db.GetQuotaBudgetByID)(ctx, id)
+}
+
+func (q *querier) GetQuotaBudgets(ctx context.Context) ([]database.QuotaBudget, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetQuotaBudgets(ctx)
+}
+
+func (q *querier) GetQuotaBudgetsByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]database.QuotaBudget, error) {
+	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.GetQuotaBudgetsByOrganizationID)(ctx, organizationID)
+}
+
+func (q *querier) GetQuotaBudgetsForUser(ctx context.Context, arg database.GetQuotaBudgetsForUserParams) ([]database.QuotaBudget, error) {
+	err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceUserObject(arg.UserID))
+	if err != nil {
+		return nil, err
+	}
+	return q.db.GetQuotaBudgetsForUser(ctx, arg)
+}
+
 func (q *querier) GetQuotaConsumedForUser(ctx context.Context, userID uuid.UUID) (int64, error) {
 	err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceUserObject(userID))
 	if err != nil {
@@ -1717,6 +1861,15 @@ func (q *querier) GetQuotaConsumedForUser(ctx context.Context, userID uuid.UUID)
 	return q.db.GetQuotaConsumedForUser(ctx, userID)
 }
 
+func (q *querier) GetQuotaSpend(ctx context.Context, arg database.GetQuotaSpendParams) (int64, error) {
+	// Spend is aggregated across the workspaces of many users, so this is
+	// restricted to the system.
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return -1, err
+	}
+	return q.db.GetQuotaSpend(ctx, arg)
+}
+
 func (q *querier) GetReplicaByID(ctx context.Context, id uuid.UUID) (database.Replica, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return database.Replica{}, err
@@ -1805,6 +1958,25 @@ func (q *querier) GetTemplateDAUs(ctx context.Context, arg database.GetTemplateD
 	return q.db.GetTemplateDAUs(ctx, arg)
 }
 
+func (q *querier) GetTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) (database.TemplateGitSync, error) {
+	// The sync includes the webhook secret, so only template admins can read it.
+	template, err := q.db.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return database.TemplateGitSync{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateGitSync{}, err
+	}
+	return q.db.GetTemplateGitSyncByTemplateID(ctx, templateID)
+}
+
+func (q *querier) GetTemplateGitSyncs(ctx context.Context) ([]database.TemplateGitSync, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateGitSyncs(ctx)
+}
+
 func (q *querier) GetTemplateInsights(ctx context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	if err := q.authorizeTemplateInsights(ctx, arg.TemplateIDs); err != nil {
 		return database.GetTemplateInsightsRow{}, err
@@ -1834,6 +2006,29 @@ func (q *querier) GetTemplateParameterInsights(ctx context.Context, arg database
 	return q.db.GetTemplateParameterInsights(ctx, arg)
 }
 
+func (q *querier) GetTemplateReleaseChannelByTemplateIDAndName(ctx context.Context, arg database.GetTemplateReleaseChannelByTemplateIDAndNameParams) (database.TemplateReleaseChannel, error) {
+	// An actor can read release channels if they can read the related template.
+	if _, err := q.GetTemplateByID(ctx, arg.TemplateID); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	return q.db.GetTemplateReleaseChannelByTemplateIDAndName(ctx, arg)
+}
+
+func (q *querier) GetTemplateReleaseChannelsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	// An actor can read release channels if they can read the related template.
+	if _, err := q.GetTemplateByID(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateReleaseChannelsByTemplateID(ctx, templateID)
+}
+
+func (q *querier) GetTemplateReleaseChannelsByTemplateIDs(ctx context.Context, ids []uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetTemplateReleaseChannelsByTemplateIDs(ctx, ids)
+}
+
 func (q *querier) GetTemplateUsageStats(ctx context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	if err := q.authorizeTemplateInsights(ctx, arg.TemplateIDs); err != nil {
 		return nil, err
@@ -2095,6 +2290,17 @@ func (q *querier) GetUserLinksByUserID(ctx context.Context, userID uuid.UUID) ([
 	return q.db.GetUserLinksByUserID(ctx, userID)
 }
 
+func (q *querier) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	u, err := q.db.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionReadPersonal, u); err != nil {
+		return nil, err
+	}
+	return q.db.GetUserNotificationPreferences(ctx, userID)
+}
+
 func (q *querier) GetUserWorkspaceBuildParameters(ctx context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	u, err := q.db.GetUserByID(ctx, params.OwnerID)
 	if err != nil {
@@ -2360,6 +2566,13 @@ func (q *querier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspaceApp
 	return fetch(q.log, q.auth, q.db.GetWorkspaceByWorkspaceAppID)(ctx, workspaceAppID)
 }
 
+func (q *querier) GetWorkspaceOwnerIDsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]uuid.UUID, error) {
+	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceOwnerIDsByTemplateID(ctx, templateID)
+}
+
 func (q *querier) GetWorkspaceProxies(ctx context.Context) ([]database.WorkspaceProxy, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, func(ctx context.Context, _ interface{}) ([]database.WorkspaceProxy, error) {
 		return q.db.GetWorkspaceProxies(ctx)
@@ -2474,6 +2687,26 @@ func (q *querier) GetWorkspaceResourcesCreatedAfter(ctx context.Context, created
 	return q.db.GetWorkspaceResourcesCreatedAfter(ctx, createdAt)
 }
 
+func (q *querier) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	snapshot, err := q.db.GetWorkspaceSnapshotByID(ctx, id)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+	// Snapshots are readable by anyone who can read the workspace, like its
+	// builds.
+	if _, err := q.GetWorkspaceByID(ctx, snapshot.WorkspaceID); err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (q *querier) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	if _, err := q.GetWorkspaceByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return q.db.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspaceID)
+}
+
 func (q *querier) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	if err := q.authorizeContext(ctx, policy.ActionRead, rbac.ResourceSystem); err != nil {
 		return nil, err
@@ -2552,6 +2785,14 @@ func (q *querier) InsertGroupMember(ctx context.Context, arg database.InsertGrou
 	return update(q.log, q.auth, fetch, q.db.InsertGroupMember)(ctx, arg)
 }
 
+func (q *querier) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error {
+	// TODO: restrict this to the notifications subsystem once it has its own role.
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.InsertInboxNotification(ctx, arg)
+}
+
 func (q *querier) InsertLicense(ctx context.Context, arg database.InsertLicenseParams) (database.License, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceLicense); err != nil {
 		return database.License{}, err
@@ -2599,6 +2840,13 @@ func (q *querier) InsertOAuth2ProviderAppToken(ctx context.Context, arg database
 	return q.db.InsertOAuth2ProviderAppToken(ctx, arg)
 }
 
+func (q *querier) InsertOAuth2ProviderDeviceCode(ctx context.Context, arg database.InsertOAuth2ProviderDeviceCodeParams) (database.OAuth2ProviderDeviceCode, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return database.OAuth2ProviderDeviceCode{}, err
+	}
+	return q.db.InsertOAuth2ProviderDeviceCode(ctx, arg)
+}
+
 func (q *querier) InsertOrganization(ctx context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	return insert(q.log, q.auth, rbac.ResourceOrganization, q.db.InsertOrganization)(ctx, arg)
 }
@@ -2640,6 +2888,17 @@ func (q *querier) InsertProvisionerKey(ctx context.Context, arg database.InsertP
 	return insert(q.log, q.auth, rbac.ResourceProvisionerKeys.InOrg(arg.OrganizationID).WithID(arg.ID), q.db.InsertProvisionerKey)(ctx, arg)
 }
 
+func (q *querier) InsertQuotaBudget(ctx context.Context, arg database.InsertQuotaBudgetParams) (database.QuotaBudget, error) {
+	return insert(q.log, q.auth, rbac.ResourceGroup.InOrg(arg.OrganizationID), q.db.InsertQuotaBudget)(ctx, arg)
+}
+
+func (q *querier) InsertQuotaBudgetAlert(ctx context.Context, arg database.InsertQuotaBudgetAlertParams) (int64, error) {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
+		return 0, err
+	}
+	return q.db.InsertQuotaBudgetAlert(ctx, arg)
+}
+
 func (q *querier) InsertReplica(ctx context.Context, arg database.InsertReplicaParams) (database.Replica, error) {
 	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceSystem); err != nil {
 		return database.Replica{}, err
@@ -2655,6 +2914,17 @@ func (q *querier) InsertTemplate(ctx context.Context, arg database.InsertTemplat
 	return q.db.InsertTemplate(ctx, arg)
 }
 
+func (q *querier) InsertTemplateReleaseChannel(ctx context.Context, arg database.InsertTemplateReleaseChannelParams) (database.TemplateReleaseChannel, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	return q.db.InsertTemplateReleaseChannel(ctx, arg)
+}
+
 func (q *querier) InsertTemplateVersion(ctx context.Context, arg database.InsertTemplateVersionParams) error {
 	if !arg.TemplateID.Valid {
 		// Making a new template version is the same permission as creating a new template.
@@ -2868,6 +3138,19 @@ func (q *querier) InsertWorkspaceResourceMetadata(ctx context.Context, arg datab
 	return q.db.InsertWorkspaceResourceMetadata(ctx, arg)
 }
 
+func (q *querier) InsertWorkspaceSnapshot(ctx context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	w, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, w); err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	return q.db.InsertWorkspaceSnapshot(ctx, arg)
+}
+
 func (q *querier) ListProvisionerKeysByOrganization(ctx context.Context, organizationID uuid.UUID) ([]database.ProvisionerKey, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.ListProvisionerKeysByOrganization)(ctx, organizationID)
 }
@@ -2886,12 +3169,23 @@ func (q *querier) ListWorkspaceAgentPortShares(ctx context.Context, workspaceID
 	return q.db.ListWorkspaceAgentPortShares(ctx, workspaceID)
 }
 
+func (q *querier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) error {
+	u, err := q.db.GetUserByID(ctx, arg.UserID)
+	if err != nil {
+		return err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, u); err != nil {
+		return err
+	}
+	return q.db.MarkAllInboxNotificationsAsRead(ctx, arg)
+}
+
 func (q *querier) OrganizationMembers(ctx context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	return fetchWithPostFilter(q.auth, policy.ActionRead, q.db.OrganizationMembers)(ctx, arg)
 }
 
-func (q *querier) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx context.Context, templateID uuid.UUID) error {
-	template, err := q.db.GetTemplateByID(ctx, templateID)
+func (q *querier) ReduceWorkspaceAgentShareLevelByTemplate(ctx context.Context, arg database.ReduceWorkspaceAgentShareLevelByTemplateParams) error {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
 	if err != nil {
 		return err
 	}
@@ -2900,7 +3194,7 @@ func (q *querier) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx co
 		return err
 	}
 
-	return q.db.ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx, templateID)
+	return q.db.ReduceWorkspaceAgentShareLevelByTemplate(ctx, arg)
 }
 
 func (q *querier) RegisterWorkspaceProxy(ctx context.Context, arg database.RegisterWorkspaceProxyParams) (database.WorkspaceProxy, error) {
@@ -2959,6 +3253,13 @@ func (q *querier) UpdateAPIKeyByID(ctx context.Context, arg database.UpdateAPIKe
 	return update(q.log, q.auth, fetch, q.db.UpdateAPIKeyByID)(ctx, arg)
 }
 
+func (q *querier) UpdateAuditLogChainHead(ctx context.Context, arg database.UpdateAuditLogChainHeadParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionCreate, rbac.ResourceAuditLog); err != nil {
+		return err
+	}
+	return q.db.UpdateAuditLogChainHead(ctx, arg)
+}
+
 func (q *querier) UpdateExternalAuthLink(ctx context.Context, arg database.UpdateExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	fetch := func(ctx context.Context, arg database.UpdateExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 		return q.db.GetExternalAuthLink(ctx, database.GetExternalAuthLinkParams{UserID: arg.UserID, ProviderID: arg.ProviderID})
@@ -2987,6 +3288,21 @@ func (q *querier) UpdateInactiveUsersToDormant(ctx context.Context, lastSeenAfte
 	return q.db.UpdateInactiveUsersToDormant(ctx, lastSeenAfter)
 }
 
+func (q *querier) UpdateInboxNotificationReadStatus(ctx context.Context, arg database.UpdateInboxNotificationReadStatusParams) error {
+	notification, err := q.db.GetInboxNotificationByID(ctx, arg.ID)
+	if err != nil {
+		return err
+	}
+	u, err := q.db.GetUserByID(ctx, notification.UserID)
+	if err != nil {
+		return err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, u); err != nil {
+		return err
+	}
+	return q.db.UpdateInboxNotificationReadStatus(ctx, arg)
+}
+
 func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	// Authorized fetch will check that the actor has read access to the org member since the org member is returned.
 	member, err := database.ExpectOne(q.OrganizationMembers(ctx, database.OrganizationMembersParams{
@@ -3021,6 +3337,20 @@ func (q *querier) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemb
 	return q.db.UpdateMemberRoles(ctx, arg)
 }
 
+func (q *querier) UpdateNotificationTemplateMandatoryByID(ctx context.Context, arg database.UpdateNotificationTemplateMandatoryByIDParams) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.UpdateNotificationTemplateMandatoryByID(ctx, arg)
+}
+
+func (q *querier) UpdateNotificationTemplateOverrideByID(ctx context.Context, arg database.UpdateNotificationTemplateOverrideByIDParams) (database.NotificationTemplate, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceDeploymentConfig); err != nil {
+		return database.NotificationTemplate{}, err
+	}
+	return q.db.UpdateNotificationTemplateOverrideByID(ctx, arg)
+}
+
 func (q *querier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceOauth2App); err != nil {
 		return database.OAuth2ProviderApp{}, err
@@ -3035,6 +3365,20 @@ func (q *querier) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg dat
 	return q.db.UpdateOAuth2ProviderAppSecretByID(ctx, arg)
 }
 
+func (q *querier) UpdateOAuth2ProviderDeviceCodeLastPolledAt(ctx context.Context, arg database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.UpdateOAuth2ProviderDeviceCodeLastPolledAt(ctx, arg)
+}
+
+func (q *querier) UpdateOAuth2ProviderDeviceCodeStatus(ctx context.Context, arg database.UpdateOAuth2ProviderDeviceCodeStatusParams) (database.OAuth2ProviderDeviceCode, error) {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return database.OAuth2ProviderDeviceCode{}, err
+	}
+	return q.db.UpdateOAuth2ProviderDeviceCodeStatus(ctx, arg)
+}
+
 func (q *querier) UpdateOrganization(ctx context.Context, arg database.UpdateOrganizationParams) (database.Organization, error) {
 	fetch := func(ctx context.Context, arg database.UpdateOrganizationParams) (database.Organization, error) {
 		return q.db.GetOrganizationByID(ctx, arg.ID)
@@ -3132,6 +3476,13 @@ func (q *querier) UpdateProvisionerJobWithCompleteByID(ctx context.Context, arg
 	return q.db.UpdateProvisionerJobWithCompleteByID(ctx, arg)
 }
 
+func (q *querier) UpdateQuotaBudgetByID(ctx context.Context, arg database.UpdateQuotaBudgetByIDParams) (database.QuotaBudget, error) {
+	fetch := func(ctx context.Context, arg database.UpdateQuotaBudgetByIDParams) (database.QuotaBudget, error) {
+		return q.db.GetQuotaBudgetByID(ctx, arg.ID)
+	}
+	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateQuotaBudgetByID)(ctx, arg)
+}
+
 func (q *querier) UpdateReplica(ctx context.Context, arg database.UpdateReplicaParams) (database.Replica, error) {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return database.Replica{}, err
@@ -3167,6 +3518,13 @@ func (q *querier) UpdateTemplateDeletedByID(ctx context.Context, arg database.Up
 	return q.SoftDeleteTemplateByID(ctx, arg.ID)
 }
 
+func (q *querier) UpdateTemplateGitSyncStatus(ctx context.Context, arg database.UpdateTemplateGitSyncStatusParams) error {
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
+		return err
+	}
+	return q.db.UpdateTemplateGitSyncStatus(ctx, arg)
+}
+
 func (q *querier) UpdateTemplateMetaByID(ctx context.Context, arg database.UpdateTemplateMetaByIDParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateTemplateMetaByIDParams) (database.Template, error) {
 		return q.db.GetTemplateByID(ctx, arg.ID)
@@ -3174,6 +3532,23 @@ func (q *querier) UpdateTemplateMetaByID(ctx context.Context, arg database.Updat
 	return update(q.log, q.auth, fetch, q.db.UpdateTemplateMetaByID)(ctx, arg)
 }
 
+func (q *querier) UpdateTemplateReleaseChannelByID(ctx context.Context, arg database.UpdateTemplateReleaseChannelByIDParams) (database.TemplateReleaseChannel, error) {
+	// Channels can only point at versions of their own template, so the
+	// version identifies the template to authorize against.
+	tv, err := q.db.GetTemplateVersionByID(ctx, arg.TemplateVersionID)
+	if err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	template, err := q.db.GetTemplateByID(ctx, tv.TemplateID.UUID)
+	if err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+	return q.db.UpdateTemplateReleaseChannelByID(ctx, arg)
+}
+
 func (q *querier) UpdateTemplateScheduleByID(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) (database.Template, error) {
 		return q.db.GetTemplateByID(ctx, arg.ID)
@@ -3378,6 +3753,15 @@ func (q *querier) UpdateWorkspace(ctx context.Context, arg database.UpdateWorksp
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateWorkspace)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) (database.Workspace, error) {
+		return q.db.GetWorkspaceByID(ctx, arg.ID)
+	}
+	// Like templates, sharing uses the ActionCreate action so that only users
+	// who own the workspace, not users it is shared with, may change the ACL.
+	return fetchAndExec(q.log, q.auth, policy.ActionCreate, fetch, q.db.UpdateWorkspaceACLByID)(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -3532,6 +3916,13 @@ func (q *querier) UpdateWorkspaceDormantDeletingAt(ctx context.Context, arg data
 	return updateWithReturn(q.log, q.auth, fetch, q.db.UpdateWorkspaceDormantDeletingAt)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceLastActiveAt(ctx context.Context, arg database.UpdateWorkspaceLastActiveAtParams) error {
+	fetch := func(ctx context.Context, arg database.UpdateWorkspaceLastActiveAtParams) (database.Workspace, error) {
+		return q.db.GetWorkspaceByID(ctx, arg.ID)
+	}
+	return update(q.log, q.auth, fetch, q.db.UpdateWorkspaceLastActiveAt)(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceLastUsedAt(ctx context.Context, arg database.UpdateWorkspaceLastUsedAtParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceLastUsedAtParams) (database.Workspace, error) {
 		return q.db.GetWorkspaceByID(ctx, arg.ID)
@@ -3553,6 +3944,19 @@ func (q *querier) UpdateWorkspaceProxyDeleted(ctx context.Context, arg database.
 	return deleteQ(q.log, q.auth, fetch, q.db.UpdateWorkspaceProxyDeleted)(ctx, arg)
 }
 
+func (q *querier) UpdateWorkspaceReleaseChannel(ctx context.Context, arg database.UpdateWorkspaceReleaseChannelParams) error {
+	workspace, err := q.db.GetWorkspaceByID(ctx, arg.ID)
+	if err != nil {
+		return err
+	}
+
+	err = q.authorizeContext(ctx, policy.ActionUpdate, workspace.RBACObject())
+	if err != nil {
+		return err
+	}
+	return q.db.UpdateWorkspaceReleaseChannel(ctx, arg)
+}
+
 func (q *querier) UpdateWorkspaceTTL(ctx context.Context, arg database.UpdateWorkspaceTTLParams) error {
 	fetch := func(ctx context.Context, arg database.UpdateWorkspaceTTLParams) (database.Workspace, error) {
 		return q.db.GetWorkspaceByID(ctx, arg.ID)
@@ -3791,6 +4195,17 @@ func (q *querier) UpsertTailnetTunnel(ctx context.Context, arg database.UpsertTa
 	return q.db.UpsertTailnetTunnel(ctx, arg)
 }
 
+func (q *querier) UpsertTemplateGitSync(ctx context.Context, arg database.UpsertTemplateGitSyncParams) (database.TemplateGitSync, error) {
+	template, err := q.db.GetTemplateByID(ctx, arg.TemplateID)
+	if err != nil {
+		return database.TemplateGitSync{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdate, template); err != nil {
+		return database.TemplateGitSync{}, err
+	}
+	return q.db.UpsertTemplateGitSync(ctx, arg)
+}
+
 func (q *querier) UpsertTemplateUsageStats(ctx context.Context) error {
 	if err := q.authorizeContext(ctx, policy.ActionUpdate, rbac.ResourceSystem); err != nil {
 		return err
@@ -3798,6 +4213,17 @@ func (q *querier) UpsertTemplateUsageStats(ctx context.Context) error {
 	return q.db.UpsertTemplateUsageStats(ctx)
 }
 
+func (q *querier) UpsertUserNotificationPreference(ctx context.Context, arg database.UpsertUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	u, err := q.db.GetUserByID(ctx, arg.UserID)
+	if err != nil {
+		return database.NotificationPreference{}, err
+	}
+	if err := q.authorizeContext(ctx, policy.ActionUpdatePersonal, u); err != nil {
+		return database.NotificationPreference{}, err
+	}
+	return q.db.UpsertUserNotificationPreference(ctx, arg)
+}
+
 func (q *querier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	workspace, err := q.db.GetWorkspaceByID(ctx, arg.WorkspaceID)
 	if err != nil {
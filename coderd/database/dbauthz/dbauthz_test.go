@@ -268,6 +268,30 @@ func (s *MethodTestSuite) TestAuditLogs() {
 			LimitOpt: 10,
 		}).Asserts(rbac.ResourceAuditLog, policy.ActionRead)
 	}))
+	s.Run("AcquireAuditLogChainHead", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.New()).Asserts(rbac.ResourceAuditLog, policy.ActionCreate)
+	}))
+	s.Run("UpdateAuditLogChainHead", s.Subtest(func(db database.Store, check *expects) {
+		head, err := db.AcquireAuditLogChainHead(context.Background(), uuid.New())
+		require.NoError(s.T(), err)
+		check.Args(database.UpdateAuditLogChainHeadParams{
+			OrganizationID: head.OrganizationID,
+			ChainIndex:     1,
+			Hash:           []byte("hash"),
+			UpdatedAt:      dbtime.Now(),
+		}).Asserts(rbac.ResourceAuditLog, policy.ActionCreate).Returns()
+	}))
+	s.Run("GetAuditLogChainHeads", s.Subtest(func(db database.Store, check *expects) {
+		_, err := db.AcquireAuditLogChainHead(context.Background(), uuid.New())
+		require.NoError(s.T(), err)
+		check.Args().Asserts(rbac.ResourceAuditLog, policy.ActionRead)
+	}))
+	s.Run("GetChainedAuditLogs", s.Subtest(func(db database.Store, check *expects) {
+		_ = dbgen.AuditLog(s.T(), db, database.AuditLog{})
+		check.Args(database.GetChainedAuditLogsParams{
+			LimitOpt: 10,
+		}).Asserts(rbac.ResourceAuditLog, policy.ActionRead)
+	}))
 }
 
 func (s *MethodTestSuite) TestFile() {
@@ -786,6 +810,63 @@ func (s *MethodTestSuite) TestTemplate() {
 		t1 := dbgen.Template(s.T(), db, database.Template{})
 		check.Args(t1.ID).Asserts(t1, policy.ActionRead).Returns(t1)
 	}))
+	s.Run("GetTemplateReleaseChannelsByTemplateID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true}})
+		channel := dbgen.TemplateReleaseChannel(s.T(), db, database.TemplateReleaseChannel{TemplateID: t1.ID, TemplateVersionID: tv.ID})
+		check.Args(t1.ID).Asserts(t1, policy.ActionRead).Returns([]database.TemplateReleaseChannel{channel})
+	}))
+	s.Run("GetTemplateReleaseChannelByTemplateIDAndName", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true}})
+		channel := dbgen.TemplateReleaseChannel(s.T(), db, database.TemplateReleaseChannel{TemplateID: t1.ID, TemplateVersionID: tv.ID, Name: "stable"})
+		check.Args(database.GetTemplateReleaseChannelByTemplateIDAndNameParams{
+			TemplateID: t1.ID,
+			Name:       "Stable",
+		}).Asserts(t1, policy.ActionRead).Returns(channel)
+	}))
+	s.Run("InsertTemplateReleaseChannel", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true}})
+		check.Args(database.InsertTemplateReleaseChannelParams{
+			ID:                uuid.New(),
+			TemplateID:        t1.ID,
+			Name:              "canary",
+			TemplateVersionID: tv.ID,
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("UpdateTemplateReleaseChannelByID", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true}})
+		channel := dbgen.TemplateReleaseChannel(s.T(), db, database.TemplateReleaseChannel{TemplateID: t1.ID, TemplateVersionID: tv.ID})
+		check.Args(database.UpdateTemplateReleaseChannelByIDParams{
+			ID:                channel.ID,
+			TemplateVersionID: tv.ID,
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("GetTemplateGitSyncByTemplateID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		sync := dbgen.TemplateGitSync(s.T(), db, database.TemplateGitSync{TemplateID: t1.ID, CreatedBy: u.ID})
+		check.Args(t1.ID).Asserts(t1, policy.ActionUpdate).Returns(sync)
+	}))
+	s.Run("UpsertTemplateGitSync", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		check.Args(database.UpsertTemplateGitSyncParams{
+			TemplateID:    t1.ID,
+			RepositoryURL: "https://example.com/templates.git",
+			Branch:        "main",
+			WebhookSecret: "secret",
+			CreatedBy:     u.ID,
+		}).Asserts(t1, policy.ActionUpdate)
+	}))
+	s.Run("DeleteTemplateGitSyncByTemplateID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		_ = dbgen.TemplateGitSync(s.T(), db, database.TemplateGitSync{TemplateID: t1.ID, CreatedBy: u.ID})
+		check.Args(t1.ID).Asserts(t1, policy.ActionUpdate).Returns()
+	}))
 	s.Run("GetTemplateByOrganizationAndName", s.Subtest(func(db database.Store, check *expects) {
 		o1 := dbgen.Organization(s.T(), db, database.Organization{})
 		t1 := dbgen.Template(s.T(), db, database.Template{
@@ -1056,6 +1137,14 @@ func (s *MethodTestSuite) TestUser() {
 		u := dbgen.User(s.T(), db, database.User{})
 		check.Args(u.ID).Asserts(u, policy.ActionRead).Returns(int64(0))
 	}))
+	s.Run("GetQuotaBudgetsForUser", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		check.Args(database.GetQuotaBudgetsForUserParams{
+			OrganizationID: o.ID,
+			UserID:         u.ID,
+		}).Asserts(u, policy.ActionRead).Returns([]database.QuotaBudget{})
+	}))
 	s.Run("GetUserByEmailOrUsername", s.Subtest(func(db database.Store, check *expects) {
 		u := dbgen.User(s.T(), db, database.User{})
 		check.Args(database.GetUserByEmailOrUsernameParams{
@@ -1639,6 +1728,31 @@ func (s *MethodTestSuite) TestWorkspace() {
 			Value:            []string{"baz", "qux"},
 		}).Asserts(w, policy.ActionUpdate)
 	}))
+	s.Run("InsertWorkspaceSnapshot", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		b := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: w.ID})
+		check.Args(database.InsertWorkspaceSnapshotParams{
+			ID:                uuid.New(),
+			WorkspaceID:       w.ID,
+			WorkspaceBuildID:  b.ID,
+			BuildNumber:       b.BuildNumber,
+			TemplateVersionID: b.TemplateVersionID,
+			Name:              "snapshot",
+			Transition:        database.WorkspaceTransitionStart,
+			RichParameters:    json.RawMessage("[]"),
+			CreatedBy:         w.OwnerID,
+		}).Asserts(w, policy.ActionUpdate)
+	}))
+	s.Run("GetWorkspaceSnapshotByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{WorkspaceID: w.ID})
+		check.Args(snapshot.ID).Asserts(w, policy.ActionRead).Returns(snapshot)
+	}))
+	s.Run("GetWorkspaceSnapshotsByWorkspaceID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		snapshot := dbgen.WorkspaceSnapshot(s.T(), db, database.WorkspaceSnapshot{WorkspaceID: w.ID})
+		check.Args(w.ID).Asserts(w, policy.ActionRead).Returns([]database.WorkspaceSnapshot{snapshot})
+	}))
 	s.Run("UpdateWorkspace", s.Subtest(func(db database.Store, check *expects) {
 		w := dbgen.Workspace(s.T(), db, database.Workspace{})
 		expected := w
@@ -1653,6 +1767,12 @@ func (s *MethodTestSuite) TestWorkspace() {
 			ID: w.ID,
 		}).Asserts(w, policy.ActionUpdate)
 	}))
+	s.Run("UpdateWorkspaceACLByID", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceACLByIDParams{
+			ID: w.ID,
+		}).Asserts(w, policy.ActionCreate)
+	}))
 	s.Run("UpdateWorkspaceAutomaticUpdates", s.Subtest(func(db database.Store, check *expects) {
 		w := dbgen.Workspace(s.T(), db, database.Workspace{})
 		check.Args(database.UpdateWorkspaceAutomaticUpdatesParams{
@@ -1660,6 +1780,13 @@ func (s *MethodTestSuite) TestWorkspace() {
 			AutomaticUpdates: database.AutomaticUpdatesAlways,
 		}).Asserts(w, policy.ActionUpdate)
 	}))
+	s.Run("UpdateWorkspaceReleaseChannel", s.Subtest(func(db database.Store, check *expects) {
+		w := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceReleaseChannelParams{
+			ID:             w.ID,
+			ReleaseChannel: "canary",
+		}).Asserts(w, policy.ActionUpdate)
+	}))
 	s.Run("UpdateWorkspaceAppHealthByID", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		build := dbgen.WorkspaceBuild(s.T(), db, database.WorkspaceBuild{WorkspaceID: ws.ID, JobID: uuid.New()})
@@ -1698,6 +1825,13 @@ func (s *MethodTestSuite) TestWorkspace() {
 			Deleted: true,
 		}).Asserts(ws, policy.ActionDelete).Returns()
 	}))
+	s.Run("UpdateWorkspaceLastActiveAt", s.Subtest(func(db database.Store, check *expects) {
+		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
+		check.Args(database.UpdateWorkspaceLastActiveAtParams{
+			ID:           ws.ID,
+			LastActiveAt: dbtime.Now(),
+		}).Asserts(ws, policy.ActionUpdate).Returns()
+	}))
 	s.Run("UpdateWorkspaceLastUsedAt", s.Subtest(func(db database.Store, check *expects) {
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{})
 		check.Args(database.UpdateWorkspaceLastUsedAtParams{
@@ -1752,11 +1886,13 @@ func (s *MethodTestSuite) TestWorkspacePortSharing() {
 		ps := dbgen.WorkspaceAgentPortShare(s.T(), db, database.WorkspaceAgentPortShare{WorkspaceID: ws.ID})
 		//nolint:gosimple // casting is not a simplification
 		check.Args(database.UpsertWorkspaceAgentPortShareParams{
-			WorkspaceID: ps.WorkspaceID,
-			AgentName:   ps.AgentName,
-			Port:        ps.Port,
-			ShareLevel:  ps.ShareLevel,
-			Protocol:    ps.Protocol,
+			WorkspaceID:     ps.WorkspaceID,
+			AgentName:       ps.AgentName,
+			Port:            ps.Port,
+			ShareLevel:      ps.ShareLevel,
+			Protocol:        ps.Protocol,
+			AllowedUserIDs:  ps.AllowedUserIDs,
+			AllowedGroupIDs: ps.AllowedGroupIDs,
 		}).Asserts(ws, policy.ActionUpdate).Returns(ps)
 	}))
 	s.Run("GetWorkspaceAgentPortShare", s.Subtest(func(db database.Store, check *expects) {
@@ -1792,12 +1928,85 @@ func (s *MethodTestSuite) TestWorkspacePortSharing() {
 		_ = dbgen.WorkspaceAgentPortShare(s.T(), db, database.WorkspaceAgentPortShare{WorkspaceID: ws.ID})
 		check.Args(t.ID).Asserts(t, policy.ActionUpdate).Returns()
 	}))
-	s.Run("ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate", s.Subtest(func(db database.Store, check *expects) {
+	s.Run("ReduceWorkspaceAgentShareLevelByTemplate", s.Subtest(func(db database.Store, check *expects) {
 		u := dbgen.User(s.T(), db, database.User{})
 		t := dbgen.Template(s.T(), db, database.Template{})
 		ws := dbgen.Workspace(s.T(), db, database.Workspace{OwnerID: u.ID, TemplateID: t.ID})
 		_ = dbgen.WorkspaceAgentPortShare(s.T(), db, database.WorkspaceAgentPortShare{WorkspaceID: ws.ID})
-		check.Args(t.ID).Asserts(t, policy.ActionUpdate).Returns()
+		check.Args(database.ReduceWorkspaceAgentShareLevelByTemplateParams{
+			ShareLevel:    database.AppSharingLevelAuthenticated,
+			ReducedLevels: []database.AppSharingLevel{database.AppSharingLevelPublic},
+			TemplateID:    t.ID,
+		}).Asserts(t, policy.ActionUpdate).Returns()
+	}))
+}
+
+func (s *MethodTestSuite) TestQuotaBudgets() {
+	s.Run("InsertQuotaBudget", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		check.Args(database.InsertQuotaBudgetParams{
+			ID:             uuid.New(),
+			OrganizationID: o.ID,
+			GroupID:        uuid.NullUUID{UUID: g.ID, Valid: true},
+			Period:         database.QuotaBudgetPeriodMonth,
+			HardLimit:      100,
+		}).Asserts(rbac.ResourceGroup.InOrg(o.ID), policy.ActionCreate)
+	}))
+	s.Run("GetQuotaBudgetByID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args(b.ID).Asserts(b, policy.ActionRead).Returns(b)
+	}))
+	s.Run("GetQuotaBudgets", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args().Asserts(rbac.ResourceSystem, policy.ActionRead).Returns([]database.QuotaBudget{b})
+	}))
+	s.Run("GetQuotaBudgetsByOrganizationID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args(o.ID).Asserts(b, policy.ActionRead).Returns([]database.QuotaBudget{b})
+	}))
+	s.Run("GetQuotaSpend", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.GetQuotaSpendParams{
+			OrganizationID: o.ID,
+			OwnerIDs:       []uuid.UUID{u.ID},
+			PeriodStart:    dbtime.Now().Add(-time.Hour),
+			PeriodEnd:      dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionRead).Returns(int64(0))
+	}))
+	s.Run("UpdateQuotaBudgetByID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args(database.UpdateQuotaBudgetByIDParams{
+			ID:        b.ID,
+			HardLimit: 200,
+		}).Asserts(b, policy.ActionUpdate)
+	}))
+	s.Run("DeleteQuotaBudgetByID", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args(b.ID).Asserts(b, policy.ActionDelete).Returns()
+	}))
+	s.Run("InsertQuotaBudgetAlert", s.Subtest(func(db database.Store, check *expects) {
+		o := dbgen.Organization(s.T(), db, database.Organization{})
+		u := dbgen.User(s.T(), db, database.User{})
+		g := dbgen.Group(s.T(), db, database.Group{OrganizationID: o.ID})
+		b := dbgen.QuotaBudget(s.T(), db, database.QuotaBudget{OrganizationID: o.ID, GroupID: uuid.NullUUID{UUID: g.ID, Valid: true}})
+		check.Args(database.InsertQuotaBudgetAlertParams{
+			BudgetID:    b.ID,
+			UserID:      u.ID,
+			PeriodStart: dbtime.Now(),
+			CreatedAt:   dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate).Returns(int64(1))
 	}))
 }
 
@@ -2216,6 +2425,31 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 			Asserts(rbac.ResourceSystem, policy.ActionRead).
 			Returns(slice.New(tv1, tv2, tv3))
 	}))
+	s.Run("GetTemplateReleaseChannelsByTemplateIDs", s.Subtest(func(db database.Store, check *expects) {
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{TemplateID: uuid.NullUUID{UUID: t1.ID, Valid: true}})
+		channel := dbgen.TemplateReleaseChannel(s.T(), db, database.TemplateReleaseChannel{TemplateID: t1.ID, TemplateVersionID: tv.ID})
+		check.Args([]uuid.UUID{t1.ID}).
+			Asserts(rbac.ResourceSystem, policy.ActionRead).
+			Returns([]database.TemplateReleaseChannel{channel})
+	}))
+	s.Run("GetTemplateGitSyncs", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		sync := dbgen.TemplateGitSync(s.T(), db, database.TemplateGitSync{TemplateID: t1.ID, CreatedBy: u.ID})
+		check.Args().
+			Asserts(rbac.ResourceSystem, policy.ActionRead).
+			Returns([]database.TemplateGitSync{sync})
+	}))
+	s.Run("UpdateTemplateGitSyncStatus", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		t1 := dbgen.Template(s.T(), db, database.Template{})
+		_ = dbgen.TemplateGitSync(s.T(), db, database.TemplateGitSync{TemplateID: t1.ID, CreatedBy: u.ID})
+		check.Args(database.UpdateTemplateGitSyncStatusParams{
+			TemplateID:    t1.ID,
+			LastCommitSha: "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate).Returns()
+	}))
 	s.Run("GetParameterSchemasByJobID", s.Subtest(func(db database.Store, check *expects) {
 		tpl := dbgen.Template(s.T(), db, database.Template{})
 		tv := dbgen.TemplateVersion(s.T(), db, database.TemplateVersion{
@@ -2477,6 +2711,9 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("GetTemplateAverageBuildTime", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(database.GetTemplateAverageBuildTimeParams{}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("ClaimWorkspacesApproachingAutostop", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.ClaimWorkspacesApproachingAutostopParams{}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
 	s.Run("GetWorkspacesEligibleForTransition", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(time.Time{}).Asserts()
 	}))
@@ -2489,6 +2726,9 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 	s.Run("UpdateInactiveUsersToDormant", s.Subtest(func(db database.Store, check *expects) {
 		check.Args(database.UpdateInactiveUsersToDormantParams{}).Asserts(rbac.ResourceSystem, policy.ActionCreate).Errors(sql.ErrNoRows)
 	}))
+	s.Run("GetWorkspaceOwnerIDsByTemplateID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(uuid.New()).Asserts(rbac.ResourceSystem, policy.ActionRead)
+	}))
 	s.Run("GetWorkspaceUniqueOwnerCountByTemplateIDs", s.Subtest(func(db database.Store, check *expects) {
 		check.Args([]uuid.UUID{uuid.New()}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
@@ -2564,6 +2804,12 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 		// TODO: update this test once we have a specific role for notifications
 		check.Args().Asserts(rbac.ResourceSystem, policy.ActionDelete)
 	}))
+	s.Run("ClearNotificationMessageDedupeHash", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.ClearNotificationMessageDedupeHashParams{
+			DedupeHash:    sql.NullString{String: "hash", Valid: true},
+			CreatedBefore: dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
 	s.Run("EnqueueNotificationMessage", s.Subtest(func(db database.Store, check *expects) {
 		// TODO: update this test once we have a specific role for notifications
 		check.Args(database.EnqueueNotificationMessageParams{
@@ -2583,6 +2829,81 @@ func (s *MethodTestSuite) TestSystemFunctions() {
 			Limit:  10,
 		}).Asserts(rbac.ResourceSystem, policy.ActionRead)
 	}))
+	s.Run("GetNotificationTemplates", s.Subtest(func(db database.Store, check *expects) {
+		// Notification templates are managed by migrations, which dbmem does not run.
+		check.Args().Asserts().Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("GetNotificationTemplateByID", s.Subtest(func(db database.Store, check *expects) {
+		// Notification templates are managed by migrations, which dbmem does not run.
+		check.Args(uuid.New()).Asserts().Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("UpdateNotificationTemplateMandatoryByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.UpdateNotificationTemplateMandatoryByIDParams{
+			ID:        uuid.New(),
+			Mandatory: true,
+		}).Asserts(rbac.ResourceDeploymentConfig, policy.ActionUpdate).Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("UpdateNotificationTemplateOverrideByID", s.Subtest(func(db database.Store, check *expects) {
+		check.Args(database.UpdateNotificationTemplateOverrideByIDParams{
+			ID:                    uuid.New(),
+			TitleTemplateOverride: sql.NullString{String: "Title", Valid: true},
+		}).Asserts(rbac.ResourceDeploymentConfig, policy.ActionUpdate).Errors(dbmem.ErrUnimplemented)
+	}))
+	s.Run("GetUserNotificationPreferences", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(u.ID).Asserts(u, policy.ActionReadPersonal)
+	}))
+	s.Run("UpsertUserNotificationPreference", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.UpsertUserNotificationPreferenceParams{
+			UserID:                 u.ID,
+			NotificationTemplateID: uuid.New(),
+			Disabled:               true,
+		}).Asserts(u, policy.ActionUpdatePersonal)
+	}))
+	s.Run("InsertInboxNotification", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.InsertInboxNotificationParams{
+			ID:        uuid.New(),
+			UserID:    u.ID,
+			Title:     "title",
+			Content:   "content",
+			Actions:   json.RawMessage("[]"),
+			CreatedAt: dbtime.Now(),
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("GetInboxNotificationByID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		n := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: u.ID})
+		check.Args(n.ID).Asserts(u, policy.ActionReadPersonal).Returns(n)
+	}))
+	s.Run("GetInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		n := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: u.ID})
+		check.Args(database.GetInboxNotificationsByUserIDParams{
+			UserID: u.ID,
+		}).Asserts(u, policy.ActionReadPersonal).Returns([]database.InboxNotification{n})
+	}))
+	s.Run("CountUnreadInboxNotificationsByUserID", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		_ = dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: u.ID})
+		check.Args(u.ID).Asserts(u, policy.ActionReadPersonal).Returns(int64(1))
+	}))
+	s.Run("UpdateInboxNotificationReadStatus", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		n := dbgen.InboxNotification(s.T(), db, database.InboxNotification{UserID: u.ID})
+		check.Args(database.UpdateInboxNotificationReadStatusParams{
+			ID:     n.ID,
+			ReadAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(u, policy.ActionUpdatePersonal)
+	}))
+	s.Run("MarkAllInboxNotificationsAsRead", s.Subtest(func(db database.Store, check *expects) {
+		u := dbgen.User(s.T(), db, database.User{})
+		check.Args(database.MarkAllInboxNotificationsAsReadParams{
+			UserID: u.ID,
+			ReadAt: dbtime.Now(),
+		}).Asserts(u, policy.ActionUpdatePersonal)
+	}))
 }
 
 func (s *MethodTestSuite) TestOAuth2ProviderApps() {
@@ -2609,8 +2930,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderApps() {
 		})
 		for i := 0; i < 5; i++ {
 			_ = dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 				APIKeyID:    key.ID,
+				AppID:       app.ID,
 			})
 		}
 		check.Args(user.ID).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionRead).Returns([]database.GetOAuth2ProviderAppsByUserIDRow{
@@ -2620,6 +2942,7 @@ func (s *MethodTestSuite) TestOAuth2ProviderApps() {
 					CallbackURL: app.CallbackURL,
 					Icon:        app.Icon,
 					Name:        app.Name,
+					ClientType:  app.ClientType,
 				},
 				TokenCount: 5,
 			},
@@ -2755,6 +3078,61 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppCodes() {
 	}))
 }
 
+func (s *MethodTestSuite) TestOAuth2ProviderDeviceCodes() {
+	s.Run("InsertOAuth2ProviderDeviceCode", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		check.Args(database.InsertOAuth2ProviderDeviceCodeParams{
+			AppID:  app.ID,
+			Scopes: []string{},
+		}).Asserts(rbac.ResourceSystem, policy.ActionCreate)
+	}))
+	s.Run("GetOAuth2ProviderDeviceCodeByPrefix", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderDeviceCode(s.T(), db, database.OAuth2ProviderDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.SecretPrefix).Asserts(code, policy.ActionRead).Returns(code)
+	}))
+	s.Run("GetOAuth2ProviderDeviceCodeByUserCode", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderDeviceCode(s.T(), db, database.OAuth2ProviderDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.UserCode).Asserts(code, policy.ActionRead).Returns(code)
+	}))
+	s.Run("UpdateOAuth2ProviderDeviceCodeStatus", s.Subtest(func(db database.Store, check *expects) {
+		user := dbgen.User(s.T(), db, database.User{})
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderDeviceCode(s.T(), db, database.OAuth2ProviderDeviceCode{
+			AppID: app.ID,
+		})
+		code.Status = database.OAuth2ProviderDeviceCodeStatusApproved
+		code.UserID = uuid.NullUUID{UUID: user.ID, Valid: true}
+		check.Args(database.UpdateOAuth2ProviderDeviceCodeStatusParams{
+			ID:     code.ID,
+			Status: code.Status,
+			UserID: code.UserID,
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate).Returns(code)
+	}))
+	s.Run("UpdateOAuth2ProviderDeviceCodeLastPolledAt", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderDeviceCode(s.T(), db, database.OAuth2ProviderDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams{
+			ID:           code.ID,
+			LastPolledAt: sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}).Asserts(rbac.ResourceSystem, policy.ActionUpdate)
+	}))
+	s.Run("DeleteOAuth2ProviderDeviceCodeByID", s.Subtest(func(db database.Store, check *expects) {
+		app := dbgen.OAuth2ProviderApp(s.T(), db, database.OAuth2ProviderApp{})
+		code := dbgen.OAuth2ProviderDeviceCode(s.T(), db, database.OAuth2ProviderDeviceCode{
+			AppID: app.ID,
+		})
+		check.Args(code.ID).Asserts(rbac.ResourceSystem, policy.ActionDelete)
+	}))
+}
+
 func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 	s.Run("InsertOAuth2ProviderAppToken", s.Subtest(func(db database.Store, check *expects) {
 		user := dbgen.User(s.T(), db, database.User{})
@@ -2766,8 +3144,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 			AppID: app.ID,
 		})
 		check.Args(database.InsertOAuth2ProviderAppTokenParams{
-			AppSecretID: secret.ID,
+			AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 			APIKeyID:    key.ID,
+			AppID:       app.ID,
 		}).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionCreate)
 	}))
 	s.Run("GetOAuth2ProviderAppTokenByPrefix", s.Subtest(func(db database.Store, check *expects) {
@@ -2780,8 +3159,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 			AppID: app.ID,
 		})
 		token := dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-			AppSecretID: secret.ID,
+			AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 			APIKeyID:    key.ID,
+			AppID:       app.ID,
 		})
 		check.Args(token.HashPrefix).Asserts(rbac.ResourceOauth2AppCodeToken.WithOwner(user.ID.String()), policy.ActionRead)
 	}))
@@ -2796,8 +3176,9 @@ func (s *MethodTestSuite) TestOAuth2ProviderAppTokens() {
 		})
 		for i := 0; i < 5; i++ {
 			_ = dbgen.OAuth2ProviderAppToken(s.T(), db, database.OAuth2ProviderAppToken{
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 				APIKeyID:    key.ID,
+				AppID:       app.ID,
 			})
 		}
 		check.Args(database.DeleteOAuth2ProviderAppTokensByAppAndUserIDParams{
@@ -61,6 +61,8 @@ func AuditLog(t testing.TB, db database.Store, seed database.AuditLog) database.
 		AdditionalFields: takeFirstSlice(seed.Diff, []byte("{}")),
 		RequestID:        takeFirst(seed.RequestID, uuid.New()),
 		ResourceIcon:     takeFirst(seed.ResourceIcon, ""),
+		ChainIndex:       seed.ChainIndex,
+		Hash:             seed.Hash,
 	})
 	require.NoError(t, err, "insert audit log")
 	return log
@@ -131,6 +133,8 @@ func APIKey(t testing.TB, db database.Store, seed database.APIKey) (key database
 		LoginType:       takeFirst(seed.LoginType, database.LoginTypePassword),
 		Scope:           takeFirst(seed.Scope, database.APIKeyScopeAll),
 		TokenName:       takeFirst(seed.TokenName),
+		Scopes:          seed.Scopes,
+		AllowList:       seed.AllowList,
 	})
 	require.NoError(t, err, "insert api key")
 	return key, fmt.Sprintf("%s-%s", key.ID, secret)
@@ -138,11 +142,13 @@ func APIKey(t testing.TB, db database.Store, seed database.APIKey) (key database
 
 func WorkspaceAgentPortShare(t testing.TB, db database.Store, orig database.WorkspaceAgentPortShare) database.WorkspaceAgentPortShare {
 	ps, err := db.UpsertWorkspaceAgentPortShare(genCtx, database.UpsertWorkspaceAgentPortShareParams{
-		WorkspaceID: takeFirst(orig.WorkspaceID, uuid.New()),
-		AgentName:   takeFirst(orig.AgentName, testutil.GetRandomName(t)),
-		Port:        takeFirst(orig.Port, 8080),
-		ShareLevel:  takeFirst(orig.ShareLevel, database.AppSharingLevelPublic),
-		Protocol:    takeFirst(orig.Protocol, database.PortShareProtocolHttp),
+		WorkspaceID:     takeFirst(orig.WorkspaceID, uuid.New()),
+		AgentName:       takeFirst(orig.AgentName, testutil.GetRandomName(t)),
+		Port:            takeFirst(orig.Port, 8080),
+		ShareLevel:      takeFirst(orig.ShareLevel, database.AppSharingLevelPublic),
+		Protocol:        takeFirst(orig.Protocol, database.PortShareProtocolHttp),
+		AllowedUserIDs:  takeFirstSlice(orig.AllowedUserIDs, []uuid.UUID{}),
+		AllowedGroupIDs: takeFirstSlice(orig.AllowedGroupIDs, []uuid.UUID{}),
 	})
 	require.NoError(t, err, "insert workspace agent")
 	return ps
@@ -478,6 +484,46 @@ func ProvisionerKey(t testing.TB, db database.Store, orig database.ProvisionerKe
 	return key
 }
 
+func QuotaBudget(t testing.TB, db database.Store, orig database.QuotaBudget) database.QuotaBudget {
+	groupID := orig.GroupID
+	if !groupID.Valid && !orig.UserID.Valid {
+		// Default to the "Everyone" group of the organization.
+		groupID = uuid.NullUUID{UUID: orig.OrganizationID, Valid: true}
+	}
+	budget, err := db.InsertQuotaBudget(genCtx, database.InsertQuotaBudgetParams{
+		ID:             takeFirst(orig.ID, uuid.New()),
+		OrganizationID: orig.OrganizationID,
+		GroupID:        groupID,
+		UserID:         orig.UserID,
+		Period:         takeFirst(orig.Period, database.QuotaBudgetPeriodMonth),
+		SoftLimit:      orig.SoftLimit,
+		HardLimit:      orig.HardLimit,
+		StopWorkspaces: orig.StopWorkspaces,
+		CreatedAt:      takeFirst(orig.CreatedAt, dbtime.Now()),
+		UpdatedAt:      takeFirst(orig.UpdatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert quota budget")
+	return budget
+}
+
+func WorkspaceSnapshot(t testing.TB, db database.Store, orig database.WorkspaceSnapshot) database.WorkspaceSnapshot {
+	snapshot, err := db.InsertWorkspaceSnapshot(genCtx, database.InsertWorkspaceSnapshotParams{
+		ID:                takeFirst(orig.ID, uuid.New()),
+		WorkspaceID:       takeFirst(orig.WorkspaceID, uuid.New()),
+		WorkspaceBuildID:  takeFirst(orig.WorkspaceBuildID, uuid.New()),
+		BuildNumber:       takeFirst(orig.BuildNumber, 1),
+		TemplateVersionID: takeFirst(orig.TemplateVersionID, uuid.New()),
+		Name:              takeFirst(orig.Name, testutil.GetRandomName(t)),
+		Transition:        takeFirst(orig.Transition, database.WorkspaceTransitionStart),
+		ProvisionerState:  takeFirstSlice(orig.ProvisionerState, []byte("{}")),
+		RichParameters:    takeFirstSlice(orig.RichParameters, []byte("[]")),
+		CreatedBy:         takeFirst(orig.CreatedBy, uuid.New()),
+		CreatedAt:         takeFirst(orig.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert workspace snapshot")
+	return snapshot
+}
+
 func WorkspaceApp(t testing.TB, db database.Store, orig database.WorkspaceApp) database.WorkspaceApp {
 	resource, err := db.InsertWorkspaceApp(genCtx, database.InsertWorkspaceAppParams{
 		ID:          takeFirst(orig.ID, uuid.New()),
@@ -649,6 +695,36 @@ func ExternalAuthLink(t testing.TB, db database.Store, orig database.ExternalAut
 	return link
 }
 
+func TemplateGitSync(t testing.TB, db database.Store, orig database.TemplateGitSync) database.TemplateGitSync {
+	sync, err := db.UpsertTemplateGitSync(genCtx, database.UpsertTemplateGitSyncParams{
+		TemplateID:             takeFirst(orig.TemplateID, uuid.New()),
+		RepositoryURL:          takeFirst(orig.RepositoryURL, "https://example.com/templates.git"),
+		Branch:                 takeFirst(orig.Branch, "main"),
+		Directory:              orig.Directory,
+		ExternalAuthProviderID: orig.ExternalAuthProviderID,
+		AutoActivate:           orig.AutoActivate,
+		WebhookSecret:          takeFirst(orig.WebhookSecret, testutil.GetRandomName(t)),
+		CreatedBy:              takeFirst(orig.CreatedBy, uuid.New()),
+		CreatedAt:              takeFirst(orig.CreatedAt, dbtime.Now()),
+		UpdatedAt:              takeFirst(orig.UpdatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "upsert template git sync")
+	return sync
+}
+
+func TemplateReleaseChannel(t testing.TB, db database.Store, orig database.TemplateReleaseChannel) database.TemplateReleaseChannel {
+	channel, err := db.InsertTemplateReleaseChannel(genCtx, database.InsertTemplateReleaseChannelParams{
+		ID:                takeFirst(orig.ID, uuid.New()),
+		TemplateID:        takeFirst(orig.TemplateID, uuid.New()),
+		Name:              takeFirst(orig.Name, testutil.GetRandomName(t)),
+		TemplateVersionID: takeFirst(orig.TemplateVersionID, uuid.New()),
+		CreatedAt:         takeFirst(orig.CreatedAt, dbtime.Now()),
+		UpdatedAt:         takeFirst(orig.UpdatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert template release channel")
+	return channel
+}
+
 func TemplateVersion(t testing.TB, db database.Store, orig database.TemplateVersion) database.TemplateVersion {
 	var version database.TemplateVersion
 	err := db.InTx(func(db database.Store) error {
@@ -788,6 +864,8 @@ func OAuth2ProviderApp(t testing.TB, db database.Store, seed database.OAuth2Prov
 		UpdatedAt:   takeFirst(seed.UpdatedAt, dbtime.Now()),
 		Icon:        takeFirst(seed.Icon, ""),
 		CallbackURL: takeFirst(seed.CallbackURL, "http://localhost"),
+		ClientType:  takeFirst(seed.ClientType, database.OAuth2ProviderAppClientTypeConfidential),
+		GrantTypes:  takeFirstSlice(seed.GrantTypes, []string{"authorization_code", "refresh_token", "urn:ietf:params:oauth:grant-type:device_code"}),
 	})
 	require.NoError(t, err, "insert oauth2 app")
 	return app
@@ -808,13 +886,16 @@ func OAuth2ProviderAppSecret(t testing.TB, db database.Store, seed database.OAut
 
 func OAuth2ProviderAppCode(t testing.TB, db database.Store, seed database.OAuth2ProviderAppCode) database.OAuth2ProviderAppCode {
 	code, err := db.InsertOAuth2ProviderAppCode(genCtx, database.InsertOAuth2ProviderAppCodeParams{
-		ID:           takeFirst(seed.ID, uuid.New()),
-		CreatedAt:    takeFirst(seed.CreatedAt, dbtime.Now()),
-		ExpiresAt:    takeFirst(seed.CreatedAt, dbtime.Now()),
-		SecretPrefix: takeFirstSlice(seed.SecretPrefix, []byte("prefix")),
-		HashedSecret: takeFirstSlice(seed.HashedSecret, []byte("hashed-secret")),
-		AppID:        takeFirst(seed.AppID, uuid.New()),
-		UserID:       takeFirst(seed.UserID, uuid.New()),
+		ID:                  takeFirst(seed.ID, uuid.New()),
+		CreatedAt:           takeFirst(seed.CreatedAt, dbtime.Now()),
+		ExpiresAt:           takeFirst(seed.CreatedAt, dbtime.Now()),
+		SecretPrefix:        takeFirstSlice(seed.SecretPrefix, []byte("prefix")),
+		HashedSecret:        takeFirstSlice(seed.HashedSecret, []byte("hashed-secret")),
+		AppID:               takeFirst(seed.AppID, uuid.New()),
+		UserID:              takeFirst(seed.UserID, uuid.New()),
+		CodeChallenge:       seed.CodeChallenge,
+		CodeChallengeMethod: seed.CodeChallengeMethod,
+		Scopes:              takeFirstSlice(seed.Scopes, []string{}),
 	})
 	require.NoError(t, err, "insert oauth2 app code")
 	return code
@@ -827,13 +908,29 @@ func OAuth2ProviderAppToken(t testing.TB, db database.Store, seed database.OAuth
 		ExpiresAt:   takeFirst(seed.CreatedAt, dbtime.Now()),
 		HashPrefix:  takeFirstSlice(seed.HashPrefix, []byte("prefix")),
 		RefreshHash: takeFirstSlice(seed.RefreshHash, []byte("hashed-secret")),
-		AppSecretID: takeFirst(seed.AppSecretID, uuid.New()),
+		AppSecretID: seed.AppSecretID,
 		APIKeyID:    takeFirst(seed.APIKeyID, uuid.New().String()),
+		AppID:       takeFirst(seed.AppID, uuid.New()),
 	})
 	require.NoError(t, err, "insert oauth2 app token")
 	return token
 }
 
+func OAuth2ProviderDeviceCode(t testing.TB, db database.Store, seed database.OAuth2ProviderDeviceCode) database.OAuth2ProviderDeviceCode {
+	code, err := db.InsertOAuth2ProviderDeviceCode(genCtx, database.InsertOAuth2ProviderDeviceCodeParams{
+		ID:           takeFirst(seed.ID, uuid.New()),
+		CreatedAt:    takeFirst(seed.CreatedAt, dbtime.Now()),
+		ExpiresAt:    takeFirst(seed.ExpiresAt, dbtime.Now().Add(15*time.Minute)),
+		SecretPrefix: takeFirstSlice(seed.SecretPrefix, []byte("prefix")),
+		HashedSecret: takeFirstSlice(seed.HashedSecret, []byte("hashed-secret")),
+		UserCode:     takeFirst(seed.UserCode, "BCDF-GHJK"),
+		AppID:        takeFirst(seed.AppID, uuid.New()),
+		Scopes:       takeFirstSlice(seed.Scopes, []string{}),
+	})
+	require.NoError(t, err, "insert oauth2 device code")
+	return code
+}
+
 func CustomRole(t testing.TB, db database.Store, seed database.CustomRole) database.CustomRole {
 	role, err := db.UpsertCustomRole(genCtx, database.UpsertCustomRoleParams{
 		Name:            takeFirst(seed.Name, strings.ToLower(testutil.GetRandomName(t))),
@@ -847,6 +944,22 @@ func CustomRole(t testing.TB, db database.Store, seed database.CustomRole) datab
 	return role
 }
 
+func InboxNotification(t testing.TB, db database.Store, seed database.InboxNotification) database.InboxNotification {
+	id := takeFirst(seed.ID, uuid.New())
+	err := db.InsertInboxNotification(genCtx, database.InsertInboxNotificationParams{
+		ID:        id,
+		UserID:    takeFirst(seed.UserID, uuid.New()),
+		Title:     takeFirst(seed.Title, testutil.GetRandomName(t)),
+		Content:   takeFirst(seed.Content, testutil.GetRandomName(t)),
+		Actions:   takeFirstSlice(seed.Actions, json.RawMessage("[]")),
+		CreatedAt: takeFirst(seed.CreatedAt, dbtime.Now()),
+	})
+	require.NoError(t, err, "insert inbox notification")
+	notification, err := db.GetInboxNotificationByID(genCtx, id)
+	require.NoError(t, err, "get inbox notification")
+	return notification
+}
+
 func must[V any](v V, err error) V {
 	if err != nil {
 		panic(err)
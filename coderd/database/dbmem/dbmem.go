@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"sort"
@@ -61,10 +62,12 @@ func New() database.Store {
 			externalAuthLinks:         make([]database.ExternalAuthLink, 0),
 			groups:                    make([]database.Group, 0),
 			groupMembers:              make([]database.GroupMember, 0),
+			inboxNotifications:        make([]database.InboxNotification, 0),
 			auditLogs:                 make([]database.AuditLog, 0),
 			files:                     make([]database.File, 0),
 			gitSSHKey:                 make([]database.GitSSHKey, 0),
 			notificationMessages:      make([]database.NotificationMessage, 0),
+			notificationPreferences:   make([]database.NotificationPreference, 0),
 			parameterSchemas:          make([]database.ParameterSchema, 0),
 			provisionerDaemons:        make([]database.ProvisionerDaemon, 0),
 			workspaceAgents:           make([]database.WorkspaceAgent, 0),
@@ -149,49 +152,59 @@ type data struct {
 	userLinks           []database.UserLink
 
 	// New tables
-	workspaceAgentStats           []database.WorkspaceAgentStat
-	auditLogs                     []database.AuditLog
-	dbcryptKeys                   []database.DBCryptKey
-	files                         []database.File
-	externalAuthLinks             []database.ExternalAuthLink
-	gitSSHKey                     []database.GitSSHKey
-	groupMembers                  []database.GroupMember
-	groups                        []database.Group
-	jfrogXRayScans                []database.JfrogXrayScan
-	licenses                      []database.License
-	notificationMessages          []database.NotificationMessage
-	oauth2ProviderApps            []database.OAuth2ProviderApp
-	oauth2ProviderAppSecrets      []database.OAuth2ProviderAppSecret
-	oauth2ProviderAppCodes        []database.OAuth2ProviderAppCode
-	oauth2ProviderAppTokens       []database.OAuth2ProviderAppToken
-	parameterSchemas              []database.ParameterSchema
-	provisionerDaemons            []database.ProvisionerDaemon
-	provisionerJobLogs            []database.ProvisionerJobLog
-	provisionerJobs               []database.ProvisionerJob
-	provisionerKeys               []database.ProvisionerKey
-	replicas                      []database.Replica
-	templateVersions              []database.TemplateVersionTable
-	templateVersionParameters     []database.TemplateVersionParameter
-	templateVersionVariables      []database.TemplateVersionVariable
-	templateVersionWorkspaceTags  []database.TemplateVersionWorkspaceTag
-	templates                     []database.TemplateTable
-	templateUsageStats            []database.TemplateUsageStat
-	workspaceAgents               []database.WorkspaceAgent
-	workspaceAgentMetadata        []database.WorkspaceAgentMetadatum
-	workspaceAgentLogs            []database.WorkspaceAgentLog
-	workspaceAgentLogSources      []database.WorkspaceAgentLogSource
-	workspaceAgentScripts         []database.WorkspaceAgentScript
-	workspaceAgentPortShares      []database.WorkspaceAgentPortShare
-	workspaceApps                 []database.WorkspaceApp
-	workspaceAppStatsLastInsertID int64
-	workspaceAppStats             []database.WorkspaceAppStat
-	workspaceBuilds               []database.WorkspaceBuild
-	workspaceBuildParameters      []database.WorkspaceBuildParameter
-	workspaceResourceMetadata     []database.WorkspaceResourceMetadatum
-	workspaceResources            []database.WorkspaceResource
-	workspaces                    []database.Workspace
-	workspaceProxies              []database.WorkspaceProxy
-	customRoles                   []database.CustomRole
+	workspaceAgentStats            []database.WorkspaceAgentStat
+	auditLogs                      []database.AuditLog
+	auditLogChainHeads             []database.AuditLogChainHead
+	dbcryptKeys                    []database.DBCryptKey
+	files                          []database.File
+	externalAuthLinks              []database.ExternalAuthLink
+	gitSSHKey                      []database.GitSSHKey
+	groupMembers                   []database.GroupMember
+	groups                         []database.Group
+	inboxNotifications             []database.InboxNotification
+	jfrogXRayScans                 []database.JfrogXrayScan
+	licenses                       []database.License
+	notificationMessages           []database.NotificationMessage
+	notificationPreferences        []database.NotificationPreference
+	oauth2ProviderApps             []database.OAuth2ProviderApp
+	oauth2ProviderAppSecrets       []database.OAuth2ProviderAppSecret
+	oauth2ProviderAppCodes         []database.OAuth2ProviderAppCode
+	oauth2ProviderAppTokens        []database.OAuth2ProviderAppToken
+	oauth2ProviderDeviceCodes      []database.OAuth2ProviderDeviceCode
+	parameterSchemas               []database.ParameterSchema
+	provisionerDaemons             []database.ProvisionerDaemon
+	provisionerJobLogs             []database.ProvisionerJobLog
+	provisionerJobs                []database.ProvisionerJob
+	provisionerKeys                []database.ProvisionerKey
+	quotaBudgetAlerts              []database.QuotaBudgetAlert
+	quotaBudgets                   []database.QuotaBudget
+	replicas                       []database.Replica
+	templateGitSyncs               []database.TemplateGitSync
+	templateReleaseChannels        []database.TemplateReleaseChannel
+	templateVersions               []database.TemplateVersionTable
+	templateVersionParameters      []database.TemplateVersionParameter
+	templateVersionVariables       []database.TemplateVersionVariable
+	templateVersionWorkspaceTags   []database.TemplateVersionWorkspaceTag
+	templates                      []database.TemplateTable
+	templateUsageStats             []database.TemplateUsageStat
+	workspaceAgents                []database.WorkspaceAgent
+	workspaceAgentMetadata         []database.WorkspaceAgentMetadatum
+	workspaceAgentLogs             []database.WorkspaceAgentLog
+	workspaceAgentLogSources       []database.WorkspaceAgentLogSource
+	workspaceAgentScripts          []database.WorkspaceAgentScript
+	workspaceAgentPortShares       []database.WorkspaceAgentPortShare
+	workspaceApps                  []database.WorkspaceApp
+	workspaceAppStatsLastInsertID  int64
+	workspaceAppStats              []database.WorkspaceAppStat
+	workspaceBuilds                []database.WorkspaceBuild
+	workspaceBuildAutostopWarnings []database.WorkspaceBuildAutostopWarning
+	workspaceBuildParameters       []database.WorkspaceBuildParameter
+	workspaceResourceMetadata      []database.WorkspaceResourceMetadatum
+	workspaceResources             []database.WorkspaceResource
+	workspaceSnapshots             []database.WorkspaceSnapshot
+	workspaces                     []database.Workspace
+	workspaceProxies               []database.WorkspaceProxy
+	customRoles                    []database.CustomRole
 	// Locks is a map of lock names. Any keys within the map are currently
 	// locked.
 	locks                   map[int64]struct{}
@@ -406,6 +419,10 @@ func (q *FakeQuerier) convertToWorkspaceRowsNoLock(ctx context.Context, workspac
 			Count:             count,
 			AutomaticUpdates:  w.AutomaticUpdates,
 			Favorite:          w.Favorite,
+			UserACL:           w.UserACL,
+			GroupACL:          w.GroupACL,
+			LastActiveAt:      w.LastActiveAt,
+			ReleaseChannel:    w.ReleaseChannel,
 		}
 
 		for _, t := range q.templates {
@@ -938,6 +955,25 @@ func (q *FakeQuerier) getOrganizationByIDNoLock(id uuid.UUID) (database.Organiza
 	return database.Organization{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) AcquireAuditLogChainHead(_ context.Context, organizationID uuid.UUID) (database.AuditLogChainHead, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, head := range q.auditLogChainHeads {
+		if head.OrganizationID == organizationID {
+			return head, nil
+		}
+	}
+	head := database.AuditLogChainHead{
+		OrganizationID: organizationID,
+		ChainIndex:     0,
+		Hash:           []byte{},
+		UpdatedAt:      dbtime.Now(),
+	}
+	q.auditLogChainHeads = append(q.auditLogChainHeads, head)
+	return head, nil
+}
+
 func (*FakeQuerier) AcquireLock(_ context.Context, _ int64) error {
 	return xerrors.New("AcquireLock must only be called within a transaction")
 }
@@ -953,14 +989,17 @@ func (q *FakeQuerier) AcquireNotificationMessages(_ context.Context, arg databas
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	// Shift the first "Count" notifications off the slice (FIFO).
-	sz := len(q.notificationMessages)
-	if sz > int(arg.Count) {
-		sz = int(arg.Count)
+	// Shift the first "Count" notifications off the slice (FIFO), skipping those which are held back until later.
+	// NOTE: the per-user rate limit is not implemented here, since sent messages are not retained.
+	var list, remaining []database.NotificationMessage
+	for _, nm := range q.notificationMessages {
+		if len(list) < int(arg.Count) && (!nm.NextRetryAfter.Valid || nm.NextRetryAfter.Time.Before(dbtime.Now())) {
+			list = append(list, nm)
+			continue
+		}
+		remaining = append(remaining, nm)
 	}
-
-	list := q.notificationMessages[:sz]
-	q.notificationMessages = q.notificationMessages[sz:]
+	q.notificationMessages = remaining
 
 	var out []database.AcquireNotificationMessagesRow
 	for _, nm := range list {
@@ -982,6 +1021,7 @@ func (q *FakeQuerier) AcquireNotificationMessages(_ context.Context, arg databas
 			TitleTemplate: "This is a title with {{.Labels.variable}}",
 			BodyTemplate:  "This is a body with {{.Labels.variable}}",
 			TemplateID:    nm.NotificationTemplateID,
+			UserID:        nm.UserID,
 		})
 	}
 
@@ -1245,6 +1285,7 @@ func (q *FakeQuerier) BatchUpdateWorkspaceLastUsedAt(_ context.Context, arg data
 			continue
 		}
 		q.workspaces[i].LastUsedAt = arg.LastUsedAt
+		q.workspaces[i].LastActiveAt = arg.LastUsedAt
 		n++
 	}
 	return nil
@@ -1266,6 +1307,76 @@ func (*FakeQuerier) BulkMarkNotificationMessagesSent(_ context.Context, arg data
 	return int64(len(arg.IDs)), nil
 }
 
+func (q *FakeQuerier) ClaimWorkspacesApproachingAutostop(ctx context.Context, arg database.ClaimWorkspacesApproachingAutostopParams) ([]database.ClaimWorkspacesApproachingAutostopRow, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	rows := []database.ClaimWorkspacesApproachingAutostopRow{}
+	for _, workspace := range q.workspaces {
+		if workspace.Deleted || workspace.DormantAt.Valid {
+			continue
+		}
+
+		build, err := q.getLatestWorkspaceBuildByWorkspaceIDNoLock(ctx, workspace.ID)
+		if err != nil {
+			return nil, err
+		}
+		if build.Transition != database.WorkspaceTransitionStart ||
+			!build.Deadline.After(arg.Now) ||
+			build.Deadline.After(arg.WarnBefore) {
+			continue
+		}
+
+		job, err := q.getProvisionerJobByIDNoLock(ctx, build.JobID)
+		if err != nil {
+			return nil, xerrors.Errorf("get provisioner job by ID: %w", err)
+		}
+		if codersdk.ProvisionerJobStatus(job.JobStatus) != codersdk.ProvisionerJobSucceeded {
+			continue
+		}
+
+		user, err := q.getUserByIDNoLock(workspace.OwnerID)
+		if err != nil {
+			return nil, xerrors.Errorf("get user by ID: %w", err)
+		}
+		if user.Status != database.UserStatusActive {
+			continue
+		}
+
+		warning := database.WorkspaceBuildAutostopWarning{
+			WorkspaceBuildID: build.ID,
+			Deadline:         build.Deadline,
+			WarnedAt:         arg.Now,
+		}
+		idx := slices.IndexFunc(q.workspaceBuildAutostopWarnings, func(w database.WorkspaceBuildAutostopWarning) bool {
+			return w.WorkspaceBuildID == build.ID
+		})
+		if idx >= 0 {
+			if q.workspaceBuildAutostopWarnings[idx].Deadline.Equal(build.Deadline) {
+				continue
+			}
+			q.workspaceBuildAutostopWarnings[idx] = warning
+		} else {
+			q.workspaceBuildAutostopWarnings = append(q.workspaceBuildAutostopWarnings, warning)
+		}
+
+		rows = append(rows, database.ClaimWorkspacesApproachingAutostopRow{
+			ID:             workspace.ID,
+			OwnerID:        workspace.OwnerID,
+			OrganizationID: workspace.OrganizationID,
+			TemplateID:     workspace.TemplateID,
+			Name:           workspace.Name,
+			Deadline:       build.Deadline,
+		})
+	}
+
+	return rows, nil
+}
+
 func (*FakeQuerier) CleanTailnetCoordinators(_ context.Context) error {
 	return ErrUnimplemented
 }
@@ -1278,6 +1389,40 @@ func (*FakeQuerier) CleanTailnetTunnels(context.Context) error {
 	return ErrUnimplemented
 }
 
+func (q *FakeQuerier) ClearNotificationMessageDedupeHash(_ context.Context, arg database.ClearNotificationMessageDedupeHashParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var cleared int64
+	for i, msg := range q.notificationMessages {
+		if msg.DedupeHash != arg.DedupeHash || msg.CreatedAt.After(arg.CreatedBefore) {
+			continue
+		}
+		msg.DedupeHash = sql.NullString{}
+		q.notificationMessages[i] = msg
+		cleared++
+	}
+	return cleared, nil
+}
+
+func (q *FakeQuerier) CountUnreadInboxNotificationsByUserID(_ context.Context, userID uuid.UUID) (int64, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var count int64
+	for _, n := range q.inboxNotifications {
+		if n.UserID == userID && !n.ReadAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func (q *FakeQuerier) CustomRoles(_ context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1472,20 +1617,18 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppByID(_ context.Context, id uuid.UUI
 	q.oauth2ProviderApps[index] = q.oauth2ProviderApps[len(q.oauth2ProviderApps)-1]
 	q.oauth2ProviderApps = q.oauth2ProviderApps[:len(q.oauth2ProviderApps)-1]
 
-	// Cascade delete secrets associated with the deleted app.
-	var deletedSecretIDs []uuid.UUID
+	// Cascade delete secrets and device codes associated with the deleted app.
 	q.oauth2ProviderAppSecrets = slices.DeleteFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
-		matches := secret.AppID == id
-		if matches {
-			deletedSecretIDs = append(deletedSecretIDs, secret.ID)
-		}
-		return matches
+		return secret.AppID == id
+	})
+	q.oauth2ProviderDeviceCodes = slices.DeleteFunc(q.oauth2ProviderDeviceCodes, func(code database.OAuth2ProviderDeviceCode) bool {
+		return code.AppID == id
 	})
 
-	// Cascade delete tokens through the deleted secrets.
+	// Cascade delete tokens associated with the deleted app.
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		matches := slice.Contains(deletedSecretIDs, token.AppSecretID)
+		matches := token.AppID == id
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
 		}
@@ -1551,7 +1694,7 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppSecretByID(_ context.Context, id uu
 	// Cascade delete tokens created through the deleted secret.
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		matches := token.AppSecretID == id
+		matches := token.AppSecretID.Valid && token.AppSecretID.UUID == id
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
 		}
@@ -1577,15 +1720,11 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(_ context.Cont
 
 	var keyIDsToDelete []string
 	q.oauth2ProviderAppTokens = slices.DeleteFunc(q.oauth2ProviderAppTokens, func(token database.OAuth2ProviderAppToken) bool {
-		// Join secrets and keys to see if the token matches.
-		secretIdx := slices.IndexFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
-			return secret.ID == token.AppSecretID
-		})
+		// Join keys to see if the token matches.
 		keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
 			return key.ID == token.APIKeyID
 		})
-		matches := secretIdx != -1 &&
-			q.oauth2ProviderAppSecrets[secretIdx].AppID == arg.AppID &&
+		matches := token.AppID == arg.AppID &&
 			keyIdx != -1 && q.apiKeys[keyIdx].UserID == arg.UserID
 		if matches {
 			keyIDsToDelete = append(keyIDsToDelete, token.APIKeyID)
@@ -1601,6 +1740,19 @@ func (q *FakeQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(_ context.Cont
 	return nil
 }
 
+func (q *FakeQuerier) DeleteOAuth2ProviderDeviceCodeByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, code := range q.oauth2ProviderDeviceCodes {
+		if code.ID == id {
+			q.oauth2ProviderDeviceCodes = append(q.oauth2ProviderDeviceCodes[:index], q.oauth2ProviderDeviceCodes[index+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
 func (*FakeQuerier) DeleteOldNotificationMessages(_ context.Context) error {
 	return nil
 }
@@ -1766,6 +1918,26 @@ func (q *FakeQuerier) DeleteProvisionerKey(_ context.Context, id uuid.UUID) erro
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) DeleteQuotaBudgetByID(_ context.Context, id uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, budget := range q.quotaBudgets {
+		if budget.ID == id {
+			q.quotaBudgets = append(q.quotaBudgets[:i], q.quotaBudgets[i+1:]...)
+			break
+		}
+	}
+	alerts := q.quotaBudgetAlerts[:0]
+	for _, alert := range q.quotaBudgetAlerts {
+		if alert.BudgetID != id {
+			alerts = append(alerts, alert)
+		}
+	}
+	q.quotaBudgetAlerts = alerts
+	return nil
+}
+
 func (q *FakeQuerier) DeleteReplicasUpdatedBefore(_ context.Context, before time.Time) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -1809,6 +1981,16 @@ func (*FakeQuerier) DeleteTailnetTunnel(_ context.Context, arg database.DeleteTa
 	return database.DeleteTailnetTunnelRow{}, ErrUnimplemented
 }
 
+func (q *FakeQuerier) DeleteTemplateGitSyncByTemplateID(_ context.Context, templateID uuid.UUID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.templateGitSyncs = slices.DeleteFunc(q.templateGitSyncs, func(sync database.TemplateGitSync) bool {
+		return sync.TemplateID == templateID
+	})
+	return nil
+}
+
 func (q *FakeQuerier) DeleteWorkspaceAgentPortShare(_ context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -1867,6 +2049,14 @@ func (q *FakeQuerier) EnqueueNotificationMessage(_ context.Context, arg database
 		return err
 	}
 
+	if arg.DedupeHash.Valid {
+		for _, existing := range q.notificationMessages {
+			if existing.DedupeHash == arg.DedupeHash {
+				return newUniqueConstraintError(database.UniqueNotificationMessagesDedupeHashIndex)
+			}
+		}
+	}
+
 	nm := database.NotificationMessage{
 		ID:                     arg.ID,
 		UserID:                 arg.UserID,
@@ -1875,6 +2065,8 @@ func (q *FakeQuerier) EnqueueNotificationMessage(_ context.Context, arg database
 		NotificationTemplateID: arg.NotificationTemplateID,
 		Targets:                arg.Targets,
 		CreatedBy:              arg.CreatedBy,
+		DedupeHash:             arg.DedupeHash,
+		NextRetryAfter:         arg.NextRetryAfter,
 		// Default fields.
 		CreatedAt: dbtime.Now(),
 		Status:    database.NotificationMessageStatusPending,
@@ -1910,6 +2102,9 @@ func (q *FakeQuerier) FetchNewMessageMetadata(_ context.Context, arg database.Fe
 		return database.FetchNewMessageMetadataRow{}, err
 	}
 
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
 	user, err := q.getUserByIDNoLock(arg.UserID)
 	if err != nil {
 		return database.FetchNewMessageMetadataRow{}, xerrors.Errorf("fetch user: %w", err)
@@ -1926,14 +2121,25 @@ func (q *FakeQuerier) FetchNewMessageMetadata(_ context.Context, arg database.Fe
 		return database.FetchNewMessageMetadataRow{}, err
 	}
 
-	return database.FetchNewMessageMetadataRow{
+	row := database.FetchNewMessageMetadataRow{
 		UserEmail:        user.Email,
 		UserName:         userName,
 		UserUsername:     user.Username,
 		NotificationName: "Some notification",
 		Actions:          actions,
 		UserID:           arg.UserID,
-	}, nil
+	}
+
+	// Mimic LEFT JOIN on notification_preferences in query
+	for _, np := range q.notificationPreferences {
+		if np.UserID == arg.UserID && np.NotificationTemplateID == arg.NotificationTemplateID {
+			row.Disabled = np.Disabled
+			row.PreferredMethod = np.Method
+			break
+		}
+	}
+
+	return row, nil
 }
 
 func (q *FakeQuerier) GetAPIKeyByID(_ context.Context, id string) (database.APIKey, error) {
@@ -2092,6 +2298,17 @@ func (q *FakeQuerier) GetApplicationName(_ context.Context) (string, error) {
 	return q.applicationName, nil
 }
 
+func (q *FakeQuerier) GetAuditLogChainHeads(_ context.Context) ([]database.AuditLogChainHead, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	heads := slices.Clone(q.auditLogChainHeads)
+	slices.SortFunc(heads, func(a, b database.AuditLogChainHead) int {
+		return slice.Ascending(a.OrganizationID.String(), b.OrganizationID.String())
+	})
+	return heads, nil
+}
+
 func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return nil, err
@@ -2172,6 +2389,8 @@ func (q *FakeQuerier) GetAuditLogsOffset(_ context.Context, arg database.GetAudi
 			ResourceID:              alog.ResourceID,
 			ResourceTarget:          alog.ResourceTarget,
 			ResourceIcon:            alog.ResourceIcon,
+			ChainIndex:              alog.ChainIndex,
+			Hash:                    alog.Hash,
 			Action:                  alog.Action,
 			Diff:                    alog.Diff,
 			StatusCode:              alog.StatusCode,
@@ -2250,6 +2469,30 @@ func (q *FakeQuerier) GetAuthorizationUserRoles(_ context.Context, userID uuid.U
 	}, nil
 }
 
+func (q *FakeQuerier) GetChainedAuditLogs(_ context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	logs := make([]database.AuditLog, 0)
+	for _, alog := range q.auditLogs {
+		if alog.OrganizationID != arg.OrganizationID || !alog.ChainIndex.Valid || alog.ChainIndex.Int64 <= arg.AfterChainIndex {
+			continue
+		}
+		logs = append(logs, alog)
+	}
+	slices.SortFunc(logs, func(a, b database.AuditLog) int {
+		return slice.Ascending(a.ChainIndex.Int64, b.ChainIndex.Int64)
+	})
+	if len(logs) > int(arg.LimitOpt) {
+		logs = logs[:arg.LimitOpt]
+	}
+	return logs, nil
+}
+
 func (q *FakeQuerier) GetDBCryptKeys(_ context.Context) ([]database.DBCryptKey, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -2677,6 +2920,50 @@ func (q *FakeQuerier) GetHungProvisionerJobs(_ context.Context, hungSince time.T
 	return hungJobs, nil
 }
 
+func (q *FakeQuerier) GetInboxNotificationByID(_ context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, n := range q.inboxNotifications {
+		if n.ID == id {
+			return n, nil
+		}
+	}
+	return database.InboxNotification{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetInboxNotificationsByUserID(_ context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	out := make([]database.InboxNotification, 0)
+	for _, n := range q.inboxNotifications {
+		if n.UserID != arg.UserID {
+			continue
+		}
+		if arg.UnreadOnly && n.ReadAt.Valid {
+			continue
+		}
+		if !arg.CreatedBefore.IsZero() && !n.CreatedAt.Before(arg.CreatedBefore) {
+			continue
+		}
+		out = append(out, n)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	if arg.LimitOpt > 0 && len(out) > int(arg.LimitOpt) {
+		out = out[:arg.LimitOpt]
+	}
+	return out, nil
+}
+
 func (q *FakeQuerier) GetJFrogXrayScanByWorkspaceAndAgentID(_ context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -2817,6 +3104,16 @@ func (q *FakeQuerier) GetNotificationMessagesByStatus(_ context.Context, arg dat
 	return out, nil
 }
 
+func (*FakeQuerier) GetNotificationTemplateByID(_ context.Context, _ uuid.UUID) (database.NotificationTemplate, error) {
+	// Notification templates are managed by migrations, which dbmem does not run.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
+func (*FakeQuerier) GetNotificationTemplates(_ context.Context) ([]database.NotificationTemplate, error) {
+	// Notification templates are managed by migrations, which dbmem does not run.
+	return nil, ErrUnimplemented
+}
+
 func (q *FakeQuerier) GetNotificationsSettings(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -2945,17 +3242,13 @@ func (q *FakeQuerier) GetOAuth2ProviderAppsByUserID(_ context.Context, userID uu
 	rows := []database.GetOAuth2ProviderAppsByUserIDRow{}
 	for _, app := range q.oauth2ProviderApps {
 		tokens := []database.OAuth2ProviderAppToken{}
-		for _, secret := range q.oauth2ProviderAppSecrets {
-			if secret.AppID == app.ID {
-				for _, token := range q.oauth2ProviderAppTokens {
-					if token.AppSecretID == secret.ID {
-						keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
-							return key.ID == token.APIKeyID
-						})
-						if keyIdx != -1 && q.apiKeys[keyIdx].UserID == userID {
-							tokens = append(tokens, token)
-						}
-					}
+		for _, token := range q.oauth2ProviderAppTokens {
+			if token.AppID == app.ID {
+				keyIdx := slices.IndexFunc(q.apiKeys, func(key database.APIKey) bool {
+					return key.ID == token.APIKeyID
+				})
+				if keyIdx != -1 && q.apiKeys[keyIdx].UserID == userID {
+					tokens = append(tokens, token)
 				}
 			}
 		}
@@ -2966,6 +3259,7 @@ func (q *FakeQuerier) GetOAuth2ProviderAppsByUserID(_ context.Context, userID uu
 					ID:          app.ID,
 					Icon:        app.Icon,
 					Name:        app.Name,
+					ClientType:  app.ClientType,
 				},
 				TokenCount: int64(len(tokens)),
 			})
@@ -2974,6 +3268,30 @@ func (q *FakeQuerier) GetOAuth2ProviderAppsByUserID(_ context.Context, userID uu
 	return rows, nil
 }
 
+func (q *FakeQuerier) GetOAuth2ProviderDeviceCodeByPrefix(_ context.Context, secretPrefix []byte) (database.OAuth2ProviderDeviceCode, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderDeviceCodes {
+		if bytes.Equal(code.SecretPrefix, secretPrefix) {
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderDeviceCode{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetOAuth2ProviderDeviceCodeByUserCode(_ context.Context, userCode string) (database.OAuth2ProviderDeviceCode, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderDeviceCodes {
+		if code.UserCode == userCode {
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderDeviceCode{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) GetOAuthSigningKey(_ context.Context) (string, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3326,6 +3644,82 @@ func (q *FakeQuerier) GetQuotaAllowanceForUser(_ context.Context, userID uuid.UU
 	return sum, nil
 }
 
+func (q *FakeQuerier) GetQuotaBudgetByID(_ context.Context, id uuid.UUID) (database.QuotaBudget, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, budget := range q.quotaBudgets {
+		if budget.ID == id {
+			return budget, nil
+		}
+	}
+	return database.QuotaBudget{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetQuotaBudgets(_ context.Context) ([]database.QuotaBudget, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	budgets := slices.Clone(q.quotaBudgets)
+	slices.SortStableFunc(budgets, func(a, b database.QuotaBudget) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return budgets, nil
+}
+
+func (q *FakeQuerier) GetQuotaBudgetsByOrganizationID(_ context.Context, organizationID uuid.UUID) ([]database.QuotaBudget, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	budgets := make([]database.QuotaBudget, 0)
+	for _, budget := range q.quotaBudgets {
+		if budget.OrganizationID == organizationID {
+			budgets = append(budgets, budget)
+		}
+	}
+	slices.SortStableFunc(budgets, func(a, b database.QuotaBudget) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return budgets, nil
+}
+
+func (q *FakeQuerier) GetQuotaBudgetsForUser(_ context.Context, arg database.GetQuotaBudgetsForUserParams) ([]database.QuotaBudget, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	budgets := make([]database.QuotaBudget, 0)
+	for _, budget := range q.quotaBudgets {
+		if budget.OrganizationID != arg.OrganizationID {
+			continue
+		}
+		switch {
+		case budget.UserID.Valid:
+			if budget.UserID.UUID != arg.UserID {
+				continue
+			}
+		case budget.GroupID.UUID == budget.OrganizationID:
+			// Everyone group.
+		default:
+			member := slices.ContainsFunc(q.groupMembers, func(m database.GroupMember) bool {
+				return m.GroupID == budget.GroupID.UUID && m.UserID == arg.UserID
+			})
+			if !member {
+				continue
+			}
+		}
+		budgets = append(budgets, budget)
+	}
+	slices.SortStableFunc(budgets, func(a, b database.QuotaBudget) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return budgets, nil
+}
+
 func (q *FakeQuerier) GetQuotaConsumedForUser(_ context.Context, userID uuid.UUID) (int64, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3353,6 +3747,62 @@ func (q *FakeQuerier) GetQuotaConsumedForUser(_ context.Context, userID uuid.UUI
 	return sum, nil
 }
 
+func (q *FakeQuerier) GetQuotaSpend(ctx context.Context, arg database.GetQuotaSpendParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var spend float64
+	for _, workspace := range q.workspaces {
+		if workspace.OrganizationID != arg.OrganizationID || !slices.Contains(arg.OwnerIDs, workspace.OwnerID) {
+			continue
+		}
+
+		type build struct {
+			number    int32
+			dailyCost int32
+			startedAt time.Time
+		}
+		var builds []build
+		for _, wb := range q.workspaceBuilds {
+			if wb.WorkspaceID != workspace.ID {
+				continue
+			}
+			job, err := q.getProvisionerJobByIDNoLock(ctx, wb.JobID)
+			if err != nil {
+				return 0, err
+			}
+			if provisonerJobStatus(job) != database.ProvisionerJobStatusSucceeded {
+				continue
+			}
+			builds = append(builds, build{number: wb.BuildNumber, dailyCost: wb.DailyCost, startedAt: job.CompletedAt.Time})
+		}
+		slices.SortFunc(builds, func(a, b build) int {
+			return int(a.number - b.number)
+		})
+
+		for i, b := range builds {
+			endedAt := arg.PeriodEnd
+			if i+1 < len(builds) && builds[i+1].startedAt.Before(endedAt) {
+				endedAt = builds[i+1].startedAt
+			}
+			startedAt := b.startedAt
+			if startedAt.Before(arg.PeriodStart) {
+				startedAt = arg.PeriodStart
+			}
+			if b.dailyCost <= 0 || !endedAt.After(startedAt) {
+				continue
+			}
+			spend += float64(b.dailyCost) * endedAt.Sub(startedAt).Hours() / 24
+		}
+	}
+	return int64(math.Round(spend)), nil
+}
+
 func (q *FakeQuerier) GetReplicaByID(_ context.Context, id uuid.UUID) (database.Replica, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -3940,6 +4390,29 @@ func (q *FakeQuerier) GetTemplateDAUs(_ context.Context, arg database.GetTemplat
 	return rs, nil
 }
 
+func (q *FakeQuerier) GetTemplateGitSyncByTemplateID(_ context.Context, templateID uuid.UUID) (database.TemplateGitSync, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, sync := range q.templateGitSyncs {
+		if sync.TemplateID == templateID {
+			return sync, nil
+		}
+	}
+	return database.TemplateGitSync{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetTemplateGitSyncs(_ context.Context) ([]database.TemplateGitSync, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	syncs := slices.Clone(q.templateGitSyncs)
+	slices.SortFunc(syncs, func(a, b database.TemplateGitSync) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return syncs, nil
+}
+
 func (q *FakeQuerier) GetTemplateInsights(_ context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -4396,6 +4869,51 @@ func (q *FakeQuerier) GetTemplateParameterInsights(ctx context.Context, arg data
 	return rows, nil
 }
 
+func (q *FakeQuerier) GetTemplateReleaseChannelByTemplateIDAndName(_ context.Context, arg database.GetTemplateReleaseChannelByTemplateIDAndNameParams) (database.TemplateReleaseChannel, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, channel := range q.templateReleaseChannels {
+		if channel.TemplateID == arg.TemplateID && strings.EqualFold(channel.Name, arg.Name) {
+			return channel, nil
+		}
+	}
+	return database.TemplateReleaseChannel{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetTemplateReleaseChannelsByTemplateID(_ context.Context, templateID uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	channels := make([]database.TemplateReleaseChannel, 0)
+	for _, channel := range q.templateReleaseChannels {
+		if channel.TemplateID == templateID {
+			channels = append(channels, channel)
+		}
+	}
+	slices.SortFunc(channels, func(a, b database.TemplateReleaseChannel) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+	return channels, nil
+}
+
+func (q *FakeQuerier) GetTemplateReleaseChannelsByTemplateIDs(_ context.Context, ids []uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	channels := make([]database.TemplateReleaseChannel, 0)
+	for _, channel := range q.templateReleaseChannels {
+		if slices.Contains(ids, channel.TemplateID) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels, nil
+}
+
 func (q *FakeQuerier) GetTemplateUsageStats(_ context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -4962,6 +5480,19 @@ func (q *FakeQuerier) GetUserLinksByUserID(_ context.Context, userID uuid.UUID)
 	return uls, nil
 }
 
+func (q *FakeQuerier) GetUserNotificationPreferences(_ context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	out := make([]database.NotificationPreference, 0)
+	for _, np := range q.notificationPreferences {
+		if np.UserID == userID {
+			out = append(out, np)
+		}
+	}
+	return out, nil
+}
+
 func (q *FakeQuerier) GetUserWorkspaceBuildParameters(_ context.Context, params database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5118,6 +5649,19 @@ func (q *FakeQuerier) GetUsers(_ context.Context, params database.GetUsersParams
 		users = usersFilteredByLastSeen
 	}
 
+	if params.OrganizationID != uuid.Nil {
+		usersFilteredByOrganization := make([]database.User, 0, len(users))
+		for i, user := range users {
+			for _, member := range q.organizationMembers {
+				if member.OrganizationID == params.OrganizationID && member.UserID == user.ID {
+					usersFilteredByOrganization = append(usersFilteredByOrganization, users[i])
+					break
+				}
+			}
+		}
+		users = usersFilteredByOrganization
+	}
+
 	beforePageCount := len(users)
 
 	if params.OffsetOpt > 0 {
@@ -5800,6 +6344,23 @@ func (q *FakeQuerier) GetWorkspaceByWorkspaceAppID(_ context.Context, workspaceA
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) GetWorkspaceOwnerIDsByTemplateID(_ context.Context, templateID uuid.UUID) ([]uuid.UUID, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	ownerIDs := make([]uuid.UUID, 0)
+	for _, workspace := range q.workspaces {
+		if workspace.TemplateID != templateID || workspace.Deleted {
+			continue
+		}
+		if slices.Contains(ownerIDs, workspace.OwnerID) {
+			continue
+		}
+		ownerIDs = append(ownerIDs, workspace.OwnerID)
+	}
+	return ownerIDs, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceProxies(_ context.Context) ([]database.WorkspaceProxy, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -5964,6 +6525,34 @@ func (q *FakeQuerier) GetWorkspaceResourcesCreatedAfter(_ context.Context, after
 	return resources, nil
 }
 
+func (q *FakeQuerier) GetWorkspaceSnapshotByID(_ context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.ID == id {
+			return snapshot, nil
+		}
+	}
+	return database.WorkspaceSnapshot{}, sql.ErrNoRows
+}
+
+func (q *FakeQuerier) GetWorkspaceSnapshotsByWorkspaceID(_ context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	snapshots := make([]database.WorkspaceSnapshot, 0)
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.WorkspaceID == workspaceID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	slices.SortFunc(snapshots, func(a, b database.WorkspaceSnapshot) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return snapshots, nil
+}
+
 func (q *FakeQuerier) GetWorkspaceUniqueOwnerCountByTemplateIDs(_ context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -6060,6 +6649,11 @@ func (q *FakeQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, no
 			workspaces = append(workspaces, workspace)
 			continue
 		}
+
+		if template.IdleTimeout > 0 && build.Transition == database.WorkspaceTransitionStart {
+			workspaces = append(workspaces, workspace)
+			continue
+		}
 	}
 
 	return workspaces, nil
@@ -6097,6 +6691,14 @@ func (q *FakeQuerier) InsertAPIKey(_ context.Context, arg database.InsertAPIKeyP
 		LoginType:       arg.LoginType,
 		Scope:           arg.Scope,
 		TokenName:       arg.TokenName,
+		Scopes:          arg.Scopes,
+		AllowList:       arg.AllowList,
+	}
+	if key.Scopes == nil {
+		key.Scopes = []string{}
+	}
+	if key.AllowList == nil {
+		key.AllowList = []uuid.UUID{}
 	}
 	q.apiKeys = append(q.apiKeys, key)
 	return key, nil
@@ -6121,6 +6723,14 @@ func (q *FakeQuerier) InsertAuditLog(_ context.Context, arg database.InsertAudit
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
+	if arg.ChainIndex.Valid {
+		for _, alog := range q.auditLogs {
+			if alog.OrganizationID == arg.OrganizationID && alog.ChainIndex == arg.ChainIndex {
+				return database.AuditLog{}, errUniqueConstraint
+			}
+		}
+	}
+
 	alog := database.AuditLog(arg)
 
 	q.auditLogs = append(q.auditLogs, alog)
@@ -6292,6 +6902,33 @@ func (q *FakeQuerier) InsertGroupMember(_ context.Context, arg database.InsertGr
 	return nil
 }
 
+func (q *FakeQuerier) InsertInboxNotification(_ context.Context, arg database.InsertInboxNotificationParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// Mimic ON CONFLICT (id) DO NOTHING in query
+	for _, n := range q.inboxNotifications {
+		if n.ID == arg.ID {
+			return nil
+		}
+	}
+
+	q.inboxNotifications = append(q.inboxNotifications, database.InboxNotification{
+		ID:        arg.ID,
+		UserID:    arg.UserID,
+		Title:     arg.Title,
+		Content:   arg.Content,
+		Actions:   arg.Actions,
+		CreatedAt: arg.CreatedAt,
+	})
+	return nil
+}
+
 func (q *FakeQuerier) InsertLicense(
 	_ context.Context, arg database.InsertLicenseParams,
 ) (database.License, error) {
@@ -6375,6 +7012,8 @@ func (q *FakeQuerier) InsertOAuth2ProviderApp(_ context.Context, arg database.In
 		Name:        arg.Name,
 		Icon:        arg.Icon,
 		CallbackURL: arg.CallbackURL,
+		ClientType:  arg.ClientType,
+		GrantTypes:  arg.GrantTypes,
 	}
 	q.oauth2ProviderApps = append(q.oauth2ProviderApps, app)
 
@@ -6393,13 +7032,19 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppCode(_ context.Context, arg databas
 	for _, app := range q.oauth2ProviderApps {
 		if app.ID == arg.AppID {
 			code := database.OAuth2ProviderAppCode{
-				ID:           arg.ID,
-				CreatedAt:    arg.CreatedAt,
-				ExpiresAt:    arg.ExpiresAt,
-				SecretPrefix: arg.SecretPrefix,
-				HashedSecret: arg.HashedSecret,
-				UserID:       arg.UserID,
-				AppID:        arg.AppID,
+				ID:                  arg.ID,
+				CreatedAt:           arg.CreatedAt,
+				ExpiresAt:           arg.ExpiresAt,
+				SecretPrefix:        arg.SecretPrefix,
+				HashedSecret:        arg.HashedSecret,
+				UserID:              arg.UserID,
+				AppID:               arg.AppID,
+				CodeChallenge:       arg.CodeChallenge,
+				CodeChallengeMethod: arg.CodeChallengeMethod,
+				Scopes:              arg.Scopes,
+			}
+			if code.Scopes == nil {
+				code.Scopes = []string{}
 			}
 			q.oauth2ProviderAppCodes = append(q.oauth2ProviderAppCodes, code)
 			return code, nil
@@ -6445,8 +7090,14 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppToken(_ context.Context, arg databa
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
 
-	for _, secret := range q.oauth2ProviderAppSecrets {
-		if secret.ID == arg.AppSecretID {
+	if arg.AppSecretID.Valid && !slices.ContainsFunc(q.oauth2ProviderAppSecrets, func(secret database.OAuth2ProviderAppSecret) bool {
+		return secret.ID == arg.AppSecretID.UUID
+	}) {
+		return database.OAuth2ProviderAppToken{}, sql.ErrNoRows
+	}
+
+	for _, app := range q.oauth2ProviderApps {
+		if app.ID == arg.AppID {
 			//nolint:gosimple // Go wants database.OAuth2ProviderAppToken(arg), but we cannot be sure the structs will remain identical.
 			token := database.OAuth2ProviderAppToken{
 				ID:          arg.ID,
@@ -6456,6 +7107,7 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppToken(_ context.Context, arg databa
 				RefreshHash: arg.RefreshHash,
 				APIKeyID:    arg.APIKeyID,
 				AppSecretID: arg.AppSecretID,
+				AppID:       arg.AppID,
 			}
 			q.oauth2ProviderAppTokens = append(q.oauth2ProviderAppTokens, token)
 			return token, nil
@@ -6465,6 +7117,45 @@ func (q *FakeQuerier) InsertOAuth2ProviderAppToken(_ context.Context, arg databa
 	return database.OAuth2ProviderAppToken{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) InsertOAuth2ProviderDeviceCode(_ context.Context, arg database.InsertOAuth2ProviderDeviceCodeParams) (database.OAuth2ProviderDeviceCode, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.OAuth2ProviderDeviceCode{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, code := range q.oauth2ProviderDeviceCodes {
+		if code.UserCode == arg.UserCode || bytes.Equal(code.SecretPrefix, arg.SecretPrefix) {
+			return database.OAuth2ProviderDeviceCode{}, errUniqueConstraint
+		}
+	}
+
+	for _, app := range q.oauth2ProviderApps {
+		if app.ID == arg.AppID {
+			code := database.OAuth2ProviderDeviceCode{
+				ID:           arg.ID,
+				CreatedAt:    arg.CreatedAt,
+				ExpiresAt:    arg.ExpiresAt,
+				SecretPrefix: arg.SecretPrefix,
+				HashedSecret: arg.HashedSecret,
+				UserCode:     arg.UserCode,
+				AppID:        arg.AppID,
+				Scopes:       arg.Scopes,
+				Status:       database.OAuth2ProviderDeviceCodeStatusPending,
+			}
+			if code.Scopes == nil {
+				code.Scopes = []string{}
+			}
+			q.oauth2ProviderDeviceCodes = append(q.oauth2ProviderDeviceCodes, code)
+			return code, nil
+		}
+	}
+
+	return database.OAuth2ProviderDeviceCode{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) InsertOrganization(_ context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Organization{}, err
@@ -6601,9 +7292,73 @@ func (q *FakeQuerier) InsertProvisionerKey(_ context.Context, arg database.Inser
 		HashedSecret:   arg.HashedSecret,
 		Tags:           arg.Tags,
 	}
-	q.provisionerKeys = append(q.provisionerKeys, provisionerKey)
+	q.provisionerKeys = append(q.provisionerKeys, provisionerKey)
+
+	return provisionerKey, nil
+}
+
+func (q *FakeQuerier) InsertQuotaBudget(_ context.Context, arg database.InsertQuotaBudgetParams) (database.QuotaBudget, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.QuotaBudget{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, budget := range q.quotaBudgets {
+		if budget.Period != arg.Period {
+			continue
+		}
+		if arg.GroupID.Valid && budget.GroupID == arg.GroupID {
+			return database.QuotaBudget{}, newUniqueConstraintError(database.UniqueIndexQuotaBudgetsGroupIDPeriod)
+		}
+		if arg.UserID.Valid && budget.UserID == arg.UserID && budget.OrganizationID == arg.OrganizationID {
+			return database.QuotaBudget{}, newUniqueConstraintError(database.UniqueIndexQuotaBudgetsOrganizationIDUserIDPeriod)
+		}
+	}
+
+	budget := database.QuotaBudget{
+		ID:             arg.ID,
+		OrganizationID: arg.OrganizationID,
+		GroupID:        arg.GroupID,
+		UserID:         arg.UserID,
+		Period:         arg.Period,
+		SoftLimit:      arg.SoftLimit,
+		HardLimit:      arg.HardLimit,
+		StopWorkspaces: arg.StopWorkspaces,
+		CreatedAt:      arg.CreatedAt,
+		UpdatedAt:      arg.UpdatedAt,
+	}
+	q.quotaBudgets = append(q.quotaBudgets, budget)
+	return budget, nil
+}
+
+func (q *FakeQuerier) InsertQuotaBudgetAlert(_ context.Context, arg database.InsertQuotaBudgetAlertParams) (int64, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return 0, err
+	}
 
-	return provisionerKey, nil
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// Mimic ON CONFLICT DO NOTHING in query
+	for _, alert := range q.quotaBudgetAlerts {
+		if alert.BudgetID == arg.BudgetID && alert.UserID == arg.UserID &&
+			alert.PeriodStart.Equal(arg.PeriodStart) && alert.HardLimit == arg.HardLimit {
+			return 0, nil
+		}
+	}
+
+	q.quotaBudgetAlerts = append(q.quotaBudgetAlerts, database.QuotaBudgetAlert{
+		BudgetID:    arg.BudgetID,
+		UserID:      arg.UserID,
+		PeriodStart: arg.PeriodStart,
+		HardLimit:   arg.HardLimit,
+		CreatedAt:   arg.CreatedAt,
+	})
+	return 1, nil
 }
 
 func (q *FakeQuerier) InsertReplica(_ context.Context, arg database.InsertReplicaParams) (database.Replica, error) {
@@ -6662,6 +7417,32 @@ func (q *FakeQuerier) InsertTemplate(_ context.Context, arg database.InsertTempl
 	return nil
 }
 
+func (q *FakeQuerier) InsertTemplateReleaseChannel(_ context.Context, arg database.InsertTemplateReleaseChannelParams) (database.TemplateReleaseChannel, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, channel := range q.templateReleaseChannels {
+		if channel.TemplateID == arg.TemplateID && strings.EqualFold(channel.Name, arg.Name) {
+			return database.TemplateReleaseChannel{}, newUniqueConstraintError(database.UniqueIndexTemplateReleaseChannelsTemplateIDName)
+		}
+	}
+
+	channel := database.TemplateReleaseChannel{
+		ID:                arg.ID,
+		TemplateID:        arg.TemplateID,
+		Name:              arg.Name,
+		TemplateVersionID: arg.TemplateVersionID,
+		CreatedAt:         arg.CreatedAt,
+		UpdatedAt:         arg.UpdatedAt,
+	}
+	q.templateReleaseChannels = append(q.templateReleaseChannels, channel)
+	return channel, nil
+}
+
 func (q *FakeQuerier) InsertTemplateVersion(_ context.Context, arg database.InsertTemplateVersionParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -6880,6 +7661,9 @@ func (q *FakeQuerier) InsertWorkspace(_ context.Context, arg database.InsertWork
 		Ttl:               arg.Ttl,
 		LastUsedAt:        arg.LastUsedAt,
 		AutomaticUpdates:  arg.AutomaticUpdates,
+		UserACL:           database.WorkspaceACL{},
+		GroupACL:          database.WorkspaceACL{},
+		LastActiveAt:      dbtime.Now(),
 	}
 	q.workspaces = append(q.workspaces, workspace)
 	return workspace, nil
@@ -7283,6 +8067,38 @@ func (q *FakeQuerier) InsertWorkspaceResourceMetadata(_ context.Context, arg dat
 	return metadata, nil
 }
 
+func (q *FakeQuerier) InsertWorkspaceSnapshot(_ context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.WorkspaceSnapshot{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, snapshot := range q.workspaceSnapshots {
+		if snapshot.WorkspaceID == arg.WorkspaceID && strings.EqualFold(snapshot.Name, arg.Name) {
+			return database.WorkspaceSnapshot{}, newUniqueConstraintError(database.UniqueIndexWorkspaceSnapshotsWorkspaceIDName)
+		}
+	}
+
+	snapshot := database.WorkspaceSnapshot{
+		ID:                arg.ID,
+		WorkspaceID:       arg.WorkspaceID,
+		WorkspaceBuildID:  arg.WorkspaceBuildID,
+		BuildNumber:       arg.BuildNumber,
+		TemplateVersionID: arg.TemplateVersionID,
+		Name:              arg.Name,
+		Transition:        arg.Transition,
+		ProvisionerState:  arg.ProvisionerState,
+		RichParameters:    arg.RichParameters,
+		CreatedBy:         arg.CreatedBy,
+		CreatedAt:         arg.CreatedAt,
+	}
+	q.workspaceSnapshots = append(q.workspaceSnapshots, snapshot)
+	return snapshot, nil
+}
+
 func (q *FakeQuerier) ListProvisionerKeysByOrganization(_ context.Context, organizationID uuid.UUID) ([]database.ProvisionerKey, error) {
 	q.mutex.RLock()
 	defer q.mutex.RUnlock()
@@ -7311,6 +8127,23 @@ func (q *FakeQuerier) ListWorkspaceAgentPortShares(_ context.Context, workspaceI
 	return shares, nil
 }
 
+func (q *FakeQuerier) MarkAllInboxNotificationsAsRead(_ context.Context, arg database.MarkAllInboxNotificationsAsReadParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, n := range q.inboxNotifications {
+		if n.UserID == arg.UserID && !n.ReadAt.Valid {
+			q.inboxNotifications[i].ReadAt = sql.NullTime{Time: arg.ReadAt, Valid: true}
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) OrganizationMembers(_ context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return []database.OrganizationMembersRow{}, err
@@ -7334,13 +8167,17 @@ func (q *FakeQuerier) OrganizationMembers(_ context.Context, arg database.Organi
 		tmp = append(tmp, database.OrganizationMembersRow{
 			OrganizationMember: organizationMember,
 			Username:           user.Username,
+			AvatarURL:          user.AvatarURL,
+			Name:               user.Name,
+			Email:              user.Email,
+			GlobalRoles:        user.RBACRoles,
 		})
 	}
 	return tmp, nil
 }
 
-func (q *FakeQuerier) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(_ context.Context, templateID uuid.UUID) error {
-	err := validateDatabaseType(templateID)
+func (q *FakeQuerier) ReduceWorkspaceAgentShareLevelByTemplate(_ context.Context, arg database.ReduceWorkspaceAgentShareLevelByTemplateParams) error {
+	err := validateDatabaseType(arg)
 	if err != nil {
 		return err
 	}
@@ -7349,15 +8186,15 @@ func (q *FakeQuerier) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(_
 	defer q.mutex.Unlock()
 
 	for _, workspace := range q.workspaces {
-		if workspace.TemplateID != templateID {
+		if workspace.TemplateID != arg.TemplateID {
 			continue
 		}
 		for i, share := range q.workspaceAgentPortShares {
 			if share.WorkspaceID != workspace.ID {
 				continue
 			}
-			if share.ShareLevel == database.AppSharingLevelPublic {
-				share.ShareLevel = database.AppSharingLevelAuthenticated
+			if slices.Contains(arg.ReducedLevels, share.ShareLevel) {
+				share.ShareLevel = arg.ShareLevel
 			}
 			q.workspaceAgentPortShares[i] = share
 		}
@@ -7506,6 +8343,25 @@ func (q *FakeQuerier) UpdateAPIKeyByID(_ context.Context, arg database.UpdateAPI
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateAuditLogChainHead(_ context.Context, arg database.UpdateAuditLogChainHeadParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, head := range q.auditLogChainHeads {
+		if head.OrganizationID == arg.OrganizationID {
+			q.auditLogChainHeads[i].ChainIndex = arg.ChainIndex
+			q.auditLogChainHeads[i].Hash = arg.Hash
+			q.auditLogChainHeads[i].UpdatedAt = arg.UpdatedAt
+			return nil
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateExternalAuthLink(_ context.Context, arg database.UpdateExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.ExternalAuthLink{}, err
@@ -7599,6 +8455,24 @@ func (q *FakeQuerier) UpdateInactiveUsersToDormant(_ context.Context, params dat
 	return updated, nil
 }
 
+func (q *FakeQuerier) UpdateInboxNotificationReadStatus(_ context.Context, arg database.UpdateInboxNotificationReadStatusParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, n := range q.inboxNotifications {
+		if n.ID == arg.ID {
+			q.inboxNotifications[i].ReadAt = arg.ReadAt
+			return nil
+		}
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.OrganizationMember{}, err
@@ -7629,6 +8503,26 @@ func (q *FakeQuerier) UpdateMemberRoles(_ context.Context, arg database.UpdateMe
 	return database.OrganizationMember{}, sql.ErrNoRows
 }
 
+func (*FakeQuerier) UpdateNotificationTemplateMandatoryByID(_ context.Context, arg database.UpdateNotificationTemplateMandatoryByIDParams) (database.NotificationTemplate, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationTemplate{}, err
+	}
+
+	// Notification templates are managed by migrations, which dbmem does not run.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
+func (*FakeQuerier) UpdateNotificationTemplateOverrideByID(_ context.Context, arg database.UpdateNotificationTemplateOverrideByIDParams) (database.NotificationTemplate, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationTemplate{}, err
+	}
+
+	// Notification templates are managed by migrations, which dbmem does not run.
+	return database.NotificationTemplate{}, ErrUnimplemented
+}
+
 func (q *FakeQuerier) UpdateOAuth2ProviderAppByID(_ context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7653,6 +8547,8 @@ func (q *FakeQuerier) UpdateOAuth2ProviderAppByID(_ context.Context, arg databas
 				Name:        arg.Name,
 				Icon:        arg.Icon,
 				CallbackURL: arg.CallbackURL,
+				ClientType:  arg.ClientType,
+				GrantTypes:  app.GrantTypes,
 			}
 			q.oauth2ProviderApps[index] = newApp
 			return newApp, nil
@@ -7688,6 +8584,44 @@ func (q *FakeQuerier) UpdateOAuth2ProviderAppSecretByID(_ context.Context, arg d
 	return database.OAuth2ProviderAppSecret{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateOAuth2ProviderDeviceCodeLastPolledAt(_ context.Context, arg database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams) error {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, code := range q.oauth2ProviderDeviceCodes {
+		if code.ID == arg.ID {
+			q.oauth2ProviderDeviceCodes[index].LastPolledAt = arg.LastPolledAt
+			return nil
+		}
+	}
+	return nil
+}
+
+func (q *FakeQuerier) UpdateOAuth2ProviderDeviceCodeStatus(_ context.Context, arg database.UpdateOAuth2ProviderDeviceCodeStatusParams) (database.OAuth2ProviderDeviceCode, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.OAuth2ProviderDeviceCode{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, code := range q.oauth2ProviderDeviceCodes {
+		if code.ID == arg.ID && code.Status == database.OAuth2ProviderDeviceCodeStatusPending {
+			code.Status = arg.Status
+			code.UserID = arg.UserID
+			q.oauth2ProviderDeviceCodes[index] = code
+			return code, nil
+		}
+	}
+	return database.OAuth2ProviderDeviceCode{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateOrganization(_ context.Context, arg database.UpdateOrganizationParams) (database.Organization, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -7804,6 +8738,29 @@ func (q *FakeQuerier) UpdateProvisionerJobWithCompleteByID(_ context.Context, ar
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateQuotaBudgetByID(_ context.Context, arg database.UpdateQuotaBudgetByIDParams) (database.QuotaBudget, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.QuotaBudget{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, budget := range q.quotaBudgets {
+		if budget.ID != arg.ID {
+			continue
+		}
+		budget.SoftLimit = arg.SoftLimit
+		budget.HardLimit = arg.HardLimit
+		budget.StopWorkspaces = arg.StopWorkspaces
+		budget.UpdatedAt = arg.UpdatedAt
+		q.quotaBudgets[i] = budget
+		return budget, nil
+	}
+	return database.QuotaBudget{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateReplica(_ context.Context, arg database.UpdateReplicaParams) (database.Replica, error) {
 	if err := validateDatabaseType(arg); err != nil {
 		return database.Replica{}, err
@@ -7913,6 +8870,28 @@ func (q *FakeQuerier) UpdateTemplateDeletedByID(_ context.Context, arg database.
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateTemplateGitSyncStatus(_ context.Context, arg database.UpdateTemplateGitSyncStatusParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, sync := range q.templateGitSyncs {
+		if sync.TemplateID != arg.TemplateID {
+			continue
+		}
+		sync.LastCommitSha = arg.LastCommitSha
+		sync.LastTemplateVersionID = arg.LastTemplateVersionID
+		sync.LastSyncedAt = arg.LastSyncedAt
+		sync.LastError = arg.LastError
+		q.templateGitSyncs[i] = sync
+		return nil
+	}
+	return nil
+}
+
 func (q *FakeQuerier) UpdateTemplateMetaByID(_ context.Context, arg database.UpdateTemplateMetaByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -7940,6 +8919,26 @@ func (q *FakeQuerier) UpdateTemplateMetaByID(_ context.Context, arg database.Upd
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateTemplateReleaseChannelByID(_ context.Context, arg database.UpdateTemplateReleaseChannelByIDParams) (database.TemplateReleaseChannel, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.TemplateReleaseChannel{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, channel := range q.templateReleaseChannels {
+		if channel.ID != arg.ID {
+			continue
+		}
+		channel.TemplateVersionID = arg.TemplateVersionID
+		channel.UpdatedAt = arg.UpdatedAt
+		q.templateReleaseChannels[i] = channel
+		return channel, nil
+	}
+	return database.TemplateReleaseChannel{}, sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateTemplateScheduleByID(_ context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -7963,6 +8962,8 @@ func (q *FakeQuerier) UpdateTemplateScheduleByID(_ context.Context, arg database
 		tpl.FailureTTL = arg.FailureTTL
 		tpl.TimeTilDormant = arg.TimeTilDormant
 		tpl.TimeTilDormantAutoDelete = arg.TimeTilDormantAutoDelete
+		tpl.IdleTimeout = arg.IdleTimeout
+		tpl.IdleCPUThreshold = arg.IdleCPUThreshold
 		q.templates[idx] = tpl
 		return nil
 	}
@@ -8328,6 +9329,27 @@ func (q *FakeQuerier) UpdateWorkspace(_ context.Context, arg database.UpdateWork
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceACLByID(_ context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, workspace := range q.workspaces {
+		if workspace.ID == arg.ID {
+			workspace.GroupACL = arg.GroupACL
+			workspace.UserACL = arg.UserACL
+
+			q.workspaces[i] = workspace
+			return nil
+		}
+	}
+
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceAgentConnectionByID(_ context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8629,6 +9651,29 @@ func (q *FakeQuerier) UpdateWorkspaceDormantDeletingAt(_ context.Context, arg da
 	return database.Workspace{}, sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceLastActiveAt(_ context.Context, arg database.UpdateWorkspaceLastActiveAtParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, workspace := range q.workspaces {
+		if workspace.ID != arg.ID {
+			continue
+		}
+		// WHERE last_active_at < @last_active_at
+		if workspace.LastActiveAt.Before(arg.LastActiveAt) {
+			workspace.LastActiveAt = arg.LastActiveAt
+			q.workspaces[index] = workspace
+		}
+		return nil
+	}
+
+	return nil
+}
+
 func (q *FakeQuerier) UpdateWorkspaceLastUsedAt(_ context.Context, arg database.UpdateWorkspaceLastUsedAtParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8689,6 +9734,26 @@ func (q *FakeQuerier) UpdateWorkspaceProxyDeleted(_ context.Context, arg databas
 	return sql.ErrNoRows
 }
 
+func (q *FakeQuerier) UpdateWorkspaceReleaseChannel(_ context.Context, arg database.UpdateWorkspaceReleaseChannelParams) error {
+	if err := validateDatabaseType(arg); err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for index, workspace := range q.workspaces {
+		if workspace.ID != arg.ID {
+			continue
+		}
+		workspace.ReleaseChannel = arg.ReleaseChannel
+		q.workspaces[index] = workspace
+		return nil
+	}
+
+	return sql.ErrNoRows
+}
+
 func (q *FakeQuerier) UpdateWorkspaceTTL(_ context.Context, arg database.UpdateWorkspaceTTLParams) error {
 	if err := validateDatabaseType(arg); err != nil {
 		return err
@@ -8961,6 +10026,48 @@ func (*FakeQuerier) UpsertTailnetTunnel(_ context.Context, arg database.UpsertTa
 	return database.TailnetTunnel{}, ErrUnimplemented
 }
 
+func (q *FakeQuerier) UpsertTemplateGitSync(_ context.Context, arg database.UpsertTemplateGitSyncParams) (database.TemplateGitSync, error) {
+	if err := validateDatabaseType(arg); err != nil {
+		return database.TemplateGitSync{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, sync := range q.templateGitSyncs {
+		if sync.TemplateID != arg.TemplateID {
+			continue
+		}
+		if sync.RepositoryURL != arg.RepositoryURL || sync.Branch != arg.Branch || sync.Directory != arg.Directory {
+			sync.LastCommitSha = ""
+		}
+		sync.RepositoryURL = arg.RepositoryURL
+		sync.Branch = arg.Branch
+		sync.Directory = arg.Directory
+		sync.ExternalAuthProviderID = arg.ExternalAuthProviderID
+		sync.AutoActivate = arg.AutoActivate
+		sync.CreatedBy = arg.CreatedBy
+		sync.UpdatedAt = arg.UpdatedAt
+		q.templateGitSyncs[i] = sync
+		return sync, nil
+	}
+
+	sync := database.TemplateGitSync{
+		TemplateID:             arg.TemplateID,
+		RepositoryURL:          arg.RepositoryURL,
+		Branch:                 arg.Branch,
+		Directory:              arg.Directory,
+		ExternalAuthProviderID: arg.ExternalAuthProviderID,
+		AutoActivate:           arg.AutoActivate,
+		WebhookSecret:          arg.WebhookSecret,
+		CreatedBy:              arg.CreatedBy,
+		CreatedAt:              arg.CreatedAt,
+		UpdatedAt:              arg.UpdatedAt,
+	}
+	q.templateGitSyncs = append(q.templateGitSyncs, sync)
+	return sync, nil
+}
+
 func (q *FakeQuerier) UpsertTemplateUsageStats(ctx context.Context) error {
 	q.mutex.Lock()
 	defer q.mutex.Unlock()
@@ -9553,6 +10660,38 @@ TemplateUsageStatsInsertLoop:
 	return nil
 }
 
+func (q *FakeQuerier) UpsertUserNotificationPreference(_ context.Context, arg database.UpsertUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	err := validateDatabaseType(arg)
+	if err != nil {
+		return database.NotificationPreference{}, err
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, np := range q.notificationPreferences {
+		if np.UserID != arg.UserID || np.NotificationTemplateID != arg.NotificationTemplateID {
+			continue
+		}
+		np.Disabled = arg.Disabled
+		np.Method = arg.Method
+		np.UpdatedAt = dbtime.Now()
+		q.notificationPreferences[i] = np
+		return np, nil
+	}
+
+	np := database.NotificationPreference{
+		UserID:                 arg.UserID,
+		NotificationTemplateID: arg.NotificationTemplateID,
+		Disabled:               arg.Disabled,
+		Method:                 arg.Method,
+		CreatedAt:              dbtime.Now(),
+		UpdatedAt:              dbtime.Now(),
+	}
+	q.notificationPreferences = append(q.notificationPreferences, np)
+	return np, nil
+}
+
 func (q *FakeQuerier) UpsertWorkspaceAgentPortShare(_ context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	err := validateDatabaseType(arg)
 	if err != nil {
@@ -9566,6 +10705,8 @@ func (q *FakeQuerier) UpsertWorkspaceAgentPortShare(_ context.Context, arg datab
 		if share.WorkspaceID == arg.WorkspaceID && share.Port == arg.Port && share.AgentName == arg.AgentName {
 			share.ShareLevel = arg.ShareLevel
 			share.Protocol = arg.Protocol
+			share.AllowedUserIDs = arg.AllowedUserIDs
+			share.AllowedGroupIDs = arg.AllowedGroupIDs
 			q.workspaceAgentPortShares[i] = share
 			return share, nil
 		}
@@ -9573,11 +10714,13 @@ func (q *FakeQuerier) UpsertWorkspaceAgentPortShare(_ context.Context, arg datab
 
 	//nolint:gosimple // casts are not a simplification
 	psl := database.WorkspaceAgentPortShare{
-		WorkspaceID: arg.WorkspaceID,
-		AgentName:   arg.AgentName,
-		Port:        arg.Port,
-		ShareLevel:  arg.ShareLevel,
-		Protocol:    arg.Protocol,
+		WorkspaceID:     arg.WorkspaceID,
+		AgentName:       arg.AgentName,
+		Port:            arg.Port,
+		ShareLevel:      arg.ShareLevel,
+		Protocol:        arg.Protocol,
+		AllowedUserIDs:  arg.AllowedUserIDs,
+		AllowedGroupIDs: arg.AllowedGroupIDs,
 	}
 	q.workspaceAgentPortShares = append(q.workspaceAgentPortShares, psl)
 
@@ -81,6 +81,13 @@ func (m metricsStore) InTx(f func(database.Store) error, options *sql.TxOptions)
 	return err
 }
 
+func (m metricsStore) AcquireAuditLogChainHead(ctx context.Context, organizationID uuid.UUID) (database.AuditLogChainHead, error) {
+	start := time.Now()
+	r0, r1 := m.s.AcquireAuditLogChainHead(ctx, organizationID)
+	m.queryLatencies.WithLabelValues("AcquireAuditLogChainHead").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) AcquireLock(ctx context.Context, pgAdvisoryXactLock int64) error {
 	start := time.Now()
 	err := m.s.AcquireLock(ctx, pgAdvisoryXactLock)
@@ -144,6 +151,13 @@ func (m metricsStore) BulkMarkNotificationMessagesSent(ctx context.Context, arg
 	return r0, r1
 }
 
+func (m metricsStore) ClaimWorkspacesApproachingAutostop(ctx context.Context, arg database.ClaimWorkspacesApproachingAutostopParams) ([]database.ClaimWorkspacesApproachingAutostopRow, error) {
+	start := time.Now()
+	r0, r1 := m.s.ClaimWorkspacesApproachingAutostop(ctx, arg)
+	m.queryLatencies.WithLabelValues("ClaimWorkspacesApproachingAutostop").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) CleanTailnetCoordinators(ctx context.Context) error {
 	start := time.Now()
 	err := m.s.CleanTailnetCoordinators(ctx)
@@ -165,6 +179,20 @@ func (m metricsStore) CleanTailnetTunnels(ctx context.Context) error {
 	return r0
 }
 
+func (m metricsStore) ClearNotificationMessageDedupeHash(ctx context.Context, arg database.ClearNotificationMessageDedupeHashParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.ClearNotificationMessageDedupeHash(ctx, arg)
+	m.queryLatencies.WithLabelValues("ClearNotificationMessageDedupeHash").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.CountUnreadInboxNotificationsByUserID(ctx, userID)
+	m.queryLatencies.WithLabelValues("CountUnreadInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) CustomRoles(ctx context.Context, arg database.CustomRolesParams) ([]database.CustomRole, error) {
 	start := time.Now()
 	r0, r1 := m.s.CustomRoles(ctx, arg)
@@ -284,6 +312,13 @@ func (m metricsStore) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Co
 	return r0
 }
 
+func (m metricsStore) DeleteOAuth2ProviderDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteOAuth2ProviderDeviceCodeByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteOAuth2ProviderDeviceCodeByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteOldNotificationMessages(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.DeleteOldNotificationMessages(ctx)
@@ -333,6 +368,13 @@ func (m metricsStore) DeleteProvisionerKey(ctx context.Context, id uuid.UUID) er
 	return r0
 }
 
+func (m metricsStore) DeleteQuotaBudgetByID(ctx context.Context, id uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteQuotaBudgetByID(ctx, id)
+	m.queryLatencies.WithLabelValues("DeleteQuotaBudgetByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteReplicasUpdatedBefore(ctx context.Context, updatedAt time.Time) error {
 	start := time.Now()
 	err := m.s.DeleteReplicasUpdatedBefore(ctx, updatedAt)
@@ -375,6 +417,13 @@ func (m metricsStore) DeleteTailnetTunnel(ctx context.Context, arg database.Dele
 	return r0, r1
 }
 
+func (m metricsStore) DeleteTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) error {
+	start := time.Now()
+	r0 := m.s.DeleteTemplateGitSyncByTemplateID(ctx, templateID)
+	m.queryLatencies.WithLabelValues("DeleteTemplateGitSyncByTemplateID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) DeleteWorkspaceAgentPortShare(ctx context.Context, arg database.DeleteWorkspaceAgentPortShareParams) error {
 	start := time.Now()
 	r0 := m.s.DeleteWorkspaceAgentPortShare(ctx, arg)
@@ -508,6 +557,13 @@ func (m metricsStore) GetApplicationName(ctx context.Context) (string, error) {
 	return r0, r1
 }
 
+func (m metricsStore) GetAuditLogChainHeads(ctx context.Context) ([]database.AuditLogChainHead, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetAuditLogChainHeads(ctx)
+	m.queryLatencies.WithLabelValues("GetAuditLogChainHeads").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetAuditLogsOffset(ctx context.Context, arg database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	start := time.Now()
 	rows, err := m.s.GetAuditLogsOffset(ctx, arg)
@@ -522,6 +578,13 @@ func (m metricsStore) GetAuthorizationUserRoles(ctx context.Context, userID uuid
 	return row, err
 }
 
+func (m metricsStore) GetChainedAuditLogs(ctx context.Context, arg database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetChainedAuditLogs(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetChainedAuditLogs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetDBCryptKeys(ctx context.Context) ([]database.DBCryptKey, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetDBCryptKeys(ctx)
@@ -683,6 +746,20 @@ func (m metricsStore) GetHungProvisionerJobs(ctx context.Context, hungSince time
 	return jobs, err
 }
 
+func (m metricsStore) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetInboxNotificationsByUserID(ctx context.Context, arg database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetInboxNotificationsByUserID(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetInboxNotificationsByUserID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetJFrogXrayScanByWorkspaceAndAgentID(ctx context.Context, arg database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetJFrogXrayScanByWorkspaceAndAgentID(ctx, arg)
@@ -746,6 +823,20 @@ func (m metricsStore) GetNotificationMessagesByStatus(ctx context.Context, arg d
 	return r0, r1
 }
 
+func (m metricsStore) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTemplateByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetNotificationTemplateByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetNotificationTemplates(ctx context.Context) ([]database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetNotificationTemplates(ctx)
+	m.queryLatencies.WithLabelValues("GetNotificationTemplates").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetNotificationsSettings(ctx context.Context) (string, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetNotificationsSettings(ctx)
@@ -816,6 +907,20 @@ func (m metricsStore) GetOAuth2ProviderAppsByUserID(ctx context.Context, userID
 	return r0, r1
 }
 
+func (m metricsStore) GetOAuth2ProviderDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (database.OAuth2ProviderDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderDeviceCodeByPrefix(ctx, secretPrefix)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderDeviceCodeByPrefix").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetOAuth2ProviderDeviceCodeByUserCode(ctx context.Context, userCode string) (database.OAuth2ProviderDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetOAuth2ProviderDeviceCodeByUserCode(ctx, userCode)
+	m.queryLatencies.WithLabelValues("GetOAuth2ProviderDeviceCodeByUserCode").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetOAuthSigningKey(ctx context.Context) (string, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetOAuthSigningKey(ctx)
@@ -949,6 +1054,34 @@ func (m metricsStore) GetQuotaAllowanceForUser(ctx context.Context, userID uuid.
 	return allowance, err
 }
 
+func (m metricsStore) GetQuotaBudgetByID(ctx context.Context, id uuid.UUID) (database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetQuotaBudgetByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetQuotaBudgetByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetQuotaBudgets(ctx context.Context) ([]database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetQuotaBudgets(ctx)
+	m.queryLatencies.WithLabelValues("GetQuotaBudgets").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetQuotaBudgetsByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetQuotaBudgetsByOrganizationID(ctx, organizationID)
+	m.queryLatencies.WithLabelValues("GetQuotaBudgetsByOrganizationID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetQuotaBudgetsForUser(ctx context.Context, arg database.GetQuotaBudgetsForUserParams) ([]database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetQuotaBudgetsForUser(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetQuotaBudgetsForUser").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetQuotaConsumedForUser(ctx context.Context, ownerID uuid.UUID) (int64, error) {
 	start := time.Now()
 	consumed, err := m.s.GetQuotaConsumedForUser(ctx, ownerID)
@@ -956,6 +1089,13 @@ func (m metricsStore) GetQuotaConsumedForUser(ctx context.Context, ownerID uuid.
 	return consumed, err
 }
 
+func (m metricsStore) GetQuotaSpend(ctx context.Context, arg database.GetQuotaSpendParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetQuotaSpend(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetQuotaSpend").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetReplicaByID(ctx context.Context, id uuid.UUID) (database.Replica, error) {
 	start := time.Now()
 	replica, err := m.s.GetReplicaByID(ctx, id)
@@ -1047,6 +1187,20 @@ func (m metricsStore) GetTemplateDAUs(ctx context.Context, arg database.GetTempl
 	return daus, err
 }
 
+func (m metricsStore) GetTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) (database.TemplateGitSync, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateGitSyncByTemplateID(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplateGitSyncByTemplateID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplateGitSyncs(ctx context.Context) ([]database.TemplateGitSync, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateGitSyncs(ctx)
+	m.queryLatencies.WithLabelValues("GetTemplateGitSyncs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetTemplateInsights(ctx context.Context, arg database.GetTemplateInsightsParams) (database.GetTemplateInsightsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplateInsights(ctx, arg)
@@ -1075,6 +1229,27 @@ func (m metricsStore) GetTemplateParameterInsights(ctx context.Context, arg data
 	return r0, r1
 }
 
+func (m metricsStore) GetTemplateReleaseChannelByTemplateIDAndName(ctx context.Context, arg database.GetTemplateReleaseChannelByTemplateIDAndNameParams) (database.TemplateReleaseChannel, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateReleaseChannelByTemplateIDAndName(ctx, arg)
+	m.queryLatencies.WithLabelValues("GetTemplateReleaseChannelByTemplateIDAndName").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplateReleaseChannelsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateReleaseChannelsByTemplateID(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetTemplateReleaseChannelsByTemplateID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetTemplateReleaseChannelsByTemplateIDs(ctx context.Context, ids []uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetTemplateReleaseChannelsByTemplateIDs(ctx, ids)
+	m.queryLatencies.WithLabelValues("GetTemplateReleaseChannelsByTemplateIDs").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetTemplateUsageStats(ctx context.Context, arg database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetTemplateUsageStats(ctx, arg)
@@ -1222,6 +1397,13 @@ func (m metricsStore) GetUserLinksByUserID(ctx context.Context, userID uuid.UUID
 	return r0, r1
 }
 
+func (m metricsStore) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]database.NotificationPreference, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetUserNotificationPreferences(ctx, userID)
+	m.queryLatencies.WithLabelValues("GetUserNotificationPreferences").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetUserWorkspaceBuildParameters(ctx context.Context, ownerID database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetUserWorkspaceBuildParameters(ctx, ownerID)
@@ -1439,6 +1621,13 @@ func (m metricsStore) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspac
 	return workspace, err
 }
 
+func (m metricsStore) GetWorkspaceOwnerIDsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]uuid.UUID, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceOwnerIDsByTemplateID(ctx, templateID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceOwnerIDsByTemplateID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetWorkspaceProxies(ctx context.Context) ([]database.WorkspaceProxy, error) {
 	start := time.Now()
 	proxies, err := m.s.GetWorkspaceProxies(ctx)
@@ -1509,6 +1698,20 @@ func (m metricsStore) GetWorkspaceResourcesCreatedAfter(ctx context.Context, cre
 	return resources, err
 }
 
+func (m metricsStore) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSnapshotByID(ctx, id)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSnapshotByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspaceID)
+	m.queryLatencies.WithLabelValues("GetWorkspaceSnapshotsByWorkspaceID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Context, templateIds []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx, templateIds)
@@ -1607,6 +1810,13 @@ func (m metricsStore) InsertGroupMember(ctx context.Context, arg database.Insert
 	return err
 }
 
+func (m metricsStore) InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error {
+	start := time.Now()
+	r0 := m.s.InsertInboxNotification(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertInboxNotification").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) InsertLicense(ctx context.Context, arg database.InsertLicenseParams) (database.License, error) {
 	start := time.Now()
 	license, err := m.s.InsertLicense(ctx, arg)
@@ -1649,6 +1859,13 @@ func (m metricsStore) InsertOAuth2ProviderAppToken(ctx context.Context, arg data
 	return r0, r1
 }
 
+func (m metricsStore) InsertOAuth2ProviderDeviceCode(ctx context.Context, arg database.InsertOAuth2ProviderDeviceCodeParams) (database.OAuth2ProviderDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertOAuth2ProviderDeviceCode(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertOAuth2ProviderDeviceCode").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertOrganization(ctx context.Context, arg database.InsertOrganizationParams) (database.Organization, error) {
 	start := time.Now()
 	organization, err := m.s.InsertOrganization(ctx, arg)
@@ -1684,6 +1901,20 @@ func (m metricsStore) InsertProvisionerKey(ctx context.Context, arg database.Ins
 	return r0, r1
 }
 
+func (m metricsStore) InsertQuotaBudget(ctx context.Context, arg database.InsertQuotaBudgetParams) (database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertQuotaBudget(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertQuotaBudget").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) InsertQuotaBudgetAlert(ctx context.Context, arg database.InsertQuotaBudgetAlertParams) (int64, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertQuotaBudgetAlert(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertQuotaBudgetAlert").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertReplica(ctx context.Context, arg database.InsertReplicaParams) (database.Replica, error) {
 	start := time.Now()
 	replica, err := m.s.InsertReplica(ctx, arg)
@@ -1698,6 +1929,13 @@ func (m metricsStore) InsertTemplate(ctx context.Context, arg database.InsertTem
 	return err
 }
 
+func (m metricsStore) InsertTemplateReleaseChannel(ctx context.Context, arg database.InsertTemplateReleaseChannelParams) (database.TemplateReleaseChannel, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertTemplateReleaseChannel(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertTemplateReleaseChannel").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) InsertTemplateVersion(ctx context.Context, arg database.InsertTemplateVersionParams) error {
 	start := time.Now()
 	err := m.s.InsertTemplateVersion(ctx, arg)
@@ -1845,6 +2083,13 @@ func (m metricsStore) InsertWorkspaceResourceMetadata(ctx context.Context, arg d
 	return metadata, err
 }
 
+func (m metricsStore) InsertWorkspaceSnapshot(ctx context.Context, arg database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	start := time.Now()
+	r0, r1 := m.s.InsertWorkspaceSnapshot(ctx, arg)
+	m.queryLatencies.WithLabelValues("InsertWorkspaceSnapshot").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) ListProvisionerKeysByOrganization(ctx context.Context, organizationID uuid.UUID) ([]database.ProvisionerKey, error) {
 	start := time.Now()
 	r0, r1 := m.s.ListProvisionerKeysByOrganization(ctx, organizationID)
@@ -1859,6 +2104,13 @@ func (m metricsStore) ListWorkspaceAgentPortShares(ctx context.Context, workspac
 	return r0, r1
 }
 
+func (m metricsStore) MarkAllInboxNotificationsAsRead(ctx context.Context, arg database.MarkAllInboxNotificationsAsReadParams) error {
+	start := time.Now()
+	r0 := m.s.MarkAllInboxNotificationsAsRead(ctx, arg)
+	m.queryLatencies.WithLabelValues("MarkAllInboxNotificationsAsRead").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) OrganizationMembers(ctx context.Context, arg database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	start := time.Now()
 	r0, r1 := m.s.OrganizationMembers(ctx, arg)
@@ -1866,10 +2118,10 @@ func (m metricsStore) OrganizationMembers(ctx context.Context, arg database.Orga
 	return r0, r1
 }
 
-func (m metricsStore) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx context.Context, templateID uuid.UUID) error {
+func (m metricsStore) ReduceWorkspaceAgentShareLevelByTemplate(ctx context.Context, arg database.ReduceWorkspaceAgentShareLevelByTemplateParams) error {
 	start := time.Now()
-	r0 := m.s.ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx, templateID)
-	m.queryLatencies.WithLabelValues("ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate").Observe(time.Since(start).Seconds())
+	r0 := m.s.ReduceWorkspaceAgentShareLevelByTemplate(ctx, arg)
+	m.queryLatencies.WithLabelValues("ReduceWorkspaceAgentShareLevelByTemplate").Observe(time.Since(start).Seconds())
 	return r0
 }
 
@@ -1922,6 +2174,13 @@ func (m metricsStore) UpdateAPIKeyByID(ctx context.Context, arg database.UpdateA
 	return err
 }
 
+func (m metricsStore) UpdateAuditLogChainHead(ctx context.Context, arg database.UpdateAuditLogChainHeadParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateAuditLogChainHead(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateAuditLogChainHead").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateExternalAuthLink(ctx context.Context, arg database.UpdateExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	start := time.Now()
 	link, err := m.s.UpdateExternalAuthLink(ctx, arg)
@@ -1950,6 +2209,13 @@ func (m metricsStore) UpdateInactiveUsersToDormant(ctx context.Context, lastSeen
 	return r0, r1
 }
 
+func (m metricsStore) UpdateInboxNotificationReadStatus(ctx context.Context, arg database.UpdateInboxNotificationReadStatusParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateInboxNotificationReadStatus(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateInboxNotificationReadStatus").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateMemberRoles(ctx context.Context, arg database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	start := time.Now()
 	member, err := m.s.UpdateMemberRoles(ctx, arg)
@@ -1957,6 +2223,20 @@ func (m metricsStore) UpdateMemberRoles(ctx context.Context, arg database.Update
 	return member, err
 }
 
+func (m metricsStore) UpdateNotificationTemplateMandatoryByID(ctx context.Context, arg database.UpdateNotificationTemplateMandatoryByIDParams) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTemplateMandatoryByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTemplateMandatoryByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
+func (m metricsStore) UpdateNotificationTemplateOverrideByID(ctx context.Context, arg database.UpdateNotificationTemplateOverrideByIDParams) (database.NotificationTemplate, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateNotificationTemplateOverrideByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateNotificationTemplateOverrideByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateOAuth2ProviderAppByID(ctx context.Context, arg database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpdateOAuth2ProviderAppByID(ctx, arg)
@@ -1971,6 +2251,20 @@ func (m metricsStore) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg
 	return r0, r1
 }
 
+func (m metricsStore) UpdateOAuth2ProviderDeviceCodeLastPolledAt(ctx context.Context, arg database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateOAuth2ProviderDeviceCodeLastPolledAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateOAuth2ProviderDeviceCodeLastPolledAt").Observe(time.Since(start).Seconds())
+	return r0
+}
+
+func (m metricsStore) UpdateOAuth2ProviderDeviceCodeStatus(ctx context.Context, arg database.UpdateOAuth2ProviderDeviceCodeStatusParams) (database.OAuth2ProviderDeviceCode, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateOAuth2ProviderDeviceCodeStatus(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateOAuth2ProviderDeviceCodeStatus").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateOrganization(ctx context.Context, arg database.UpdateOrganizationParams) (database.Organization, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpdateOrganization(ctx, arg)
@@ -2006,6 +2300,13 @@ func (m metricsStore) UpdateProvisionerJobWithCompleteByID(ctx context.Context,
 	return err
 }
 
+func (m metricsStore) UpdateQuotaBudgetByID(ctx context.Context, arg database.UpdateQuotaBudgetByIDParams) (database.QuotaBudget, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateQuotaBudgetByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateQuotaBudgetByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateReplica(ctx context.Context, arg database.UpdateReplicaParams) (database.Replica, error) {
 	start := time.Now()
 	replica, err := m.s.UpdateReplica(ctx, arg)
@@ -2041,6 +2342,13 @@ func (m metricsStore) UpdateTemplateDeletedByID(ctx context.Context, arg databas
 	return err
 }
 
+func (m metricsStore) UpdateTemplateGitSyncStatus(ctx context.Context, arg database.UpdateTemplateGitSyncStatusParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateTemplateGitSyncStatus(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateTemplateGitSyncStatus").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateTemplateMetaByID(ctx context.Context, arg database.UpdateTemplateMetaByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateTemplateMetaByID(ctx, arg)
@@ -2048,6 +2356,13 @@ func (m metricsStore) UpdateTemplateMetaByID(ctx context.Context, arg database.U
 	return err
 }
 
+func (m metricsStore) UpdateTemplateReleaseChannelByID(ctx context.Context, arg database.UpdateTemplateReleaseChannelByIDParams) (database.TemplateReleaseChannel, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpdateTemplateReleaseChannelByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateTemplateReleaseChannelByID").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpdateTemplateScheduleByID(ctx context.Context, arg database.UpdateTemplateScheduleByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateTemplateScheduleByID(ctx, arg)
@@ -2167,6 +2482,13 @@ func (m metricsStore) UpdateWorkspace(ctx context.Context, arg database.UpdateWo
 	return workspace, err
 }
 
+func (m metricsStore) UpdateWorkspaceACLByID(ctx context.Context, arg database.UpdateWorkspaceACLByIDParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceACLByID(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceACLByID").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateWorkspaceAgentConnectionByID(ctx context.Context, arg database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceAgentConnectionByID(ctx, arg)
@@ -2258,6 +2580,13 @@ func (m metricsStore) UpdateWorkspaceDormantDeletingAt(ctx context.Context, arg
 	return ws, r0
 }
 
+func (m metricsStore) UpdateWorkspaceLastActiveAt(ctx context.Context, arg database.UpdateWorkspaceLastActiveAtParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceLastActiveAt(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceLastActiveAt").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateWorkspaceLastUsedAt(ctx context.Context, arg database.UpdateWorkspaceLastUsedAtParams) error {
 	start := time.Now()
 	err := m.s.UpdateWorkspaceLastUsedAt(ctx, arg)
@@ -2279,6 +2608,13 @@ func (m metricsStore) UpdateWorkspaceProxyDeleted(ctx context.Context, arg datab
 	return r0
 }
 
+func (m metricsStore) UpdateWorkspaceReleaseChannel(ctx context.Context, arg database.UpdateWorkspaceReleaseChannelParams) error {
+	start := time.Now()
+	r0 := m.s.UpdateWorkspaceReleaseChannel(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpdateWorkspaceReleaseChannel").Observe(time.Since(start).Seconds())
+	return r0
+}
+
 func (m metricsStore) UpdateWorkspaceTTL(ctx context.Context, arg database.UpdateWorkspaceTTLParams) error {
 	start := time.Now()
 	r0 := m.s.UpdateWorkspaceTTL(ctx, arg)
@@ -2419,6 +2755,13 @@ func (m metricsStore) UpsertTailnetTunnel(ctx context.Context, arg database.Upse
 	return r0, r1
 }
 
+func (m metricsStore) UpsertTemplateGitSync(ctx context.Context, arg database.UpsertTemplateGitSyncParams) (database.TemplateGitSync, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertTemplateGitSync(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertTemplateGitSync").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpsertTemplateUsageStats(ctx context.Context) error {
 	start := time.Now()
 	r0 := m.s.UpsertTemplateUsageStats(ctx)
@@ -2426,6 +2769,13 @@ func (m metricsStore) UpsertTemplateUsageStats(ctx context.Context) error {
 	return r0
 }
 
+func (m metricsStore) UpsertUserNotificationPreference(ctx context.Context, arg database.UpsertUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	start := time.Now()
+	r0, r1 := m.s.UpsertUserNotificationPreference(ctx, arg)
+	m.queryLatencies.WithLabelValues("UpsertUserNotificationPreference").Observe(time.Since(start).Seconds())
+	return r0, r1
+}
+
 func (m metricsStore) UpsertWorkspaceAgentPortShare(ctx context.Context, arg database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	start := time.Now()
 	r0, r1 := m.s.UpsertWorkspaceAgentPortShare(ctx, arg)
@@ -44,6 +44,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcquireAuditLogChainHead mocks base method.
+func (m *MockStore) AcquireAuditLogChainHead(arg0 context.Context, arg1 uuid.UUID) (database.AuditLogChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireAuditLogChainHead", arg0, arg1)
+	ret0, _ := ret[0].(database.AuditLogChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireAuditLogChainHead indicates an expected call of AcquireAuditLogChainHead.
+func (mr *MockStoreMockRecorder) AcquireAuditLogChainHead(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireAuditLogChainHead", reflect.TypeOf((*MockStore)(nil).AcquireAuditLogChainHead), arg0, arg1)
+}
+
 // AcquireLock mocks base method.
 func (m *MockStore) AcquireLock(arg0 context.Context, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -176,6 +191,21 @@ func (mr *MockStoreMockRecorder) BulkMarkNotificationMessagesSent(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkMarkNotificationMessagesSent", reflect.TypeOf((*MockStore)(nil).BulkMarkNotificationMessagesSent), arg0, arg1)
 }
 
+// ClaimWorkspacesApproachingAutostop mocks base method.
+func (m *MockStore) ClaimWorkspacesApproachingAutostop(arg0 context.Context, arg1 database.ClaimWorkspacesApproachingAutostopParams) ([]database.ClaimWorkspacesApproachingAutostopRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWorkspacesApproachingAutostop", arg0, arg1)
+	ret0, _ := ret[0].([]database.ClaimWorkspacesApproachingAutostopRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWorkspacesApproachingAutostop indicates an expected call of ClaimWorkspacesApproachingAutostop.
+func (mr *MockStoreMockRecorder) ClaimWorkspacesApproachingAutostop(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWorkspacesApproachingAutostop", reflect.TypeOf((*MockStore)(nil).ClaimWorkspacesApproachingAutostop), arg0, arg1)
+}
+
 // CleanTailnetCoordinators mocks base method.
 func (m *MockStore) CleanTailnetCoordinators(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -218,6 +248,36 @@ func (mr *MockStoreMockRecorder) CleanTailnetTunnels(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanTailnetTunnels", reflect.TypeOf((*MockStore)(nil).CleanTailnetTunnels), arg0)
 }
 
+// ClearNotificationMessageDedupeHash mocks base method.
+func (m *MockStore) ClearNotificationMessageDedupeHash(arg0 context.Context, arg1 database.ClearNotificationMessageDedupeHashParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearNotificationMessageDedupeHash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearNotificationMessageDedupeHash indicates an expected call of ClearNotificationMessageDedupeHash.
+func (mr *MockStoreMockRecorder) ClearNotificationMessageDedupeHash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearNotificationMessageDedupeHash", reflect.TypeOf((*MockStore)(nil).ClearNotificationMessageDedupeHash), arg0, arg1)
+}
+
+// CountUnreadInboxNotificationsByUserID mocks base method.
+func (m *MockStore) CountUnreadInboxNotificationsByUserID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadInboxNotificationsByUserID indicates an expected call of CountUnreadInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) CountUnreadInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).CountUnreadInboxNotificationsByUserID), arg0, arg1)
+}
+
 // CustomRoles mocks base method.
 func (m *MockStore) CustomRoles(arg0 context.Context, arg1 database.CustomRolesParams) ([]database.CustomRole, error) {
 	m.ctrl.T.Helper()
@@ -458,6 +518,20 @@ func (mr *MockStoreMockRecorder) DeleteOAuth2ProviderAppTokensByAppAndUserID(arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2ProviderAppTokensByAppAndUserID", reflect.TypeOf((*MockStore)(nil).DeleteOAuth2ProviderAppTokensByAppAndUserID), arg0, arg1)
 }
 
+// DeleteOAuth2ProviderDeviceCodeByID mocks base method.
+func (m *MockStore) DeleteOAuth2ProviderDeviceCodeByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOAuth2ProviderDeviceCodeByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOAuth2ProviderDeviceCodeByID indicates an expected call of DeleteOAuth2ProviderDeviceCodeByID.
+func (mr *MockStoreMockRecorder) DeleteOAuth2ProviderDeviceCodeByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOAuth2ProviderDeviceCodeByID", reflect.TypeOf((*MockStore)(nil).DeleteOAuth2ProviderDeviceCodeByID), arg0, arg1)
+}
+
 // DeleteOldNotificationMessages mocks base method.
 func (m *MockStore) DeleteOldNotificationMessages(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -556,6 +630,20 @@ func (mr *MockStoreMockRecorder) DeleteProvisionerKey(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProvisionerKey", reflect.TypeOf((*MockStore)(nil).DeleteProvisionerKey), arg0, arg1)
 }
 
+// DeleteQuotaBudgetByID mocks base method.
+func (m *MockStore) DeleteQuotaBudgetByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQuotaBudgetByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteQuotaBudgetByID indicates an expected call of DeleteQuotaBudgetByID.
+func (mr *MockStoreMockRecorder) DeleteQuotaBudgetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQuotaBudgetByID", reflect.TypeOf((*MockStore)(nil).DeleteQuotaBudgetByID), arg0, arg1)
+}
+
 // DeleteReplicasUpdatedBefore mocks base method.
 func (m *MockStore) DeleteReplicasUpdatedBefore(arg0 context.Context, arg1 time.Time) error {
 	m.ctrl.T.Helper()
@@ -644,6 +732,20 @@ func (mr *MockStoreMockRecorder) DeleteTailnetTunnel(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTailnetTunnel", reflect.TypeOf((*MockStore)(nil).DeleteTailnetTunnel), arg0, arg1)
 }
 
+// DeleteTemplateGitSyncByTemplateID mocks base method.
+func (m *MockStore) DeleteTemplateGitSyncByTemplateID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplateGitSyncByTemplateID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplateGitSyncByTemplateID indicates an expected call of DeleteTemplateGitSyncByTemplateID.
+func (mr *MockStoreMockRecorder) DeleteTemplateGitSyncByTemplateID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplateGitSyncByTemplateID", reflect.TypeOf((*MockStore)(nil).DeleteTemplateGitSyncByTemplateID), arg0, arg1)
+}
+
 // DeleteWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) DeleteWorkspaceAgentPortShare(arg0 context.Context, arg1 database.DeleteWorkspaceAgentPortShareParams) error {
 	m.ctrl.T.Helper()
@@ -925,6 +1027,21 @@ func (mr *MockStoreMockRecorder) GetApplicationName(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApplicationName", reflect.TypeOf((*MockStore)(nil).GetApplicationName), arg0)
 }
 
+// GetAuditLogChainHeads mocks base method.
+func (m *MockStore) GetAuditLogChainHeads(arg0 context.Context) ([]database.AuditLogChainHead, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogChainHeads", arg0)
+	ret0, _ := ret[0].([]database.AuditLogChainHead)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogChainHeads indicates an expected call of GetAuditLogChainHeads.
+func (mr *MockStoreMockRecorder) GetAuditLogChainHeads(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogChainHeads", reflect.TypeOf((*MockStore)(nil).GetAuditLogChainHeads), arg0)
+}
+
 // GetAuditLogsOffset mocks base method.
 func (m *MockStore) GetAuditLogsOffset(arg0 context.Context, arg1 database.GetAuditLogsOffsetParams) ([]database.GetAuditLogsOffsetRow, error) {
 	m.ctrl.T.Helper()
@@ -1000,6 +1117,21 @@ func (mr *MockStoreMockRecorder) GetAuthorizedWorkspaces(arg0, arg1, arg2 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorizedWorkspaces", reflect.TypeOf((*MockStore)(nil).GetAuthorizedWorkspaces), arg0, arg1, arg2)
 }
 
+// GetChainedAuditLogs mocks base method.
+func (m *MockStore) GetChainedAuditLogs(arg0 context.Context, arg1 database.GetChainedAuditLogsParams) ([]database.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChainedAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]database.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChainedAuditLogs indicates an expected call of GetChainedAuditLogs.
+func (mr *MockStoreMockRecorder) GetChainedAuditLogs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChainedAuditLogs", reflect.TypeOf((*MockStore)(nil).GetChainedAuditLogs), arg0, arg1)
+}
+
 // GetDBCryptKeys mocks base method.
 func (m *MockStore) GetDBCryptKeys(arg0 context.Context) ([]database.DBCryptKey, error) {
 	m.ctrl.T.Helper()
@@ -1345,6 +1477,36 @@ func (mr *MockStoreMockRecorder) GetHungProvisionerJobs(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHungProvisionerJobs", reflect.TypeOf((*MockStore)(nil).GetHungProvisionerJobs), arg0, arg1)
 }
 
+// GetInboxNotificationByID mocks base method.
+func (m *MockStore) GetInboxNotificationByID(arg0 context.Context, arg1 uuid.UUID) (database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationByID", arg0, arg1)
+	ret0, _ := ret[0].(database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationByID indicates an expected call of GetInboxNotificationByID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationByID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationByID), arg0, arg1)
+}
+
+// GetInboxNotificationsByUserID mocks base method.
+func (m *MockStore) GetInboxNotificationsByUserID(arg0 context.Context, arg1 database.GetInboxNotificationsByUserIDParams) ([]database.InboxNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboxNotificationsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]database.InboxNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInboxNotificationsByUserID indicates an expected call of GetInboxNotificationsByUserID.
+func (mr *MockStoreMockRecorder) GetInboxNotificationsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboxNotificationsByUserID", reflect.TypeOf((*MockStore)(nil).GetInboxNotificationsByUserID), arg0, arg1)
+}
+
 // GetJFrogXrayScanByWorkspaceAndAgentID mocks base method.
 func (m *MockStore) GetJFrogXrayScanByWorkspaceAndAgentID(arg0 context.Context, arg1 database.GetJFrogXrayScanByWorkspaceAndAgentIDParams) (database.JfrogXrayScan, error) {
 	m.ctrl.T.Helper()
@@ -1480,6 +1642,36 @@ func (mr *MockStoreMockRecorder) GetNotificationMessagesByStatus(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationMessagesByStatus", reflect.TypeOf((*MockStore)(nil).GetNotificationMessagesByStatus), arg0, arg1)
 }
 
+// GetNotificationTemplateByID mocks base method.
+func (m *MockStore) GetNotificationTemplateByID(arg0 context.Context, arg1 uuid.UUID) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTemplateByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTemplateByID indicates an expected call of GetNotificationTemplateByID.
+func (mr *MockStoreMockRecorder) GetNotificationTemplateByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTemplateByID", reflect.TypeOf((*MockStore)(nil).GetNotificationTemplateByID), arg0, arg1)
+}
+
+// GetNotificationTemplates mocks base method.
+func (m *MockStore) GetNotificationTemplates(arg0 context.Context) ([]database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTemplates", arg0)
+	ret0, _ := ret[0].([]database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationTemplates indicates an expected call of GetNotificationTemplates.
+func (mr *MockStoreMockRecorder) GetNotificationTemplates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTemplates", reflect.TypeOf((*MockStore)(nil).GetNotificationTemplates), arg0)
+}
+
 // GetNotificationsSettings mocks base method.
 func (m *MockStore) GetNotificationsSettings(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -1630,6 +1822,36 @@ func (mr *MockStoreMockRecorder) GetOAuth2ProviderAppsByUserID(arg0, arg1 any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderAppsByUserID", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderAppsByUserID), arg0, arg1)
 }
 
+// GetOAuth2ProviderDeviceCodeByPrefix mocks base method.
+func (m *MockStore) GetOAuth2ProviderDeviceCodeByPrefix(arg0 context.Context, arg1 []byte) (database.OAuth2ProviderDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderDeviceCodeByPrefix", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderDeviceCodeByPrefix indicates an expected call of GetOAuth2ProviderDeviceCodeByPrefix.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderDeviceCodeByPrefix(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderDeviceCodeByPrefix", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderDeviceCodeByPrefix), arg0, arg1)
+}
+
+// GetOAuth2ProviderDeviceCodeByUserCode mocks base method.
+func (m *MockStore) GetOAuth2ProviderDeviceCodeByUserCode(arg0 context.Context, arg1 string) (database.OAuth2ProviderDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuth2ProviderDeviceCodeByUserCode", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuth2ProviderDeviceCodeByUserCode indicates an expected call of GetOAuth2ProviderDeviceCodeByUserCode.
+func (mr *MockStoreMockRecorder) GetOAuth2ProviderDeviceCodeByUserCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuth2ProviderDeviceCodeByUserCode", reflect.TypeOf((*MockStore)(nil).GetOAuth2ProviderDeviceCodeByUserCode), arg0, arg1)
+}
+
 // GetOAuthSigningKey mocks base method.
 func (m *MockStore) GetOAuthSigningKey(arg0 context.Context) (string, error) {
 	m.ctrl.T.Helper()
@@ -1915,6 +2137,66 @@ func (mr *MockStoreMockRecorder) GetQuotaAllowanceForUser(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaAllowanceForUser", reflect.TypeOf((*MockStore)(nil).GetQuotaAllowanceForUser), arg0, arg1)
 }
 
+// GetQuotaBudgetByID mocks base method.
+func (m *MockStore) GetQuotaBudgetByID(arg0 context.Context, arg1 uuid.UUID) (database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaBudgetByID", arg0, arg1)
+	ret0, _ := ret[0].(database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaBudgetByID indicates an expected call of GetQuotaBudgetByID.
+func (mr *MockStoreMockRecorder) GetQuotaBudgetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaBudgetByID", reflect.TypeOf((*MockStore)(nil).GetQuotaBudgetByID), arg0, arg1)
+}
+
+// GetQuotaBudgets mocks base method.
+func (m *MockStore) GetQuotaBudgets(arg0 context.Context) ([]database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaBudgets", arg0)
+	ret0, _ := ret[0].([]database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaBudgets indicates an expected call of GetQuotaBudgets.
+func (mr *MockStoreMockRecorder) GetQuotaBudgets(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaBudgets", reflect.TypeOf((*MockStore)(nil).GetQuotaBudgets), arg0)
+}
+
+// GetQuotaBudgetsByOrganizationID mocks base method.
+func (m *MockStore) GetQuotaBudgetsByOrganizationID(arg0 context.Context, arg1 uuid.UUID) ([]database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaBudgetsByOrganizationID", arg0, arg1)
+	ret0, _ := ret[0].([]database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaBudgetsByOrganizationID indicates an expected call of GetQuotaBudgetsByOrganizationID.
+func (mr *MockStoreMockRecorder) GetQuotaBudgetsByOrganizationID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaBudgetsByOrganizationID", reflect.TypeOf((*MockStore)(nil).GetQuotaBudgetsByOrganizationID), arg0, arg1)
+}
+
+// GetQuotaBudgetsForUser mocks base method.
+func (m *MockStore) GetQuotaBudgetsForUser(arg0 context.Context, arg1 database.GetQuotaBudgetsForUserParams) ([]database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaBudgetsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaBudgetsForUser indicates an expected call of GetQuotaBudgetsForUser.
+func (mr *MockStoreMockRecorder) GetQuotaBudgetsForUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaBudgetsForUser", reflect.TypeOf((*MockStore)(nil).GetQuotaBudgetsForUser), arg0, arg1)
+}
+
 // GetQuotaConsumedForUser mocks base method.
 func (m *MockStore) GetQuotaConsumedForUser(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1930,6 +2212,21 @@ func (mr *MockStoreMockRecorder) GetQuotaConsumedForUser(arg0, arg1 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaConsumedForUser", reflect.TypeOf((*MockStore)(nil).GetQuotaConsumedForUser), arg0, arg1)
 }
 
+// GetQuotaSpend mocks base method.
+func (m *MockStore) GetQuotaSpend(arg0 context.Context, arg1 database.GetQuotaSpendParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaSpend", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaSpend indicates an expected call of GetQuotaSpend.
+func (mr *MockStoreMockRecorder) GetQuotaSpend(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaSpend", reflect.TypeOf((*MockStore)(nil).GetQuotaSpend), arg0, arg1)
+}
+
 // GetReplicaByID mocks base method.
 func (m *MockStore) GetReplicaByID(arg0 context.Context, arg1 uuid.UUID) (database.Replica, error) {
 	m.ctrl.T.Helper()
@@ -2125,6 +2422,36 @@ func (mr *MockStoreMockRecorder) GetTemplateDAUs(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateDAUs", reflect.TypeOf((*MockStore)(nil).GetTemplateDAUs), arg0, arg1)
 }
 
+// GetTemplateGitSyncByTemplateID mocks base method.
+func (m *MockStore) GetTemplateGitSyncByTemplateID(arg0 context.Context, arg1 uuid.UUID) (database.TemplateGitSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateGitSyncByTemplateID", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateGitSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateGitSyncByTemplateID indicates an expected call of GetTemplateGitSyncByTemplateID.
+func (mr *MockStoreMockRecorder) GetTemplateGitSyncByTemplateID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateGitSyncByTemplateID", reflect.TypeOf((*MockStore)(nil).GetTemplateGitSyncByTemplateID), arg0, arg1)
+}
+
+// GetTemplateGitSyncs mocks base method.
+func (m *MockStore) GetTemplateGitSyncs(arg0 context.Context) ([]database.TemplateGitSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateGitSyncs", arg0)
+	ret0, _ := ret[0].([]database.TemplateGitSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateGitSyncs indicates an expected call of GetTemplateGitSyncs.
+func (mr *MockStoreMockRecorder) GetTemplateGitSyncs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateGitSyncs", reflect.TypeOf((*MockStore)(nil).GetTemplateGitSyncs), arg0)
+}
+
 // GetTemplateGroupRoles mocks base method.
 func (m *MockStore) GetTemplateGroupRoles(arg0 context.Context, arg1 uuid.UUID) ([]database.TemplateGroup, error) {
 	m.ctrl.T.Helper()
@@ -2200,6 +2527,51 @@ func (mr *MockStoreMockRecorder) GetTemplateParameterInsights(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateParameterInsights", reflect.TypeOf((*MockStore)(nil).GetTemplateParameterInsights), arg0, arg1)
 }
 
+// GetTemplateReleaseChannelByTemplateIDAndName mocks base method.
+func (m *MockStore) GetTemplateReleaseChannelByTemplateIDAndName(arg0 context.Context, arg1 database.GetTemplateReleaseChannelByTemplateIDAndNameParams) (database.TemplateReleaseChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateReleaseChannelByTemplateIDAndName", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateReleaseChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateReleaseChannelByTemplateIDAndName indicates an expected call of GetTemplateReleaseChannelByTemplateIDAndName.
+func (mr *MockStoreMockRecorder) GetTemplateReleaseChannelByTemplateIDAndName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateReleaseChannelByTemplateIDAndName", reflect.TypeOf((*MockStore)(nil).GetTemplateReleaseChannelByTemplateIDAndName), arg0, arg1)
+}
+
+// GetTemplateReleaseChannelsByTemplateID mocks base method.
+func (m *MockStore) GetTemplateReleaseChannelsByTemplateID(arg0 context.Context, arg1 uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateReleaseChannelsByTemplateID", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemplateReleaseChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateReleaseChannelsByTemplateID indicates an expected call of GetTemplateReleaseChannelsByTemplateID.
+func (mr *MockStoreMockRecorder) GetTemplateReleaseChannelsByTemplateID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateReleaseChannelsByTemplateID", reflect.TypeOf((*MockStore)(nil).GetTemplateReleaseChannelsByTemplateID), arg0, arg1)
+}
+
+// GetTemplateReleaseChannelsByTemplateIDs mocks base method.
+func (m *MockStore) GetTemplateReleaseChannelsByTemplateIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.TemplateReleaseChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateReleaseChannelsByTemplateIDs", arg0, arg1)
+	ret0, _ := ret[0].([]database.TemplateReleaseChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateReleaseChannelsByTemplateIDs indicates an expected call of GetTemplateReleaseChannelsByTemplateIDs.
+func (mr *MockStoreMockRecorder) GetTemplateReleaseChannelsByTemplateIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateReleaseChannelsByTemplateIDs", reflect.TypeOf((*MockStore)(nil).GetTemplateReleaseChannelsByTemplateIDs), arg0, arg1)
+}
+
 // GetTemplateUsageStats mocks base method.
 func (m *MockStore) GetTemplateUsageStats(arg0 context.Context, arg1 database.GetTemplateUsageStatsParams) ([]database.TemplateUsageStat, error) {
 	m.ctrl.T.Helper()
@@ -2530,6 +2902,21 @@ func (mr *MockStoreMockRecorder) GetUserLinksByUserID(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLinksByUserID", reflect.TypeOf((*MockStore)(nil).GetUserLinksByUserID), arg0, arg1)
 }
 
+// GetUserNotificationPreferences mocks base method.
+func (m *MockStore) GetUserNotificationPreferences(arg0 context.Context, arg1 uuid.UUID) ([]database.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserNotificationPreferences", arg0, arg1)
+	ret0, _ := ret[0].([]database.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserNotificationPreferences indicates an expected call of GetUserNotificationPreferences.
+func (mr *MockStoreMockRecorder) GetUserNotificationPreferences(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotificationPreferences", reflect.TypeOf((*MockStore)(nil).GetUserNotificationPreferences), arg0, arg1)
+}
+
 // GetUserWorkspaceBuildParameters mocks base method.
 func (m *MockStore) GetUserWorkspaceBuildParameters(arg0 context.Context, arg1 database.GetUserWorkspaceBuildParametersParams) ([]database.GetUserWorkspaceBuildParametersRow, error) {
 	m.ctrl.T.Helper()
@@ -2995,6 +3382,21 @@ func (mr *MockStoreMockRecorder) GetWorkspaceByWorkspaceAppID(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceByWorkspaceAppID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceByWorkspaceAppID), arg0, arg1)
 }
 
+// GetWorkspaceOwnerIDsByTemplateID mocks base method.
+func (m *MockStore) GetWorkspaceOwnerIDsByTemplateID(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceOwnerIDsByTemplateID", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceOwnerIDsByTemplateID indicates an expected call of GetWorkspaceOwnerIDsByTemplateID.
+func (mr *MockStoreMockRecorder) GetWorkspaceOwnerIDsByTemplateID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceOwnerIDsByTemplateID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceOwnerIDsByTemplateID), arg0, arg1)
+}
+
 // GetWorkspaceProxies mocks base method.
 func (m *MockStore) GetWorkspaceProxies(arg0 context.Context) ([]database.WorkspaceProxy, error) {
 	m.ctrl.T.Helper()
@@ -3145,6 +3547,36 @@ func (mr *MockStoreMockRecorder) GetWorkspaceResourcesCreatedAfter(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceResourcesCreatedAfter", reflect.TypeOf((*MockStore)(nil).GetWorkspaceResourcesCreatedAfter), arg0, arg1)
 }
 
+// GetWorkspaceSnapshotByID mocks base method.
+func (m *MockStore) GetWorkspaceSnapshotByID(arg0 context.Context, arg1 uuid.UUID) (database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSnapshotByID", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSnapshotByID indicates an expected call of GetWorkspaceSnapshotByID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSnapshotByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSnapshotByID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSnapshotByID), arg0, arg1)
+}
+
+// GetWorkspaceSnapshotsByWorkspaceID mocks base method.
+func (m *MockStore) GetWorkspaceSnapshotsByWorkspaceID(arg0 context.Context, arg1 uuid.UUID) ([]database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceSnapshotsByWorkspaceID", arg0, arg1)
+	ret0, _ := ret[0].([]database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceSnapshotsByWorkspaceID indicates an expected call of GetWorkspaceSnapshotsByWorkspaceID.
+func (mr *MockStoreMockRecorder) GetWorkspaceSnapshotsByWorkspaceID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceSnapshotsByWorkspaceID", reflect.TypeOf((*MockStore)(nil).GetWorkspaceSnapshotsByWorkspaceID), arg0, arg1)
+}
+
 // GetWorkspaceUniqueOwnerCountByTemplateIDs mocks base method.
 func (m *MockStore) GetWorkspaceUniqueOwnerCountByTemplateIDs(arg0 context.Context, arg1 []uuid.UUID) ([]database.GetWorkspaceUniqueOwnerCountByTemplateIDsRow, error) {
 	m.ctrl.T.Helper()
@@ -3365,6 +3797,20 @@ func (mr *MockStoreMockRecorder) InsertGroupMember(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertGroupMember", reflect.TypeOf((*MockStore)(nil).InsertGroupMember), arg0, arg1)
 }
 
+// InsertInboxNotification mocks base method.
+func (m *MockStore) InsertInboxNotification(arg0 context.Context, arg1 database.InsertInboxNotificationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertInboxNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertInboxNotification indicates an expected call of InsertInboxNotification.
+func (mr *MockStoreMockRecorder) InsertInboxNotification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertInboxNotification", reflect.TypeOf((*MockStore)(nil).InsertInboxNotification), arg0, arg1)
+}
+
 // InsertLicense mocks base method.
 func (m *MockStore) InsertLicense(arg0 context.Context, arg1 database.InsertLicenseParams) (database.License, error) {
 	m.ctrl.T.Helper()
@@ -3455,6 +3901,21 @@ func (mr *MockStoreMockRecorder) InsertOAuth2ProviderAppToken(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOAuth2ProviderAppToken", reflect.TypeOf((*MockStore)(nil).InsertOAuth2ProviderAppToken), arg0, arg1)
 }
 
+// InsertOAuth2ProviderDeviceCode mocks base method.
+func (m *MockStore) InsertOAuth2ProviderDeviceCode(arg0 context.Context, arg1 database.InsertOAuth2ProviderDeviceCodeParams) (database.OAuth2ProviderDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOAuth2ProviderDeviceCode", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertOAuth2ProviderDeviceCode indicates an expected call of InsertOAuth2ProviderDeviceCode.
+func (mr *MockStoreMockRecorder) InsertOAuth2ProviderDeviceCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOAuth2ProviderDeviceCode", reflect.TypeOf((*MockStore)(nil).InsertOAuth2ProviderDeviceCode), arg0, arg1)
+}
+
 // InsertOrganization mocks base method.
 func (m *MockStore) InsertOrganization(arg0 context.Context, arg1 database.InsertOrganizationParams) (database.Organization, error) {
 	m.ctrl.T.Helper()
@@ -3530,6 +3991,36 @@ func (mr *MockStoreMockRecorder) InsertProvisionerKey(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProvisionerKey", reflect.TypeOf((*MockStore)(nil).InsertProvisionerKey), arg0, arg1)
 }
 
+// InsertQuotaBudget mocks base method.
+func (m *MockStore) InsertQuotaBudget(arg0 context.Context, arg1 database.InsertQuotaBudgetParams) (database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertQuotaBudget", arg0, arg1)
+	ret0, _ := ret[0].(database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertQuotaBudget indicates an expected call of InsertQuotaBudget.
+func (mr *MockStoreMockRecorder) InsertQuotaBudget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertQuotaBudget", reflect.TypeOf((*MockStore)(nil).InsertQuotaBudget), arg0, arg1)
+}
+
+// InsertQuotaBudgetAlert mocks base method.
+func (m *MockStore) InsertQuotaBudgetAlert(arg0 context.Context, arg1 database.InsertQuotaBudgetAlertParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertQuotaBudgetAlert", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertQuotaBudgetAlert indicates an expected call of InsertQuotaBudgetAlert.
+func (mr *MockStoreMockRecorder) InsertQuotaBudgetAlert(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertQuotaBudgetAlert", reflect.TypeOf((*MockStore)(nil).InsertQuotaBudgetAlert), arg0, arg1)
+}
+
 // InsertReplica mocks base method.
 func (m *MockStore) InsertReplica(arg0 context.Context, arg1 database.InsertReplicaParams) (database.Replica, error) {
 	m.ctrl.T.Helper()
@@ -3559,6 +4050,21 @@ func (mr *MockStoreMockRecorder) InsertTemplate(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplate", reflect.TypeOf((*MockStore)(nil).InsertTemplate), arg0, arg1)
 }
 
+// InsertTemplateReleaseChannel mocks base method.
+func (m *MockStore) InsertTemplateReleaseChannel(arg0 context.Context, arg1 database.InsertTemplateReleaseChannelParams) (database.TemplateReleaseChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertTemplateReleaseChannel", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateReleaseChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertTemplateReleaseChannel indicates an expected call of InsertTemplateReleaseChannel.
+func (mr *MockStoreMockRecorder) InsertTemplateReleaseChannel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertTemplateReleaseChannel", reflect.TypeOf((*MockStore)(nil).InsertTemplateReleaseChannel), arg0, arg1)
+}
+
 // InsertTemplateVersion mocks base method.
 func (m *MockStore) InsertTemplateVersion(arg0 context.Context, arg1 database.InsertTemplateVersionParams) error {
 	m.ctrl.T.Helper()
@@ -3867,6 +4373,21 @@ func (mr *MockStoreMockRecorder) InsertWorkspaceResourceMetadata(arg0, arg1 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceResourceMetadata", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceResourceMetadata), arg0, arg1)
 }
 
+// InsertWorkspaceSnapshot mocks base method.
+func (m *MockStore) InsertWorkspaceSnapshot(arg0 context.Context, arg1 database.InsertWorkspaceSnapshotParams) (database.WorkspaceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertWorkspaceSnapshot", arg0, arg1)
+	ret0, _ := ret[0].(database.WorkspaceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertWorkspaceSnapshot indicates an expected call of InsertWorkspaceSnapshot.
+func (mr *MockStoreMockRecorder) InsertWorkspaceSnapshot(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertWorkspaceSnapshot", reflect.TypeOf((*MockStore)(nil).InsertWorkspaceSnapshot), arg0, arg1)
+}
+
 // ListProvisionerKeysByOrganization mocks base method.
 func (m *MockStore) ListProvisionerKeysByOrganization(arg0 context.Context, arg1 uuid.UUID) ([]database.ProvisionerKey, error) {
 	m.ctrl.T.Helper()
@@ -3897,6 +4418,20 @@ func (mr *MockStoreMockRecorder) ListWorkspaceAgentPortShares(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceAgentPortShares", reflect.TypeOf((*MockStore)(nil).ListWorkspaceAgentPortShares), arg0, arg1)
 }
 
+// MarkAllInboxNotificationsAsRead mocks base method.
+func (m *MockStore) MarkAllInboxNotificationsAsRead(arg0 context.Context, arg1 database.MarkAllInboxNotificationsAsReadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllInboxNotificationsAsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllInboxNotificationsAsRead indicates an expected call of MarkAllInboxNotificationsAsRead.
+func (mr *MockStoreMockRecorder) MarkAllInboxNotificationsAsRead(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllInboxNotificationsAsRead", reflect.TypeOf((*MockStore)(nil).MarkAllInboxNotificationsAsRead), arg0, arg1)
+}
+
 // OrganizationMembers mocks base method.
 func (m *MockStore) OrganizationMembers(arg0 context.Context, arg1 database.OrganizationMembersParams) ([]database.OrganizationMembersRow, error) {
 	m.ctrl.T.Helper()
@@ -3927,18 +4462,18 @@ func (mr *MockStoreMockRecorder) Ping(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockStore)(nil).Ping), arg0)
 }
 
-// ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate mocks base method.
-func (m *MockStore) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(arg0 context.Context, arg1 uuid.UUID) error {
+// ReduceWorkspaceAgentShareLevelByTemplate mocks base method.
+func (m *MockStore) ReduceWorkspaceAgentShareLevelByTemplate(arg0 context.Context, arg1 database.ReduceWorkspaceAgentShareLevelByTemplateParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReduceWorkspaceAgentShareLevelByTemplate", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate indicates an expected call of ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate.
-func (mr *MockStoreMockRecorder) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(arg0, arg1 any) *gomock.Call {
+// ReduceWorkspaceAgentShareLevelByTemplate indicates an expected call of ReduceWorkspaceAgentShareLevelByTemplate.
+func (mr *MockStoreMockRecorder) ReduceWorkspaceAgentShareLevelByTemplate(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate", reflect.TypeOf((*MockStore)(nil).ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReduceWorkspaceAgentShareLevelByTemplate", reflect.TypeOf((*MockStore)(nil).ReduceWorkspaceAgentShareLevelByTemplate), arg0, arg1)
 }
 
 // RegisterWorkspaceProxy mocks base method.
@@ -4041,6 +4576,20 @@ func (mr *MockStoreMockRecorder) UpdateAPIKeyByID(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPIKeyByID", reflect.TypeOf((*MockStore)(nil).UpdateAPIKeyByID), arg0, arg1)
 }
 
+// UpdateAuditLogChainHead mocks base method.
+func (m *MockStore) UpdateAuditLogChainHead(arg0 context.Context, arg1 database.UpdateAuditLogChainHeadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAuditLogChainHead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAuditLogChainHead indicates an expected call of UpdateAuditLogChainHead.
+func (mr *MockStoreMockRecorder) UpdateAuditLogChainHead(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAuditLogChainHead", reflect.TypeOf((*MockStore)(nil).UpdateAuditLogChainHead), arg0, arg1)
+}
+
 // UpdateExternalAuthLink mocks base method.
 func (m *MockStore) UpdateExternalAuthLink(arg0 context.Context, arg1 database.UpdateExternalAuthLinkParams) (database.ExternalAuthLink, error) {
 	m.ctrl.T.Helper()
@@ -4101,6 +4650,20 @@ func (mr *MockStoreMockRecorder) UpdateInactiveUsersToDormant(arg0, arg1 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInactiveUsersToDormant", reflect.TypeOf((*MockStore)(nil).UpdateInactiveUsersToDormant), arg0, arg1)
 }
 
+// UpdateInboxNotificationReadStatus mocks base method.
+func (m *MockStore) UpdateInboxNotificationReadStatus(arg0 context.Context, arg1 database.UpdateInboxNotificationReadStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInboxNotificationReadStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInboxNotificationReadStatus indicates an expected call of UpdateInboxNotificationReadStatus.
+func (mr *MockStoreMockRecorder) UpdateInboxNotificationReadStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInboxNotificationReadStatus", reflect.TypeOf((*MockStore)(nil).UpdateInboxNotificationReadStatus), arg0, arg1)
+}
+
 // UpdateMemberRoles mocks base method.
 func (m *MockStore) UpdateMemberRoles(arg0 context.Context, arg1 database.UpdateMemberRolesParams) (database.OrganizationMember, error) {
 	m.ctrl.T.Helper()
@@ -4116,6 +4679,36 @@ func (mr *MockStoreMockRecorder) UpdateMemberRoles(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRoles", reflect.TypeOf((*MockStore)(nil).UpdateMemberRoles), arg0, arg1)
 }
 
+// UpdateNotificationTemplateMandatoryByID mocks base method.
+func (m *MockStore) UpdateNotificationTemplateMandatoryByID(arg0 context.Context, arg1 database.UpdateNotificationTemplateMandatoryByIDParams) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTemplateMandatoryByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTemplateMandatoryByID indicates an expected call of UpdateNotificationTemplateMandatoryByID.
+func (mr *MockStoreMockRecorder) UpdateNotificationTemplateMandatoryByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTemplateMandatoryByID", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTemplateMandatoryByID), arg0, arg1)
+}
+
+// UpdateNotificationTemplateOverrideByID mocks base method.
+func (m *MockStore) UpdateNotificationTemplateOverrideByID(arg0 context.Context, arg1 database.UpdateNotificationTemplateOverrideByIDParams) (database.NotificationTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationTemplateOverrideByID", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNotificationTemplateOverrideByID indicates an expected call of UpdateNotificationTemplateOverrideByID.
+func (mr *MockStoreMockRecorder) UpdateNotificationTemplateOverrideByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationTemplateOverrideByID", reflect.TypeOf((*MockStore)(nil).UpdateNotificationTemplateOverrideByID), arg0, arg1)
+}
+
 // UpdateOAuth2ProviderAppByID mocks base method.
 func (m *MockStore) UpdateOAuth2ProviderAppByID(arg0 context.Context, arg1 database.UpdateOAuth2ProviderAppByIDParams) (database.OAuth2ProviderApp, error) {
 	m.ctrl.T.Helper()
@@ -4146,6 +4739,35 @@ func (mr *MockStoreMockRecorder) UpdateOAuth2ProviderAppSecretByID(arg0, arg1 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2ProviderAppSecretByID", reflect.TypeOf((*MockStore)(nil).UpdateOAuth2ProviderAppSecretByID), arg0, arg1)
 }
 
+// UpdateOAuth2ProviderDeviceCodeLastPolledAt mocks base method.
+func (m *MockStore) UpdateOAuth2ProviderDeviceCodeLastPolledAt(arg0 context.Context, arg1 database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2ProviderDeviceCodeLastPolledAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOAuth2ProviderDeviceCodeLastPolledAt indicates an expected call of UpdateOAuth2ProviderDeviceCodeLastPolledAt.
+func (mr *MockStoreMockRecorder) UpdateOAuth2ProviderDeviceCodeLastPolledAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2ProviderDeviceCodeLastPolledAt", reflect.TypeOf((*MockStore)(nil).UpdateOAuth2ProviderDeviceCodeLastPolledAt), arg0, arg1)
+}
+
+// UpdateOAuth2ProviderDeviceCodeStatus mocks base method.
+func (m *MockStore) UpdateOAuth2ProviderDeviceCodeStatus(arg0 context.Context, arg1 database.UpdateOAuth2ProviderDeviceCodeStatusParams) (database.OAuth2ProviderDeviceCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOAuth2ProviderDeviceCodeStatus", arg0, arg1)
+	ret0, _ := ret[0].(database.OAuth2ProviderDeviceCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOAuth2ProviderDeviceCodeStatus indicates an expected call of UpdateOAuth2ProviderDeviceCodeStatus.
+func (mr *MockStoreMockRecorder) UpdateOAuth2ProviderDeviceCodeStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2ProviderDeviceCodeStatus", reflect.TypeOf((*MockStore)(nil).UpdateOAuth2ProviderDeviceCodeStatus), arg0, arg1)
+}
+
 // UpdateOrganization mocks base method.
 func (m *MockStore) UpdateOrganization(arg0 context.Context, arg1 database.UpdateOrganizationParams) (database.Organization, error) {
 	m.ctrl.T.Helper()
@@ -4217,6 +4839,21 @@ func (mr *MockStoreMockRecorder) UpdateProvisionerJobWithCompleteByID(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProvisionerJobWithCompleteByID", reflect.TypeOf((*MockStore)(nil).UpdateProvisionerJobWithCompleteByID), arg0, arg1)
 }
 
+// UpdateQuotaBudgetByID mocks base method.
+func (m *MockStore) UpdateQuotaBudgetByID(arg0 context.Context, arg1 database.UpdateQuotaBudgetByIDParams) (database.QuotaBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuotaBudgetByID", arg0, arg1)
+	ret0, _ := ret[0].(database.QuotaBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateQuotaBudgetByID indicates an expected call of UpdateQuotaBudgetByID.
+func (mr *MockStoreMockRecorder) UpdateQuotaBudgetByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuotaBudgetByID", reflect.TypeOf((*MockStore)(nil).UpdateQuotaBudgetByID), arg0, arg1)
+}
+
 // UpdateReplica mocks base method.
 func (m *MockStore) UpdateReplica(arg0 context.Context, arg1 database.UpdateReplicaParams) (database.Replica, error) {
 	m.ctrl.T.Helper()
@@ -4288,6 +4925,20 @@ func (mr *MockStoreMockRecorder) UpdateTemplateDeletedByID(arg0, arg1 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateDeletedByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateDeletedByID), arg0, arg1)
 }
 
+// UpdateTemplateGitSyncStatus mocks base method.
+func (m *MockStore) UpdateTemplateGitSyncStatus(arg0 context.Context, arg1 database.UpdateTemplateGitSyncStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplateGitSyncStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplateGitSyncStatus indicates an expected call of UpdateTemplateGitSyncStatus.
+func (mr *MockStoreMockRecorder) UpdateTemplateGitSyncStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateGitSyncStatus", reflect.TypeOf((*MockStore)(nil).UpdateTemplateGitSyncStatus), arg0, arg1)
+}
+
 // UpdateTemplateMetaByID mocks base method.
 func (m *MockStore) UpdateTemplateMetaByID(arg0 context.Context, arg1 database.UpdateTemplateMetaByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4302,6 +4953,21 @@ func (mr *MockStoreMockRecorder) UpdateTemplateMetaByID(arg0, arg1 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateMetaByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateMetaByID), arg0, arg1)
 }
 
+// UpdateTemplateReleaseChannelByID mocks base method.
+func (m *MockStore) UpdateTemplateReleaseChannelByID(arg0 context.Context, arg1 database.UpdateTemplateReleaseChannelByIDParams) (database.TemplateReleaseChannel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplateReleaseChannelByID", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateReleaseChannel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplateReleaseChannelByID indicates an expected call of UpdateTemplateReleaseChannelByID.
+func (mr *MockStoreMockRecorder) UpdateTemplateReleaseChannelByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplateReleaseChannelByID", reflect.TypeOf((*MockStore)(nil).UpdateTemplateReleaseChannelByID), arg0, arg1)
+}
+
 // UpdateTemplateScheduleByID mocks base method.
 func (m *MockStore) UpdateTemplateScheduleByID(arg0 context.Context, arg1 database.UpdateTemplateScheduleByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4550,6 +5216,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspace(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspace", reflect.TypeOf((*MockStore)(nil).UpdateWorkspace), arg0, arg1)
 }
 
+// UpdateWorkspaceACLByID mocks base method.
+func (m *MockStore) UpdateWorkspaceACLByID(arg0 context.Context, arg1 database.UpdateWorkspaceACLByIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceACLByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceACLByID indicates an expected call of UpdateWorkspaceACLByID.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceACLByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceACLByID", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceACLByID), arg0, arg1)
+}
+
 // UpdateWorkspaceAgentConnectionByID mocks base method.
 func (m *MockStore) UpdateWorkspaceAgentConnectionByID(arg0 context.Context, arg1 database.UpdateWorkspaceAgentConnectionByIDParams) error {
 	m.ctrl.T.Helper()
@@ -4733,6 +5413,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceDormantDeletingAt(arg0, arg1 any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceDormantDeletingAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceDormantDeletingAt), arg0, arg1)
 }
 
+// UpdateWorkspaceLastActiveAt mocks base method.
+func (m *MockStore) UpdateWorkspaceLastActiveAt(arg0 context.Context, arg1 database.UpdateWorkspaceLastActiveAtParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceLastActiveAt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceLastActiveAt indicates an expected call of UpdateWorkspaceLastActiveAt.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceLastActiveAt(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceLastActiveAt", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceLastActiveAt), arg0, arg1)
+}
+
 // UpdateWorkspaceLastUsedAt mocks base method.
 func (m *MockStore) UpdateWorkspaceLastUsedAt(arg0 context.Context, arg1 database.UpdateWorkspaceLastUsedAtParams) error {
 	m.ctrl.T.Helper()
@@ -4776,6 +5470,20 @@ func (mr *MockStoreMockRecorder) UpdateWorkspaceProxyDeleted(arg0, arg1 any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceProxyDeleted", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceProxyDeleted), arg0, arg1)
 }
 
+// UpdateWorkspaceReleaseChannel mocks base method.
+func (m *MockStore) UpdateWorkspaceReleaseChannel(arg0 context.Context, arg1 database.UpdateWorkspaceReleaseChannelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkspaceReleaseChannel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkspaceReleaseChannel indicates an expected call of UpdateWorkspaceReleaseChannel.
+func (mr *MockStoreMockRecorder) UpdateWorkspaceReleaseChannel(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkspaceReleaseChannel", reflect.TypeOf((*MockStore)(nil).UpdateWorkspaceReleaseChannel), arg0, arg1)
+}
+
 // UpdateWorkspaceTTL mocks base method.
 func (m *MockStore) UpdateWorkspaceTTL(arg0 context.Context, arg1 database.UpdateWorkspaceTTLParams) error {
 	m.ctrl.T.Helper()
@@ -5064,6 +5772,21 @@ func (mr *MockStoreMockRecorder) UpsertTailnetTunnel(arg0, arg1 any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTailnetTunnel", reflect.TypeOf((*MockStore)(nil).UpsertTailnetTunnel), arg0, arg1)
 }
 
+// UpsertTemplateGitSync mocks base method.
+func (m *MockStore) UpsertTemplateGitSync(arg0 context.Context, arg1 database.UpsertTemplateGitSyncParams) (database.TemplateGitSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTemplateGitSync", arg0, arg1)
+	ret0, _ := ret[0].(database.TemplateGitSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTemplateGitSync indicates an expected call of UpsertTemplateGitSync.
+func (mr *MockStoreMockRecorder) UpsertTemplateGitSync(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateGitSync", reflect.TypeOf((*MockStore)(nil).UpsertTemplateGitSync), arg0, arg1)
+}
+
 // UpsertTemplateUsageStats mocks base method.
 func (m *MockStore) UpsertTemplateUsageStats(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -5078,6 +5801,21 @@ func (mr *MockStoreMockRecorder) UpsertTemplateUsageStats(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTemplateUsageStats", reflect.TypeOf((*MockStore)(nil).UpsertTemplateUsageStats), arg0)
 }
 
+// UpsertUserNotificationPreference mocks base method.
+func (m *MockStore) UpsertUserNotificationPreference(arg0 context.Context, arg1 database.UpsertUserNotificationPreferenceParams) (database.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserNotificationPreference", arg0, arg1)
+	ret0, _ := ret[0].(database.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserNotificationPreference indicates an expected call of UpsertUserNotificationPreference.
+func (mr *MockStoreMockRecorder) UpsertUserNotificationPreference(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserNotificationPreference", reflect.TypeOf((*MockStore)(nil).UpsertUserNotificationPreference), arg0, arg1)
+}
+
 // UpsertWorkspaceAgentPortShare mocks base method.
 func (m *MockStore) UpsertWorkspaceAgentPortShare(arg0 context.Context, arg1 database.UpsertWorkspaceAgentPortShareParams) (database.WorkspaceAgentPortShare, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,8 @@ CREATE TYPE api_key_scope AS ENUM (
 
 CREATE TYPE app_sharing_level AS ENUM (
     'owner',
+    'allowlist',
+    'organization',
     'authenticated',
     'public'
 );
@@ -33,7 +35,9 @@ CREATE TYPE build_reason AS ENUM (
     'autostop',
     'dormancy',
     'failedstop',
-    'autodelete'
+    'autodelete',
+    'quota',
+    'idle'
 );
 
 CREATE TYPE display_app AS ENUM (
@@ -89,7 +93,21 @@ CREATE TYPE notification_message_status AS ENUM (
 
 CREATE TYPE notification_method AS ENUM (
     'smtp',
-    'webhook'
+    'webhook',
+    'slack',
+    'teams',
+    'inbox'
+);
+
+CREATE TYPE oauth2_provider_app_client_type AS ENUM (
+    'confidential',
+    'public'
+);
+
+CREATE TYPE oauth2_provider_device_code_status AS ENUM (
+    'pending',
+    'approved',
+    'denied'
 );
 
 CREATE TYPE parameter_destination_scheme AS ENUM (
@@ -116,7 +134,9 @@ CREATE TYPE parameter_type_system AS ENUM (
 
 CREATE TYPE port_share_protocol AS ENUM (
     'http',
-    'https'
+    'https',
+    'h2c',
+    'tcp'
 );
 
 CREATE TYPE provisioner_job_status AS ENUM (
@@ -146,6 +166,11 @@ CREATE TYPE provisioner_type AS ENUM (
     'terraform'
 );
 
+CREATE TYPE quota_budget_period AS ENUM (
+    'day',
+    'month'
+);
+
 CREATE TYPE resource_type AS ENUM (
     'organization',
     'template',
@@ -164,7 +189,11 @@ CREATE TYPE resource_type AS ENUM (
     'oauth2_provider_app_secret',
     'custom_role',
     'organization_member',
-    'notifications_settings'
+    'notifications_settings',
+    'notification_template',
+    'quota_budget',
+    'template_release_channel',
+    'template_git_sync'
 );
 
 CREATE TYPE startup_script_behavior AS ENUM (
@@ -403,11 +432,26 @@ CREATE TABLE api_keys (
     lifetime_seconds bigint DEFAULT 86400 NOT NULL,
     ip_address inet DEFAULT '0.0.0.0'::inet NOT NULL,
     scope api_key_scope DEFAULT 'all'::api_key_scope NOT NULL,
-    token_name text DEFAULT ''::text NOT NULL
+    token_name text DEFAULT ''::text NOT NULL,
+    scopes text[] DEFAULT '{}'::text[] NOT NULL,
+    allow_list uuid[] DEFAULT '{}'::uuid[] NOT NULL
 );
 
 COMMENT ON COLUMN api_keys.hashed_secret IS 'hashed_secret contains a SHA256 hash of the key secret. This is considered a secret and MUST NOT be returned from the API as it is used for API key encryption in app proxying code.';
 
+COMMENT ON COLUMN api_keys.scopes IS 'Permissions of the form "resource:action" the key is restricted to. When empty, the key is restricted by scope alone.';
+
+COMMENT ON COLUMN api_keys.allow_list IS 'IDs of the resources the key is restricted to, in addition to its owner. When empty, the key may access any resource its scopes allow.';
+
+CREATE TABLE audit_log_chain_heads (
+    organization_id uuid NOT NULL,
+    chain_index bigint NOT NULL,
+    hash bytea NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE audit_log_chain_heads IS 'Last audit log in the hash chain of each organization, so audit logs deleted from the end of a chain are detected.';
+
 CREATE TABLE audit_logs (
     id uuid NOT NULL,
     "time" timestamp with time zone NOT NULL,
@@ -423,9 +467,15 @@ CREATE TABLE audit_logs (
     status_code integer NOT NULL,
     additional_fields jsonb NOT NULL,
     request_id uuid NOT NULL,
-    resource_icon text NOT NULL
+    resource_icon text NOT NULL,
+    chain_index bigint,
+    hash bytea
 );
 
+COMMENT ON COLUMN audit_logs.chain_index IS 'Position of the audit log in the hash chain of its organization, starting at 1. Audit logs created before hash chains were introduced are not in a chain.';
+
+COMMENT ON COLUMN audit_logs.hash IS 'HMAC-SHA256 of the audit log and the hash of the previous audit log in the chain, keyed with a secret that is kept outside the database, so hashes cannot be recomputed from the database alone.';
+
 CREATE TABLE custom_roles (
     name text NOT NULL,
     display_name text NOT NULL,
@@ -520,6 +570,22 @@ COMMENT ON COLUMN groups.display_name IS 'Display name is a custom, human-friend
 
 COMMENT ON COLUMN groups.source IS 'Source indicates how the group was created. It can be created by a user manually, or through some system process like OIDC group sync.';
 
+CREATE TABLE inbox_notifications (
+    id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    title text NOT NULL,
+    content text NOT NULL,
+    actions jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    read_at timestamp with time zone
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Notifications delivered by the inbox method, which users can read in the dashboard and CLI.';
+
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message which was delivered to the inbox.';
+
+COMMENT ON COLUMN inbox_notifications.read_at IS 'When the user marked the notification as read; NULL if unread.';
+
 CREATE TABLE jfrog_xray_scans (
     agent_id uuid NOT NULL,
     workspace_id uuid NOT NULL,
@@ -564,20 +630,51 @@ CREATE TABLE notification_messages (
     updated_at timestamp with time zone,
     leased_until timestamp with time zone,
     next_retry_after timestamp with time zone,
-    queued_seconds double precision
+    queued_seconds double precision,
+    dedupe_hash text
+);
+
+COMMENT ON COLUMN notification_messages.dedupe_hash IS 'Hash of the message contents; prevents identical messages from being enqueued for the same user within the deduplication window since the last one was enqueued.';
+
+CREATE TABLE notification_preferences (
+    user_id uuid NOT NULL,
+    notification_template_id uuid NOT NULL,
+    disabled boolean DEFAULT false NOT NULL,
+    method notification_method,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
 );
 
+COMMENT ON TABLE notification_preferences IS 'Per-user preferences for how (and whether) notifications of a given template are delivered.';
+
+COMMENT ON COLUMN notification_preferences.method IS 'The method by which to deliver notifications of this template to the user. If NULL, the deployment-wide method is used.';
+
 CREATE TABLE notification_templates (
     id uuid NOT NULL,
     name text NOT NULL,
     title_template text NOT NULL,
     body_template text NOT NULL,
     actions jsonb,
-    "group" text
+    "group" text,
+    mandatory boolean DEFAULT false NOT NULL,
+    digest boolean DEFAULT false NOT NULL,
+    title_template_override text,
+    body_template_override text,
+    actions_override jsonb
 );
 
 COMMENT ON TABLE notification_templates IS 'Templates from which to create notification messages.';
 
+COMMENT ON COLUMN notification_templates.mandatory IS 'Mandatory notification templates cannot be disabled by users.';
+
+COMMENT ON COLUMN notification_templates.digest IS 'Messages of digest templates are held back and delivered together as a single message per user, once per digest window.';
+
+COMMENT ON COLUMN notification_templates.title_template_override IS 'Title template set by an administrator, used instead of title_template when set.';
+
+COMMENT ON COLUMN notification_templates.body_template_override IS 'Body template set by an administrator, used instead of body_template when set.';
+
+COMMENT ON COLUMN notification_templates.actions_override IS 'Actions set by an administrator, used instead of actions when set.';
+
 CREATE TABLE oauth2_provider_app_codes (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -585,11 +682,18 @@ CREATE TABLE oauth2_provider_app_codes (
     secret_prefix bytea NOT NULL,
     hashed_secret bytea NOT NULL,
     user_id uuid NOT NULL,
-    app_id uuid NOT NULL
+    app_id uuid NOT NULL,
+    code_challenge text DEFAULT ''::text NOT NULL,
+    code_challenge_method text DEFAULT ''::text NOT NULL,
+    scopes text[] DEFAULT '{}'::text[] NOT NULL
 );
 
 COMMENT ON TABLE oauth2_provider_app_codes IS 'Codes are meant to be exchanged for access tokens.';
 
+COMMENT ON COLUMN oauth2_provider_app_codes.code_challenge IS 'PKCE code challenge the code verifier must match when the code is exchanged. Empty if the client did not use PKCE.';
+
+COMMENT ON COLUMN oauth2_provider_app_codes.scopes IS 'Permissions the user granted to the application. When empty, the application has full access.';
+
 CREATE TABLE oauth2_provider_app_secrets (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -608,8 +712,9 @@ CREATE TABLE oauth2_provider_app_tokens (
     expires_at timestamp with time zone NOT NULL,
     hash_prefix bytea NOT NULL,
     refresh_hash bytea NOT NULL,
-    app_secret_id uuid NOT NULL,
-    api_key_id text NOT NULL
+    app_secret_id uuid,
+    api_key_id text NOT NULL,
+    app_id uuid NOT NULL
 );
 
 COMMENT ON COLUMN oauth2_provider_app_tokens.refresh_hash IS 'Refresh tokens provide a way to refresh an access token (API key). An expired API key can be refreshed if this token is not yet expired, meaning this expiry can outlive an API key.';
@@ -620,11 +725,37 @@ CREATE TABLE oauth2_provider_apps (
     updated_at timestamp with time zone NOT NULL,
     name character varying(64) NOT NULL,
     icon character varying(256) NOT NULL,
-    callback_url text NOT NULL
+    callback_url text NOT NULL,
+    client_type oauth2_provider_app_client_type DEFAULT 'confidential'::oauth2_provider_app_client_type NOT NULL,
+    grant_types text[] DEFAULT '{authorization_code,refresh_token,urn:ietf:params:oauth:grant-type:device_code}'::text[] NOT NULL
 );
 
 COMMENT ON TABLE oauth2_provider_apps IS 'A table used to configure apps that can use Coder as an OAuth2 provider, the reverse of what we are calling external authentication.';
 
+COMMENT ON COLUMN oauth2_provider_apps.client_type IS 'Public clients, such as desktop and command line tools, cannot keep a client secret and must use PKCE instead.';
+
+COMMENT ON COLUMN oauth2_provider_apps.grant_types IS 'Grant types the application may use at the token endpoint. Dynamically registered clients are limited to the grant types they registered with.';
+
+CREATE TABLE oauth2_provider_device_codes (
+    id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    secret_prefix bytea NOT NULL,
+    hashed_secret bytea NOT NULL,
+    user_code text NOT NULL,
+    app_id uuid NOT NULL,
+    scopes text[] DEFAULT '{}'::text[] NOT NULL,
+    status oauth2_provider_device_code_status DEFAULT 'pending'::oauth2_provider_device_code_status NOT NULL,
+    user_id uuid,
+    last_polled_at timestamp with time zone
+);
+
+COMMENT ON TABLE oauth2_provider_device_codes IS 'Device codes are exchanged for access tokens by devices without a browser, once the user has approved the request on another device.';
+
+COMMENT ON COLUMN oauth2_provider_device_codes.user_code IS 'Short code the user enters to identify the device they are approving.';
+
+COMMENT ON COLUMN oauth2_provider_device_codes.user_id IS 'User that approved or denied the request.';
+
 CREATE TABLE organization_members (
     user_id uuid NOT NULL,
     organization_id uuid NOT NULL,
@@ -758,6 +889,38 @@ CREATE TABLE provisioner_keys (
     tags jsonb NOT NULL
 );
 
+CREATE TABLE quota_budget_alerts (
+    budget_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    period_start timestamp with time zone NOT NULL,
+    hard_limit boolean NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE quota_budget_alerts IS 'Records users who have been notified that a budget limit was reached, so they are only notified once per period.';
+
+CREATE TABLE quota_budgets (
+    id uuid NOT NULL,
+    organization_id uuid NOT NULL,
+    group_id uuid,
+    user_id uuid,
+    period quota_budget_period NOT NULL,
+    soft_limit bigint DEFAULT 0 NOT NULL,
+    hard_limit bigint DEFAULT 0 NOT NULL,
+    stop_workspaces boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL,
+    CONSTRAINT quota_budgets_group_or_user CHECK (((group_id IS NULL) <> (user_id IS NULL)))
+);
+
+COMMENT ON TABLE quota_budgets IS 'Limits on the cost accrued by running workspaces within a day or month, for a single user or for all members of a group combined.';
+
+COMMENT ON COLUMN quota_budgets.soft_limit IS 'Accrued cost at which the affected users are notified. 0 disables the soft limit.';
+
+COMMENT ON COLUMN quota_budgets.hard_limit IS 'Accrued cost at which workspace starts are rejected. 0 disables the hard limit.';
+
+COMMENT ON COLUMN quota_budgets.stop_workspaces IS 'Whether running workspaces are stopped once the hard limit is reached.';
+
 CREATE TABLE replicas (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -821,6 +984,40 @@ CREATE TABLE tailnet_tunnels (
     updated_at timestamp with time zone NOT NULL
 );
 
+CREATE TABLE template_git_syncs (
+    template_id uuid NOT NULL,
+    repository_url text NOT NULL,
+    branch text NOT NULL,
+    directory text DEFAULT ''::text NOT NULL,
+    external_auth_provider_id text DEFAULT ''::text NOT NULL,
+    auto_activate boolean DEFAULT false NOT NULL,
+    webhook_secret text NOT NULL,
+    created_by uuid NOT NULL,
+    last_commit_sha text DEFAULT ''::text NOT NULL,
+    last_template_version_id uuid,
+    last_synced_at timestamp with time zone,
+    last_error text DEFAULT ''::text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_git_syncs IS 'Git repositories that templates are synced from. New commits create template versions.';
+
+COMMENT ON COLUMN template_git_syncs.external_auth_provider_id IS 'External auth provider whose token, linked to created_by, is used to fetch the repository. Empty for public repositories.';
+
+COMMENT ON COLUMN template_git_syncs.created_by IS 'User that template versions are created as.';
+
+CREATE TABLE template_release_channels (
+    id uuid NOT NULL,
+    template_id uuid NOT NULL,
+    name text NOT NULL,
+    template_version_id uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE template_release_channels IS 'Named pointers to template versions, such as canary and stable, that workspaces can follow instead of the template active version.';
+
 CREATE TABLE template_usage_stats (
     start_time timestamp with time zone NOT NULL,
     end_time timestamp with time zone NOT NULL,
@@ -1045,7 +1242,9 @@ CREATE TABLE templates (
     require_active_version boolean DEFAULT false NOT NULL,
     deprecated text DEFAULT ''::text NOT NULL,
     activity_bump bigint DEFAULT '3600000000000'::bigint NOT NULL,
-    max_port_sharing_level app_sharing_level DEFAULT 'owner'::app_sharing_level NOT NULL
+    max_port_sharing_level app_sharing_level DEFAULT 'owner'::app_sharing_level NOT NULL,
+    idle_timeout bigint DEFAULT 0 NOT NULL,
+    idle_cpu_threshold integer DEFAULT 0 NOT NULL
 );
 
 COMMENT ON COLUMN templates.default_ttl IS 'The default duration for autostop for workspaces created from this template.';
@@ -1066,6 +1265,10 @@ COMMENT ON COLUMN templates.autostart_block_days_of_week IS 'A bitmap of days of
 
 COMMENT ON COLUMN templates.deprecated IS 'If set to a non empty string, the template will no longer be able to be used. The message will be displayed to the user.';
 
+COMMENT ON COLUMN templates.idle_timeout IS 'The duration after which a running workspace with no sessions and CPU usage below idle_cpu_threshold is stopped. 0 disables idle stop.';
+
+COMMENT ON COLUMN templates.idle_cpu_threshold IS 'The percentage of CPU usage at or above which a workspace is considered active. 0 ignores CPU usage.';
+
 CREATE VIEW template_with_names AS
  SELECT templates.id,
     templates.created_at,
@@ -1095,6 +1298,8 @@ CREATE VIEW template_with_names AS
     templates.deprecated,
     templates.activity_bump,
     templates.max_port_sharing_level,
+    templates.idle_timeout,
+    templates.idle_cpu_threshold,
     COALESCE(visible_users.avatar_url, ''::text) AS created_by_avatar_url,
     COALESCE(visible_users.username, ''::text) AS created_by_username,
     COALESCE(organizations.name, ''::text) AS organization_name,
@@ -1161,9 +1366,15 @@ CREATE TABLE workspace_agent_port_share (
     agent_name text NOT NULL,
     port integer NOT NULL,
     share_level app_sharing_level NOT NULL,
-    protocol port_share_protocol DEFAULT 'http'::port_share_protocol NOT NULL
+    protocol port_share_protocol DEFAULT 'http'::port_share_protocol NOT NULL,
+    allowed_user_ids uuid[] DEFAULT '{}'::uuid[] NOT NULL,
+    allowed_group_ids uuid[] DEFAULT '{}'::uuid[] NOT NULL
 );
 
+COMMENT ON COLUMN workspace_agent_port_share.allowed_user_ids IS 'The users that can access the port when share_level is allowlist.';
+
+COMMENT ON COLUMN workspace_agent_port_share.allowed_group_ids IS 'The groups that can access the port when share_level is allowlist.';
+
 CREATE TABLE workspace_agent_scripts (
     workspace_agent_id uuid NOT NULL,
     log_source_id uuid NOT NULL,
@@ -1329,6 +1540,16 @@ CREATE TABLE workspace_apps (
 
 COMMENT ON COLUMN workspace_apps.display_order IS 'Specifies the order in which to display agent app in user interfaces.';
 
+CREATE TABLE workspace_build_autostop_warnings (
+    workspace_build_id uuid NOT NULL,
+    deadline timestamp with time zone NOT NULL,
+    warned_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_build_autostop_warnings IS 'Records that the owner of a workspace build was warned of its approaching autostop.';
+
+COMMENT ON COLUMN workspace_build_autostop_warnings.deadline IS 'The deadline the owner was warned of. If the deadline of the build changes, the owner is warned again.';
+
 CREATE TABLE workspace_build_parameters (
     workspace_build_id uuid NOT NULL,
     name text NOT NULL,
@@ -1447,6 +1668,26 @@ CREATE TABLE workspace_resources (
     daily_cost integer DEFAULT 0 NOT NULL
 );
 
+CREATE TABLE workspace_snapshots (
+    id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    workspace_build_id uuid NOT NULL,
+    build_number integer NOT NULL,
+    template_version_id uuid NOT NULL,
+    name text NOT NULL,
+    transition workspace_transition NOT NULL,
+    provisioner_state bytea,
+    rich_parameters jsonb DEFAULT '[]'::jsonb NOT NULL,
+    created_by uuid NOT NULL,
+    created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_snapshots IS 'Point in time copies of a workspace build, which can be restored to create a new build with the same state.';
+
+COMMENT ON COLUMN workspace_snapshots.provisioner_state IS 'Terraform state of the build, which includes the identity of persistent volumes.';
+
+COMMENT ON COLUMN workspace_snapshots.rich_parameters IS 'Rich parameter values of the build, as a list of name and value objects.';
+
 CREATE TABLE workspaces (
     id uuid NOT NULL,
     created_at timestamp with time zone NOT NULL,
@@ -1462,11 +1703,19 @@ CREATE TABLE workspaces (
     dormant_at timestamp with time zone,
     deleting_at timestamp with time zone,
     automatic_updates automatic_updates DEFAULT 'never'::automatic_updates NOT NULL,
-    favorite boolean DEFAULT false NOT NULL
+    favorite boolean DEFAULT false NOT NULL,
+    user_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    group_acl jsonb DEFAULT '{}'::jsonb NOT NULL,
+    last_active_at timestamp with time zone DEFAULT now() NOT NULL,
+    release_channel text DEFAULT ''::text NOT NULL
 );
 
 COMMENT ON COLUMN workspaces.favorite IS 'Favorite is true if the workspace owner has favorited the workspace.';
 
+COMMENT ON COLUMN workspaces.last_active_at IS 'The last time the workspace had an open session or CPU usage at or above the template idle_cpu_threshold.';
+
+COMMENT ON COLUMN workspaces.release_channel IS 'Name of the template release channel the workspace follows. Workspaces that follow no channel, or a channel that does not exist, use the template active version.';
+
 ALTER TABLE ONLY licenses ALTER COLUMN id SET DEFAULT nextval('licenses_id_seq'::regclass);
 
 ALTER TABLE ONLY provisioner_job_logs ALTER COLUMN id SET DEFAULT nextval('provisioner_job_logs_id_seq'::regclass);
@@ -1485,6 +1734,9 @@ ALTER TABLE ONLY workspace_agent_stats
 ALTER TABLE ONLY api_keys
     ADD CONSTRAINT api_keys_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY audit_log_chain_heads
+    ADD CONSTRAINT audit_log_chain_heads_pkey PRIMARY KEY (organization_id);
+
 ALTER TABLE ONLY audit_logs
     ADD CONSTRAINT audit_logs_pkey PRIMARY KEY (id);
 
@@ -1521,6 +1773,9 @@ ALTER TABLE ONLY groups
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_pkey PRIMARY KEY (agent_id, workspace_id);
 
@@ -1533,6 +1788,9 @@ ALTER TABLE ONLY licenses
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id, notification_template_id);
+
 ALTER TABLE ONLY notification_templates
     ADD CONSTRAINT notification_templates_name_key UNIQUE (name);
 
@@ -1563,6 +1821,15 @@ ALTER TABLE ONLY oauth2_provider_apps
 ALTER TABLE ONLY oauth2_provider_apps
     ADD CONSTRAINT oauth2_provider_apps_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY oauth2_provider_device_codes
+    ADD CONSTRAINT oauth2_provider_device_codes_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY oauth2_provider_device_codes
+    ADD CONSTRAINT oauth2_provider_device_codes_secret_prefix_key UNIQUE (secret_prefix);
+
+ALTER TABLE ONLY oauth2_provider_device_codes
+    ADD CONSTRAINT oauth2_provider_device_codes_user_code_key UNIQUE (user_code);
+
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id);
 
@@ -1596,6 +1863,12 @@ ALTER TABLE ONLY provisioner_jobs
 ALTER TABLE ONLY provisioner_keys
     ADD CONSTRAINT provisioner_keys_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY quota_budget_alerts
+    ADD CONSTRAINT quota_budget_alerts_pkey PRIMARY KEY (budget_id, user_id, period_start, hard_limit);
+
+ALTER TABLE ONLY quota_budgets
+    ADD CONSTRAINT quota_budgets_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY site_configs
     ADD CONSTRAINT site_configs_key_key UNIQUE (key);
 
@@ -1617,6 +1890,12 @@ ALTER TABLE ONLY tailnet_peers
 ALTER TABLE ONLY tailnet_tunnels
     ADD CONSTRAINT tailnet_tunnels_pkey PRIMARY KEY (coordinator_id, src_id, dst_id);
 
+ALTER TABLE ONLY template_git_syncs
+    ADD CONSTRAINT template_git_syncs_pkey PRIMARY KEY (template_id);
+
+ALTER TABLE ONLY template_release_channels
+    ADD CONSTRAINT template_release_channels_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY template_usage_stats
     ADD CONSTRAINT template_usage_stats_pkey PRIMARY KEY (start_time, template_id, user_id);
 
@@ -1671,6 +1950,9 @@ ALTER TABLE ONLY workspace_apps
 ALTER TABLE ONLY workspace_apps
     ADD CONSTRAINT workspace_apps_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY workspace_build_autostop_warnings
+    ADD CONSTRAINT workspace_build_autostop_warnings_pkey PRIMARY KEY (workspace_build_id);
+
 ALTER TABLE ONLY workspace_build_parameters
     ADD CONSTRAINT workspace_build_parameters_workspace_build_id_name_key UNIQUE (workspace_build_id, name);
 
@@ -1698,6 +1980,9 @@ ALTER TABLE ONLY workspace_resource_metadata
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_pkey PRIMARY KEY (id);
 
@@ -1715,14 +2000,20 @@ CREATE INDEX idx_audit_log_resource_id ON audit_logs USING btree (resource_id);
 
 CREATE INDEX idx_audit_log_user_id ON audit_logs USING btree (user_id);
 
+CREATE UNIQUE INDEX idx_audit_logs_organization_id_chain_index ON audit_logs USING btree (organization_id, chain_index) WHERE (chain_index IS NOT NULL);
+
 CREATE INDEX idx_audit_logs_time_desc ON audit_logs USING btree ("time" DESC);
 
 CREATE INDEX idx_custom_roles_id ON custom_roles USING btree (id);
 
 CREATE UNIQUE INDEX idx_custom_roles_name_lower ON custom_roles USING btree (lower(name));
 
+CREATE INDEX idx_inbox_notifications_user_id_created_at ON inbox_notifications USING btree (user_id, created_at DESC);
+
 CREATE INDEX idx_notification_messages_status ON notification_messages USING btree (status);
 
+CREATE INDEX idx_notification_messages_user_id_updated_at ON notification_messages USING btree (user_id, updated_at) WHERE (status = ANY (ARRAY['sent'::notification_message_status, 'leased'::notification_message_status]));
+
 CREATE INDEX idx_organization_member_organization_id_uuid ON organization_members USING btree (organization_id);
 
 CREATE INDEX idx_organization_member_user_id_uuid ON organization_members USING btree (user_id);
@@ -1735,6 +2026,10 @@ CREATE UNIQUE INDEX idx_provisioner_daemons_name_owner_key ON provisioner_daemon
 
 COMMENT ON INDEX idx_provisioner_daemons_name_owner_key IS 'Allow unique provisioner daemon names by user';
 
+CREATE UNIQUE INDEX idx_quota_budgets_group_id_period ON quota_budgets USING btree (group_id, period) WHERE (group_id IS NOT NULL);
+
+CREATE UNIQUE INDEX idx_quota_budgets_organization_id_user_id_period ON quota_budgets USING btree (organization_id, user_id, period) WHERE (user_id IS NOT NULL);
+
 CREATE INDEX idx_tailnet_agents_coordinator ON tailnet_agents USING btree (coordinator_id);
 
 CREATE INDEX idx_tailnet_clients_coordinator ON tailnet_clients USING btree (coordinator_id);
@@ -1745,10 +2040,16 @@ CREATE INDEX idx_tailnet_tunnels_dst_id ON tailnet_tunnels USING hash (dst_id);
 
 CREATE INDEX idx_tailnet_tunnels_src_id ON tailnet_tunnels USING hash (src_id);
 
+CREATE UNIQUE INDEX idx_template_release_channels_template_id_name ON template_release_channels USING btree (template_id, lower(name));
+
 CREATE UNIQUE INDEX idx_users_email ON users USING btree (email) WHERE (deleted = false);
 
 CREATE UNIQUE INDEX idx_users_username ON users USING btree (username) WHERE (deleted = false);
 
+CREATE UNIQUE INDEX idx_workspace_snapshots_workspace_id_name ON workspace_snapshots USING btree (workspace_id, lower(name));
+
+CREATE UNIQUE INDEX notification_messages_dedupe_hash_idx ON notification_messages USING btree (dedupe_hash);
+
 CREATE UNIQUE INDEX organizations_single_default_org ON organizations USING btree (is_default) WHERE (is_default = true);
 
 CREATE INDEX provisioner_job_logs_id_job_id_idx ON provisioner_job_logs USING btree (job_id, id);
@@ -1836,6 +2137,9 @@ ALTER TABLE ONLY group_members
 ALTER TABLE ONLY groups
     ADD CONSTRAINT groups_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY inbox_notifications
+    ADD CONSTRAINT inbox_notifications_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY jfrog_xray_scans
     ADD CONSTRAINT jfrog_xray_scans_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
@@ -1848,6 +2152,12 @@ ALTER TABLE ONLY notification_messages
 ALTER TABLE ONLY notification_messages
     ADD CONSTRAINT notification_messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_notification_template_id_fkey FOREIGN KEY (notification_template_id) REFERENCES notification_templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY notification_preferences
+    ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_codes
     ADD CONSTRAINT oauth2_provider_app_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
 
@@ -1860,9 +2170,18 @@ ALTER TABLE ONLY oauth2_provider_app_secrets
 ALTER TABLE ONLY oauth2_provider_app_tokens
     ADD CONSTRAINT oauth2_provider_app_tokens_api_key_id_fkey FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY oauth2_provider_app_tokens
+    ADD CONSTRAINT oauth2_provider_app_tokens_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY oauth2_provider_app_tokens
     ADD CONSTRAINT oauth2_provider_app_tokens_app_secret_id_fkey FOREIGN KEY (app_secret_id) REFERENCES oauth2_provider_app_secrets(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY oauth2_provider_device_codes
+    ADD CONSTRAINT oauth2_provider_device_codes_app_id_fkey FOREIGN KEY (app_id) REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY oauth2_provider_device_codes
+    ADD CONSTRAINT oauth2_provider_device_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY organization_members
     ADD CONSTRAINT organization_members_organization_id_uuid_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
@@ -1884,6 +2203,21 @@ ALTER TABLE ONLY provisioner_jobs
 ALTER TABLE ONLY provisioner_keys
     ADD CONSTRAINT provisioner_keys_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY quota_budget_alerts
+    ADD CONSTRAINT quota_budget_alerts_budget_id_fkey FOREIGN KEY (budget_id) REFERENCES quota_budgets(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY quota_budget_alerts
+    ADD CONSTRAINT quota_budget_alerts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY quota_budgets
+    ADD CONSTRAINT quota_budgets_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY quota_budgets
+    ADD CONSTRAINT quota_budgets_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY quota_budgets
+    ADD CONSTRAINT quota_budgets_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY tailnet_agents
     ADD CONSTRAINT tailnet_agents_coordinator_id_fkey FOREIGN KEY (coordinator_id) REFERENCES tailnet_coordinators(id) ON DELETE CASCADE;
 
@@ -1899,6 +2233,21 @@ ALTER TABLE ONLY tailnet_peers
 ALTER TABLE ONLY tailnet_tunnels
     ADD CONSTRAINT tailnet_tunnels_coordinator_id_fkey FOREIGN KEY (coordinator_id) REFERENCES tailnet_coordinators(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY template_git_syncs
+    ADD CONSTRAINT template_git_syncs_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_git_syncs
+    ADD CONSTRAINT template_git_syncs_last_template_version_id_fkey FOREIGN KEY (last_template_version_id) REFERENCES template_versions(id) ON DELETE SET NULL;
+
+ALTER TABLE ONLY template_git_syncs
+    ADD CONSTRAINT template_git_syncs_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_release_channels
+    ADD CONSTRAINT template_release_channels_template_id_fkey FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY template_release_channels
+    ADD CONSTRAINT template_release_channels_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY template_version_parameters
     ADD CONSTRAINT template_version_parameters_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
 
@@ -1962,6 +2311,9 @@ ALTER TABLE ONLY workspace_app_stats
 ALTER TABLE ONLY workspace_apps
     ADD CONSTRAINT workspace_apps_agent_id_fkey FOREIGN KEY (agent_id) REFERENCES workspace_agents(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_build_autostop_warnings
+    ADD CONSTRAINT workspace_build_autostop_warnings_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspace_build_parameters
     ADD CONSTRAINT workspace_build_parameters_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
 
@@ -1980,6 +2332,18 @@ ALTER TABLE ONLY workspace_resource_metadata
 ALTER TABLE ONLY workspace_resources
     ADD CONSTRAINT workspace_resources_job_id_fkey FOREIGN KEY (job_id) REFERENCES provisioner_jobs(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_template_version_id_fkey FOREIGN KEY (template_version_id) REFERENCES template_versions(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_workspace_build_id_fkey FOREIGN KEY (workspace_build_id) REFERENCES workspace_builds(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY workspace_snapshots
+    ADD CONSTRAINT workspace_snapshots_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY workspaces
     ADD CONSTRAINT workspaces_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE RESTRICT;
 
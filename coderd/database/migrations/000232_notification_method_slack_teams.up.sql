@@ -0,0 +1,2 @@
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'slack';
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'teams';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS notification_preferences;
+
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS mandatory;
@@ -0,0 +1,22 @@
+-- It's not possible to drop enum values from enum types, so the up migration has "IF NOT EXISTS".
+-- This has to be outside a transaction
+ALTER TYPE resource_type ADD VALUE IF NOT EXISTS 'notification_template';
+
+ALTER TABLE notification_templates
+    ADD COLUMN mandatory boolean NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN notification_templates.mandatory IS 'Mandatory notification templates cannot be disabled by users.';
+
+CREATE TABLE notification_preferences
+(
+    user_id                  uuid                     NOT NULL REFERENCES users ON DELETE CASCADE,
+    notification_template_id uuid                     NOT NULL REFERENCES notification_templates ON DELETE CASCADE,
+    disabled                 bool                     NOT NULL DEFAULT FALSE,
+    method                   notification_method,
+    created_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at               TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, notification_template_id)
+);
+
+COMMENT ON TABLE notification_preferences IS 'Per-user preferences for how (and whether) notifications of a given template are delivered.';
+COMMENT ON COLUMN notification_preferences.method IS 'The method by which to deliver notifications of this template to the user. If NULL, the deployment-wide method is used.';
@@ -0,0 +1,18 @@
+ALTER TYPE notification_method ADD VALUE IF NOT EXISTS 'inbox';
+
+CREATE TABLE inbox_notifications
+(
+    id         uuid                     NOT NULL PRIMARY KEY,
+    user_id    uuid                     NOT NULL REFERENCES users ON DELETE CASCADE,
+    title      text                     NOT NULL,
+    content    text                     NOT NULL,
+    actions    jsonb                    NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    read_at    TIMESTAMP WITH TIME ZONE
+);
+
+COMMENT ON TABLE inbox_notifications IS 'Notifications delivered by the inbox method, which users can read in the dashboard and CLI.';
+COMMENT ON COLUMN inbox_notifications.id IS 'The ID of the notification message which was delivered to the inbox.';
+COMMENT ON COLUMN inbox_notifications.read_at IS 'When the user marked the notification as read; NULL if unread.';
+
+CREATE INDEX idx_inbox_notifications_user_id_created_at ON inbox_notifications (user_id, created_at DESC);
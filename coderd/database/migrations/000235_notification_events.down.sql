@@ -0,0 +1,8 @@
+DELETE FROM notification_templates
+WHERE
+    id IN (
+        '06b2d212-34fa-48d5-9d2f-ebe3f66ebb5b',
+        '0adc9c2e-cee1-4e5a-b8e9-b57b60803ef4',
+        '25b1c063-adc4-4ee2-b607-9f191fe4ea0f',
+        'c7206c8a-1644-4092-b90b-acc6bd198950'
+    );
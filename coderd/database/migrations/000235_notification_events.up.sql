@@ -0,0 +1,56 @@
+INSERT INTO
+    notification_templates (
+        id,
+        name,
+        title_template,
+        body_template,
+        "group",
+        actions
+    )
+VALUES (
+        '06b2d212-34fa-48d5-9d2f-ebe3f66ebb5b',
+        'Template Version Pushed',
+        E'New version of template "{{.Labels.template_name}}" available',
+        E'Hi {{.UserName}}\n\n' || E'**{{.Labels.initiator}}** has pushed a new active version of the template **{{.Labels.template_name}}**: **{{.Labels.template_version_name}}**.\n' || E'Your workspaces using this template will be updated to the new version the next time you update them.',
+        'Template Events',
+        '[
+        {
+			"label": "View workspaces",
+			"url": "{{ base_url }}/workspaces?filter=owner:me+template:{{.Labels.template_name}}"
+		}
+    ]'::jsonb
+    ),
+    (
+        '0adc9c2e-cee1-4e5a-b8e9-b57b60803ef4',
+        'Workspace Manual Build Failed',
+        E'Workspace "{{.Labels.name}}" manual build failed',
+        E'Hi {{.UserName}}\n\n' || E'A manual build of the workspace **{{.Labels.name}}** owned by **{{.Labels.workspace_owner_username}}** failed.\n' || E'The workspace uses the template **{{.Labels.template_name}}** (version **{{.Labels.template_version_name}}**), and the build was initiated by **{{.Labels.initiator}}**.',
+        'Workspace Events',
+        '[
+        {
+			"label": "View build",
+			"url": "{{ base_url }}/@{{.Labels.workspace_owner_username}}/{{.Labels.name}}/builds/{{.Labels.workspace_build_number}}"
+		}
+    ]'::jsonb
+    ),
+    (
+        '25b1c063-adc4-4ee2-b607-9f191fe4ea0f',
+        'Workspace Autostop Approaching',
+        E'Workspace "{{.Labels.name}}" will stop soon',
+        E'Hi {{.UserName}}\n\n' || E'Your workspace **{{.Labels.name}}** will be stopped automatically in {{.Labels.time_remaining}} (at {{.Labels.deadline}}).\n' || E'To keep it running, extend its deadline from the dashboard or with `coder schedule override-stop`.',
+        'Workspace Events',
+        '[
+        {
+			"label": "View workspace",
+			"url": "{{ base_url }}/@{{.UserUsername}}/{{.Labels.name}}"
+		}
+    ]'::jsonb
+    ),
+    (
+        'c7206c8a-1644-4092-b90b-acc6bd198950',
+        'User Account Suspended',
+        E'Your account has been suspended',
+        E'Hi {{.UserName}}\n\n' || E'Your account **{{.UserUsername}}** has been suspended by **{{.Labels.initiator}}**.\n' || E'You will not be able to sign in or use your workspaces until your account is reactivated. Contact your administrator if you believe this is a mistake.',
+        'User Events',
+        '[]'::jsonb
+    );
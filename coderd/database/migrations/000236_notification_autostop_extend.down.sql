@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS workspace_build_autostop_warnings;
+
+UPDATE notification_templates
+SET
+    body_template = E'Hi {{.UserName}}\n\n' || E'Your workspace **{{.Labels.name}}** will be stopped automatically in {{.Labels.time_remaining}} (at {{.Labels.deadline}}).\n' || E'To keep it running, extend its deadline from the dashboard or with `coder schedule override-stop`.',
+    actions = '[
+        {
+            "label": "View workspace",
+            "url": "{{ base_url }}/@{{.UserUsername}}/{{.Labels.name}}"
+        }
+    ]'::jsonb
+WHERE
+    id = '25b1c063-adc4-4ee2-b607-9f191fe4ea0f';
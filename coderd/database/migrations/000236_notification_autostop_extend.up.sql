@@ -0,0 +1,24 @@
+UPDATE notification_templates
+SET
+    body_template = E'Hi {{.UserName}}\n\n' || E'Your workspace **{{.Labels.name}}** will be stopped automatically in {{.Labels.time_remaining}} (at {{.Labels.deadline}}).\n' || E'To keep it running, extend its deadline by {{.Labels.extension}} with the button below, or run `coder schedule override-stop {{.Labels.name}} <duration>`.',
+    actions = '[
+        {
+            "label": "Extend by {{.Labels.extension}}",
+            "url": "{{ base_url }}/api/v2/extend-workspace?token={{.Labels.extend_token}}"
+        },
+        {
+            "label": "View workspace",
+            "url": "{{ base_url }}/@{{.UserUsername}}/{{.Labels.name}}"
+        }
+    ]'::jsonb
+WHERE
+    id = '25b1c063-adc4-4ee2-b607-9f191fe4ea0f';
+
+CREATE TABLE workspace_build_autostop_warnings (
+	workspace_build_id uuid NOT NULL PRIMARY KEY REFERENCES workspace_builds(id) ON DELETE CASCADE,
+	deadline timestamp with time zone NOT NULL,
+	warned_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE workspace_build_autostop_warnings IS 'Records that the owner of a workspace build was warned of its approaching autostop.';
+COMMENT ON COLUMN workspace_build_autostop_warnings.deadline IS 'The deadline the owner was warned of. If the deadline of the build changes, the owner is warned again.';
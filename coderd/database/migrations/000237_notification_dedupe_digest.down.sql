@@ -0,0 +1,9 @@
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS digest;
+
+DROP INDEX IF EXISTS idx_notification_messages_user_id_updated_at;
+
+DROP INDEX IF EXISTS notification_messages_dedupe_hash_idx;
+
+ALTER TABLE notification_messages
+    DROP COLUMN IF EXISTS dedupe_hash;
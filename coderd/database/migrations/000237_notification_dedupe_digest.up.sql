@@ -0,0 +1,24 @@
+ALTER TABLE notification_messages
+    ADD COLUMN dedupe_hash TEXT NULL;
+
+COMMENT ON COLUMN notification_messages.dedupe_hash IS 'Hash of the message contents; prevents identical messages from being enqueued for the same user within the deduplication window since the last one was enqueued.';
+
+CREATE UNIQUE INDEX notification_messages_dedupe_hash_idx ON notification_messages (dedupe_hash);
+
+-- Speeds up counting the messages recently sent to a user, which is used to enforce the per-user rate limit.
+CREATE INDEX idx_notification_messages_user_id_updated_at ON notification_messages (user_id, updated_at)
+    WHERE status IN ('sent'::notification_message_status, 'leased'::notification_message_status);
+
+ALTER TABLE notification_templates
+    ADD COLUMN digest BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN notification_templates.digest IS 'Messages of digest templates are held back and delivered together as a single message per user, once per digest window.';
+
+-- Templates which can fan out to many messages per user at once.
+UPDATE notification_templates
+SET digest = TRUE
+WHERE id IN (
+    'c34a0c09-0704-4cac-bd1c-0c0146811c2b', -- Workspace updated automatically
+    '0ea69165-ec14-4314-91f1-69566ac3c5a0', -- Workspace Marked as Dormant
+    '51ce2fdf-c9ca-4be1-8d70-628674f9bc42'  -- Workspace Marked for Deletion
+);
@@ -0,0 +1,4 @@
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS actions_override,
+    DROP COLUMN IF EXISTS body_template_override,
+    DROP COLUMN IF EXISTS title_template_override;
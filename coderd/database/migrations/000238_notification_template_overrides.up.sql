@@ -0,0 +1,8 @@
+ALTER TABLE notification_templates
+    ADD COLUMN title_template_override TEXT NULL,
+    ADD COLUMN body_template_override TEXT NULL,
+    ADD COLUMN actions_override JSONB NULL;
+
+COMMENT ON COLUMN notification_templates.title_template_override IS 'Title template set by an administrator, used instead of title_template when set.';
+COMMENT ON COLUMN notification_templates.body_template_override IS 'Body template set by an administrator, used instead of body_template when set.';
+COMMENT ON COLUMN notification_templates.actions_override IS 'Actions set by an administrator, used instead of actions when set.';
@@ -0,0 +1,3 @@
+ALTER TABLE workspaces
+	DROP COLUMN IF EXISTS user_acl,
+	DROP COLUMN IF EXISTS group_acl;
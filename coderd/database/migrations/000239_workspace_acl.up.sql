@@ -0,0 +1,3 @@
+ALTER TABLE workspaces
+	ADD COLUMN user_acl jsonb NOT NULL DEFAULT '{}'::jsonb,
+	ADD COLUMN group_acl jsonb NOT NULL DEFAULT '{}'::jsonb;
@@ -0,0 +1,10 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS". Anything using the new values is narrowed to the closest remaining
+-- level instead.
+DELETE FROM workspace_agent_port_share WHERE share_level::text IN ('allowlist', 'organization');
+UPDATE templates SET max_port_sharing_level = 'owner' WHERE max_port_sharing_level::text IN ('allowlist', 'organization');
+UPDATE workspace_apps SET sharing_level = 'owner' WHERE sharing_level::text IN ('allowlist', 'organization');
+
+ALTER TABLE workspace_agent_port_share
+	DROP COLUMN allowed_user_ids,
+	DROP COLUMN allowed_group_ids;
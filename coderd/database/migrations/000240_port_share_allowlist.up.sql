@@ -0,0 +1,11 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS". The values are ordered from the narrowest to the widest level.
+ALTER TYPE app_sharing_level ADD VALUE IF NOT EXISTS 'allowlist' BEFORE 'authenticated';
+ALTER TYPE app_sharing_level ADD VALUE IF NOT EXISTS 'organization' BEFORE 'authenticated';
+
+ALTER TABLE workspace_agent_port_share
+	ADD COLUMN allowed_user_ids uuid[] NOT NULL DEFAULT '{}',
+	ADD COLUMN allowed_group_ids uuid[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN workspace_agent_port_share.allowed_user_ids IS 'The users that can access the port when share_level is allowlist.';
+COMMENT ON COLUMN workspace_agent_port_share.allowed_group_ids IS 'The groups that can access the port when share_level is allowlist.';
@@ -0,0 +1,3 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS". Ports shared with the new protocols are unshared instead.
+DELETE FROM workspace_agent_port_share WHERE protocol::text IN ('h2c', 'tcp');
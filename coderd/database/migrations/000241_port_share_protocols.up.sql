@@ -0,0 +1,4 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT
+-- EXISTS".
+ALTER TYPE port_share_protocol ADD VALUE IF NOT EXISTS 'h2c';
+ALTER TYPE port_share_protocol ADD VALUE IF NOT EXISTS 'tcp';
@@ -0,0 +1,13 @@
+DELETE FROM notification_templates
+WHERE
+    id IN (
+        '856980b8-7522-457c-b8b2-c6d8234f2428',
+        'd44a0e8a-cd25-4523-aad6-efa9688e2430'
+    );
+
+DROP TABLE IF EXISTS quota_budget_alerts;
+DROP TABLE IF EXISTS quota_budgets;
+DROP TYPE IF EXISTS quota_budget_period;
+
+UPDATE workspace_builds SET reason = 'autostop' WHERE reason = 'quota';
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT EXISTS"
@@ -0,0 +1,76 @@
+CREATE TYPE quota_budget_period AS ENUM ('day', 'month');
+
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT EXISTS"
+ALTER TYPE build_reason ADD VALUE IF NOT EXISTS 'quota';
+ALTER TYPE resource_type ADD VALUE IF NOT EXISTS 'quota_budget';
+
+CREATE TABLE quota_budgets
+(
+    id              uuid                     NOT NULL PRIMARY KEY,
+    organization_id uuid                     NOT NULL REFERENCES organizations ON DELETE CASCADE,
+    group_id        uuid                     REFERENCES groups ON DELETE CASCADE,
+    user_id         uuid                     REFERENCES users ON DELETE CASCADE,
+    period          quota_budget_period      NOT NULL,
+    soft_limit      bigint                   NOT NULL DEFAULT 0,
+    hard_limit      bigint                   NOT NULL DEFAULT 0,
+    stop_workspaces boolean                  NOT NULL DEFAULT false,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT quota_budgets_group_or_user CHECK ((group_id IS NULL) <> (user_id IS NULL))
+);
+
+COMMENT ON TABLE quota_budgets IS 'Limits on the cost accrued by running workspaces within a day or month, for a single user or for all members of a group combined.';
+COMMENT ON COLUMN quota_budgets.soft_limit IS 'Accrued cost at which the affected users are notified. 0 disables the soft limit.';
+COMMENT ON COLUMN quota_budgets.hard_limit IS 'Accrued cost at which workspace starts are rejected. 0 disables the hard limit.';
+COMMENT ON COLUMN quota_budgets.stop_workspaces IS 'Whether running workspaces are stopped once the hard limit is reached.';
+
+CREATE UNIQUE INDEX idx_quota_budgets_group_id_period ON quota_budgets (group_id, period) WHERE group_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_quota_budgets_organization_id_user_id_period ON quota_budgets (organization_id, user_id, period) WHERE user_id IS NOT NULL;
+
+CREATE TABLE quota_budget_alerts
+(
+    budget_id    uuid                     NOT NULL REFERENCES quota_budgets ON DELETE CASCADE,
+    user_id      uuid                     NOT NULL REFERENCES users ON DELETE CASCADE,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    hard_limit   boolean                  NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (budget_id, user_id, period_start, hard_limit)
+);
+
+COMMENT ON TABLE quota_budget_alerts IS 'Records users who have been notified that a budget limit was reached, so they are only notified once per period.';
+
+INSERT INTO
+    notification_templates (
+        id,
+        name,
+        title_template,
+        body_template,
+        "group",
+        actions
+    )
+VALUES (
+        '856980b8-7522-457c-b8b2-c6d8234f2428',
+        'Quota Budget Soft Limit Reached',
+        E'Workspace budget soft limit reached',
+        E'Hi {{.UserName}}\n\n' || E'Workspaces have accrued **{{.Labels.spend}}** credits against {{.Labels.budget}}, reaching its soft limit of **{{.Labels.limit}}** credits.\n' || E'{{if .Labels.hard_limit}}Workspaces will not be able to start once **{{.Labels.hard_limit}}** credits have been accrued.{{end}}',
+        'Workspace Events',
+        '[
+        {
+			"label": "View workspaces",
+			"url": "{{ base_url }}/workspaces?filter=owner:me"
+		}
+    ]'::jsonb
+    ),
+    (
+        'd44a0e8a-cd25-4523-aad6-efa9688e2430',
+        'Quota Budget Hard Limit Reached',
+        E'Workspace budget exceeded',
+        E'Hi {{.UserName}}\n\n' || E'Workspaces have accrued **{{.Labels.spend}}** credits against {{.Labels.budget}}, reaching its limit of **{{.Labels.limit}}** credits.\n' || E'Workspaces cannot be started until the budget resets at {{.Labels.period_end}}.{{if eq .Labels.stop_workspaces "true"}} Running workspaces are being stopped.{{end}}',
+        'Workspace Events',
+        '[
+        {
+			"label": "View workspaces",
+			"url": "{{ base_url }}/workspaces?filter=owner:me"
+		}
+    ]'::jsonb
+    );
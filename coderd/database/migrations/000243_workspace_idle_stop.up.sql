@@ -0,0 +1,39 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT EXISTS"
+ALTER TYPE build_reason ADD VALUE IF NOT EXISTS 'idle';
+
+ALTER TABLE templates
+	ADD COLUMN idle_timeout bigint NOT NULL DEFAULT 0,
+	ADD COLUMN idle_cpu_threshold integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN templates.idle_timeout IS 'The duration after which a running workspace with no sessions and CPU usage below idle_cpu_threshold is stopped. 0 disables idle stop.';
+COMMENT ON COLUMN templates.idle_cpu_threshold IS 'The percentage of CPU usage at or above which a workspace is considered active. 0 ignores CPU usage.';
+
+ALTER TABLE workspaces
+	ADD COLUMN last_active_at timestamp with time zone NOT NULL DEFAULT now();
+
+COMMENT ON COLUMN workspaces.last_active_at IS 'The last time the workspace had an open session or CPU usage at or above the template idle_cpu_threshold.';
+
+-- Update the template_with_names view by recreating it.
+DROP VIEW template_with_names;
+CREATE VIEW
+	template_with_names
+AS
+SELECT
+	templates.*,
+	coalesce(visible_users.avatar_url, '') AS created_by_avatar_url,
+	coalesce(visible_users.username, '') AS created_by_username,
+	coalesce(organizations.name, '') AS organization_name,
+	coalesce(organizations.display_name, '') AS organization_display_name,
+	coalesce(organizations.icon, '') AS organization_icon
+FROM
+	templates
+		LEFT JOIN
+	visible_users
+	ON
+		templates.created_by = visible_users.id
+		LEFT JOIN
+	organizations
+	ON templates.organization_id = organizations.id
+;
+
+COMMENT ON VIEW template_with_names IS 'Joins in the display name information such as username, avatar, and organization name.';
@@ -0,0 +1,20 @@
+CREATE TABLE workspace_snapshots
+(
+    id                  uuid                     NOT NULL PRIMARY KEY,
+    workspace_id        uuid                     NOT NULL REFERENCES workspaces ON DELETE CASCADE,
+    workspace_build_id  uuid                     NOT NULL REFERENCES workspace_builds ON DELETE CASCADE,
+    build_number        integer                  NOT NULL,
+    template_version_id uuid                     NOT NULL REFERENCES template_versions ON DELETE CASCADE,
+    name                text                     NOT NULL,
+    transition          workspace_transition     NOT NULL,
+    provisioner_state   bytea,
+    rich_parameters     jsonb                    NOT NULL DEFAULT '[]'::jsonb,
+    created_by          uuid                     NOT NULL REFERENCES users ON DELETE CASCADE,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMENT ON TABLE workspace_snapshots IS 'Point in time copies of a workspace build, which can be restored to create a new build with the same state.';
+COMMENT ON COLUMN workspace_snapshots.provisioner_state IS 'Terraform state of the build, which includes the identity of persistent volumes.';
+COMMENT ON COLUMN workspace_snapshots.rich_parameters IS 'Rich parameter values of the build, as a list of name and value objects.';
+
+CREATE UNIQUE INDEX idx_workspace_snapshots_workspace_id_name ON workspace_snapshots (workspace_id, lower(name));
@@ -0,0 +1,3 @@
+ALTER TABLE workspaces DROP COLUMN IF EXISTS release_channel;
+
+DROP TABLE IF EXISTS template_release_channels;
@@ -0,0 +1,20 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT EXISTS"
+ALTER TYPE resource_type ADD VALUE IF NOT EXISTS 'template_release_channel';
+
+CREATE TABLE template_release_channels
+(
+    id                  uuid                     NOT NULL PRIMARY KEY,
+    template_id         uuid                     NOT NULL REFERENCES templates ON DELETE CASCADE,
+    name                text                     NOT NULL,
+    template_version_id uuid                     NOT NULL REFERENCES template_versions ON DELETE CASCADE,
+    created_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at          TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMENT ON TABLE template_release_channels IS 'Named pointers to template versions, such as canary and stable, that workspaces can follow instead of the template active version.';
+
+CREATE UNIQUE INDEX idx_template_release_channels_template_id_name ON template_release_channels (template_id, lower(name));
+
+ALTER TABLE workspaces ADD COLUMN release_channel text NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN workspaces.release_channel IS 'Name of the template release channel the workspace follows. Workspaces that follow no channel, or a channel that does not exist, use the template active version.';
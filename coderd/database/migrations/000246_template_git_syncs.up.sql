@@ -0,0 +1,26 @@
+-- It's not possible to drop enum values from enum types, so the UP has "IF NOT EXISTS"
+ALTER TYPE resource_type ADD VALUE IF NOT EXISTS 'template_git_sync';
+
+CREATE TABLE template_git_syncs
+(
+    template_id               uuid                     NOT NULL PRIMARY KEY REFERENCES templates ON DELETE CASCADE,
+    repository_url            text                     NOT NULL,
+    branch                    text                     NOT NULL,
+    directory                 text                     NOT NULL DEFAULT '',
+    external_auth_provider_id text                     NOT NULL DEFAULT '',
+    auto_activate             boolean                  NOT NULL DEFAULT false,
+    webhook_secret            text                     NOT NULL,
+    created_by                uuid                     NOT NULL REFERENCES users ON DELETE CASCADE,
+    last_commit_sha           text                     NOT NULL DEFAULT '',
+    last_template_version_id  uuid                     REFERENCES template_versions ON DELETE SET NULL,
+    last_synced_at            TIMESTAMP WITH TIME ZONE,
+    last_error                text                     NOT NULL DEFAULT '',
+    created_at                TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at                TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+COMMENT ON TABLE template_git_syncs IS 'Git repositories that templates are synced from. New commits create template versions.';
+
+COMMENT ON COLUMN template_git_syncs.external_auth_provider_id IS 'External auth provider whose token, linked to created_by, is used to fetch the repository. Empty for public repositories.';
+
+COMMENT ON COLUMN template_git_syncs.created_by IS 'User that template versions are created as.';
@@ -0,0 +1,7 @@
+ALTER TABLE api_keys
+	ADD COLUMN scopes text[] DEFAULT '{}'::text[] NOT NULL,
+	ADD COLUMN allow_list uuid[] DEFAULT '{}'::uuid[] NOT NULL;
+
+COMMENT ON COLUMN api_keys.scopes IS 'Permissions of the form "resource:action" the key is restricted to. When empty, the key is restricted by scope alone.';
+
+COMMENT ON COLUMN api_keys.allow_list IS 'IDs of the resources the key is restricted to, in addition to its owner. When empty, the key may access any resource its scopes allow.';
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS oauth2_provider_device_codes;
+
+DROP TYPE IF EXISTS oauth2_provider_device_code_status;
+
+-- Tokens of public clients cannot be kept without a secret.
+DELETE FROM oauth2_provider_app_tokens WHERE app_secret_id IS NULL;
+
+ALTER TABLE oauth2_provider_app_tokens
+	ALTER COLUMN app_secret_id SET NOT NULL,
+	DROP COLUMN IF EXISTS app_id;
+
+ALTER TABLE oauth2_provider_app_codes
+	DROP COLUMN IF EXISTS code_challenge,
+	DROP COLUMN IF EXISTS code_challenge_method,
+	DROP COLUMN IF EXISTS scopes;
+
+ALTER TABLE oauth2_provider_apps
+	DROP COLUMN IF EXISTS grant_types,
+	DROP COLUMN IF EXISTS client_type;
+
+DROP TYPE IF EXISTS oauth2_provider_app_client_type;
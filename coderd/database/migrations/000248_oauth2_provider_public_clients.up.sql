@@ -0,0 +1,62 @@
+CREATE TYPE oauth2_provider_app_client_type AS ENUM (
+	'confidential',
+	'public'
+);
+
+ALTER TABLE oauth2_provider_apps
+	ADD COLUMN client_type oauth2_provider_app_client_type DEFAULT 'confidential'::oauth2_provider_app_client_type NOT NULL;
+
+COMMENT ON COLUMN oauth2_provider_apps.client_type IS 'Public clients, such as desktop and command line tools, cannot keep a client secret and must use PKCE instead.';
+
+ALTER TABLE oauth2_provider_apps
+	ADD COLUMN grant_types text[] DEFAULT '{authorization_code,refresh_token,urn:ietf:params:oauth:grant-type:device_code}'::text[] NOT NULL;
+
+COMMENT ON COLUMN oauth2_provider_apps.grant_types IS 'Grant types the application may use at the token endpoint. Dynamically registered clients are limited to the grant types they registered with.';
+
+ALTER TABLE oauth2_provider_app_codes
+	ADD COLUMN code_challenge text DEFAULT ''::text NOT NULL,
+	ADD COLUMN code_challenge_method text DEFAULT ''::text NOT NULL,
+	ADD COLUMN scopes text[] DEFAULT '{}'::text[] NOT NULL;
+
+COMMENT ON COLUMN oauth2_provider_app_codes.code_challenge IS 'PKCE code challenge the code verifier must match when the code is exchanged. Empty if the client did not use PKCE.';
+
+COMMENT ON COLUMN oauth2_provider_app_codes.scopes IS 'Permissions the user granted to the application. When empty, the application has full access.';
+
+-- Public clients have no secret, so tokens reference the app directly.
+ALTER TABLE oauth2_provider_app_tokens
+	ADD COLUMN app_id uuid REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE;
+
+UPDATE oauth2_provider_app_tokens
+SET app_id = oauth2_provider_app_secrets.app_id
+FROM oauth2_provider_app_secrets
+WHERE oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id;
+
+ALTER TABLE oauth2_provider_app_tokens
+	ALTER COLUMN app_id SET NOT NULL,
+	ALTER COLUMN app_secret_id DROP NOT NULL;
+
+CREATE TYPE oauth2_provider_device_code_status AS ENUM (
+	'pending',
+	'approved',
+	'denied'
+);
+
+CREATE TABLE oauth2_provider_device_codes (
+	id uuid NOT NULL PRIMARY KEY,
+	created_at timestamp with time zone NOT NULL,
+	expires_at timestamp with time zone NOT NULL,
+	secret_prefix bytea NOT NULL UNIQUE,
+	hashed_secret bytea NOT NULL,
+	user_code text NOT NULL UNIQUE,
+	app_id uuid NOT NULL REFERENCES oauth2_provider_apps(id) ON DELETE CASCADE,
+	scopes text[] DEFAULT '{}'::text[] NOT NULL,
+	status oauth2_provider_device_code_status DEFAULT 'pending'::oauth2_provider_device_code_status NOT NULL,
+	user_id uuid REFERENCES users(id) ON DELETE CASCADE,
+	last_polled_at timestamp with time zone
+);
+
+COMMENT ON TABLE oauth2_provider_device_codes IS 'Device codes are exchanged for access tokens by devices without a browser, once the user has approved the request on another device.';
+
+COMMENT ON COLUMN oauth2_provider_device_codes.user_code IS 'Short code the user enters to identify the device they are approving.';
+
+COMMENT ON COLUMN oauth2_provider_device_codes.user_id IS 'User that approved or denied the request.';
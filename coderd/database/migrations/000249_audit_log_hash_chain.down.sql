@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS audit_log_chain_heads;
+
+DROP INDEX IF EXISTS idx_audit_logs_organization_id_chain_index;
+
+ALTER TABLE audit_logs
+	DROP COLUMN IF EXISTS chain_index,
+	DROP COLUMN IF EXISTS hash;
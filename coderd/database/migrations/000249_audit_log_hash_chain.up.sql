@@ -0,0 +1,20 @@
+ALTER TABLE audit_logs
+	ADD COLUMN chain_index bigint,
+	ADD COLUMN hash bytea;
+
+COMMENT ON COLUMN audit_logs.chain_index IS 'Position of the audit log in the hash chain of its organization, starting at 1. Audit logs created before hash chains were introduced are not in a chain.';
+
+COMMENT ON COLUMN audit_logs.hash IS 'HMAC-SHA256 of the audit log and the hash of the previous audit log in the chain, keyed with a secret that is kept outside the database, so hashes cannot be recomputed from the database alone.';
+
+CREATE UNIQUE INDEX idx_audit_logs_organization_id_chain_index ON audit_logs USING btree (organization_id, chain_index) WHERE (chain_index IS NOT NULL);
+
+-- Organizations are not referenced, since audit logs outlive their
+-- organization, and site-wide audit logs have the nil UUID.
+CREATE TABLE audit_log_chain_heads (
+	organization_id uuid NOT NULL PRIMARY KEY,
+	chain_index bigint NOT NULL,
+	hash bytea NOT NULL,
+	updated_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON TABLE audit_log_chain_heads IS 'Last audit log in the hash chain of each organization, so audit logs deleted from the end of a chain are detected.';
@@ -0,0 +1,3 @@
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method)
+VALUES ('fc1511ef-4fcf-4a3b-98a1-8df64160e35a', 'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11', FALSE, 'webhook'::notification_method),
+       ('fc1511ef-4fcf-4a3b-98a1-8df64160e35a', 'b0eebc99-9c0b-4ef8-bb6d-6bb9bd380a12', TRUE, NULL);
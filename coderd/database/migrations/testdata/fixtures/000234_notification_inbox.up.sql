@@ -0,0 +1,6 @@
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, read_at)
+VALUES ('c4a1e3f6-1bd5-4b4c-8f5a-9b0f8d2b6e71', 'fc1511ef-4fcf-4a3b-98a1-8df64160e35a', 'Workspace "bob" deleted',
+        'Your workspace **bob** was deleted.', '[{"label": "View workspaces", "url": "https://coder.com/workspaces"}]',
+        NULL),
+       ('d5b2f4a7-2ce6-4c5d-9a6b-ac1f9e3c7f82', 'fc1511ef-4fcf-4a3b-98a1-8df64160e35a', 'Workspace "alice" deleted',
+        'Your workspace **alice** was deleted.', '[]', NOW());
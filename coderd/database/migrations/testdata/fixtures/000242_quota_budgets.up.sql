@@ -0,0 +1,9 @@
+INSERT INTO quota_budgets
+    (id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at)
+VALUES
+    ('5a4e0f6c-8a3e-4d6c-9f0a-3c2b1d7e9f10', 'bb640d07-ca8a-4869-b6bc-ae61ebb2fda1', NULL, '30095c71-380b-457a-8995-97b8ee6e5307', 'month', 800, 1000, true, '2024-08-01 00:00:00+00', '2024-08-01 00:00:00+00');
+
+INSERT INTO quota_budget_alerts
+    (budget_id, user_id, period_start, hard_limit, created_at)
+VALUES
+    ('5a4e0f6c-8a3e-4d6c-9f0a-3c2b1d7e9f10', '30095c71-380b-457a-8995-97b8ee6e5307', '2024-08-01 00:00:00+00', false, '2024-08-20 12:00:00+00');
@@ -0,0 +1,4 @@
+INSERT INTO workspace_snapshots
+    (id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at)
+VALUES
+    ('8f3a6c1e-2d4b-4e7a-9c5f-1b2d3e4f5a6b', '3a9a1feb-e89d-457c-9d53-ac751b198ebe', 'a8c0b8c5-c9a8-4f33-93a4-8142e6858244', 1, '920baba5-4c64-4686-8b7d-d1bef5683eae', 'before-upgrade', 'start', '\x7b7d', '[{"name": "region", "value": "eu"}]', '30095c71-380b-457a-8995-97b8ee6e5307', '2024-08-20 12:00:00+00');
@@ -0,0 +1,6 @@
+INSERT INTO template_release_channels
+    (id, template_id, name, template_version_id, created_at, updated_at)
+VALUES
+    ('2c7d9e4a-5b1f-4a3e-8d6c-7f0e1b2a3c4d', '4cc1f466-f326-477e-8762-9d0c6781fc56', 'canary', '920baba5-4c64-4686-8b7d-d1bef5683eae', '2024-08-20 12:00:00+00', '2024-08-20 12:00:00+00');
+
+UPDATE workspaces SET release_channel = 'canary' WHERE id = '3a9a1feb-e89d-457c-9d53-ac751b198ebe';
@@ -0,0 +1,4 @@
+INSERT INTO template_git_syncs
+    (template_id, repository_url, branch, directory, webhook_secret, created_by, last_commit_sha, last_template_version_id, last_synced_at, created_at, updated_at)
+VALUES
+    ('4cc1f466-f326-477e-8762-9d0c6781fc56', 'https://github.com/coder/templates.git', 'main', 'docker', 'e3b0c44298fc1c149afbf4c8996fb924', '30095c71-380b-457a-8995-97b8ee6e5307', 'a94a8fe5ccb19ba61c4c0873d391e987982fbbd3', '920baba5-4c64-4686-8b7d-d1bef5683eae', '2024-08-21 12:00:00+00', '2024-08-21 12:00:00+00', '2024-08-21 12:00:00+00');
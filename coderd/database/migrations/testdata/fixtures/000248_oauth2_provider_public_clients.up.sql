@@ -0,0 +1,14 @@
+INSERT INTO oauth2_provider_device_codes
+	(id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, scopes, status, user_id)
+VALUES (
+	'e0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11',
+	'2023-06-15 10:23:54+00',
+	'2023-06-15 10:38:54+00',
+	CAST('hijklmn' AS bytea),
+	CAST('abcdefg' AS bytea),
+	'BCDF-GHJK',
+	'a0eebc99-9c0b-4ef8-bb6d-6bb9bd380a11',
+	'{workspace:read}',
+	'approved',
+	'0ed9befc-4911-4ccf-a8e2-559bf72daa94'
+);
@@ -0,0 +1,8 @@
+INSERT INTO audit_log_chain_heads
+	(organization_id, chain_index, hash, updated_at)
+VALUES (
+	'bb640d07-ca8a-4869-b6bc-ae61ebb2fda1',
+	1,
+	CAST('abcdefg' AS bytea),
+	'2023-06-15 10:23:54+00'
+);
@@ -112,6 +112,23 @@ func (s APIKeyScope) ToRBAC() rbac.ScopeName {
 	}
 }
 
+// RBACScope returns the scope that requests authenticated with the key are
+// authorized with. Keys with scopes are restricted to them.
+func (k APIKey) RBACScope() rbac.ExpandableScope {
+	if len(k.Scopes) == 0 {
+		return rbac.ScopeName(k.Scope)
+	}
+	allowList := make([]string, 0, len(k.AllowList))
+	for _, id := range k.AllowList {
+		allowList = append(allowList, id.String())
+	}
+	return rbac.TokenScope{
+		OwnerID:     k.UserID.String(),
+		Permissions: k.Scopes,
+		AllowIDList: allowList,
+	}
+}
+
 func (k APIKey) RBACObject() rbac.Object {
 	return rbac.ResourceApiKey.WithIDString(k.ID).
 		WithOwner(k.UserID.String())
@@ -147,6 +164,26 @@ func (t Template) AutostartAllowedDays() uint8 {
 	return ^uint8(t.AutostartBlockDaysOfWeek) & 0b01111111
 }
 
+// Customized reports whether an administrator has overridden the notification template's title, body, or actions.
+func (t NotificationTemplate) Customized() bool {
+	return t.TitleTemplateOverride.Valid || t.BodyTemplateOverride.Valid || t.ActionsOverride != nil
+}
+
+// Effective returns the notification template with any overrides set by an administrator applied, i.e. as it is used
+// to render messages.
+func (t NotificationTemplate) Effective() NotificationTemplate {
+	if t.TitleTemplateOverride.Valid {
+		t.TitleTemplate = t.TitleTemplateOverride.String
+	}
+	if t.BodyTemplateOverride.Valid {
+		t.BodyTemplate = t.BodyTemplateOverride.String
+	}
+	if t.ActionsOverride != nil {
+		t.Actions = t.ActionsOverride
+	}
+	return t
+}
+
 func (TemplateVersion) RBACObject(template Template) rbac.Object {
 	// Just use the parent template resource for controlling versions
 	return template.RBACObject()
@@ -162,6 +199,13 @@ func (g Group) RBACObject() rbac.Object {
 		InOrg(g.OrganizationID)
 }
 
+// RBACObject returns the group resource of the budget's organization, since
+// budgets are managed by those who can manage groups.
+func (b QuotaBudget) RBACObject() rbac.Object {
+	return rbac.ResourceGroup.WithID(b.ID).
+		InOrg(b.OrganizationID)
+}
+
 func (w GetWorkspaceByAgentIDRow) RBACObject() rbac.Object {
 	return w.Workspace.RBACObject()
 }
@@ -174,7 +218,9 @@ func (w Workspace) RBACObject() rbac.Object {
 
 	return rbac.ResourceWorkspace.WithID(w.ID).
 		InOrg(w.OrganizationID).
-		WithOwner(w.OwnerID.String())
+		WithOwner(w.OwnerID.String()).
+		WithACLUserList(w.UserACL).
+		WithGroupACL(w.GroupACL)
 }
 
 func (w Workspace) DormantRBAC() rbac.Object {
@@ -264,6 +310,14 @@ func (c OAuth2ProviderAppCode) RBACObject() rbac.Object {
 	return rbac.ResourceOauth2AppCodeToken.WithOwner(c.UserID.String())
 }
 
+func (c OAuth2ProviderDeviceCode) RBACObject() rbac.Object {
+	// Pending device codes are not owned by any user until they are approved.
+	if !c.UserID.Valid {
+		return rbac.ResourceOauth2AppCodeToken
+	}
+	return rbac.ResourceOauth2AppCodeToken.WithOwner(c.UserID.UUID.String())
+}
+
 func (OAuth2ProviderAppSecret) RBACObject() rbac.Object {
 	return rbac.ResourceOauth2AppSecret
 }
@@ -374,6 +428,10 @@ func ConvertWorkspaceRows(rows []GetWorkspacesRow) []Workspace {
 			DeletingAt:        r.DeletingAt,
 			AutomaticUpdates:  r.AutomaticUpdates,
 			Favorite:          r.Favorite,
+			UserACL:           r.UserACL,
+			GroupACL:          r.GroupACL,
+			LastActiveAt:      r.LastActiveAt,
+			ReleaseChannel:    r.ReleaseChannel,
 		}
 	}
 
@@ -78,6 +78,8 @@ type AppSharingLevel string
 
 const (
 	AppSharingLevelOwner         AppSharingLevel = "owner"
+	AppSharingLevelAllowlist     AppSharingLevel = "allowlist"
+	AppSharingLevelOrganization  AppSharingLevel = "organization"
 	AppSharingLevelAuthenticated AppSharingLevel = "authenticated"
 	AppSharingLevelPublic        AppSharingLevel = "public"
 )
@@ -120,6 +122,8 @@ func (ns NullAppSharingLevel) Value() (driver.Value, error) {
 func (e AppSharingLevel) Valid() bool {
 	switch e {
 	case AppSharingLevelOwner,
+		AppSharingLevelAllowlist,
+		AppSharingLevelOrganization,
 		AppSharingLevelAuthenticated,
 		AppSharingLevelPublic:
 		return true
@@ -130,6 +134,8 @@ func (e AppSharingLevel) Valid() bool {
 func AllAppSharingLevelValues() []AppSharingLevel {
 	return []AppSharingLevel{
 		AppSharingLevelOwner,
+		AppSharingLevelAllowlist,
+		AppSharingLevelOrganization,
 		AppSharingLevelAuthenticated,
 		AppSharingLevelPublic,
 	}
@@ -278,6 +284,8 @@ const (
 	BuildReasonDormancy   BuildReason = "dormancy"
 	BuildReasonFailedstop BuildReason = "failedstop"
 	BuildReasonAutodelete BuildReason = "autodelete"
+	BuildReasonQuota      BuildReason = "quota"
+	BuildReasonIdle       BuildReason = "idle"
 )
 
 func (e *BuildReason) Scan(src interface{}) error {
@@ -322,7 +330,9 @@ func (e BuildReason) Valid() bool {
 		BuildReasonAutostop,
 		BuildReasonDormancy,
 		BuildReasonFailedstop,
-		BuildReasonAutodelete:
+		BuildReasonAutodelete,
+		BuildReasonQuota,
+		BuildReasonIdle:
 		return true
 	}
 	return false
@@ -336,6 +346,8 @@ func AllBuildReasonValues() []BuildReason {
 		BuildReasonDormancy,
 		BuildReasonFailedstop,
 		BuildReasonAutodelete,
+		BuildReasonQuota,
+		BuildReasonIdle,
 	}
 }
 
@@ -735,6 +747,9 @@ type NotificationMethod string
 const (
 	NotificationMethodSmtp    NotificationMethod = "smtp"
 	NotificationMethodWebhook NotificationMethod = "webhook"
+	NotificationMethodSlack   NotificationMethod = "slack"
+	NotificationMethodTeams   NotificationMethod = "teams"
+	NotificationMethodInbox   NotificationMethod = "inbox"
 )
 
 func (e *NotificationMethod) Scan(src interface{}) error {
@@ -775,7 +790,10 @@ func (ns NullNotificationMethod) Value() (driver.Value, error) {
 func (e NotificationMethod) Valid() bool {
 	switch e {
 	case NotificationMethodSmtp,
-		NotificationMethodWebhook:
+		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox:
 		return true
 	}
 	return false
@@ -785,6 +803,128 @@ func AllNotificationMethodValues() []NotificationMethod {
 	return []NotificationMethod{
 		NotificationMethodSmtp,
 		NotificationMethodWebhook,
+		NotificationMethodSlack,
+		NotificationMethodTeams,
+		NotificationMethodInbox,
+	}
+}
+
+type OAuth2ProviderAppClientType string
+
+const (
+	OAuth2ProviderAppClientTypeConfidential OAuth2ProviderAppClientType = "confidential"
+	OAuth2ProviderAppClientTypePublic       OAuth2ProviderAppClientType = "public"
+)
+
+func (e *OAuth2ProviderAppClientType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OAuth2ProviderAppClientType(s)
+	case string:
+		*e = OAuth2ProviderAppClientType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OAuth2ProviderAppClientType: %T", src)
+	}
+	return nil
+}
+
+type NullOAuth2ProviderAppClientType struct {
+	OAuth2ProviderAppClientType OAuth2ProviderAppClientType `json:"oauth2_provider_app_client_type"`
+	Valid                       bool                        `json:"valid"` // Valid is true if OAuth2ProviderAppClientType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOAuth2ProviderAppClientType) Scan(value interface{}) error {
+	if value == nil {
+		ns.OAuth2ProviderAppClientType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OAuth2ProviderAppClientType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOAuth2ProviderAppClientType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OAuth2ProviderAppClientType), nil
+}
+
+func (e OAuth2ProviderAppClientType) Valid() bool {
+	switch e {
+	case OAuth2ProviderAppClientTypeConfidential,
+		OAuth2ProviderAppClientTypePublic:
+		return true
+	}
+	return false
+}
+
+func AllOAuth2ProviderAppClientTypeValues() []OAuth2ProviderAppClientType {
+	return []OAuth2ProviderAppClientType{
+		OAuth2ProviderAppClientTypeConfidential,
+		OAuth2ProviderAppClientTypePublic,
+	}
+}
+
+type OAuth2ProviderDeviceCodeStatus string
+
+const (
+	OAuth2ProviderDeviceCodeStatusPending  OAuth2ProviderDeviceCodeStatus = "pending"
+	OAuth2ProviderDeviceCodeStatusApproved OAuth2ProviderDeviceCodeStatus = "approved"
+	OAuth2ProviderDeviceCodeStatusDenied   OAuth2ProviderDeviceCodeStatus = "denied"
+)
+
+func (e *OAuth2ProviderDeviceCodeStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OAuth2ProviderDeviceCodeStatus(s)
+	case string:
+		*e = OAuth2ProviderDeviceCodeStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OAuth2ProviderDeviceCodeStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOAuth2ProviderDeviceCodeStatus struct {
+	OAuth2ProviderDeviceCodeStatus OAuth2ProviderDeviceCodeStatus `json:"oauth2_provider_device_code_status"`
+	Valid                          bool                           `json:"valid"` // Valid is true if OAuth2ProviderDeviceCodeStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOAuth2ProviderDeviceCodeStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OAuth2ProviderDeviceCodeStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OAuth2ProviderDeviceCodeStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOAuth2ProviderDeviceCodeStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OAuth2ProviderDeviceCodeStatus), nil
+}
+
+func (e OAuth2ProviderDeviceCodeStatus) Valid() bool {
+	switch e {
+	case OAuth2ProviderDeviceCodeStatusPending,
+		OAuth2ProviderDeviceCodeStatusApproved,
+		OAuth2ProviderDeviceCodeStatusDenied:
+		return true
+	}
+	return false
+}
+
+func AllOAuth2ProviderDeviceCodeStatusValues() []OAuth2ProviderDeviceCodeStatus {
+	return []OAuth2ProviderDeviceCodeStatus{
+		OAuth2ProviderDeviceCodeStatusPending,
+		OAuth2ProviderDeviceCodeStatusApproved,
+		OAuth2ProviderDeviceCodeStatusDenied,
 	}
 }
 
@@ -1031,6 +1171,8 @@ type PortShareProtocol string
 const (
 	PortShareProtocolHttp  PortShareProtocol = "http"
 	PortShareProtocolHttps PortShareProtocol = "https"
+	PortShareProtocolH2c   PortShareProtocol = "h2c"
+	PortShareProtocolTcp   PortShareProtocol = "tcp"
 )
 
 func (e *PortShareProtocol) Scan(src interface{}) error {
@@ -1071,7 +1213,9 @@ func (ns NullPortShareProtocol) Value() (driver.Value, error) {
 func (e PortShareProtocol) Valid() bool {
 	switch e {
 	case PortShareProtocolHttp,
-		PortShareProtocolHttps:
+		PortShareProtocolHttps,
+		PortShareProtocolH2c,
+		PortShareProtocolTcp:
 		return true
 	}
 	return false
@@ -1081,6 +1225,8 @@ func AllPortShareProtocolValues() []PortShareProtocol {
 	return []PortShareProtocol{
 		PortShareProtocolHttp,
 		PortShareProtocolHttps,
+		PortShareProtocolH2c,
+		PortShareProtocolTcp,
 	}
 }
 
@@ -1332,6 +1478,64 @@ func AllProvisionerTypeValues() []ProvisionerType {
 	}
 }
 
+type QuotaBudgetPeriod string
+
+const (
+	QuotaBudgetPeriodDay   QuotaBudgetPeriod = "day"
+	QuotaBudgetPeriodMonth QuotaBudgetPeriod = "month"
+)
+
+func (e *QuotaBudgetPeriod) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QuotaBudgetPeriod(s)
+	case string:
+		*e = QuotaBudgetPeriod(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QuotaBudgetPeriod: %T", src)
+	}
+	return nil
+}
+
+type NullQuotaBudgetPeriod struct {
+	QuotaBudgetPeriod QuotaBudgetPeriod `json:"quota_budget_period"`
+	Valid             bool              `json:"valid"` // Valid is true if QuotaBudgetPeriod is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQuotaBudgetPeriod) Scan(value interface{}) error {
+	if value == nil {
+		ns.QuotaBudgetPeriod, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QuotaBudgetPeriod.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQuotaBudgetPeriod) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QuotaBudgetPeriod), nil
+}
+
+func (e QuotaBudgetPeriod) Valid() bool {
+	switch e {
+	case QuotaBudgetPeriodDay,
+		QuotaBudgetPeriodMonth:
+		return true
+	}
+	return false
+}
+
+func AllQuotaBudgetPeriodValues() []QuotaBudgetPeriod {
+	return []QuotaBudgetPeriod{
+		QuotaBudgetPeriodDay,
+		QuotaBudgetPeriodMonth,
+	}
+}
+
 type ResourceType string
 
 const (
@@ -1353,6 +1557,10 @@ const (
 	ResourceTypeCustomRole              ResourceType = "custom_role"
 	ResourceTypeOrganizationMember      ResourceType = "organization_member"
 	ResourceTypeNotificationsSettings   ResourceType = "notifications_settings"
+	ResourceTypeNotificationTemplate    ResourceType = "notification_template"
+	ResourceTypeQuotaBudget             ResourceType = "quota_budget"
+	ResourceTypeTemplateReleaseChannel  ResourceType = "template_release_channel"
+	ResourceTypeTemplateGitSync         ResourceType = "template_git_sync"
 )
 
 func (e *ResourceType) Scan(src interface{}) error {
@@ -1409,7 +1617,11 @@ func (e ResourceType) Valid() bool {
 		ResourceTypeOauth2ProviderAppSecret,
 		ResourceTypeCustomRole,
 		ResourceTypeOrganizationMember,
-		ResourceTypeNotificationsSettings:
+		ResourceTypeNotificationsSettings,
+		ResourceTypeNotificationTemplate,
+		ResourceTypeQuotaBudget,
+		ResourceTypeTemplateReleaseChannel,
+		ResourceTypeTemplateGitSync:
 		return true
 	}
 	return false
@@ -1435,6 +1647,10 @@ func AllResourceTypeValues() []ResourceType {
 		ResourceTypeCustomRole,
 		ResourceTypeOrganizationMember,
 		ResourceTypeNotificationsSettings,
+		ResourceTypeNotificationTemplate,
+		ResourceTypeQuotaBudget,
+		ResourceTypeTemplateReleaseChannel,
+		ResourceTypeTemplateGitSync,
 	}
 }
 
@@ -1898,6 +2114,10 @@ type APIKey struct {
 	IPAddress       pqtype.Inet `db:"ip_address" json:"ip_address"`
 	Scope           APIKeyScope `db:"scope" json:"scope"`
 	TokenName       string      `db:"token_name" json:"token_name"`
+	// Permissions of the form "resource:action" the key is restricted to. When empty, the key is restricted by scope alone.
+	Scopes []string `db:"scopes" json:"scopes"`
+	// IDs of the resources the key is restricted to, in addition to its owner. When empty, the key may access any resource its scopes allow.
+	AllowList []uuid.UUID `db:"allow_list" json:"allow_list"`
 }
 
 type AuditLog struct {
@@ -1916,6 +2136,18 @@ type AuditLog struct {
 	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	// Position of the audit log in the hash chain of its organization, starting at 1. Audit logs created before hash chains were introduced are not in a chain.
+	ChainIndex sql.NullInt64 `db:"chain_index" json:"chain_index"`
+	// HMAC-SHA256 of the audit log and the hash of the previous audit log in the chain, keyed with a secret that is kept outside the database, so hashes cannot be recomputed from the database alone.
+	Hash []byte `db:"hash" json:"hash"`
+}
+
+// Last audit log in the hash chain of each organization, so audit logs deleted from the end of a chain are detected.
+type AuditLogChainHead struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	ChainIndex     int64     `db:"chain_index" json:"chain_index"`
+	Hash           []byte    `db:"hash" json:"hash"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
 }
 
 // Custom roles allow dynamic roles expanded at runtime
@@ -1998,6 +2230,19 @@ type GroupMember struct {
 	GroupID uuid.UUID `db:"group_id" json:"group_id"`
 }
 
+// Notifications delivered by the inbox method, which users can read in the dashboard and CLI.
+type InboxNotification struct {
+	// The ID of the notification message which was delivered to the inbox.
+	ID        uuid.UUID       `db:"id" json:"id"`
+	UserID    uuid.UUID       `db:"user_id" json:"user_id"`
+	Title     string          `db:"title" json:"title"`
+	Content   string          `db:"content" json:"content"`
+	Actions   json.RawMessage `db:"actions" json:"actions"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+	// When the user marked the notification as read; NULL if unread.
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+}
+
 type JfrogXrayScan struct {
 	AgentID     uuid.UUID `db:"agent_id" json:"agent_id"`
 	WorkspaceID uuid.UUID `db:"workspace_id" json:"workspace_id"`
@@ -2032,6 +2277,19 @@ type NotificationMessage struct {
 	LeasedUntil            sql.NullTime              `db:"leased_until" json:"leased_until"`
 	NextRetryAfter         sql.NullTime              `db:"next_retry_after" json:"next_retry_after"`
 	QueuedSeconds          sql.NullFloat64           `db:"queued_seconds" json:"queued_seconds"`
+	// Hash of the message contents and the deduplication window it was enqueued in; prevents identical messages from being enqueued for the same user more than once per window.
+	DedupeHash sql.NullString `db:"dedupe_hash" json:"dedupe_hash"`
+}
+
+// Per-user preferences for how (and whether) notifications of a given template are delivered.
+type NotificationPreference struct {
+	UserID                 uuid.UUID `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID `db:"notification_template_id" json:"notification_template_id"`
+	Disabled               bool      `db:"disabled" json:"disabled"`
+	// The method by which to deliver notifications of this template to the user. If NULL, the deployment-wide method is used.
+	Method    NullNotificationMethod `db:"method" json:"method"`
+	CreatedAt time.Time              `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time              `db:"updated_at" json:"updated_at"`
 }
 
 // Templates from which to create notification messages.
@@ -2042,6 +2300,16 @@ type NotificationTemplate struct {
 	BodyTemplate  string         `db:"body_template" json:"body_template"`
 	Actions       []byte         `db:"actions" json:"actions"`
 	Group         sql.NullString `db:"group" json:"group"`
+	// Mandatory notification templates cannot be disabled by users.
+	Mandatory bool `db:"mandatory" json:"mandatory"`
+	// Messages of digest templates are held back and delivered together as a single message per user, once per digest window.
+	Digest bool `db:"digest" json:"digest"`
+	// Title template set by an administrator, used instead of title_template when set.
+	TitleTemplateOverride sql.NullString `db:"title_template_override" json:"title_template_override"`
+	// Body template set by an administrator, used instead of body_template when set.
+	BodyTemplateOverride sql.NullString `db:"body_template_override" json:"body_template_override"`
+	// Actions set by an administrator, used instead of actions when set.
+	ActionsOverride []byte `db:"actions_override" json:"actions_override"`
 }
 
 // A table used to configure apps that can use Coder as an OAuth2 provider, the reverse of what we are calling external authentication.
@@ -2052,6 +2320,10 @@ type OAuth2ProviderApp struct {
 	Name        string    `db:"name" json:"name"`
 	Icon        string    `db:"icon" json:"icon"`
 	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	// Public clients, such as desktop and command line tools, cannot keep a client secret and must use PKCE instead.
+	ClientType OAuth2ProviderAppClientType `db:"client_type" json:"client_type"`
+	// Grant types the application may use at the token endpoint. Dynamically registered clients are limited to the grant types they registered with.
+	GrantTypes []string `db:"grant_types" json:"grant_types"`
 }
 
 // Codes are meant to be exchanged for access tokens.
@@ -2063,6 +2335,11 @@ type OAuth2ProviderAppCode struct {
 	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
 	UserID       uuid.UUID `db:"user_id" json:"user_id"`
 	AppID        uuid.UUID `db:"app_id" json:"app_id"`
+	// PKCE code challenge the code verifier must match when the code is exchanged. Empty if the client did not use PKCE.
+	CodeChallenge       string `db:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `db:"code_challenge_method" json:"code_challenge_method"`
+	// Permissions the user granted to the application. When empty, the application has full access.
+	Scopes []string `db:"scopes" json:"scopes"`
 }
 
 type OAuth2ProviderAppSecret struct {
@@ -2082,9 +2359,27 @@ type OAuth2ProviderAppToken struct {
 	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
 	HashPrefix []byte    `db:"hash_prefix" json:"hash_prefix"`
 	// Refresh tokens provide a way to refresh an access token (API key). An expired API key can be refreshed if this token is not yet expired, meaning this expiry can outlive an API key.
-	RefreshHash []byte    `db:"refresh_hash" json:"refresh_hash"`
-	AppSecretID uuid.UUID `db:"app_secret_id" json:"app_secret_id"`
-	APIKeyID    string    `db:"api_key_id" json:"api_key_id"`
+	RefreshHash []byte        `db:"refresh_hash" json:"refresh_hash"`
+	AppSecretID uuid.NullUUID `db:"app_secret_id" json:"app_secret_id"`
+	APIKeyID    string        `db:"api_key_id" json:"api_key_id"`
+	AppID       uuid.UUID     `db:"app_id" json:"app_id"`
+}
+
+// Device codes are exchanged for access tokens by devices without a browser, once the user has approved the request on another device.
+type OAuth2ProviderDeviceCode struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
+	// Short code the user enters to identify the device they are approving.
+	UserCode string                         `db:"user_code" json:"user_code"`
+	AppID    uuid.UUID                      `db:"app_id" json:"app_id"`
+	Scopes   []string                       `db:"scopes" json:"scopes"`
+	Status   OAuth2ProviderDeviceCodeStatus `db:"status" json:"status"`
+	// User that approved or denied the request.
+	UserID       uuid.NullUUID `db:"user_id" json:"user_id"`
+	LastPolledAt sql.NullTime  `db:"last_polled_at" json:"last_polled_at"`
 }
 
 type Organization struct {
@@ -2194,6 +2489,33 @@ type ProvisionerKey struct {
 	Tags           StringMap `db:"tags" json:"tags"`
 }
 
+// Records users who have been notified that a budget limit was reached, so they are only notified once per period.
+type QuotaBudgetAlert struct {
+	BudgetID    uuid.UUID `db:"budget_id" json:"budget_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	PeriodStart time.Time `db:"period_start" json:"period_start"`
+	HardLimit   bool      `db:"hard_limit" json:"hard_limit"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+// Limits on the cost accrued by running workspaces within a day or month, for a single user or for all members of a group combined.
+// Limits on the cost accrued by running workspaces within a day or month, for a single user or for all members of a group combined.
+type QuotaBudget struct {
+	ID             uuid.UUID         `db:"id" json:"id"`
+	OrganizationID uuid.UUID         `db:"organization_id" json:"organization_id"`
+	GroupID        uuid.NullUUID     `db:"group_id" json:"group_id"`
+	UserID         uuid.NullUUID     `db:"user_id" json:"user_id"`
+	Period         QuotaBudgetPeriod `db:"period" json:"period"`
+	// Accrued cost at which the affected users are notified. 0 disables the soft limit.
+	SoftLimit int64 `db:"soft_limit" json:"soft_limit"`
+	// Accrued cost at which workspace starts are rejected. 0 disables the hard limit.
+	HardLimit int64 `db:"hard_limit" json:"hard_limit"`
+	// Whether running workspaces are stopped once the hard limit is reached.
+	StopWorkspaces bool      `db:"stop_workspaces" json:"stop_workspaces"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+}
+
 type Replica struct {
 	ID              uuid.UUID    `db:"id" json:"id"`
 	CreatedAt       time.Time    `db:"created_at" json:"created_at"`
@@ -2286,6 +2608,8 @@ type Template struct {
 	Deprecated                    string          `db:"deprecated" json:"deprecated"`
 	ActivityBump                  int64           `db:"activity_bump" json:"activity_bump"`
 	MaxPortSharingLevel           AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	IdleTimeout                   int64           `db:"idle_timeout" json:"idle_timeout"`
+	IdleCPUThreshold              int32           `db:"idle_cpu_threshold" json:"idle_cpu_threshold"`
 	CreatedByAvatarURL            string          `db:"created_by_avatar_url" json:"created_by_avatar_url"`
 	CreatedByUsername             string          `db:"created_by_username" json:"created_by_username"`
 	OrganizationName              string          `db:"organization_name" json:"organization_name"`
@@ -2293,6 +2617,36 @@ type Template struct {
 	OrganizationIcon              string          `db:"organization_icon" json:"organization_icon"`
 }
 
+// Git repositories that templates are synced from. New commits create template versions.
+type TemplateGitSync struct {
+	TemplateID    uuid.UUID `db:"template_id" json:"template_id"`
+	RepositoryURL string    `db:"repository_url" json:"repository_url"`
+	Branch        string    `db:"branch" json:"branch"`
+	Directory     string    `db:"directory" json:"directory"`
+	// External auth provider whose token, linked to created_by, is used to fetch the repository. Empty for public repositories.
+	ExternalAuthProviderID string `db:"external_auth_provider_id" json:"external_auth_provider_id"`
+	AutoActivate           bool   `db:"auto_activate" json:"auto_activate"`
+	WebhookSecret          string `db:"webhook_secret" json:"webhook_secret"`
+	// User that template versions are created as.
+	CreatedBy             uuid.UUID     `db:"created_by" json:"created_by"`
+	LastCommitSha         string        `db:"last_commit_sha" json:"last_commit_sha"`
+	LastTemplateVersionID uuid.NullUUID `db:"last_template_version_id" json:"last_template_version_id"`
+	LastSyncedAt          sql.NullTime  `db:"last_synced_at" json:"last_synced_at"`
+	LastError             string        `db:"last_error" json:"last_error"`
+	CreatedAt             time.Time     `db:"created_at" json:"created_at"`
+	UpdatedAt             time.Time     `db:"updated_at" json:"updated_at"`
+}
+
+// Named pointers to template versions, such as canary and stable, that workspaces can follow instead of the template active version.
+type TemplateReleaseChannel struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TemplateID        uuid.UUID `db:"template_id" json:"template_id"`
+	Name              string    `db:"name" json:"name"`
+	TemplateVersionID uuid.UUID `db:"template_version_id" json:"template_version_id"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+}
+
 type TemplateTable struct {
 	ID              uuid.UUID       `db:"id" json:"id"`
 	CreatedAt       time.Time       `db:"created_at" json:"created_at"`
@@ -2331,6 +2685,10 @@ type TemplateTable struct {
 	Deprecated          string          `db:"deprecated" json:"deprecated"`
 	ActivityBump        int64           `db:"activity_bump" json:"activity_bump"`
 	MaxPortSharingLevel AppSharingLevel `db:"max_port_sharing_level" json:"max_port_sharing_level"`
+	// The duration after which a running workspace with no sessions and CPU usage below idle_cpu_threshold is stopped. 0 disables idle stop.
+	IdleTimeout int64 `db:"idle_timeout" json:"idle_timeout"`
+	// The percentage of CPU usage at or above which a workspace is considered active. 0 ignores CPU usage.
+	IdleCPUThreshold int32 `db:"idle_cpu_threshold" json:"idle_cpu_threshold"`
 }
 
 // Records aggregated usage statistics for templates/users. All usage is rounded up to the nearest minute.
@@ -2515,7 +2873,13 @@ type Workspace struct {
 	DeletingAt        sql.NullTime     `db:"deleting_at" json:"deleting_at"`
 	AutomaticUpdates  AutomaticUpdates `db:"automatic_updates" json:"automatic_updates"`
 	// Favorite is true if the workspace owner has favorited the workspace.
-	Favorite bool `db:"favorite" json:"favorite"`
+	Favorite bool         `db:"favorite" json:"favorite"`
+	UserACL  WorkspaceACL `db:"user_acl" json:"user_acl"`
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
+	// The last time the workspace had an open session or CPU usage at or above the template idle_cpu_threshold.
+	LastActiveAt time.Time `db:"last_active_at" json:"last_active_at"`
+	// Name of the template release channel the workspace follows. Workspaces that follow no channel, or a channel that does not exist, use the template active version.
+	ReleaseChannel string `db:"release_channel" json:"release_channel"`
 }
 
 type WorkspaceAgent struct {
@@ -2600,6 +2964,10 @@ type WorkspaceAgentPortShare struct {
 	Port        int32             `db:"port" json:"port"`
 	ShareLevel  AppSharingLevel   `db:"share_level" json:"share_level"`
 	Protocol    PortShareProtocol `db:"protocol" json:"protocol"`
+	// The users that can access the port when share_level is allowlist.
+	AllowedUserIDs []uuid.UUID `db:"allowed_user_ids" json:"allowed_user_ids"`
+	// The groups that can access the port when share_level is allowlist.
+	AllowedGroupIDs []uuid.UUID `db:"allowed_group_ids" json:"allowed_group_ids"`
 }
 
 type WorkspaceAgentScript struct {
@@ -2699,6 +3067,14 @@ type WorkspaceBuild struct {
 	InitiatorByUsername  string              `db:"initiator_by_username" json:"initiator_by_username"`
 }
 
+// Records that the owner of a workspace build was warned of its approaching autostop.
+type WorkspaceBuildAutostopWarning struct {
+	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
+	// The deadline the owner was warned of. If the deadline of the build changes, the owner is warned again.
+	Deadline time.Time `db:"deadline" json:"deadline"`
+	WarnedAt time.Time `db:"warned_at" json:"warned_at"`
+}
+
 type WorkspaceBuildParameter struct {
 	WorkspaceBuildID uuid.UUID `db:"workspace_build_id" json:"workspace_build_id"`
 	// Parameter name
@@ -2767,3 +3143,20 @@ type WorkspaceResourceMetadatum struct {
 	Sensitive           bool           `db:"sensitive" json:"sensitive"`
 	ID                  int64          `db:"id" json:"id"`
 }
+
+// Point in time copies of a workspace build, which can be restored to create a new build with the same state.
+type WorkspaceSnapshot struct {
+	ID                uuid.UUID           `db:"id" json:"id"`
+	WorkspaceID       uuid.UUID           `db:"workspace_id" json:"workspace_id"`
+	WorkspaceBuildID  uuid.UUID           `db:"workspace_build_id" json:"workspace_build_id"`
+	BuildNumber       int32               `db:"build_number" json:"build_number"`
+	TemplateVersionID uuid.UUID           `db:"template_version_id" json:"template_version_id"`
+	Name              string              `db:"name" json:"name"`
+	Transition        WorkspaceTransition `db:"transition" json:"transition"`
+	// Terraform state of the build, which includes the identity of persistent volumes.
+	ProvisionerState []byte `db:"provisioner_state" json:"provisioner_state"`
+	// Rich parameter values of the build, as a list of name and value objects.
+	RichParameters json.RawMessage `db:"rich_parameters" json:"rich_parameters"`
+	CreatedBy      uuid.UUID       `db:"created_by" json:"created_by"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+}
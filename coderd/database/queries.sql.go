@@ -146,7 +146,7 @@ func (q *sqlQuerier) DeleteApplicationConnectAPIKeysByUserID(ctx context.Context
 
 const getAPIKeyByID = `-- name: GetAPIKeyByID :one
 SELECT
-	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list
 FROM
 	api_keys
 WHERE
@@ -171,13 +171,15 @@ func (q *sqlQuerier) GetAPIKeyByID(ctx context.Context, id string) (APIKey, erro
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		pq.Array(&i.AllowList),
 	)
 	return i, err
 }
 
 const getAPIKeyByName = `-- name: GetAPIKeyByName :one
 SELECT
-	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list
 FROM
 	api_keys
 WHERE
@@ -210,12 +212,14 @@ func (q *sqlQuerier) GetAPIKeyByName(ctx context.Context, arg GetAPIKeyByNamePar
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		pq.Array(&i.AllowList),
 	)
 	return i, err
 }
 
 const getAPIKeysByLoginType = `-- name: GetAPIKeysByLoginType :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE login_type = $1
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list FROM api_keys WHERE login_type = $1
 `
 
 func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginType) ([]APIKey, error) {
@@ -240,6 +244,8 @@ func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginT
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			pq.Array(&i.AllowList),
 		); err != nil {
 			return nil, err
 		}
@@ -255,7 +261,7 @@ func (q *sqlQuerier) GetAPIKeysByLoginType(ctx context.Context, loginType LoginT
 }
 
 const getAPIKeysByUserID = `-- name: GetAPIKeysByUserID :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE login_type = $1 AND user_id = $2
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list FROM api_keys WHERE login_type = $1 AND user_id = $2
 `
 
 type GetAPIKeysByUserIDParams struct {
@@ -285,6 +291,8 @@ func (q *sqlQuerier) GetAPIKeysByUserID(ctx context.Context, arg GetAPIKeysByUse
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			pq.Array(&i.AllowList),
 		); err != nil {
 			return nil, err
 		}
@@ -300,7 +308,7 @@ func (q *sqlQuerier) GetAPIKeysByUserID(ctx context.Context, arg GetAPIKeysByUse
 }
 
 const getAPIKeysLastUsedAfter = `-- name: GetAPIKeysLastUsedAfter :many
-SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name FROM api_keys WHERE last_used > $1
+SELECT id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list FROM api_keys WHERE last_used > $1
 `
 
 func (q *sqlQuerier) GetAPIKeysLastUsedAfter(ctx context.Context, lastUsed time.Time) ([]APIKey, error) {
@@ -325,6 +333,8 @@ func (q *sqlQuerier) GetAPIKeysLastUsedAfter(ctx context.Context, lastUsed time.
 			&i.IPAddress,
 			&i.Scope,
 			&i.TokenName,
+			pq.Array(&i.Scopes),
+			pq.Array(&i.AllowList),
 		); err != nil {
 			return nil, err
 		}
@@ -353,7 +363,9 @@ INSERT INTO
 		updated_at,
 		login_type,
 		scope,
-		token_name
+		token_name,
+		scopes,
+		allow_list
 	)
 VALUES
 	($1,
@@ -362,7 +374,9 @@ VALUES
 	     WHEN 0 THEN 86400
 		 ELSE $2::bigint
 	 END
-	 , $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name
+	 , $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+	 -- Keys created without scopes store empty arrays rather than NULL.
+	 COALESCE($13::text[], '{}'::text[]), COALESCE($14::uuid[], '{}'::uuid[])) RETURNING id, hashed_secret, user_id, last_used, expires_at, created_at, updated_at, login_type, lifetime_seconds, ip_address, scope, token_name, scopes, allow_list
 `
 
 type InsertAPIKeyParams struct {
@@ -378,6 +392,8 @@ type InsertAPIKeyParams struct {
 	LoginType       LoginType   `db:"login_type" json:"login_type"`
 	Scope           APIKeyScope `db:"scope" json:"scope"`
 	TokenName       string      `db:"token_name" json:"token_name"`
+	Scopes          []string    `db:"scopes" json:"scopes"`
+	AllowList       []uuid.UUID `db:"allow_list" json:"allow_list"`
 }
 
 func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (APIKey, error) {
@@ -394,6 +410,8 @@ func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (
 		arg.LoginType,
 		arg.Scope,
 		arg.TokenName,
+		pq.Array(arg.Scopes),
+		pq.Array(arg.AllowList),
 	)
 	var i APIKey
 	err := row.Scan(
@@ -409,6 +427,8 @@ func (q *sqlQuerier) InsertAPIKey(ctx context.Context, arg InsertAPIKeyParams) (
 		&i.IPAddress,
 		&i.Scope,
 		&i.TokenName,
+		pq.Array(&i.Scopes),
+		pq.Array(&i.AllowList),
 	)
 	return i, err
 }
@@ -441,9 +461,67 @@ func (q *sqlQuerier) UpdateAPIKeyByID(ctx context.Context, arg UpdateAPIKeyByIDP
 	return err
 }
 
+const acquireAuditLogChainHead = `-- name: AcquireAuditLogChainHead :one
+INSERT INTO
+	audit_log_chain_heads (organization_id, chain_index, hash, updated_at)
+VALUES
+	($1, 0, ''::bytea, NOW())
+ON CONFLICT (organization_id) DO UPDATE SET
+	-- A no-op update, which locks the row.
+	organization_id = EXCLUDED.organization_id
+RETURNING organization_id, chain_index, hash, updated_at
+`
+
+// AcquireAuditLogChainHead locks the head of the hash chain of an organization
+// until the end of the transaction, so audit logs are appended to the chain one
+// at a time. An empty chain is created if the organization has none.
+func (q *sqlQuerier) AcquireAuditLogChainHead(ctx context.Context, organizationID uuid.UUID) (AuditLogChainHead, error) {
+	row := q.db.QueryRowContext(ctx, acquireAuditLogChainHead, organizationID)
+	var i AuditLogChainHead
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.ChainIndex,
+		&i.Hash,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAuditLogChainHeads = `-- name: GetAuditLogChainHeads :many
+SELECT organization_id, chain_index, hash, updated_at FROM audit_log_chain_heads ORDER BY organization_id
+`
+
+func (q *sqlQuerier) GetAuditLogChainHeads(ctx context.Context) ([]AuditLogChainHead, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditLogChainHeads)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLogChainHead
+	for rows.Next() {
+		var i AuditLogChainHead
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.ChainIndex,
+			&i.Hash,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditLogsOffset = `-- name: GetAuditLogsOffset :many
 SELECT
-    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon,
+    audit_logs.id, audit_logs.time, audit_logs.user_id, audit_logs.organization_id, audit_logs.ip, audit_logs.user_agent, audit_logs.resource_type, audit_logs.resource_id, audit_logs.resource_target, audit_logs.action, audit_logs.diff, audit_logs.status_code, audit_logs.additional_fields, audit_logs.request_id, audit_logs.resource_icon, audit_logs.chain_index, audit_logs.hash,
     -- sqlc.embed(users) would be nice but it does not seem to play well with
     -- left joins.
     users.username AS user_username,
@@ -601,6 +679,8 @@ type GetAuditLogsOffsetRow struct {
 	AdditionalFields        json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID               uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon            string          `db:"resource_icon" json:"resource_icon"`
+	ChainIndex              sql.NullInt64   `db:"chain_index" json:"chain_index"`
+	Hash                    []byte          `db:"hash" json:"hash"`
 	UserUsername            sql.NullString  `db:"user_username" json:"user_username"`
 	UserName                sql.NullString  `db:"user_name" json:"user_name"`
 	UserEmail               sql.NullString  `db:"user_email" json:"user_email"`
@@ -661,6 +741,8 @@ func (q *sqlQuerier) GetAuditLogsOffset(ctx context.Context, arg GetAuditLogsOff
 			&i.AdditionalFields,
 			&i.RequestID,
 			&i.ResourceIcon,
+			&i.ChainIndex,
+			&i.Hash,
 			&i.UserUsername,
 			&i.UserName,
 			&i.UserEmail,
@@ -692,6 +774,69 @@ func (q *sqlQuerier) GetAuditLogsOffset(ctx context.Context, arg GetAuditLogsOff
 	return items, nil
 }
 
+const getChainedAuditLogs = `-- name: GetChainedAuditLogs :many
+SELECT
+	id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon, chain_index, hash
+FROM
+	audit_logs
+WHERE
+	organization_id = $1
+	AND chain_index > $2 :: bigint
+ORDER BY
+	chain_index ASC
+LIMIT
+	$3 :: int
+`
+
+type GetChainedAuditLogsParams struct {
+	OrganizationID  uuid.UUID `db:"organization_id" json:"organization_id"`
+	AfterChainIndex int64     `db:"after_chain_index" json:"after_chain_index"`
+	LimitOpt        int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+// GetChainedAuditLogs returns the audit logs in the hash chain of an
+// organization in order, starting after the given chain index.
+func (q *sqlQuerier) GetChainedAuditLogs(ctx context.Context, arg GetChainedAuditLogsParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, getChainedAuditLogs, arg.OrganizationID, arg.AfterChainIndex, arg.LimitOpt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Time,
+			&i.UserID,
+			&i.OrganizationID,
+			&i.Ip,
+			&i.UserAgent,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.ResourceTarget,
+			&i.Action,
+			&i.Diff,
+			&i.StatusCode,
+			&i.AdditionalFields,
+			&i.RequestID,
+			&i.ResourceIcon,
+			&i.ChainIndex,
+			&i.Hash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertAuditLog = `-- name: InsertAuditLog :one
 INSERT INTO
 	audit_logs (
@@ -709,10 +854,12 @@ INSERT INTO
         status_code,
         additional_fields,
         request_id,
-        resource_icon
+        resource_icon,
+        chain_index,
+        hash
     )
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING id, time, user_id, organization_id, ip, user_agent, resource_type, resource_id, resource_target, action, diff, status_code, additional_fields, request_id, resource_icon, chain_index, hash
 `
 
 type InsertAuditLogParams struct {
@@ -731,6 +878,8 @@ type InsertAuditLogParams struct {
 	AdditionalFields json.RawMessage `db:"additional_fields" json:"additional_fields"`
 	RequestID        uuid.UUID       `db:"request_id" json:"request_id"`
 	ResourceIcon     string          `db:"resource_icon" json:"resource_icon"`
+	ChainIndex       sql.NullInt64   `db:"chain_index" json:"chain_index"`
+	Hash             []byte          `db:"hash" json:"hash"`
 }
 
 func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParams) (AuditLog, error) {
@@ -750,6 +899,8 @@ func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParam
 		arg.AdditionalFields,
 		arg.RequestID,
 		arg.ResourceIcon,
+		arg.ChainIndex,
+		arg.Hash,
 	)
 	var i AuditLog
 	err := row.Scan(
@@ -768,10 +919,40 @@ func (q *sqlQuerier) InsertAuditLog(ctx context.Context, arg InsertAuditLogParam
 		&i.AdditionalFields,
 		&i.RequestID,
 		&i.ResourceIcon,
+		&i.ChainIndex,
+		&i.Hash,
 	)
 	return i, err
 }
 
+const updateAuditLogChainHead = `-- name: UpdateAuditLogChainHead :exec
+UPDATE
+	audit_log_chain_heads
+SET
+	chain_index = $1,
+	hash = $2,
+	updated_at = $3
+WHERE
+	organization_id = $4
+`
+
+type UpdateAuditLogChainHeadParams struct {
+	ChainIndex     int64     `db:"chain_index" json:"chain_index"`
+	Hash           []byte    `db:"hash" json:"hash"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+}
+
+func (q *sqlQuerier) UpdateAuditLogChainHead(ctx context.Context, arg UpdateAuditLogChainHeadParams) error {
+	_, err := q.db.ExecContext(ctx, updateAuditLogChainHead,
+		arg.ChainIndex,
+		arg.Hash,
+		arg.UpdatedAt,
+		arg.OrganizationID,
+	)
+	return err
+}
+
 const getDBCryptKeys = `-- name: GetDBCryptKeys :many
 SELECT number, active_key_digest, revoked_key_digest, created_at, revoked_at, test FROM dbcrypt_keys ORDER BY number ASC
 `
@@ -3299,47 +3480,91 @@ func (q *sqlQuerier) TryAcquireLock(ctx context.Context, pgTryAdvisoryXactLock i
 }
 
 const acquireNotificationMessages = `-- name: AcquireNotificationMessages :many
-WITH acquired AS (
-    UPDATE
-        notification_messages
-            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
-                updated_at = NOW(),
-                status = 'leased'::notification_message_status,
-                status_reason = 'Leased by notifier ' || $1::uuid,
-                leased_until = NOW() + CONCAT($2::int, ' seconds')::interval
-            WHERE id IN (SELECT nm.id
-                         FROM notification_messages AS nm
-                         WHERE (
-                             (
-                                 -- message is in acquirable states
-                                 nm.status IN (
-                                               'pending'::notification_message_status,
-                                               'temporary_failure'::notification_message_status
-                                     )
-                                 )
-                                 -- or somehow the message was left in leased for longer than its lease period
-                                 OR (
-                                 nm.status = 'leased'::notification_message_status
-                                     AND nm.leased_until < NOW()
-                                 )
-                             )
-                           AND (
-                             -- exclude all messages which have exceeded the max attempts; these will be purged later
-                             nm.attempt_count IS NULL OR nm.attempt_count < $3::int
-                             )
-                           -- if set, do not retry until we've exceeded the wait time
-                           AND (
-                             CASE
-                                 WHEN nm.next_retry_after IS NOT NULL THEN nm.next_retry_after < NOW()
-                                 ELSE true
-                                 END
-                             )
-                         ORDER BY nm.created_at ASC
-                                  -- Ensure that multiple concurrent readers cannot retrieve the same rows
-                             FOR UPDATE OF nm
-                                 SKIP LOCKED
-                         LIMIT $4)
-            RETURNING id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds)
+WITH candidates AS (SELECT nm.id,
+                           nm.user_id,
+                           nm.notification_template_id,
+                           nm.method,
+                           nm.created_at,
+                           -- the number of messages which have been sent (or are currently being sent) to the user in
+                           -- the last hour
+                           (SELECT COUNT(*)
+                            FROM notification_messages AS sent
+                            WHERE sent.user_id = nm.user_id
+                              AND sent.status IN ('sent'::notification_message_status,
+                                                  'leased'::notification_message_status)
+                              AND sent.updated_at > NOW() - INTERVAL '1 hour') AS recent_count
+                    FROM notification_messages AS nm
+                    WHERE (
+                        (
+                            -- message is in acquirable states
+                            nm.status IN (
+                                          'pending'::notification_message_status,
+                                          'temporary_failure'::notification_message_status
+                                )
+                            )
+                            -- or somehow the message was left in leased for longer than its lease period
+                            OR (
+                            nm.status = 'leased'::notification_message_status
+                                AND nm.leased_until < NOW()
+                            )
+                        )
+                      AND (
+                        -- exclude all messages which have exceeded the max attempts; these will be purged later
+                        nm.attempt_count IS NULL OR nm.attempt_count < $1::int
+                        )
+                      -- if set, do not retry until we've exceeded the wait time
+                      AND (
+                        CASE
+                            WHEN nm.next_retry_after IS NOT NULL THEN nm.next_retry_after < NOW()
+                            ELSE true
+                            END
+                        )
+                      -- if a per-user rate limit is set, skip the messages of users who have already been sent (or are
+                      -- currently being sent) that many messages in the last hour; these will be acquired once the user
+                      -- falls below the limit again
+                      AND (
+                        $2::int = 0
+                            OR (SELECT COUNT(*)
+                                FROM notification_messages AS sent
+                                WHERE sent.user_id = nm.user_id
+                                  AND sent.status IN ('sent'::notification_message_status,
+                                                      'leased'::notification_message_status)
+                                  AND sent.updated_at > NOW() - INTERVAL '1 hour') < $2::int
+                        )
+                    ORDER BY nm.created_at ASC
+                        -- Ensure that multiple concurrent readers cannot retrieve the same rows
+                        FOR UPDATE OF nm
+                            SKIP LOCKED
+                    LIMIT $3),
+     -- messages acquired in this batch count towards the per-user rate limit too
+     within_limit AS (SELECT ranked.id, ranked.user_id, ranked.notification_template_id, ranked.method
+                      FROM (SELECT candidates.*,
+                                   ROW_NUMBER() OVER (PARTITION BY candidates.user_id ORDER BY candidates.created_at) AS batch_count
+                            FROM candidates) AS ranked
+                      WHERE $2::int = 0
+                         OR ranked.recent_count + ranked.batch_count <= $2::int),
+     digests AS (SELECT nm.id
+                 FROM notification_messages AS nm
+                          JOIN notification_templates nt ON nm.notification_template_id = nt.id
+                 WHERE nt.digest
+                   AND (nm.user_id, nm.notification_template_id, nm.method) IN
+                       (SELECT user_id, notification_template_id, method FROM within_limit)
+                   AND nm.status IN ('pending'::notification_message_status,
+                                     'temporary_failure'::notification_message_status)
+                   AND (nm.attempt_count IS NULL OR nm.attempt_count < $1::int)
+                   AND (nm.next_retry_after IS NULL OR nm.next_retry_after < NOW())
+                     FOR UPDATE OF nm
+                         SKIP LOCKED),
+     acquired AS (
+         UPDATE
+             notification_messages
+                 SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
+                     updated_at = NOW(),
+                     status = 'leased'::notification_message_status,
+                     status_reason = 'Leased by notifier ' || $4::uuid,
+                     leased_until = NOW() + CONCAT($5::int, ' seconds')::interval
+                 WHERE id IN (SELECT id FROM within_limit UNION SELECT id FROM digests)
+                 RETURNING *)
 SELECT
     -- message
     nm.id,
@@ -3347,19 +3572,22 @@ SELECT
     nm.method,
     nm.attempt_count::int AS attempt_count,
     nm.queued_seconds::float AS queued_seconds,
+    nm.user_id,
     -- template
     nt.id AS template_id,
-    nt.title_template,
-    nt.body_template
+    -- templates customized by an administrator take precedence
+    COALESCE(nt.title_template_override, nt.title_template)::text AS title_template,
+    COALESCE(nt.body_template_override, nt.body_template)::text AS body_template,
+    nt.digest
 FROM acquired nm
-         JOIN notification_templates nt ON nm.notification_template_id = nt.id
-`
+         JOIN notification_templates nt ON nm.notification_template_id = nt.id;`
 
 type AcquireNotificationMessagesParams struct {
-	NotifierID      uuid.UUID `db:"notifier_id" json:"notifier_id"`
-	LeaseSeconds    int32     `db:"lease_seconds" json:"lease_seconds"`
-	MaxAttemptCount int32     `db:"max_attempt_count" json:"max_attempt_count"`
-	Count           int32     `db:"count" json:"count"`
+	MaxAttemptCount  int32     `db:"max_attempt_count" json:"max_attempt_count"`
+	PerUserRateLimit int32     `db:"per_user_rate_limit" json:"per_user_rate_limit"`
+	Count            int32     `db:"count" json:"count"`
+	NotifierID       uuid.UUID `db:"notifier_id" json:"notifier_id"`
+	LeaseSeconds     int32     `db:"lease_seconds" json:"lease_seconds"`
 }
 
 type AcquireNotificationMessagesRow struct {
@@ -3368,9 +3596,11 @@ type AcquireNotificationMessagesRow struct {
 	Method        NotificationMethod `db:"method" json:"method"`
 	AttemptCount  int32              `db:"attempt_count" json:"attempt_count"`
 	QueuedSeconds float64            `db:"queued_seconds" json:"queued_seconds"`
+	UserID        uuid.UUID          `db:"user_id" json:"user_id"`
 	TemplateID    uuid.UUID          `db:"template_id" json:"template_id"`
 	TitleTemplate string             `db:"title_template" json:"title_template"`
 	BodyTemplate  string             `db:"body_template" json:"body_template"`
+	Digest        bool               `db:"digest" json:"digest"`
 }
 
 // Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
@@ -3383,12 +3613,16 @@ type AcquireNotificationMessagesRow struct {
 //
 // SKIP LOCKED is used to jump over locked rows. This prevents multiple notifiers from acquiring the same messages.
 // See: https://www.postgresql.org/docs/9.5/sql-select.html#SQL-FOR-UPDATE-SHARE
+//
+// All acquirable messages of digest templates for the same user, template and method as an acquired one are acquired
+// along with it, regardless of the count, so that they are delivered as a single digest.
 func (q *sqlQuerier) AcquireNotificationMessages(ctx context.Context, arg AcquireNotificationMessagesParams) ([]AcquireNotificationMessagesRow, error) {
 	rows, err := q.db.QueryContext(ctx, acquireNotificationMessages,
-		arg.NotifierID,
-		arg.LeaseSeconds,
 		arg.MaxAttemptCount,
+		arg.PerUserRateLimit,
 		arg.Count,
+		arg.NotifierID,
+		arg.LeaseSeconds,
 	)
 	if err != nil {
 		return nil, err
@@ -3403,9 +3637,11 @@ func (q *sqlQuerier) AcquireNotificationMessages(ctx context.Context, arg Acquir
 			&i.Method,
 			&i.AttemptCount,
 			&i.QueuedSeconds,
+			&i.UserID,
 			&i.TemplateID,
 			&i.TitleTemplate,
 			&i.BodyTemplate,
+			&i.Digest,
 		); err != nil {
 			return nil, err
 		}
@@ -3492,6 +3728,42 @@ func (q *sqlQuerier) BulkMarkNotificationMessagesSent(ctx context.Context, arg B
 	return result.RowsAffected()
 }
 
+const clearNotificationMessageDedupeHash = `-- name: ClearNotificationMessageDedupeHash :execrows
+UPDATE notification_messages
+SET dedupe_hash = NULL
+WHERE dedupe_hash = $1
+  AND created_at <= $2
+`
+
+type ClearNotificationMessageDedupeHashParams struct {
+	DedupeHash    sql.NullString `db:"dedupe_hash" json:"dedupe_hash"`
+	CreatedBefore time.Time      `db:"created_before" json:"created_before"`
+}
+
+// Clears the deduplication hash of a message which was enqueued before the given time, so that an identical message can
+// be enqueued once the deduplication window since the last one has passed.
+func (q *sqlQuerier) ClearNotificationMessageDedupeHash(ctx context.Context, arg ClearNotificationMessageDedupeHashParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, clearNotificationMessageDedupeHash, arg.DedupeHash, arg.CreatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countUnreadInboxNotificationsByUserID = `-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = $1
+  AND read_at IS NULL
+`
+
+func (q *sqlQuerier) CountUnreadInboxNotificationsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadInboxNotificationsByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteOldNotificationMessages = `-- name: DeleteOldNotificationMessages :exec
 DELETE
 FROM notification_messages
@@ -3508,14 +3780,17 @@ func (q *sqlQuerier) DeleteOldNotificationMessages(ctx context.Context) error {
 }
 
 const enqueueNotificationMessage = `-- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by,
+                                   dedupe_hash, next_retry_after)
 VALUES ($1,
         $2,
         $3,
         $4::notification_method,
         $5::jsonb,
         $6,
-        $7)
+        $7,
+        $8,
+        $9)
 `
 
 type EnqueueNotificationMessageParams struct {
@@ -3526,6 +3801,8 @@ type EnqueueNotificationMessageParams struct {
 	Payload                json.RawMessage    `db:"payload" json:"payload"`
 	Targets                []uuid.UUID        `db:"targets" json:"targets"`
 	CreatedBy              string             `db:"created_by" json:"created_by"`
+	DedupeHash             sql.NullString     `db:"dedupe_hash" json:"dedupe_hash"`
+	NextRetryAfter         sql.NullTime       `db:"next_retry_after" json:"next_retry_after"`
 }
 
 func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg EnqueueNotificationMessageParams) error {
@@ -3537,19 +3814,26 @@ func (q *sqlQuerier) EnqueueNotificationMessage(ctx context.Context, arg Enqueue
 		arg.Payload,
 		pq.Array(arg.Targets),
 		arg.CreatedBy,
+		arg.DedupeHash,
+		arg.NextRetryAfter,
 	)
 	return err
 }
 
 const fetchNewMessageMetadata = `-- name: FetchNewMessageMetadata :one
 SELECT nt.name                                                    AS notification_name,
-       nt.actions                                                 AS actions,
+       COALESCE(nt.actions_override, nt.actions)                  AS actions,
        u.id                                                       AS user_id,
        u.email                                                    AS user_email,
        COALESCE(NULLIF(u.name, ''), NULLIF(u.username, ''))::text AS user_name,
-       COALESCE(u.username, '')                                   AS user_username
-FROM notification_templates nt,
-     users u
+       COALESCE(u.username, '')                                   AS user_username,
+       nt.mandatory                                               AS mandatory,
+       COALESCE(np.disabled, FALSE)                               AS disabled,
+       np.method                                                  AS preferred_method,
+       nt.digest                                                  AS digest
+FROM notification_templates nt
+         CROSS JOIN users u
+         LEFT JOIN notification_preferences np ON np.notification_template_id = nt.id AND np.user_id = u.id
 WHERE nt.id = $1
   AND u.id = $2
 `
@@ -3560,12 +3844,16 @@ type FetchNewMessageMetadataParams struct {
 }
 
 type FetchNewMessageMetadataRow struct {
-	NotificationName string    `db:"notification_name" json:"notification_name"`
-	Actions          []byte    `db:"actions" json:"actions"`
-	UserID           uuid.UUID `db:"user_id" json:"user_id"`
-	UserEmail        string    `db:"user_email" json:"user_email"`
-	UserName         string    `db:"user_name" json:"user_name"`
-	UserUsername     string    `db:"user_username" json:"user_username"`
+	NotificationName string                 `db:"notification_name" json:"notification_name"`
+	Actions          []byte                 `db:"actions" json:"actions"`
+	UserID           uuid.UUID              `db:"user_id" json:"user_id"`
+	UserEmail        string                 `db:"user_email" json:"user_email"`
+	UserName         string                 `db:"user_name" json:"user_name"`
+	UserUsername     string                 `db:"user_username" json:"user_username"`
+	Mandatory        bool                   `db:"mandatory" json:"mandatory"`
+	Disabled         bool                   `db:"disabled" json:"disabled"`
+	PreferredMethod  NullNotificationMethod `db:"preferred_method" json:"preferred_method"`
+	Digest           bool                   `db:"digest" json:"digest"`
 }
 
 // This is used to build up the notification_message's JSON payload.
@@ -3579,12 +3867,99 @@ func (q *sqlQuerier) FetchNewMessageMetadata(ctx context.Context, arg FetchNewMe
 		&i.UserEmail,
 		&i.UserName,
 		&i.UserUsername,
+		&i.Mandatory,
+		&i.Disabled,
+		&i.PreferredMethod,
+		&i.Digest,
+	)
+	return i, err
+}
+
+const getInboxNotificationByID = `-- name: GetInboxNotificationByID :one
+SELECT id, user_id, title, content, actions, created_at, read_at
+FROM inbox_notifications
+WHERE id = $1
+`
+
+func (q *sqlQuerier) GetInboxNotificationByID(ctx context.Context, id uuid.UUID) (InboxNotification, error) {
+	row := q.db.QueryRowContext(ctx, getInboxNotificationByID, id)
+	var i InboxNotification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Content,
+		&i.Actions,
+		&i.CreatedAt,
+		&i.ReadAt,
 	)
 	return i, err
 }
 
+const getInboxNotificationsByUserID = `-- name: GetInboxNotificationsByUserID :many
+SELECT id, user_id, title, content, actions, created_at, read_at
+FROM inbox_notifications
+WHERE user_id = $1
+  AND CASE
+          WHEN $2::boolean THEN read_at IS NULL
+          ELSE true
+    END
+  -- Filter by created_at to page through older notifications.
+  AND CASE
+          WHEN $3::timestamptz != '0001-01-01 00:00:00Z' THEN created_at < $3
+          ELSE true
+    END
+ORDER BY created_at DESC
+-- A limit of 0 means "no limit".
+LIMIT
+    NULLIF($4 :: int, 0)
+`
+
+type GetInboxNotificationsByUserIDParams struct {
+	UserID        uuid.UUID `db:"user_id" json:"user_id"`
+	UnreadOnly    bool      `db:"unread_only" json:"unread_only"`
+	CreatedBefore time.Time `db:"created_before" json:"created_before"`
+	LimitOpt      int32     `db:"limit_opt" json:"limit_opt"`
+}
+
+func (q *sqlQuerier) GetInboxNotificationsByUserID(ctx context.Context, arg GetInboxNotificationsByUserIDParams) ([]InboxNotification, error) {
+	rows, err := q.db.QueryContext(ctx, getInboxNotificationsByUserID,
+		arg.UserID,
+		arg.UnreadOnly,
+		arg.CreatedBefore,
+		arg.LimitOpt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InboxNotification
+	for rows.Next() {
+		var i InboxNotification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Content,
+			&i.Actions,
+			&i.CreatedAt,
+			&i.ReadAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getNotificationMessagesByStatus = `-- name: GetNotificationMessagesByStatus :many
-SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds FROM notification_messages WHERE status = $1 LIMIT $2::int
+SELECT id, notification_template_id, user_id, method, status, status_reason, created_by, payload, attempt_count, targets, created_at, updated_at, leased_until, next_retry_after, queued_seconds, dedupe_hash FROM notification_messages WHERE status = $1 LIMIT $2::int
 `
 
 type GetNotificationMessagesByStatusParams struct {
@@ -3617,6 +3992,7 @@ func (q *sqlQuerier) GetNotificationMessagesByStatus(ctx context.Context, arg Ge
 			&i.LeasedUntil,
 			&i.NextRetryAfter,
 			&i.QueuedSeconds,
+			&i.DedupeHash,
 		); err != nil {
 			return nil, err
 		}
@@ -3631,56 +4007,328 @@ func (q *sqlQuerier) GetNotificationMessagesByStatus(ctx context.Context, arg Ge
 	return items, nil
 }
 
-const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
-DELETE FROM oauth2_provider_apps WHERE id = $1
+const getNotificationTemplateByID = `-- name: GetNotificationTemplateByID :one
+SELECT id, name, title_template, body_template, actions, "group", mandatory, digest, title_template_override, body_template_override, actions_override
+FROM notification_templates
+WHERE id = $1::uuid
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
-	return err
+func (q *sqlQuerier) GetNotificationTemplateByID(ctx context.Context, id uuid.UUID) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, getNotificationTemplateByID, id)
+	var i NotificationTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.Mandatory,
+		&i.Digest,
+		&i.TitleTemplateOverride,
+		&i.BodyTemplateOverride,
+		&i.ActionsOverride,
+	)
+	return i, err
 }
 
-const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
-DELETE FROM oauth2_provider_app_codes WHERE id = $1
+const getNotificationTemplates = `-- name: GetNotificationTemplates :many
+SELECT id, name, title_template, body_template, actions, "group", mandatory, digest, title_template_override, body_template_override, actions_override
+FROM notification_templates
+ORDER BY "group", name
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
+func (q *sqlQuerier) GetNotificationTemplates(ctx context.Context) ([]NotificationTemplate, error) {
+	rows, err := q.db.QueryContext(ctx, getNotificationTemplates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationTemplate
+	for rows.Next() {
+		var i NotificationTemplate
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.TitleTemplate,
+			&i.BodyTemplate,
+			&i.Actions,
+			&i.Group,
+			&i.Mandatory,
+			&i.Digest,
+			&i.TitleTemplateOverride,
+			&i.BodyTemplateOverride,
+			&i.ActionsOverride,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserNotificationPreferences = `-- name: GetUserNotificationPreferences :many
+SELECT user_id, notification_template_id, disabled, method, created_at, updated_at
+FROM notification_preferences
+WHERE user_id = $1::uuid
+`
+
+func (q *sqlQuerier) GetUserNotificationPreferences(ctx context.Context, userID uuid.UUID) ([]NotificationPreference, error) {
+	rows, err := q.db.QueryContext(ctx, getUserNotificationPreferences, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NotificationPreference
+	for rows.Next() {
+		var i NotificationPreference
+		if err := rows.Scan(
+			&i.UserID,
+			&i.NotificationTemplateID,
+			&i.Disabled,
+			&i.Method,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertInboxNotification = `-- name: InsertInboxNotification :exec
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (id) DO NOTHING
+`
+
+type InsertInboxNotificationParams struct {
+	ID        uuid.UUID       `db:"id" json:"id"`
+	UserID    uuid.UUID       `db:"user_id" json:"user_id"`
+	Title     string          `db:"title" json:"title"`
+	Content   string          `db:"content" json:"content"`
+	Actions   json.RawMessage `db:"actions" json:"actions"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
+
+// The ID of an inbox notification is the ID of the notification message it was delivered from, so that retried
+// deliveries of the same message do not produce duplicates.
+func (q *sqlQuerier) InsertInboxNotification(ctx context.Context, arg InsertInboxNotificationParams) error {
+	_, err := q.db.ExecContext(ctx, insertInboxNotification,
+		arg.ID,
+		arg.UserID,
+		arg.Title,
+		arg.Content,
+		arg.Actions,
+		arg.CreatedAt,
+	)
 	return err
 }
 
-const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
-DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
+const markAllInboxNotificationsAsRead = `-- name: MarkAllInboxNotificationsAsRead :exec
+UPDATE inbox_notifications
+SET read_at = $1::timestamptz
+WHERE user_id = $2
+  AND read_at IS NULL
 `
 
-type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
-	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+type MarkAllInboxNotificationsAsReadParams struct {
+	ReadAt time.Time `db:"read_at" json:"read_at"`
 	UserID uuid.UUID `db:"user_id" json:"user_id"`
 }
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
+func (q *sqlQuerier) MarkAllInboxNotificationsAsRead(ctx context.Context, arg MarkAllInboxNotificationsAsReadParams) error {
+	_, err := q.db.ExecContext(ctx, markAllInboxNotificationsAsRead, arg.ReadAt, arg.UserID)
 	return err
 }
 
-const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
-DELETE FROM oauth2_provider_app_secrets WHERE id = $1
+const updateInboxNotificationReadStatus = `-- name: UpdateInboxNotificationReadStatus :exec
+UPDATE inbox_notifications
+SET read_at = $1
+WHERE id = $2
 `
 
-func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
-	return err
+type UpdateInboxNotificationReadStatusParams struct {
+	ReadAt sql.NullTime `db:"read_at" json:"read_at"`
+	ID     uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateInboxNotificationReadStatus(ctx context.Context, arg UpdateInboxNotificationReadStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateInboxNotificationReadStatus, arg.ReadAt, arg.ID)
+	return err
+}
+
+const updateNotificationTemplateMandatoryByID = `-- name: UpdateNotificationTemplateMandatoryByID :one
+UPDATE notification_templates
+SET mandatory = $1::boolean
+WHERE id = $2::uuid
+RETURNING id, name, title_template, body_template, actions, "group", mandatory, digest, title_template_override, body_template_override, actions_override
+`
+
+type UpdateNotificationTemplateMandatoryByIDParams struct {
+	Mandatory bool      `db:"mandatory" json:"mandatory"`
+	ID        uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateNotificationTemplateMandatoryByID(ctx context.Context, arg UpdateNotificationTemplateMandatoryByIDParams) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTemplateMandatoryByID, arg.Mandatory, arg.ID)
+	var i NotificationTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.Mandatory,
+		&i.Digest,
+		&i.TitleTemplateOverride,
+		&i.BodyTemplateOverride,
+		&i.ActionsOverride,
+	)
+	return i, err
+}
+
+const updateNotificationTemplateOverrideByID = `-- name: UpdateNotificationTemplateOverrideByID :one
+UPDATE notification_templates
+SET title_template_override = $1,
+    body_template_override  = $2,
+    actions_override        = $3
+WHERE id = $4::uuid
+RETURNING id, name, title_template, body_template, actions, "group", mandatory, digest, title_template_override, body_template_override, actions_override
+`
+
+type UpdateNotificationTemplateOverrideByIDParams struct {
+	TitleTemplateOverride sql.NullString `db:"title_template_override" json:"title_template_override"`
+	BodyTemplateOverride  sql.NullString `db:"body_template_override" json:"body_template_override"`
+	ActionsOverride       []byte         `db:"actions_override" json:"actions_override"`
+	ID                    uuid.UUID      `db:"id" json:"id"`
+}
+
+// Sets or, when NULL, clears the administrator's overrides of a template's title, body, and actions.
+func (q *sqlQuerier) UpdateNotificationTemplateOverrideByID(ctx context.Context, arg UpdateNotificationTemplateOverrideByIDParams) (NotificationTemplate, error) {
+	row := q.db.QueryRowContext(ctx, updateNotificationTemplateOverrideByID,
+		arg.TitleTemplateOverride,
+		arg.BodyTemplateOverride,
+		arg.ActionsOverride,
+		arg.ID,
+	)
+	var i NotificationTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.Actions,
+		&i.Group,
+		&i.Mandatory,
+		&i.Digest,
+		&i.TitleTemplateOverride,
+		&i.BodyTemplateOverride,
+		&i.ActionsOverride,
+	)
+	return i, err
+}
+
+const upsertUserNotificationPreference = `-- name: UpsertUserNotificationPreference :one
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method, created_at, updated_at)
+VALUES ($1::uuid, $2::uuid, $3::boolean, $4::notification_method,
+        NOW(), NOW())
+ON CONFLICT (user_id, notification_template_id) DO UPDATE
+    SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
+        updated_at = NOW()
+RETURNING user_id, notification_template_id, disabled, method, created_at, updated_at
+`
+
+type UpsertUserNotificationPreferenceParams struct {
+	UserID                 uuid.UUID              `db:"user_id" json:"user_id"`
+	NotificationTemplateID uuid.UUID              `db:"notification_template_id" json:"notification_template_id"`
+	Disabled               bool                   `db:"disabled" json:"disabled"`
+	Method                 NullNotificationMethod `db:"method" json:"method"`
+}
+
+func (q *sqlQuerier) UpsertUserNotificationPreference(ctx context.Context, arg UpsertUserNotificationPreferenceParams) (NotificationPreference, error) {
+	row := q.db.QueryRowContext(ctx, upsertUserNotificationPreference,
+		arg.UserID,
+		arg.NotificationTemplateID,
+		arg.Disabled,
+		arg.Method,
+	)
+	var i NotificationPreference
+	err := row.Scan(
+		&i.UserID,
+		&i.NotificationTemplateID,
+		&i.Disabled,
+		&i.Method,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteOAuth2ProviderAppByID = `-- name: DeleteOAuth2ProviderAppByID :exec
+DELETE FROM oauth2_provider_apps WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodeByID = `-- name: DeleteOAuth2ProviderAppCodeByID :exec
+DELETE FROM oauth2_provider_app_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodeByID, id)
+	return err
+}
+
+const deleteOAuth2ProviderAppCodesByAppAndUserID = `-- name: DeleteOAuth2ProviderAppCodesByAppAndUserID :exec
+DELETE FROM oauth2_provider_app_codes WHERE app_id = $1 AND user_id = $2
+`
+
+type DeleteOAuth2ProviderAppCodesByAppAndUserIDParams struct {
+	AppID  uuid.UUID `db:"app_id" json:"app_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppCodesByAppAndUserID(ctx context.Context, arg DeleteOAuth2ProviderAppCodesByAppAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppCodesByAppAndUserID, arg.AppID, arg.UserID)
+	return err
+}
+
+const deleteOAuth2ProviderAppSecretByID = `-- name: DeleteOAuth2ProviderAppSecretByID :exec
+DELETE FROM oauth2_provider_app_secrets WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderAppSecretByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderAppSecretByID, id)
+	return err
 }
 
 const deleteOAuth2ProviderAppTokensByAppAndUserID = `-- name: DeleteOAuth2ProviderAppTokensByAppAndUserID :exec
 DELETE FROM
   oauth2_provider_app_tokens
 USING
-  oauth2_provider_app_secrets, api_keys
+  api_keys
 WHERE
-  oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
-  AND api_keys.id = oauth2_provider_app_tokens.api_key_id
-  AND oauth2_provider_app_secrets.app_id = $1
+  api_keys.id = oauth2_provider_app_tokens.api_key_id
+  AND oauth2_provider_app_tokens.app_id = $1
 	AND api_keys.user_id = $2
 `
 
@@ -3694,8 +4342,17 @@ func (q *sqlQuerier) DeleteOAuth2ProviderAppTokensByAppAndUserID(ctx context.Con
 	return err
 }
 
+const deleteOAuth2ProviderDeviceCodeByID = `-- name: DeleteOAuth2ProviderDeviceCodeByID :exec
+DELETE FROM oauth2_provider_device_codes WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteOAuth2ProviderDeviceCodeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteOAuth2ProviderDeviceCodeByID, id)
+	return err
+}
+
 const getOAuth2ProviderAppByID = `-- name: GetOAuth2ProviderAppByID :one
-SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps WHERE id = $1
+SELECT id, created_at, updated_at, name, icon, callback_url, client_type, grant_types FROM oauth2_provider_apps WHERE id = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderApp, error) {
@@ -3708,12 +4365,14 @@ func (q *sqlQuerier) GetOAuth2ProviderAppByID(ctx context.Context, id uuid.UUID)
 		&i.Name,
 		&i.Icon,
 		&i.CallbackURL,
+		&i.ClientType,
+		pq.Array(&i.GrantTypes),
 	)
 	return i, err
 }
 
 const getOAuth2ProviderAppCodeByID = `-- name: GetOAuth2ProviderAppCodeByID :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE id = $1
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method, scopes FROM oauth2_provider_app_codes WHERE id = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.UUID) (OAuth2ProviderAppCode, error) {
@@ -3727,12 +4386,15 @@ func (q *sqlQuerier) GetOAuth2ProviderAppCodeByID(ctx context.Context, id uuid.U
 		&i.HashedSecret,
 		&i.UserID,
 		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
+		pq.Array(&i.Scopes),
 	)
 	return i, err
 }
 
 const getOAuth2ProviderAppCodeByPrefix = `-- name: GetOAuth2ProviderAppCodeByPrefix :one
-SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id FROM oauth2_provider_app_codes WHERE secret_prefix = $1
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method, scopes FROM oauth2_provider_app_codes WHERE secret_prefix = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderAppCode, error) {
@@ -3746,6 +4408,9 @@ func (q *sqlQuerier) GetOAuth2ProviderAppCodeByPrefix(ctx context.Context, secre
 		&i.HashedSecret,
 		&i.UserID,
 		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
+		pq.Array(&i.Scopes),
 	)
 	return i, err
 }
@@ -3824,7 +4489,7 @@ func (q *sqlQuerier) GetOAuth2ProviderAppSecretsByAppID(ctx context.Context, app
 }
 
 const getOAuth2ProviderAppTokenByPrefix = `-- name: GetOAuth2ProviderAppTokenByPrefix :one
-SELECT id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id FROM oauth2_provider_app_tokens WHERE hash_prefix = $1
+SELECT id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id, app_id FROM oauth2_provider_app_tokens WHERE hash_prefix = $1
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hashPrefix []byte) (OAuth2ProviderAppToken, error) {
@@ -3838,12 +4503,13 @@ func (q *sqlQuerier) GetOAuth2ProviderAppTokenByPrefix(ctx context.Context, hash
 		&i.RefreshHash,
 		&i.AppSecretID,
 		&i.APIKeyID,
+		&i.AppID,
 	)
 	return i, err
 }
 
 const getOAuth2ProviderApps = `-- name: GetOAuth2ProviderApps :many
-SELECT id, created_at, updated_at, name, icon, callback_url FROM oauth2_provider_apps ORDER BY (name, id) ASC
+SELECT id, created_at, updated_at, name, icon, callback_url, client_type, grant_types FROM oauth2_provider_apps ORDER BY (name, id) ASC
 `
 
 func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2ProviderApp, error) {
@@ -3862,6 +4528,8 @@ func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2Provide
 			&i.Name,
 			&i.Icon,
 			&i.CallbackURL,
+			&i.ClientType,
+			pq.Array(&i.GrantTypes),
 		); err != nil {
 			return nil, err
 		}
@@ -3879,12 +4547,10 @@ func (q *sqlQuerier) GetOAuth2ProviderApps(ctx context.Context) ([]OAuth2Provide
 const getOAuth2ProviderAppsByUserID = `-- name: GetOAuth2ProviderAppsByUserID :many
 SELECT
   COUNT(DISTINCT oauth2_provider_app_tokens.id) as token_count,
-  oauth2_provider_apps.id, oauth2_provider_apps.created_at, oauth2_provider_apps.updated_at, oauth2_provider_apps.name, oauth2_provider_apps.icon, oauth2_provider_apps.callback_url
+  oauth2_provider_apps.id, oauth2_provider_apps.created_at, oauth2_provider_apps.updated_at, oauth2_provider_apps.name, oauth2_provider_apps.icon, oauth2_provider_apps.callback_url, oauth2_provider_apps.client_type, oauth2_provider_apps.grant_types
 FROM oauth2_provider_app_tokens
-  INNER JOIN oauth2_provider_app_secrets
-    ON oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
   INNER JOIN oauth2_provider_apps
-    ON oauth2_provider_apps.id = oauth2_provider_app_secrets.app_id
+    ON oauth2_provider_apps.id = oauth2_provider_app_tokens.app_id
   INNER JOIN api_keys
     ON api_keys.id = oauth2_provider_app_tokens.api_key_id
 WHERE
@@ -3915,6 +4581,8 @@ func (q *sqlQuerier) GetOAuth2ProviderAppsByUserID(ctx context.Context, userID u
 			&i.OAuth2ProviderApp.Name,
 			&i.OAuth2ProviderApp.Icon,
 			&i.OAuth2ProviderApp.CallbackURL,
+			&i.OAuth2ProviderApp.ClientType,
+			pq.Array(&i.OAuth2ProviderApp.GrantTypes),
 		); err != nil {
 			return nil, err
 		}
@@ -3929,6 +4597,52 @@ func (q *sqlQuerier) GetOAuth2ProviderAppsByUserID(ctx context.Context, userID u
 	return items, nil
 }
 
+const getOAuth2ProviderDeviceCodeByPrefix = `-- name: GetOAuth2ProviderDeviceCodeByPrefix :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, scopes, status, user_id, last_polled_at FROM oauth2_provider_device_codes WHERE secret_prefix = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderDeviceCodeByPrefix(ctx context.Context, secretPrefix []byte) (OAuth2ProviderDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderDeviceCodeByPrefix, secretPrefix)
+	var i OAuth2ProviderDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		pq.Array(&i.Scopes),
+		&i.Status,
+		&i.UserID,
+		&i.LastPolledAt,
+	)
+	return i, err
+}
+
+const getOAuth2ProviderDeviceCodeByUserCode = `-- name: GetOAuth2ProviderDeviceCodeByUserCode :one
+SELECT id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, scopes, status, user_id, last_polled_at FROM oauth2_provider_device_codes WHERE user_code = $1
+`
+
+func (q *sqlQuerier) GetOAuth2ProviderDeviceCodeByUserCode(ctx context.Context, userCode string) (OAuth2ProviderDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, getOAuth2ProviderDeviceCodeByUserCode, userCode)
+	var i OAuth2ProviderDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		pq.Array(&i.Scopes),
+		&i.Status,
+		&i.UserID,
+		&i.LastPolledAt,
+	)
+	return i, err
+}
+
 const insertOAuth2ProviderApp = `-- name: InsertOAuth2ProviderApp :one
 INSERT INTO oauth2_provider_apps (
     id,
@@ -3936,24 +4650,30 @@ INSERT INTO oauth2_provider_apps (
     updated_at,
     name,
     icon,
-    callback_url
+    callback_url,
+    client_type,
+    grant_types
 ) VALUES(
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
-) RETURNING id, created_at, updated_at, name, icon, callback_url
+    $6,
+    $7,
+    $8
+) RETURNING id, created_at, updated_at, name, icon, callback_url, client_type, grant_types
 `
 
 type InsertOAuth2ProviderAppParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
-	Name        string    `db:"name" json:"name"`
-	Icon        string    `db:"icon" json:"icon"`
-	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	ID          uuid.UUID                   `db:"id" json:"id"`
+	CreatedAt   time.Time                   `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time                   `db:"updated_at" json:"updated_at"`
+	Name        string                      `db:"name" json:"name"`
+	Icon        string                      `db:"icon" json:"icon"`
+	CallbackURL string                      `db:"callback_url" json:"callback_url"`
+	ClientType  OAuth2ProviderAppClientType `db:"client_type" json:"client_type"`
+	GrantTypes  []string                    `db:"grant_types" json:"grant_types"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAuth2ProviderAppParams) (OAuth2ProviderApp, error) {
@@ -3964,6 +4684,8 @@ func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAut
 		arg.Name,
 		arg.Icon,
 		arg.CallbackURL,
+		arg.ClientType,
+		pq.Array(arg.GrantTypes),
 	)
 	var i OAuth2ProviderApp
 	err := row.Scan(
@@ -3973,6 +4695,8 @@ func (q *sqlQuerier) InsertOAuth2ProviderApp(ctx context.Context, arg InsertOAut
 		&i.Name,
 		&i.Icon,
 		&i.CallbackURL,
+		&i.ClientType,
+		pq.Array(&i.GrantTypes),
 	)
 	return i, err
 }
@@ -3985,7 +4709,10 @@ INSERT INTO oauth2_provider_app_codes (
     secret_prefix,
     hashed_secret,
     app_id,
-    user_id
+    user_id,
+    code_challenge,
+    code_challenge_method,
+    scopes
 ) VALUES(
     $1,
     $2,
@@ -3993,18 +4720,24 @@ INSERT INTO oauth2_provider_app_codes (
     $4,
     $5,
     $6,
-    $7
-) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id
+    $7,
+    $8,
+    $9,
+    $10
+) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_id, app_id, code_challenge, code_challenge_method, scopes
 `
 
 type InsertOAuth2ProviderAppCodeParams struct {
-	ID           uuid.UUID `db:"id" json:"id"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
-	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
-	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
-	AppID        uuid.UUID `db:"app_id" json:"app_id"`
-	UserID       uuid.UUID `db:"user_id" json:"user_id"`
+	ID                  uuid.UUID `db:"id" json:"id"`
+	CreatedAt           time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt           time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix        []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret        []byte    `db:"hashed_secret" json:"hashed_secret"`
+	AppID               uuid.UUID `db:"app_id" json:"app_id"`
+	UserID              uuid.UUID `db:"user_id" json:"user_id"`
+	CodeChallenge       string    `db:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string    `db:"code_challenge_method" json:"code_challenge_method"`
+	Scopes              []string  `db:"scopes" json:"scopes"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg InsertOAuth2ProviderAppCodeParams) (OAuth2ProviderAppCode, error) {
@@ -4016,6 +4749,9 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg Insert
 		arg.HashedSecret,
 		arg.AppID,
 		arg.UserID,
+		arg.CodeChallenge,
+		arg.CodeChallengeMethod,
+		pq.Array(arg.Scopes),
 	)
 	var i OAuth2ProviderAppCode
 	err := row.Scan(
@@ -4026,6 +4762,9 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppCode(ctx context.Context, arg Insert
 		&i.HashedSecret,
 		&i.UserID,
 		&i.AppID,
+		&i.CodeChallenge,
+		&i.CodeChallengeMethod,
+		pq.Array(&i.Scopes),
 	)
 	return i, err
 }
@@ -4087,7 +4826,8 @@ INSERT INTO oauth2_provider_app_tokens (
     hash_prefix,
     refresh_hash,
     app_secret_id,
-    api_key_id
+    api_key_id,
+    app_id
 ) VALUES(
     $1,
     $2,
@@ -4095,18 +4835,20 @@ INSERT INTO oauth2_provider_app_tokens (
     $4,
     $5,
     $6,
-    $7
-) RETURNING id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id
+    $7,
+    $8
+) RETURNING id, created_at, expires_at, hash_prefix, refresh_hash, app_secret_id, api_key_id, app_id
 `
 
 type InsertOAuth2ProviderAppTokenParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	ExpiresAt   time.Time `db:"expires_at" json:"expires_at"`
-	HashPrefix  []byte    `db:"hash_prefix" json:"hash_prefix"`
-	RefreshHash []byte    `db:"refresh_hash" json:"refresh_hash"`
-	AppSecretID uuid.UUID `db:"app_secret_id" json:"app_secret_id"`
-	APIKeyID    string    `db:"api_key_id" json:"api_key_id"`
+	ID          uuid.UUID     `db:"id" json:"id"`
+	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
+	ExpiresAt   time.Time     `db:"expires_at" json:"expires_at"`
+	HashPrefix  []byte        `db:"hash_prefix" json:"hash_prefix"`
+	RefreshHash []byte        `db:"refresh_hash" json:"refresh_hash"`
+	AppSecretID uuid.NullUUID `db:"app_secret_id" json:"app_secret_id"`
+	APIKeyID    string        `db:"api_key_id" json:"api_key_id"`
+	AppID       uuid.UUID     `db:"app_id" json:"app_id"`
 }
 
 func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg InsertOAuth2ProviderAppTokenParams) (OAuth2ProviderAppToken, error) {
@@ -4118,6 +4860,7 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg Inser
 		arg.RefreshHash,
 		arg.AppSecretID,
 		arg.APIKeyID,
+		arg.AppID,
 	)
 	var i OAuth2ProviderAppToken
 	err := row.Scan(
@@ -4128,6 +4871,68 @@ func (q *sqlQuerier) InsertOAuth2ProviderAppToken(ctx context.Context, arg Inser
 		&i.RefreshHash,
 		&i.AppSecretID,
 		&i.APIKeyID,
+		&i.AppID,
+	)
+	return i, err
+}
+
+const insertOAuth2ProviderDeviceCode = `-- name: InsertOAuth2ProviderDeviceCode :one
+INSERT INTO oauth2_provider_device_codes (
+    id,
+    created_at,
+    expires_at,
+    secret_prefix,
+    hashed_secret,
+    user_code,
+    app_id,
+    scopes
+) VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, scopes, status, user_id, last_polled_at
+`
+
+type InsertOAuth2ProviderDeviceCodeParams struct {
+	ID           uuid.UUID `db:"id" json:"id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at" json:"expires_at"`
+	SecretPrefix []byte    `db:"secret_prefix" json:"secret_prefix"`
+	HashedSecret []byte    `db:"hashed_secret" json:"hashed_secret"`
+	UserCode     string    `db:"user_code" json:"user_code"`
+	AppID        uuid.UUID `db:"app_id" json:"app_id"`
+	Scopes       []string  `db:"scopes" json:"scopes"`
+}
+
+func (q *sqlQuerier) InsertOAuth2ProviderDeviceCode(ctx context.Context, arg InsertOAuth2ProviderDeviceCodeParams) (OAuth2ProviderDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, insertOAuth2ProviderDeviceCode,
+		arg.ID,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+		arg.SecretPrefix,
+		arg.HashedSecret,
+		arg.UserCode,
+		arg.AppID,
+		pq.Array(arg.Scopes),
+	)
+	var i OAuth2ProviderDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		pq.Array(&i.Scopes),
+		&i.Status,
+		&i.UserID,
+		&i.LastPolledAt,
 	)
 	return i, err
 }
@@ -4137,16 +4942,18 @@ UPDATE oauth2_provider_apps SET
     updated_at = $2,
     name = $3,
     icon = $4,
-    callback_url = $5
-WHERE id = $1 RETURNING id, created_at, updated_at, name, icon, callback_url
+    callback_url = $5,
+    client_type = $6
+WHERE id = $1 RETURNING id, created_at, updated_at, name, icon, callback_url, client_type, grant_types
 `
 
 type UpdateOAuth2ProviderAppByIDParams struct {
-	ID          uuid.UUID `db:"id" json:"id"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
-	Name        string    `db:"name" json:"name"`
-	Icon        string    `db:"icon" json:"icon"`
-	CallbackURL string    `db:"callback_url" json:"callback_url"`
+	ID          uuid.UUID                   `db:"id" json:"id"`
+	UpdatedAt   time.Time                   `db:"updated_at" json:"updated_at"`
+	Name        string                      `db:"name" json:"name"`
+	Icon        string                      `db:"icon" json:"icon"`
+	CallbackURL string                      `db:"callback_url" json:"callback_url"`
+	ClientType  OAuth2ProviderAppClientType `db:"client_type" json:"client_type"`
 }
 
 func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg UpdateOAuth2ProviderAppByIDParams) (OAuth2ProviderApp, error) {
@@ -4156,6 +4963,7 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg Update
 		arg.Name,
 		arg.Icon,
 		arg.CallbackURL,
+		arg.ClientType,
 	)
 	var i OAuth2ProviderApp
 	err := row.Scan(
@@ -4165,6 +4973,8 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppByID(ctx context.Context, arg Update
 		&i.Name,
 		&i.Icon,
 		&i.CallbackURL,
+		&i.ClientType,
+		pq.Array(&i.GrantTypes),
 	)
 	return i, err
 }
@@ -4195,6 +5005,56 @@ func (q *sqlQuerier) UpdateOAuth2ProviderAppSecretByID(ctx context.Context, arg
 	return i, err
 }
 
+const updateOAuth2ProviderDeviceCodeLastPolledAt = `-- name: UpdateOAuth2ProviderDeviceCodeLastPolledAt :exec
+UPDATE oauth2_provider_device_codes SET
+    last_polled_at = $2
+WHERE id = $1
+`
+
+type UpdateOAuth2ProviderDeviceCodeLastPolledAtParams struct {
+	ID           uuid.UUID    `db:"id" json:"id"`
+	LastPolledAt sql.NullTime `db:"last_polled_at" json:"last_polled_at"`
+}
+
+func (q *sqlQuerier) UpdateOAuth2ProviderDeviceCodeLastPolledAt(ctx context.Context, arg UpdateOAuth2ProviderDeviceCodeLastPolledAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateOAuth2ProviderDeviceCodeLastPolledAt, arg.ID, arg.LastPolledAt)
+	return err
+}
+
+const updateOAuth2ProviderDeviceCodeStatus = `-- name: UpdateOAuth2ProviderDeviceCodeStatus :one
+UPDATE oauth2_provider_device_codes SET
+    status = $2,
+    user_id = $3
+WHERE id = $1 AND status = 'pending' RETURNING id, created_at, expires_at, secret_prefix, hashed_secret, user_code, app_id, scopes, status, user_id, last_polled_at
+`
+
+type UpdateOAuth2ProviderDeviceCodeStatusParams struct {
+	ID     uuid.UUID                      `db:"id" json:"id"`
+	Status OAuth2ProviderDeviceCodeStatus `db:"status" json:"status"`
+	UserID uuid.NullUUID                  `db:"user_id" json:"user_id"`
+}
+
+// Approves or denies a pending device code. A code that was already approved
+// or denied cannot be changed.
+func (q *sqlQuerier) UpdateOAuth2ProviderDeviceCodeStatus(ctx context.Context, arg UpdateOAuth2ProviderDeviceCodeStatusParams) (OAuth2ProviderDeviceCode, error) {
+	row := q.db.QueryRowContext(ctx, updateOAuth2ProviderDeviceCodeStatus, arg.ID, arg.Status, arg.UserID)
+	var i OAuth2ProviderDeviceCode
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+		&i.SecretPrefix,
+		&i.HashedSecret,
+		&i.UserCode,
+		&i.AppID,
+		pq.Array(&i.Scopes),
+		&i.Status,
+		&i.UserID,
+		&i.LastPolledAt,
+	)
+	return i, err
+}
+
 const deleteOrganizationMember = `-- name: DeleteOrganizationMember :exec
 DELETE
 	FROM
@@ -6065,6 +6925,15 @@ func (q *sqlQuerier) UpdateWorkspaceProxyDeleted(ctx context.Context, arg Update
 	return err
 }
 
+const deleteQuotaBudgetByID = `-- name: DeleteQuotaBudgetByID :exec
+DELETE FROM quota_budgets WHERE id = $1
+`
+
+func (q *sqlQuerier) DeleteQuotaBudgetByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteQuotaBudgetByID, id)
+	return err
+}
+
 const getQuotaAllowanceForUser = `-- name: GetQuotaAllowanceForUser :one
 SELECT
 	coalesce(SUM(quota_allowance), 0)::BIGINT
@@ -6085,36 +6954,390 @@ func (q *sqlQuerier) GetQuotaAllowanceForUser(ctx context.Context, userID uuid.U
 	return column_1, err
 }
 
-const getQuotaConsumedForUser = `-- name: GetQuotaConsumedForUser :one
-WITH latest_builds AS (
-SELECT
-	DISTINCT ON
-	(workspace_id) id,
-	workspace_id,
-	daily_cost
-FROM
-	workspace_builds wb
-ORDER BY
-	workspace_id,
-	created_at DESC
-)
-SELECT
-	coalesce(SUM(daily_cost), 0)::BIGINT
-FROM
-	workspaces
-JOIN latest_builds ON
-	latest_builds.workspace_id = workspaces.id
-WHERE NOT deleted AND workspaces.owner_id = $1
+const getQuotaBudgetByID = `-- name: GetQuotaBudgetByID :one
+SELECT id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at FROM quota_budgets WHERE id = $1
 `
 
-func (q *sqlQuerier) GetQuotaConsumedForUser(ctx context.Context, ownerID uuid.UUID) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getQuotaConsumedForUser, ownerID)
-	var column_1 int64
-	err := row.Scan(&column_1)
-	return column_1, err
-}
-
-const deleteReplicasUpdatedBefore = `-- name: DeleteReplicasUpdatedBefore :exec
+func (q *sqlQuerier) GetQuotaBudgetByID(ctx context.Context, id uuid.UUID) (QuotaBudget, error) {
+	row := q.db.QueryRowContext(ctx, getQuotaBudgetByID, id)
+	var i QuotaBudget
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.UserID,
+		&i.Period,
+		&i.SoftLimit,
+		&i.HardLimit,
+		&i.StopWorkspaces,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getQuotaBudgets = `-- name: GetQuotaBudgets :many
+SELECT id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at FROM quota_budgets ORDER BY created_at ASC
+`
+
+func (q *sqlQuerier) GetQuotaBudgets(ctx context.Context) ([]QuotaBudget, error) {
+	rows, err := q.db.QueryContext(ctx, getQuotaBudgets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuotaBudget
+	for rows.Next() {
+		var i QuotaBudget
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.GroupID,
+			&i.UserID,
+			&i.Period,
+			&i.SoftLimit,
+			&i.HardLimit,
+			&i.StopWorkspaces,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuotaBudgetsByOrganizationID = `-- name: GetQuotaBudgetsByOrganizationID :many
+SELECT id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at FROM quota_budgets WHERE organization_id = $1 ORDER BY created_at ASC
+`
+
+func (q *sqlQuerier) GetQuotaBudgetsByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]QuotaBudget, error) {
+	rows, err := q.db.QueryContext(ctx, getQuotaBudgetsByOrganizationID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuotaBudget
+	for rows.Next() {
+		var i QuotaBudget
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.GroupID,
+			&i.UserID,
+			&i.Period,
+			&i.SoftLimit,
+			&i.HardLimit,
+			&i.StopWorkspaces,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuotaBudgetsForUser = `-- name: GetQuotaBudgetsForUser :many
+SELECT
+	id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at
+FROM
+	quota_budgets
+WHERE
+	organization_id = $1
+	AND (
+		user_id = $2
+		OR group_id = organization_id
+		OR group_id IN (
+			SELECT group_id FROM group_members WHERE group_members.user_id = $2
+		)
+	)
+ORDER BY
+	created_at ASC
+`
+
+type GetQuotaBudgetsForUserParams struct {
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	UserID         uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+// GetQuotaBudgetsForUser returns the budgets which apply to a user in an
+// organization: their own budgets and those of every group they are a member
+// of, including the "Everyone" group.
+func (q *sqlQuerier) GetQuotaBudgetsForUser(ctx context.Context, arg GetQuotaBudgetsForUserParams) ([]QuotaBudget, error) {
+	rows, err := q.db.QueryContext(ctx, getQuotaBudgetsForUser, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuotaBudget
+	for rows.Next() {
+		var i QuotaBudget
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.GroupID,
+			&i.UserID,
+			&i.Period,
+			&i.SoftLimit,
+			&i.HardLimit,
+			&i.StopWorkspaces,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQuotaConsumedForUser = `-- name: GetQuotaConsumedForUser :one
+WITH latest_builds AS (
+SELECT
+	DISTINCT ON
+	(workspace_id) id,
+	workspace_id,
+	daily_cost
+FROM
+	workspace_builds wb
+ORDER BY
+	workspace_id,
+	created_at DESC
+)
+SELECT
+	coalesce(SUM(daily_cost), 0)::BIGINT
+FROM
+	workspaces
+JOIN latest_builds ON
+	latest_builds.workspace_id = workspaces.id
+WHERE NOT deleted AND workspaces.owner_id = $1
+`
+
+func (q *sqlQuerier) GetQuotaConsumedForUser(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getQuotaConsumedForUser, ownerID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const getQuotaSpend = `-- name: GetQuotaSpend :one
+WITH builds AS (
+	SELECT
+		wb.daily_cost,
+		pj.completed_at AS started_at,
+		lead(pj.completed_at) OVER (PARTITION BY wb.workspace_id ORDER BY wb.build_number) AS ended_at
+	FROM
+		workspace_builds wb
+	JOIN provisioner_jobs pj ON
+		pj.id = wb.job_id
+	JOIN workspaces w ON
+		w.id = wb.workspace_id
+	WHERE
+		pj.job_status = 'succeeded'
+		AND w.organization_id = $1
+		AND w.owner_id = ANY($2 :: uuid[])
+)
+SELECT
+	coalesce(SUM(
+		daily_cost * EXTRACT(EPOCH FROM (
+			LEAST(coalesce(ended_at, $3 :: timestamptz), $3 :: timestamptz) -
+			GREATEST(started_at, $4 :: timestamptz)
+		)) / 86400
+	), 0)::BIGINT
+FROM
+	builds
+WHERE
+	daily_cost > 0
+	AND started_at < $3 :: timestamptz
+	AND coalesce(ended_at, $3 :: timestamptz) > $4 :: timestamptz
+`
+
+type GetQuotaSpendParams struct {
+	OrganizationID uuid.UUID   `db:"organization_id" json:"organization_id"`
+	OwnerIDs       []uuid.UUID `db:"owner_ids" json:"owner_ids"`
+	PeriodEnd      time.Time   `db:"period_end" json:"period_end"`
+	PeriodStart    time.Time   `db:"period_start" json:"period_start"`
+}
+
+// GetQuotaSpend returns the cost accrued by the workspaces of the given owners
+// in an organization between @period_start and @period_end. A workspace
+// accrues the daily cost of its latest successful build, prorated over the
+// time until the next successful build completed.
+func (q *sqlQuerier) GetQuotaSpend(ctx context.Context, arg GetQuotaSpendParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getQuotaSpend,
+		arg.OrganizationID,
+		pq.Array(arg.OwnerIDs),
+		arg.PeriodEnd,
+		arg.PeriodStart,
+	)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const insertQuotaBudget = `-- name: InsertQuotaBudget :one
+INSERT INTO quota_budgets (
+	id,
+	organization_id,
+	group_id,
+	user_id,
+	period,
+	soft_limit,
+	hard_limit,
+	stop_workspaces,
+	created_at,
+	updated_at
+)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at
+`
+
+type InsertQuotaBudgetParams struct {
+	ID             uuid.UUID         `db:"id" json:"id"`
+	OrganizationID uuid.UUID         `db:"organization_id" json:"organization_id"`
+	GroupID        uuid.NullUUID     `db:"group_id" json:"group_id"`
+	UserID         uuid.NullUUID     `db:"user_id" json:"user_id"`
+	Period         QuotaBudgetPeriod `db:"period" json:"period"`
+	SoftLimit      int64             `db:"soft_limit" json:"soft_limit"`
+	HardLimit      int64             `db:"hard_limit" json:"hard_limit"`
+	StopWorkspaces bool              `db:"stop_workspaces" json:"stop_workspaces"`
+	CreatedAt      time.Time         `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time         `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertQuotaBudget(ctx context.Context, arg InsertQuotaBudgetParams) (QuotaBudget, error) {
+	row := q.db.QueryRowContext(ctx, insertQuotaBudget,
+		arg.ID,
+		arg.OrganizationID,
+		arg.GroupID,
+		arg.UserID,
+		arg.Period,
+		arg.SoftLimit,
+		arg.HardLimit,
+		arg.StopWorkspaces,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i QuotaBudget
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.UserID,
+		&i.Period,
+		&i.SoftLimit,
+		&i.HardLimit,
+		&i.StopWorkspaces,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const insertQuotaBudgetAlert = `-- name: InsertQuotaBudgetAlert :execrows
+INSERT INTO quota_budget_alerts (
+	budget_id,
+	user_id,
+	period_start,
+	hard_limit,
+	created_at
+)
+VALUES
+	($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING
+`
+
+type InsertQuotaBudgetAlertParams struct {
+	BudgetID    uuid.UUID `db:"budget_id" json:"budget_id"`
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	PeriodStart time.Time `db:"period_start" json:"period_start"`
+	HardLimit   bool      `db:"hard_limit" json:"hard_limit"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+// InsertQuotaBudgetAlert records that a user was notified of a budget limit
+// in a period. It affects no rows if they have already been notified.
+func (q *sqlQuerier) InsertQuotaBudgetAlert(ctx context.Context, arg InsertQuotaBudgetAlertParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertQuotaBudgetAlert,
+		arg.BudgetID,
+		arg.UserID,
+		arg.PeriodStart,
+		arg.HardLimit,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateQuotaBudgetByID = `-- name: UpdateQuotaBudgetByID :one
+UPDATE
+	quota_budgets
+SET
+	soft_limit = $1,
+	hard_limit = $2,
+	stop_workspaces = $3,
+	updated_at = $4
+WHERE
+	id = $5
+RETURNING id, organization_id, group_id, user_id, period, soft_limit, hard_limit, stop_workspaces, created_at, updated_at
+`
+
+type UpdateQuotaBudgetByIDParams struct {
+	SoftLimit      int64     `db:"soft_limit" json:"soft_limit"`
+	HardLimit      int64     `db:"hard_limit" json:"hard_limit"`
+	StopWorkspaces bool      `db:"stop_workspaces" json:"stop_workspaces"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
+	ID             uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateQuotaBudgetByID(ctx context.Context, arg UpdateQuotaBudgetByIDParams) (QuotaBudget, error) {
+	row := q.db.QueryRowContext(ctx, updateQuotaBudgetByID,
+		arg.SoftLimit,
+		arg.HardLimit,
+		arg.StopWorkspaces,
+		arg.UpdatedAt,
+		arg.ID,
+	)
+	var i QuotaBudget
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.GroupID,
+		&i.UserID,
+		&i.Period,
+		&i.SoftLimit,
+		&i.HardLimit,
+		&i.StopWorkspaces,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteReplicasUpdatedBefore = `-- name: DeleteReplicasUpdatedBefore :exec
 DELETE FROM replicas WHERE updated_at < $1
 `
 
@@ -7404,9 +8627,396 @@ func (q *sqlQuerier) UpsertTailnetTunnel(ctx context.Context, arg UpsertTailnetT
 	row := q.db.QueryRowContext(ctx, upsertTailnetTunnel, arg.CoordinatorID, arg.SrcID, arg.DstID)
 	var i TailnetTunnel
 	err := row.Scan(
-		&i.CoordinatorID,
-		&i.SrcID,
-		&i.DstID,
+		&i.CoordinatorID,
+		&i.SrcID,
+		&i.DstID,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteTemplateGitSyncByTemplateID = `-- name: DeleteTemplateGitSyncByTemplateID :exec
+DELETE FROM
+	template_git_syncs
+WHERE
+	template_id = $1
+`
+
+func (q *sqlQuerier) DeleteTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteTemplateGitSyncByTemplateID, templateID)
+	return err
+}
+
+const getTemplateGitSyncByTemplateID = `-- name: GetTemplateGitSyncByTemplateID :one
+SELECT
+	template_id, repository_url, branch, directory, external_auth_provider_id, auto_activate, webhook_secret, created_by, last_commit_sha, last_template_version_id, last_synced_at, last_error, created_at, updated_at
+FROM
+	template_git_syncs
+WHERE
+	template_id = $1
+`
+
+func (q *sqlQuerier) GetTemplateGitSyncByTemplateID(ctx context.Context, templateID uuid.UUID) (TemplateGitSync, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateGitSyncByTemplateID, templateID)
+	var i TemplateGitSync
+	err := row.Scan(
+		&i.TemplateID,
+		&i.RepositoryURL,
+		&i.Branch,
+		&i.Directory,
+		&i.ExternalAuthProviderID,
+		&i.AutoActivate,
+		&i.WebhookSecret,
+		&i.CreatedBy,
+		&i.LastCommitSha,
+		&i.LastTemplateVersionID,
+		&i.LastSyncedAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplateGitSyncs = `-- name: GetTemplateGitSyncs :many
+SELECT
+	template_id, repository_url, branch, directory, external_auth_provider_id, auto_activate, webhook_secret, created_by, last_commit_sha, last_template_version_id, last_synced_at, last_error, created_at, updated_at
+FROM
+	template_git_syncs
+ORDER BY
+	created_at ASC
+`
+
+func (q *sqlQuerier) GetTemplateGitSyncs(ctx context.Context) ([]TemplateGitSync, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateGitSyncs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateGitSync
+	for rows.Next() {
+		var i TemplateGitSync
+		if err := rows.Scan(
+			&i.TemplateID,
+			&i.RepositoryURL,
+			&i.Branch,
+			&i.Directory,
+			&i.ExternalAuthProviderID,
+			&i.AutoActivate,
+			&i.WebhookSecret,
+			&i.CreatedBy,
+			&i.LastCommitSha,
+			&i.LastTemplateVersionID,
+			&i.LastSyncedAt,
+			&i.LastError,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTemplateGitSyncStatus = `-- name: UpdateTemplateGitSyncStatus :exec
+UPDATE
+	template_git_syncs
+SET
+	last_commit_sha = $1,
+	last_template_version_id = $2,
+	last_synced_at = $3,
+	last_error = $4
+WHERE
+	template_id = $5
+`
+
+type UpdateTemplateGitSyncStatusParams struct {
+	LastCommitSha         string        `db:"last_commit_sha" json:"last_commit_sha"`
+	LastTemplateVersionID uuid.NullUUID `db:"last_template_version_id" json:"last_template_version_id"`
+	LastSyncedAt          sql.NullTime  `db:"last_synced_at" json:"last_synced_at"`
+	LastError             string        `db:"last_error" json:"last_error"`
+	TemplateID            uuid.UUID     `db:"template_id" json:"template_id"`
+}
+
+func (q *sqlQuerier) UpdateTemplateGitSyncStatus(ctx context.Context, arg UpdateTemplateGitSyncStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateTemplateGitSyncStatus,
+		arg.LastCommitSha,
+		arg.LastTemplateVersionID,
+		arg.LastSyncedAt,
+		arg.LastError,
+		arg.TemplateID,
+	)
+	return err
+}
+
+const upsertTemplateGitSync = `-- name: UpsertTemplateGitSync :one
+INSERT INTO
+	template_git_syncs (
+		template_id,
+		repository_url,
+		branch,
+		directory,
+		external_auth_provider_id,
+		auto_activate,
+		webhook_secret,
+		created_by,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (template_id)
+DO UPDATE SET
+	repository_url = EXCLUDED.repository_url,
+	branch = EXCLUDED.branch,
+	directory = EXCLUDED.directory,
+	external_auth_provider_id = EXCLUDED.external_auth_provider_id,
+	auto_activate = EXCLUDED.auto_activate,
+	created_by = EXCLUDED.created_by,
+	updated_at = EXCLUDED.updated_at,
+	-- Sync the new source on the next poll, even if the commit is the same.
+	last_commit_sha = CASE
+		WHEN template_git_syncs.repository_url = EXCLUDED.repository_url
+			AND template_git_syncs.branch = EXCLUDED.branch
+			AND template_git_syncs.directory = EXCLUDED.directory
+		THEN template_git_syncs.last_commit_sha
+		ELSE ''
+	END
+RETURNING template_id, repository_url, branch, directory, external_auth_provider_id, auto_activate, webhook_secret, created_by, last_commit_sha, last_template_version_id, last_synced_at, last_error, created_at, updated_at
+`
+
+type UpsertTemplateGitSyncParams struct {
+	TemplateID             uuid.UUID `db:"template_id" json:"template_id"`
+	RepositoryURL          string    `db:"repository_url" json:"repository_url"`
+	Branch                 string    `db:"branch" json:"branch"`
+	Directory              string    `db:"directory" json:"directory"`
+	ExternalAuthProviderID string    `db:"external_auth_provider_id" json:"external_auth_provider_id"`
+	AutoActivate           bool      `db:"auto_activate" json:"auto_activate"`
+	WebhookSecret          string    `db:"webhook_secret" json:"webhook_secret"`
+	CreatedBy              uuid.UUID `db:"created_by" json:"created_by"`
+	CreatedAt              time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt              time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpsertTemplateGitSync(ctx context.Context, arg UpsertTemplateGitSyncParams) (TemplateGitSync, error) {
+	row := q.db.QueryRowContext(ctx, upsertTemplateGitSync,
+		arg.TemplateID,
+		arg.RepositoryURL,
+		arg.Branch,
+		arg.Directory,
+		arg.ExternalAuthProviderID,
+		arg.AutoActivate,
+		arg.WebhookSecret,
+		arg.CreatedBy,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplateGitSync
+	err := row.Scan(
+		&i.TemplateID,
+		&i.RepositoryURL,
+		&i.Branch,
+		&i.Directory,
+		&i.ExternalAuthProviderID,
+		&i.AutoActivate,
+		&i.WebhookSecret,
+		&i.CreatedBy,
+		&i.LastCommitSha,
+		&i.LastTemplateVersionID,
+		&i.LastSyncedAt,
+		&i.LastError,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplateReleaseChannelByTemplateIDAndName = `-- name: GetTemplateReleaseChannelByTemplateIDAndName :one
+SELECT
+	id, template_id, name, template_version_id, created_at, updated_at
+FROM
+	template_release_channels
+WHERE
+	template_id = $1
+	AND lower(name) = lower($2)
+`
+
+type GetTemplateReleaseChannelByTemplateIDAndNameParams struct {
+	TemplateID uuid.UUID `db:"template_id" json:"template_id"`
+	Name       string    `db:"name" json:"name"`
+}
+
+func (q *sqlQuerier) GetTemplateReleaseChannelByTemplateIDAndName(ctx context.Context, arg GetTemplateReleaseChannelByTemplateIDAndNameParams) (TemplateReleaseChannel, error) {
+	row := q.db.QueryRowContext(ctx, getTemplateReleaseChannelByTemplateIDAndName, arg.TemplateID, arg.Name)
+	var i TemplateReleaseChannel
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.TemplateVersionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTemplateReleaseChannelsByTemplateID = `-- name: GetTemplateReleaseChannelsByTemplateID :many
+SELECT
+	id, template_id, name, template_version_id, created_at, updated_at
+FROM
+	template_release_channels
+WHERE
+	template_id = $1
+ORDER BY
+	lower(name) ASC
+`
+
+func (q *sqlQuerier) GetTemplateReleaseChannelsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]TemplateReleaseChannel, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateReleaseChannelsByTemplateID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateReleaseChannel
+	for rows.Next() {
+		var i TemplateReleaseChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Name,
+			&i.TemplateVersionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTemplateReleaseChannelsByTemplateIDs = `-- name: GetTemplateReleaseChannelsByTemplateIDs :many
+SELECT
+	id, template_id, name, template_version_id, created_at, updated_at
+FROM
+	template_release_channels
+WHERE
+	template_id = ANY($1 :: uuid [ ])
+`
+
+func (q *sqlQuerier) GetTemplateReleaseChannelsByTemplateIDs(ctx context.Context, ids []uuid.UUID) ([]TemplateReleaseChannel, error) {
+	rows, err := q.db.QueryContext(ctx, getTemplateReleaseChannelsByTemplateIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TemplateReleaseChannel
+	for rows.Next() {
+		var i TemplateReleaseChannel
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Name,
+			&i.TemplateVersionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertTemplateReleaseChannel = `-- name: InsertTemplateReleaseChannel :one
+INSERT INTO
+	template_release_channels (
+		id,
+		template_id,
+		name,
+		template_version_id,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6) RETURNING id, template_id, name, template_version_id, created_at, updated_at
+`
+
+type InsertTemplateReleaseChannelParams struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TemplateID        uuid.UUID `db:"template_id" json:"template_id"`
+	Name              string    `db:"name" json:"name"`
+	TemplateVersionID uuid.UUID `db:"template_version_id" json:"template_version_id"`
+	CreatedAt         time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) InsertTemplateReleaseChannel(ctx context.Context, arg InsertTemplateReleaseChannelParams) (TemplateReleaseChannel, error) {
+	row := q.db.QueryRowContext(ctx, insertTemplateReleaseChannel,
+		arg.ID,
+		arg.TemplateID,
+		arg.Name,
+		arg.TemplateVersionID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i TemplateReleaseChannel
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.TemplateVersionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateTemplateReleaseChannelByID = `-- name: UpdateTemplateReleaseChannelByID :one
+UPDATE
+	template_release_channels
+SET
+	template_version_id = $2,
+	updated_at = $3
+WHERE
+	id = $1
+RETURNING id, template_id, name, template_version_id, created_at, updated_at
+`
+
+type UpdateTemplateReleaseChannelByIDParams struct {
+	ID                uuid.UUID `db:"id" json:"id"`
+	TemplateVersionID uuid.UUID `db:"template_version_id" json:"template_version_id"`
+	UpdatedAt         time.Time `db:"updated_at" json:"updated_at"`
+}
+
+func (q *sqlQuerier) UpdateTemplateReleaseChannelByID(ctx context.Context, arg UpdateTemplateReleaseChannelByIDParams) (TemplateReleaseChannel, error) {
+	row := q.db.QueryRowContext(ctx, updateTemplateReleaseChannelByID, arg.ID, arg.TemplateVersionID, arg.UpdatedAt)
+	var i TemplateReleaseChannel
+	err := row.Scan(
+		&i.ID,
+		&i.TemplateID,
+		&i.Name,
+		&i.TemplateVersionID,
+		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
@@ -7474,7 +9084,7 @@ func (q *sqlQuerier) GetTemplateAverageBuildTime(ctx context.Context, arg GetTem
 
 const getTemplateByID = `-- name: GetTemplateByID :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, idle_timeout, idle_cpu_threshold, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names
 WHERE
@@ -7515,6 +9125,8 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 		&i.Deprecated,
 		&i.ActivityBump,
 		&i.MaxPortSharingLevel,
+		&i.IdleTimeout,
+		&i.IdleCPUThreshold,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.OrganizationName,
@@ -7526,7 +9138,7 @@ func (q *sqlQuerier) GetTemplateByID(ctx context.Context, id uuid.UUID) (Templat
 
 const getTemplateByOrganizationAndName = `-- name: GetTemplateByOrganizationAndName :one
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, idle_timeout, idle_cpu_threshold, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names AS templates
 WHERE
@@ -7575,6 +9187,8 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 		&i.Deprecated,
 		&i.ActivityBump,
 		&i.MaxPortSharingLevel,
+		&i.IdleTimeout,
+		&i.IdleCPUThreshold,
 		&i.CreatedByAvatarURL,
 		&i.CreatedByUsername,
 		&i.OrganizationName,
@@ -7585,7 +9199,7 @@ func (q *sqlQuerier) GetTemplateByOrganizationAndName(ctx context.Context, arg G
 }
 
 const getTemplates = `-- name: GetTemplates :many
-SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
+SELECT id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, idle_timeout, idle_cpu_threshold, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon FROM template_with_names AS templates
 ORDER BY (name, id) ASC
 `
 
@@ -7627,6 +9241,8 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 			&i.Deprecated,
 			&i.ActivityBump,
 			&i.MaxPortSharingLevel,
+			&i.IdleTimeout,
+			&i.IdleCPUThreshold,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.OrganizationName,
@@ -7648,7 +9264,7 @@ func (q *sqlQuerier) GetTemplates(ctx context.Context) ([]Template, error) {
 
 const getTemplatesWithFilter = `-- name: GetTemplatesWithFilter :many
 SELECT
-	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
+	id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, idle_timeout, idle_cpu_threshold, created_by_avatar_url, created_by_username, organization_name, organization_display_name, organization_icon
 FROM
 	template_with_names AS templates
 WHERE
@@ -7740,6 +9356,8 @@ func (q *sqlQuerier) GetTemplatesWithFilter(ctx context.Context, arg GetTemplate
 			&i.Deprecated,
 			&i.ActivityBump,
 			&i.MaxPortSharingLevel,
+			&i.IdleTimeout,
+			&i.IdleCPUThreshold,
 			&i.CreatedByAvatarURL,
 			&i.CreatedByUsername,
 			&i.OrganizationName,
@@ -7962,7 +9580,9 @@ SET
 	autostart_block_days_of_week = $9,
 	failure_ttl = $10,
 	time_til_dormant = $11,
-	time_til_dormant_autodelete = $12
+	time_til_dormant_autodelete = $12,
+	idle_timeout = $13,
+	idle_cpu_threshold = $14
 WHERE
 	id = $1
 `
@@ -7980,6 +9600,8 @@ type UpdateTemplateScheduleByIDParams struct {
 	FailureTTL                    int64     `db:"failure_ttl" json:"failure_ttl"`
 	TimeTilDormant                int64     `db:"time_til_dormant" json:"time_til_dormant"`
 	TimeTilDormantAutoDelete      int64     `db:"time_til_dormant_autodelete" json:"time_til_dormant_autodelete"`
+	IdleTimeout                   int64     `db:"idle_timeout" json:"idle_timeout"`
+	IdleCPUThreshold              int32     `db:"idle_cpu_threshold" json:"idle_cpu_threshold"`
 }
 
 func (q *sqlQuerier) UpdateTemplateScheduleByID(ctx context.Context, arg UpdateTemplateScheduleByIDParams) error {
@@ -7996,6 +9618,8 @@ func (q *sqlQuerier) UpdateTemplateScheduleByID(ctx context.Context, arg UpdateT
 		arg.FailureTTL,
 		arg.TimeTilDormant,
 		arg.TimeTilDormantAutoDelete,
+		arg.IdleTimeout,
+		arg.IdleCPUThreshold,
 	)
 	return err
 }
@@ -9383,16 +11007,29 @@ WHERE
 			last_seen_at >= $6
 		ELSE true
 	END
+	-- Filter by organization membership
+	AND CASE
+		WHEN $7 :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			id IN (
+				SELECT
+					user_id
+				FROM
+					organization_members
+				WHERE
+					organization_id = $7
+			)
+		ELSE true
+	END
 	-- End of filters
 
 	-- Authorize Filter clause will be injected below in GetAuthorizedUsers
 	-- @authorize_filter
 ORDER BY
 	-- Deterministic and consistent ordering of all users. This is to ensure consistent pagination.
-	LOWER(username) ASC OFFSET $7
+	LOWER(username) ASC OFFSET $8
 LIMIT
 	-- A null limit means "no limit", so 0 means return all
-	NULLIF($8 :: int, 0)
+	NULLIF($9 :: int, 0)
 `
 
 type GetUsersParams struct {
@@ -9402,6 +11039,7 @@ type GetUsersParams struct {
 	RbacRole       []string     `db:"rbac_role" json:"rbac_role"`
 	LastSeenBefore time.Time    `db:"last_seen_before" json:"last_seen_before"`
 	LastSeenAfter  time.Time    `db:"last_seen_after" json:"last_seen_after"`
+	OrganizationID uuid.UUID    `db:"organization_id" json:"organization_id"`
 	OffsetOpt      int32        `db:"offset_opt" json:"offset_opt"`
 	LimitOpt       int32        `db:"limit_opt" json:"limit_opt"`
 }
@@ -9434,6 +11072,7 @@ func (q *sqlQuerier) GetUsers(ctx context.Context, arg GetUsersParams) ([]GetUse
 		pq.Array(arg.RbacRole),
 		arg.LastSeenBefore,
 		arg.LastSeenAfter,
+		arg.OrganizationID,
 		arg.OffsetOpt,
 		arg.LimitOpt,
 	)
@@ -9995,7 +11634,7 @@ func (q *sqlQuerier) DeleteWorkspaceAgentPortSharesByTemplate(ctx context.Contex
 
 const getWorkspaceAgentPortShare = `-- name: GetWorkspaceAgentPortShare :one
 SELECT
-	workspace_id, agent_name, port, share_level, protocol
+	workspace_id, agent_name, port, share_level, protocol, allowed_user_ids, allowed_group_ids
 FROM
 	workspace_agent_port_share
 WHERE
@@ -10019,13 +11658,15 @@ func (q *sqlQuerier) GetWorkspaceAgentPortShare(ctx context.Context, arg GetWork
 		&i.Port,
 		&i.ShareLevel,
 		&i.Protocol,
+		pq.Array(&i.AllowedUserIDs),
+		pq.Array(&i.AllowedGroupIDs),
 	)
 	return i, err
 }
 
 const listWorkspaceAgentPortShares = `-- name: ListWorkspaceAgentPortShares :many
 SELECT
-	workspace_id, agent_name, port, share_level, protocol
+	workspace_id, agent_name, port, share_level, protocol, allowed_user_ids, allowed_group_ids
 FROM
 	workspace_agent_port_share
 WHERE
@@ -10047,6 +11688,8 @@ func (q *sqlQuerier) ListWorkspaceAgentPortShares(ctx context.Context, workspace
 			&i.Port,
 			&i.ShareLevel,
 			&i.Protocol,
+			pq.Array(&i.AllowedUserIDs),
+			pq.Array(&i.AllowedGroupIDs),
 		); err != nil {
 			return nil, err
 		}
@@ -10061,25 +11704,33 @@ func (q *sqlQuerier) ListWorkspaceAgentPortShares(ctx context.Context, workspace
 	return items, nil
 }
 
-const reduceWorkspaceAgentShareLevelToAuthenticatedByTemplate = `-- name: ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate :exec
+const reduceWorkspaceAgentShareLevelByTemplate = `-- name: ReduceWorkspaceAgentShareLevelByTemplate :exec
 UPDATE
 	workspace_agent_port_share
 SET
-	share_level = 'authenticated'
+	share_level = $1::app_sharing_level
 WHERE
-	share_level = 'public'
+	share_level = ANY($2::app_sharing_level[])
 	AND workspace_id IN (
 		SELECT
 			id
 		FROM
 			workspaces
 		WHERE
-			template_id = $1
+			template_id = $3
 	)
 `
 
-func (q *sqlQuerier) ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx context.Context, templateID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, reduceWorkspaceAgentShareLevelToAuthenticatedByTemplate, templateID)
+type ReduceWorkspaceAgentShareLevelByTemplateParams struct {
+	ShareLevel    AppSharingLevel   `db:"share_level" json:"share_level"`
+	ReducedLevels []AppSharingLevel `db:"reduced_levels" json:"reduced_levels"`
+	TemplateID    uuid.UUID         `db:"template_id" json:"template_id"`
+}
+
+// Lowers port shares of the template's workspaces which are above the
+// template's new maximum share level to that level.
+func (q *sqlQuerier) ReduceWorkspaceAgentShareLevelByTemplate(ctx context.Context, arg ReduceWorkspaceAgentShareLevelByTemplateParams) error {
+	_, err := q.db.ExecContext(ctx, reduceWorkspaceAgentShareLevelByTemplate, arg.ShareLevel, pq.Array(arg.ReducedLevels), arg.TemplateID)
 	return err
 }
 
@@ -10090,14 +11741,18 @@ INSERT INTO
 		agent_name,
 		port,
 		share_level,
-		protocol
+		protocol,
+		allowed_user_ids,
+		allowed_group_ids
 	)
 VALUES (
 	$1,
 	$2,
 	$3,
 	$4,
-	$5
+	$5,
+	$6,
+	$7
 )
 ON CONFLICT (
 	workspace_id,
@@ -10106,16 +11761,20 @@ ON CONFLICT (
 )
 DO UPDATE SET
 	share_level = $4,
-	protocol = $5
-RETURNING workspace_id, agent_name, port, share_level, protocol
+	protocol = $5,
+	allowed_user_ids = $6,
+	allowed_group_ids = $7
+RETURNING workspace_id, agent_name, port, share_level, protocol, allowed_user_ids, allowed_group_ids
 `
 
 type UpsertWorkspaceAgentPortShareParams struct {
-	WorkspaceID uuid.UUID         `db:"workspace_id" json:"workspace_id"`
-	AgentName   string            `db:"agent_name" json:"agent_name"`
-	Port        int32             `db:"port" json:"port"`
-	ShareLevel  AppSharingLevel   `db:"share_level" json:"share_level"`
-	Protocol    PortShareProtocol `db:"protocol" json:"protocol"`
+	WorkspaceID     uuid.UUID         `db:"workspace_id" json:"workspace_id"`
+	AgentName       string            `db:"agent_name" json:"agent_name"`
+	Port            int32             `db:"port" json:"port"`
+	ShareLevel      AppSharingLevel   `db:"share_level" json:"share_level"`
+	Protocol        PortShareProtocol `db:"protocol" json:"protocol"`
+	AllowedUserIDs  []uuid.UUID       `db:"allowed_user_ids" json:"allowed_user_ids"`
+	AllowedGroupIDs []uuid.UUID       `db:"allowed_group_ids" json:"allowed_group_ids"`
 }
 
 func (q *sqlQuerier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg UpsertWorkspaceAgentPortShareParams) (WorkspaceAgentPortShare, error) {
@@ -10125,6 +11784,8 @@ func (q *sqlQuerier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg Upse
 		arg.Port,
 		arg.ShareLevel,
 		arg.Protocol,
+		pq.Array(arg.AllowedUserIDs),
+		pq.Array(arg.AllowedGroupIDs),
 	)
 	var i WorkspaceAgentPortShare
 	err := row.Scan(
@@ -10133,6 +11794,8 @@ func (q *sqlQuerier) UpsertWorkspaceAgentPortShare(ctx context.Context, arg Upse
 		&i.Port,
 		&i.ShareLevel,
 		&i.Protocol,
+		pq.Array(&i.AllowedUserIDs),
+		pq.Array(&i.AllowedGroupIDs),
 	)
 	return i, err
 }
@@ -10152,7 +11815,7 @@ func (q *sqlQuerier) DeleteOldWorkspaceAgentLogs(ctx context.Context) error {
 
 const getWorkspaceAgentAndLatestBuildByAuthToken = `-- name: GetWorkspaceAgentAndLatestBuildByAuthToken :one
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl, workspaces.last_active_at, workspaces.release_channel,
 	workspace_agents.id, workspace_agents.created_at, workspace_agents.updated_at, workspace_agents.name, workspace_agents.first_connected_at, workspace_agents.last_connected_at, workspace_agents.disconnected_at, workspace_agents.resource_id, workspace_agents.auth_token, workspace_agents.auth_instance_id, workspace_agents.architecture, workspace_agents.environment_variables, workspace_agents.operating_system, workspace_agents.instance_metadata, workspace_agents.resource_metadata, workspace_agents.directory, workspace_agents.version, workspace_agents.last_connected_replica_id, workspace_agents.connection_timeout_seconds, workspace_agents.troubleshooting_url, workspace_agents.motd_file, workspace_agents.lifecycle_state, workspace_agents.expanded_directory, workspace_agents.logs_length, workspace_agents.logs_overflowed, workspace_agents.started_at, workspace_agents.ready_at, workspace_agents.subsystems, workspace_agents.display_apps, workspace_agents.api_version, workspace_agents.display_order,
 	workspace_build_with_user.id, workspace_build_with_user.created_at, workspace_build_with_user.updated_at, workspace_build_with_user.workspace_id, workspace_build_with_user.template_version_id, workspace_build_with_user.build_number, workspace_build_with_user.transition, workspace_build_with_user.initiator_id, workspace_build_with_user.provisioner_state, workspace_build_with_user.job_id, workspace_build_with_user.deadline, workspace_build_with_user.reason, workspace_build_with_user.daily_cost, workspace_build_with_user.max_deadline, workspace_build_with_user.initiator_by_avatar_url, workspace_build_with_user.initiator_by_username
 FROM
@@ -10211,6 +11874,10 @@ func (q *sqlQuerier) GetWorkspaceAgentAndLatestBuildByAuthToken(ctx context.Cont
 		&i.Workspace.DeletingAt,
 		&i.Workspace.AutomaticUpdates,
 		&i.Workspace.Favorite,
+		&i.Workspace.UserACL,
+		&i.Workspace.GroupACL,
+		&i.Workspace.LastActiveAt,
+		&i.Workspace.ReleaseChannel,
 		&i.WorkspaceAgent.ID,
 		&i.WorkspaceAgent.CreatedAt,
 		&i.WorkspaceAgent.UpdatedAt,
@@ -12932,7 +14599,9 @@ const batchUpdateWorkspaceLastUsedAt = `-- name: BatchUpdateWorkspaceLastUsedAt
 UPDATE
 	workspaces
 SET
-	last_used_at = $1
+	last_used_at = $1,
+	-- Usage tracked outside of the agent always comes from a session.
+	last_active_at = $1
 WHERE
 	id = ANY($2 :: uuid[])
 AND
@@ -13045,7 +14714,7 @@ func (q *sqlQuerier) GetDeploymentWorkspaceStats(ctx context.Context) (GetDeploy
 
 const getWorkspaceByAgentID = `-- name: GetWorkspaceByAgentID :one
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl, workspaces.last_active_at, workspaces.release_channel,
 	templates.name as template_name
 FROM
 	workspaces
@@ -13100,6 +14769,10 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 		&i.Workspace.DeletingAt,
 		&i.Workspace.AutomaticUpdates,
 		&i.Workspace.Favorite,
+		&i.Workspace.UserACL,
+		&i.Workspace.GroupACL,
+		&i.Workspace.LastActiveAt,
+		&i.Workspace.ReleaseChannel,
 		&i.TemplateName,
 	)
 	return i, err
@@ -13107,7 +14780,7 @@ func (q *sqlQuerier) GetWorkspaceByAgentID(ctx context.Context, agentID uuid.UUI
 
 const getWorkspaceByID = `-- name: GetWorkspaceByID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 FROM
 	workspaces
 WHERE
@@ -13135,13 +14808,17 @@ func (q *sqlQuerier) GetWorkspaceByID(ctx context.Context, id uuid.UUID) (Worksp
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
 
 const getWorkspaceByOwnerIDAndName = `-- name: GetWorkspaceByOwnerIDAndName :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 FROM
 	workspaces
 WHERE
@@ -13176,13 +14853,17 @@ func (q *sqlQuerier) GetWorkspaceByOwnerIDAndName(ctx context.Context, arg GetWo
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
 
 const getWorkspaceByWorkspaceAppID = `-- name: GetWorkspaceByWorkspaceAppID :one
 SELECT
-	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 FROM
 	workspaces
 WHERE
@@ -13236,6 +14917,10 @@ func (q *sqlQuerier) GetWorkspaceByWorkspaceAppID(ctx context.Context, workspace
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
@@ -13278,6 +14963,39 @@ func (q *sqlQuerier) GetWorkspaceUniqueOwnerCountByTemplateIDs(ctx context.Conte
 	return items, nil
 }
 
+const getWorkspaceOwnerIDsByTemplateID = `-- name: GetWorkspaceOwnerIDsByTemplateID :many
+SELECT DISTINCT
+	owner_id
+FROM
+	workspaces
+WHERE
+	template_id = $1 AND deleted = false
+`
+
+// Returns the distinct owners of the workspaces built from a template.
+func (q *sqlQuerier) GetWorkspaceOwnerIDsByTemplateID(ctx context.Context, templateID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceOwnerIDsByTemplateID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var owner_id uuid.UUID
+		if err := rows.Scan(&owner_id); err != nil {
+			return nil, err
+		}
+		items = append(items, owner_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkspaces = `-- name: GetWorkspaces :many
 WITH
 build_params AS (
@@ -13287,7 +15005,7 @@ SELECT
 ),
 filtered_workspaces AS (
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite,
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl, workspaces.last_active_at, workspaces.release_channel,
 	COALESCE(template.name, 'unknown') as template_name,
 	latest_build.template_version_id,
 	latest_build.template_version_name,
@@ -13335,7 +15053,7 @@ LEFT JOIN LATERAL (
 ) latest_build ON TRUE
 LEFT JOIN LATERAL (
 	SELECT
-		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level
+		id, created_at, updated_at, organization_id, deleted, name, provisioner, active_version_id, description, default_ttl, created_by, icon, user_acl, group_acl, display_name, allow_user_cancel_workspace_jobs, allow_user_autostart, allow_user_autostop, failure_ttl, time_til_dormant, time_til_dormant_autodelete, autostop_requirement_days_of_week, autostop_requirement_weeks, autostart_block_days_of_week, require_active_version, deprecated, activity_bump, max_port_sharing_level, idle_timeout, idle_cpu_threshold
 	FROM
 		templates
 	WHERE
@@ -13523,7 +15241,7 @@ WHERE
 	-- @authorize_filter
 ), filtered_workspaces_order AS (
 	SELECT
-		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.template_name, fw.template_version_id, fw.template_version_name, fw.username, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status
+		fw.id, fw.created_at, fw.updated_at, fw.owner_id, fw.organization_id, fw.template_id, fw.deleted, fw.name, fw.autostart_schedule, fw.ttl, fw.last_used_at, fw.dormant_at, fw.deleting_at, fw.automatic_updates, fw.favorite, fw.user_acl, fw.group_acl, fw.last_active_at, fw.release_channel, fw.template_name, fw.template_version_id, fw.template_version_name, fw.username, fw.latest_build_completed_at, fw.latest_build_canceled_at, fw.latest_build_error, fw.latest_build_transition, fw.latest_build_status
 	FROM
 		filtered_workspaces fw
 	ORDER BY
@@ -13544,7 +15262,7 @@ WHERE
 		$19
 ), filtered_workspaces_order_with_summary AS (
 	SELECT
-		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.template_name, fwo.template_version_id, fwo.template_version_name, fwo.username, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status
+		fwo.id, fwo.created_at, fwo.updated_at, fwo.owner_id, fwo.organization_id, fwo.template_id, fwo.deleted, fwo.name, fwo.autostart_schedule, fwo.ttl, fwo.last_used_at, fwo.dormant_at, fwo.deleting_at, fwo.automatic_updates, fwo.favorite, fwo.user_acl, fwo.group_acl, fwo.last_active_at, fwo.release_channel, fwo.template_name, fwo.template_version_id, fwo.template_version_name, fwo.username, fwo.latest_build_completed_at, fwo.latest_build_canceled_at, fwo.latest_build_error, fwo.latest_build_transition, fwo.latest_build_status
 	FROM
 		filtered_workspaces_order fwo
 	-- Return a technical summary row with total count of workspaces.
@@ -13566,6 +15284,10 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- deleting_at
 		'never'::automatic_updates, -- automatic_updates
 		false, -- favorite
+		'{}'::jsonb, -- user_acl
+		'{}'::jsonb, -- group_acl
+		'0001-01-01 00:00:00+00'::timestamptz, -- last_active_at
+		'', -- release_channel
 		-- Extra columns added to ` + "`" + `filtered_workspaces` + "`" + `
 		'', -- template_name
 		'00000000-0000-0000-0000-000000000000'::uuid, -- template_version_id
@@ -13585,7 +15307,7 @@ WHERE
 		filtered_workspaces
 )
 SELECT
-	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.template_name, fwos.template_version_id, fwos.template_version_name, fwos.username, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status,
+	fwos.id, fwos.created_at, fwos.updated_at, fwos.owner_id, fwos.organization_id, fwos.template_id, fwos.deleted, fwos.name, fwos.autostart_schedule, fwos.ttl, fwos.last_used_at, fwos.dormant_at, fwos.deleting_at, fwos.automatic_updates, fwos.favorite, fwos.user_acl, fwos.group_acl, fwos.last_active_at, fwos.release_channel, fwos.template_name, fwos.template_version_id, fwos.template_version_name, fwos.username, fwos.latest_build_completed_at, fwos.latest_build_canceled_at, fwos.latest_build_error, fwos.latest_build_transition, fwos.latest_build_status,
 	tc.count
 FROM
 	filtered_workspaces_order_with_summary fwos
@@ -13633,6 +15355,10 @@ type GetWorkspacesRow struct {
 	DeletingAt             sql.NullTime         `db:"deleting_at" json:"deleting_at"`
 	AutomaticUpdates       AutomaticUpdates     `db:"automatic_updates" json:"automatic_updates"`
 	Favorite               bool                 `db:"favorite" json:"favorite"`
+	UserACL                WorkspaceACL         `db:"user_acl" json:"user_acl"`
+	GroupACL               WorkspaceACL         `db:"group_acl" json:"group_acl"`
+	LastActiveAt           time.Time            `db:"last_active_at" json:"last_active_at"`
+	ReleaseChannel         string               `db:"release_channel" json:"release_channel"`
 	TemplateName           string               `db:"template_name" json:"template_name"`
 	TemplateVersionID      uuid.UUID            `db:"template_version_id" json:"template_version_id"`
 	TemplateVersionName    sql.NullString       `db:"template_version_name" json:"template_version_name"`
@@ -13695,6 +15421,10 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.LastActiveAt,
+			&i.ReleaseChannel,
 			&i.TemplateName,
 			&i.TemplateVersionID,
 			&i.TemplateVersionName,
@@ -13719,9 +15449,122 @@ func (q *sqlQuerier) GetWorkspaces(ctx context.Context, arg GetWorkspacesParams)
 	return items, nil
 }
 
+const claimWorkspacesApproachingAutostop = `-- name: ClaimWorkspacesApproachingAutostop :many
+WITH approaching AS (
+	SELECT
+		workspace_builds.id,
+		workspace_builds.deadline
+	FROM
+		workspace_builds
+	INNER JOIN
+		workspaces ON workspace_builds.workspace_id = workspaces.id
+	INNER JOIN
+		provisioner_jobs ON workspace_builds.job_id = provisioner_jobs.id
+	INNER JOIN
+		users ON workspaces.owner_id = users.id
+	LEFT JOIN
+		workspace_build_autostop_warnings ON workspace_build_autostop_warnings.workspace_build_id = workspace_builds.id
+	WHERE
+		workspace_builds.build_number = (
+			SELECT
+				MAX(build_number)
+			FROM
+				workspace_builds
+			WHERE
+				workspace_builds.workspace_id = workspaces.id
+		) AND
+		workspace_builds.transition = 'start'::workspace_transition AND
+		provisioner_jobs.job_status = 'succeeded'::provisioner_job_status AND
+		workspace_builds.deadline > $1 :: timestamptz AND
+		workspace_builds.deadline <= $2 :: timestamptz AND
+		workspace_build_autostop_warnings.deadline IS DISTINCT FROM workspace_builds.deadline AND
+		workspaces.dormant_at IS NULL AND
+		users.status = 'active'::user_status AND
+		workspaces.deleted = 'false'
+	FOR UPDATE OF workspace_builds SKIP LOCKED
+), warned AS (
+	INSERT INTO
+		workspace_build_autostop_warnings (workspace_build_id, deadline, warned_at)
+	SELECT
+		id, deadline, $1 :: timestamptz
+	FROM
+		approaching
+	ON CONFLICT (workspace_build_id) DO UPDATE SET
+		deadline = EXCLUDED.deadline,
+		warned_at = EXCLUDED.warned_at
+	-- Another replica may have warned of the same deadline since the
+	-- snapshot was taken.
+	WHERE
+		workspace_build_autostop_warnings.deadline != EXCLUDED.deadline
+	RETURNING
+		workspace_build_id, deadline
+)
+SELECT
+	workspaces.id,
+	workspaces.owner_id,
+	workspaces.organization_id,
+	workspaces.template_id,
+	workspaces.name,
+	warned.deadline
+FROM
+	warned
+INNER JOIN
+	workspace_builds ON workspace_builds.id = warned.workspace_build_id
+INNER JOIN
+	workspaces ON workspace_builds.workspace_id = workspaces.id
+`
+
+type ClaimWorkspacesApproachingAutostopParams struct {
+	Now        time.Time `db:"now" json:"now"`
+	WarnBefore time.Time `db:"warn_before" json:"warn_before"`
+}
+
+type ClaimWorkspacesApproachingAutostopRow struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	OwnerID        uuid.UUID `db:"owner_id" json:"owner_id"`
+	OrganizationID uuid.UUID `db:"organization_id" json:"organization_id"`
+	TemplateID     uuid.UUID `db:"template_id" json:"template_id"`
+	Name           string    `db:"name" json:"name"`
+	Deadline       time.Time `db:"deadline" json:"deadline"`
+}
+
+// Records that the owners of running workspaces whose latest build is due to
+// be autostopped by warn_before are warned, and returns those workspaces. Each
+// deadline of a build is only returned once, even if the executor ticks late or
+// on several replicas at the same time.
+func (q *sqlQuerier) ClaimWorkspacesApproachingAutostop(ctx context.Context, arg ClaimWorkspacesApproachingAutostopParams) ([]ClaimWorkspacesApproachingAutostopRow, error) {
+	rows, err := q.db.QueryContext(ctx, claimWorkspacesApproachingAutostop, arg.Now, arg.WarnBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWorkspacesApproachingAutostopRow
+	for rows.Next() {
+		var i ClaimWorkspacesApproachingAutostopRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OwnerID,
+			&i.OrganizationID,
+			&i.TemplateID,
+			&i.Name,
+			&i.Deadline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getWorkspacesEligibleForTransition = `-- name: GetWorkspacesEligibleForTransition :many
 SELECT
-	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite
+	workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl, workspaces.last_active_at, workspaces.release_channel
 FROM
 	workspaces
 LEFT JOIN
@@ -13789,6 +15632,13 @@ WHERE
 		(
 			users.status = 'suspended'::user_status AND
 			workspace_builds.transition = 'start'::workspace_transition
+		) OR
+
+		-- If the workspace's template has an idle_timeout set, and the
+		-- workspace is running, it may be eligible for idle stop.
+		(
+			templates.idle_timeout > 0 AND
+			workspace_builds.transition = 'start'::workspace_transition
 		)
 	) AND workspaces.deleted = 'false'
 `
@@ -13818,6 +15668,10 @@ func (q *sqlQuerier) GetWorkspacesEligibleForTransition(ctx context.Context, now
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.LastActiveAt,
+			&i.ReleaseChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -13848,7 +15702,7 @@ INSERT INTO
 		automatic_updates
 	)
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 `
 
 type InsertWorkspaceParams struct {
@@ -13896,6 +15750,10 @@ func (q *sqlQuerier) InsertWorkspace(ctx context.Context, arg InsertWorkspacePar
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
@@ -13935,7 +15793,7 @@ SET
 WHERE
 	id = $1
 	AND deleted = false
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 `
 
 type UpdateWorkspaceParams struct {
@@ -13962,10 +15820,35 @@ func (q *sqlQuerier) UpdateWorkspace(ctx context.Context, arg UpdateWorkspacePar
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
 
+const updateWorkspaceACLByID = `-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = $1,
+	user_acl = $2
+WHERE
+	id = $3
+`
+
+type UpdateWorkspaceACLByIDParams struct {
+	GroupACL WorkspaceACL `db:"group_acl" json:"group_acl"`
+	UserACL  WorkspaceACL `db:"user_acl" json:"user_acl"`
+	ID       uuid.UUID    `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceACLByID(ctx context.Context, arg UpdateWorkspaceACLByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceACLByID, arg.GroupACL, arg.UserACL, arg.ID)
+	return err
+}
+
 const updateWorkspaceAutomaticUpdates = `-- name: UpdateWorkspaceAutomaticUpdates :exec
 UPDATE
 	workspaces
@@ -14048,7 +15931,7 @@ WHERE
     workspaces.id = $1
     AND templates.id = workspaces.template_id
 RETURNING
-    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite
+    workspaces.id, workspaces.created_at, workspaces.updated_at, workspaces.owner_id, workspaces.organization_id, workspaces.template_id, workspaces.deleted, workspaces.name, workspaces.autostart_schedule, workspaces.ttl, workspaces.last_used_at, workspaces.dormant_at, workspaces.deleting_at, workspaces.automatic_updates, workspaces.favorite, workspaces.user_acl, workspaces.group_acl, workspaces.last_active_at, workspaces.release_channel
 `
 
 type UpdateWorkspaceDormantDeletingAtParams struct {
@@ -14075,10 +15958,36 @@ func (q *sqlQuerier) UpdateWorkspaceDormantDeletingAt(ctx context.Context, arg U
 		&i.DeletingAt,
 		&i.AutomaticUpdates,
 		&i.Favorite,
+		&i.UserACL,
+		&i.GroupACL,
+		&i.LastActiveAt,
+		&i.ReleaseChannel,
 	)
 	return i, err
 }
 
+const updateWorkspaceLastActiveAt = `-- name: UpdateWorkspaceLastActiveAt :exec
+UPDATE
+	workspaces
+SET
+	last_active_at = $1
+WHERE
+	id = $2
+AND
+	-- Do not overwrite with older data
+	last_active_at < $1
+`
+
+type UpdateWorkspaceLastActiveAtParams struct {
+	LastActiveAt time.Time `db:"last_active_at" json:"last_active_at"`
+	ID           uuid.UUID `db:"id" json:"id"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceLastActiveAt(ctx context.Context, arg UpdateWorkspaceLastActiveAtParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceLastActiveAt, arg.LastActiveAt, arg.ID)
+	return err
+}
+
 const updateWorkspaceLastUsedAt = `-- name: UpdateWorkspaceLastUsedAt :exec
 UPDATE
 	workspaces
@@ -14098,6 +16007,25 @@ func (q *sqlQuerier) UpdateWorkspaceLastUsedAt(ctx context.Context, arg UpdateWo
 	return err
 }
 
+const updateWorkspaceReleaseChannel = `-- name: UpdateWorkspaceReleaseChannel :exec
+UPDATE
+	workspaces
+SET
+	release_channel = $2
+WHERE
+	id = $1
+`
+
+type UpdateWorkspaceReleaseChannelParams struct {
+	ID             uuid.UUID `db:"id" json:"id"`
+	ReleaseChannel string    `db:"release_channel" json:"release_channel"`
+}
+
+func (q *sqlQuerier) UpdateWorkspaceReleaseChannel(ctx context.Context, arg UpdateWorkspaceReleaseChannelParams) error {
+	_, err := q.db.ExecContext(ctx, updateWorkspaceReleaseChannel, arg.ID, arg.ReleaseChannel)
+	return err
+}
+
 const updateWorkspaceTTL = `-- name: UpdateWorkspaceTTL :exec
 UPDATE
 	workspaces
@@ -14130,7 +16058,7 @@ WHERE
     template_id = $3
 AND
     dormant_at IS NOT NULL
-RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite
+RETURNING id, created_at, updated_at, owner_id, organization_id, template_id, deleted, name, autostart_schedule, ttl, last_used_at, dormant_at, deleting_at, automatic_updates, favorite, user_acl, group_acl, last_active_at, release_channel
 `
 
 type UpdateWorkspacesDormantDeletingAtByTemplateIDParams struct {
@@ -14164,6 +16092,10 @@ func (q *sqlQuerier) UpdateWorkspacesDormantDeletingAtByTemplateID(ctx context.C
 			&i.DeletingAt,
 			&i.AutomaticUpdates,
 			&i.Favorite,
+			&i.UserACL,
+			&i.GroupACL,
+			&i.LastActiveAt,
+			&i.ReleaseChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -14290,3 +16222,129 @@ func (q *sqlQuerier) InsertWorkspaceAgentScripts(ctx context.Context, arg Insert
 	}
 	return items, nil
 }
+
+const insertWorkspaceSnapshot = `-- name: InsertWorkspaceSnapshot :one
+INSERT INTO
+	workspace_snapshots (id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at
+`
+
+type InsertWorkspaceSnapshotParams struct {
+	ID                uuid.UUID           `db:"id" json:"id"`
+	WorkspaceID       uuid.UUID           `db:"workspace_id" json:"workspace_id"`
+	WorkspaceBuildID  uuid.UUID           `db:"workspace_build_id" json:"workspace_build_id"`
+	BuildNumber       int32               `db:"build_number" json:"build_number"`
+	TemplateVersionID uuid.UUID           `db:"template_version_id" json:"template_version_id"`
+	Name              string              `db:"name" json:"name"`
+	Transition        WorkspaceTransition `db:"transition" json:"transition"`
+	ProvisionerState  []byte              `db:"provisioner_state" json:"provisioner_state"`
+	RichParameters    json.RawMessage     `db:"rich_parameters" json:"rich_parameters"`
+	CreatedBy         uuid.UUID           `db:"created_by" json:"created_by"`
+	CreatedAt         time.Time           `db:"created_at" json:"created_at"`
+}
+
+func (q *sqlQuerier) InsertWorkspaceSnapshot(ctx context.Context, arg InsertWorkspaceSnapshotParams) (WorkspaceSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, insertWorkspaceSnapshot,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.WorkspaceBuildID,
+		arg.BuildNumber,
+		arg.TemplateVersionID,
+		arg.Name,
+		arg.Transition,
+		arg.ProvisionerState,
+		arg.RichParameters,
+		arg.CreatedBy,
+		arg.CreatedAt,
+	)
+	var i WorkspaceSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.WorkspaceBuildID,
+		&i.BuildNumber,
+		&i.TemplateVersionID,
+		&i.Name,
+		&i.Transition,
+		&i.ProvisionerState,
+		&i.RichParameters,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceSnapshotByID = `-- name: GetWorkspaceSnapshotByID :one
+SELECT
+	id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at
+FROM
+	workspace_snapshots
+WHERE
+	id = $1
+`
+
+func (q *sqlQuerier) GetWorkspaceSnapshotByID(ctx context.Context, id uuid.UUID) (WorkspaceSnapshot, error) {
+	row := q.db.QueryRowContext(ctx, getWorkspaceSnapshotByID, id)
+	var i WorkspaceSnapshot
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.WorkspaceBuildID,
+		&i.BuildNumber,
+		&i.TemplateVersionID,
+		&i.Name,
+		&i.Transition,
+		&i.ProvisionerState,
+		&i.RichParameters,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWorkspaceSnapshotsByWorkspaceID = `-- name: GetWorkspaceSnapshotsByWorkspaceID :many
+SELECT
+	id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at
+FROM
+	workspace_snapshots
+WHERE
+	workspace_id = $1
+ORDER BY
+	created_at DESC
+`
+
+func (q *sqlQuerier) GetWorkspaceSnapshotsByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]WorkspaceSnapshot, error) {
+	rows, err := q.db.QueryContext(ctx, getWorkspaceSnapshotsByWorkspaceID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkspaceSnapshot
+	for rows.Next() {
+		var i WorkspaceSnapshot
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.WorkspaceBuildID,
+			&i.BuildNumber,
+			&i.TemplateVersionID,
+			&i.Name,
+			&i.Transition,
+			&i.ProvisionerState,
+			&i.RichParameters,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -44,7 +44,9 @@ INSERT INTO
 		updated_at,
 		login_type,
 		scope,
-		token_name
+		token_name,
+		scopes,
+		allow_list
 	)
 VALUES
 	(@id,
@@ -53,7 +55,9 @@ VALUES
 	     WHEN 0 THEN 86400
 		 ELSE @lifetime_seconds::bigint
 	 END
-	 , @hashed_secret, @ip_address, @user_id, @last_used, @expires_at, @created_at, @updated_at, @login_type, @scope, @token_name) RETURNING *;
+	 , @hashed_secret, @ip_address, @user_id, @last_used, @expires_at, @created_at, @updated_at, @login_type, @scope, @token_name,
+	 -- Keys created without scopes store empty arrays rather than NULL.
+	 COALESCE(@scopes::text[], '{}'::text[]), COALESCE(@allow_list::uuid[], '{}'::uuid[])) RETURNING *;
 
 -- name: UpdateAPIKeyByID :exec
 UPDATE
@@ -144,7 +144,50 @@ INSERT INTO
         status_code,
         additional_fields,
         request_id,
-        resource_icon
+        resource_icon,
+        chain_index,
+        hash
     )
 VALUES
-	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING *;
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) RETURNING *;
+
+-- AcquireAuditLogChainHead locks the head of the hash chain of an organization
+-- until the end of the transaction, so audit logs are appended to the chain one
+-- at a time. An empty chain is created if the organization has none.
+-- name: AcquireAuditLogChainHead :one
+INSERT INTO
+	audit_log_chain_heads (organization_id, chain_index, hash, updated_at)
+VALUES
+	(@organization_id, 0, ''::bytea, NOW())
+ON CONFLICT (organization_id) DO UPDATE SET
+	-- A no-op update, which locks the row.
+	organization_id = EXCLUDED.organization_id
+RETURNING *;
+
+-- name: UpdateAuditLogChainHead :exec
+UPDATE
+	audit_log_chain_heads
+SET
+	chain_index = @chain_index,
+	hash = @hash,
+	updated_at = @updated_at
+WHERE
+	organization_id = @organization_id;
+
+-- name: GetAuditLogChainHeads :many
+SELECT * FROM audit_log_chain_heads ORDER BY organization_id;
+
+-- GetChainedAuditLogs returns the audit logs in the hash chain of an
+-- organization in order, starting after the given chain index.
+-- name: GetChainedAuditLogs :many
+SELECT
+	*
+FROM
+	audit_logs
+WHERE
+	organization_id = @organization_id
+	AND chain_index > @after_chain_index :: bigint
+ORDER BY
+	chain_index ASC
+LIMIT
+	@limit_opt :: int;
@@ -1,25 +1,41 @@
 -- name: FetchNewMessageMetadata :one
 -- This is used to build up the notification_message's JSON payload.
 SELECT nt.name                                                    AS notification_name,
-       nt.actions                                                 AS actions,
+       COALESCE(nt.actions_override, nt.actions)                  AS actions,
        u.id                                                       AS user_id,
        u.email                                                    AS user_email,
        COALESCE(NULLIF(u.name, ''), NULLIF(u.username, ''))::text AS user_name,
-       COALESCE(u.username, '')                                   AS user_username
-FROM notification_templates nt,
-     users u
+       COALESCE(u.username, '')                                   AS user_username,
+       nt.mandatory                                               AS mandatory,
+       COALESCE(np.disabled, FALSE)                               AS disabled,
+       np.method                                                  AS preferred_method,
+       nt.digest                                                  AS digest
+FROM notification_templates nt
+         CROSS JOIN users u
+         LEFT JOIN notification_preferences np ON np.notification_template_id = nt.id AND np.user_id = u.id
 WHERE nt.id = @notification_template_id
   AND u.id = @user_id;
 
 -- name: EnqueueNotificationMessage :exec
-INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by)
+INSERT INTO notification_messages (id, notification_template_id, user_id, method, payload, targets, created_by,
+                                   dedupe_hash, next_retry_after)
 VALUES (@id,
         @notification_template_id,
         @user_id,
         @method::notification_method,
         @payload::jsonb,
         @targets,
-        @created_by);
+        @created_by,
+        @dedupe_hash,
+        @next_retry_after);
+
+-- name: ClearNotificationMessageDedupeHash :execrows
+-- Clears the deduplication hash of a message which was enqueued before the given time, so that an identical message can
+-- be enqueued once the deduplication window since the last one has passed.
+UPDATE notification_messages
+SET dedupe_hash = NULL
+WHERE dedupe_hash = @dedupe_hash
+  AND created_at <= @created_before;
 
 -- Acquires the lease for a given count of notification messages, to enable concurrent dequeuing and subsequent sending.
 -- Only rows that aren't already leased (or ones which are leased but have exceeded their lease period) are returned.
@@ -32,48 +48,95 @@ VALUES (@id,
 -- SKIP LOCKED is used to jump over locked rows. This prevents multiple notifiers from acquiring the same messages.
 -- See: https://www.postgresql.org/docs/9.5/sql-select.html#SQL-FOR-UPDATE-SHARE
 --
+-- All acquirable messages of digest templates for the same user, template and method as an acquired one are acquired
+-- along with it, regardless of the count, so that they are delivered as a single digest.
+--
 -- name: AcquireNotificationMessages :many
-WITH acquired AS (
-    UPDATE
-        notification_messages
-            SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
-                updated_at = NOW(),
-                status = 'leased'::notification_message_status,
-                status_reason = 'Leased by notifier ' || sqlc.arg('notifier_id')::uuid,
-                leased_until = NOW() + CONCAT(sqlc.arg('lease_seconds')::int, ' seconds')::interval
-            WHERE id IN (SELECT nm.id
-                         FROM notification_messages AS nm
-                         WHERE (
-                             (
-                                 -- message is in acquirable states
-                                 nm.status IN (
-                                               'pending'::notification_message_status,
-                                               'temporary_failure'::notification_message_status
-                                     )
-                                 )
-                                 -- or somehow the message was left in leased for longer than its lease period
-                                 OR (
-                                 nm.status = 'leased'::notification_message_status
-                                     AND nm.leased_until < NOW()
-                                 )
-                             )
-                           AND (
-                             -- exclude all messages which have exceeded the max attempts; these will be purged later
-                             nm.attempt_count IS NULL OR nm.attempt_count < sqlc.arg('max_attempt_count')::int
-                             )
-                           -- if set, do not retry until we've exceeded the wait time
-                           AND (
-                             CASE
-                                 WHEN nm.next_retry_after IS NOT NULL THEN nm.next_retry_after < NOW()
-                                 ELSE true
-                                 END
-                             )
-                         ORDER BY nm.created_at ASC
-                                  -- Ensure that multiple concurrent readers cannot retrieve the same rows
-                             FOR UPDATE OF nm
-                                 SKIP LOCKED
-                         LIMIT sqlc.arg('count'))
-            RETURNING *)
+WITH candidates AS (SELECT nm.id,
+                           nm.user_id,
+                           nm.notification_template_id,
+                           nm.method,
+                           nm.created_at,
+                           -- the number of messages which have been sent (or are currently being sent) to the user in
+                           -- the last hour
+                           (SELECT COUNT(*)
+                            FROM notification_messages AS sent
+                            WHERE sent.user_id = nm.user_id
+                              AND sent.status IN ('sent'::notification_message_status,
+                                                  'leased'::notification_message_status)
+                              AND sent.updated_at > NOW() - INTERVAL '1 hour') AS recent_count
+                    FROM notification_messages AS nm
+                    WHERE (
+                        (
+                            -- message is in acquirable states
+                            nm.status IN (
+                                          'pending'::notification_message_status,
+                                          'temporary_failure'::notification_message_status
+                                )
+                            )
+                            -- or somehow the message was left in leased for longer than its lease period
+                            OR (
+                            nm.status = 'leased'::notification_message_status
+                                AND nm.leased_until < NOW()
+                            )
+                        )
+                      AND (
+                        -- exclude all messages which have exceeded the max attempts; these will be purged later
+                        nm.attempt_count IS NULL OR nm.attempt_count < sqlc.arg('max_attempt_count')::int
+                        )
+                      -- if set, do not retry until we've exceeded the wait time
+                      AND (
+                        CASE
+                            WHEN nm.next_retry_after IS NOT NULL THEN nm.next_retry_after < NOW()
+                            ELSE true
+                            END
+                        )
+                      -- if a per-user rate limit is set, skip the messages of users who have already been sent (or are
+                      -- currently being sent) that many messages in the last hour; these will be acquired once the user
+                      -- falls below the limit again
+                      AND (
+                        sqlc.arg('per_user_rate_limit')::int = 0
+                            OR (SELECT COUNT(*)
+                                FROM notification_messages AS sent
+                                WHERE sent.user_id = nm.user_id
+                                  AND sent.status IN ('sent'::notification_message_status,
+                                                      'leased'::notification_message_status)
+                                  AND sent.updated_at > NOW() - INTERVAL '1 hour') < sqlc.arg('per_user_rate_limit')::int
+                        )
+                    ORDER BY nm.created_at ASC
+                        -- Ensure that multiple concurrent readers cannot retrieve the same rows
+                        FOR UPDATE OF nm
+                            SKIP LOCKED
+                    LIMIT sqlc.arg('count')),
+     -- messages acquired in this batch count towards the per-user rate limit too
+     within_limit AS (SELECT ranked.id, ranked.user_id, ranked.notification_template_id, ranked.method
+                      FROM (SELECT candidates.*,
+                                   ROW_NUMBER() OVER (PARTITION BY candidates.user_id ORDER BY candidates.created_at) AS batch_count
+                            FROM candidates) AS ranked
+                      WHERE sqlc.arg('per_user_rate_limit')::int = 0
+                         OR ranked.recent_count + ranked.batch_count <= sqlc.arg('per_user_rate_limit')::int),
+     digests AS (SELECT nm.id
+                 FROM notification_messages AS nm
+                          JOIN notification_templates nt ON nm.notification_template_id = nt.id
+                 WHERE nt.digest
+                   AND (nm.user_id, nm.notification_template_id, nm.method) IN
+                       (SELECT user_id, notification_template_id, method FROM within_limit)
+                   AND nm.status IN ('pending'::notification_message_status,
+                                     'temporary_failure'::notification_message_status)
+                   AND (nm.attempt_count IS NULL OR nm.attempt_count < sqlc.arg('max_attempt_count')::int)
+                   AND (nm.next_retry_after IS NULL OR nm.next_retry_after < NOW())
+                     FOR UPDATE OF nm
+                         SKIP LOCKED),
+     acquired AS (
+         UPDATE
+             notification_messages
+                 SET queued_seconds = GREATEST(0, EXTRACT(EPOCH FROM (NOW() - updated_at)))::FLOAT,
+                     updated_at = NOW(),
+                     status = 'leased'::notification_message_status,
+                     status_reason = 'Leased by notifier ' || sqlc.arg('notifier_id')::uuid,
+                     leased_until = NOW() + CONCAT(sqlc.arg('lease_seconds')::int, ' seconds')::interval
+                 WHERE id IN (SELECT id FROM within_limit UNION SELECT id FROM digests)
+                 RETURNING *)
 SELECT
     -- message
     nm.id,
@@ -81,10 +144,13 @@ SELECT
     nm.method,
     nm.attempt_count::int AS attempt_count,
     nm.queued_seconds::float AS queued_seconds,
+    nm.user_id,
     -- template
     nt.id AS template_id,
-    nt.title_template,
-    nt.body_template
+    -- templates customized by an administrator take precedence
+    COALESCE(nt.title_template_override, nt.title_template)::text AS title_template,
+    COALESCE(nt.body_template_override, nt.body_template)::text AS body_template,
+    nt.digest
 FROM acquired nm
          JOIN notification_templates nt ON nm.notification_template_id = nt.id;
 
@@ -133,3 +199,89 @@ WHERE id IN
 -- name: GetNotificationMessagesByStatus :many
 SELECT * FROM notification_messages WHERE status = @status LIMIT sqlc.arg('limit')::int;
 
+-- name: GetNotificationTemplates :many
+SELECT *
+FROM notification_templates
+ORDER BY "group", name;
+
+-- name: GetNotificationTemplateByID :one
+SELECT *
+FROM notification_templates
+WHERE id = @id::uuid;
+
+-- name: UpdateNotificationTemplateMandatoryByID :one
+UPDATE notification_templates
+SET mandatory = @mandatory::boolean
+WHERE id = @id::uuid
+RETURNING *;
+
+-- Sets or, when NULL, clears the administrator's overrides of a template's title, body, and actions.
+-- name: UpdateNotificationTemplateOverrideByID :one
+UPDATE notification_templates
+SET title_template_override = sqlc.narg('title_template_override'),
+    body_template_override  = sqlc.narg('body_template_override'),
+    actions_override        = sqlc.narg('actions_override')
+WHERE id = @id::uuid
+RETURNING *;
+
+-- name: GetUserNotificationPreferences :many
+SELECT *
+FROM notification_preferences
+WHERE user_id = @user_id::uuid;
+
+-- name: UpsertUserNotificationPreference :one
+INSERT INTO notification_preferences (user_id, notification_template_id, disabled, method, created_at, updated_at)
+VALUES (@user_id::uuid, @notification_template_id::uuid, @disabled::boolean, sqlc.narg('method')::notification_method,
+        NOW(), NOW())
+ON CONFLICT (user_id, notification_template_id) DO UPDATE
+    SET disabled   = EXCLUDED.disabled,
+        method     = EXCLUDED.method,
+        updated_at = NOW()
+RETURNING *;
+
+-- name: InsertInboxNotification :exec
+-- The ID of an inbox notification is the ID of the notification message it was delivered from, so that retried
+-- deliveries of the same message do not produce duplicates.
+INSERT INTO inbox_notifications (id, user_id, title, content, actions, created_at)
+VALUES (@id, @user_id, @title, @content, @actions, @created_at)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: GetInboxNotificationByID :one
+SELECT *
+FROM inbox_notifications
+WHERE id = @id;
+
+-- name: GetInboxNotificationsByUserID :many
+SELECT *
+FROM inbox_notifications
+WHERE user_id = @user_id
+  AND CASE
+          WHEN @unread_only::boolean THEN read_at IS NULL
+          ELSE true
+    END
+  -- Filter by created_at to page through older notifications.
+  AND CASE
+          WHEN @created_before::timestamptz != '0001-01-01 00:00:00Z' THEN created_at < @created_before
+          ELSE true
+    END
+ORDER BY created_at DESC
+-- A limit of 0 means "no limit".
+LIMIT
+    NULLIF(@limit_opt :: int, 0);
+
+-- name: CountUnreadInboxNotificationsByUserID :one
+SELECT COUNT(*)
+FROM inbox_notifications
+WHERE user_id = @user_id
+  AND read_at IS NULL;
+
+-- name: UpdateInboxNotificationReadStatus :exec
+UPDATE inbox_notifications
+SET read_at = @read_at
+WHERE id = @id;
+
+-- name: MarkAllInboxNotificationsAsRead :exec
+UPDATE inbox_notifications
+SET read_at = @read_at::timestamptz
+WHERE user_id = @user_id
+  AND read_at IS NULL;
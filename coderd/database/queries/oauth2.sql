@@ -11,14 +11,18 @@ INSERT INTO oauth2_provider_apps (
     updated_at,
     name,
     icon,
-    callback_url
+    callback_url,
+    client_type,
+    grant_types
 ) VALUES(
     $1,
     $2,
     $3,
     $4,
     $5,
-    $6
+    $6,
+    $7,
+    $8
 ) RETURNING *;
 
 -- name: UpdateOAuth2ProviderAppByID :one
@@ -26,7 +30,8 @@ UPDATE oauth2_provider_apps SET
     updated_at = $2,
     name = $3,
     icon = $4,
-    callback_url = $5
+    callback_url = $5,
+    client_type = $6
 WHERE id = $1 RETURNING *;
 
 -- name: DeleteOAuth2ProviderAppByID :exec
@@ -80,7 +85,10 @@ INSERT INTO oauth2_provider_app_codes (
     secret_prefix,
     hashed_secret,
     app_id,
-    user_id
+    user_id,
+    code_challenge,
+    code_challenge_method,
+    scopes
 ) VALUES(
     $1,
     $2,
@@ -88,7 +96,10 @@ INSERT INTO oauth2_provider_app_codes (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8,
+    $9,
+    $10
 ) RETURNING *;
 
 -- name: DeleteOAuth2ProviderAppCodeByID :exec
@@ -105,7 +116,8 @@ INSERT INTO oauth2_provider_app_tokens (
     hash_prefix,
     refresh_hash,
     app_secret_id,
-    api_key_id
+    api_key_id,
+    app_id
 ) VALUES(
     $1,
     $2,
@@ -113,7 +125,8 @@ INSERT INTO oauth2_provider_app_tokens (
     $4,
     $5,
     $6,
-    $7
+    $7,
+    $8
 ) RETURNING *;
 
 -- name: GetOAuth2ProviderAppTokenByPrefix :one
@@ -124,10 +137,8 @@ SELECT
   COUNT(DISTINCT oauth2_provider_app_tokens.id) as token_count,
   sqlc.embed(oauth2_provider_apps)
 FROM oauth2_provider_app_tokens
-  INNER JOIN oauth2_provider_app_secrets
-    ON oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
   INNER JOIN oauth2_provider_apps
-    ON oauth2_provider_apps.id = oauth2_provider_app_secrets.app_id
+    ON oauth2_provider_apps.id = oauth2_provider_app_tokens.app_id
   INNER JOIN api_keys
     ON api_keys.id = oauth2_provider_app_tokens.api_key_id
 WHERE
@@ -139,9 +150,51 @@ GROUP BY
 DELETE FROM
   oauth2_provider_app_tokens
 USING
-  oauth2_provider_app_secrets, api_keys
+  api_keys
 WHERE
-  oauth2_provider_app_secrets.id = oauth2_provider_app_tokens.app_secret_id
-  AND api_keys.id = oauth2_provider_app_tokens.api_key_id
-  AND oauth2_provider_app_secrets.app_id = $1
+  api_keys.id = oauth2_provider_app_tokens.api_key_id
+  AND oauth2_provider_app_tokens.app_id = $1
 	AND api_keys.user_id = $2;
+
+-- name: InsertOAuth2ProviderDeviceCode :one
+INSERT INTO oauth2_provider_device_codes (
+    id,
+    created_at,
+    expires_at,
+    secret_prefix,
+    hashed_secret,
+    user_code,
+    app_id,
+    scopes
+) VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) RETURNING *;
+
+-- name: GetOAuth2ProviderDeviceCodeByPrefix :one
+SELECT * FROM oauth2_provider_device_codes WHERE secret_prefix = $1;
+
+-- name: GetOAuth2ProviderDeviceCodeByUserCode :one
+SELECT * FROM oauth2_provider_device_codes WHERE user_code = $1;
+
+-- name: UpdateOAuth2ProviderDeviceCodeStatus :one
+-- Approves or denies a pending device code. A code that was already approved
+-- or denied cannot be changed.
+UPDATE oauth2_provider_device_codes SET
+    status = $2,
+    user_id = $3
+WHERE id = $1 AND status = 'pending' RETURNING *;
+
+-- name: UpdateOAuth2ProviderDeviceCodeLastPolledAt :exec
+UPDATE oauth2_provider_device_codes SET
+    last_polled_at = $2
+WHERE id = $1;
+
+-- name: DeleteOAuth2ProviderDeviceCodeByID :exec
+DELETE FROM oauth2_provider_device_codes WHERE id = $1;
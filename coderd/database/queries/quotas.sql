@@ -30,3 +30,112 @@ FROM
 JOIN latest_builds ON
 	latest_builds.workspace_id = workspaces.id
 WHERE NOT deleted AND workspaces.owner_id = $1;
+
+-- name: GetQuotaBudgetByID :one
+SELECT * FROM quota_budgets WHERE id = @id;
+
+-- name: GetQuotaBudgets :many
+SELECT * FROM quota_budgets ORDER BY created_at ASC;
+
+-- name: GetQuotaBudgetsByOrganizationID :many
+SELECT * FROM quota_budgets WHERE organization_id = @organization_id ORDER BY created_at ASC;
+
+-- GetQuotaBudgetsForUser returns the budgets which apply to a user in an
+-- organization: their own budgets and those of every group they are a member
+-- of, including the "Everyone" group.
+-- name: GetQuotaBudgetsForUser :many
+SELECT
+	*
+FROM
+	quota_budgets
+WHERE
+	organization_id = @organization_id
+	AND (
+		user_id = @user_id
+		OR group_id = organization_id
+		OR group_id IN (
+			SELECT group_id FROM group_members WHERE group_members.user_id = @user_id
+		)
+	)
+ORDER BY
+	created_at ASC;
+
+-- GetQuotaSpend returns the cost accrued by the workspaces of the given owners
+-- in an organization between @period_start and @period_end. A workspace
+-- accrues the daily cost of its latest successful build, prorated over the
+-- time until the next successful build completed.
+-- name: GetQuotaSpend :one
+WITH builds AS (
+	SELECT
+		wb.daily_cost,
+		pj.completed_at AS started_at,
+		lead(pj.completed_at) OVER (PARTITION BY wb.workspace_id ORDER BY wb.build_number) AS ended_at
+	FROM
+		workspace_builds wb
+	JOIN provisioner_jobs pj ON
+		pj.id = wb.job_id
+	JOIN workspaces w ON
+		w.id = wb.workspace_id
+	WHERE
+		pj.job_status = 'succeeded'
+		AND w.organization_id = @organization_id
+		AND w.owner_id = ANY(@owner_ids :: uuid[])
+)
+SELECT
+	coalesce(SUM(
+		daily_cost * EXTRACT(EPOCH FROM (
+			LEAST(coalesce(ended_at, @period_end :: timestamptz), @period_end :: timestamptz) -
+			GREATEST(started_at, @period_start :: timestamptz)
+		)) / 86400
+	), 0)::BIGINT
+FROM
+	builds
+WHERE
+	daily_cost > 0
+	AND started_at < @period_end :: timestamptz
+	AND coalesce(ended_at, @period_end :: timestamptz) > @period_start :: timestamptz;
+
+-- name: InsertQuotaBudget :one
+INSERT INTO quota_budgets (
+	id,
+	organization_id,
+	group_id,
+	user_id,
+	period,
+	soft_limit,
+	hard_limit,
+	stop_workspaces,
+	created_at,
+	updated_at
+)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING *;
+
+-- name: UpdateQuotaBudgetByID :one
+UPDATE
+	quota_budgets
+SET
+	soft_limit = @soft_limit,
+	hard_limit = @hard_limit,
+	stop_workspaces = @stop_workspaces,
+	updated_at = @updated_at
+WHERE
+	id = @id
+RETURNING *;
+
+-- name: DeleteQuotaBudgetByID :exec
+DELETE FROM quota_budgets WHERE id = @id;
+
+-- InsertQuotaBudgetAlert records that a user was notified of a budget limit
+-- in a period. It affects no rows if they have already been notified.
+-- name: InsertQuotaBudgetAlert :execrows
+INSERT INTO quota_budget_alerts (
+	budget_id,
+	user_id,
+	period_start,
+	hard_limit,
+	created_at
+)
+VALUES
+	($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;
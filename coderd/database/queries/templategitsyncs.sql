@@ -0,0 +1,67 @@
+-- name: GetTemplateGitSyncByTemplateID :one
+SELECT
+	*
+FROM
+	template_git_syncs
+WHERE
+	template_id = $1;
+
+-- name: GetTemplateGitSyncs :many
+SELECT
+	*
+FROM
+	template_git_syncs
+ORDER BY
+	created_at ASC;
+
+-- name: UpsertTemplateGitSync :one
+INSERT INTO
+	template_git_syncs (
+		template_id,
+		repository_url,
+		branch,
+		directory,
+		external_auth_provider_id,
+		auto_activate,
+		webhook_secret,
+		created_by,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (template_id)
+DO UPDATE SET
+	repository_url = EXCLUDED.repository_url,
+	branch = EXCLUDED.branch,
+	directory = EXCLUDED.directory,
+	external_auth_provider_id = EXCLUDED.external_auth_provider_id,
+	auto_activate = EXCLUDED.auto_activate,
+	created_by = EXCLUDED.created_by,
+	updated_at = EXCLUDED.updated_at,
+	-- Sync the new source on the next poll, even if the commit is the same.
+	last_commit_sha = CASE
+		WHEN template_git_syncs.repository_url = EXCLUDED.repository_url
+			AND template_git_syncs.branch = EXCLUDED.branch
+			AND template_git_syncs.directory = EXCLUDED.directory
+		THEN template_git_syncs.last_commit_sha
+		ELSE ''
+	END
+RETURNING *;
+
+-- name: UpdateTemplateGitSyncStatus :exec
+UPDATE
+	template_git_syncs
+SET
+	last_commit_sha = @last_commit_sha,
+	last_template_version_id = @last_template_version_id,
+	last_synced_at = @last_synced_at,
+	last_error = @last_error
+WHERE
+	template_id = @template_id;
+
+-- name: DeleteTemplateGitSyncByTemplateID :exec
+DELETE FROM
+	template_git_syncs
+WHERE
+	template_id = $1;
@@ -0,0 +1,49 @@
+-- name: GetTemplateReleaseChannelsByTemplateID :many
+SELECT
+	*
+FROM
+	template_release_channels
+WHERE
+	template_id = $1
+ORDER BY
+	lower(name) ASC;
+
+-- name: GetTemplateReleaseChannelsByTemplateIDs :many
+SELECT
+	*
+FROM
+	template_release_channels
+WHERE
+	template_id = ANY(@ids :: uuid [ ]);
+
+-- name: GetTemplateReleaseChannelByTemplateIDAndName :one
+SELECT
+	*
+FROM
+	template_release_channels
+WHERE
+	template_id = @template_id
+	AND lower(name) = lower(@name);
+
+-- name: InsertTemplateReleaseChannel :one
+INSERT INTO
+	template_release_channels (
+		id,
+		template_id,
+		name,
+		template_version_id,
+		created_at,
+		updated_at
+	)
+VALUES
+	($1, $2, $3, $4, $5, $6) RETURNING *;
+
+-- name: UpdateTemplateReleaseChannelByID :one
+UPDATE
+	template_release_channels
+SET
+	template_version_id = $2,
+	updated_at = $3
+WHERE
+	id = $1
+RETURNING *;
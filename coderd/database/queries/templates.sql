@@ -137,7 +137,9 @@ SET
 	autostart_block_days_of_week = $9,
 	failure_ttl = $10,
 	time_til_dormant = $11,
-	time_til_dormant_autodelete = $12
+	time_til_dormant_autodelete = $12,
+	idle_timeout = $13,
+	idle_cpu_threshold = $14
 WHERE
 	id = $1
 ;
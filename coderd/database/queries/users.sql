@@ -184,6 +184,19 @@ WHERE
 			last_seen_at >= @last_seen_after
 		ELSE true
 	END
+	-- Filter by organization membership
+	AND CASE
+		WHEN @organization_id :: uuid != '00000000-0000-0000-0000-000000000000'::uuid THEN
+			id IN (
+				SELECT
+					user_id
+				FROM
+					organization_members
+				WHERE
+					organization_id = @organization_id
+			)
+		ELSE true
+	END
 	-- End of filters
 
 	-- Authorize Filter clause will be injected below in GetAuthorizedUsers
@@ -31,14 +31,18 @@ INSERT INTO
 		agent_name,
 		port,
 		share_level,
-		protocol
+		protocol,
+		allowed_user_ids,
+		allowed_group_ids
 	)
 VALUES (
 	$1,
 	$2,
 	$3,
 	$4,
-	$5
+	$5,
+	$6,
+	$7
 )
 ON CONFLICT (
 	workspace_id,
@@ -47,23 +51,27 @@ ON CONFLICT (
 )
 DO UPDATE SET
 	share_level = $4,
-	protocol = $5
+	protocol = $5,
+	allowed_user_ids = $6,
+	allowed_group_ids = $7
 RETURNING *;
 
--- name: ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate :exec
+-- name: ReduceWorkspaceAgentShareLevelByTemplate :exec
+-- Lowers port shares of the template's workspaces which are above the
+-- template's new maximum share level to that level.
 UPDATE
 	workspace_agent_port_share
 SET
-	share_level = 'authenticated'
+	share_level = @share_level::app_sharing_level
 WHERE
-	share_level = 'public'
+	share_level = ANY(@reduced_levels::app_sharing_level[])
 	AND workspace_id IN (
 		SELECT
 			id
 		FROM
 			workspaces
 		WHERE
-			template_id = $1
+			template_id = @template_id
 	);
 
 -- name: DeleteWorkspaceAgentPortSharesByTemplate :exec
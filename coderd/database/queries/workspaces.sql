@@ -367,6 +367,10 @@ WHERE
 		'0001-01-01 00:00:00+00'::timestamptz, -- deleting_at
 		'never'::automatic_updates, -- automatic_updates
 		false, -- favorite
+		'{}'::jsonb, -- user_acl
+		'{}'::jsonb, -- group_acl
+		'0001-01-01 00:00:00+00'::timestamptz, -- last_active_at
+		'', -- release_channel
 		-- Extra columns added to `filtered_workspaces`
 		'', -- template_name
 		'00000000-0000-0000-0000-000000000000'::uuid, -- template_version_id
@@ -413,6 +417,15 @@ WHERE
 	template_id = ANY(@template_ids :: uuid[]) AND deleted = false
 GROUP BY template_id;
 
+-- name: GetWorkspaceOwnerIDsByTemplateID :many
+-- Returns the distinct owners of the workspaces built from a template.
+SELECT DISTINCT
+	owner_id
+FROM
+	workspaces
+WHERE
+	template_id = @template_id AND deleted = false;
+
 -- name: InsertWorkspace :one
 INSERT INTO
 	workspaces (
@@ -465,6 +478,17 @@ SET
 WHERE
 	id = $1;
 
+-- name: UpdateWorkspaceLastActiveAt :exec
+UPDATE
+	workspaces
+SET
+	last_active_at = @last_active_at
+WHERE
+	id = @id
+AND
+	-- Do not overwrite with older data
+	last_active_at < @last_active_at;
+
 -- name: UpdateWorkspaceLastUsedAt :exec
 UPDATE
 	workspaces
@@ -477,7 +501,9 @@ WHERE
 UPDATE
 	workspaces
 SET
-	last_used_at = @last_used_at
+	last_used_at = @last_used_at,
+	-- Usage tracked outside of the agent always comes from a session.
+	last_active_at = @last_used_at
 WHERE
 	id = ANY(@ids :: uuid[])
 AND
@@ -616,9 +642,84 @@ WHERE
 		(
 			users.status = 'suspended'::user_status AND
 			workspace_builds.transition = 'start'::workspace_transition
+		) OR
+
+		-- If the workspace's template has an idle_timeout set, and the
+		-- workspace is running, it may be eligible for idle stop.
+		(
+			templates.idle_timeout > 0 AND
+			workspace_builds.transition = 'start'::workspace_transition
 		)
 	) AND workspaces.deleted = 'false';
 
+-- name: ClaimWorkspacesApproachingAutostop :many
+-- Records that the owners of running workspaces whose latest build is due to
+-- be autostopped by @warn_before are warned, and returns those workspaces. Each
+-- deadline of a build is only returned once, even if the executor ticks late or
+-- on several replicas at the same time.
+WITH approaching AS (
+	SELECT
+		workspace_builds.id,
+		workspace_builds.deadline
+	FROM
+		workspace_builds
+	INNER JOIN
+		workspaces ON workspace_builds.workspace_id = workspaces.id
+	INNER JOIN
+		provisioner_jobs ON workspace_builds.job_id = provisioner_jobs.id
+	INNER JOIN
+		users ON workspaces.owner_id = users.id
+	LEFT JOIN
+		workspace_build_autostop_warnings ON workspace_build_autostop_warnings.workspace_build_id = workspace_builds.id
+	WHERE
+		workspace_builds.build_number = (
+			SELECT
+				MAX(build_number)
+			FROM
+				workspace_builds
+			WHERE
+				workspace_builds.workspace_id = workspaces.id
+		) AND
+		workspace_builds.transition = 'start'::workspace_transition AND
+		provisioner_jobs.job_status = 'succeeded'::provisioner_job_status AND
+		workspace_builds.deadline > @now :: timestamptz AND
+		workspace_builds.deadline <= @warn_before :: timestamptz AND
+		workspace_build_autostop_warnings.deadline IS DISTINCT FROM workspace_builds.deadline AND
+		workspaces.dormant_at IS NULL AND
+		users.status = 'active'::user_status AND
+		workspaces.deleted = 'false'
+	FOR UPDATE OF workspace_builds SKIP LOCKED
+), warned AS (
+	INSERT INTO
+		workspace_build_autostop_warnings (workspace_build_id, deadline, warned_at)
+	SELECT
+		id, deadline, @now :: timestamptz
+	FROM
+		approaching
+	ON CONFLICT (workspace_build_id) DO UPDATE SET
+		deadline = EXCLUDED.deadline,
+		warned_at = EXCLUDED.warned_at
+	-- Another replica may have warned of the same deadline since the
+	-- snapshot was taken.
+	WHERE
+		workspace_build_autostop_warnings.deadline != EXCLUDED.deadline
+	RETURNING
+		workspace_build_id, deadline
+)
+SELECT
+	workspaces.id,
+	workspaces.owner_id,
+	workspaces.organization_id,
+	workspaces.template_id,
+	workspaces.name,
+	warned.deadline
+FROM
+	warned
+INNER JOIN
+	workspace_builds ON workspace_builds.id = warned.workspace_build_id
+INNER JOIN
+	workspaces ON workspace_builds.workspace_id = workspaces.id;
+
 -- name: UpdateWorkspaceDormantDeletingAt :one
 UPDATE
     workspaces
@@ -676,8 +777,25 @@ SET
 WHERE
 		id = $1;
 
+-- name: UpdateWorkspaceReleaseChannel :exec
+UPDATE
+	workspaces
+SET
+	release_channel = $2
+WHERE
+	id = $1;
+
 -- name: FavoriteWorkspace :exec
 UPDATE workspaces SET favorite = true WHERE id = @id;
 
 -- name: UnfavoriteWorkspace :exec
 UPDATE workspaces SET favorite = false WHERE id = @id;
+
+-- name: UpdateWorkspaceACLByID :exec
+UPDATE
+	workspaces
+SET
+	group_acl = @group_acl,
+	user_acl = @user_acl
+WHERE
+	id = @id;
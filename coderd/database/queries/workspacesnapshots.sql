@@ -0,0 +1,23 @@
+-- name: InsertWorkspaceSnapshot :one
+INSERT INTO
+	workspace_snapshots (id, workspace_id, workspace_build_id, build_number, template_version_id, name, transition, provisioner_state, rich_parameters, created_by, created_at)
+VALUES
+	($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
+
+-- name: GetWorkspaceSnapshotByID :one
+SELECT
+	*
+FROM
+	workspace_snapshots
+WHERE
+	id = $1;
+
+-- name: GetWorkspaceSnapshotsByWorkspaceID :many
+SELECT
+	*
+FROM
+	workspace_snapshots
+WHERE
+	workspace_id = $1
+ORDER BY
+	created_at DESC;
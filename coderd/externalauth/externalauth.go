@@ -95,6 +95,22 @@ func (c *Config) GenerateTokenExtra(token *oauth2.Token) (pqtype.NullRawMessage,
 	}, nil
 }
 
+// GitCredentials returns the username and password that Git uses to
+// authenticate over HTTPS with an access token of the given provider type.
+func GitCredentials(typ, token string) (username, password string) {
+	switch typ {
+	case string(codersdk.EnhancedExternalAuthProviderGitLab):
+		// https://stackoverflow.com/questions/25409700/using-gitlab-token-to-clone-without-authentication
+		return "oauth2", token
+	case string(codersdk.EnhancedExternalAuthProviderBitBucketCloud), string(codersdk.EnhancedExternalAuthProviderBitBucketServer):
+		// The string "bitbucket" was a legacy parameter that needs to still be supported.
+		// https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/#Cloning-a-repository-with-an-access-token
+		return "x-token-auth", token
+	default:
+		return token, ""
+	}
+}
+
 // InvalidTokenError is a case where the "RefreshToken" failed to complete
 // as a result of invalid credentials. Error contains the reason of the failure.
 type InvalidTokenError string
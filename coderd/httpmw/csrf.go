@@ -45,6 +45,8 @@ func CSRF(secureCookie bool) func(next http.Handler) http.Handler {
 		// Exempt all requests that do not require CSRF protection.
 		// All GET requests are exempt by default.
 		mw.ExemptPath("/api/v2/csp/reports")
+		// Authenticated by a signed token in the form rather than a cookie.
+		mw.ExemptPath("/api/v2/extend-workspace")
 
 		// This should not be required?
 		mw.ExemptRegexp(regexp.MustCompile("/api/v2/users/first"))
@@ -3,8 +3,10 @@ package identityprovider
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,29 +16,49 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/codersdk"
 )
 
+// codeChallengeMethodS256 is the only PKCE method we support, since the plain
+// method does not protect against an intercepted authorization request.
+const codeChallengeMethodS256 = "S256"
+
 type authorizeParams struct {
-	clientID     string
-	redirectURL  *url.URL
-	responseType codersdk.OAuth2ProviderResponseType
-	scope        []string
-	state        string
+	clientID            string
+	codeChallenge       string
+	codeChallengeMethod string
+	redirectURL         *url.URL
+	responseType        codersdk.OAuth2ProviderResponseType
+	scope               []string
+	state               string
 }
 
-func extractAuthorizeParams(r *http.Request, callbackURL *url.URL) (authorizeParams, []codersdk.ValidationError, error) {
+func extractAuthorizeParams(r *http.Request, app database.OAuth2ProviderApp, callbackURL *url.URL) (authorizeParams, []codersdk.ValidationError, error) {
 	p := httpapi.NewQueryParamParser()
 	vals := r.URL.Query()
 
 	p.RequiredNotEmpty("state", "response_type", "client_id")
+	// Public clients have no secret, so PKCE is the only proof that the client
+	// exchanging the code is the one that requested it.
+	if app.ClientType == database.OAuth2ProviderAppClientTypePublic {
+		p.RequiredNotEmpty("code_challenge")
+	}
 
 	params := authorizeParams{
-		clientID:     p.String(vals, "", "client_id"),
-		redirectURL:  p.RedirectURL(vals, callbackURL, "redirect_uri"),
-		responseType: httpapi.ParseCustom(p, vals, "", "response_type", httpapi.ParseEnum[codersdk.OAuth2ProviderResponseType]),
-		scope:        p.Strings(vals, []string{}, "scope"),
-		state:        p.String(vals, "", "state"),
+		clientID:            p.String(vals, "", "client_id"),
+		codeChallenge:       p.String(vals, "", "code_challenge"),
+		codeChallengeMethod: p.String(vals, "", "code_challenge_method"),
+		redirectURL:         p.RedirectURL(vals, callbackURL, "redirect_uri"),
+		responseType:        httpapi.ParseCustom(p, vals, "", "response_type", httpapi.ParseEnum[codersdk.OAuth2ProviderResponseType]),
+		scope:               parseScopes(p, vals),
+		state:               p.String(vals, "", "state"),
+	}
+	if params.codeChallenge != "" && params.codeChallengeMethod != codeChallengeMethodS256 {
+		p.Errors = append(p.Errors, codersdk.ValidationError{
+			Field:  "code_challenge_method",
+			Detail: fmt.Sprintf("Query param %q must be %q", "code_challenge_method", codeChallengeMethodS256),
+		})
 	}
 
 	// We add "redirected" when coming from the authorize page.
@@ -49,6 +71,26 @@ func extractAuthorizeParams(r *http.Request, callbackURL *url.URL) (authorizePar
 	return params, nil, nil
 }
 
+// parseScopes parses the space-delimited scope parameter into API key scopes,
+// which restrict the token to the listed permissions. An empty scope grants
+// full access to the user's account.
+func parseScopes(p *httpapi.QueryParamParser, vals url.Values) []string {
+	scopes := strings.Fields(p.String(vals, "", "scope"))
+	for _, scope := range scopes {
+		_, err := rbac.ScopePermissions(scope)
+		if err != nil {
+			p.Errors = append(p.Errors, codersdk.ValidationError{
+				Field:  "scope",
+				Detail: fmt.Sprintf("Query param %q has invalid value: %s", "scope", err.Error()),
+			})
+		}
+	}
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
 // Authorize displays an HTML page for authorizing an application when the user
 // has first been redirected to this path and generates a code and redirects to
 // the app's callback URL after the user clicks "allow" on that page, which is
@@ -67,8 +109,14 @@ func Authorize(db database.Store, accessURL *url.URL) http.HandlerFunc {
 			})
 			return
 		}
+		if !allowsGrantType(app, codersdk.OAuth2ProviderGrantTypeAuthorizationCode) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "The application is not registered for the authorization code grant.",
+			})
+			return
+		}
 
-		params, validationErrs, err := extractAuthorizeParams(r, callbackURL)
+		params, validationErrs, err := extractAuthorizeParams(r, app, callbackURL)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 				Message:     "Invalid query params.",
@@ -78,7 +126,6 @@ func Authorize(db database.Store, accessURL *url.URL) http.HandlerFunc {
 			return
 		}
 
-		// TODO: Ignoring scope for now, but should look into implementing.
 		code, err := GenerateSecret()
 		if err != nil {
 			httpapi.Write(r.Context(), rw, http.StatusInternalServerError, codersdk.Response{
@@ -107,11 +154,14 @@ func Authorize(db database.Store, accessURL *url.URL) http.HandlerFunc {
 				// is received.  If the application does wait before exchanging the
 				// token (for example suppose they ask the user to confirm and the user
 				// has left) then they can just retry immediately and get a new code.
-				ExpiresAt:    dbtime.Now().Add(time.Duration(10) * time.Minute),
-				SecretPrefix: []byte(code.Prefix),
-				HashedSecret: []byte(code.Hashed),
-				AppID:        app.ID,
-				UserID:       apiKey.UserID,
+				ExpiresAt:           dbtime.Now().Add(time.Duration(10) * time.Minute),
+				SecretPrefix:        []byte(code.Prefix),
+				HashedSecret:        []byte(code.Hashed),
+				AppID:               app.ID,
+				UserID:              apiKey.UserID,
+				CodeChallenge:       params.codeChallenge,
+				CodeChallengeMethod: params.codeChallengeMethod,
+				Scopes:              params.scope,
 			})
 			if err != nil {
 				return xerrors.Errorf("insert oauth2 authorization code: %w", err)
@@ -0,0 +1,260 @@
+package identityprovider
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/cryptorand"
+	"github.com/coder/coder/v2/site"
+)
+
+const (
+	// deviceCodeLifetime matches GitHub, and gives the user time to switch to a
+	// browser and sign in before entering the code.
+	deviceCodeLifetime = 15 * time.Minute
+	// deviceCodePollInterval is the minimum time between polls of the token
+	// endpoint by a device.
+	deviceCodePollInterval = 5 * time.Second
+	// userCodeCharset has no vowels so codes never spell words, and no
+	// characters that are easily confused with each other.
+	userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength  = 8
+)
+
+// DeviceAuthorize starts the device authorization grant from RFC 8628. It
+// returns a device code for the device to poll the token endpoint with, and a
+// user code for the user to enter at the verification URI.
+func DeviceAuthorize(db database.Store, accessURL *url.URL) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		app := httpmw.OAuth2ProviderApp(r)
+		if !allowsGrantType(app, codersdk.OAuth2ProviderGrantTypeDeviceCode) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, errUnauthorizedClient)
+			return
+		}
+
+		err := r.ParseForm()
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "Failed to parse form.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		p := httpapi.NewQueryParamParser()
+		vals := r.Form
+		p.RequiredNotEmpty("client_id")
+		_ = p.String(vals, "", "client_id")
+		// Confidential clients authenticate when they poll for the token.
+		_ = p.String(vals, "", "client_secret")
+		scopes := parseScopes(p, vals)
+		p.ErrorExcessParams(vals)
+		if len(p.Errors) > 0 {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid query params.",
+				Validations: p.Errors,
+			})
+			return
+		}
+
+		deviceCode, err := GenerateSecret()
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to generate OAuth2 device code.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		userCode, err := cryptorand.StringCharset(userCodeCharset, userCodeLength)
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to generate OAuth2 user code.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		userCode = userCode[:userCodeLength/2] + "-" + userCode[userCodeLength/2:]
+
+		//nolint:gocritic // Device codes have no owner until a user approves them.
+		dbCode, err := db.InsertOAuth2ProviderDeviceCode(dbauthz.AsSystemRestricted(ctx), database.InsertOAuth2ProviderDeviceCodeParams{
+			ID:           uuid.New(),
+			CreatedAt:    dbtime.Now(),
+			ExpiresAt:    dbtime.Now().Add(deviceCodeLifetime),
+			SecretPrefix: []byte(deviceCode.Prefix),
+			HashedSecret: []byte(deviceCode.Hashed),
+			UserCode:     userCode,
+			AppID:        app.ID,
+			Scopes:       scopes,
+		})
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to create OAuth2 device code.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+
+		verificationURI := accessURL.ResolveReference(&url.URL{Path: "/oauth2/device"})
+		verificationURIComplete := *verificationURI
+		verificationURIComplete.RawQuery = url.Values{"user_code": {dbCode.UserCode}}.Encode()
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.OAuth2DeviceAuthorizationResponse{
+			DeviceCode:              deviceCode.Formatted,
+			UserCode:                dbCode.UserCode,
+			VerificationURI:         verificationURI.String(),
+			VerificationURIComplete: verificationURIComplete.String(),
+			ExpiresIn:               int64(deviceCodeLifetime.Seconds()),
+			Interval:                int64(deviceCodePollInterval.Seconds()),
+		})
+	}
+}
+
+// DeviceVerify displays an HTML page for entering the user code shown by a
+// device, then a page for allowing the device's app like Authorize does. The
+// user approves or denies the device by following a link on that page, which
+// is detected via the origin and referer headers.
+func DeviceVerify(db database.Store, accessURL *url.URL) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		apiKey := httpmw.APIKey(r)
+		ua := httpmw.UserAuthorization(r)
+
+		userCode := normalizeUserCode(r.URL.Query().Get("user_code"))
+		if userCode == "" {
+			site.RenderOAuthAllowPage(rw, r, site.RenderOAuthAllowData{
+				Username:     ua.FriendlyName,
+				UserCodeForm: true,
+			})
+			return
+		}
+
+		invalidCode := site.ErrorPageData{
+			Status:       http.StatusNotFound,
+			HideStatus:   false,
+			Title:        "Invalid Code",
+			Description:  "The code is invalid or has expired. Start again on your device to get a new code.",
+			RetryEnabled: false,
+			DashboardURL: accessURL.String(),
+			Warnings:     nil,
+		}
+		//nolint:gocritic // Device codes have no owner until a user approves them.
+		dbCode, err := db.GetOAuth2ProviderDeviceCodeByUserCode(dbauthz.AsSystemRestricted(ctx), userCode)
+		if errors.Is(err, sql.ErrNoRows) {
+			site.RenderStaticErrorPage(rw, r, invalidCode)
+			return
+		}
+		if err != nil {
+			renderInternalError(rw, r, accessURL, err)
+			return
+		}
+		if dbCode.ExpiresAt.Before(dbtime.Now()) || dbCode.Status != database.OAuth2ProviderDeviceCodeStatusPending {
+			site.RenderStaticErrorPage(rw, r, invalidCode)
+			return
+		}
+		//nolint:gocritic // The user may not be able to read the app.
+		app, err := db.GetOAuth2ProviderAppByID(dbauthz.AsSystemRestricted(ctx), dbCode.AppID)
+		if err != nil {
+			renderInternalError(rw, r, accessURL, err)
+			return
+		}
+
+		action := r.URL.Query().Get("action")
+		if action == "" {
+			link := func(choice string) string {
+				u := *r.URL
+				q := url.Values{"user_code": {dbCode.UserCode}, "action": {choice}}
+				u.RawQuery = q.Encode()
+				return u.String()
+			}
+			site.RenderOAuthAllowPage(rw, r, site.RenderOAuthAllowData{
+				AppIcon:     app.Icon,
+				AppName:     app.Name,
+				CancelURI:   link("deny"),
+				RedirectURI: link("allow"),
+				Username:    ua.FriendlyName,
+				Scopes:      dbCode.Scopes,
+			})
+			return
+		}
+
+		// Without this check, a link could approve a device on behalf of the
+		// user without them ever seeing the allow page.
+		if !cameFrom(r, accessURL, "/oauth2/device") {
+			site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+				Status:       http.StatusBadRequest,
+				HideStatus:   false,
+				Title:        "Referer header missing",
+				Description:  "We cannot continue authorization because your client has not sent the referer header.",
+				RetryEnabled: false,
+				DashboardURL: accessURL.String(),
+				Warnings:     nil,
+			})
+			return
+		}
+
+		status := database.OAuth2ProviderDeviceCodeStatusDenied
+		title := "Device Denied"
+		if action == "allow" {
+			status = database.OAuth2ProviderDeviceCodeStatusApproved
+			title = "Device Authorized"
+		}
+		//nolint:gocritic // Device codes have no owner until a user approves them.
+		_, err = db.UpdateOAuth2ProviderDeviceCodeStatus(dbauthz.AsSystemRestricted(ctx), database.UpdateOAuth2ProviderDeviceCodeStatusParams{
+			ID:     dbCode.ID,
+			Status: status,
+			UserID: uuid.NullUUID{UUID: apiKey.UserID, Valid: true},
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			// The code was approved or denied in the meantime.
+			site.RenderStaticErrorPage(rw, r, invalidCode)
+			return
+		}
+		if err != nil {
+			renderInternalError(rw, r, accessURL, err)
+			return
+		}
+		site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+			Status:       http.StatusOK,
+			HideStatus:   true,
+			Title:        title,
+			Description:  "You can close this window and return to your device.",
+			RetryEnabled: false,
+			DashboardURL: accessURL.String(),
+			Warnings:     nil,
+		})
+	}
+}
+
+// normalizeUserCode formats a user code as it is stored, since users may type
+// it in lowercase or without the dash.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	if len(code) != userCodeLength {
+		return code
+	}
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+func renderInternalError(rw http.ResponseWriter, r *http.Request, accessURL *url.URL, err error) {
+	site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+		Status:       http.StatusInternalServerError,
+		HideStatus:   false,
+		Title:        "Internal Server Error",
+		Description:  err.Error(),
+		RetryEnabled: false,
+		DashboardURL: accessURL.String(),
+		Warnings:     nil,
+	})
+}
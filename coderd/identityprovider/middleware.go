@@ -110,7 +110,7 @@ func authorizeMW(accessURL *url.URL) func(next http.Handler) http.Handler {
 			// 2. Since validation will run once the user clicks "allow", it is
 			//    better to validate now to avoid wasting the user's time clicking a
 			//    button that will just error anyway.
-			params, validationErrs, err := extractAuthorizeParams(r, callbackURL)
+			params, validationErrs, err := extractAuthorizeParams(r, app, callbackURL)
 			if err != nil {
 				errStr := make([]string, len(validationErrs))
 				for i, err := range validationErrs {
@@ -143,7 +143,25 @@ func authorizeMW(accessURL *url.URL) func(next http.Handler) http.Handler {
 				CancelURI:   cancel.String(),
 				RedirectURI: r.URL.String(),
 				Username:    ua.FriendlyName,
+				Scopes:      params.scope,
 			})
 		})
 	}
 }
+
+// cameFrom reports whether the request came from a link on the page at path,
+// which is how we detect that the user pressed a button on our HTML pages.
+func cameFrom(r *http.Request, accessURL *url.URL, path string) bool {
+	origin := r.Header.Get(httpmw.OriginHeader)
+	originU, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	refererU, err := url.Parse(r.Referer())
+	if err != nil {
+		return false
+	}
+	return (origin == "" || originU.Hostname() == accessURL.Hostname()) &&
+		refererU.Hostname() == accessURL.Hostname() &&
+		refererU.Path == path
+}
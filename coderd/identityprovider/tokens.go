@@ -2,11 +2,15 @@ package identityprovider
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
@@ -30,18 +34,53 @@ var (
 	errBadCode = xerrors.New("Invalid code")
 	// errBadToken means the user provided a bad token.
 	errBadToken = xerrors.New("Invalid token")
+	// errBadVerifier means the user provided a bad PKCE code verifier.
+	errBadVerifier = xerrors.New("Invalid code verifier")
 )
 
+// oauth2Error is an error response as described in RFC 6749, section 5.2.
+// Device code grants are polled until the error is no longer
+// authorization_pending or slow_down, so clients need the error code.
+type oauth2Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *oauth2Error) Error() string {
+	return e.Description
+}
+
+var (
+	errAuthorizationPending = &oauth2Error{Code: "authorization_pending", Description: "The user has not approved the device yet."}
+	errSlowDown             = &oauth2Error{Code: "slow_down", Description: "The device is polling too frequently."}
+	errAccessDenied         = &oauth2Error{Code: "access_denied", Description: "The user denied the device."}
+	errExpiredToken         = &oauth2Error{Code: "expired_token", Description: "The device code has expired."}
+	errUnauthorizedClient   = &oauth2Error{Code: "unauthorized_client", Description: "The client is not registered for this grant type."}
+)
+
+// allowsGrantType reports whether an app is registered for a grant type.
+func allowsGrantType(app database.OAuth2ProviderApp, grantType codersdk.OAuth2ProviderGrantType) bool {
+	return slices.Contains(app.GrantTypes, string(grantType))
+}
+
+// WriteClientRegistrationError responds to a dynamic client registration
+// request with an error as described in RFC 7591, section 3.2.2.
+func WriteClientRegistrationError(ctx context.Context, rw http.ResponseWriter, code, description string) {
+	httpapi.Write(ctx, rw, http.StatusBadRequest, &oauth2Error{Code: code, Description: description})
+}
+
 type tokenParams struct {
 	clientID     string
 	clientSecret string
 	code         string
+	codeVerifier string
+	deviceCode   string
 	grantType    codersdk.OAuth2ProviderGrantType
 	redirectURL  *url.URL
 	refreshToken string
 }
 
-func extractTokenParams(r *http.Request, callbackURL *url.URL) (tokenParams, []codersdk.ValidationError, error) {
+func extractTokenParams(r *http.Request, app database.OAuth2ProviderApp, callbackURL *url.URL) (tokenParams, []codersdk.ValidationError, error) {
 	p := httpapi.NewQueryParamParser()
 	err := r.ParseForm()
 	if err != nil {
@@ -55,13 +94,21 @@ func extractTokenParams(r *http.Request, callbackURL *url.URL) (tokenParams, []c
 	case codersdk.OAuth2ProviderGrantTypeRefreshToken:
 		p.RequiredNotEmpty("refresh_token")
 	case codersdk.OAuth2ProviderGrantTypeAuthorizationCode:
-		p.RequiredNotEmpty("client_secret", "client_id", "code")
+		p.RequiredNotEmpty("client_id", "code")
+	case codersdk.OAuth2ProviderGrantTypeDeviceCode:
+		p.RequiredNotEmpty("client_id", "device_code")
+	}
+	// Public clients cannot keep a secret, so they do not send one.
+	if grantType != codersdk.OAuth2ProviderGrantTypeRefreshToken && app.ClientType != database.OAuth2ProviderAppClientTypePublic {
+		p.RequiredNotEmpty("client_secret")
 	}
 
 	params := tokenParams{
 		clientID:     p.String(vals, "", "client_id"),
 		clientSecret: p.String(vals, "", "client_secret"),
 		code:         p.String(vals, "", "code"),
+		codeVerifier: p.String(vals, "", "code_verifier"),
+		deviceCode:   p.String(vals, "", "device_code"),
 		grantType:    grantType,
 		redirectURL:  p.RedirectURL(vals, callbackURL, "redirect_uri"),
 		refreshToken: p.String(vals, "", "refresh_token"),
@@ -92,7 +139,7 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 			return
 		}
 
-		params, validationErrs, err := extractTokenParams(r, callbackURL)
+		params, validationErrs, err := extractTokenParams(r, app, callbackURL)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
 				Message:     "Invalid query params.",
@@ -102,14 +149,21 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 			return
 		}
 
+		if !allowsGrantType(app, params.grantType) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, errUnauthorizedClient)
+			return
+		}
+
 		var token oauth2.Token
 		//nolint:gocritic,revive // More cases will be added later.
 		switch params.grantType {
-		// TODO: Client creds, device code.
+		// TODO: Client creds.
 		case codersdk.OAuth2ProviderGrantTypeRefreshToken:
 			token, err = refreshTokenGrant(ctx, db, app, lifetimes, params)
 		case codersdk.OAuth2ProviderGrantTypeAuthorizationCode:
 			token, err = authorizationCodeGrant(ctx, db, app, lifetimes, params)
+		case codersdk.OAuth2ProviderGrantTypeDeviceCode:
+			token, err = deviceCodeGrant(ctx, db, app, lifetimes, params)
 		default:
 			// Grant types are validated by the parser, so getting through here means
 			// the developer added a type but forgot to add a case here.
@@ -120,12 +174,17 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 			return
 		}
 
-		if errors.Is(err, errBadCode) || errors.Is(err, errBadSecret) {
+		if errors.Is(err, errBadCode) || errors.Is(err, errBadSecret) || errors.Is(err, errBadVerifier) {
 			httpapi.Write(r.Context(), rw, http.StatusUnauthorized, codersdk.Response{
 				Message: err.Error(),
 			})
 			return
 		}
+		var oauthErr *oauth2Error
+		if errors.As(err, &oauthErr) {
+			httpapi.Write(r.Context(), rw, http.StatusBadRequest, oauthErr)
+			return
+		}
 		if err != nil {
 			httpapi.Write(r.Context(), rw, http.StatusInternalServerError, codersdk.Response{
 				Message: "Failed to exchange token",
@@ -142,65 +201,170 @@ func Tokens(db database.Store, lifetimes codersdk.SessionLifetime) http.HandlerF
 
 func authorizationCodeGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
 	// Validate the client secret.
-	secret, err := parseSecret(params.clientSecret)
+	secretID, err := authenticateClient(ctx, db, app, params.clientSecret)
 	if err != nil {
-		return oauth2.Token{}, errBadSecret
+		return oauth2.Token{}, err
 	}
-	//nolint:gocritic // Users cannot read secrets so we must use the system.
-	dbSecret, err := db.GetOAuth2ProviderAppSecretByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(secret.prefix))
+
+	// Validate the authorization code.
+	code, err := parseSecret(params.code)
+	if err != nil {
+		return oauth2.Token{}, errBadCode
+	}
+	//nolint:gocritic // There is no user yet so we must use the system.
+	dbCode, err := db.GetOAuth2ProviderAppCodeByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(code.prefix))
 	if errors.Is(err, sql.ErrNoRows) {
-		return oauth2.Token{}, errBadSecret
+		return oauth2.Token{}, errBadCode
 	}
 	if err != nil {
 		return oauth2.Token{}, err
 	}
-	equal, err := userpassword.Compare(string(dbSecret.HashedSecret), secret.secret)
+	equal, err := userpassword.Compare(string(dbCode.HashedSecret), code.secret)
 	if err != nil {
-		return oauth2.Token{}, xerrors.Errorf("unable to compare secret: %w", err)
+		return oauth2.Token{}, xerrors.Errorf("unable to compare code: %w", err)
 	}
-	if !equal {
-		return oauth2.Token{}, errBadSecret
+	if !equal || dbCode.AppID != app.ID {
+		return oauth2.Token{}, errBadCode
 	}
 
-	// Validate the authorization code.
-	code, err := parseSecret(params.code)
+	// Ensure the code has not expired.
+	if dbCode.ExpiresAt.Before(dbtime.Now()) {
+		return oauth2.Token{}, errBadCode
+	}
+
+	// Ensure the client exchanging the code is the one that requested it.
+	if dbCode.CodeChallenge != "" && !verifyCodeChallenge(params.codeVerifier, dbCode.CodeChallenge) {
+		return oauth2.Token{}, errBadVerifier
+	}
+
+	return issueToken(ctx, db, app, lifetimes, dbCode.UserID, dbCode.Scopes, secretID, func(ctx context.Context, tx database.Store) error {
+		err := tx.DeleteOAuth2ProviderAppCodeByID(ctx, dbCode.ID)
+		if err != nil {
+			return xerrors.Errorf("delete oauth2 app code: %w", err)
+		}
+		return nil
+	})
+}
+
+func deviceCodeGrant(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, params tokenParams) (oauth2.Token, error) {
+	// Validate the client secret.
+	secretID, err := authenticateClient(ctx, db, app, params.clientSecret)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	// Validate the device code.
+	code, err := parseSecret(params.deviceCode)
 	if err != nil {
 		return oauth2.Token{}, errBadCode
 	}
 	//nolint:gocritic // There is no user yet so we must use the system.
-	dbCode, err := db.GetOAuth2ProviderAppCodeByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(code.prefix))
+	dbCode, err := db.GetOAuth2ProviderDeviceCodeByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(code.prefix))
 	if errors.Is(err, sql.ErrNoRows) {
 		return oauth2.Token{}, errBadCode
 	}
 	if err != nil {
 		return oauth2.Token{}, err
 	}
-	equal, err = userpassword.Compare(string(dbCode.HashedSecret), code.secret)
+	equal, err := userpassword.Compare(string(dbCode.HashedSecret), code.secret)
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("unable to compare code: %w", err)
 	}
-	if !equal {
+	if !equal || dbCode.AppID != app.ID {
 		return oauth2.Token{}, errBadCode
 	}
 
 	// Ensure the code has not expired.
 	if dbCode.ExpiresAt.Before(dbtime.Now()) {
-		return oauth2.Token{}, errBadCode
+		return oauth2.Token{}, errExpiredToken
 	}
 
+	switch dbCode.Status {
+	case database.OAuth2ProviderDeviceCodeStatusApproved:
+	case database.OAuth2ProviderDeviceCodeStatusDenied:
+		return oauth2.Token{}, errAccessDenied
+	default:
+		now := dbtime.Now()
+		//nolint:gocritic // There is no user yet so we must use the system.
+		err = db.UpdateOAuth2ProviderDeviceCodeLastPolledAt(dbauthz.AsSystemRestricted(ctx), database.UpdateOAuth2ProviderDeviceCodeLastPolledAtParams{
+			ID:           dbCode.ID,
+			LastPolledAt: sql.NullTime{Time: now, Valid: true},
+		})
+		if err != nil {
+			return oauth2.Token{}, xerrors.Errorf("update oauth2 device code: %w", err)
+		}
+		if dbCode.LastPolledAt.Valid && now.Sub(dbCode.LastPolledAt.Time) < deviceCodePollInterval {
+			return oauth2.Token{}, errSlowDown
+		}
+		return oauth2.Token{}, errAuthorizationPending
+	}
+
+	return issueToken(ctx, db, app, lifetimes, dbCode.UserID.UUID, dbCode.Scopes, secretID, func(ctx context.Context, tx database.Store) error {
+		//nolint:gocritic // Device codes are only readable by the system.
+		err := tx.DeleteOAuth2ProviderDeviceCodeByID(dbauthz.AsSystemRestricted(ctx), dbCode.ID)
+		if err != nil {
+			return xerrors.Errorf("delete oauth2 device code: %w", err)
+		}
+		return nil
+	})
+}
+
+// authenticateClient validates the secret of a confidential client and returns
+// its ID. Public clients have no secret, so there is nothing to validate.
+func authenticateClient(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, clientSecret string) (uuid.NullUUID, error) {
+	if app.ClientType == database.OAuth2ProviderAppClientTypePublic {
+		return uuid.NullUUID{}, nil
+	}
+	secret, err := parseSecret(clientSecret)
+	if err != nil {
+		return uuid.NullUUID{}, errBadSecret
+	}
+	//nolint:gocritic // Users cannot read secrets so we must use the system.
+	dbSecret, err := db.GetOAuth2ProviderAppSecretByPrefix(dbauthz.AsSystemRestricted(ctx), []byte(secret.prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, errBadSecret
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	equal, err := userpassword.Compare(string(dbSecret.HashedSecret), secret.secret)
+	if err != nil {
+		return uuid.NullUUID{}, xerrors.Errorf("unable to compare secret: %w", err)
+	}
+	if !equal || dbSecret.AppID != app.ID {
+		return uuid.NullUUID{}, errBadSecret
+	}
+	return uuid.NullUUID{UUID: dbSecret.ID, Valid: true}, nil
+}
+
+// verifyCodeChallenge checks the PKCE code verifier against the S256 challenge
+// sent with the authorization request, as described in RFC 7636.
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	encoded := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(encoded), []byte(challenge)) == 1
+}
+
+// issueToken generates an API key and refresh token for the user, replacing any
+// previous token for the app. The grant is consumed in the same transaction so
+// it cannot be exchanged twice.
+func issueToken(ctx context.Context, db database.Store, app database.OAuth2ProviderApp, lifetimes codersdk.SessionLifetime, userID uuid.UUID, scopes []string, secretID uuid.NullUUID, consume func(ctx context.Context, tx database.Store) error) (oauth2.Token, error) {
 	// Generate a refresh token.
 	refreshToken, err := GenerateSecret()
 	if err != nil {
 		return oauth2.Token{}, err
 	}
 
-	// Generate the API key we will swap for the code.
-	// TODO: We are ignoring scopes for now.
-	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", dbCode.UserID, app.ID)
+	// Generate the API key we will swap for the grant.
+	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", userID, app.ID)
 	key, sessionToken, err := apikey.Generate(apikey.CreateParams{
-		UserID:          dbCode.UserID,
+		UserID:          userID,
 		LoginType:       database.LoginTypeOAuth2ProviderApp,
 		DefaultLifetime: lifetimes.DefaultDuration.Value(),
+		Scopes:          scopes,
 		// For now, we allow only one token per app and user at a time.
 		TokenName: tokenName,
 	})
@@ -209,7 +373,7 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 	}
 
 	// Grab the user roles so we can perform the exchange as the user.
-	actor, _, err := httpmw.UserRBACSubject(ctx, db, dbCode.UserID, rbac.ScopeAll)
+	actor, _, err := httpmw.UserRBACSubject(ctx, db, userID, rbac.ScopeAll)
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("fetch user actor: %w", err)
 	}
@@ -217,14 +381,14 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 	// Do the actual token exchange in the database.
 	err = db.InTx(func(tx database.Store) error {
 		ctx := dbauthz.As(ctx, actor)
-		err = tx.DeleteOAuth2ProviderAppCodeByID(ctx, dbCode.ID)
+		err = consume(ctx, tx)
 		if err != nil {
-			return xerrors.Errorf("delete oauth2 app code: %w", err)
+			return err
 		}
 
 		// Delete the previous key, if any.
 		prevKey, err := tx.GetAPIKeyByName(ctx, database.GetAPIKeyByNameParams{
-			UserID:    dbCode.UserID,
+			UserID:    userID,
 			TokenName: tokenName,
 		})
 		if err == nil {
@@ -245,8 +409,9 @@ func authorizationCodeGrant(ctx context.Context, db database.Store, app database
 			ExpiresAt:   key.ExpiresAt,
 			HashPrefix:  []byte(refreshToken.Prefix),
 			RefreshHash: []byte(refreshToken.Hashed),
-			AppSecretID: dbSecret.ID,
+			AppSecretID: secretID,
 			APIKeyID:    newKey.ID,
+			AppID:       app.ID,
 		})
 		if err != nil {
 			return xerrors.Errorf("insert oauth2 refresh token: %w", err)
@@ -283,7 +448,7 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 	if err != nil {
 		return oauth2.Token{}, xerrors.Errorf("unable to compare token: %w", err)
 	}
-	if !equal {
+	if !equal || dbToken.AppID != app.ID {
 		return oauth2.Token{}, errBadToken
 	}
 
@@ -310,13 +475,13 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 		return oauth2.Token{}, err
 	}
 
-	// Generate the new API key.
-	// TODO: We are ignoring scopes for now.
+	// Generate the new API key, keeping the scopes of the previous one.
 	tokenName := fmt.Sprintf("%s_%s_oauth_session_token", prevKey.UserID, app.ID)
 	key, sessionToken, err := apikey.Generate(apikey.CreateParams{
 		UserID:          prevKey.UserID,
 		LoginType:       database.LoginTypeOAuth2ProviderApp,
 		DefaultLifetime: lifetimes.DefaultDuration.Value(),
+		Scopes:          prevKey.Scopes,
 		// For now, we allow only one token per app and user at a time.
 		TokenName: tokenName,
 	})
@@ -345,6 +510,7 @@ func refreshTokenGrant(ctx context.Context, db database.Store, app database.OAut
 			RefreshHash: []byte(refreshToken.Hashed),
 			AppSecretID: dbToken.AppSecretID,
 			APIKeyID:    newKey.ID,
+			AppID:       dbToken.AppID,
 		})
 		if err != nil {
 			return xerrors.Errorf("insert oauth2 refresh token: %w", err)
@@ -2,19 +2,38 @@ package coderd
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
 )
 
+// defaultInboxNotificationsLimit is the number of inbox notifications returned when the request does not specify a limit.
+const defaultInboxNotificationsLimit = 25
+
 // @Summary Get notifications settings
 // @ID get-notifications-settings
 // @Security CoderSessionToken
@@ -120,3 +139,827 @@ func (api *API) putNotificationsSettings(rw http.ResponseWriter, r *http.Request
 
 	httpapi.Write(r.Context(), rw, http.StatusOK, settings)
 }
+
+// @Summary Get system notification templates
+// @ID get-system-notification-templates
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Success 200 {array} codersdk.NotificationTemplate
+// @Router /notifications/templates/system [get]
+func (api *API) systemNotificationTemplates(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templates, err := api.Database.GetNotificationTemplates(ctx)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve system notification templates.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.List(templates, db2sdk.NotificationTemplate))
+}
+
+// @Summary Update notification template mandatory flag
+// @ID update-notification-template-mandatory-flag
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.UpdateNotificationTemplateMandatory true "Mandatory request"
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template}/mandatory [put]
+func (api *API) putNotificationTemplateMandatory(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return
+	}
+
+	var req codersdk.UpdateNotificationTemplateMandatory
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, rbac.ResourceDeploymentConfig) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Insufficient permissions to update notification templates.",
+		})
+		return
+	}
+
+	old, err := api.Database.GetNotificationTemplateByID(ctx, templateID)
+	if err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = old
+
+	template, err := api.Database.UpdateNotificationTemplateMandatoryByID(ctx, database.UpdateNotificationTemplateMandatoryByIDParams{
+		ID:        templateID,
+		Mandatory: req.Mandatory,
+	})
+	if err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+				Message: "Insufficient permissions to update notification templates.",
+			})
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = template
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.NotificationTemplate(template))
+}
+
+// @Summary Get notification template
+// @ID get-notification-template
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template} [get]
+func (api *API) notificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return
+	}
+
+	template, err := api.Database.GetNotificationTemplateByID(ctx, templateID)
+	if err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.NotificationTemplate(template))
+}
+
+// @Summary Update notification template
+// @ID update-notification-template
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.UpdateNotificationTemplateRequest true "Update notification template request"
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template} [put]
+func (api *API) putNotificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, rbac.ResourceDeploymentConfig) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Insufficient permissions to update notification templates.",
+		})
+		return
+	}
+
+	var req codersdk.UpdateNotificationTemplateRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	template, err := api.Database.GetNotificationTemplateByID(ctx, templateID)
+	if err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = template
+
+	params := database.UpdateNotificationTemplateOverrideByIDParams{
+		ID:                    templateID,
+		TitleTemplateOverride: sql.NullString{String: req.TitleTemplate, Valid: req.TitleTemplate != ""},
+		BodyTemplateOverride:  sql.NullString{String: req.BodyTemplate, Valid: req.BodyTemplate != ""},
+	}
+	if req.Actions != "" {
+		params.ActionsOverride = []byte(req.Actions)
+	}
+
+	// Render the template as it would be used before storing it, so that mistakes surface now rather than when a
+	// notification fails to be delivered.
+	template.TitleTemplateOverride = params.TitleTemplateOverride
+	template.BodyTemplateOverride = params.BodyTemplateOverride
+	template.ActionsOverride = params.ActionsOverride
+	payload, err := api.sampleNotificationPayload(ctx, httpmw.APIKey(r).UserID, notifications.SampleLabels(template))
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if _, err := notifications.RenderPreview(template, payload, notifications.TemplateHelpers(api.AccessURL)); err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Invalid notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	template, err = api.Database.UpdateNotificationTemplateOverrideByID(ctx, params)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = template
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.NotificationTemplate(template))
+}
+
+// @Summary Reset notification template to its default
+// @ID reset-notification-template-to-its-default
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Success 200 {object} codersdk.NotificationTemplate
+// @Router /notifications/templates/{notification_template}/override [delete]
+func (api *API) deleteNotificationTemplateOverride(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return
+	}
+
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:   *auditor,
+		Log:     api.Logger,
+		Request: r,
+		Action:  database.AuditActionWrite,
+	})
+	defer commitAudit()
+
+	old, err := api.Database.GetNotificationTemplateByID(ctx, templateID)
+	if err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = old
+
+	template, err := api.Database.UpdateNotificationTemplateOverrideByID(ctx, database.UpdateNotificationTemplateOverrideByIDParams{
+		ID: templateID,
+	})
+	if err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+				Message: "Insufficient permissions to update notification templates.",
+			})
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to reset notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = template
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.NotificationTemplate(template))
+}
+
+// @Summary Send test notification from template
+// @ID send-test-notification-from-template
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param notification_template path string true "Notification template ID" format(uuid)
+// @Param request body codersdk.TestNotificationTemplateRequest true "Test notification template request"
+// @Success 200 {object} codersdk.NotificationTemplatePreview
+// @Router /notifications/templates/{notification_template}/test [post]
+func (api *API) postTestNotificationTemplate(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		apiKey = httpmw.APIKey(r)
+	)
+
+	templateID, ok := httpmw.ParseUUIDParam(rw, r, "notification_template")
+	if !ok {
+		return
+	}
+
+	var req codersdk.TestNotificationTemplateRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	// Sending a test notification is audited as creating a notification from the template.
+	auditor := api.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.NotificationTemplate](rw, &audit.RequestParams{
+		Audit:            *auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionCreate,
+		AdditionalFields: map[string]any{"test_labels": req.Labels},
+	})
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, rbac.ResourceDeploymentConfig) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Insufficient permissions to test notification templates.",
+		})
+		return
+	}
+
+	template, err := api.Database.GetNotificationTemplateByID(ctx, templateID)
+	if err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.Old = template
+	aReq.New = template
+
+	labels := notifications.SampleLabels(template)
+	for k, v := range req.Labels {
+		labels[k] = v
+	}
+
+	payload, err := api.sampleNotificationPayload(ctx, apiKey.UserID, labels)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	preview, err := notifications.RenderPreview(template, payload, notifications.TemplateHelpers(api.AccessURL))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to render notification template.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	// Send the notification to the caller through the regular pipeline, so that the deployment's delivery methods
+	// are exercised too.
+	if _, err := api.NotificationsEnqueuer.Enqueue(ctx, apiKey.UserID, templateID, labels, "api"); err != nil {
+		switch {
+		case errors.Is(err, notifications.ErrCannotEnqueueDisabledNotification):
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: "You have disabled this notification; enable it in your notification preferences to test it.",
+			})
+		case errors.Is(err, notifications.ErrDuplicate):
+			httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+				Message: "An identical test notification was sent recently; change its labels to send another.",
+			})
+		default:
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to send test notification.",
+				Detail:  err.Error(),
+			})
+		}
+		return
+	}
+
+	actions := make([]codersdk.NotificationTemplateAction, 0, len(preview.Actions))
+	for _, action := range preview.Actions {
+		actions = append(actions, codersdk.NotificationTemplateAction{
+			Label: action.Label,
+			URL:   action.URL,
+		})
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.NotificationTemplatePreview{
+		Title:   preview.Title,
+		Body:    preview.Body,
+		Actions: actions,
+		Labels:  labels,
+	})
+}
+
+// sampleNotificationPayload builds a payload addressed to the given user, used to render notification templates
+// outside of an event.
+func (api *API) sampleNotificationPayload(ctx context.Context, userID uuid.UUID, labels map[string]string) (types.MessagePayload, error) {
+	user, err := api.Database.GetUserByID(ctx, userID)
+	if err != nil {
+		return types.MessagePayload{}, xerrors.Errorf("get user: %w", err)
+	}
+	return types.MessagePayload{
+		Version:      "1.0",
+		UserID:       user.ID.String(),
+		UserEmail:    user.Email,
+		UserName:     user.Name,
+		UserUsername: user.Username,
+		Labels:       labels,
+	}, nil
+}
+
+// @Summary Get user notification preferences
+// @ID get-user-notification-preferences
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [get]
+func (api *API) userNotificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	prefs, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.List(prefs, db2sdk.NotificationPreference))
+}
+
+// @Summary Update user notification preferences
+// @ID update-user-notification-preferences
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param request body codersdk.UpdateUserNotificationPreferences true "Preferences"
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {array} codersdk.NotificationPreference
+// @Router /users/{user}/notifications/preferences [put]
+func (api *API) putUserNotificationPreferences(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	var req codersdk.UpdateUserNotificationPreferences
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	templates, err := api.Database.GetNotificationTemplates(ctx)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve system notification templates.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	templatesByID := make(map[uuid.UUID]database.NotificationTemplate, len(templates))
+	for _, template := range templates {
+		templatesByID[template.ID] = template
+	}
+	enabledMethods := notifications.EnabledMethods(api.DeploymentValues.Notifications)
+
+	var validations []codersdk.ValidationError
+	for i, pref := range req.Preferences {
+		template, ok := templatesByID[pref.NotificationTemplateID]
+		if !ok {
+			validations = append(validations, codersdk.ValidationError{
+				Field:  fmt.Sprintf("preferences[%d].notification_template_id", i),
+				Detail: fmt.Sprintf("Notification template %q does not exist.", pref.NotificationTemplateID),
+			})
+			continue
+		}
+		if pref.Disabled && template.Mandatory {
+			validations = append(validations, codersdk.ValidationError{
+				Field:  fmt.Sprintf("preferences[%d].disabled", i),
+				Detail: fmt.Sprintf("Notification template %q is mandatory and cannot be disabled.", template.Name),
+			})
+		}
+		if pref.Method != "" {
+			method := database.NotificationMethod(pref.Method)
+			switch {
+			case !method.Valid():
+				validations = append(validations, codersdk.ValidationError{
+					Field:  fmt.Sprintf("preferences[%d].method", i),
+					Detail: fmt.Sprintf("Notification method %q is invalid.", pref.Method),
+				})
+			case !slices.Contains(enabledMethods, method):
+				validations = append(validations, codersdk.ValidationError{
+					Field:  fmt.Sprintf("preferences[%d].method", i),
+					Detail: fmt.Sprintf("Notification method %q is not enabled on this deployment.", pref.Method),
+				})
+			}
+		}
+	}
+	if len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid notification preferences.",
+			Validations: validations,
+		})
+		return
+	}
+
+	err = api.Database.InTx(func(tx database.Store) error {
+		for _, pref := range req.Preferences {
+			_, err := tx.UpsertUserNotificationPreference(ctx, database.UpsertUserNotificationPreferenceParams{
+				UserID:                 user.ID,
+				NotificationTemplateID: pref.NotificationTemplateID,
+				Disabled:               pref.Disabled,
+				Method: database.NullNotificationMethod{
+					NotificationMethod: database.NotificationMethod(pref.Method),
+					Valid:              pref.Method != "",
+				},
+			})
+			if err != nil {
+				return xerrors.Errorf("upsert preference for template %q: %w", pref.NotificationTemplateID, err)
+			}
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.Forbidden(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update user notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	prefs, err := api.Database.GetUserNotificationPreferences(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve user notification preferences.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.List(prefs, db2sdk.NotificationPreference))
+}
+
+// @Summary List user inbox notifications
+// @ID list-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param unread_only query bool false "Only return unread notifications"
+// @Param created_before query string false "Only return notifications created before this time" format(date-time)
+// @Param limit query int false "Page limit"
+// @Success 200 {object} codersdk.ListInboxNotificationsResponse
+// @Router /users/{user}/notifications/inbox [get]
+func (api *API) userInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	parser := httpapi.NewQueryParamParser()
+	query := r.URL.Query()
+	unreadOnly := parser.Boolean(query, false, "unread_only")
+	createdBefore := parser.Time3339Nano(query, time.Time{}, "created_before")
+	limit := parser.PositiveInt32(query, defaultInboxNotificationsLimit, "limit")
+	parser.ErrorExcessParams(query)
+	if len(parser.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query parameters.",
+			Validations: parser.Errors,
+		})
+		return
+	}
+
+	notifications, err := api.Database.GetInboxNotificationsByUserID(ctx, database.GetInboxNotificationsByUserIDParams{
+		UserID:        user.ID,
+		UnreadOnly:    unreadOnly,
+		CreatedBefore: createdBefore,
+		LimitOpt:      limit,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	unreadCount, err := api.Database.CountUnreadInboxNotificationsByUserID(ctx, user.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to count unread inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.ListInboxNotificationsResponse{
+		Notifications: db2sdk.List(notifications, db2sdk.InboxNotification),
+		UnreadCount:   int(unreadCount),
+	})
+}
+
+// @Summary Update inbox notification read status
+// @ID update-inbox-notification-read-status
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Param inbox_notification path string true "Inbox notification ID" format(uuid)
+// @Param request body codersdk.UpdateInboxNotificationReadStatusRequest true "Read status"
+// @Success 200 {object} codersdk.InboxNotification
+// @Router /users/{user}/notifications/inbox/{inbox_notification}/read-status [put]
+func (api *API) putInboxNotificationReadStatus(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	notificationID, ok := httpmw.ParseUUIDParam(rw, r, "inbox_notification")
+	if !ok {
+		return
+	}
+
+	var req codersdk.UpdateInboxNotificationReadStatusRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	notification, err := api.Database.GetInboxNotificationByID(ctx, notificationID)
+	if httpapi.Is404Error(err) || (err == nil && notification.UserID != user.ID) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	err = api.Database.UpdateInboxNotificationReadStatus(ctx, database.UpdateInboxNotificationReadStatusParams{
+		ID: notification.ID,
+		ReadAt: sql.NullTime{
+			Time:  dbtime.Now(),
+			Valid: req.IsRead,
+		},
+	})
+	if err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.Forbidden(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to update inbox notification read status.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	notification, err = api.Database.GetInboxNotificationByID(ctx, notification.ID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve inbox notification.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.InboxNotification(notification))
+}
+
+// @Summary Mark all inbox notifications as read
+// @ID mark-all-inbox-notifications-as-read
+// @Security CoderSessionToken
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 204
+// @Router /users/{user}/notifications/inbox/mark-all-read [put]
+func (api *API) putMarkAllInboxNotificationsAsRead(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	err := api.Database.MarkAllInboxNotificationsAsRead(ctx, database.MarkAllInboxNotificationsAsReadParams{
+		UserID: user.ID,
+		ReadAt: dbtime.Now(),
+	})
+	if err != nil {
+		if dbauthz.IsNotAuthorizedError(err) {
+			httpapi.Forbidden(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to mark inbox notifications as read.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Watch user inbox notifications
+// @ID watch-user-inbox-notifications
+// @Security CoderSessionToken
+// @Produce text/event-stream
+// @Tags Notifications
+// @Param user path string true "User ID, name, or me"
+// @Success 200 {object} codersdk.Response
+// @Router /users/{user}/notifications/inbox/watch [get]
+func (api *API) watchInboxNotifications(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		user = httpmw.UserParam(r)
+	)
+
+	// Counting the unread notifications checks that the caller can read the user's personal data before any stream is opened.
+	if _, err := api.Database.CountUnreadInboxNotificationsByUserID(ctx, user.ID); err != nil {
+		if httpapi.Is404Error(err) {
+			httpapi.ResourceNotFound(rw)
+			return
+		}
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to retrieve inbox notifications.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	sendEvent, senderClosed, err := httpapi.ServerSentEventSender(rw, r)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error setting up server-sent events.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	// Prevent handler from returning until the sender is closed.
+	defer func() {
+		<-senderClosed
+	}()
+
+	sendNotification := func(_ context.Context, message []byte) {
+		notificationID, err := uuid.ParseBytes(message)
+		if err != nil {
+			api.Logger.Warn(ctx, "invalid inbox notification ID received", slog.F("message", string(message)), slog.Error(err))
+			return
+		}
+
+		notification, err := api.Database.GetInboxNotificationByID(ctx, notificationID)
+		if err != nil {
+			_ = sendEvent(ctx, codersdk.ServerSentEvent{
+				Type: codersdk.ServerSentEventTypeError,
+				Data: codersdk.Response{
+					Message: "Internal error fetching inbox notification.",
+					Detail:  err.Error(),
+				},
+			})
+			return
+		}
+
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeData,
+			Data: db2sdk.InboxNotification(notification),
+		})
+	}
+
+	cancelSubscribe, err := api.Pubsub.Subscribe(dispatch.InboxNotificationsChannel(user.ID), sendNotification)
+	if err != nil {
+		_ = sendEvent(ctx, codersdk.ServerSentEvent{
+			Type: codersdk.ServerSentEventTypeError,
+			Data: codersdk.Response{
+				Message: "Internal error subscribing to inbox notifications.",
+				Detail:  err.Error(),
+			},
+		})
+		return
+	}
+	defer cancelSubscribe()
+
+	// An initial ping signals to the request that the server is now ready
+	// and the client can begin servicing a channel with data.
+	_ = sendEvent(ctx, codersdk.ServerSentEvent{
+		Type: codersdk.ServerSentEventTypePing,
+	})
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-senderClosed:
+			return
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package dispatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+)
+
+// maxResponseBodySize limits how much of a response body is read by postJSON.
+const maxResponseBodySize = 64 << 10
+
+// postJSON sends the given payload as JSON to the endpoint via an HTTP POST request, and returns the response body.
+// The first return param indicates whether a retry can be attempted, following the semantics of DeliveryFunc.
+func postJSON(ctx context.Context, cl *http.Client, log slog.Logger, endpoint string, msgID uuid.UUID, payload any, header http.Header) (respBody []byte, retryable bool, err error) {
+	m, err := json.Marshal(payload)
+	if err != nil {
+		return nil, false, xerrors.Errorf("marshal payload: %v", err)
+	}
+
+	// Prepare request.
+	// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(m))
+	if err != nil {
+		return nil, false, xerrors.Errorf("create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send request.
+	resp, err := cl.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, true, xerrors.Errorf("request timeout: %w", err)
+		}
+
+		return nil, true, xerrors.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Handle response.
+	if resp.StatusCode/100 > 2 {
+		// Body could be quite long here, let's grab the first 512B and hope it contains useful debug info.
+		respBody := make([]byte, 512)
+		lr := io.LimitReader(resp.Body, int64(len(respBody)))
+		n, err := lr.Read(respBody)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, true, xerrors.Errorf("non-2xx response (%d), read body: %w", resp.StatusCode, err)
+		}
+		log.Warn(ctx, "unsuccessful delivery", slog.F("status_code", resp.StatusCode),
+			slog.F("response", respBody[:n]), slog.F("msg_id", msgID))
+		return respBody[:n], true, xerrors.Errorf("non-2xx response (%d)", resp.StatusCode)
+	}
+
+	respBody, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, true, xerrors.Errorf("read response body: %w", err)
+	}
+	return respBody, false, nil
+}
@@ -0,0 +1,87 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+)
+
+// InboxStore is the subset of the store required to deliver inbox notifications.
+type InboxStore interface {
+	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error
+}
+
+// InboxHandler delivers notification messages to the recipient's in-app inbox.
+type InboxHandler struct {
+	store InboxStore
+	ps    pubsub.Pubsub
+	log   slog.Logger
+}
+
+// InboxNotificationsChannel is the pubsub channel on which the IDs of new inbox notifications for the given user are
+// published.
+func InboxNotificationsChannel(userID uuid.UUID) string {
+	return "inbox_notifications:" + userID.String()
+}
+
+func NewInboxHandler(store InboxStore, ps pubsub.Pubsub, log slog.Logger) *InboxHandler {
+	return &InboxHandler{store: store, ps: ps, log: log}
+}
+
+func (i *InboxHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	userID, err := uuid.Parse(payload.UserID)
+	if err != nil {
+		return nil, xerrors.Errorf("parse user ID %q: %w", payload.UserID, err)
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	actions := payload.Actions
+	if actions == nil {
+		actions = []types.TemplateAction{}
+	}
+	rawActions, err := json.Marshal(actions)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal actions: %w", err)
+	}
+
+	// The body is stored as Markdown; clients are responsible for rendering it.
+	return i.dispatch(userID, title, bodyTmpl, rawActions), nil
+}
+
+func (i *InboxHandler) dispatch(userID uuid.UUID, title, content string, actions json.RawMessage) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		// The message ID is reused as the inbox notification ID, which makes retries idempotent.
+		err = i.store.InsertInboxNotification(ctx, database.InsertInboxNotificationParams{
+			ID:        msgID,
+			UserID:    userID,
+			Title:     title,
+			Content:   content,
+			Actions:   actions,
+			CreatedAt: dbtime.Now(),
+		})
+		if err != nil {
+			return true, xerrors.Errorf("insert inbox notification: %w", err)
+		}
+
+		// Subscribers can always fall back to listing the inbox, so failing to publish is not a delivery failure.
+		if err := i.ps.Publish(InboxNotificationsChannel(userID), []byte(msgID.String())); err != nil {
+			i.log.Warn(ctx, "failed to publish inbox notification", slog.F("msg_id", msgID), slog.Error(err))
+		}
+
+		return false, nil
+	}
+}
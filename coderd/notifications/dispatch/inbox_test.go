@@ -0,0 +1,100 @@
+package dispatch_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestInbox(t *testing.T) {
+	t.Parallel()
+
+	const (
+		titleTemplate = "Workspace **bob** deleted"
+		bodyTemplate  = "Your workspace **bob** was deleted."
+	)
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		db := dbmem.New()
+		ps := pubsub.NewInMemory()
+		user := dbgen.User(t, db, database.User{})
+
+		published := make(chan string, 1)
+		cancel, err := ps.Subscribe(dispatch.InboxNotificationsChannel(user.ID), func(_ context.Context, message []byte) {
+			published <- string(message)
+		})
+		require.NoError(t, err)
+		defer cancel()
+
+		handler := dispatch.NewInboxHandler(db, ps, logger)
+		deliveryFn, err := handler.Dispatcher(types.MessagePayload{
+			Version:          "1.0",
+			NotificationName: "test",
+			UserID:           user.ID.String(),
+			Labels:           map[string]string{},
+			Actions: []types.TemplateAction{
+				{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+			},
+		}, titleTemplate, bodyTemplate)
+		require.NoError(t, err)
+
+		msgID := uuid.New()
+		retryable, err := deliveryFn(ctx, msgID)
+		require.NoError(t, err)
+		require.False(t, retryable)
+
+		require.Equal(t, msgID.String(), testutil.RequireRecvCtx(ctx, t, published))
+
+		notif, err := db.GetInboxNotificationByID(ctx, msgID)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, notif.UserID)
+		require.Equal(t, "Workspace bob deleted", notif.Title)
+		require.Equal(t, bodyTemplate, notif.Content)
+		require.False(t, notif.ReadAt.Valid)
+
+		var actions []types.TemplateAction
+		require.NoError(t, json.Unmarshal(notif.Actions, &actions))
+		require.Len(t, actions, 1)
+		require.Equal(t, "View workspaces", actions[0].Label)
+		require.Equal(t, "https://coder.com/workspaces", actions[0].URL)
+
+		// Redelivering the same message must not create a duplicate.
+		retryable, err = deliveryFn(ctx, msgID)
+		require.NoError(t, err)
+		require.False(t, retryable)
+		count, err := db.CountUnreadInboxNotificationsByUserID(ctx, user.ID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, count)
+	})
+
+	t.Run("InvalidUserID", func(t *testing.T) {
+		t.Parallel()
+
+		handler := dispatch.NewInboxHandler(dbmem.New(), pubsub.NewInMemory(), logger)
+		_, err := handler.Dispatcher(types.MessagePayload{
+			Version:          "1.0",
+			NotificationName: "test",
+			UserID:           "not-a-uuid",
+		}, titleTemplate, bodyTemplate)
+		require.ErrorContains(t, err, "parse user ID")
+	})
+}
@@ -0,0 +1,247 @@
+package dispatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	// slackAPIURL is the base URL of the Slack Web API, which is used when a bot token is configured.
+	slackAPIURL = "https://slack.com/api"
+	// slackHeaderMaxLength is the maximum length of the text in a Block Kit header block.
+	slackHeaderMaxLength = 150
+)
+
+var (
+	ValidationNoSlackTargetErr = xerrors.New("neither slack endpoint nor bot token defined")
+	ValidationNoSlackEmailErr  = xerrors.New("recipient has no email address to look up in slack")
+
+	slackBoldRegex = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	slackLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+
+	// slackRetryableErrors are the Slack Web API error codes which indicate a temporary failure.
+	// See https://api.slack.com/web#evaluating_responses.
+	slackRetryableErrors = map[string]struct{}{
+		"ratelimited":         {},
+		"request_timeout":     {},
+		"service_unavailable": {},
+		"internal_error":      {},
+		"fatal_error":         {},
+	}
+)
+
+// SlackHandler dispatches notification messages to Slack.
+//
+// If a bot token is configured, messages are delivered as direct messages to the recipient, who is looked up in Slack
+// by their email address. Otherwise, messages are posted to the configured incoming webhook.
+type SlackHandler struct {
+	cfg codersdk.NotificationsSlackConfig
+	log slog.Logger
+
+	cl     *http.Client
+	apiURL string
+}
+
+// SlackMessage describes the Block Kit message delivered to Slack.
+// See https://api.slack.com/block-kit.
+type SlackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []SlackBlock `json:"blocks"`
+}
+
+type SlackBlock struct {
+	Type     string         `json:"type"`
+	Text     *SlackText     `json:"text,omitempty"`
+	Elements []SlackElement `json:"elements,omitempty"`
+}
+
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type SlackElement struct {
+	Type string     `json:"type"`
+	Text *SlackText `json:"text,omitempty"`
+	URL  string     `json:"url,omitempty"`
+}
+
+// slackAPIResponse is the envelope common to all Slack Web API responses.
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+	User  struct {
+		ID string `json:"id"`
+	} `json:"user"`
+}
+
+func NewSlackHandler(cfg codersdk.NotificationsSlackConfig, log slog.Logger) *SlackHandler {
+	return &SlackHandler{cfg: cfg, log: log, cl: &http.Client{}, apiURL: slackAPIURL}
+}
+
+// WithAPIURL allows for tests to target a fake Slack Web API.
+func (s *SlackHandler) WithAPIURL(u string) {
+	s.apiURL = strings.TrimSuffix(u, "/")
+}
+
+func (s *SlackHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if s.cfg.BotToken.String() == "" && s.cfg.Endpoint.String() == "" {
+		return nil, ValidationNoSlackTargetErr
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	msg := SlackMessage{
+		// Text is used as a fallback in notifications and by clients which cannot render blocks.
+		Text:   title,
+		Blocks: slackBlocks(title, slackMarkdown(bodyTmpl), payload.Actions),
+	}
+
+	if s.cfg.BotToken.String() != "" {
+		if payload.UserEmail == "" {
+			return nil, ValidationNoSlackEmailErr
+		}
+		return s.dispatchDirect(msg, payload.UserEmail), nil
+	}
+	return s.dispatchWebhook(msg, s.cfg.Endpoint.String()), nil
+}
+
+// dispatchWebhook returns a DeliveryFunc which posts the message to a Slack incoming webhook.
+func (s *SlackHandler) dispatchWebhook(msg SlackMessage, endpoint string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		_, retryable, err = postJSON(ctx, s.cl, s.log, endpoint, msgID, msg, nil)
+		return retryable, err
+	}
+}
+
+// dispatchDirect returns a DeliveryFunc which sends the message as a direct message from the bot to the Slack user
+// with the given email address.
+func (s *SlackHandler) dispatchDirect(msg SlackMessage, email string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (bool, error) {
+		userID, retryable, err := s.lookupUserByEmail(ctx, email)
+		if err != nil {
+			return retryable, xerrors.Errorf("lookup slack user: %w", err)
+		}
+
+		// Posting a message to a user ID opens (or reuses) the direct message channel between the bot and the user.
+		msg.Channel = userID
+		body, retryable, err := postJSON(ctx, s.cl, s.log, s.apiURL+"/chat.postMessage", msgID, msg, s.authHeader())
+		if err != nil {
+			return retryable, err
+		}
+
+		var resp slackAPIResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return true, xerrors.Errorf("decode chat.postMessage response: %w", err)
+		}
+		if !resp.OK {
+			return slackRetryable(resp.Error), xerrors.Errorf("chat.postMessage: %s", resp.Error)
+		}
+		return false, nil
+	}
+}
+
+func (s *SlackHandler) lookupUserByEmail(ctx context.Context, email string) (id string, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		s.apiURL+"/users.lookupByEmail?"+url.Values{"email": []string{email}}.Encode(), nil)
+	if err != nil {
+		return "", false, xerrors.Errorf("create HTTP request: %v", err)
+	}
+	req.Header = s.authHeader()
+
+	res, err := s.cl.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", true, xerrors.Errorf("request timeout: %w", err)
+		}
+		return "", true, xerrors.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 > 2 {
+		return "", true, xerrors.Errorf("non-2xx response (%d)", res.StatusCode)
+	}
+
+	var resp slackAPIResponse
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseBodySize)).Decode(&resp); err != nil {
+		return "", true, xerrors.Errorf("decode response: %w", err)
+	}
+	if !resp.OK {
+		return "", slackRetryable(resp.Error), xerrors.Errorf("users.lookupByEmail: %s", resp.Error)
+	}
+	return resp.User.ID, false, nil
+}
+
+func (s *SlackHandler) authHeader() http.Header {
+	return http.Header{
+		"Authorization": []string{"Bearer " + s.cfg.BotToken.String()},
+	}
+}
+
+func slackRetryable(code string) bool {
+	_, ok := slackRetryableErrors[code]
+	return ok
+}
+
+// slackBlocks builds the Block Kit layout for a notification: a header with the title, a section with the body, and
+// a button per action.
+func slackBlocks(title, body string, actions []types.TemplateAction) []SlackBlock {
+	// Slack counts characters rather than bytes, and rejects text that isn't valid UTF-8.
+	if runes := []rune(title); len(runes) > slackHeaderMaxLength {
+		title = string(runes[:slackHeaderMaxLength-3]) + "..."
+	}
+
+	blocks := []SlackBlock{
+		{
+			Type: "header",
+			Text: &SlackText{Type: "plain_text", Text: title},
+		},
+		{
+			Type: "section",
+			Text: &SlackText{Type: "mrkdwn", Text: body},
+		},
+	}
+
+	if len(actions) == 0 {
+		return blocks
+	}
+
+	elements := make([]SlackElement, 0, len(actions))
+	for _, action := range actions {
+		elements = append(elements, SlackElement{
+			Type: "button",
+			Text: &SlackText{Type: "plain_text", Text: action.Label},
+			URL:  action.URL,
+		})
+	}
+	return append(blocks, SlackBlock{Type: "actions", Elements: elements})
+}
+
+// slackMarkdown converts the subset of Markdown used in notification templates into Slack's "mrkdwn" format.
+// See https://api.slack.com/reference/surfaces/formatting.
+func slackMarkdown(md string) string {
+	// Control characters must be escaped before any links are produced.
+	out := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(md)
+	out = slackLinkRegex.ReplaceAllString(out, "<$2|$1>")
+	out = slackBoldRegex.ReplaceAllString(out, "*$1*")
+	return strings.TrimSpace(out)
+}
@@ -0,0 +1,113 @@
+package dispatch
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	markdown "github.com/coder/coder/v2/coderd/render"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+const (
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	adaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	adaptiveCardVersion     = "1.4"
+)
+
+// TeamsHandler dispatches notification messages to a Microsoft Teams incoming webhook as Adaptive Cards.
+type TeamsHandler struct {
+	cfg codersdk.NotificationsTeamsConfig
+	log slog.Logger
+
+	cl *http.Client
+}
+
+// TeamsMessage describes the message delivered to a Microsoft Teams incoming webhook.
+// See https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/connectors-using.
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard is a minimal representation of an Adaptive Card.
+// See https://adaptivecards.io/explorer/AdaptiveCard.html.
+type AdaptiveCard struct {
+	Schema  string               `json:"$schema"`
+	Type    string               `json:"type"`
+	Version string               `json:"version"`
+	Body    []AdaptiveCardBlock  `json:"body"`
+	Actions []AdaptiveCardAction `json:"actions,omitempty"`
+}
+
+type AdaptiveCardBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text"`
+	Weight string `json:"weight,omitempty"`
+	Size   string `json:"size,omitempty"`
+	Wrap   bool   `json:"wrap"`
+}
+
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func NewTeamsHandler(cfg codersdk.NotificationsTeamsConfig, log slog.Logger) *TeamsHandler {
+	return &TeamsHandler{cfg: cfg, log: log, cl: &http.Client{}}
+}
+
+func (t *TeamsHandler) Dispatcher(payload types.MessagePayload, titleTmpl, bodyTmpl string) (DeliveryFunc, error) {
+	if t.cfg.Endpoint.String() == "" {
+		return nil, xerrors.New("teams endpoint not defined")
+	}
+
+	title, err := markdown.PlaintextFromMarkdown(titleTmpl)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+
+	// TextBlocks support a subset of Markdown (bold, italics, lists and links), which covers what is used in our
+	// notification templates, so the body is passed through as-is.
+	card := AdaptiveCard{
+		Schema:  adaptiveCardSchema,
+		Type:    "AdaptiveCard",
+		Version: adaptiveCardVersion,
+		Body: []AdaptiveCardBlock{
+			{Type: "TextBlock", Text: title, Weight: "Bolder", Size: "Medium", Wrap: true},
+			{Type: "TextBlock", Text: bodyTmpl, Wrap: true},
+		},
+	}
+	for _, action := range payload.Actions {
+		card.Actions = append(card.Actions, AdaptiveCardAction{
+			Type:  "Action.OpenUrl",
+			Title: action.Label,
+			URL:   action.URL,
+		})
+	}
+
+	return t.dispatch(TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{
+			{ContentType: adaptiveCardContentType, Content: card},
+		},
+	}, t.cfg.Endpoint.String()), nil
+}
+
+func (t *TeamsHandler) dispatch(msg TeamsMessage, endpoint string) DeliveryFunc {
+	return func(ctx context.Context, msgID uuid.UUID) (retryable bool, err error) {
+		_, retryable, err = postJSON(ctx, t.cl, t.log, endpoint, msgID, msg, nil)
+		return retryable, err
+	}
+}
@@ -0,0 +1,118 @@
+package dispatch_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog"
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/serpent"
+
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTeams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		titleTemplate = "Workspace **bob** deleted"
+		bodyTemplate  = "Your workspace **bob** was deleted."
+	)
+
+	msgPayload := types.MessagePayload{
+		Version:          "1.0",
+		NotificationName: "test",
+		Labels:           map[string]string{},
+		Actions: []types.TemplateAction{
+			{Label: "View workspaces", URL: "https://coder.com/workspaces"},
+		},
+	}
+
+	logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		received := make(chan dispatch.TeamsMessage, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var msg dispatch.TeamsMessage
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+			received <- msg
+			_, _ = w.Write([]byte("1"))
+		}))
+		defer server.Close()
+
+		endpoint, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		handler := dispatch.NewTeamsHandler(codersdk.NotificationsTeamsConfig{
+			Endpoint: *serpent.URLOf(endpoint),
+		}, logger)
+		deliveryFn, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
+		require.NoError(t, err)
+
+		retryable, err := deliveryFn(ctx, uuid.New())
+		require.NoError(t, err)
+		require.False(t, retryable)
+
+		msg := testutil.RequireRecvCtx(ctx, t, received)
+		require.Equal(t, "message", msg.Type)
+		require.Len(t, msg.Attachments, 1)
+		require.Equal(t, "application/vnd.microsoft.card.adaptive", msg.Attachments[0].ContentType)
+
+		card := msg.Attachments[0].Content
+		require.Equal(t, "AdaptiveCard", card.Type)
+		require.Len(t, card.Body, 2)
+		require.Equal(t, "Workspace bob deleted", card.Body[0].Text)
+		require.Equal(t, bodyTemplate, card.Body[1].Text)
+		require.Len(t, card.Actions, 1)
+		require.Equal(t, "Action.OpenUrl", card.Actions[0].Type)
+		require.Equal(t, "View workspaces", card.Actions[0].Title)
+		require.Equal(t, "https://coder.com/workspaces", card.Actions[0].URL)
+	})
+
+	t.Run("Non200Response", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		endpoint, err := url.Parse(server.URL)
+		require.NoError(t, err)
+
+		handler := dispatch.NewTeamsHandler(codersdk.NotificationsTeamsConfig{
+			Endpoint: *serpent.URLOf(endpoint),
+		}, logger)
+		deliveryFn, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
+		require.NoError(t, err)
+
+		retryable, err := deliveryFn(ctx, uuid.New())
+		require.ErrorContains(t, err, "non-2xx response (502)")
+		require.True(t, retryable)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		t.Parallel()
+
+		handler := dispatch.NewTeamsHandler(codersdk.NotificationsTeamsConfig{}, logger)
+		_, err := handler.Dispatcher(msgPayload, titleTemplate, bodyTemplate)
+		require.ErrorContains(t, err, "teams endpoint not defined")
+	})
+}
@@ -1,11 +1,7 @@
 package dispatch
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"errors"
-	"io"
 	"net/http"
 
 	"github.com/google/uuid"
@@ -66,45 +62,9 @@ func (w *WebhookHandler) dispatch(msgPayload types.MessagePayload, title, body,
 			Body:    body,
 			Payload: msgPayload,
 		}
-		m, err := json.Marshal(payload)
-		if err != nil {
-			return false, xerrors.Errorf("marshal payload: %v", err)
-		}
-
-		// Prepare request.
-		// Outer context has a deadline (see CODER_NOTIFICATIONS_DISPATCH_TIMEOUT).
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(m))
-		if err != nil {
-			return false, xerrors.Errorf("create HTTP request: %v", err)
-		}
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("X-Message-Id", msgID.String())
-
-		// Send request.
-		resp, err := w.cl.Do(req)
-		if err != nil {
-			if errors.Is(err, context.DeadlineExceeded) {
-				return true, xerrors.Errorf("request timeout: %w", err)
-			}
-
-			return true, xerrors.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		// Handle response.
-		if resp.StatusCode/100 > 2 {
-			// Body could be quite long here, let's grab the first 512B and hope it contains useful debug info.
-			respBody := make([]byte, 512)
-			lr := io.LimitReader(resp.Body, int64(len(respBody)))
-			n, err := lr.Read(respBody)
-			if err != nil && !errors.Is(err, io.EOF) {
-				return true, xerrors.Errorf("non-2xx response (%d), read body: %w", resp.StatusCode, err)
-			}
-			w.log.Warn(ctx, "unsuccessful delivery", slog.F("status_code", resp.StatusCode),
-				slog.F("response", respBody[:n]), slog.F("msg_id", msgID))
-			return true, xerrors.Errorf("non-2xx response (%d)", resp.StatusCode)
-		}
-
-		return false, nil
+		_, retryable, err = postJSON(ctx, w.cl, w.log, endpoint, msgID, payload, http.Header{
+			"X-Message-Id": []string{msgID.String()},
+		})
+		return retryable, err
 	}
 }
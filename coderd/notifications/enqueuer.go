@@ -2,8 +2,14 @@ package notifications
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"net/url"
 	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
@@ -11,22 +17,54 @@ import (
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/notifications/render"
 	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
 )
 
+var (
+	ErrCannotEnqueueDisabledNotification = xerrors.New("user has disabled this notification")
+	ErrDuplicate                         = xerrors.New("duplicate notification")
+)
+
+// LogEnqueueError logs an error returned by Enqueue. Users disabling a notification and duplicate notifications being
+// dropped are expected, so they're logged at debug level rather than as a warning.
+func LogEnqueueError(ctx context.Context, log slog.Logger, msg string, err error, fields ...any) {
+	slog.Helper()
+
+	fields = append(fields, slog.Error(err))
+	if errors.Is(err, ErrCannotEnqueueDisabledNotification) || errors.Is(err, ErrDuplicate) {
+		log.Debug(ctx, msg, fields...)
+		return
+	}
+	log.Warn(ctx, msg, fields...)
+}
+
+// TemplateHelpers returns the functions which can be called in notification templates. The same helpers are used to
+// enqueue notifications and to preview templates, so that previews match what is delivered.
+// We can later use this to inject whitelabel fields when app name / logo URL are overridden.
+func TemplateHelpers(accessURL *url.URL) template.FuncMap {
+	return template.FuncMap{
+		"base_url": func() string { return accessURL.String() },
+	}
+}
+
 type StoreEnqueuer struct {
 	store Store
 	log   slog.Logger
 
-	// TODO: expand this to allow for each notification to have custom delivery methods, or multiple, or none.
-	// 		 For example, Larry might want email notifications for "workspace deleted" notifications, but Harry wants
-	//		 Slack notifications, and Mary doesn't want any.
+	// method is the default delivery method, which is used unless the user has chosen a different method for the
+	// notification template in their preferences.
 	method database.NotificationMethod
 	// helpers holds a map of template funcs which are used when rendering templates. These need to be passed in because
 	// the template funcs will return values which are inappropriately encapsulated in this struct.
 	helpers template.FuncMap
+
+	// dedupeWindow is how long after a message is enqueued identical messages for the same user are dropped.
+	dedupeWindow time.Duration
+	// digestWindow is how long messages of digest templates are held back, so that they are acquired together.
+	digestWindow time.Duration
 }
 
 // NewStoreEnqueuer creates an Enqueuer implementation which can persist notification messages in the store.
@@ -37,17 +75,44 @@ func NewStoreEnqueuer(cfg codersdk.NotificationsConfig, store Store, helpers tem
 	}
 
 	return &StoreEnqueuer{
-		store:   store,
-		log:     log,
-		method:  method,
-		helpers: helpers,
+		store:        store,
+		log:          log,
+		method:       method,
+		helpers:      helpers,
+		dedupeWindow: cfg.DedupeWindow.Value(),
+		digestWindow: cfg.DigestWindow.Value(),
 	}, nil
 }
 
 // Enqueue queues a notification message for later delivery.
 // Messages will be dequeued by a notifier later and dispatched.
+//
+// ErrCannotEnqueueDisabledNotification is returned if the user has disabled the given notification template, unless
+// the template has been marked as mandatory by an administrator.
+//
+// ErrDuplicate is returned if an identical message has already been enqueued for the user within the deduplication
+// window.
 func (s *StoreEnqueuer) Enqueue(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string, createdBy string, targets ...uuid.UUID) (*uuid.UUID, error) {
-	payload, err := s.buildPayload(ctx, userID, templateID, labels)
+	metadata, err := s.store.FetchNewMessageMetadata(ctx, database.FetchNewMessageMetadataParams{
+		UserID:                 userID,
+		NotificationTemplateID: templateID,
+	})
+	if err != nil {
+		s.log.Warn(ctx, "failed to fetch message metadata", slog.F("template_id", templateID), slog.F("user_id", userID), slog.Error(err))
+		return nil, xerrors.Errorf("new message metadata: %w", err)
+	}
+
+	if metadata.Disabled && !metadata.Mandatory {
+		s.log.Debug(ctx, "notification disabled by user", slog.F("template_id", templateID), slog.F("user_id", userID))
+		return nil, ErrCannotEnqueueDisabledNotification
+	}
+
+	method := s.method
+	if metadata.PreferredMethod.Valid {
+		method = metadata.PreferredMethod.NotificationMethod
+	}
+
+	payload, err := s.buildPayload(metadata, labels)
 	if err != nil {
 		s.log.Warn(ctx, "failed to build payload", slog.F("template_id", templateID), slog.F("user_id", userID), slog.Error(err))
 		return nil, xerrors.Errorf("enqueue notification (payload build): %w", err)
@@ -58,16 +123,51 @@ func (s *StoreEnqueuer) Enqueue(ctx context.Context, userID, templateID uuid.UUI
 		return nil, xerrors.Errorf("failed encoding input labels: %w", err)
 	}
 
+	now := dbtime.Now()
+
+	var dedupeHash sql.NullString
+	if s.dedupeWindow > 0 {
+		dedupeHash = sql.NullString{String: messageDedupeHash(templateID, userID, method, input, targets), Valid: true}
+	}
+
+	// Messages of digest templates are not acquirable until the end of the current digest window, at which point all
+	// of a user's messages for the template are acquired and delivered together.
+	var nextRetryAfter sql.NullTime
+	if metadata.Digest && s.digestWindow > 0 {
+		nextRetryAfter = sql.NullTime{Time: now.Truncate(s.digestWindow).Add(s.digestWindow), Valid: true}
+	}
+
 	id := uuid.New()
-	err = s.store.EnqueueNotificationMessage(ctx, database.EnqueueNotificationMessageParams{
+	params := database.EnqueueNotificationMessageParams{
 		ID:                     id,
 		UserID:                 userID,
 		NotificationTemplateID: templateID,
-		Method:                 s.method,
+		Method:                 method,
 		Payload:                input,
 		Targets:                targets,
 		CreatedBy:              createdBy,
-	})
+		DedupeHash:             dedupeHash,
+		NextRetryAfter:         nextRetryAfter,
+	}
+	err = s.store.EnqueueNotificationMessage(ctx, params)
+	if database.IsUniqueViolation(err, database.UniqueNotificationMessagesDedupeHashIndex) {
+		// The hash is held by the last identical message. If it was enqueued before the window, release the hash and
+		// try again; concurrent attempts still conflict on the hash, so only one of them is enqueued.
+		cleared, clearErr := s.store.ClearNotificationMessageDedupeHash(ctx, database.ClearNotificationMessageDedupeHashParams{
+			DedupeHash:    dedupeHash,
+			CreatedBefore: now.Add(-s.dedupeWindow),
+		})
+		if clearErr != nil {
+			return nil, xerrors.Errorf("clear expired dedupe hash: %w", clearErr)
+		}
+		if cleared > 0 {
+			err = s.store.EnqueueNotificationMessage(ctx, params)
+		}
+	}
+	if database.IsUniqueViolation(err, database.UniqueNotificationMessagesDedupeHashIndex) {
+		s.log.Debug(ctx, "notification deduplicated", slog.F("template_id", templateID), slog.F("user_id", userID))
+		return nil, ErrDuplicate
+	}
 	if err != nil {
 		s.log.Warn(ctx, "failed to enqueue notification", slog.F("template_id", templateID), slog.F("input", input), slog.Error(err))
 		return nil, xerrors.Errorf("enqueue notification: %w", err)
@@ -77,18 +177,23 @@ func (s *StoreEnqueuer) Enqueue(ctx context.Context, userID, templateID uuid.UUI
 	return &id, nil
 }
 
+// messageDedupeHash identifies a message by its template, recipient, method, payload, and targets.
+func messageDedupeHash(templateID, userID uuid.UUID, method database.NotificationMethod, payload []byte, targets []uuid.UUID) string {
+	h := sha256.New()
+	_, _ = h.Write(templateID[:])
+	_, _ = h.Write(userID[:])
+	_, _ = h.Write([]byte(method))
+	_, _ = h.Write(payload)
+	for _, target := range targets {
+		_, _ = h.Write(target[:])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // buildPayload creates the payload that the notification will for variable substitution and/or routing.
 // The payload contains information about the recipient, the event that triggered the notification, and any subsequent
 // actions which can be taken by the recipient.
-func (s *StoreEnqueuer) buildPayload(ctx context.Context, userID, templateID uuid.UUID, labels map[string]string) (*types.MessagePayload, error) {
-	metadata, err := s.store.FetchNewMessageMetadata(ctx, database.FetchNewMessageMetadataParams{
-		UserID:                 userID,
-		NotificationTemplateID: templateID,
-	})
-	if err != nil {
-		return nil, xerrors.Errorf("new message metadata: %w", err)
-	}
-
+func (s *StoreEnqueuer) buildPayload(metadata database.FetchNewMessageMetadataRow, labels map[string]string) (*types.MessagePayload, error) {
 	payload := types.MessagePayload{
 		Version: "1.0",
 
@@ -103,19 +208,26 @@ func (s *StoreEnqueuer) buildPayload(ctx context.Context, userID, templateID uui
 		// No actions yet
 	}
 
-	// Execute any templates in actions.
-	out, err := render.GoTemplate(string(metadata.Actions), payload, s.helpers)
+	actions, err := renderActions(metadata.Actions, payload, s.helpers)
+	if err != nil {
+		return nil, xerrors.Errorf("new message metadata: %w", err)
+	}
+	payload.Actions = actions
+	return &payload, nil
+}
+
+// renderActions executes any templates in the given actions and parses the result.
+func renderActions(in []byte, payload types.MessagePayload, helpers template.FuncMap) ([]types.TemplateAction, error) {
+	out, err := render.GoTemplate(string(in), payload, helpers)
 	if err != nil {
 		return nil, xerrors.Errorf("render actions: %w", err)
 	}
-	metadata.Actions = []byte(out)
 
 	var actions []types.TemplateAction
-	if err = json.Unmarshal(metadata.Actions, &actions); err != nil {
-		return nil, xerrors.Errorf("new message metadata: parse template actions: %w", err)
+	if err = json.Unmarshal([]byte(out), &actions); err != nil {
+		return nil, xerrors.Errorf("parse template actions: %w", err)
 	}
-	payload.Actions = actions
-	return &payload, nil
+	return actions, nil
 }
 
 // NoopEnqueuer implements the Enqueuer interface but performs a noop.
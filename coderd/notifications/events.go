@@ -7,9 +7,23 @@ import "github.com/google/uuid"
 
 // Workspace-related events.
 var (
-	TemplateWorkspaceDeleted           = uuid.MustParse("f517da0b-cdc9-410f-ab89-a86107c420ed")
-	TemplateWorkspaceAutobuildFailed   = uuid.MustParse("381df2a9-c0c0-4749-420f-80a9280c66f9")
-	TemplateWorkspaceDormant           = uuid.MustParse("0ea69165-ec14-4314-91f1-69566ac3c5a0")
-	TemplateWorkspaceAutoUpdated       = uuid.MustParse("c34a0c09-0704-4cac-bd1c-0c0146811c2b")
-	TemplateWorkspaceMarkedForDeletion = uuid.MustParse("51ce2fdf-c9ca-4be1-8d70-628674f9bc42")
+	TemplateWorkspaceDeleted             = uuid.MustParse("f517da0b-cdc9-410f-ab89-a86107c420ed")
+	TemplateWorkspaceAutobuildFailed     = uuid.MustParse("381df2a9-c0c0-4749-420f-80a9280c66f9")
+	TemplateWorkspaceDormant             = uuid.MustParse("0ea69165-ec14-4314-91f1-69566ac3c5a0")
+	TemplateWorkspaceAutoUpdated         = uuid.MustParse("c34a0c09-0704-4cac-bd1c-0c0146811c2b")
+	TemplateWorkspaceMarkedForDeletion   = uuid.MustParse("51ce2fdf-c9ca-4be1-8d70-628674f9bc42")
+	TemplateWorkspaceManualBuildFailed   = uuid.MustParse("0adc9c2e-cee1-4e5a-b8e9-b57b60803ef4")
+	TemplateWorkspaceAutostopApproaching = uuid.MustParse("25b1c063-adc4-4ee2-b607-9f191fe4ea0f")
+	TemplateQuotaBudgetSoftLimitReached  = uuid.MustParse("856980b8-7522-457c-b8b2-c6d8234f2428")
+	TemplateQuotaBudgetHardLimitReached  = uuid.MustParse("d44a0e8a-cd25-4523-aad6-efa9688e2430")
+)
+
+// Template-related events.
+var (
+	TemplateTemplateVersionPushed = uuid.MustParse("06b2d212-34fa-48d5-9d2f-ebe3f66ebb5b")
+)
+
+// Account-related events.
+var (
+	TemplateUserAccountSuspended = uuid.MustParse("c7206c8a-1644-4092-b90b-acc6bd198950")
 )
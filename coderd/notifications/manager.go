@@ -12,6 +12,7 @@ import (
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/codersdk"
 )
@@ -35,6 +36,14 @@ var ErrInvalidDispatchTimeout = xerrors.New("dispatch timeout must be less than
 // NOTE: The above backpressure mechanism only works within the same process, which may not be true forever, such as if
 // we split notifiers out into separate targets for greater processing throughput; in this case we will need an
 // alternative mechanism for handling backpressure.
+//
+// To avoid flooding users when many notifications are produced at once (e.g. when a template update fans out to hundreds
+// of workspaces), the following are applied:
+//   - identical messages for the same user are enqueued only once per CODER_NOTIFICATIONS_DEDUPE_WINDOW;
+//   - messages of digest templates are held back for CODER_NOTIFICATIONS_DIGEST_WINDOW, and then delivered as a single
+//     message per user and template;
+//   - no more than CODER_NOTIFICATIONS_PER_USER_RATE_LIMIT messages are delivered to a user per hour; excess messages
+//     remain queued. Messages leased in the same batch count towards this limit.
 type Manager struct {
 	cfg codersdk.NotificationsConfig
 
@@ -59,7 +68,9 @@ type Manager struct {
 //
 // helpers is a map of template helpers which are used to customize notification messages to use global settings like
 // access URL etc.
-func NewManager(cfg codersdk.NotificationsConfig, store Store, metrics *Metrics, log slog.Logger) (*Manager, error) {
+//
+// ps is used to notify subscribers when a notification is delivered to a user's inbox.
+func NewManager(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub, metrics *Metrics, log slog.Logger) (*Manager, error) {
 	// TODO(dannyk): add the ability to use multiple notification methods.
 	var method database.NotificationMethod
 	if err := method.Scan(cfg.Method.String()); err != nil {
@@ -93,16 +104,39 @@ func NewManager(cfg codersdk.NotificationsConfig, store Store, metrics *Metrics,
 		stop: make(chan any),
 		done: make(chan any),
 
-		handlers: defaultHandlers(cfg, log),
+		handlers: defaultHandlers(cfg, store, ps, log),
 	}, nil
 }
 
 // defaultHandlers builds a set of known handlers; panics if any error occurs as these handlers should be valid at compile time.
-func defaultHandlers(cfg codersdk.NotificationsConfig, log slog.Logger) map[database.NotificationMethod]Handler {
+func defaultHandlers(cfg codersdk.NotificationsConfig, store Store, ps pubsub.Pubsub, log slog.Logger) map[database.NotificationMethod]Handler {
 	return map[database.NotificationMethod]Handler{
 		database.NotificationMethodSmtp:    dispatch.NewSMTPHandler(cfg.SMTP, log.Named("dispatcher.smtp")),
 		database.NotificationMethodWebhook: dispatch.NewWebhookHandler(cfg.Webhook, log.Named("dispatcher.webhook")),
+		database.NotificationMethodSlack:   dispatch.NewSlackHandler(cfg.Slack, log.Named("dispatcher.slack")),
+		database.NotificationMethodTeams:   dispatch.NewTeamsHandler(cfg.Teams, log.Named("dispatcher.teams")),
+		database.NotificationMethodInbox:   dispatch.NewInboxHandler(store, ps, log.Named("dispatcher.inbox")),
+	}
+}
+
+// EnabledMethods returns the delivery methods the deployment can deliver notifications with: the default method, every
+// method whose destination is configured, and the inbox, which needs no configuration.
+func EnabledMethods(cfg codersdk.NotificationsConfig) []database.NotificationMethod {
+	configured := map[database.NotificationMethod]bool{
+		database.NotificationMethodSmtp:    cfg.SMTP.Smarthost.String() != "",
+		database.NotificationMethodWebhook: cfg.Webhook.Endpoint.String() != "",
+		database.NotificationMethodSlack:   cfg.Slack.Endpoint.String() != "" || cfg.Slack.BotToken.String() != "",
+		database.NotificationMethodTeams:   cfg.Teams.Endpoint.String() != "",
+		database.NotificationMethodInbox:   true,
+	}
+
+	var methods []database.NotificationMethod
+	for _, method := range database.AllNotificationMethodValues() {
+		if configured[method] || string(method) == cfg.Method.String() {
+			methods = append(methods, method)
+		}
 	}
+	return methods
 }
 
 // WithHandlers allows for tests to inject their own handlers to verify functionality.
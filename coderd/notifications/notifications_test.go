@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -27,6 +28,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/notifications/dispatch"
 	"github.com/coder/coder/v2/coderd/notifications/types"
@@ -57,7 +59,7 @@ func TestBasicNotificationRoundtrip(t *testing.T) {
 	interceptor := &syncInterceptor{Store: db}
 	cfg := defaultNotificationsConfig(method)
 	cfg.RetryInterval = serpent.Duration(time.Hour) // Ensure retries don't interfere with the test
-	mgr, err := notifications.NewManager(cfg, interceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, interceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -131,7 +133,7 @@ func TestSMTPDispatch(t *testing.T) {
 		Hello:     "localhost",
 	}
 	handler := newDispatchInterceptor(dispatch.NewSMTPHandler(cfg.SMTP, logger.Named("smtp")))
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -192,7 +194,7 @@ func TestWebhookDispatch(t *testing.T) {
 	cfg.Webhook = codersdk.NotificationsWebhookConfig{
 		Endpoint: *serpent.URLOf(endpoint),
 	}
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -290,7 +292,7 @@ func TestBackpressure(t *testing.T) {
 	storeInterceptor := &syncInterceptor{Store: db}
 
 	// GIVEN: a notification manager whose updates will be intercepted
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
@@ -385,7 +387,7 @@ func TestRetries(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: db}
 
-	mgr, err := notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, storeInterceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		assert.NoError(t, mgr.Stop(ctx))
@@ -446,7 +448,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	mgrCtx, cancelManagerCtx := context.WithCancel(context.Background())
 	t.Cleanup(cancelManagerCtx)
 
-	mgr, err := notifications.NewManager(cfg, noopInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, noopInterceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
 	require.NoError(t, err)
@@ -493,7 +495,7 @@ func TestExpiredLeaseIsRequeued(t *testing.T) {
 	// Intercept calls to submit the buffered updates to the store.
 	storeInterceptor := &syncInterceptor{Store: db}
 	handler := newDispatchInterceptor(&fakeHandler{})
-	mgr, err = notifications.NewManager(cfg, storeInterceptor, createMetrics(), logger.Named("manager"))
+	mgr, err = notifications.NewManager(cfg, storeInterceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 
@@ -534,7 +536,7 @@ func TestInvalidConfig(t *testing.T) {
 	cfg.DispatchTimeout = serpent.Duration(leasePeriod)
 
 	// WHEN: the manager is created with invalid config
-	_, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	_, err := notifications.NewManager(cfg, db, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 
 	// THEN: the manager will fail to be created, citing invalid config as error
 	require.ErrorIs(t, err, notifications.ErrInvalidDispatchTimeout)
@@ -552,7 +554,7 @@ func TestNotifierPaused(t *testing.T) {
 	user := createSampleUser(t, db)
 
 	cfg := defaultNotificationsConfig(method)
-	mgr, err := notifications.NewManager(cfg, db, createMetrics(), logger.Named("manager"))
+	mgr, err := notifications.NewManager(cfg, db, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
 	require.NoError(t, err)
 	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
 	t.Cleanup(func() {
@@ -603,6 +605,307 @@ func TestNotifierPaused(t *testing.T) {
 	}, testutil.WaitShort, testutil.IntervalFast)
 }
 
+func TestUserPreferences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, logger, db := setupInMemory(t)
+		user := createSampleUser(t, db)
+
+		cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+		enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+		require.NoError(t, err)
+
+		// GIVEN: the user has disabled the notification template
+		_, err = db.UpsertUserNotificationPreference(ctx, database.UpsertUserNotificationPreferenceParams{
+			UserID:                 user.ID,
+			NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+			Disabled:               true,
+		})
+		require.NoError(t, err)
+
+		// WHEN: a message is enqueued for the disabled template
+		_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+
+		// THEN: the message is rejected and nothing is stored
+		require.ErrorIs(t, err, notifications.ErrCannotEnqueueDisabledNotification)
+		pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+			Status: database.NotificationMessageStatusPending,
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		require.Empty(t, pending)
+
+		// WHEN: a message is enqueued for a different template
+		_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDormant, map[string]string{}, "test")
+
+		// THEN: the message is stored
+		require.NoError(t, err)
+	})
+
+	t.Run("PreferredMethod", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, logger, db := setupInMemory(t)
+		user := createSampleUser(t, db)
+
+		cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+		enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+		require.NoError(t, err)
+
+		// GIVEN: the user prefers to receive the notification via a webhook
+		_, err = db.UpsertUserNotificationPreference(ctx, database.UpsertUserNotificationPreferenceParams{
+			UserID:                 user.ID,
+			NotificationTemplateID: notifications.TemplateWorkspaceDeleted,
+			Method: database.NullNotificationMethod{
+				NotificationMethod: database.NotificationMethodWebhook,
+				Valid:              true,
+			},
+		})
+		require.NoError(t, err)
+
+		// WHEN: messages are enqueued
+		preferredID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{}, "test")
+		require.NoError(t, err)
+		defaultID, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDormant, map[string]string{}, "test")
+		require.NoError(t, err)
+
+		// THEN: the preferred method is only used for the template it was set on
+		pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+			Status: database.NotificationMessageStatusPending,
+			Limit:  10,
+		})
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		for _, msg := range pending {
+			switch msg.ID {
+			case *preferredID:
+				require.Equal(t, database.NotificationMethodWebhook, msg.Method)
+			case *defaultID:
+				require.Equal(t, database.NotificationMethodSmtp, msg.Method)
+			default:
+				t.Fatalf("unexpected message %s", msg.ID)
+			}
+		}
+	})
+}
+
+func TestDeduplication(t *testing.T) {
+	t.Parallel()
+
+	ctx, logger, db := setupInMemory(t)
+	user := createSampleUser(t, db)
+
+	// GIVEN: an enqueuer which deduplicates messages
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	cfg.DedupeWindow = serpent.Duration(time.Hour)
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	// WHEN: a message is enqueued
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "a"}, "test")
+	require.NoError(t, err)
+
+	// THEN: an identical message is rejected
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "a"}, "test")
+	require.ErrorIs(t, err, notifications.ErrDuplicate)
+
+	// THEN: messages with different labels or templates are not duplicates
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "b"}, "test")
+	require.NoError(t, err)
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDormant, map[string]string{"name": "a"}, "test")
+	require.NoError(t, err)
+
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+}
+
+func TestDeduplicationWindow(t *testing.T) {
+	t.Parallel()
+
+	ctx, logger, db := setupInMemory(t)
+	user := createSampleUser(t, db)
+
+	// GIVEN: an enqueuer which deduplicates messages within a short window
+	const window = 200 * time.Millisecond
+	cfg := defaultNotificationsConfig(database.NotificationMethodSmtp)
+	cfg.DedupeWindow = serpent.Duration(window)
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+	enqueue := func() error {
+		_, err := enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "a"}, "test")
+		return err
+	}
+
+	// WHEN: a message is enqueued
+	require.NoError(t, enqueue())
+	first := time.Now()
+
+	// THEN: identical messages are dropped until the window since the message has passed, and dropping them doesn't
+	// extend the window
+	require.Eventually(t, func() bool {
+		err := enqueue()
+		if err != nil {
+			assert.ErrorIs(t, err, notifications.ErrDuplicate)
+			return false
+		}
+		return true
+	}, testutil.WaitShort, testutil.IntervalFast)
+	require.GreaterOrEqual(t, time.Since(first), window)
+
+	// THEN: the window restarts with the message that was enqueued
+	require.ErrorIs(t, enqueue(), notifications.ErrDuplicate)
+
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+}
+
+func TestDigest(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; it relies on business-logic only implemented in the database")
+	}
+
+	ctx, logger, db := setup(t)
+	method := database.NotificationMethodSmtp
+
+	// GIVEN: a manager which holds back messages of digest templates for a short window, and acquires one message at a
+	// time
+	const window = 2 * time.Second
+	handler := &recordingHandler{}
+	interceptor := &syncInterceptor{Store: db}
+	cfg := defaultNotificationsConfig(method)
+	cfg.DigestWindow = serpent.Duration(window)
+	cfg.LeaseCount = 1
+	mgr, err := notifications.NewManager(cfg, interceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
+	require.NoError(t, err)
+	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
+	t.Cleanup(func() {
+		assert.NoError(t, mgr.Stop(ctx))
+	})
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	user := createSampleUser(t, db)
+	otherUser := dbgen.User(t, db, database.User{})
+
+	// Start enqueuing at the beginning of a window so that all messages fall in the same one.
+	time.Sleep(time.Until(time.Now().Truncate(window).Add(window)))
+
+	// WHEN: several messages of a digest template are enqueued, interleaved with a message of the same template for
+	// another user, along with a message of a regular template
+	names := []string{"alpha", "beta", "gamma"}
+	for _, name := range names {
+		_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceAutoUpdated, map[string]string{"name": name, "template_version_name": "v2"}, "test")
+		require.NoError(t, err)
+		if name == names[0] {
+			_, err = enq.Enqueue(ctx, otherUser.ID, notifications.TemplateWorkspaceAutoUpdated, map[string]string{"name": "other", "template_version_name": "v2"}, "test")
+			require.NoError(t, err)
+		}
+	}
+	_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"name": "delta"}, "test")
+	require.NoError(t, err)
+
+	mgr.Run(ctx)
+
+	// THEN: all messages are marked as sent...
+	require.Eventually(t, func() bool {
+		return interceptor.sent.Load() == int32(len(names)+2)
+	}, testutil.WaitLong, testutil.IntervalFast)
+
+	// ...but only three messages are delivered: the regular message, the other user's message, and a single digest of
+	// the others, even though they were acquired one at a time
+	handler.mu.RLock()
+	defer handler.mu.RUnlock()
+	require.Len(t, handler.delivered, 3)
+	var digest *recordedDelivery
+	for i := range handler.delivered {
+		if strings.Contains(handler.delivered[i].title, "updated automatically") && strings.Contains(handler.delivered[i].body, names[0]) {
+			digest = &handler.delivered[i]
+		}
+	}
+	require.NotNil(t, digest)
+	require.Contains(t, digest.title, "(and 2 more)")
+	for _, name := range names {
+		require.Contains(t, digest.body, name)
+	}
+	// Each message has a "View workspace" action for a different workspace.
+	require.Len(t, digest.actions, len(names))
+}
+
+func TestPerUserRateLimit(t *testing.T) {
+	t.Parallel()
+
+	// SETUP
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; it relies on business-logic only implemented in the database")
+	}
+
+	ctx, logger, db := setup(t)
+	method := database.NotificationMethodSmtp
+
+	// GIVEN: a manager which delivers at most 2 messages per user per hour, acquiring all messages in a single batch
+	const limit = 2
+	handler := &fakeHandler{}
+	interceptor := &syncInterceptor{Store: db}
+	cfg := defaultNotificationsConfig(method)
+	cfg.PerUserRateLimit = limit
+	cfg.LeaseCount = 10
+	mgr, err := notifications.NewManager(cfg, interceptor, pubsub.NewInMemory(), createMetrics(), logger.Named("manager"))
+	require.NoError(t, err)
+	mgr.WithHandlers(map[database.NotificationMethod]notifications.Handler{method: handler})
+	t.Cleanup(func() {
+		assert.NoError(t, mgr.Stop(ctx))
+	})
+	enq, err := notifications.NewStoreEnqueuer(cfg, db, defaultHelpers(), logger.Named("enqueuer"))
+	require.NoError(t, err)
+
+	user := createSampleUser(t, db)
+	otherUser := dbgen.User(t, db, database.User{})
+
+	// WHEN: more messages than the limit are enqueued for a user, and one for another user
+	for i := 0; i < limit+2; i++ {
+		_, err = enq.Enqueue(ctx, user.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"type": "success", "i": fmt.Sprintf("%d", i)}, "test")
+		require.NoError(t, err)
+	}
+	otherID, err := enq.Enqueue(ctx, otherUser.ID, notifications.TemplateWorkspaceDeleted, map[string]string{"type": "success"}, "test")
+	require.NoError(t, err)
+
+	mgr.Run(ctx)
+
+	// THEN: the other user's message is delivered, as are the first messages up to the limit
+	require.Eventually(t, func() bool {
+		handler.mu.RLock()
+		defer handler.mu.RUnlock()
+		return slices.Contains(handler.succeeded, otherID.String()) && len(handler.succeeded) == limit+1
+	}, testutil.WaitLong, testutil.IntervalFast)
+
+	// THEN: the remaining messages stay queued
+	require.Never(t, func() bool {
+		handler.mu.RLock()
+		defer handler.mu.RUnlock()
+		return len(handler.succeeded) > limit+1
+	}, time.Second, testutil.IntervalFast)
+	pending, err := db.GetNotificationMessagesByStatus(ctx, database.GetNotificationMessagesByStatusParams{
+		Status: database.NotificationMessageStatusPending,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+}
+
 type fakeHandler struct {
 	mu                sync.RWMutex
 	succeeded, failed []string
@@ -623,6 +926,27 @@ func (f *fakeHandler) Dispatcher(payload types.MessagePayload, _, _ string) (dis
 	}, nil
 }
 
+type recordedDelivery struct {
+	title, body string
+	actions     []types.TemplateAction
+}
+
+// recordingHandler records every message it is asked to deliver.
+type recordingHandler struct {
+	mu        sync.RWMutex
+	delivered []recordedDelivery
+}
+
+func (r *recordingHandler) Dispatcher(payload types.MessagePayload, title, body string) (dispatch.DeliveryFunc, error) {
+	return func(_ context.Context, _ uuid.UUID) (retryable bool, err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.delivered = append(r.delivered, recordedDelivery{title: title, body: body, actions: payload.Actions})
+		return false, nil
+	}, nil
+}
+
 // noopStoreSyncer pretends to perform store syncs, but does not; leading to messages being stuck in "leased" state.
 type noopStoreSyncer struct {
 	*acquireSignalingInterceptor
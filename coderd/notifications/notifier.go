@@ -3,6 +3,8 @@ package notifications
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -143,12 +145,14 @@ func (n *notifier) process(ctx context.Context, success chan<- dispatchResult, f
 	}
 
 	var eg errgroup.Group
-	for _, msg := range msgs {
+	for _, group := range digest(msgs) {
 		// A message failing to be prepared correctly should not affect other messages.
-		deliverFn, err := n.prepare(ctx, msg)
+		deliverFn, err := n.prepare(ctx, group)
 		if err != nil {
-			n.log.Warn(ctx, "dispatcher construction failed", slog.F("msg_id", msg.ID), slog.Error(err))
-			failure <- n.newFailedDispatch(msg, err, false)
+			n.log.Warn(ctx, "dispatcher construction failed", slog.F("msg_id", group[0].ID), slog.F("count", len(group)), slog.Error(err))
+			for _, msg := range group {
+				failure <- n.newFailedDispatch(msg, err, false)
+			}
 
 			n.metrics.PendingUpdates.Set(float64(len(success) + len(failure)))
 			continue
@@ -156,7 +160,7 @@ func (n *notifier) process(ctx context.Context, success chan<- dispatchResult, f
 
 		eg.Go(func() error {
 			// Dispatch must only return an error for exceptional cases, NOT for failed messages.
-			return n.deliver(ctx, msg, deliverFn, success, failure)
+			return n.deliver(ctx, group, deliverFn, success, failure)
 		})
 	}
 
@@ -173,10 +177,11 @@ func (n *notifier) process(ctx context.Context, success chan<- dispatchResult, f
 // messages until they are dispatched - or until the lease expires (in exceptional cases).
 func (n *notifier) fetch(ctx context.Context) ([]database.AcquireNotificationMessagesRow, error) {
 	msgs, err := n.store.AcquireNotificationMessages(ctx, database.AcquireNotificationMessagesParams{
-		Count:           int32(n.cfg.LeaseCount),
-		MaxAttemptCount: int32(n.cfg.MaxSendAttempts),
-		NotifierID:      n.id,
-		LeaseSeconds:    int32(n.cfg.LeasePeriod.Value().Seconds()),
+		Count:            int32(n.cfg.LeaseCount),
+		MaxAttemptCount:  int32(n.cfg.MaxSendAttempts),
+		NotifierID:       n.id,
+		LeaseSeconds:     int32(n.cfg.LeasePeriod.Value().Seconds()),
+		PerUserRateLimit: int32(n.cfg.PerUserRateLimit),
 	})
 	if err != nil {
 		return nil, xerrors.Errorf("acquire messages: %w", err)
@@ -185,44 +190,107 @@ func (n *notifier) fetch(ctx context.Context) ([]database.AcquireNotificationMes
 	return msgs, nil
 }
 
+// digest groups the messages of digest templates by recipient, template, and method so that each group can be
+// delivered as a single message. All due messages of a group are acquired together, so a group is never split across
+// batches. Messages of other templates are returned in groups of their own.
+// The order in which messages were acquired is preserved.
+func digest(msgs []database.AcquireNotificationMessagesRow) [][]database.AcquireNotificationMessagesRow {
+	type digestKey struct {
+		userID, templateID uuid.UUID
+		method             database.NotificationMethod
+	}
+
+	var groups [][]database.AcquireNotificationMessagesRow
+	index := make(map[digestKey]int)
+	for _, msg := range msgs {
+		if !msg.Digest {
+			groups = append(groups, []database.AcquireNotificationMessagesRow{msg})
+			continue
+		}
+
+		key := digestKey{userID: msg.UserID, templateID: msg.TemplateID, method: msg.Method}
+		if i, ok := index[key]; ok {
+			groups[i] = append(groups[i], msg)
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, []database.AcquireNotificationMessagesRow{msg})
+	}
+	return groups
+}
+
 // prepare has two roles:
 // 1. render the title & body templates
-// 2. build a dispatcher from the given message, payload, and these templates - to be used for delivering the notification
-func (n *notifier) prepare(ctx context.Context, msg database.AcquireNotificationMessagesRow) (dispatch.DeliveryFunc, error) {
+// 2. build a dispatcher from the given messages, payloads, and these templates - to be used for delivering the notification
+//
+// When given more than one message, they are rendered into a single digest message: the bodies of all messages are
+// combined, and the title of the first message is used.
+func (n *notifier) prepare(ctx context.Context, msgs []database.AcquireNotificationMessagesRow) (dispatch.DeliveryFunc, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
-	// NOTE: when we change the format of the MessagePayload, we have to bump its version and handle unmarshalling
-	// differently here based on that version.
-	var payload types.MessagePayload
-	err := json.Unmarshal(msg.Payload, &payload)
-	if err != nil {
-		return nil, xerrors.Errorf("unmarshal payload: %w", err)
-	}
-
-	handler, ok := n.handlers[msg.Method]
+	// All messages in a group share the same method.
+	handler, ok := n.handlers[msgs[0].Method]
 	if !ok {
-		return nil, xerrors.Errorf("failed to resolve handler %q", msg.Method)
+		return nil, xerrors.Errorf("failed to resolve handler %q", msgs[0].Method)
 	}
 
-	var title, body string
-	if title, err = render.GoTemplate(msg.TitleTemplate, payload, nil); err != nil {
-		return nil, xerrors.Errorf("render title: %w", err)
-	}
-	if body, err = render.GoTemplate(msg.BodyTemplate, payload, nil); err != nil {
-		return nil, xerrors.Errorf("render body: %w", err)
+	var (
+		digestPayload types.MessagePayload
+		titles        []string
+		bodies        []string
+		seenActions   = make(map[string]struct{})
+	)
+	for i, msg := range msgs {
+		// NOTE: when we change the format of the MessagePayload, we have to bump its version and handle unmarshalling
+		// differently here based on that version.
+		var payload types.MessagePayload
+		err := json.Unmarshal(msg.Payload, &payload)
+		if err != nil {
+			return nil, xerrors.Errorf("unmarshal payload: %w", err)
+		}
+
+		var title, body string
+		if title, err = render.GoTemplate(msg.TitleTemplate, payload, nil); err != nil {
+			return nil, xerrors.Errorf("render title: %w", err)
+		}
+		if body, err = render.GoTemplate(msg.BodyTemplate, payload, nil); err != nil {
+			return nil, xerrors.Errorf("render body: %w", err)
+		}
+		titles = append(titles, title)
+		bodies = append(bodies, body)
+
+		if i == 0 {
+			digestPayload = payload
+			digestPayload.Actions = nil
+		}
+		// Actions which are identical across messages (e.g. "View workspaces") are only included once.
+		for _, action := range payload.Actions {
+			if _, ok := seenActions[action.URL]; ok {
+				continue
+			}
+			seenActions[action.URL] = struct{}{}
+			digestPayload.Actions = append(digestPayload.Actions, action)
+		}
 	}
 
-	return handler.Dispatcher(payload, title, body)
+	title := titles[0]
+	if len(msgs) > 1 {
+		title = fmt.Sprintf("%s (and %d more)", title, len(msgs)-1)
+	}
+	return handler.Dispatcher(digestPayload, title, strings.Join(bodies, "\n\n---\n\n"))
 }
 
 // deliver sends a given notification message via its defined method.
 // This method *only* returns an error when a context error occurs; any other error is interpreted as a failure to
 // deliver the notification and as such the message will be marked as failed (to later be optionally retried).
-func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotificationMessagesRow, deliver dispatch.DeliveryFunc, success, failure chan<- dispatchResult) error {
+//
+// A digest of several messages is delivered once, using the ID of its first message, and the result is recorded
+// against all of its messages.
+func (n *notifier) deliver(ctx context.Context, msgs []database.AcquireNotificationMessagesRow, deliver dispatch.DeliveryFunc, success, failure chan<- dispatchResult) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -231,7 +299,11 @@ func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotification
 
 	ctx, cancel := context.WithTimeout(ctx, n.cfg.DispatchTimeout.Value())
 	defer cancel()
+	msg := msgs[0]
 	logger := n.log.With(slog.F("msg_id", msg.ID), slog.F("method", msg.Method), slog.F("attempt", msg.AttemptCount+1))
+	if len(msgs) > 1 {
+		logger = logger.With(slog.F("digest_count", len(msgs)))
+	}
 
 	if msg.AttemptCount > 0 {
 		n.metrics.RetryCount.WithLabelValues(string(n.method), msg.TemplateID.String()).Inc()
@@ -259,21 +331,25 @@ func (n *notifier) deliver(ctx context.Context, msg database.AcquireNotification
 			return err
 		}
 
-		select {
-		case <-ctx.Done():
-			logger.Warn(context.Background(), "cannot record dispatch failure result", slog.Error(ctx.Err()))
-			return ctx.Err()
-		case failure <- n.newFailedDispatch(msg, err, retryable):
-			logger.Warn(ctx, "message dispatch failed", slog.Error(err))
+		for _, m := range msgs {
+			select {
+			case <-ctx.Done():
+				logger.Warn(context.Background(), "cannot record dispatch failure result", slog.Error(ctx.Err()))
+				return ctx.Err()
+			case failure <- n.newFailedDispatch(m, err, retryable):
+			}
 		}
+		logger.Warn(ctx, "message dispatch failed", slog.Error(err))
 	} else {
-		select {
-		case <-ctx.Done():
-			logger.Warn(context.Background(), "cannot record dispatch success result", slog.Error(ctx.Err()))
-			return ctx.Err()
-		case success <- n.newSuccessfulDispatch(msg):
-			logger.Debug(ctx, "message dispatch succeeded")
+		for _, m := range msgs {
+			select {
+			case <-ctx.Done():
+				logger.Warn(context.Background(), "cannot record dispatch success result", slog.Error(ctx.Err()))
+				return ctx.Err()
+			case success <- n.newSuccessfulDispatch(m):
+			}
 		}
+		logger.Debug(ctx, "message dispatch succeeded")
 	}
 	n.metrics.PendingUpdates.Set(float64(len(success) + len(failure)))
 
@@ -0,0 +1,56 @@
+package notifications
+
+import (
+	"fmt"
+	"text/template"
+
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/notifications/render"
+	"github.com/coder/coder/v2/coderd/notifications/types"
+)
+
+// Preview is a notification template rendered against a given payload, as it would be delivered.
+type Preview struct {
+	Title   string
+	Body    string
+	Actions []types.TemplateAction
+}
+
+// RenderPreview renders the effective title, body, and actions of the given template against the payload. Actions are
+// rendered with the given helpers, matching what happens when a notification is enqueued; the title and body are
+// rendered without them, matching what happens when it is dispatched.
+func RenderPreview(tmpl database.NotificationTemplate, payload types.MessagePayload, helpers template.FuncMap) (*Preview, error) {
+	tmpl = tmpl.Effective()
+
+	payload.NotificationName = tmpl.Name
+	actions, err := renderActions(tmpl.Actions, payload, helpers)
+	if err != nil {
+		return nil, err
+	}
+	payload.Actions = actions
+
+	title, err := render.GoTemplate(tmpl.TitleTemplate, payload, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("render title: %w", err)
+	}
+	body, err := render.GoTemplate(tmpl.BodyTemplate, payload, nil)
+	if err != nil {
+		return nil, xerrors.Errorf("render body: %w", err)
+	}
+
+	return &Preview{Title: title, Body: body, Actions: actions}, nil
+}
+
+// SampleLabels returns a placeholder value for each label which the given template refers to, so that it can be
+// previewed without a real event.
+func SampleLabels(tmpl database.NotificationTemplate) map[string]string {
+	tmpl = tmpl.Effective()
+
+	labels := make(map[string]string)
+	for _, name := range render.LabelNames(tmpl.TitleTemplate, tmpl.BodyTemplate, string(tmpl.Actions)) {
+		labels[name] = fmt.Sprintf("[%s]", name)
+	}
+	return labels
+}
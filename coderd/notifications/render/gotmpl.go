@@ -1,6 +1,8 @@
 package render
 
 import (
+	"regexp"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -24,3 +26,18 @@ func GoTemplate(in string, payload types.MessagePayload, extraFuncs template.Fun
 
 	return out.String(), nil
 }
+
+var labelRefRe = regexp.MustCompile(`\.Labels\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// LabelNames returns the sorted, unique names of the labels which the given templates refer to, e.g. "name" for
+// {{.Labels.name}}.
+func LabelNames(templates ...string) []string {
+	var names []string
+	for _, in := range templates {
+		for _, match := range labelRefRe.FindAllStringSubmatch(in, -1) {
+			names = append(names, match[1])
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
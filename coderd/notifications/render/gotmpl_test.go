@@ -77,3 +77,15 @@ func TestGoTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelNames(t *testing.T) {
+	t.Parallel()
+
+	names := render.LabelNames(
+		"Workspace {{.Labels.name}} was {{ .Labels.reason }}",
+		`[{"url": "{{ base_url }}/@{{.UserUsername}}/{{.Labels.name}}"}]`,
+		"Hi {{.UserName}}",
+	)
+	require.Equal(t, []string{"name", "reason"}, names)
+	require.Empty(t, render.LabelNames("no labels here"))
+}
@@ -18,10 +18,12 @@ type Store interface {
 	AcquireNotificationMessages(ctx context.Context, params database.AcquireNotificationMessagesParams) ([]database.AcquireNotificationMessagesRow, error)
 	BulkMarkNotificationMessagesSent(ctx context.Context, arg database.BulkMarkNotificationMessagesSentParams) (int64, error)
 	BulkMarkNotificationMessagesFailed(ctx context.Context, arg database.BulkMarkNotificationMessagesFailedParams) (int64, error)
+	ClearNotificationMessageDedupeHash(ctx context.Context, arg database.ClearNotificationMessageDedupeHashParams) (int64, error)
 	EnqueueNotificationMessage(ctx context.Context, arg database.EnqueueNotificationMessageParams) error
 	FetchNewMessageMetadata(ctx context.Context, arg database.FetchNewMessageMetadataParams) (database.FetchNewMessageMetadataRow, error)
 	GetNotificationMessagesByStatus(ctx context.Context, arg database.GetNotificationMessagesByStatusParams) ([]database.NotificationMessage, error)
 	GetNotificationsSettings(ctx context.Context) (string, error)
+	InsertInboxNotification(ctx context.Context, arg database.InsertInboxNotificationParams) error
 }
 
 // Handler is responsible for preparing and delivering a notification by a given method.
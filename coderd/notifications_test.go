@@ -1,12 +1,25 @@
 package coderd_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/notifications/dispatch"
+	"github.com/coder/coder/v2/coderd/notifications/types"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/testutil"
 )
@@ -93,3 +106,404 @@ func TestUpdateNotificationsSettings(t *testing.T) {
 		require.Equal(t, expected.NotifierPaused, actual.NotifierPaused)
 	})
 }
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only inserted by migrations")
+	}
+
+	t.Run("Update", func(t *testing.T) {
+		t.Parallel()
+
+		dv := coderdtest.DeploymentValues(t)
+		require.NoError(t, dv.Notifications.Webhook.Endpoint.Set("https://example.com/webhook"))
+		client := coderdtest.New(t, &coderdtest.Options{DeploymentValues: dv})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given
+		prefs, err := member.GetUserNotificationPreferences(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Empty(t, prefs)
+
+		// when
+		prefs, err = member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{
+				{NotificationTemplateID: notifications.TemplateWorkspaceAutoUpdated, Disabled: true},
+				{NotificationTemplateID: notifications.TemplateWorkspaceDeleted, Method: string(database.NotificationMethodWebhook)},
+			},
+		})
+		require.NoError(t, err)
+
+		// then
+		require.Len(t, prefs, 2)
+		for _, pref := range prefs {
+			switch pref.NotificationTemplateID {
+			case notifications.TemplateWorkspaceAutoUpdated:
+				require.True(t, pref.Disabled)
+				require.Empty(t, pref.Method)
+			case notifications.TemplateWorkspaceDeleted:
+				require.False(t, pref.Disabled)
+				require.Equal(t, string(database.NotificationMethodWebhook), pref.Method)
+			default:
+				t.Fatalf("unexpected preference for template %s", pref.NotificationTemplateID)
+			}
+		}
+	})
+
+	t.Run("InvalidMethod", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{
+				{NotificationTemplateID: notifications.TemplateWorkspaceDeleted, Method: "carrier-pigeon"},
+			},
+		})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+
+	t.Run("MethodNotEnabled", func(t *testing.T) {
+		t.Parallel()
+
+		// Only the default method, SMTP, and the inbox are enabled.
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		_, err := member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{
+				{NotificationTemplateID: notifications.TemplateWorkspaceDeleted, Method: string(database.NotificationMethodSlack)},
+			},
+		})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+		require.Len(t, sdkError.Validations, 1)
+		require.Equal(t, "preferences[0].method", sdkError.Validations[0].Field)
+
+		prefs, err := member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{
+				{NotificationTemplateID: notifications.TemplateWorkspaceDeleted, Method: string(database.NotificationMethodInbox)},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, prefs, 1)
+	})
+
+	t.Run("Mandatory", func(t *testing.T) {
+		t.Parallel()
+
+		auditor := audit.NewMock()
+		client := coderdtest.New(t, &coderdtest.Options{Auditor: auditor})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given: only admins can mark templates as mandatory
+		_, err := member.UpdateNotificationTemplateMandatory(ctx, notifications.TemplateWorkspaceDormant, codersdk.UpdateNotificationTemplateMandatory{Mandatory: true})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+
+		template, err := client.UpdateNotificationTemplateMandatory(ctx, notifications.TemplateWorkspaceDormant, codersdk.UpdateNotificationTemplateMandatory{Mandatory: true})
+		require.NoError(t, err)
+		require.True(t, template.Mandatory)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			ResourceType: database.ResourceTypeNotificationTemplate,
+			ResourceID:   notifications.TemplateWorkspaceDormant,
+			Action:       database.AuditActionWrite,
+			StatusCode:   http.StatusOK,
+		}))
+
+		// when
+		_, err = member.UpdateUserNotificationPreferences(ctx, codersdk.Me, codersdk.UpdateUserNotificationPreferences{
+			Preferences: []codersdk.UpdateNotificationPreference{
+				{NotificationTemplateID: notifications.TemplateWorkspaceDormant, Disabled: true},
+			},
+		})
+
+		// then
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+	})
+}
+
+func TestNotificationTemplateOverride(t *testing.T) {
+	t.Parallel()
+
+	if !dbtestutil.WillUsePostgres() {
+		t.Skip("This test requires postgres; notification templates are only inserted by migrations")
+	}
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// Members can read templates, but not change them.
+		_, err := member.GetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+		require.NoError(t, err)
+
+		_, err = member.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			TitleTemplate: "Gone",
+		})
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+
+		_, err = member.ResetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+
+		_, err = member.TestNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.TestNotificationTemplateRequest{})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusForbidden, sdkError.StatusCode())
+	})
+
+	t.Run("UpdateTestReset", func(t *testing.T) {
+		t.Parallel()
+
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+		auditor := audit.NewMock()
+		client := coderdtest.New(t, &coderdtest.Options{NotificationsEnqueuer: notifyEnq, Auditor: auditor})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		original, err := client.GetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+		require.NoError(t, err)
+		require.False(t, original.Customized)
+
+		// when
+		template, err := client.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			TitleTemplate: "Workspace {{.Labels.name}} removed",
+			Actions:       `[{"label": "Runbook", "url": "https://runbooks.example.com/{{.Labels.name}}"}]`,
+		})
+		require.NoError(t, err)
+
+		// then
+		require.True(t, template.Customized)
+		require.Equal(t, "Workspace {{.Labels.name}} removed", template.TitleTemplate)
+		require.Equal(t, original.BodyTemplate, template.BodyTemplate)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			ResourceType: database.ResourceTypeNotificationTemplate,
+			ResourceID:   notifications.TemplateWorkspaceDeleted,
+			Action:       database.AuditActionWrite,
+			StatusCode:   http.StatusOK,
+		}))
+
+		preview, err := client.TestNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.TestNotificationTemplateRequest{
+			Labels: map[string]string{"name": "bobby-workspace"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Workspace bobby-workspace removed", preview.Title)
+		require.Equal(t, []codersdk.NotificationTemplateAction{
+			{Label: "Runbook", URL: "https://runbooks.example.com/bobby-workspace"},
+		}, preview.Actions)
+		// Labels which were not given are filled with sample values.
+		require.Equal(t, "[reason]", preview.Labels["reason"])
+
+		require.Len(t, notifyEnq.Sent, 1)
+		require.Equal(t, firstUser.UserID, notifyEnq.Sent[0].UserID)
+		require.Equal(t, notifications.TemplateWorkspaceDeleted, notifyEnq.Sent[0].TemplateID)
+		require.Equal(t, "bobby-workspace", notifyEnq.Sent[0].Labels["name"])
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			ResourceType: database.ResourceTypeNotificationTemplate,
+			ResourceID:   notifications.TemplateWorkspaceDeleted,
+			Action:       database.AuditActionCreate,
+			StatusCode:   http.StatusOK,
+		}))
+
+		auditor.ResetLogs()
+		template, err = client.ResetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+		require.NoError(t, err)
+		require.False(t, template.Customized)
+		require.Equal(t, original.TitleTemplate, template.TitleTemplate)
+		require.Equal(t, original.Actions, template.Actions)
+		require.True(t, auditor.Contains(t, database.AuditLog{
+			ResourceType: database.ResourceTypeNotificationTemplate,
+			ResourceID:   notifications.TemplateWorkspaceDeleted,
+			Action:       database.AuditActionWrite,
+			StatusCode:   http.StatusOK,
+		}))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+
+		client := coderdtest.New(t, nil)
+		_ = coderdtest.CreateFirstUser(t, client)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		var sdkError *codersdk.Error
+		_, err := client.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			BodyTemplate: "Hi {{.UserName",
+		})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+
+		_, err = client.UpdateNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted, codersdk.UpdateNotificationTemplateRequest{
+			Actions: `{"label": "not an array"}`,
+		})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusBadRequest, sdkError.StatusCode())
+
+		template, err := client.GetNotificationTemplate(ctx, notifications.TemplateWorkspaceDeleted)
+		require.NoError(t, err)
+		require.False(t, template.Customized)
+	})
+}
+
+func TestInboxNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ListAndMarkRead", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given
+		older := dbgen.InboxNotification(t, db, database.InboxNotification{
+			UserID:    memberUser.ID,
+			Title:     "older",
+			CreatedAt: dbtime.Now().Add(-time.Hour),
+		})
+		newer := dbgen.InboxNotification(t, db, database.InboxNotification{
+			UserID:  memberUser.ID,
+			Title:   "newer",
+			Actions: json.RawMessage(`[{"label":"View workspace","url":"https://coder.com"}]`),
+		})
+		// A notification for another user must not be visible.
+		_ = dbgen.InboxNotification(t, db, database.InboxNotification{UserID: firstUser.UserID})
+
+		// when
+		res, err := member.ListInboxNotifications(ctx, codersdk.Me, codersdk.ListInboxNotificationsRequest{})
+		require.NoError(t, err)
+
+		// then
+		require.Equal(t, 2, res.UnreadCount)
+		require.Len(t, res.Notifications, 2)
+		require.Equal(t, newer.ID, res.Notifications[0].ID)
+		require.Equal(t, older.ID, res.Notifications[1].ID)
+		require.Equal(t, []codersdk.InboxNotificationAction{{Label: "View workspace", URL: "https://coder.com"}}, res.Notifications[0].Actions)
+		require.Empty(t, res.Notifications[1].Actions)
+
+		// Paging through older notifications.
+		res, err = member.ListInboxNotifications(ctx, codersdk.Me, codersdk.ListInboxNotificationsRequest{
+			CreatedBefore: newer.CreatedAt,
+			Limit:         1,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Notifications, 1)
+		require.Equal(t, older.ID, res.Notifications[0].ID)
+
+		// when
+		notif, err := member.UpdateInboxNotificationReadStatus(ctx, codersdk.Me, newer.ID, codersdk.UpdateInboxNotificationReadStatusRequest{IsRead: true})
+		require.NoError(t, err)
+		require.NotNil(t, notif.ReadAt)
+
+		// then
+		res, err = member.ListInboxNotifications(ctx, codersdk.Me, codersdk.ListInboxNotificationsRequest{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, 1, res.UnreadCount)
+		require.Len(t, res.Notifications, 1)
+		require.Equal(t, older.ID, res.Notifications[0].ID)
+
+		// when
+		err = member.MarkAllInboxNotificationsAsRead(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		// then
+		res, err = member.ListInboxNotifications(ctx, codersdk.Me, codersdk.ListInboxNotificationsRequest{UnreadOnly: true})
+		require.NoError(t, err)
+		require.Zero(t, res.UnreadCount)
+		require.Empty(t, res.Notifications)
+	})
+
+	t.Run("OtherUsersNotification", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, _ := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+
+		// given
+		notif := dbgen.InboxNotification(t, db, database.InboxNotification{UserID: firstUser.UserID})
+
+		// when
+		_, err := member.UpdateInboxNotificationReadStatus(ctx, codersdk.Me, notif.ID, codersdk.UpdateInboxNotificationReadStatusRequest{IsRead: true})
+
+		// then
+		var sdkError *codersdk.Error
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+
+		_, err = member.ListInboxNotifications(ctx, firstUser.UserID.String(), codersdk.ListInboxNotificationsRequest{})
+		require.ErrorAs(t, err, &sdkError)
+		require.Equal(t, http.StatusNotFound, sdkError.StatusCode())
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		t.Parallel()
+
+		db, ps := dbtestutil.NewDB(t)
+		client := coderdtest.New(t, &coderdtest.Options{Database: db, Pubsub: ps})
+		firstUser := coderdtest.CreateFirstUser(t, client)
+		member, memberUser := coderdtest.CreateAnotherUser(t, client, firstUser.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		logger := slogtest.Make(t, nil)
+
+		// given
+		notifs, err := member.WatchInboxNotifications(ctx, codersdk.Me)
+		require.NoError(t, err)
+
+		// when
+		handler := dispatch.NewInboxHandler(db, ps, logger)
+		deliver, err := handler.Dispatcher(types.MessagePayload{
+			Version:          "1.0",
+			NotificationName: "test",
+			UserID:           memberUser.ID.String(),
+		}, "Workspace **bob** deleted", "Your workspace was deleted.")
+		require.NoError(t, err)
+		msgID := uuid.New()
+		_, err = deliver(ctx, msgID)
+		require.NoError(t, err)
+
+		// then
+		notif := testutil.RequireRecvCtx(ctx, t, notifs)
+		require.Equal(t, msgID, notif.ID)
+		require.Equal(t, memberUser.ID, notif.UserID)
+		require.Equal(t, "Workspace bob deleted", notif.Title)
+		require.Equal(t, "Your workspace was deleted.", notif.Content)
+		require.Nil(t, notif.ReadAt)
+	})
+}
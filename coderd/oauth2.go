@@ -3,8 +3,11 @@ package coderd
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"slices"
 
 	"github.com/google/uuid"
+	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/buildinfo"
 	"github.com/coder/coder/v2/coderd/audit"
@@ -14,6 +17,7 @@ import (
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/identityprovider"
+	"github.com/coder/coder/v2/coderd/util/slice"
 	"github.com/coder/coder/v2/codersdk"
 )
 
@@ -113,6 +117,10 @@ func (api *API) postOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &req) {
 		return
 	}
+	clientType := database.OAuth2ProviderAppClientTypeConfidential
+	if req.ClientType != "" {
+		clientType = database.OAuth2ProviderAppClientType(req.ClientType)
+	}
 	app, err := api.Database.InsertOAuth2ProviderApp(ctx, database.InsertOAuth2ProviderAppParams{
 		ID:          uuid.New(),
 		CreatedAt:   dbtime.Now(),
@@ -120,6 +128,13 @@ func (api *API) postOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 		Name:        req.Name,
 		Icon:        req.Icon,
 		CallbackURL: req.CallbackURL,
+		ClientType:  clientType,
+		// Apps created by administrators may use every grant type.
+		GrantTypes: slice.ToStrings([]codersdk.OAuth2ProviderGrantType{
+			codersdk.OAuth2ProviderGrantTypeAuthorizationCode,
+			codersdk.OAuth2ProviderGrantTypeRefreshToken,
+			codersdk.OAuth2ProviderGrantTypeDeviceCode,
+		}),
 	})
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -160,12 +175,18 @@ func (api *API) putOAuth2ProviderApp(rw http.ResponseWriter, r *http.Request) {
 	if !httpapi.Read(ctx, rw, r, &req) {
 		return
 	}
+	// Omitting the client type keeps the current one.
+	clientType := app.ClientType
+	if req.ClientType != "" {
+		clientType = database.OAuth2ProviderAppClientType(req.ClientType)
+	}
 	app, err := api.Database.UpdateOAuth2ProviderAppByID(ctx, database.UpdateOAuth2ProviderAppByIDParams{
 		ID:          app.ID,
 		UpdatedAt:   dbtime.Now(),
 		Name:        req.Name,
 		Icon:        req.Icon,
 		CallbackURL: req.CallbackURL,
+		ClientType:  clientType,
 	})
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -335,7 +356,9 @@ func (api *API) deleteOAuth2ProviderAppSecret(rw http.ResponseWriter, r *http.Re
 // @Param state query string true "A random unguessable string"
 // @Param response_type query codersdk.OAuth2ProviderResponseType true "Response type"
 // @Param redirect_uri query string false "Redirect here after authorization"
-// @Param scope query string false "Token scopes (currently ignored)"
+// @Param scope query string false "Space-delimited token scopes, for example \"workspace:read template:read\". Omit for full access."
+// @Param code_challenge query string false "PKCE code challenge, required for public clients"
+// @Param code_challenge_method query string false "PKCE code challenge method, must be S256"
 // @Success 302
 // @Router /oauth2/authorize [post]
 func (api *API) getOAuth2ProviderAppAuthorize() http.HandlerFunc {
@@ -347,8 +370,10 @@ func (api *API) getOAuth2ProviderAppAuthorize() http.HandlerFunc {
 // @Produce json
 // @Tags Enterprise
 // @Param client_id formData string false "Client ID, required if grant_type=authorization_code"
-// @Param client_secret formData string false "Client secret, required if grant_type=authorization_code"
+// @Param client_secret formData string false "Client secret, required for confidential clients if grant_type=authorization_code or device_code"
 // @Param code formData string false "Authorization code, required if grant_type=authorization_code"
+// @Param code_verifier formData string false "PKCE code verifier, required if the authorization request had a code challenge"
+// @Param device_code formData string false "Device code, required if grant_type=urn:ietf:params:oauth:grant-type:device_code"
 // @Param refresh_token formData string false "Refresh token, required if grant_type=refresh_token"
 // @Param grant_type formData codersdk.OAuth2ProviderGrantType true "Grant type"
 // @Success 200 {object} oauth2.Token
@@ -367,3 +392,171 @@ func (api *API) postOAuth2ProviderAppToken() http.HandlerFunc {
 func (api *API) deleteOAuth2ProviderAppTokens() http.HandlerFunc {
 	return identityprovider.RevokeApp(api.Database)
 }
+
+// @Summary OAuth2 device authorization request.
+// @ID oauth2-device-authorization-request
+// @Produce json
+// @Tags Enterprise
+// @Param client_id formData string true "Client ID"
+// @Param scope formData string false "Space-delimited token scopes. Omit for full access."
+// @Success 200 {object} codersdk.OAuth2DeviceAuthorizationResponse
+// @Router /oauth2/device/authorize [post]
+func (api *API) postOAuth2ProviderDeviceAuthorization() http.HandlerFunc {
+	return identityprovider.DeviceAuthorize(api.Database, api.AccessURL)
+}
+
+// @Summary OAuth2 device verification page.
+// @ID oauth2-device-verification-page
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param user_code query string false "User code shown by the device"
+// @Success 200
+// @Router /oauth2/device [get]
+func (api *API) getOAuth2ProviderDeviceVerification() http.HandlerFunc {
+	return identityprovider.DeviceVerify(api.Database, api.AccessURL)
+}
+
+// @Summary Register OAuth2 client.
+// @ID register-oauth2-client
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param request body codersdk.OAuth2ClientRegistrationRequest true "Client metadata"
+// @Success 201 {object} codersdk.OAuth2ClientRegistrationResponse
+// @Router /oauth2/register [post]
+func (api *API) postOAuth2ClientRegistration(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.OAuth2ProviderApp](rw, &audit.RequestParams{
+			Audit:   *auditor,
+			Log:     api.Logger,
+			Request: r,
+			Action:  database.AuditActionCreate,
+		})
+	)
+	defer commitAudit()
+	var req codersdk.OAuth2ClientRegistrationRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	grantTypes := req.GrantTypes
+	if len(grantTypes) == 0 {
+		grantTypes = []codersdk.OAuth2ProviderGrantType{
+			codersdk.OAuth2ProviderGrantTypeAuthorizationCode,
+			codersdk.OAuth2ProviderGrantTypeRefreshToken,
+		}
+	}
+	for _, grantType := range grantTypes {
+		if !grantType.Valid() {
+			identityprovider.WriteClientRegistrationError(ctx, rw, "invalid_client_metadata",
+				fmt.Sprintf("Grant type %q is not supported.", grantType))
+			return
+		}
+	}
+	grantTypes = slice.Unique(grantTypes)
+
+	authMethod := req.TokenEndpointAuthMethod
+	if authMethod == "" {
+		authMethod = "client_secret_post"
+	}
+	clientType := database.OAuth2ProviderAppClientTypeConfidential
+	switch authMethod {
+	case "client_secret_post":
+	case "none":
+		clientType = database.OAuth2ProviderAppClientTypePublic
+	default:
+		identityprovider.WriteClientRegistrationError(ctx, rw, "invalid_client_metadata",
+			fmt.Sprintf("Token endpoint auth method %q is not supported.", authMethod))
+		return
+	}
+
+	// Apps have a single callback URL. Clients that only use the device
+	// authorization grant do not need one.
+	var callbackURL string
+	switch {
+	case len(req.RedirectURIs) > 1:
+		identityprovider.WriteClientRegistrationError(ctx, rw, "invalid_redirect_uri",
+			"Only one redirect URI is supported.")
+		return
+	case len(req.RedirectURIs) == 1:
+		u, err := url.Parse(req.RedirectURIs[0])
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			identityprovider.WriteClientRegistrationError(ctx, rw, "invalid_redirect_uri",
+				fmt.Sprintf("Redirect URI %q must be an HTTP or HTTPS URL.", req.RedirectURIs[0]))
+			return
+		}
+		callbackURL = u.String()
+	case slices.Contains(grantTypes, codersdk.OAuth2ProviderGrantTypeAuthorizationCode):
+		identityprovider.WriteClientRegistrationError(ctx, rw, "invalid_redirect_uri",
+			"A redirect URI is required for the authorization_code grant type.")
+		return
+	}
+
+	var (
+		app    database.OAuth2ProviderApp
+		secret identityprovider.OAuth2ProviderAppSecret
+	)
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		app, err = tx.InsertOAuth2ProviderApp(ctx, database.InsertOAuth2ProviderAppParams{
+			ID:          uuid.New(),
+			CreatedAt:   dbtime.Now(),
+			UpdatedAt:   dbtime.Now(),
+			Name:        req.ClientName,
+			Icon:        req.LogoURI,
+			CallbackURL: callbackURL,
+			ClientType:  clientType,
+			GrantTypes:  slice.ToStrings(grantTypes),
+		})
+		if err != nil {
+			return xerrors.Errorf("insert oauth2 app: %w", err)
+		}
+		if clientType == database.OAuth2ProviderAppClientTypePublic {
+			return nil
+		}
+
+		secret, err = identityprovider.GenerateSecret()
+		if err != nil {
+			return xerrors.Errorf("generate secret: %w", err)
+		}
+		_, err = tx.InsertOAuth2ProviderAppSecret(ctx, database.InsertOAuth2ProviderAppSecretParams{
+			ID:            uuid.New(),
+			CreatedAt:     dbtime.Now(),
+			SecretPrefix:  []byte(secret.Prefix),
+			HashedSecret:  []byte(secret.Hashed),
+			DisplaySecret: secret.Formatted[len(secret.Formatted)-6:],
+			AppID:         app.ID,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert oauth2 app secret: %w", err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error registering OAuth2 client.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = app
+
+	redirectURIs := []string{}
+	if callbackURL != "" {
+		redirectURIs = append(redirectURIs, callbackURL)
+	}
+	httpapi.Write(ctx, rw, http.StatusCreated, codersdk.OAuth2ClientRegistrationResponse{
+		ClientID:                app.ID.String(),
+		ClientSecret:            secret.Formatted,
+		ClientIDIssuedAt:        app.CreatedAt.Unix(),
+		ClientSecretExpiresAt:   0, // Secrets do not expire.
+		ClientName:              app.Name,
+		RedirectURIs:            redirectURIs,
+		LogoURI:                 app.Icon,
+		GrantTypes:              grantTypes,
+		TokenEndpointAuthMethod: authMethod,
+	})
+}
@@ -2,10 +2,12 @@ package coderd_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
 	"testing"
 	"time"
 
@@ -651,6 +653,7 @@ func TestOAuth2ProviderTokenExchange(t *testing.T) {
 					HashedSecret: []byte(hashedCode),
 					AppID:        apps.Default.ID,
 					UserID:       user.ID,
+					Scopes:       []string{},
 				})
 				return err
 			},
@@ -836,8 +839,9 @@ func TestOAuth2ProviderTokenRefresh(t *testing.T) {
 				ExpiresAt:   expires,
 				HashPrefix:  []byte(token.Prefix),
 				RefreshHash: []byte(token.Hashed),
-				AppSecretID: secret.ID,
+				AppSecretID: uuid.NullUUID{UUID: secret.ID, Valid: true},
 				APIKeyID:    newKey.ID,
+				AppID:       apps.Default.ID,
 			})
 			require.NoError(t, err)
 
@@ -1053,6 +1057,301 @@ func TestOAuth2ProviderRevoke(t *testing.T) {
 	}
 }
 
+func TestOAuth2ProviderPKCE(t *testing.T) {
+	t.Parallel()
+
+	ownerClient := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	app, err := ownerClient.PostOAuth2ProviderApp(ctx, codersdk.PostOAuth2ProviderAppRequest{
+		Name:        "public-pkce",
+		CallbackURL: "http://localhost:3000",
+		ClientType:  codersdk.OAuth2ProviderAppClientTypePublic,
+	})
+	require.NoError(t, err)
+	require.Equal(t, codersdk.OAuth2ProviderAppClientTypePublic, app.ClientType)
+
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	// Public clients have no secret.
+	cfg := &oauth2.Config{
+		ClientID: app.ID.String(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   app.Endpoints.Authorization,
+			TokenURL:  app.Endpoints.Token,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: app.CallbackURL,
+	}
+
+	// Public clients must use PKCE.
+	_, err = authorizationFlow(ctx, userClient, cfg)
+	require.ErrorContains(t, err, "Invalid query params")
+
+	verifier := oauth2.GenerateVerifier()
+	code, err := authorizationFlow(ctx, userClient, cfg, oauth2.S256ChallengeOption(verifier))
+	require.NoError(t, err)
+
+	_, err = cfg.Exchange(ctx, code)
+	require.ErrorContains(t, err, "Invalid code verifier")
+	_, err = cfg.Exchange(ctx, code, oauth2.VerifierOption(oauth2.GenerateVerifier()))
+	require.ErrorContains(t, err, "Invalid code verifier")
+
+	token, err := cfg.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	require.NoError(t, err)
+	newClient := codersdk.New(userClient.URL)
+	newClient.SetSessionToken(token.AccessToken)
+	gotUser, err := newClient.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, gotUser.ID)
+}
+
+func TestOAuth2ProviderScopes(t *testing.T) {
+	t.Parallel()
+
+	ownerClient := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+	apps := generateApps(ctx, t, ownerClient, "scopes")
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	secret, err := ownerClient.PostOAuth2ProviderAppSecret(ctx, apps.Default.ID)
+	require.NoError(t, err)
+
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	cfg := &oauth2.Config{
+		ClientID:     apps.Default.ID.String(),
+		ClientSecret: secret.ClientSecretFull,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   apps.Default.Endpoints.Authorization,
+			TokenURL:  apps.Default.Endpoints.Token,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+		RedirectURL: apps.Default.CallbackURL,
+		Scopes:      []string{"workspace:invalid"},
+	}
+	_, err = authorizationFlow(ctx, userClient, cfg)
+	require.ErrorContains(t, err, "Invalid query params")
+
+	cfg.Scopes = []string{"workspace:read"}
+	code, err := authorizationFlow(ctx, userClient, cfg)
+	require.NoError(t, err)
+	token, err := cfg.Exchange(ctx, code)
+	require.NoError(t, err)
+
+	newClient := codersdk.New(userClient.URL)
+	newClient.SetSessionToken(token.AccessToken)
+	// The token may always read its own user.
+	gotUser, err := newClient.User(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, gotUser.ID)
+
+	// The user can read their organization, but the token cannot.
+	_, err = userClient.Organization(ctx, owner.OrganizationID)
+	require.NoError(t, err)
+	_, err = newClient.Organization(ctx, owner.OrganizationID)
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+}
+
+func TestOAuth2ProviderDeviceAuthorization(t *testing.T) {
+	t.Parallel()
+
+	ownerClient := coderdtest.New(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	app, err := ownerClient.PostOAuth2ProviderApp(ctx, codersdk.PostOAuth2ProviderAppRequest{
+		Name:        "public-device",
+		CallbackURL: "http://localhost:3000",
+		ClientType:  codersdk.OAuth2ProviderAppClientTypePublic,
+	})
+	require.NoError(t, err)
+	userClient, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	cfg := &oauth2.Config{
+		ClientID: app.ID.String(),
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: app.Endpoints.DeviceAuth,
+			TokenURL:      app.Endpoints.Token,
+			AuthStyle:     oauth2.AuthStyleInParams,
+		},
+		Scopes: []string{"workspace:read"},
+	}
+
+	// poll requests a token once, since DeviceAccessToken waits for the
+	// interval before polling.
+	poll := func(t *testing.T, deviceCode string) (*oauth2.Token, string) {
+		res, err := userClient.HTTPClient.PostForm(app.Endpoints.Token, url.Values{
+			"grant_type":  {string(codersdk.OAuth2ProviderGrantTypeDeviceCode)},
+			"client_id":   {app.ID.String()},
+			"device_code": {deviceCode},
+		})
+		require.NoError(t, err)
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			var body struct {
+				Error string `json:"error"`
+			}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			return nil, body.Error
+		}
+		var token oauth2.Token
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&token))
+		return &token, ""
+	}
+	// visit opens the verification page, optionally pressing a button on it.
+	visit := func(t *testing.T, userCode, action string, fromPage bool) *http.Response {
+		u := must(url.Parse(userClient.URL.String() + "/oauth2/device"))
+		q := url.Values{"user_code": {userCode}}
+		if action != "" {
+			q.Set("action", action)
+		}
+		u.RawQuery = q.Encode()
+		res, err := userClient.Request(ctx, http.MethodGet, u.String(), nil, func(req *http.Request) {
+			if fromPage {
+				req.Header.Set("Referer", u.String())
+			}
+		})
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		t.Parallel()
+
+		auth, err := cfg.DeviceAuth(ctx)
+		require.NoError(t, err)
+		require.Regexp(t, `^[A-Z]{4}-[A-Z]{4}$`, auth.UserCode)
+		require.Contains(t, auth.VerificationURIComplete, auth.UserCode)
+
+		_, errCode := poll(t, auth.DeviceCode)
+		require.Equal(t, "authorization_pending", errCode)
+		_, errCode = poll(t, auth.DeviceCode)
+		require.Equal(t, "slow_down", errCode)
+
+		require.Equal(t, http.StatusOK, visit(t, strings.ToLower(auth.UserCode), "", false).StatusCode)
+		// Following the allow link from anywhere other than the page fails.
+		require.Equal(t, http.StatusBadRequest, visit(t, auth.UserCode, "allow", false).StatusCode)
+		require.Equal(t, http.StatusOK, visit(t, auth.UserCode, "allow", true).StatusCode)
+
+		token, errCode := poll(t, auth.DeviceCode)
+		require.Empty(t, errCode)
+		require.NotNil(t, token)
+		newClient := codersdk.New(userClient.URL)
+		newClient.SetSessionToken(token.AccessToken)
+		gotUser, err := newClient.User(ctx, codersdk.Me)
+		require.NoError(t, err)
+		require.Equal(t, user.ID, gotUser.ID)
+
+		// The code is consumed by the exchange.
+		require.Equal(t, http.StatusNotFound, visit(t, auth.UserCode, "", false).StatusCode)
+	})
+
+	t.Run("Deny", func(t *testing.T) {
+		t.Parallel()
+
+		auth, err := cfg.DeviceAuth(ctx)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, visit(t, auth.UserCode, "deny", true).StatusCode)
+
+		_, errCode := poll(t, auth.DeviceCode)
+		require.Equal(t, "access_denied", errCode)
+	})
+}
+
+func TestOAuth2ClientRegistration(t *testing.T) {
+	t.Parallel()
+
+	client := coderdtest.New(t, nil)
+	_ = coderdtest.CreateFirstUser(t, client)
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	public, err := client.RegisterOAuth2Client(ctx, codersdk.OAuth2ClientRegistrationRequest{
+		ClientName:              "ide-plugin",
+		GrantTypes:              []codersdk.OAuth2ProviderGrantType{codersdk.OAuth2ProviderGrantTypeDeviceCode},
+		TokenEndpointAuthMethod: "none",
+	})
+	require.NoError(t, err)
+	require.Empty(t, public.ClientSecret)
+	require.Equal(t, "none", public.TokenEndpointAuthMethod)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	app, err := client.OAuth2ProviderApp(ctx, uuid.MustParse(public.ClientID))
+	require.NoError(t, err)
+	require.Equal(t, codersdk.OAuth2ProviderAppClientTypePublic, app.ClientType)
+
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	confidential, err := client.RegisterOAuth2Client(ctx, codersdk.OAuth2ClientRegistrationRequest{
+		ClientName:   "web-app",
+		RedirectURIs: []string{"http://localhost:3000/callback"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, confidential.ClientSecret)
+	require.Equal(t, "client_secret_post", confidential.TokenEndpointAuthMethod)
+	//nolint:gocritic // OAauth2 app management requires owner permission.
+	secrets, err := client.OAuth2ProviderAppSecrets(ctx, uuid.MustParse(confidential.ClientID))
+	require.NoError(t, err)
+	require.Len(t, secrets, 1)
+
+	// Invalid metadata is rejected with the errors of RFC 7591.
+	for _, tc := range []struct {
+		req  codersdk.OAuth2ClientRegistrationRequest
+		code string
+	}{
+		{
+			req:  codersdk.OAuth2ClientRegistrationRequest{ClientName: "no-redirect"},
+			code: "invalid_redirect_uri",
+		},
+		{
+			req: codersdk.OAuth2ClientRegistrationRequest{
+				ClientName:   "implicit",
+				RedirectURIs: []string{"http://localhost:3000/callback"},
+				GrantTypes:   []codersdk.OAuth2ProviderGrantType{"implicit"},
+			},
+			code: "invalid_client_metadata",
+		},
+		{
+			req: codersdk.OAuth2ClientRegistrationRequest{
+				ClientName:              "basic-auth",
+				RedirectURIs:            []string{"http://localhost:3000/callback"},
+				TokenEndpointAuthMethod: "client_secret_basic",
+			},
+			code: "invalid_client_metadata",
+		},
+	} {
+		//nolint:gocritic // OAauth2 app management requires owner permission.
+		res, err := client.Request(ctx, http.MethodPost, "/oauth2/register", tc.req)
+		require.NoError(t, err)
+		var body struct {
+			Error string `json:"error"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode, tc.req.ClientName)
+		require.Equal(t, tc.code, body.Error, tc.req.ClientName)
+	}
+
+	// Clients can only use the grant types they registered with.
+	res, err := client.HTTPClient.PostForm(client.URL.String()+"/oauth2/device/authorize", url.Values{
+		"client_id":     {confidential.ClientID},
+		"client_secret": {confidential.ClientSecret},
+	})
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	var body struct {
+		Error string `json:"error"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	require.Equal(t, "unauthorized_client", body.Error)
+}
+
 type provisionedApps struct {
 	Default   codersdk.OAuth2ProviderApp
 	NoPort    codersdk.OAuth2ProviderApp
@@ -1087,10 +1386,10 @@ func generateApps(ctx context.Context, t *testing.T, client *codersdk.Client, su
 	}
 }
 
-func authorizationFlow(ctx context.Context, client *codersdk.Client, cfg *oauth2.Config) (string, error) {
+func authorizationFlow(ctx context.Context, client *codersdk.Client, cfg *oauth2.Config, opts ...oauth2.AuthCodeOption) (string, error) {
 	state := uuid.NewString()
 	return oidctest.OAuth2GetCode(
-		cfg.AuthCodeURL(state),
+		cfg.AuthCodeURL(state, opts...),
 		func(req *http.Request) (*http.Response, error) {
 			// TODO: Would be better if client had a .Do() method.
 			// TODO: Is this the best way to handle redirects?
@@ -9,12 +9,14 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/sqlc-dev/pqtype"
 	semconv "go.opentelemetry.io/otel/semconv/v1.14.0"
 	"go.opentelemetry.io/otel/trace"
@@ -35,12 +37,15 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/promoauth"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/telemetry"
+	"github.com/coder/coder/v2/coderd/templatepolicy"
 	"github.com/coder/coder/v2/coderd/tracing"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/drpc"
 	"github.com/coder/coder/v2/provisioner"
+	"github.com/coder/coder/v2/provisioner/terraform"
 	"github.com/coder/coder/v2/provisionerd/proto"
 	"github.com/coder/coder/v2/provisionersdk"
 	sdkproto "github.com/coder/coder/v2/provisionersdk/proto"
@@ -1096,11 +1101,11 @@ func (s *server) FailJob(ctx context.Context, failJob *proto.FailedJob) (*proto.
 }
 
 func (s *server) notifyWorkspaceBuildFailed(ctx context.Context, workspace database.Workspace, build database.WorkspaceBuild) {
-	var reason string
 	if build.Reason.Valid() && build.Reason == database.BuildReasonInitiator {
-		return // failed workspace build initiated by a user should not notify
+		s.notifyWorkspaceManualBuildFailed(ctx, workspace, build)
+		return
 	}
-	reason = string(build.Reason)
+	reason := string(build.Reason)
 	initiator := "autobuild"
 
 	if _, err := s.NotificationsEnqueuer.Enqueue(ctx, workspace.OwnerID, notifications.TemplateWorkspaceAutobuildFailed,
@@ -1112,8 +1117,96 @@ func (s *server) notifyWorkspaceBuildFailed(ctx context.Context, workspace datab
 		// Associate this notification with all the related entities.
 		workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
 	); err != nil {
-		s.Logger.Warn(ctx, "failed to notify of failed workspace autobuild", slog.Error(err))
+		notifications.LogEnqueueError(ctx, s.Logger, "failed to notify of failed workspace autobuild", err)
+	}
+}
+
+// notifyWorkspaceManualBuildFailed notifies the template administrators of the template's organization that a build
+// initiated by a user failed, so that broken templates can be spotted. The initiator already sees the failure, so they
+// are not notified. The workspace owner isn't notified either, unless they're one of the template administrators and
+// didn't initiate the build.
+func (s *server) notifyWorkspaceManualBuildFailed(ctx context.Context, workspace database.Workspace, build database.WorkspaceBuild) {
+	initiator, err := s.Database.GetUserByID(ctx, build.InitiatorID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch build initiator for manual build failure notification", slog.Error(err))
+		return
+	}
+	owner, err := s.Database.GetUserByID(ctx, workspace.OwnerID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch workspace owner for manual build failure notification", slog.Error(err))
+		return
+	}
+	template, err := s.Database.GetTemplateByID(ctx, workspace.TemplateID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch template for manual build failure notification", slog.Error(err))
+		return
+	}
+	templateVersion, err := s.Database.GetTemplateVersionByID(ctx, build.TemplateVersionID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch template version for manual build failure notification", slog.Error(err))
+		return
+	}
+
+	admins, err := s.organizationTemplateAdmins(ctx, template.OrganizationID)
+	if err != nil {
+		s.Logger.Warn(ctx, "failed to fetch template admins for manual build failure notification", slog.Error(err))
+		return
+	}
+
+	for _, admin := range admins {
+		if admin.ID == initiator.ID {
+			continue
+		}
+		if _, err := s.NotificationsEnqueuer.Enqueue(ctx, admin.ID, notifications.TemplateWorkspaceManualBuildFailed,
+			map[string]string{
+				"name":                     workspace.Name,
+				"template_name":            template.Name,
+				"template_version_name":    templateVersion.Name,
+				"initiator":                initiator.Username,
+				"workspace_owner_username": owner.Username,
+				"workspace_build_number":   strconv.FormatInt(int64(build.BuildNumber), 10),
+			}, "provisionerdserver",
+			// Associate this notification with all the related entities.
+			workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
+		); err != nil {
+			notifications.LogEnqueueError(ctx, s.Logger, "failed to notify of failed manual workspace build", err)
+		}
+	}
+}
+
+// organizationTemplateAdmins returns the active members of the organization that administer its templates, either
+// through the organization template admin role or the site-wide template admin role.
+func (s *server) organizationTemplateAdmins(ctx context.Context, organizationID uuid.UUID) ([]database.User, error) {
+	//nolint:gocritic // Provisionerd can't read organization members.
+	members, err := s.Database.OrganizationMembers(dbauthz.AsSystemRestricted(ctx), database.OrganizationMembersParams{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("get organization members: %w", err)
+	}
+
+	var ids []uuid.UUID
+	for _, member := range members {
+		if slices.Contains(member.OrganizationMember.Roles, rbac.RoleOrgTemplateAdmin()) ||
+			slices.Contains(member.GlobalRoles, rbac.RoleTemplateAdmin().String()) {
+			ids = append(ids, member.OrganizationMember.UserID)
+		}
 	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.Database.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, xerrors.Errorf("get users: %w", err)
+	}
+	admins := make([]database.User, 0, len(users))
+	for _, user := range users {
+		if user.Status == database.UserStatusActive {
+			admins = append(admins, user)
+		}
+	}
+	return admins, nil
 }
 
 // CompleteJob is triggered by a provision daemon to mark a provisioner job as completed.
@@ -1256,6 +1349,24 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 			}
 		}
 
+		var completedErrorCode sql.NullString
+		if !completedError.Valid && len(s.DeploymentValues.TemplatePolicyRules.Value) > 0 {
+			violations, err := s.checkTemplatePolicy(ctx, job, jobType.TemplateImport.StartResources)
+			if err != nil {
+				return nil, xerrors.Errorf("check template policy: %w", err)
+			}
+			if violations > 0 {
+				completedError = sql.NullString{
+					String: fmt.Sprintf("template version violates the template policy with %d error(s)", violations),
+					Valid:  true,
+				}
+				completedErrorCode = sql.NullString{
+					String: string(codersdk.TemplatePolicyViolation),
+					Valid:  true,
+				}
+			}
+		}
+
 		externalAuthProvidersMessage, err := json.Marshal(externalAuthProviders)
 		if err != nil {
 			return nil, xerrors.Errorf("failed to serialize external_auth_providers value: %w", err)
@@ -1278,7 +1389,7 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 				Valid: true,
 			},
 			Error:     completedError,
-			ErrorCode: sql.NullString{},
+			ErrorCode: completedErrorCode,
 		})
 		if err != nil {
 			return nil, xerrors.Errorf("update provisioner job: %w", err)
@@ -1287,6 +1398,14 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 		if err != nil {
 			return nil, xerrors.Errorf("complete job: %w", err)
 		}
+		if !completedError.Valid && input.ActivateOnSuccess {
+			// The job is already complete, so a failure to activate the
+			// version must not fail it.
+			err = s.activateTemplateVersion(ctx, job, input.TemplateVersionID)
+			if err != nil {
+				s.Logger.Error(ctx, "activate template version", slog.F("job_id", jobID), slog.Error(err))
+			}
+		}
 	case *proto.CompletedJob_WorkspaceBuild_:
 		var input WorkspaceProvisionJob
 		err = json.Unmarshal(job.Input, &input)
@@ -1550,6 +1669,112 @@ func (s *server) CompleteJob(ctx context.Context, completed *proto.CompletedJob)
 	return &proto.Empty{}, nil
 }
 
+// activateTemplateVersion promotes a successfully imported template version
+// to the active version of its template.
+func (s *server) activateTemplateVersion(ctx context.Context, job database.ProvisionerJob, versionID uuid.UUID) error {
+	version, err := s.Database.GetTemplateVersionByID(ctx, versionID)
+	if err != nil {
+		return xerrors.Errorf("get template version: %w", err)
+	}
+	if !version.TemplateID.Valid {
+		return nil
+	}
+	template, err := s.Database.GetTemplateByID(ctx, version.TemplateID.UUID)
+	if err != nil {
+		return xerrors.Errorf("get template: %w", err)
+	}
+	if template.ActiveVersionID == version.ID {
+		return nil
+	}
+	err = s.Database.UpdateTemplateActiveVersionByID(ctx, database.UpdateTemplateActiveVersionByIDParams{
+		ID:              template.ID,
+		ActiveVersionID: version.ID,
+		UpdatedAt:       dbtime.Now(),
+	})
+	if err != nil {
+		return xerrors.Errorf("update active version: %w", err)
+	}
+	s.Logger.Info(ctx, "activated template version",
+		slog.F("template_id", template.ID),
+		slog.F("template_version_id", version.ID))
+
+	newTemplate := template
+	newTemplate.ActiveVersionID = version.ID
+	bag := audit.BaggageFromContext(ctx)
+	audit.BackgroundAudit(ctx, &audit.BackgroundAuditParams[database.Template]{
+		Audit:          *s.Auditor.Load(),
+		Log:            s.Logger,
+		UserID:         job.InitiatorID,
+		OrganizationID: template.OrganizationID,
+		RequestID:      job.ID,
+		IP:             bag.IP,
+		Action:         database.AuditActionWrite,
+		Old:            template,
+		New:            newTemplate,
+		Status:         http.StatusOK,
+	})
+	return nil
+}
+
+// checkTemplatePolicy checks a template version import against the template
+// policy rules, and logs a diagnostic for every violation to the job. It
+// returns the number of violations.
+func (s *server) checkTemplatePolicy(ctx context.Context, job database.ProvisionerJob, resources []*sdkproto.Resource) (int, error) {
+	file, err := s.Database.GetFileByID(ctx, job.FileID)
+	if err != nil {
+		return 0, xerrors.Errorf("get file by id: %w", err)
+	}
+	var diags []*tfjson.Diagnostic
+	module, err := templatepolicy.LoadModule(file.Data)
+	if err != nil {
+		diags = append(diags, &tfjson.Diagnostic{
+			Severity: tfjson.DiagnosticSeverityError,
+			Summary:  "Failed to load template source",
+			Detail:   err.Error(),
+		})
+	} else {
+		diags = templatepolicy.Check(s.DeploymentValues.TemplatePolicyRules.Value, module, resources)
+	}
+
+	const stage = "Checking template policy"
+	//nolint:exhaustruct // We append to the additional fields below.
+	params := database.InsertProvisionerJobLogsParams{
+		JobID: job.ID,
+	}
+	appendLog := func(level database.LogLevel, output string) {
+		params.CreatedAt = append(params.CreatedAt, dbtime.Now())
+		params.Level = append(params.Level, level)
+		params.Stage = append(params.Stage, stage)
+		params.Source = append(params.Source, database.LogSourceProvisionerDaemon)
+		params.Output = append(params.Output, output)
+	}
+	for _, diag := range diags {
+		appendLog(database.LogLevelError, "Error: "+diag.Summary)
+		for _, line := range strings.Split(terraform.FormatDiagnostic(diag), "\n") {
+			appendLog(database.LogLevelError, line)
+		}
+	}
+	if len(diags) == 0 {
+		appendLog(database.LogLevelInfo, fmt.Sprintf("Template version satisfies %d template policy rule(s).", len(s.DeploymentValues.TemplatePolicyRules.Value)))
+	}
+
+	logs, err := s.Database.InsertProvisionerJobLogs(ctx, params)
+	if err != nil {
+		return 0, xerrors.Errorf("insert job logs: %w", err)
+	}
+	data, err := json.Marshal(provisionersdk.ProvisionerJobLogsNotifyMessage{
+		CreatedAfter: logs[0].ID - 1,
+	})
+	if err != nil {
+		return 0, xerrors.Errorf("marshal: %w", err)
+	}
+	err = s.Pubsub.Publish(provisionersdk.ProvisionerJobLogsNotifyChannel(job.ID), data)
+	if err != nil {
+		return 0, xerrors.Errorf("publish job logs: %w", err)
+	}
+	return len(diags), nil
+}
+
 func (s *server) notifyWorkspaceDeleted(ctx context.Context, workspace database.Workspace, build database.WorkspaceBuild) {
 	var reason string
 	initiator := build.InitiatorByUsername
@@ -1583,7 +1808,7 @@ func (s *server) notifyWorkspaceDeleted(ctx context.Context, workspace database.
 		// Associate this notification with all the related entities.
 		workspace.ID, workspace.OwnerID, workspace.TemplateID, workspace.OrganizationID,
 	); err != nil {
-		s.Logger.Warn(ctx, "failed to notify of workspace deletion", slog.Error(err))
+		notifications.LogEnqueueError(ctx, s.Logger, "failed to notify of workspace deletion", err)
 	}
 }
 
@@ -2025,6 +2250,9 @@ func auditActionFromTransition(transition database.WorkspaceTransition) database
 type TemplateVersionImportJob struct {
 	TemplateVersionID  uuid.UUID                `json:"template_version_id"`
 	UserVariableValues []codersdk.VariableValue `json:"user_variable_values"`
+	// ActivateOnSuccess promotes the version to the active version of its
+	// template when the import succeeds.
+	ActivateOnSuccess bool `json:"activate_on_success,omitempty"`
 }
 
 // WorkspaceProvisionJob is the payload for the "workspace_provision" job type.
@@ -1,6 +1,8 @@
 package provisionerdserver_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -36,6 +38,7 @@ import (
 	"github.com/coder/coder/v2/coderd/externalauth"
 	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
 	"github.com/coder/coder/v2/coderd/telemetry"
@@ -1100,6 +1103,92 @@ func TestCompleteJob(t *testing.T) {
 		require.False(t, job.Error.Valid)
 	})
 
+	t.Run("TemplateImport_PolicyViolation", func(t *testing.T) {
+		t.Parallel()
+		deploymentValues := &codersdk.DeploymentValues{}
+		deploymentValues.TemplatePolicyRules.Value = []codersdk.TemplatePolicyRule{{
+			Name:      "no-aws",
+			Type:      codersdk.TemplatePolicyRuleForbiddenProviders,
+			Providers: []string{"aws"},
+		}}
+		srv, db, _, pd := setup(t, false, &overrides{
+			deploymentValues: deploymentValues,
+		})
+
+		source := []byte(`resource "aws_instance" "hello" {}`)
+		var archive bytes.Buffer
+		tarWriter := tar.NewWriter(&archive)
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name: "main.tf",
+			Mode: 0o644,
+			Size: int64(len(source)),
+		})
+		require.NoError(t, err)
+		_, err = tarWriter.Write(source)
+		require.NoError(t, err)
+		require.NoError(t, tarWriter.Close())
+		file := dbgen.File(t, db, database.File{Data: archive.Bytes()})
+
+		jobID := uuid.New()
+		versionID := uuid.New()
+		err = db.InsertTemplateVersion(ctx, database.InsertTemplateVersionParams{
+			ID:             versionID,
+			JobID:          jobID,
+			OrganizationID: pd.OrganizationID,
+		})
+		require.NoError(t, err)
+		job, err := db.InsertProvisionerJob(ctx, database.InsertProvisionerJobParams{
+			OrganizationID: pd.OrganizationID,
+			ID:             jobID,
+			Provisioner:    database.ProvisionerTypeEcho,
+			Input:          []byte(`{"template_version_id": "` + versionID.String() + `"}`),
+			StorageMethod:  database.ProvisionerStorageMethodFile,
+			FileID:         file.ID,
+			Type:           database.ProvisionerJobTypeTemplateVersionImport,
+		})
+		require.NoError(t, err)
+		_, err = db.AcquireProvisionerJob(ctx, database.AcquireProvisionerJobParams{
+			OrganizationID: pd.OrganizationID,
+			WorkerID: uuid.NullUUID{
+				UUID:  pd.ID,
+				Valid: true,
+			},
+			Types: []database.ProvisionerType{database.ProvisionerTypeEcho},
+		})
+		require.NoError(t, err)
+		_, err = srv.CompleteJob(ctx, &proto.CompletedJob{
+			JobId: job.ID.String(),
+			Type: &proto.CompletedJob_TemplateImport_{
+				TemplateImport: &proto.CompletedJob_TemplateImport{
+					StartResources: []*sdkproto.Resource{{
+						Name: "hello",
+						Type: "aws_instance",
+					}},
+					StopResources: []*sdkproto.Resource{},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		job, err = db.GetProvisionerJobByID(ctx, job.ID)
+		require.NoError(t, err)
+		require.True(t, job.Error.Valid)
+		require.Equal(t, string(codersdk.TemplatePolicyViolation), job.ErrorCode.String)
+
+		logs, err := db.GetProvisionerLogsAfterID(ctx, database.GetProvisionerLogsAfterIDParams{
+			JobID: job.ID,
+		})
+		require.NoError(t, err)
+		var output strings.Builder
+		for _, log := range logs {
+			require.Equal(t, "Checking template policy", log.Stage)
+			_, _ = output.WriteString(log.Output + "\n")
+		}
+		require.Contains(t, output.String(), "Error: Forbidden provider")
+		require.Contains(t, output.String(), `on main.tf line 1:`)
+		require.Contains(t, output.String(), `"no-aws" forbids the provider "aws"`)
+	})
+
 	t.Run("WorkspaceBuild", func(t *testing.T) {
 		t.Parallel()
 
@@ -1805,6 +1894,111 @@ func TestNotifications(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Workspace manual build failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		notifEnq := &testutil.FakeNotificationsEnqueuer{}
+
+		//	Otherwise `(*Server).FailJob` fails with:
+		// audit log - get build {"error": "sql: no rows in result set"}
+		ignoreLogErrors := true
+		srv, db, ps, pd := setup(t, ignoreLogErrors, &overrides{
+			notificationEnqueuer: notifEnq,
+		})
+
+		// Template admins of the organization are notified, except for the one who initiated the build.
+		templateAdmin := dbgen.User(t, db, database.User{RBACRoles: []string{rbac.RoleTemplateAdmin().String()}})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{UserID: templateAdmin.ID, OrganizationID: pd.OrganizationID})
+		orgTemplateAdmin := dbgen.User(t, db, database.User{})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{
+			UserID:         orgTemplateAdmin.ID,
+			OrganizationID: pd.OrganizationID,
+			Roles:          []string{rbac.RoleOrgTemplateAdmin()},
+		})
+		initiator := dbgen.User(t, db, database.User{RBACRoles: []string{rbac.RoleTemplateAdmin().String()}})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{UserID: initiator.ID, OrganizationID: pd.OrganizationID})
+		owner := dbgen.User(t, db, database.User{})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{UserID: owner.ID, OrganizationID: pd.OrganizationID})
+		// Neither the owners of the deployment nor the template admins of other organizations are notified.
+		siteOwner := dbgen.User(t, db, database.User{RBACRoles: []string{rbac.RoleOwner().String()}})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{UserID: siteOwner.ID, OrganizationID: pd.OrganizationID})
+		otherOrg := dbgen.Organization(t, db, database.Organization{})
+		otherTemplateAdmin := dbgen.User(t, db, database.User{})
+		dbgen.OrganizationMember(t, db, database.OrganizationMember{
+			UserID:         otherTemplateAdmin.ID,
+			OrganizationID: otherOrg.ID,
+			Roles:          []string{rbac.RoleOrgTemplateAdmin()},
+		})
+
+		template := dbgen.Template(t, db, database.Template{
+			Name:           "template",
+			Provisioner:    database.ProvisionerTypeEcho,
+			OrganizationID: pd.OrganizationID,
+		})
+		file := dbgen.File(t, db, database.File{CreatedBy: owner.ID})
+		workspace := dbgen.Workspace(t, db, database.Workspace{
+			TemplateID:     template.ID,
+			OwnerID:        owner.ID,
+			OrganizationID: pd.OrganizationID,
+		})
+		version := dbgen.TemplateVersion(t, db, database.TemplateVersion{
+			OrganizationID: pd.OrganizationID,
+			TemplateID: uuid.NullUUID{
+				UUID:  template.ID,
+				Valid: true,
+			},
+			JobID: uuid.New(),
+		})
+		build := dbgen.WorkspaceBuild(t, db, database.WorkspaceBuild{
+			WorkspaceID:       workspace.ID,
+			TemplateVersionID: version.ID,
+			InitiatorID:       initiator.ID,
+			Transition:        database.WorkspaceTransitionStart,
+			Reason:            database.BuildReasonInitiator,
+			BuildNumber:       3,
+		})
+		job := dbgen.ProvisionerJob(t, db, ps, database.ProvisionerJob{
+			FileID: file.ID,
+			Type:   database.ProvisionerJobTypeWorkspaceBuild,
+			Input: must(json.Marshal(provisionerdserver.WorkspaceProvisionJob{
+				WorkspaceBuildID: build.ID,
+			})),
+			OrganizationID: pd.OrganizationID,
+		})
+		_, err := db.AcquireProvisionerJob(ctx, database.AcquireProvisionerJobParams{
+			OrganizationID: pd.OrganizationID,
+			WorkerID: uuid.NullUUID{
+				UUID:  pd.ID,
+				Valid: true,
+			},
+			Types: []database.ProvisionerType{database.ProvisionerTypeEcho},
+		})
+		require.NoError(t, err)
+
+		_, err = srv.FailJob(ctx, &proto.FailedJob{
+			JobId: job.ID.String(),
+			Type: &proto.FailedJob_WorkspaceBuild_{
+				WorkspaceBuild: &proto.FailedJob_WorkspaceBuild{
+					State: []byte{},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, notifEnq.Sent, 2)
+		require.ElementsMatch(t, []uuid.UUID{templateAdmin.ID, orgTemplateAdmin.ID}, []uuid.UUID{notifEnq.Sent[0].UserID, notifEnq.Sent[1].UserID})
+		require.Equal(t, notifications.TemplateWorkspaceManualBuildFailed, notifEnq.Sent[0].TemplateID)
+		require.Contains(t, notifEnq.Sent[0].Targets, template.ID)
+		require.Contains(t, notifEnq.Sent[0].Targets, workspace.ID)
+		require.Equal(t, workspace.Name, notifEnq.Sent[0].Labels["name"])
+		require.Equal(t, template.Name, notifEnq.Sent[0].Labels["template_name"])
+		require.Equal(t, version.Name, notifEnq.Sent[0].Labels["template_version_name"])
+		require.Equal(t, initiator.Username, notifEnq.Sent[0].Labels["initiator"])
+		require.Equal(t, owner.Username, notifEnq.Sent[0].Labels["workspace_owner_username"])
+		require.Equal(t, "3", notifEnq.Sent[0].Labels["workspace_build_number"])
+	})
 }
 
 type overrides struct {
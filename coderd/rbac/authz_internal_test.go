@@ -1028,6 +1028,52 @@ func TestAuthorizeScope(t *testing.T) {
 			{resource: ResourceWorkspace.InOrg(unusedID).WithOwner("not-me"), actions: []policy.Action{policy.ActionCreate}, allow: false},
 		},
 	)
+
+	// This token can only start and read a single workspace.
+	user = Subject{
+		ID: "me",
+		Roles: Roles{
+			must(RoleByName(RoleMember())),
+			must(RoleByName(ScopedRoleOrgMember(defOrg))),
+		},
+		Scope: TokenScope{
+			OwnerID:     "me",
+			Permissions: []string{"workspace:read", "workspace:start"},
+			AllowIDList: []string{workspaceID.String()},
+		},
+	}
+
+	testAuthorize(t, "TokenScope", user,
+		// Actions that are not in the token permissions.
+		cases(func(c authTestCase) authTestCase {
+			c.actions = []policy.Action{policy.ActionUpdate, policy.ActionDelete, policy.ActionWorkspaceStop, policy.ActionSSH}
+			c.allow = false
+			c.resource = c.resource.WithID(workspaceID)
+			return c
+		}, []authTestCase{
+			{resource: ResourceWorkspace.InOrg(defOrg).WithOwner(user.ID)},
+			{resource: ResourceWorkspaceDormant.InOrg(defOrg).WithOwner(user.ID)},
+		}),
+		// Workspaces that are not in the allow list.
+		cases(func(c authTestCase) authTestCase {
+			c.actions = []policy.Action{policy.ActionRead, policy.ActionWorkspaceStart}
+			c.allow = false
+			c.resource = c.resource.WithID(uuid.New())
+			return c
+		}, []authTestCase{
+			{resource: ResourceWorkspace.InOrg(defOrg).WithOwner(user.ID)},
+			{resource: ResourceWorkspaceDormant.InOrg(defOrg).WithOwner(user.ID)},
+		}),
+		// Allowed by scope:
+		[]authTestCase{
+			{resource: ResourceWorkspace.WithID(workspaceID).InOrg(defOrg).WithOwner(user.ID), actions: []policy.Action{policy.ActionRead, policy.ActionWorkspaceStart}, allow: true},
+			{resource: ResourceWorkspaceDormant.WithID(workspaceID).InOrg(defOrg).WithOwner(user.ID), actions: []policy.Action{policy.ActionRead}, allow: true},
+			// The token can always read its owner.
+			{resource: ResourceUser.WithIDString(user.ID).WithOwner(user.ID), actions: []policy.Action{policy.ActionRead, policy.ActionReadPersonal}, allow: true},
+			// Resources of other types are not allowed, even in the allow list.
+			{resource: ResourceTemplate.WithID(workspaceID).InOrg(defOrg), actions: []policy.Action{policy.ActionRead}, allow: false},
+		},
+	)
 }
 
 // cases applies a given function to all test cases. This makes generalities easier to create.
@@ -60,8 +60,8 @@ func WorkspaceConverter() *sqltypes.VariableConverter {
 		userOwnerMatcher(),
 	)
 	matcher.RegisterMatcher(
-		sqltypes.AlwaysFalse(groupACLMatcher(matcher)),
-		sqltypes.AlwaysFalse(userACLMatcher(matcher)),
+		ACLGroupMatcher(matcher, "workspaces.group_acl", []string{"input", "object", "acl_group_list"}),
+		ACLGroupMatcher(matcher, "workspaces.user_acl", []string{"input", "object", "acl_user_list"}),
 	)
 
 	return matcher
@@ -2,6 +2,9 @@ package rbac
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -83,6 +86,156 @@ var builtinScopes = map[ScopeName]Scope{
 	},
 }
 
+// scopeAliases are named sets of permissions that can be granted to a token
+// as if they were a single permission.
+var scopeAliases = map[string][]string{
+	// Everything "coder templates push" needs to create or update a template.
+	"template:push": {
+		"template:read", "template:create", "template:update",
+		"file:read", "file:create",
+		"organization:read",
+	},
+}
+
+// ScopePermissions parses a token permission of the form "resource:action",
+// where the action may be a wildcard, into the permissions it grants.
+func ScopePermissions(name string) (map[string][]policy.Action, error) {
+	if alias, ok := scopeAliases[name]; ok {
+		perms := map[string][]policy.Action{}
+		for _, name := range alias {
+			expanded, err := ScopePermissions(name)
+			if err != nil {
+				return nil, xerrors.Errorf("alias %q: %w", name, err)
+			}
+			for resource, actions := range expanded {
+				perms[resource] = append(perms[resource], actions...)
+			}
+		}
+		return perms, nil
+	}
+
+	resource, action, ok := strings.Cut(name, ":")
+	if !ok {
+		return nil, xerrors.Errorf("scope %q must be of the form \"resource:action\"", name)
+	}
+	def, ok := policy.RBACPermissions[resource]
+	if !ok || resource == policy.WildcardSymbol {
+		return nil, xerrors.Errorf("scope %q has unknown resource %q", name, resource)
+	}
+	if action != policy.WildcardSymbol {
+		if _, ok := def.Actions[policy.Action(action)]; !ok {
+			return nil, xerrors.Errorf("scope %q has unknown action %q for resource %q", name, action, resource)
+		}
+	}
+	perms := map[string][]policy.Action{resource: {policy.Action(action)}}
+	if resource == ResourceWorkspace.Type {
+		// Dormant workspaces have the same permissions as workspaces.
+		perms[ResourceWorkspaceDormant.Type] = perms[resource]
+	}
+	return perms, nil
+}
+
+// TokenScope restricts an API token to a list of permissions parsed by
+// ScopePermissions, and optionally to the resources in an allow list. The
+// token may always read the user that owns it, so clients can identify
+// themselves.
+type TokenScope struct {
+	OwnerID     string   `json:"owner_id"`
+	Permissions []string `json:"permissions"`
+	AllowIDList []string `json:"allow_list"`
+}
+
+func (s TokenScope) Expand() (Scope, error) {
+	site := map[string][]policy.Action{}
+	for _, name := range s.Permissions {
+		perms, err := ScopePermissions(name)
+		if err != nil {
+			return Scope{}, err
+		}
+		for resource, actions := range perms {
+			site[resource] = append(site[resource], actions...)
+		}
+	}
+	if _, ok := site[ResourceWorkspace.Type]; ok {
+		// Workspaces cannot be read without their template.
+		site[ResourceTemplate.Type] = append(site[ResourceTemplate.Type], policy.ActionRead)
+	}
+
+	allowList := []string{policy.WildcardSymbol}
+	if len(s.AllowIDList) > 0 {
+		// Objects without an ID, such as those being created, are not
+		// restricted by the allow list.
+		allowList = append([]string{"", s.OwnerID}, s.AllowIDList...)
+	}
+	return Scope{
+		Role: Role{
+			Identifier:  s.Name(),
+			DisplayName: "Token permissions",
+			Site:        Permissions(site),
+			Org:         map[string][]Permission{},
+			User: Permissions(map[string][]policy.Action{
+				ResourceUser.Type: {policy.ActionRead, policy.ActionReadPersonal},
+			}),
+		},
+		AllowIDList: allowList,
+	}, nil
+}
+
+// Covers reports whether every permission of other is granted by s, and other
+// is restricted to resources in the allow list of s. A token may only create
+// tokens that it covers.
+func (s TokenScope) Covers(other TokenScope) (bool, error) {
+	granted := map[string][]policy.Action{}
+	for _, name := range s.Permissions {
+		perms, err := ScopePermissions(name)
+		if err != nil {
+			return false, err
+		}
+		for resource, actions := range perms {
+			granted[resource] = append(granted[resource], actions...)
+		}
+	}
+	for _, name := range other.Permissions {
+		perms, err := ScopePermissions(name)
+		if err != nil {
+			return false, err
+		}
+		for resource, actions := range perms {
+			if slices.Contains(granted[resource], policy.WildcardSymbol) {
+				continue
+			}
+			for _, action := range actions {
+				if !slices.Contains(granted[resource], action) {
+					return false, nil
+				}
+			}
+		}
+	}
+
+	if len(s.AllowIDList) == 0 {
+		return true, nil
+	}
+	if len(other.AllowIDList) == 0 {
+		return false, nil
+	}
+	for _, id := range other.AllowIDList {
+		if !slices.Contains(s.AllowIDList, id) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s TokenScope) Name() RoleIdentifier {
+	perms := append([]string{}, s.Permissions...)
+	sort.Strings(perms)
+	name := fmt.Sprintf("Scope_token[%s]", strings.Join(perms, ","))
+	if len(s.AllowIDList) > 0 {
+		name += fmt.Sprintf("(%s)", strings.Join(s.AllowIDList, ","))
+	}
+	return RoleIdentifier{Name: name}
+}
+
 type ExpandableScope interface {
 	Expand() (Scope, error)
 	// Name is for logging and tracing purposes, we want to know the human
@@ -134,6 +134,14 @@ type TemplateScheduleOptions struct {
 	// TimeTilDormantAutoDelete dictates the duration after which dormant workspaces will be
 	// permanently deleted.
 	TimeTilDormantAutoDelete time.Duration
+	// IdleTimeout dictates the duration after which running workspaces with
+	// no open sessions and CPU usage below IdleCPUThreshold will be stopped
+	// automatically. A value of 0 disables idle stop.
+	IdleTimeout time.Duration
+	// IdleCPUThreshold is the percentage of CPU usage at or above which a
+	// workspace is considered active even if it has no open sessions. A value
+	// of 0 ignores CPU usage.
+	IdleCPUThreshold int32
 	// UpdateWorkspaceLastUsedAt updates the template's workspaces'
 	// last_used_at field. This is useful for preventing updates to the
 	// templates inactivity_ttl immediately triggering a dormant action against
@@ -180,7 +188,8 @@ func (*agplTemplateScheduleStore) Get(ctx context.Context, db database.Store, te
 		DefaultTTL:           time.Duration(tpl.DefaultTTL),
 		ActivityBump:         time.Duration(tpl.ActivityBump),
 		// Disregard the values in the database, since AutostopRequirement,
-		// FailureTTL, TimeTilDormant, and TimeTilDormantAutoDelete are enterprise features.
+		// FailureTTL, TimeTilDormant, TimeTilDormantAutoDelete and IdleTimeout
+		// are enterprise features.
 		AutostartRequirement: TemplateAutostartRequirement{
 			// Default to allowing all days for AGPL
 			DaysOfWeek: 0b01111111,
@@ -194,6 +203,8 @@ func (*agplTemplateScheduleStore) Get(ctx context.Context, db database.Store, te
 		FailureTTL:               0,
 		TimeTilDormant:           0,
 		TimeTilDormantAutoDelete: 0,
+		IdleTimeout:              0,
+		IdleCPUThreshold:         0,
 	}, nil
 }
 
@@ -223,6 +234,8 @@ func (*agplTemplateScheduleStore) Set(ctx context.Context, db database.Store, tp
 			FailureTTL:                    tpl.FailureTTL,
 			TimeTilDormant:                tpl.TimeTilDormant,
 			TimeTilDormantAutoDelete:      tpl.TimeTilDormantAutoDelete,
+			IdleTimeout:                   tpl.IdleTimeout,
+			IdleCPUThreshold:              tpl.IdleCPUThreshold,
 		})
 		if err != nil {
 			return xerrors.Errorf("update template schedule: %w", err)
@@ -19,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/http2"
 	"golang.org/x/xerrors"
 	"tailscale.com/derp"
 	"tailscale.com/tailcfg"
@@ -161,6 +162,14 @@ func NewServerTailnet(
 		//nolint:gosec
 		InsecureSkipVerify: true,
 	}
+	tn.h2cTransport = &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			// AllowHTTP makes the transport dial "http" URLs through here
+			// too, so no TLS handshake is done.
+			return tn.dialContext(ctx, network, addr)
+		},
+	}
 
 	agentConn, err := getMultiAgent(ctx)
 	if err != nil {
@@ -340,6 +349,9 @@ type ServerTailnet struct {
 	agentTickets map[uuid.UUID]map[uuid.UUID]struct{}
 
 	transport *http.Transport
+	// h2cTransport proxies requests to ports shared with the h2c protocol,
+	// e.g. gRPC services.
+	h2cTransport *http2.Transport
 
 	connsPerAgent *prometheus.GaugeVec
 	totalConns    *prometheus.CounterVec
@@ -353,6 +365,11 @@ func (s *ServerTailnet) ReverseProxy(targetURL, dashboardURL *url.URL, agentID u
 	tgt := *targetURL
 	_, port, _ := net.SplitHostPort(tgt.Host)
 	tgt.Host = net.JoinHostPort(tailnet.IPFromUUID(agentID).String(), port)
+	transport := http.RoundTripper(s.transport)
+	if tgt.Scheme == "h2c" {
+		tgt.Scheme = "http"
+		transport = s.h2cTransport
+	}
 
 	proxy := httputil.NewSingleHostReverseProxy(&tgt)
 	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, theErr error) {
@@ -398,7 +415,7 @@ func (s *ServerTailnet) ReverseProxy(targetURL, dashboardURL *url.URL, agentID u
 		})
 	}
 	proxy.Director = s.director(agentID, proxy.Director)
-	proxy.Transport = s.transport
+	proxy.Transport = transport
 
 	return proxy
 }
@@ -535,6 +552,7 @@ func (s *ServerTailnet) Close() error {
 	s.cancel()
 	_ = s.conn.Close()
 	s.transport.CloseIdleConnections()
+	s.h2cTransport.CloseIdleConnections()
 	<-s.derpMapUpdaterClosed
 	return nil
 }
@@ -0,0 +1,121 @@
+package templategitsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/provisionersdk"
+)
+
+// GitFetcher reads repositories with the git command.
+type GitFetcher struct{}
+
+func (GitFetcher) Resolve(ctx context.Context, repo Repository) (string, error) {
+	out, err := git(ctx, repo, "", "ls-remote", "--", repo.URL, "refs/heads/"+repo.Branch)
+	if err != nil {
+		return "", err
+	}
+	commit, _, _ := strings.Cut(strings.TrimSpace(out), "\t")
+	if commit == "" {
+		return "", xerrors.Errorf("branch %q does not exist", repo.Branch)
+	}
+	return commit, nil
+}
+
+func (GitFetcher) Fetch(ctx context.Context, repo Repository) (string, []byte, error) {
+	dir, err := os.MkdirTemp("", "coder-template-git-sync-")
+	if err != nil {
+		return "", nil, xerrors.Errorf("create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"fetch", "--quiet", "--depth=1", "--", repo.URL, "refs/heads/" + repo.Branch},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		_, err = git(ctx, repo, dir, args...)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	commit, err := git(ctx, repo, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+
+	source, err := filepath.Abs(filepath.Join(dir, filepath.FromSlash(repo.Directory)))
+	if err != nil {
+		return "", nil, xerrors.Errorf("resolve directory: %w", err)
+	}
+	source, err = filepath.EvalSymlinks(source)
+	if err != nil {
+		return "", nil, xerrors.Errorf("directory %q does not exist in the repository", repo.Directory)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", nil, xerrors.Errorf("resolve repository: %w", err)
+	}
+	if source != root && !strings.HasPrefix(source, root+string(filepath.Separator)) {
+		return "", nil, xerrors.Errorf("directory %q is outside the repository", repo.Directory)
+	}
+
+	var archive bytes.Buffer
+	err = provisionersdk.Tar(&archive, slog.Make(), source, provisionersdk.TemplateArchiveLimit)
+	if err != nil {
+		return "", nil, xerrors.Errorf("archive %q: %w", repo.Directory, err)
+	}
+	return strings.TrimSpace(commit), archive.Bytes(), nil
+}
+
+// git runs a git command that cannot prompt for credentials or use local
+// repositories as remotes. Credentials are only ever sent over HTTPS.
+func git(ctx context.Context, repo Repository, dir string, args ...string) (string, error) {
+	authenticated := repo.Username != "" || repo.Password != ""
+	allowProtocol := "https:http"
+	if authenticated {
+		u, err := url.Parse(repo.URL)
+		if err != nil || u.Scheme != "https" {
+			return "", xerrors.New("credentials are only sent to HTTPS repositories")
+		}
+		// Redirects are restricted to the allowed protocols too.
+		allowProtocol = "https"
+	}
+
+	//nolint:gosec // The arguments are separated from options with "--".
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_ALLOW_PROTOCOL="+allowProtocol,
+		"GIT_CONFIG_NOSYSTEM=1",
+	)
+	if authenticated {
+		// Pass the credentials as a header through the environment, so they
+		// are not visible in the process list.
+		auth := base64.StdEncoding.EncodeToString([]byte(repo.Username + ":" + repo.Password))
+		cmd.Env = append(cmd.Env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=http.extraHeader",
+			"GIT_CONFIG_VALUE_0=Authorization: Basic "+auth,
+		)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err != nil {
+		return "", xerrors.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,453 @@
+// Package templategitsync creates template versions from the Git repositories
+// that templates are linked to.
+package templategitsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moby/moby/pkg/namesgenerator"
+	"github.com/sqlc-dev/pqtype"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/externalauth"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/provisionerdserver"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/tracing"
+)
+
+const (
+	// DefaultInterval is the default time between polls of the linked
+	// repositories.
+	DefaultInterval = time.Minute
+
+	tarMimeType = "application/x-tar"
+)
+
+// Repository is the source a template is synced from.
+type Repository struct {
+	URL       string
+	Branch    string
+	Directory string
+	// Username and Password authenticate over HTTPS. They are empty for
+	// public repositories.
+	Username string
+	Password string
+}
+
+// Fetcher reads template source from a Git repository.
+type Fetcher interface {
+	// Resolve returns the commit at the head of the branch.
+	Resolve(ctx context.Context, repo Repository) (string, error)
+	// Fetch returns the commit at the head of the branch and a tar archive
+	// of the directory at that commit.
+	Fetch(ctx context.Context, repo Repository) (commit string, archive []byte, err error)
+}
+
+// Syncer periodically creates template versions for new commits in the
+// repositories that templates are linked to.
+type Syncer struct {
+	cancel   context.CancelFunc
+	closed   chan struct{}
+	db       database.Store
+	ps       pubsub.Pubsub
+	logger   slog.Logger
+	interval time.Duration
+	fetcher  Fetcher
+	trigger  chan uuid.UUID
+
+	externalAuthConfigs []*externalauth.Config
+}
+
+type Option func(*Syncer)
+
+// WithInterval sets the interval between polls.
+func WithInterval(interval time.Duration) Option {
+	return func(s *Syncer) {
+		s.interval = interval
+	}
+}
+
+// WithFetcher sets the fetcher used to read repositories. Defaults to the
+// git command.
+func WithFetcher(fetcher Fetcher) Option {
+	return func(s *Syncer) {
+		s.fetcher = fetcher
+	}
+}
+
+// New creates a new template Git sync service that polls the linked
+// repositories. It is the caller's responsibility to call Close on the
+// returned instance.
+func New(logger slog.Logger, db database.Store, ps pubsub.Pubsub, externalAuthConfigs []*externalauth.Config, opts ...Option) *Syncer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Syncer{
+		cancel:              cancel,
+		closed:              make(chan struct{}),
+		db:                  db,
+		ps:                  ps,
+		logger:              logger,
+		interval:            DefaultInterval,
+		fetcher:             GitFetcher{},
+		trigger:             make(chan uuid.UUID, 64),
+		externalAuthConfigs: externalAuthConfigs,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	//nolint:gocritic // The system polls repositories without user input.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	go s.start(ctx)
+
+	return s
+}
+
+// Trigger schedules a sync of the template in the background, e.g. when a
+// webhook reports a push.
+func (s *Syncer) Trigger(templateID uuid.UUID) {
+	select {
+	case s.trigger <- templateID:
+	default:
+		s.logger.Warn(context.Background(), "template git sync queue is full, waiting for the next poll",
+			slog.F("template_id", templateID))
+	}
+}
+
+func (s *Syncer) start(ctx context.Context) {
+	defer close(s.closed)
+
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case templateID := <-s.trigger:
+			err := s.Sync(ctx, templateID)
+			if err != nil && ctx.Err() == nil {
+				s.logger.Warn(ctx, "failed to sync template", slog.F("template_id", templateID), slog.Error(err))
+			}
+		case <-t.C:
+			s.poll(ctx)
+		}
+	}
+}
+
+func (s *Syncer) poll(ctx context.Context) {
+	syncs, err := s.db.GetTemplateGitSyncs(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error(ctx, "failed to get template git syncs", slog.Error(err))
+		}
+		return
+	}
+	for _, sync := range syncs {
+		if ctx.Err() != nil {
+			return
+		}
+		err := s.Sync(ctx, sync.TemplateID)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Warn(ctx, "failed to sync template", slog.F("template_id", sync.TemplateID), slog.Error(err))
+		}
+	}
+}
+
+// Sync creates a template version if the head of the linked branch has
+// changed since the last sync. Failures are recorded on the sync as well as
+// returned.
+func (s *Syncer) Sync(ctx context.Context, templateID uuid.UUID) error {
+	//nolint:gocritic // The caller is authorized to sync the template.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+
+	err := s.sync(ctx, templateID)
+	if err != nil {
+		s.recordError(ctx, templateID, err)
+	}
+	return err
+}
+
+func (s *Syncer) sync(ctx context.Context, templateID uuid.UUID) error {
+	sync, err := s.db.GetTemplateGitSyncByTemplateID(ctx, templateID)
+	if err != nil {
+		return xerrors.Errorf("get template git sync: %w", err)
+	}
+	template, err := s.db.GetTemplateByID(ctx, sync.TemplateID)
+	if err != nil {
+		return xerrors.Errorf("get template: %w", err)
+	}
+	if template.Deleted {
+		return nil
+	}
+
+	// Versions are created as the user that configured the sync, so they
+	// cannot do more than the user could by pushing the template.
+	actor, _, err := httpmw.UserRBACSubject(ctx, s.db, sync.CreatedBy, rbac.ScopeAll)
+	if err != nil {
+		return xerrors.Errorf("fetch user actor: %w", err)
+	}
+	userCtx := dbauthz.As(ctx, actor)
+
+	repo := Repository{
+		URL:       sync.RepositoryURL,
+		Branch:    sync.Branch,
+		Directory: sync.Directory,
+	}
+	if sync.ExternalAuthProviderID != "" {
+		repo.Username, repo.Password, err = s.credentials(userCtx, sync)
+		if err != nil {
+			return err
+		}
+	}
+
+	commit, err := s.fetcher.Resolve(ctx, repo)
+	if err != nil {
+		return xerrors.Errorf("resolve %s: %w", sync.Branch, err)
+	}
+	var archive []byte
+	if commit != sync.LastCommitSha {
+		commit, archive, err = s.fetcher.Fetch(ctx, repo)
+		if err != nil {
+			return xerrors.Errorf("fetch %s: %w", sync.Branch, err)
+		}
+	}
+
+	var (
+		version database.TemplateVersion
+		job     database.ProvisionerJob
+	)
+	err = s.locked(ctx, templateID, func(tx database.Store, current database.TemplateGitSync) error {
+		status := database.UpdateTemplateGitSyncStatusParams{
+			TemplateID:            current.TemplateID,
+			LastCommitSha:         current.LastCommitSha,
+			LastTemplateVersionID: current.LastTemplateVersionID,
+			LastSyncedAt:          sql.NullTime{Time: dbtime.Now(), Valid: true},
+		}
+		// Another replica may have synced the commit, or the sync may have
+		// been pointed elsewhere, since the sync was read.
+		if archive != nil && current.LastCommitSha != commit &&
+			current.RepositoryURL == sync.RepositoryURL && current.Branch == sync.Branch && current.Directory == sync.Directory {
+			var err error
+			version, job, err = s.createVersion(userCtx, tx, template, current, commit, archive)
+			if err != nil {
+				return err
+			}
+			status.LastCommitSha = commit
+			status.LastTemplateVersionID = uuid.NullUUID{UUID: version.ID, Valid: true}
+		}
+		return tx.UpdateTemplateGitSyncStatus(ctx, status)
+	})
+	if err != nil {
+		return err
+	}
+	if version.ID == uuid.Nil {
+		return nil
+	}
+
+	err = provisionerjobs.PostJob(s.ps, job)
+	if err != nil {
+		s.logger.Error(ctx, "failed to post provisioner job to pubsub", slog.Error(err))
+	}
+	s.logger.Info(ctx, "created template version from git",
+		slog.F("template_id", template.ID),
+		slog.F("template_version_id", version.ID),
+		slog.F("commit", commit))
+	return nil
+}
+
+// locked runs fn in a transaction that holds the lock of the template's
+// sync. Replicas poll at the same time, so fn is skipped if another replica
+// holds the lock.
+func (s *Syncer) locked(ctx context.Context, templateID uuid.UUID, fn func(tx database.Store, current database.TemplateGitSync) error) error {
+	return s.db.InTx(func(tx database.Store) error {
+		ok, err := tx.TryAcquireLock(ctx, database.GenLockID("template-git-sync:"+templateID.String()))
+		if err != nil {
+			return xerrors.Errorf("acquire lock: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		current, err := tx.GetTemplateGitSyncByTemplateID(ctx, templateID)
+		if err != nil {
+			return xerrors.Errorf("get template git sync: %w", err)
+		}
+		return fn(tx, current)
+	}, nil)
+}
+
+func (s *Syncer) recordError(ctx context.Context, templateID uuid.UUID, syncErr error) {
+	err := s.locked(ctx, templateID, func(tx database.Store, current database.TemplateGitSync) error {
+		return tx.UpdateTemplateGitSyncStatus(ctx, database.UpdateTemplateGitSyncStatusParams{
+			TemplateID:            current.TemplateID,
+			LastCommitSha:         current.LastCommitSha,
+			LastTemplateVersionID: current.LastTemplateVersionID,
+			LastSyncedAt:          sql.NullTime{Time: dbtime.Now(), Valid: true},
+			LastError:             syncErr.Error(),
+		})
+	})
+	if err != nil && !errors.Is(err, sql.ErrNoRows) && ctx.Err() == nil {
+		s.logger.Error(ctx, "failed to record template git sync error", slog.F("template_id", templateID), slog.Error(err))
+	}
+}
+
+// credentials returns the Git credentials of the user that configured the
+// sync for its external auth provider.
+func (s *Syncer) credentials(userCtx context.Context, sync database.TemplateGitSync) (string, string, error) {
+	var config *externalauth.Config
+	for _, c := range s.externalAuthConfigs {
+		if c.ID == sync.ExternalAuthProviderID {
+			config = c
+			break
+		}
+	}
+	if config == nil {
+		return "", "", xerrors.Errorf("external auth provider %q is not configured", sync.ExternalAuthProviderID)
+	}
+
+	link, err := s.db.GetExternalAuthLink(userCtx, database.GetExternalAuthLinkParams{
+		ProviderID: config.ID,
+		UserID:     sync.CreatedBy,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", xerrors.Errorf("the user that configured the sync has not authenticated with %q", config.ID)
+	}
+	if err != nil {
+		return "", "", xerrors.Errorf("get external auth link: %w", err)
+	}
+	link, err = config.RefreshToken(userCtx, s.db, link)
+	if err != nil {
+		return "", "", xerrors.Errorf("refresh %q token: %w", config.ID, err)
+	}
+	username, password := externalauth.GitCredentials(config.Type, link.OAuthAccessToken)
+	return username, password, nil
+}
+
+func (*Syncer) createVersion(userCtx context.Context, tx database.Store, template database.Template, sync database.TemplateGitSync, commit string, archive []byte) (database.TemplateVersion, database.ProvisionerJob, error) {
+	// Versions are built on the same provisioners as the active version.
+	activeVersion, err := tx.GetTemplateVersionByID(userCtx, template.ActiveVersionID)
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("get active version: %w", err)
+	}
+	activeJob, err := tx.GetProvisionerJobByID(userCtx, activeVersion.JobID)
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("get active version job: %w", err)
+	}
+
+	hashBytes := sha256.Sum256(archive)
+	hash := hex.EncodeToString(hashBytes[:])
+	file, err := tx.GetFileByHashAndCreator(userCtx, database.GetFileByHashAndCreatorParams{
+		Hash:      hash,
+		CreatedBy: sync.CreatedBy,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		file, err = tx.InsertFile(userCtx, database.InsertFileParams{
+			ID:        uuid.New(),
+			Hash:      hash,
+			CreatedBy: sync.CreatedBy,
+			CreatedAt: dbtime.Now(),
+			Mimetype:  tarMimeType,
+			Data:      archive,
+		})
+	}
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("insert file: %w", err)
+	}
+
+	templateVersionID := uuid.New()
+	jobInput, err := json.Marshal(provisionerdserver.TemplateVersionImportJob{
+		TemplateVersionID: templateVersionID,
+		ActivateOnSuccess: sync.AutoActivate,
+	})
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("marshal job input: %w", err)
+	}
+	traceMetadataRaw, err := json.Marshal(tracing.MetadataFromContext(userCtx))
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("marshal job metadata: %w", err)
+	}
+	job, err := tx.InsertProvisionerJob(userCtx, database.InsertProvisionerJobParams{
+		ID:             uuid.New(),
+		CreatedAt:      dbtime.Now(),
+		UpdatedAt:      dbtime.Now(),
+		OrganizationID: template.OrganizationID,
+		InitiatorID:    sync.CreatedBy,
+		Provisioner:    template.Provisioner,
+		StorageMethod:  database.ProvisionerStorageMethodFile,
+		FileID:         file.ID,
+		Type:           database.ProvisionerJobTypeTemplateVersionImport,
+		Input:          jobInput,
+		Tags:           activeJob.Tags,
+		TraceMetadata: pqtype.NullRawMessage{
+			Valid:      true,
+			RawMessage: traceMetadataRaw,
+		},
+	})
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("insert provisioner job: %w", err)
+	}
+
+	// Name the version after the commit, unless a version of the template
+	// already has that name, e.g. when the sync was pointed back at a
+	// previous commit.
+	name := shortCommit(commit)
+	_, err = tx.GetTemplateVersionByTemplateIDAndName(userCtx, database.GetTemplateVersionByTemplateIDAndNameParams{
+		TemplateID: uuid.NullUUID{UUID: template.ID, Valid: true},
+		Name:       name,
+	})
+	if err == nil {
+		name = namesgenerator.GetRandomName(1)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("get template version by name: %w", err)
+	}
+
+	err = tx.InsertTemplateVersion(userCtx, database.InsertTemplateVersionParams{
+		ID:             templateVersionID,
+		TemplateID:     uuid.NullUUID{UUID: template.ID, Valid: true},
+		OrganizationID: template.OrganizationID,
+		CreatedAt:      dbtime.Now(),
+		UpdatedAt:      dbtime.Now(),
+		Name:           name,
+		Message:        fmt.Sprintf("Synced from %s at %s", sync.Branch, commit),
+		Readme:         "",
+		JobID:          job.ID,
+		CreatedBy:      sync.CreatedBy,
+	})
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("insert template version: %w", err)
+	}
+	version, err := tx.GetTemplateVersionByID(userCtx, templateVersionID)
+	if err != nil {
+		return database.TemplateVersion{}, database.ProvisionerJob{}, xerrors.Errorf("fetch inserted template version: %w", err)
+	}
+	return version, job, nil
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
+
+func (s *Syncer) Close() error {
+	s.cancel()
+	<-s.closed
+	return nil
+}
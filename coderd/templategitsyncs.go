@@ -0,0 +1,420 @@
+package coderd
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/cryptorand"
+)
+
+// templateGitSyncWebhookLimit is the largest push event that is read to
+// verify its signature.
+const templateGitSyncWebhookLimit = 10 << 20
+
+// @Summary Get template Git sync
+// @ID get-template-git-sync
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {object} codersdk.TemplateGitSync
+// @Router /templates/{template}/git-sync [get]
+func (api *API) templateGitSync(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may view the Git sync of a template.",
+		})
+		return
+	}
+
+	sync, err := api.Database.GetTemplateGitSyncByTemplateID(ctx, template.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Template is not synced from Git.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplateGitSync(sync, false))
+}
+
+// @Summary Update template Git sync
+// @ID update-template-git-sync
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Param request body codersdk.UpdateTemplateGitSyncRequest true "Update template Git sync request"
+// @Success 200 {object} codersdk.TemplateGitSync
+// @Router /templates/{template}/git-sync [put]
+func (api *API) putTemplateGitSync(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		apiKey            = httpmw.APIKey(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateGitSync](rw, &audit.RequestParams{
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may sync a template from Git.",
+		})
+		return
+	}
+
+	var req codersdk.UpdateTemplateGitSyncRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if req.Branch == "" {
+		req.Branch = "main"
+	}
+	var validations []codersdk.ValidationError
+	if detail := validateGitRepositoryURL(req.RepositoryURL, req.ExternalAuthProviderID != ""); detail != "" {
+		validations = append(validations, codersdk.ValidationError{Field: "repository_url", Detail: detail})
+	}
+	if strings.HasPrefix(req.Branch, "-") || strings.ContainsAny(req.Branch, " \t\n~^:?*[\\") {
+		validations = append(validations, codersdk.ValidationError{Field: "branch", Detail: "Must be a valid branch name."})
+	}
+	directory := path.Clean("/" + req.Directory)[1:]
+	if req.Directory != "" && (path.IsAbs(req.Directory) || strings.HasPrefix(path.Clean(req.Directory), "..")) {
+		validations = append(validations, codersdk.ValidationError{Field: "directory", Detail: "Must be a relative path inside the repository."})
+	}
+	if len(validations) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid Git sync.",
+			Validations: validations,
+		})
+		return
+	}
+
+	if req.ExternalAuthProviderID != "" {
+		var configured bool
+		for _, config := range api.ExternalAuthConfigs {
+			if config.ID == req.ExternalAuthProviderID {
+				configured = true
+				break
+			}
+		}
+		if !configured {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("External auth provider %q is not configured.", req.ExternalAuthProviderID),
+			})
+			return
+		}
+		// Repositories are fetched with the token of the user that
+		// configures the sync.
+		_, err := api.Database.GetExternalAuthLink(ctx, database.GetExternalAuthLinkParams{
+			ProviderID: req.ExternalAuthProviderID,
+			UserID:     apiKey.UserID,
+		})
+		if httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message: fmt.Sprintf("You must authenticate with %q before using it to sync templates.", req.ExternalAuthProviderID),
+			})
+			return
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+	}
+
+	old, err := api.Database.GetTemplateGitSyncByTemplateID(ctx, template.ID)
+	if err != nil && !httpapi.Is404Error(err) {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.Old = old
+
+	// The secret is kept when an existing sync is updated, so webhooks
+	// that are already configured keep working.
+	secret, err := cryptorand.String(32)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	sync, err := api.Database.UpsertTemplateGitSync(ctx, database.UpsertTemplateGitSyncParams{
+		TemplateID:             template.ID,
+		RepositoryURL:          req.RepositoryURL,
+		Branch:                 req.Branch,
+		Directory:              directory,
+		ExternalAuthProviderID: req.ExternalAuthProviderID,
+		AutoActivate:           req.AutoActivate,
+		WebhookSecret:          secret,
+		CreatedBy:              apiKey.UserID,
+		CreatedAt:              dbtime.Now(),
+		UpdatedAt:              dbtime.Now(),
+	})
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.New = sync
+
+	api.templateGitSyncer.Trigger(template.ID)
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplateGitSync(sync, true))
+}
+
+// @Summary Delete template Git sync
+// @ID delete-template-git-sync
+// @Security CoderSessionToken
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 204
+// @Router /templates/{template}/git-sync [delete]
+func (api *API) deleteTemplateGitSync(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateGitSync](rw, &audit.RequestParams{
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may stop syncing a template from Git.",
+		})
+		return
+	}
+
+	sync, err := api.Database.GetTemplateGitSyncByTemplateID(ctx, template.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Template is not synced from Git.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.Old = sync
+
+	err = api.Database.DeleteTemplateGitSyncByTemplateID(ctx, template.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Sync template from Git
+// @ID sync-template-from-git
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {object} codersdk.TemplateGitSync
+// @Router /templates/{template}/git-sync/sync [post]
+func (api *API) postTemplateGitSyncSync(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may sync a template from Git.",
+		})
+		return
+	}
+
+	_, err := api.Database.GetTemplateGitSyncByTemplateID(ctx, template.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Template is not synced from Git.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	// Failures are recorded on the sync, which is returned below.
+	err = api.templateGitSyncer.Sync(ctx, template.ID)
+	if err != nil {
+		api.Logger.Debug(ctx, "sync template from git", slog.F("template_id", template.ID), slog.Error(err))
+	}
+
+	sync, err := api.Database.GetTemplateGitSyncByTemplateID(ctx, template.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, api.convertTemplateGitSync(sync, false))
+}
+
+// @Summary Receive template Git sync webhook
+// @ID receive-template-git-sync-webhook
+// @Accept json
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 202 {object} codersdk.Response
+// @Router /templates/{template}/git-sync/webhook [post]
+func (api *API) templateGitSyncWebhook(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	templateID, err := uuid.Parse(chi.URLParam(r, "template"))
+	if err != nil {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	//nolint:gocritic // The webhook is authenticated with the sync's secret.
+	sync, err := api.Database.GetTemplateGitSyncByTemplateID(dbauthz.AsSystemRestricted(ctx), templateID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, templateGitSyncWebhookLimit))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to read request body.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if !verifyTemplateGitSyncWebhook(r.Header, body, sync.WebhookSecret) {
+		httpapi.Write(ctx, rw, http.StatusUnauthorized, codersdk.Response{
+			Message: "Invalid webhook signature.",
+		})
+		return
+	}
+
+	// Pushes to other branches do not change the template.
+	var event struct {
+		Ref string `json:"ref"`
+	}
+	_ = json.Unmarshal(body, &event)
+	if event.Ref != "" && event.Ref != "refs/heads/"+sync.Branch {
+		httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+			Message: fmt.Sprintf("Ignored push to %q.", event.Ref),
+		})
+		return
+	}
+
+	api.templateGitSyncer.Trigger(sync.TemplateID)
+	httpapi.Write(ctx, rw, http.StatusAccepted, codersdk.Response{
+		Message: "Template sync scheduled.",
+	})
+}
+
+// verifyTemplateGitSyncWebhook authenticates a push event with the signature
+// GitHub and Gitea send, or the token GitLab sends.
+func verifyTemplateGitSyncWebhook(header http.Header, body []byte, secret string) bool {
+	if signature := header.Get("X-Hub-Signature-256"); signature != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(body)
+		expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		return hmac.Equal([]byte(signature), []byte(expected))
+	}
+	if token := header.Get("X-Gitlab-Token"); token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
+	}
+	return false
+}
+
+// validateGitRepositoryURL returns why a repository URL is invalid, or an
+// empty string if it's valid. Repositories that are fetched with credentials
+// must use HTTPS, so the credentials aren't sent in the clear.
+func validateGitRepositoryURL(raw string, authenticated bool) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "Must be a valid URL."
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "Must be an HTTP or HTTPS URL."
+	}
+	if authenticated && u.Scheme != "https" {
+		return "Must be an HTTPS URL when an external auth provider is used."
+	}
+	if u.Host == "" {
+		return "Must include a host."
+	}
+	if u.User != nil {
+		return "Must not include credentials. Use an external auth provider instead."
+	}
+	return ""
+}
+
+func (api *API) convertTemplateGitSync(sync database.TemplateGitSync, includeSecret bool) codersdk.TemplateGitSync {
+	resp := codersdk.TemplateGitSync{
+		TemplateID:             sync.TemplateID,
+		RepositoryURL:          sync.RepositoryURL,
+		Branch:                 sync.Branch,
+		Directory:              sync.Directory,
+		ExternalAuthProviderID: sync.ExternalAuthProviderID,
+		AutoActivate:           sync.AutoActivate,
+		WebhookURL:             api.AccessURL.JoinPath("/api/v2/templates", sync.TemplateID.String(), "git-sync/webhook").String(),
+		CreatedBy:              sync.CreatedBy,
+		LastCommitSHA:          sync.LastCommitSha,
+		LastError:              sync.LastError,
+		CreatedAt:              sync.CreatedAt,
+		UpdatedAt:              sync.UpdatedAt,
+	}
+	if includeSecret {
+		resp.WebhookSecret = sync.WebhookSecret
+	}
+	if sync.LastTemplateVersionID.Valid {
+		resp.LastTemplateVersionID = &sync.LastTemplateVersionID.UUID
+	}
+	if sync.LastSyncedAt.Valid {
+		resp.LastSyncedAt = &sync.LastSyncedAt.Time
+	}
+	return resp
+}
@@ -0,0 +1,358 @@
+// Package templatepolicy checks template versions against the template policy
+// rules configured for the deployment.
+package templatepolicy
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+const defaultRegistry = "registry.terraform.io/"
+
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "terraform"},
+		{Type: "provider", LabelNames: []string{"name"}},
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "data", LabelNames: []string{"type", "name"}},
+	},
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "required_providers"},
+	},
+}
+
+// Module is the Terraform configuration of a template version.
+type Module struct {
+	files     map[string]*hcl.File
+	providers map[string]moduleProvider
+	resources map[string]hcl.Range
+}
+
+type moduleProvider struct {
+	source   string
+	declared hcl.Range
+}
+
+// LoadModule parses the Terraform files of a template version's source
+// archive.
+func LoadModule(source []byte) (*Module, error) {
+	module := &Module{
+		files:     make(map[string]*hcl.File),
+		providers: make(map[string]moduleProvider),
+		resources: make(map[string]hcl.Range),
+	}
+	parser := hclparse.NewParser()
+	reader := tar.NewReader(bytes.NewReader(source))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("read source archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if slices.Contains(strings.Split(name, "/"), ".terraform") {
+			continue
+		}
+
+		var parse func([]byte, string) (*hcl.File, hcl.Diagnostics)
+		switch {
+		case strings.HasSuffix(name, ".tf"):
+			parse = parser.ParseHCL
+		case strings.HasSuffix(name, ".tf.json"):
+			parse = parser.ParseJSON
+		default:
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, xerrors.Errorf("read %q: %w", name, err)
+		}
+		file, diags := parse(content, name)
+		if diags.HasErrors() {
+			return nil, xerrors.Errorf("parse %q: %w", name, diags)
+		}
+		module.files[name] = file
+		module.inspect(file)
+	}
+	return module, nil
+}
+
+func (m *Module) inspect(file *hcl.File) {
+	// Templates may use blocks that are not in the schema, so the
+	// diagnostics of a partial decode are expected.
+	content, _, _ := file.Body.PartialContent(moduleSchema)
+	for _, block := range content.Blocks {
+		switch block.Type {
+		case "terraform":
+			terraform, _, _ := block.Body.PartialContent(terraformSchema)
+			for _, requiredProviders := range terraform.Blocks {
+				attrs, _ := requiredProviders.Body.JustAttributes()
+				for name, attr := range attrs {
+					m.addProvider(name, providerSource(attr.Expr), attr.Range)
+				}
+			}
+		case "provider":
+			m.addProvider(block.Labels[0], "", block.DefRange)
+		case "resource":
+			key := block.Labels[0] + "." + block.Labels[1]
+			if _, ok := m.resources[key]; !ok {
+				m.resources[key] = block.DefRange
+			}
+			m.addProvider(impliedProvider(block.Labels[0]), "", block.DefRange)
+		case "data":
+			m.addProvider(impliedProvider(block.Labels[0]), "", block.DefRange)
+		}
+	}
+}
+
+// addProvider records a provider used by the module. Declarations in
+// required_providers take precedence, as they specify the source.
+func (m *Module) addProvider(name, source string, declared hcl.Range) {
+	existing, ok := m.providers[name]
+	if ok && (existing.source != "" || source == "") {
+		return
+	}
+	m.providers[name] = moduleProvider{source: source, declared: declared}
+}
+
+// Providers returns the source addresses of the providers used by the module,
+// keyed by local name. Providers that are not in required_providers are
+// assumed to be published by HashiCorp, like Terraform does.
+func (m *Module) Providers() map[string]string {
+	providers := make(map[string]string, len(m.providers))
+	for name, provider := range m.providers {
+		providers[name] = providerAddress(name, provider.source)
+	}
+	return providers
+}
+
+func providerSource(expr hcl.Expression) string {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() {
+		return ""
+	}
+	if !value.Type().IsObjectType() || !value.Type().HasAttribute("source") {
+		return ""
+	}
+	source := value.GetAttr("source")
+	if source.Type() != cty.String || !source.IsKnown() || source.IsNull() {
+		return ""
+	}
+	return source.AsString()
+}
+
+func providerAddress(name, source string) string {
+	if source == "" {
+		source = "hashicorp/" + name
+	}
+	return strings.TrimPrefix(strings.ToLower(source), defaultRegistry)
+}
+
+// impliedProvider returns the local name of the provider of a resource type,
+// which by convention is the prefix of the type.
+func impliedProvider(resourceType string) string {
+	name, _, _ := strings.Cut(resourceType, "_")
+	return name
+}
+
+// Check evaluates the rules against a template version's module and the
+// resources planned for the start transition. It returns an error diagnostic
+// for every violation.
+func Check(rules []codersdk.TemplatePolicyRule, module *Module, resources []*proto.Resource) []*tfjson.Diagnostic {
+	var diags []*tfjson.Diagnostic
+	for _, rule := range rules {
+		switch rule.Type {
+		case codersdk.TemplatePolicyRuleRequiredMetadata:
+			diags = append(diags, checkRequiredMetadata(rule, module, resources)...)
+		case codersdk.TemplatePolicyRuleForbiddenProviders:
+			diags = append(diags, checkForbiddenProviders(rule, module)...)
+		case codersdk.TemplatePolicyRuleAllowedInstanceTypes:
+			diags = append(diags, checkAllowedInstanceTypes(rule, module, resources)...)
+		case codersdk.TemplatePolicyRuleRequiredShutdownScript:
+			diags = append(diags, checkRequiredShutdownScript(rule, module, resources)...)
+		}
+	}
+	return diags
+}
+
+func checkRequiredMetadata(rule codersdk.TemplatePolicyRule, module *Module, resources []*proto.Resource) []*tfjson.Diagnostic {
+	var diags []*tfjson.Diagnostic
+	for _, resource := range resources {
+		if len(resource.Agents) == 0 {
+			continue
+		}
+		keys := make(map[string]bool, len(resource.Metadata))
+		for _, item := range resource.Metadata {
+			keys[item.Key] = true
+		}
+
+		var missing []string
+		for _, key := range rule.MetadataKeys {
+			if !keys[key] {
+				missing = append(missing, key)
+			}
+		}
+		address := resource.Type + "." + resource.Name
+		switch {
+		case len(rule.MetadataKeys) == 0 && len(resource.Metadata) == 0:
+			diags = append(diags, module.diagnostic(
+				"Missing resource metadata",
+				fmt.Sprintf("The template policy rule %q requires resources with an agent to have coder_metadata. Add a coder_metadata resource for %s.", rule.DisplayName(), address),
+				module.resource(resource.Type, resource.Name),
+			))
+		case len(missing) > 0:
+			diags = append(diags, module.diagnostic(
+				"Missing resource metadata",
+				fmt.Sprintf("The template policy rule %q requires resources with an agent to have the coder_metadata items %s. %s is missing %s.", rule.DisplayName(), quoteList(rule.MetadataKeys), address, quoteList(missing)),
+				module.resource(resource.Type, resource.Name),
+			))
+		}
+	}
+	return diags
+}
+
+func checkForbiddenProviders(rule codersdk.TemplatePolicyRule, module *Module) []*tfjson.Diagnostic {
+	names := make([]string, 0, len(module.providers))
+	for name := range module.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags []*tfjson.Diagnostic
+	for _, name := range names {
+		provider := module.providers[name]
+		address := providerAddress(name, provider.source)
+		forbidden := slices.ContainsFunc(rule.Providers, func(forbidden string) bool {
+			forbidden = strings.TrimPrefix(strings.ToLower(forbidden), defaultRegistry)
+			return forbidden == name || forbidden == address
+		})
+		if !forbidden {
+			continue
+		}
+		declared := provider.declared
+		diags = append(diags, module.diagnostic(
+			"Forbidden provider",
+			fmt.Sprintf("The template policy rule %q forbids the provider %q (%s).", rule.DisplayName(), name, address),
+			&declared,
+		))
+	}
+	return diags
+}
+
+func checkAllowedInstanceTypes(rule codersdk.TemplatePolicyRule, module *Module, resources []*proto.Resource) []*tfjson.Diagnostic {
+	var diags []*tfjson.Diagnostic
+	for _, resource := range resources {
+		if resource.InstanceType == "" {
+			continue
+		}
+		allowed := slices.ContainsFunc(rule.InstanceTypes, func(pattern string) bool {
+			matched, _ := path.Match(pattern, resource.InstanceType)
+			return matched
+		})
+		if allowed {
+			continue
+		}
+		diags = append(diags, module.diagnostic(
+			"Instance type not allowed",
+			fmt.Sprintf("The template policy rule %q does not allow the instance type %q of %s.%s. Allowed instance types are %s.", rule.DisplayName(), resource.InstanceType, resource.Type, resource.Name, quoteList(rule.InstanceTypes)),
+			module.resource(resource.Type, resource.Name),
+		))
+	}
+	return diags
+}
+
+func checkRequiredShutdownScript(rule codersdk.TemplatePolicyRule, module *Module, resources []*proto.Resource) []*tfjson.Diagnostic {
+	var diags []*tfjson.Diagnostic
+	for _, resource := range resources {
+		for _, agent := range resource.Agents {
+			hasShutdownScript := slices.ContainsFunc(agent.Scripts, func(script *proto.Script) bool {
+				return script.RunOnStop
+			})
+			if hasShutdownScript {
+				continue
+			}
+			diags = append(diags, module.diagnostic(
+				"Missing shutdown script",
+				fmt.Sprintf("The template policy rule %q requires agents to run a script when the workspace stops. Add a coder_script with run_on_stop = true to the agent %q.", rule.DisplayName(), agent.Name),
+				module.resource("coder_agent", agent.Name),
+			))
+		}
+	}
+	return diags
+}
+
+// resource returns the location of a resource block, if it is declared in
+// the module.
+func (m *Module) resource(resourceType, name string) *hcl.Range {
+	declared, ok := m.resources[resourceType+"."+name]
+	if !ok {
+		return nil
+	}
+	return &declared
+}
+
+// diagnostic creates an error diagnostic in the format Terraform uses, so it
+// renders like other template import errors.
+func (m *Module) diagnostic(summary, detail string, subject *hcl.Range) *tfjson.Diagnostic {
+	diag := &tfjson.Diagnostic{
+		Severity: tfjson.DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   detail,
+	}
+	if subject == nil {
+		return diag
+	}
+	diag.Range = &tfjson.Range{
+		Filename: subject.Filename,
+		Start:    tfjson.Pos{Line: subject.Start.Line, Column: subject.Start.Column, Byte: subject.Start.Byte},
+		End:      tfjson.Pos{Line: subject.End.Line, Column: subject.End.Column, Byte: subject.End.Byte},
+	}
+	file, ok := m.files[subject.Filename]
+	if !ok {
+		return diag
+	}
+	lines := strings.Split(string(file.Bytes), "\n")
+	if subject.Start.Line < 1 || subject.Start.Line > len(lines) {
+		return diag
+	}
+	code := strings.TrimSuffix(lines[subject.Start.Line-1], "\r")
+	diag.Snippet = &tfjson.DiagnosticSnippet{
+		Code:                 code,
+		StartLine:            subject.Start.Line,
+		HighlightStartOffset: subject.Start.Column - 1,
+		HighlightEndOffset:   min(len(code), subject.End.Column-1),
+	}
+	return diag
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,157 @@
+package templatepolicy_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/templatepolicy"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisionersdk"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+)
+
+const mainTF = `terraform {
+  required_providers {
+    coder = {
+      source = "coder/coder"
+    }
+    docker = {
+      source = "kreuzwerker/docker"
+    }
+  }
+}
+
+resource "coder_agent" "main" {
+  os   = "linux"
+  arch = "amd64"
+}
+
+resource "docker_container" "workspace" {
+  image = "codercom/enterprise-base:ubuntu"
+}
+
+resource "aws_instance" "dev" {
+  instance_type = "m5.8xlarge"
+}
+`
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	module := loadModule(t, map[string]string{"main.tf": mainTF})
+	require.Equal(t, map[string]string{
+		"coder":  "coder/coder",
+		"docker": "kreuzwerker/docker",
+		"aws":    "hashicorp/aws",
+	}, module.Providers())
+
+	resources := []*proto.Resource{{
+		Name: "workspace",
+		Type: "docker_container",
+		Agents: []*proto.Agent{{
+			Name: "main",
+			Scripts: []*proto.Script{{
+				DisplayName: "Startup",
+				RunOnStart:  true,
+			}},
+		}},
+		Metadata: []*proto.Resource_Metadata{{
+			Key:   "image",
+			Value: "codercom/enterprise-base:ubuntu",
+		}},
+	}, {
+		Name:         "dev",
+		Type:         "aws_instance",
+		InstanceType: "m5.8xlarge",
+	}}
+
+	t.Run("Pass", func(t *testing.T) {
+		t.Parallel()
+
+		diags := templatepolicy.Check([]codersdk.TemplatePolicyRule{{
+			Type:         codersdk.TemplatePolicyRuleRequiredMetadata,
+			MetadataKeys: []string{"image"},
+		}, {
+			Type:      codersdk.TemplatePolicyRuleForbiddenProviders,
+			Providers: []string{"hashicorp/null", "google"},
+		}, {
+			Type:          codersdk.TemplatePolicyRuleAllowedInstanceTypes,
+			InstanceTypes: []string{"m5.*"},
+		}}, module, resources)
+		require.Empty(t, diags)
+	})
+
+	t.Run("ForbiddenProviders", func(t *testing.T) {
+		t.Parallel()
+
+		diags := templatepolicy.Check([]codersdk.TemplatePolicyRule{{
+			Name:      "no-aws",
+			Type:      codersdk.TemplatePolicyRuleForbiddenProviders,
+			Providers: []string{"registry.terraform.io/hashicorp/aws", "kreuzwerker/docker"},
+		}}, module, resources)
+		require.Len(t, diags, 2)
+		require.Equal(t, "Forbidden provider", diags[0].Summary)
+		require.Contains(t, diags[0].Detail, `"no-aws" forbids the provider "aws"`)
+		require.Equal(t, "main.tf", diags[0].Range.Filename)
+		require.Equal(t, `resource "aws_instance" "dev" {`, diags[0].Snippet.Code)
+		require.Contains(t, diags[1].Detail, `"docker" (kreuzwerker/docker)`)
+		require.Equal(t, 6, diags[1].Range.Start.Line)
+	})
+
+	t.Run("RequiredMetadata", func(t *testing.T) {
+		t.Parallel()
+
+		diags := templatepolicy.Check([]codersdk.TemplatePolicyRule{{
+			Type:         codersdk.TemplatePolicyRuleRequiredMetadata,
+			MetadataKeys: []string{"image", "region"},
+		}}, module, resources)
+		require.Len(t, diags, 1)
+		require.Contains(t, diags[0].Detail, `docker_container.workspace is missing "region"`)
+		require.Equal(t, 17, diags[0].Range.Start.Line)
+	})
+
+	t.Run("AllowedInstanceTypes", func(t *testing.T) {
+		t.Parallel()
+
+		diags := templatepolicy.Check([]codersdk.TemplatePolicyRule{{
+			Type:          codersdk.TemplatePolicyRuleAllowedInstanceTypes,
+			InstanceTypes: []string{"t3.*", "m5.large"},
+		}}, module, resources)
+		require.Len(t, diags, 1)
+		require.Contains(t, diags[0].Detail, `instance type "m5.8xlarge" of aws_instance.dev`)
+	})
+
+	t.Run("RequiredShutdownScript", func(t *testing.T) {
+		t.Parallel()
+
+		diags := templatepolicy.Check([]codersdk.TemplatePolicyRule{{
+			Type: codersdk.TemplatePolicyRuleRequiredShutdownScript,
+		}}, module, resources)
+		require.Len(t, diags, 1)
+		require.Contains(t, diags[0].Detail, `agent "main"`)
+		require.Equal(t, `resource "coder_agent" "main" {`, diags[0].Snippet.Code)
+	})
+}
+
+func loadModule(t *testing.T, files map[string]string) *templatepolicy.Module {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600)
+		require.NoError(t, err)
+	}
+	var buf bytes.Buffer
+	err := provisionersdk.Tar(&buf, slogtest.Make(t, nil), dir, provisionersdk.TemplateArchiveLimit)
+	require.NoError(t, err)
+
+	module, err := templatepolicy.LoadModule(buf.Bytes())
+	require.NoError(t, err)
+	return module
+}
@@ -0,0 +1,212 @@
+package coderd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get template release channels
+// @ID get-template-release-channels
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Success 200 {array} codersdk.TemplateReleaseChannel
+// @Router /templates/{template}/channels [get]
+func (api *API) templateReleaseChannels(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx      = r.Context()
+		template = httpmw.TemplateParam(r)
+	)
+
+	channels, err := api.Database.GetTemplateReleaseChannelsByTemplateID(ctx, template.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	versionIDs := make([]uuid.UUID, 0, len(channels))
+	for _, channel := range channels {
+		versionIDs = append(versionIDs, channel.TemplateVersionID)
+	}
+	// nolint:gocritic // Getting template versions by ID is a system function.
+	versions, err := api.Database.GetTemplateVersionsByIDs(dbauthz.AsSystemRestricted(ctx), versionIDs)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	versionNames := make(map[uuid.UUID]string, len(versions))
+	for _, version := range versions {
+		versionNames[version.ID] = version.Name
+	}
+
+	resp := make([]codersdk.TemplateReleaseChannel, 0, len(channels))
+	for _, channel := range channels {
+		resp = append(resp, convertTemplateReleaseChannel(channel, versionNames[channel.TemplateVersionID]))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// @Summary Promote template version to release channel
+// @ID promote-template-version-to-release-channel
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Templates
+// @Param template path string true "Template ID" format(uuid)
+// @Param channel path string true "Release channel name"
+// @Param request body codersdk.PromoteTemplateVersionRequest true "Promote template version request"
+// @Success 200 {object} codersdk.TemplateReleaseChannel
+// @Router /templates/{template}/channels/{channel} [put]
+func (api *API) putTemplateReleaseChannel(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		template          = httpmw.TemplateParam(r)
+		auditor           = *api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.TemplateReleaseChannel](rw, &audit.RequestParams{
+			Audit:          auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: template.OrganizationID,
+		})
+	)
+	defer commitAudit()
+
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may promote template versions.",
+		})
+		return
+	}
+
+	name := chi.URLParam(r, "channel")
+	if err := httpapi.NameValid(name); err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid release channel name.",
+			Validations: []codersdk.ValidationError{{Field: "channel", Detail: err.Error()}},
+		})
+		return
+	}
+
+	var req codersdk.PromoteTemplateVersionRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	version, err := api.Database.GetTemplateVersionByID(ctx, req.TemplateVersionID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Template version not found.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if version.TemplateID.UUID != template.ID {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The provided template version doesn't belong to the specified template.",
+		})
+		return
+	}
+	job, err := api.Database.GetProvisionerJobByID(ctx, version.JobID)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error fetching template version job status.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if job.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only versions that have been built successfully can be promoted.",
+			Detail:  fmt.Sprintf("Attempted to promote a version with a %s build", job.JobStatus),
+		})
+		return
+	}
+	if version.Archived {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "The provided template version is archived.",
+		})
+		return
+	}
+
+	var channel database.TemplateReleaseChannel
+	err = api.Database.InTx(func(tx database.Store) error {
+		existing, err := tx.GetTemplateReleaseChannelByTemplateIDAndName(ctx, database.GetTemplateReleaseChannelByTemplateIDAndNameParams{
+			TemplateID: template.ID,
+			Name:       name,
+		})
+		if httpapi.Is404Error(err) {
+			aReq.Action = database.AuditActionCreate
+			channel, err = tx.InsertTemplateReleaseChannel(ctx, database.InsertTemplateReleaseChannelParams{
+				ID:                uuid.New(),
+				TemplateID:        template.ID,
+				Name:              name,
+				TemplateVersionID: version.ID,
+				CreatedAt:         dbtime.Now(),
+				UpdatedAt:         dbtime.Now(),
+			})
+			if err != nil {
+				return xerrors.Errorf("insert release channel: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return xerrors.Errorf("get release channel: %w", err)
+		}
+
+		aReq.Old = existing
+		channel, err = tx.UpdateTemplateReleaseChannelByID(ctx, database.UpdateTemplateReleaseChannelByIDParams{
+			ID:                existing.ID,
+			TemplateVersionID: version.ID,
+			UpdatedAt:         dbtime.Now(),
+		})
+		if err != nil {
+			return xerrors.Errorf("update release channel: %w", err)
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error promoting template version.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	aReq.New = channel
+
+	api.publishTemplateUpdate(ctx, template.ID)
+
+	httpapi.Write(ctx, rw, http.StatusOK, convertTemplateReleaseChannel(channel, version.Name))
+}
+
+func convertTemplateReleaseChannel(channel database.TemplateReleaseChannel, templateVersionName string) codersdk.TemplateReleaseChannel {
+	return codersdk.TemplateReleaseChannel{
+		ID:                  channel.ID,
+		TemplateID:          channel.TemplateID,
+		Name:                channel.Name,
+		TemplateVersionID:   channel.TemplateVersionID,
+		TemplateVersionName: templateVersionName,
+		CreatedAt:           channel.CreatedAt,
+		UpdatedAt:           channel.UpdatedAt,
+	}
+}
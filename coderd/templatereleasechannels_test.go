@@ -0,0 +1,101 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTemplateReleaseChannels(t *testing.T) {
+	t.Parallel()
+
+	auditor := audit.NewMock()
+	ownerClient := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true, Auditor: auditor})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	client, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	stable := coderdtest.CreateTemplateVersion(t, ownerClient, owner.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, ownerClient, stable.ID)
+	template := coderdtest.CreateTemplate(t, ownerClient, owner.OrganizationID, stable.ID)
+	canary := coderdtest.UpdateTemplateVersion(t, ownerClient, owner.OrganizationID, nil, template.ID)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, ownerClient, canary.ID)
+
+	workspace := coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// Only template admins may promote versions.
+	_, err := client.PromoteTemplateVersion(ctx, template.ID, "canary", codersdk.PromoteTemplateVersionRequest{
+		TemplateVersionID: canary.ID,
+	})
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+	channel, err := ownerClient.PromoteTemplateVersion(ctx, template.ID, "canary", codersdk.PromoteTemplateVersionRequest{
+		TemplateVersionID: canary.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "canary", channel.Name)
+	require.Equal(t, canary.ID, channel.TemplateVersionID)
+	require.Equal(t, canary.Name, channel.TemplateVersionName)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		ResourceType: database.ResourceTypeTemplateReleaseChannel,
+		ResourceID:   channel.ID,
+		Action:       database.AuditActionCreate,
+	}))
+
+	_, err = ownerClient.PromoteTemplateVersion(ctx, template.ID, "stable", codersdk.PromoteTemplateVersionRequest{
+		TemplateVersionID: stable.ID,
+	})
+	require.NoError(t, err)
+
+	channels, err := client.TemplateReleaseChannels(ctx, template.ID)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	require.Equal(t, "canary", channels[0].Name)
+	require.Equal(t, "stable", channels[1].Name)
+
+	// Workspaces follow the active version until they opt in to a channel.
+	workspace, err = client.Workspace(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, stable.ID, workspace.TemplateActiveVersionID)
+	require.False(t, workspace.Outdated)
+
+	err = client.UpdateWorkspaceReleaseChannel(ctx, workspace.ID, codersdk.UpdateWorkspaceReleaseChannelRequest{
+		ReleaseChannel: "unknown",
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+
+	err = client.UpdateWorkspaceReleaseChannel(ctx, workspace.ID, codersdk.UpdateWorkspaceReleaseChannelRequest{
+		ReleaseChannel: "Canary",
+	})
+	require.NoError(t, err)
+
+	workspace, err = client.Workspace(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, "canary", workspace.ReleaseChannel)
+	require.Equal(t, canary.ID, workspace.TemplateActiveVersionID)
+	require.True(t, workspace.Outdated)
+
+	// Promoting a different version moves the channel.
+	channel, err = ownerClient.PromoteTemplateVersion(ctx, template.ID, "canary", codersdk.PromoteTemplateVersionRequest{
+		TemplateVersionID: stable.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, stable.ID, channel.TemplateVersionID)
+	require.True(t, auditor.Contains(t, database.AuditLog{
+		ResourceType: database.ResourceTypeTemplateReleaseChannel,
+		ResourceID:   channel.ID,
+		Action:       database.AuditActionWrite,
+	}))
+}
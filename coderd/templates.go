@@ -12,12 +12,15 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
+
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/schedule"
@@ -638,6 +641,20 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 	if req.TimeTilDormantAutoDeleteMillis < 0 || (req.TimeTilDormantAutoDeleteMillis > 0 && req.TimeTilDormantAutoDeleteMillis < minTTL) {
 		validErrs = append(validErrs, codersdk.ValidationError{Field: "time_til_dormant_autodelete_ms", Detail: "Value must be at least one minute."})
 	}
+	idleTimeout := time.Duration(template.IdleTimeout)
+	if req.IdleTimeoutMillis != nil {
+		if *req.IdleTimeoutMillis < 0 || (*req.IdleTimeoutMillis > 0 && *req.IdleTimeoutMillis < minTTL) {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "idle_timeout_ms", Detail: "Value must be at least one minute."})
+		}
+		idleTimeout = time.Duration(*req.IdleTimeoutMillis) * time.Millisecond
+	}
+	idleCPUThreshold := template.IdleCPUThreshold
+	if req.IdleCPUThreshold != nil {
+		if *req.IdleCPUThreshold < 0 || *req.IdleCPUThreshold > 100 {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "idle_cpu_threshold", Detail: "Value must be a percentage between 0 and 100."})
+		}
+		idleCPUThreshold = *req.IdleCPUThreshold
+	}
 	maxPortShareLevel := template.MaxPortSharingLevel
 	if req.MaxPortShareLevel != nil && *req.MaxPortShareLevel != portSharer.ConvertMaxLevel(template.MaxPortSharingLevel) {
 		err := portSharer.ValidateTemplateMaxLevel(*req.MaxPortShareLevel)
@@ -673,6 +690,8 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			req.FailureTTLMillis == time.Duration(template.FailureTTL).Milliseconds() &&
 			req.TimeTilDormantMillis == time.Duration(template.TimeTilDormant).Milliseconds() &&
 			req.TimeTilDormantAutoDeleteMillis == time.Duration(template.TimeTilDormantAutoDelete).Milliseconds() &&
+			idleTimeout == time.Duration(template.IdleTimeout) &&
+			idleCPUThreshold == template.IdleCPUThreshold &&
 			req.RequireActiveVersion == template.RequireActiveVersion &&
 			(deprecationMessage == template.Deprecated) &&
 			maxPortShareLevel == template.MaxPortSharingLevel {
@@ -697,10 +716,22 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 				if err != nil {
 					return xerrors.Errorf("delete workspace agent port shares by template: %w", err)
 				}
-			case database.AppSharingLevelAuthenticated:
-				err = tx.ReduceWorkspaceAgentShareLevelToAuthenticatedByTemplate(ctx, template.ID)
+			default:
+				// Shares above the new maximum are narrowed to it. Shares
+				// narrowed to the allowlist level have no users or groups,
+				// so only the owner can access them until some are added.
+				wider := codersdk.WorkspaceAgentPortShareLevel(maxPortShareLevel).WiderLevels()
+				reduced := make([]database.AppSharingLevel, 0, len(wider))
+				for _, level := range wider {
+					reduced = append(reduced, database.AppSharingLevel(level))
+				}
+				err = tx.ReduceWorkspaceAgentShareLevelByTemplate(ctx, database.ReduceWorkspaceAgentShareLevelByTemplateParams{
+					ShareLevel:    maxPortShareLevel,
+					ReducedLevels: reduced,
+					TemplateID:    template.ID,
+				})
 				if err != nil {
-					return xerrors.Errorf("reduce workspace agent share level to authenticated by template: %w", err)
+					return xerrors.Errorf("reduce workspace agent share level by template: %w", err)
 				}
 			}
 		}
@@ -754,6 +785,8 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 			failureTTL != time.Duration(template.FailureTTL) ||
 			inactivityTTL != time.Duration(template.TimeTilDormant) ||
 			timeTilDormantAutoDelete != time.Duration(template.TimeTilDormantAutoDelete) ||
+			idleTimeout != time.Duration(template.IdleTimeout) ||
+			idleCPUThreshold != template.IdleCPUThreshold ||
 			req.AllowUserAutostart != template.AllowUserAutostart ||
 			req.AllowUserAutostop != template.AllowUserAutostop {
 			updated, err = (*api.TemplateScheduleStore.Load()).Set(ctx, tx, updated, schedule.TemplateScheduleOptions{
@@ -774,6 +807,8 @@ func (api *API) patchTemplateMeta(rw http.ResponseWriter, r *http.Request) {
 				FailureTTL:                failureTTL,
 				TimeTilDormant:            inactivityTTL,
 				TimeTilDormantAutoDelete:  timeTilDormantAutoDelete,
+				IdleTimeout:               idleTimeout,
+				IdleCPUThreshold:          idleCPUThreshold,
 				UpdateWorkspaceLastUsedAt: updateWorkspaceLastUsedAt,
 				UpdateWorkspaceDormantAt:  req.UpdateWorkspaceDormantAt,
 			})
@@ -844,6 +879,58 @@ func (api *API) templateExamples(rw http.ResponseWriter, r *http.Request) {
 	httpapi.Write(ctx, rw, http.StatusOK, ex)
 }
 
+// notifyTemplateVersionPushed notifies the owners of all workspaces built from
+// the given template that a new version has been promoted to active. Templates
+// can have many workspaces, so notifications are enqueued in the background
+// rather than delaying the response.
+func (api *API) notifyTemplateVersionPushed(template database.Template, version database.TemplateVersion, initiatorID uuid.UUID) {
+	api.WebsocketWaitMutex.Lock()
+	api.WebsocketWaitGroup.Add(1)
+	api.WebsocketWaitMutex.Unlock()
+	go func() {
+		defer api.WebsocketWaitGroup.Done()
+
+		// The request context is canceled once the response is written.
+		ctx := api.ctx
+		// nolint:gocritic // Need access to all workspaces and users, regardless of the initiator's permissions.
+		sysCtx := dbauthz.AsSystemRestricted(ctx)
+
+		initiator, err := api.Database.GetUserByID(sysCtx, initiatorID)
+		if err != nil {
+			api.Logger.Warn(ctx, "failed to fetch initiator for template version pushed notification",
+				slog.F("template_id", template.ID), slog.Error(err))
+			return
+		}
+
+		ownerIDs, err := api.Database.GetWorkspaceOwnerIDsByTemplateID(sysCtx, template.ID)
+		if err != nil {
+			api.Logger.Warn(ctx, "failed to fetch workspace owners for template version pushed notification",
+				slog.F("template_id", template.ID), slog.Error(err))
+			return
+		}
+
+		for _, ownerID := range ownerIDs {
+			// The initiator already knows about the new version.
+			if ownerID == initiator.ID {
+				continue
+			}
+
+			if _, err := api.NotificationsEnqueuer.Enqueue(ctx, ownerID, notifications.TemplateTemplateVersionPushed,
+				map[string]string{
+					"template_name":         template.Name,
+					"template_version_name": version.Name,
+					"initiator":             initiator.Username,
+				}, "api",
+				// Associate this notification with all the related entities.
+				template.ID, template.OrganizationID, ownerID,
+			); err != nil {
+				notifications.LogEnqueueError(ctx, api.Logger, "failed to notify of template version pushed", err,
+					slog.F("template_id", template.ID), slog.F("user_id", ownerID))
+			}
+		}
+	}()
+}
+
 func (api *API) convertTemplates(templates []database.Template) []codersdk.Template {
 	apiTemplates := make([]codersdk.Template, 0, len(templates))
 
@@ -906,6 +993,8 @@ func (api *API) convertTemplate(
 		FailureTTLMillis:               time.Duration(template.FailureTTL).Milliseconds(),
 		TimeTilDormantMillis:           time.Duration(template.TimeTilDormant).Milliseconds(),
 		TimeTilDormantAutoDeleteMillis: time.Duration(template.TimeTilDormantAutoDelete).Milliseconds(),
+		IdleTimeoutMillis:              time.Duration(template.IdleTimeout).Milliseconds(),
+		IdleCPUThreshold:               template.IdleCPUThreshold,
 		AutostopRequirement: codersdk.TemplateAutostopRequirement{
 			DaysOfWeek: codersdk.BitmapToWeekdays(uint8(template.AutostopRequirementDaysOfWeek)),
 			Weeks:      autostopRequirementWeeks,
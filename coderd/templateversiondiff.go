@@ -0,0 +1,510 @@
+package coderd
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/diff"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Compare template versions
+// @ID compare-template-versions
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Templates
+// @Param templateversion path string true "Template version ID" format(uuid)
+// @Param from query string true "Template version ID to compare against" format(uuid)
+// @Success 200 {object} codersdk.TemplateVersionDiff
+// @Router /templateversions/{templateversion}/diff [get]
+func (api *API) templateVersionDiff(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		to  = httpmw.TemplateVersionParam(r)
+	)
+
+	fromID, err := uuid.Parse(r.URL.Query().Get("from"))
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid template version to compare against.",
+			Validations: []codersdk.ValidationError{{Field: "from", Detail: "Must be a template version ID."}},
+		})
+		return
+	}
+	from, err := api.Database.GetTemplateVersionByID(ctx, fromID)
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Template version to compare against not found.",
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	fromData, ok := api.templateVersionDiffData(ctx, rw, from)
+	if !ok {
+		return
+	}
+	toData, ok := api.templateVersionDiffData(ctx, rw, to)
+	if !ok {
+		return
+	}
+
+	resp := codersdk.TemplateVersionDiff{
+		FromVersionID: from.ID,
+		ToVersionID:   to.ID,
+		Resources:     diffTemplateVersionResources(fromData.resources, toData.resources),
+	}
+	resp.Files, err = diffTemplateVersionFiles(fromData.source, toData.source)
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error comparing template version files.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	resp.Parameters, err = diffTemplateVersionParameters(fromData.parameters, toData.parameters)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	resp.Variables, err = diffTemplateVersionVariables(fromData.variables, toData.variables)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+type templateVersionDiffData struct {
+	source     []byte
+	parameters []codersdk.TemplateVersionParameter
+	variables  []codersdk.TemplateVersionVariable
+	resources  []templateVersionDiffResource
+}
+
+type templateVersionDiffResource struct {
+	resource database.WorkspaceResource
+	agents   []database.WorkspaceAgent
+	metadata []database.WorkspaceResourceMetadatum
+}
+
+// templateVersionDiffData fetches everything that is compared for a version.
+// It writes an error response and returns false on failure.
+func (api *API) templateVersionDiffData(ctx context.Context, rw http.ResponseWriter, version database.TemplateVersion) (templateVersionDiffData, bool) {
+	job, err := api.Database.GetProvisionerJobByID(ctx, version.JobID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+	if job.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: fmt.Sprintf("Template version %q has not been imported successfully.", version.Name),
+			Detail:  fmt.Sprintf("The import job is %s.", job.JobStatus),
+		})
+		return templateVersionDiffData{}, false
+	}
+
+	// Reading the source archive requires the same permissions as pulling the
+	// template.
+	file, err := api.Database.GetFileByID(ctx, job.FileID)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may compare template versions.",
+		})
+		return templateVersionDiffData{}, false
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+
+	dbParameters, err := api.Database.GetTemplateVersionParameters(ctx, version.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+	parameters, err := convertTemplateVersionParameters(dbParameters)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+	dbVariables, err := api.Database.GetTemplateVersionVariables(ctx, version.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+
+	// nolint:gocritic // GetWorkspaceResourcesByJobID is a system function.
+	resources, err := api.Database.GetWorkspaceResourcesByJobID(dbauthz.AsSystemRestricted(ctx), job.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+	resourceIDs := make([]uuid.UUID, 0, len(resources))
+	for _, resource := range resources {
+		resourceIDs = append(resourceIDs, resource.ID)
+	}
+	// nolint:gocritic // GetWorkspaceAgentsByResourceIDs is a system function.
+	agents, err := api.Database.GetWorkspaceAgentsByResourceIDs(dbauthz.AsSystemRestricted(ctx), resourceIDs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+	// nolint:gocritic // GetWorkspaceResourceMetadataByResourceIDs is a system function.
+	metadata, err := api.Database.GetWorkspaceResourceMetadataByResourceIDs(dbauthz.AsSystemRestricted(ctx), resourceIDs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return templateVersionDiffData{}, false
+	}
+
+	diffResources := make([]templateVersionDiffResource, 0, len(resources))
+	for _, resource := range resources {
+		diffResource := templateVersionDiffResource{resource: resource}
+		for _, agent := range agents {
+			if agent.ResourceID == resource.ID {
+				diffResource.agents = append(diffResource.agents, agent)
+			}
+		}
+		for _, datum := range metadata {
+			if datum.WorkspaceResourceID == resource.ID {
+				diffResource.metadata = append(diffResource.metadata, datum)
+			}
+		}
+		diffResources = append(diffResources, diffResource)
+	}
+
+	return templateVersionDiffData{
+		source:     file.Data,
+		parameters: parameters,
+		variables:  convertTemplateVersionVariables(dbVariables),
+		resources:  diffResources,
+	}, true
+}
+
+// diffTemplateVersionFiles compares the regular files of two tar archives.
+func diffTemplateVersionFiles(from, to []byte) ([]codersdk.TemplateVersionFileDiff, error) {
+	fromFiles, err := templateVersionSourceFiles(from)
+	if err != nil {
+		return nil, xerrors.Errorf("read source archive: %w", err)
+	}
+	toFiles, err := templateVersionSourceFiles(to)
+	if err != nil {
+		return nil, xerrors.Errorf("read source archive: %w", err)
+	}
+
+	diffs := make([]codersdk.TemplateVersionFileDiff, 0)
+	for _, name := range unionKeys(fromFiles, toFiles) {
+		a, inFrom := fromFiles[name]
+		b, inTo := toFiles[name]
+		fileDiff := codersdk.TemplateVersionFileDiff{Path: name}
+		aName, bName := "a/"+name, "b/"+name
+		switch {
+		case !inFrom:
+			fileDiff.Status = codersdk.TemplateVersionDiffStatusAdded
+			aName = "/dev/null"
+		case !inTo:
+			fileDiff.Status = codersdk.TemplateVersionDiffStatusRemoved
+			bName = "/dev/null"
+		case bytes.Equal(a, b):
+			continue
+		default:
+			fileDiff.Status = codersdk.TemplateVersionDiffStatusModified
+		}
+
+		if isBinaryFile(a) || isBinaryFile(b) {
+			fileDiff.Binary = true
+		} else {
+			var buf bytes.Buffer
+			err = diff.Text(aName, bName, a, b, &buf)
+			if err != nil {
+				return nil, xerrors.Errorf("diff %q: %w", name, err)
+			}
+			fileDiff.Diff = buf.String()
+		}
+		diffs = append(diffs, fileDiff)
+	}
+	return diffs, nil
+}
+
+func templateVersionSourceFiles(data []byte) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	reader := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, xerrors.Errorf("read %q: %w", header.Name, err)
+		}
+		files[path.Clean(strings.TrimPrefix(header.Name, "./"))] = content
+	}
+	return files, nil
+}
+
+func isBinaryFile(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+func diffTemplateVersionParameters(from, to []codersdk.TemplateVersionParameter) ([]codersdk.TemplateVersionParameterDiff, error) {
+	changes, err := diffNamed(from, to, func(p codersdk.TemplateVersionParameter) string { return p.Name })
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([]codersdk.TemplateVersionParameterDiff, 0, len(changes))
+	for _, change := range changes {
+		diffs = append(diffs, codersdk.TemplateVersionParameterDiff{
+			Name:    change.name,
+			Status:  change.status,
+			Changes: change.fields,
+			From:    change.from,
+			To:      change.to,
+		})
+	}
+	return diffs, nil
+}
+
+func diffTemplateVersionVariables(from, to []codersdk.TemplateVersionVariable) ([]codersdk.TemplateVersionVariableDiff, error) {
+	changes, err := diffNamed(from, to, func(v codersdk.TemplateVersionVariable) string { return v.Name })
+	if err != nil {
+		return nil, err
+	}
+	diffs := make([]codersdk.TemplateVersionVariableDiff, 0, len(changes))
+	for _, change := range changes {
+		diffs = append(diffs, codersdk.TemplateVersionVariableDiff{
+			Name:    change.name,
+			Status:  change.status,
+			Changes: change.fields,
+			From:    change.from,
+			To:      change.to,
+		})
+	}
+	return diffs, nil
+}
+
+// diffTemplateVersionResources compares the resources of the start
+// transition, as those are what workspaces run.
+func diffTemplateVersionResources(from, to []templateVersionDiffResource) []codersdk.TemplateVersionResourceDiff {
+	index := func(resources []templateVersionDiffResource) map[string]templateVersionDiffResource {
+		m := make(map[string]templateVersionDiffResource, len(resources))
+		for _, r := range resources {
+			if r.resource.Transition != database.WorkspaceTransitionStart {
+				continue
+			}
+			m[r.resource.Type+"."+r.resource.Name] = r
+		}
+		return m
+	}
+	fromResources, toResources := index(from), index(to)
+
+	diffs := make([]codersdk.TemplateVersionResourceDiff, 0)
+	for _, key := range unionKeys(fromResources, toResources) {
+		a, inFrom := fromResources[key]
+		b, inTo := toResources[key]
+		resource := b.resource
+		if !inTo {
+			resource = a.resource
+		}
+		resourceDiff := codersdk.TemplateVersionResourceDiff{
+			Type: resource.Type,
+			Name: resource.Name,
+		}
+		switch {
+		case !inFrom:
+			resourceDiff.Status = codersdk.TemplateVersionDiffStatusAdded
+		case !inTo:
+			resourceDiff.Status = codersdk.TemplateVersionDiffStatusRemoved
+		default:
+			resourceDiff.Changes = resourceChanges(a, b)
+			if len(resourceDiff.Changes) == 0 {
+				continue
+			}
+			resourceDiff.Status = codersdk.TemplateVersionDiffStatusModified
+		}
+		diffs = append(diffs, resourceDiff)
+	}
+	return diffs
+}
+
+func resourceChanges(a, b templateVersionDiffResource) []string {
+	var changes []string
+	if a.resource.Hide != b.resource.Hide {
+		changes = append(changes, "hide")
+	}
+	if a.resource.Icon != b.resource.Icon {
+		changes = append(changes, "icon")
+	}
+	if a.resource.DailyCost != b.resource.DailyCost {
+		changes = append(changes, "daily_cost")
+	}
+
+	agentsByName := func(agents []database.WorkspaceAgent) map[string]database.WorkspaceAgent {
+		m := make(map[string]database.WorkspaceAgent, len(agents))
+		for _, agent := range agents {
+			m[agent.Name] = agent
+		}
+		return m
+	}
+	fromAgents, toAgents := agentsByName(a.agents), agentsByName(b.agents)
+	for _, name := range unionKeys(fromAgents, toAgents) {
+		fromAgent, inFrom := fromAgents[name]
+		toAgent, inTo := toAgents[name]
+		switch {
+		case !inFrom:
+			changes = append(changes, fmt.Sprintf("agent %q added", name))
+		case !inTo:
+			changes = append(changes, fmt.Sprintf("agent %q removed", name))
+		case fromAgent.OperatingSystem != toAgent.OperatingSystem,
+			fromAgent.Architecture != toAgent.Architecture,
+			fromAgent.Directory != toAgent.Directory,
+			!slices.Equal(fromAgent.DisplayApps, toAgent.DisplayApps):
+			changes = append(changes, fmt.Sprintf("agent %q modified", name))
+		}
+	}
+
+	metadataByKey := func(metadata []database.WorkspaceResourceMetadatum) map[string]database.WorkspaceResourceMetadatum {
+		m := make(map[string]database.WorkspaceResourceMetadatum, len(metadata))
+		for _, datum := range metadata {
+			m[datum.Key] = datum
+		}
+		return m
+	}
+	fromMetadata, toMetadata := metadataByKey(a.metadata), metadataByKey(b.metadata)
+	for _, key := range unionKeys(fromMetadata, toMetadata) {
+		fromDatum, inFrom := fromMetadata[key]
+		toDatum, inTo := toMetadata[key]
+		switch {
+		case !inFrom:
+			changes = append(changes, fmt.Sprintf("metadata %q added", key))
+		case !inTo:
+			changes = append(changes, fmt.Sprintf("metadata %q removed", key))
+		case fromDatum.Value != toDatum.Value, fromDatum.Sensitive != toDatum.Sensitive:
+			changes = append(changes, fmt.Sprintf("metadata %q modified", key))
+		}
+	}
+	return changes
+}
+
+type namedChange[T any] struct {
+	name   string
+	status codersdk.TemplateVersionDiffStatus
+	fields []string
+	from   *T
+	to     *T
+}
+
+// diffNamed matches the entries of two lists by name, and reports the JSON
+// fields that differ between entries with the same name.
+func diffNamed[T any](from, to []T, nameOf func(T) string) ([]namedChange[T], error) {
+	fromByName := make(map[string]T, len(from))
+	for _, v := range from {
+		fromByName[nameOf(v)] = v
+	}
+	toByName := make(map[string]T, len(to))
+	for _, v := range to {
+		toByName[nameOf(v)] = v
+	}
+
+	changes := make([]namedChange[T], 0)
+	for _, name := range unionKeys(fromByName, toByName) {
+		a, inFrom := fromByName[name]
+		b, inTo := toByName[name]
+		change := namedChange[T]{name: name}
+		switch {
+		case !inFrom:
+			change.status = codersdk.TemplateVersionDiffStatusAdded
+			change.to = &b
+		case !inTo:
+			change.status = codersdk.TemplateVersionDiffStatusRemoved
+			change.from = &a
+		default:
+			fields, err := changedJSONFields(a, b)
+			if err != nil {
+				return nil, xerrors.Errorf("compare %q: %w", name, err)
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			change.status = codersdk.TemplateVersionDiffStatusModified
+			change.fields = fields
+			change.from = &a
+			change.to = &b
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// changedJSONFields returns the names of the top-level JSON fields that differ
+// between a and b.
+func changedJSONFields(a, b any) ([]string, error) {
+	toFields := func(v any) (map[string]json.RawMessage, error) {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]json.RawMessage
+		return fields, json.Unmarshal(data, &fields)
+	}
+	aFields, err := toFields(a)
+	if err != nil {
+		return nil, err
+	}
+	bFields, err := toFields(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, field := range unionKeys(aFields, bFields) {
+		if !bytes.Equal(aFields[field], bFields[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed, nil
+}
+
+// unionKeys returns the sorted keys that are present in either map.
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
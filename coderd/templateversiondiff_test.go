@@ -0,0 +1,108 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/provisioner/echo"
+	"github.com/coder/coder/v2/provisionersdk/proto"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestTemplateVersionDiff(t *testing.T) {
+	t.Parallel()
+
+	responses := func(region string, parameters []*proto.RichParameter, resources []*proto.Resource) *echo.Responses {
+		return &echo.Responses{
+			Parse: echo.ParseComplete,
+			ProvisionPlan: []*proto.Response{{
+				Type: &proto.Response_Plan{
+					Plan: &proto.PlanComplete{
+						Parameters: append([]*proto.RichParameter{{
+							Name:         "region",
+							Type:         "string",
+							DefaultValue: region,
+						}}, parameters...),
+					},
+				},
+			}},
+			ProvisionApply: []*proto.Response{{
+				Type: &proto.Response_Apply{
+					Apply: &proto.ApplyComplete{
+						Resources: resources,
+					},
+				},
+			}},
+		}
+	}
+
+	client := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	user := coderdtest.CreateFirstUser(t, client)
+	member, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+	from := coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, responses("us", nil, []*proto.Resource{{
+		Name: "dev",
+		Type: "example",
+	}}))
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, from.ID)
+	template := coderdtest.CreateTemplate(t, client, user.OrganizationID, from.ID)
+	to := coderdtest.UpdateTemplateVersion(t, client, user.OrganizationID, responses("eu", []*proto.RichParameter{{
+		Name: "size",
+		Type: "number",
+	}}, []*proto.Resource{{
+		Name: "dev",
+		Type: "example",
+	}, {
+		Name: "cache",
+		Type: "example",
+	}}), template.ID)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, client, to.ID)
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		diff, err := client.TemplateVersionDiff(ctx, from.ID, to.ID)
+		require.NoError(t, err)
+		require.Equal(t, from.ID, diff.FromVersionID)
+		require.Equal(t, to.ID, diff.ToVersionID)
+		require.NotEmpty(t, diff.Files)
+
+		require.Len(t, diff.Parameters, 2)
+		require.Equal(t, "region", diff.Parameters[0].Name)
+		require.Equal(t, codersdk.TemplateVersionDiffStatusModified, diff.Parameters[0].Status)
+		require.Contains(t, diff.Parameters[0].Changes, "default_value")
+		require.Equal(t, "us", diff.Parameters[0].From.DefaultValue)
+		require.Equal(t, "eu", diff.Parameters[0].To.DefaultValue)
+		require.Equal(t, "size", diff.Parameters[1].Name)
+		require.Equal(t, codersdk.TemplateVersionDiffStatusAdded, diff.Parameters[1].Status)
+		require.Nil(t, diff.Parameters[1].From)
+
+		require.Len(t, diff.Resources, 1)
+		require.Equal(t, "cache", diff.Resources[0].Name)
+		require.Equal(t, codersdk.TemplateVersionDiffStatusAdded, diff.Resources[0].Status)
+	})
+
+	t.Run("Same", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		diff, err := client.TemplateVersionDiff(ctx, to.ID, to.ID)
+		require.NoError(t, err)
+		require.True(t, diff.Empty())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		t.Parallel()
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := member.TemplateVersionDiff(ctx, from.ID, to.ID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+	})
+}
@@ -1203,6 +1203,7 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	var (
 		ctx               = r.Context()
 		template          = httpmw.TemplateParam(r)
+		apiKey            = httpmw.APIKey(r)
 		auditor           = *api.Auditor.Load()
 		aReq, commitAudit = audit.InitRequest[database.Template](rw, &audit.RequestParams{
 			Audit:          auditor,
@@ -1284,6 +1285,9 @@ func (api *API) patchActiveTemplateVersion(rw http.ResponseWriter, r *http.Reque
 	aReq.New = newTemplate
 
 	api.publishTemplateUpdate(ctx, template.ID)
+	if template.ActiveVersionID != req.ID {
+		api.notifyTemplateVersionPushed(template, version, apiKey.UserID)
+	}
 
 	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
 		Message: "Updated the active template version!",
@@ -17,6 +17,7 @@ import (
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/externalauth"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
@@ -673,6 +674,58 @@ func TestPatchActiveTemplateVersion(t *testing.T) {
 		require.Len(t, auditor.AuditLogs(), 6)
 		assert.Equal(t, database.AuditActionWrite, auditor.AuditLogs()[5].Action)
 	})
+
+	t.Run("NotifiesWorkspaceOwners", func(t *testing.T) {
+		t.Parallel()
+
+		// Given: a template with workspaces owned by its author and by another member
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+		client := coderdtest.New(t, &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+			NotificationsEnqueuer:    notifyEnq,
+		})
+		owner := coderdtest.CreateFirstUser(t, client)
+		memberClient, member := coderdtest.CreateAnotherUser(t, client, owner.OrganizationID)
+		version := coderdtest.CreateTemplateVersion(t, client, owner.OrganizationID, nil)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template := coderdtest.CreateTemplate(t, client, owner.OrganizationID, version.ID)
+
+		ownerWorkspace := coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+		coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, ownerWorkspace.LatestBuild.ID)
+		for i := 0; i < 2; i++ {
+			memberWorkspace := coderdtest.CreateWorkspace(t, memberClient, owner.OrganizationID, template.ID)
+			coderdtest.AwaitWorkspaceBuildJobCompleted(t, memberClient, memberWorkspace.LatestBuild.ID)
+		}
+
+		version = coderdtest.UpdateTemplateVersion(t, client, owner.OrganizationID, nil, template.ID)
+		_ = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		// When: the new version is promoted to active
+		err := client.UpdateActiveTemplateVersion(ctx, template.ID, codersdk.UpdateActiveTemplateVersion{
+			ID: version.ID,
+		})
+		require.NoError(t, err)
+
+		// Then: only the other workspace owner is notified, once
+		var sent []*testutil.Notification
+		require.Eventually(t, func() bool {
+			sent = nil
+			for _, n := range notifyEnq.Notifications() {
+				if n.TemplateID == notifications.TemplateTemplateVersionPushed {
+					sent = append(sent, n)
+				}
+			}
+			return len(sent) > 0
+		}, testutil.WaitShort, testutil.IntervalFast)
+		require.Len(t, sent, 1)
+		require.Equal(t, member.ID, sent[0].UserID)
+		require.Contains(t, sent[0].Targets, template.ID)
+		require.Equal(t, template.Name, sent[0].Labels["template_name"])
+		require.Equal(t, version.Name, sent[0].Labels["template_version_name"])
+		require.Equal(t, coderdtest.FirstUserParams.Username, sent[0].Labels["initiator"])
+	})
 }
 
 func TestTemplateVersionDryRun(t *testing.T) {
@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"golang.org/x/xerrors"
 
+	"cdr.dev/slog"
+
 	"github.com/coder/coder/v2/coderd/audit"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/db2sdk"
@@ -20,6 +22,7 @@ import (
 	"github.com/coder/coder/v2/coderd/gitsshkey"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/searchquery"
@@ -826,6 +829,10 @@ func (api *API) putUserStatus(status database.UserStatus) func(rw http.ResponseW
 		}
 		aReq.New = suspendedUser
 
+		if status == database.UserStatusSuspended && user.Status != database.UserStatusSuspended {
+			api.notifyUserSuspended(ctx, suspendedUser, apiKey.UserID)
+		}
+
 		organizations, err := userOrganizationIDs(ctx, api, user)
 		if err != nil {
 			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
@@ -839,6 +846,24 @@ func (api *API) putUserStatus(status database.UserStatus) func(rw http.ResponseW
 	}
 }
 
+func (api *API) notifyUserSuspended(ctx context.Context, user database.User, initiatorID uuid.UUID) {
+	initiator, err := api.Database.GetUserByID(ctx, initiatorID)
+	if err != nil {
+		api.Logger.Warn(ctx, "failed to fetch initiator for user suspended notification",
+			slog.F("user_id", user.ID), slog.Error(err))
+		return
+	}
+
+	if _, err := api.NotificationsEnqueuer.Enqueue(ctx, user.ID, notifications.TemplateUserAccountSuspended,
+		map[string]string{
+			"initiator": initiator.Username,
+		}, "api", user.ID,
+	); err != nil {
+		notifications.LogEnqueueError(ctx, api.Logger, "failed to notify of user suspension", err,
+			slog.F("user_id", user.ID))
+	}
+}
+
 // @Summary Update user appearance settings
 // @ID update-user-appearance-settings
 // @Security CoderSessionToken
@@ -1317,6 +1342,8 @@ func convertAPIKey(k database.APIKey) codersdk.APIKey {
 		UpdatedAt:       k.UpdatedAt,
 		LoginType:       codersdk.LoginType(k.LoginType),
 		Scope:           codersdk.APIKeyScope(k.Scope),
+		Scopes:          k.Scopes,
+		AllowList:       k.AllowList,
 		LifetimeSeconds: k.LifetimeSeconds,
 		TokenName:       k.TokenName,
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/coder/coder/v2/coderd/database/dbfake"
 	"github.com/coder/coder/v2/coderd/database/dbgen"
 	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/util/slice"
 	"github.com/coder/coder/v2/codersdk"
@@ -1001,6 +1002,29 @@ func TestPutUserSuspend(t *testing.T) {
 
 		require.ErrorContains(t, err, "suspend yourself", "cannot suspend yourself")
 	})
+
+	t.Run("NotifiesSuspendedUser", func(t *testing.T) {
+		t.Parallel()
+		notifyEnq := &testutil.FakeNotificationsEnqueuer{}
+		client := coderdtest.New(t, &coderdtest.Options{NotificationsEnqueuer: notifyEnq})
+		me := coderdtest.CreateFirstUser(t, client)
+		_, user := coderdtest.CreateAnotherUser(t, client, me.OrganizationID)
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+
+		_, err := client.UpdateUserStatus(ctx, user.Username, codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+
+		require.Len(t, notifyEnq.Sent, 1)
+		require.Equal(t, user.ID, notifyEnq.Sent[0].UserID)
+		require.Equal(t, notifications.TemplateUserAccountSuspended, notifyEnq.Sent[0].TemplateID)
+		require.Equal(t, coderdtest.FirstUserParams.Username, notifyEnq.Sent[0].Labels["initiator"])
+
+		// Suspending an already suspended user does not notify again.
+		_, err = client.UpdateUserStatus(ctx, user.Username, codersdk.UserStatusSuspended)
+		require.NoError(t, err)
+		require.Len(t, notifyEnq.Sent, 1)
+	})
 }
 
 func TestActivateDormantUser(t *testing.T) {
@@ -1,12 +1,17 @@
 package coderd
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
 	"slices"
 
+	"github.com/google/uuid"
+
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/codersdk"
@@ -63,6 +68,20 @@ func (api *API) postWorkspaceAgentPortShare(rw http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if req.ShareLevel != codersdk.WorkspaceAgentPortShareLevelAllowlist && (len(req.AllowedUserIDs) > 0 || len(req.AllowedGroupIDs) > 0) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Allowed users and groups can only be set with the allowlist sharing level.",
+		})
+		return
+	}
+	if validErrs := api.validatePortShareAllowlist(ctx, workspace, req); len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid port share allowlist.",
+			Validations: validErrs,
+		})
+		return
+	}
+
 	template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
 	if err != nil {
 		httpapi.InternalServerError(rw, err)
@@ -98,11 +117,13 @@ func (api *API) postWorkspaceAgentPortShare(rw http.ResponseWriter, r *http.Requ
 	}
 
 	psl, err := api.Database.UpsertWorkspaceAgentPortShare(ctx, database.UpsertWorkspaceAgentPortShareParams{
-		WorkspaceID: workspace.ID,
-		AgentName:   req.AgentName,
-		Port:        req.Port,
-		ShareLevel:  database.AppSharingLevel(req.ShareLevel),
-		Protocol:    database.PortShareProtocol(req.Protocol),
+		WorkspaceID:     workspace.ID,
+		AgentName:       req.AgentName,
+		Port:            req.Port,
+		ShareLevel:      database.AppSharingLevel(req.ShareLevel),
+		Protocol:        database.PortShareProtocol(req.Protocol),
+		AllowedUserIDs:  uniqueUUIDs(req.AllowedUserIDs),
+		AllowedGroupIDs: uniqueUUIDs(req.AllowedGroupIDs),
 	})
 	if err != nil {
 		httpapi.InternalServerError(rw, err)
@@ -182,6 +203,43 @@ func (api *API) deleteWorkspaceAgentPortShare(rw http.ResponseWriter, r *http.Re
 	rw.WriteHeader(http.StatusOK)
 }
 
+// validatePortShareAllowlist checks that the users and groups a port is shared
+// with exist, and that the groups belong to the workspace's organization.
+func (api *API) validatePortShareAllowlist(ctx context.Context, workspace database.Workspace, req codersdk.UpsertWorkspaceAgentPortShareRequest) []codersdk.ValidationError {
+	// The workspace owner may not be able to read every user and group they
+	// share with.
+	// nolint:gocritic
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	var validErrs []codersdk.ValidationError
+	for _, id := range req.AllowedUserIDs {
+		user, err := api.Database.GetUserByID(ctx, id)
+		if err != nil || user.Deleted {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "allowed_user_ids", Detail: fmt.Sprintf("Failed to find user with ID %q.", id)})
+		}
+	}
+	for _, id := range req.AllowedGroupIDs {
+		group, err := api.Database.GetGroupByID(ctx, id)
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "allowed_group_ids", Detail: fmt.Sprintf("Failed to find group with ID %q.", id)})
+			continue
+		}
+		if group.OrganizationID != workspace.OrganizationID {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: "allowed_group_ids", Detail: fmt.Sprintf("Group %q is not in the organization of the workspace.", group.Name)})
+		}
+	}
+	return validErrs
+}
+
+func uniqueUUIDs(ids []uuid.UUID) []uuid.UUID {
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
 func convertPortShares(shares []database.WorkspaceAgentPortShare) []codersdk.WorkspaceAgentPortShare {
 	converted := []codersdk.WorkspaceAgentPortShare{}
 	for _, share := range shares {
@@ -195,10 +253,12 @@ func convertPortShares(shares []database.WorkspaceAgentPortShare) []codersdk.Wor
 
 func convertPortShare(share database.WorkspaceAgentPortShare) codersdk.WorkspaceAgentPortShare {
 	return codersdk.WorkspaceAgentPortShare{
-		WorkspaceID: share.WorkspaceID,
-		AgentName:   share.AgentName,
-		Port:        share.Port,
-		ShareLevel:  codersdk.WorkspaceAgentPortShareLevel(share.ShareLevel),
-		Protocol:    codersdk.WorkspaceAgentPortShareProtocol(share.Protocol),
+		WorkspaceID:     share.WorkspaceID,
+		AgentName:       share.AgentName,
+		Port:            share.Port,
+		ShareLevel:      codersdk.WorkspaceAgentPortShareLevel(share.ShareLevel),
+		Protocol:        codersdk.WorkspaceAgentPortShareProtocol(share.Protocol),
+		AllowedUserIDs:  share.AllowedUserIDs,
+		AllowedGroupIDs: share.AllowedGroupIDs,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
@@ -76,6 +77,26 @@ func TestPostWorkspaceAgentPortShare(t *testing.T) {
 	})
 	require.Error(t, err)
 
+	// allowed users are only valid with the allowlist level
+	_, err = client.UpsertWorkspaceAgentPortShare(ctx, r.Workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+		AgentName:      agents[0].Name,
+		Port:           8080,
+		ShareLevel:     codersdk.WorkspaceAgentPortShareLevelPublic,
+		Protocol:       codersdk.WorkspaceAgentPortShareProtocolHTTP,
+		AllowedUserIDs: []uuid.UUID{owner.UserID},
+	})
+	require.Error(t, err)
+
+	// unknown users can't be allowed
+	_, err = client.UpsertWorkspaceAgentPortShare(ctx, r.Workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+		AgentName:      agents[0].Name,
+		Port:           8080,
+		ShareLevel:     codersdk.WorkspaceAgentPortShareLevelAllowlist,
+		Protocol:       codersdk.WorkspaceAgentPortShareProtocolHTTP,
+		AllowedUserIDs: []uuid.UUID{uuid.New()},
+	})
+	require.Error(t, err)
+
 	// OK, ignoring template max port share level because we are AGPL
 	ps, err := client.UpsertWorkspaceAgentPortShare(ctx, r.Workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
 		AgentName:  agents[0].Name,
@@ -129,6 +150,21 @@ func TestPostWorkspaceAgentPortShare(t *testing.T) {
 	require.Len(t, list.Shares, 2)
 	require.EqualValues(t, 8080, list.Shares[0].Port)
 	require.EqualValues(t, 8081, list.Shares[1].Port)
+
+	// gRPC and TCP protocols
+	for _, protocol := range []codersdk.WorkspaceAgentPortShareProtocol{
+		codersdk.WorkspaceAgentPortShareProtocolH2C,
+		codersdk.WorkspaceAgentPortShareProtocolTCP,
+	} {
+		ps, err = client.UpsertWorkspaceAgentPortShare(ctx, r.Workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+			AgentName:  agents[0].Name,
+			Port:       5432,
+			ShareLevel: codersdk.WorkspaceAgentPortShareLevelAuthenticated,
+			Protocol:   protocol,
+		})
+		require.NoError(t, err)
+		require.EqualValues(t, protocol, ps.Protocol)
+	}
 }
 
 func TestGetWorkspaceAgentPortShares(t *testing.T) {
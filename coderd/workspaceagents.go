@@ -1447,25 +1447,7 @@ func (api *API) workspaceAgentsExternalAuthListen(ctx context.Context, rw http.R
 // which uses `Username` and `Password`.
 func createExternalAuthResponse(typ, token string, extra pqtype.NullRawMessage) (agentsdk.ExternalAuthResponse, error) {
 	var resp agentsdk.ExternalAuthResponse
-	switch typ {
-	case string(codersdk.EnhancedExternalAuthProviderGitLab):
-		// https://stackoverflow.com/questions/25409700/using-gitlab-token-to-clone-without-authentication
-		resp = agentsdk.ExternalAuthResponse{
-			Username: "oauth2",
-			Password: token,
-		}
-	case string(codersdk.EnhancedExternalAuthProviderBitBucketCloud), string(codersdk.EnhancedExternalAuthProviderBitBucketServer):
-		// The string "bitbucket" was a legacy parameter that needs to still be supported.
-		// https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/#Cloning-a-repository-with-an-access-token
-		resp = agentsdk.ExternalAuthResponse{
-			Username: "x-token-auth",
-			Password: token,
-		}
-	default:
-		resp = agentsdk.ExternalAuthResponse{
-			Username: token,
-		}
-	}
+	resp.Username, resp.Password = externalauth.GitCredentials(typ, token)
 	resp.AccessToken = token
 	resp.Type = typ
 
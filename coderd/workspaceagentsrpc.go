@@ -146,6 +146,7 @@ func (api *API) workspaceAgentRPC(rw http.ResponseWriter, r *http.Request) {
 		DerpMapUpdateFrequency:    api.Options.DERPMapUpdateFrequency,
 		ExternalAuthConfigs:       api.ExternalAuthConfigs,
 		Experiments:               api.Experiments,
+		AutostopWarning:           api.DeploymentValues.Notifications.AutostopWarning.Value(),
 
 		// Optional:
 		WorkspaceID:          build.WorkspaceID, // saves the extra lookup later
@@ -310,6 +310,30 @@ func (p *DBTokenProvider) authorizeRequest(ctx context.Context, roles *rbac.Subj
 		// We essentially already did this above with the regular RBAC check.
 		// Owners can always access their own apps according to RBAC rules, so
 		// they have already been returned from this function.
+	case database.AppSharingLevelAllowlist:
+		// Grant the listed users and groups access through the workspace's
+		// ACL, so that group membership and scopes are checked by RBAC.
+		userACL := make(map[string][]policy.Action, len(dbReq.AllowedUserIDs))
+		for _, id := range dbReq.AllowedUserIDs {
+			userACL[id.String()] = []policy.Action{rbacAction}
+		}
+		groupACL := make(map[string][]policy.Action, len(dbReq.AllowedGroupIDs))
+		for _, id := range dbReq.AllowedGroupIDs {
+			groupACL[id.String()] = []policy.Action{rbacAction}
+		}
+		err := p.Authorizer.Authorize(ctx, *roles, rbacAction, rbacResource.WithACLUserList(userACL).WithGroupACL(groupACL))
+		if err == nil {
+			return true, []string{}, nil
+		}
+	case database.AppSharingLevelOrganization:
+		// The "Everyone" group of an organization has the same ID as the
+		// organization, and every member of the organization is in it.
+		err := p.Authorizer.Authorize(ctx, *roles, rbacAction, rbacResource.WithGroupACL(map[string][]policy.Action{
+			dbReq.Workspace.OrganizationID.String(): {rbacAction},
+		}))
+		if err == nil {
+			return true, []string{}, nil
+		}
 	case database.AppSharingLevelAuthenticated:
 		// Check with the owned resource to ensure the API key has permissions
 		// to connect to the actor's own workspace. This enforces scopes.
@@ -546,7 +546,13 @@ func (s *Server) proxyWorkspaceApp(rw http.ResponseWriter, r *http.Request, appT
 	r.URL.Path = path
 	appURL.RawQuery = ""
 	_, protocol, isPort := app.PortInfo()
-	if isPort {
+	switch {
+	case appURL.Scheme == string(codersdk.WorkspaceAgentPortShareProtocolTCP):
+		s.proxyWorkspaceAppTCP(rw, r, appToken, appURL)
+		return
+	case appURL.Scheme == string(codersdk.WorkspaceAgentPortShareProtocolH2C):
+		// The agent provider dials h2c URLs with HTTP/2 over cleartext.
+	case isPort:
 		appURL.Scheme = protocol
 	}
 
@@ -603,6 +609,71 @@ func (s *Server) proxyWorkspaceApp(rw http.ResponseWriter, r *http.Request, appT
 	proxy.ServeHTTP(rw, r)
 }
 
+// proxyWorkspaceAppTCP tunnels a WebSocket to a port shared with the "tcp"
+// protocol. Clients connect with codersdk.Client.DialWorkspaceAgentPortShareTCP.
+func (s *Server) proxyWorkspaceAppTCP(rw http.ResponseWriter, r *http.Request, appToken SignedToken, appURL *url.URL) {
+	ctx := r.Context()
+
+	if !httpapi.IsWebsocketUpgrade(r) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "This port is shared over TCP.",
+			Detail:  "Connect to it with \"coder shared-port\" instead of a browser.",
+		})
+		return
+	}
+
+	s.websocketWaitMutex.Lock()
+	s.websocketWaitGroup.Add(1)
+	s.websocketWaitMutex.Unlock()
+	defer s.websocketWaitGroup.Done()
+
+	log := s.Logger.With(slog.F("agent_id", appToken.AgentID), slog.F("port", appURL.Port()))
+
+	conn, err := websocket.Accept(rw, r, &websocket.AcceptOptions{
+		CompressionMode: websocket.CompressionDisabled,
+	})
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Failed to accept websocket.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	ctx, wsNetConn := WebsocketNetConn(ctx, conn, websocket.MessageBinary)
+	defer wsNetConn.Close() // Also closes conn.
+
+	go httpapi.Heartbeat(ctx, conn)
+
+	agentConn, release, err := s.AgentProvider.AgentConn(ctx, appToken.AgentID)
+	if err != nil {
+		log.Debug(ctx, "dial workspace agent", slog.Error(err))
+		_ = conn.Close(websocket.StatusInternalError, httpapi.WebsocketCloseSprintf("dial workspace agent: %s", err))
+		return
+	}
+	defer release()
+
+	portConn, err := agentConn.DialContext(ctx, "tcp", net.JoinHostPort("127.0.0.1", appURL.Port()))
+	if err != nil {
+		log.Debug(ctx, "dial shared port", slog.Error(err))
+		_ = conn.Close(websocket.StatusInternalError, httpapi.WebsocketCloseSprintf("dial port: %s", err))
+		return
+	}
+	defer portConn.Close()
+
+	// end span so we don't get long lived trace data
+	tracing.EndHTTPSpan(r, http.StatusOK, trace.SpanFromContext(ctx))
+
+	report := newStatsReportFromSignedToken(appToken)
+	s.collectStats(report)
+	defer func() {
+		report.SessionEndedAt = dbtime.Now()
+		s.collectStats(report)
+	}()
+
+	agentssh.Bicopy(ctx, wsNetConn, portConn)
+}
+
 // workspaceAgentPTY spawns a PTY and pipes it over a WebSocket.
 // This is used for the web terminal.
 //
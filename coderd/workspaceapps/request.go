@@ -202,6 +202,10 @@ type databaseRequest struct {
 	// AppSharingLevel is the sharing level of the app. This is forced to be set
 	// to AppSharingLevelOwner if the access method is terminal.
 	AppSharingLevel database.AppSharingLevel
+	// AllowedUserIDs and AllowedGroupIDs are the users and groups that can
+	// access the app when AppSharingLevel is AppSharingLevelAllowlist.
+	AllowedUserIDs  []uuid.UUID
+	AllowedGroupIDs []uuid.UUID
 }
 
 // getDatabase does queries to get the owner user, workspace and agent
@@ -290,6 +294,8 @@ func (r Request) getDatabase(ctx context.Context, db database.Store) (*databaseR
 		agentNameOrID   = r.AgentNameOrID
 		appURL          string
 		appSharingLevel database.AppSharingLevel
+		allowedUserIDs  []uuid.UUID
+		allowedGroupIDs []uuid.UUID
 		// First check if it's a port-based URL with an optional "s" suffix for HTTPS.
 		potentialPortStr      = strings.TrimSuffix(r.AppSlugOrPort, "s")
 		portUint, portUintErr = strconv.ParseUint(potentialPortStr, 10, 16)
@@ -351,6 +357,14 @@ func (r Request) getDatabase(ctx context.Context, db database.Store) (*databaseR
 			// No port share found, so we keep default to owner.
 		} else {
 			appSharingLevel = ps.ShareLevel
+			allowedUserIDs = ps.AllowedUserIDs
+			allowedGroupIDs = ps.AllowedGroupIDs
+			// HTTP and HTTPS are chosen by the "s" suffix of the port, but
+			// the other protocols can only be selected by sharing the port.
+			switch ps.Protocol {
+			case database.PortShareProtocolH2c, database.PortShareProtocolTcp:
+				appURL = fmt.Sprintf("%s://127.0.0.1:%d", ps.Protocol, portUint)
+			}
 		}
 	} else {
 		for _, app := range apps {
@@ -412,6 +426,8 @@ func (r Request) getDatabase(ctx context.Context, db database.Store) (*databaseR
 		Agent:           agent,
 		AppURL:          appURLParsed,
 		AppSharingLevel: appSharingLevel,
+		AllowedUserIDs:  allowedUserIDs,
+		AllowedGroupIDs: allowedGroupIDs,
 	}, nil
 }
 
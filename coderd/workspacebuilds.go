@@ -372,6 +372,13 @@ func (api *API) postWorkspaceBuilds(rw http.ResponseWriter, r *http.Request) {
 		builder = builder.State(createBuild.ProvisionerState)
 	}
 
+	api.buildWorkspace(rw, r, workspace, builder)
+}
+
+// buildWorkspace inserts the build described by builder and writes it to the
+// response.
+func (api *API) buildWorkspace(rw http.ResponseWriter, r *http.Request, workspace database.Workspace, builder wsbuilder.Builder) {
+	ctx := r.Context()
 	workspaceBuild, provisionerJob, err := builder.Build(
 		ctx,
 		api.Database,
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -18,6 +19,7 @@ import (
 	"cdr.dev/slog"
 	"github.com/coder/coder/v2/agent/proto"
 	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/autobuild"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/db2sdk"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
@@ -26,6 +28,7 @@ import (
 	"github.com/coder/coder/v2/coderd/dormancy"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/notifications"
 	"github.com/coder/coder/v2/coderd/rbac"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
@@ -35,6 +38,7 @@ import (
 	"github.com/coder/coder/v2/coderd/wsbuilder"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/codersdk/agentsdk"
+	"github.com/coder/coder/v2/site"
 )
 
 var (
@@ -112,6 +116,7 @@ func (api *API) workspace(rw http.ResponseWriter, r *http.Request) {
 		workspace,
 		data.builds[0],
 		data.templates[0],
+		data.activeVersionID(workspace, data.templates[0]),
 		owner.Username,
 		owner.AvatarURL,
 		api.Options.AllowWorkspaceRenames,
@@ -319,6 +324,7 @@ func (api *API) workspaceByOwnerAndName(rw http.ResponseWriter, r *http.Request)
 		workspace,
 		data.builds[0],
 		data.templates[0],
+		data.activeVersionID(workspace, data.templates[0]),
 		owner.Username,
 		owner.AvatarURL,
 		api.Options.AllowWorkspaceRenames,
@@ -633,6 +639,7 @@ func (api *API) postWorkspacesByOrganization(rw http.ResponseWriter, r *http.Req
 		workspace,
 		apiBuild,
 		template,
+		template.ActiveVersionID,
 		member.Username,
 		member.AvatarURL,
 		api.Options.AllowWorkspaceRenames,
@@ -974,7 +981,7 @@ func (api *API) putWorkspaceDormant(rw http.ResponseWriter, r *http.Request) {
 				},
 			)
 			if err != nil {
-				api.Logger.Warn(ctx, "failed to notify of workspace marked as dormant", slog.Error(err))
+				notifications.LogEnqueueError(ctx, api.Logger, "failed to notify of workspace marked as dormant", err)
 			}
 		}
 	}
@@ -1008,6 +1015,7 @@ func (api *API) putWorkspaceDormant(rw http.ResponseWriter, r *http.Request) {
 		workspace,
 		data.builds[0],
 		data.templates[0],
+		data.activeVersionID(workspace, data.templates[0]),
 		owner.Username,
 		owner.AvatarURL,
 		api.Options.AllowWorkspaceRenames,
@@ -1041,6 +1049,16 @@ func (api *API) putExtendWorkspace(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	code, resp := api.extendWorkspaceDeadline(ctx, workspace, time.Time{}, req.Deadline)
+	httpapi.Write(ctx, rw, code, resp)
+}
+
+// extendWorkspaceDeadline sets the deadline of the latest build of the
+// workspace, returning the status code and response to send to the client. If
+// previousDeadline isn't zero, the deadline is only set if it's still
+// previousDeadline.
+func (api *API) extendWorkspaceDeadline(ctx context.Context, workspace database.Workspace, previousDeadline, newDeadline time.Time) (int, codersdk.Response) {
+	newDeadline = newDeadline.UTC()
 	code := http.StatusOK
 	resp := codersdk.Response{}
 
@@ -1051,6 +1069,11 @@ func (api *API) putExtendWorkspace(rw http.ResponseWriter, r *http.Request) {
 			resp.Message = "Error fetching workspace build."
 			return xerrors.Errorf("get latest workspace build: %w", err)
 		}
+		if !previousDeadline.IsZero() && !build.Deadline.Equal(previousDeadline) {
+			code = http.StatusConflict
+			resp.Message = "Workspace deadline has changed."
+			return xerrors.Errorf("workspace deadline has changed")
+		}
 
 		job, err := s.GetProvisionerJobByID(ctx, build.JobID)
 		if err != nil {
@@ -1089,7 +1112,6 @@ func (api *API) putExtendWorkspace(rw http.ResponseWriter, r *http.Request) {
 			return xerrors.New("cannot extend workspace: template does not allow user autostop")
 		}
 
-		newDeadline := req.Deadline.UTC()
 		if err := validWorkspaceDeadline(job.CompletedAt.Time, newDeadline); err != nil {
 			// NOTE(Cian): Putting the error in the Message field on request from the FE folks.
 			// Normally, we would put the validation error in Validations, but this endpoint is
@@ -1122,7 +1144,121 @@ func (api *API) putExtendWorkspace(rw http.ResponseWriter, r *http.Request) {
 		api.Logger.Info(ctx, "extending workspace", slog.Error(err))
 	}
 	api.publishWorkspaceUpdate(ctx, workspace.ID)
-	httpapi.Write(ctx, rw, code, resp)
+	return code, resp
+}
+
+// extendWorkspaceByTokenPage asks the owner of a workspace to confirm the
+// extension of its deadline with a token from an autostop warning. Following
+// the link in a warning must not change anything, as links in messages are
+// often followed by scanners before the recipient opens them.
+//
+// @Summary Confirm workspace deadline extension by signed token
+// @ID confirm-workspace-deadline-extension-by-signed-token
+// @Produce text/html
+// @Tags Workspaces
+// @Param token query string true "Signed extend token"
+// @Success 200
+// @Router /extend-workspace [get]
+// @x-apidocgen {"skip": true}
+func (api *API) extendWorkspaceByTokenPage(rw http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	tok, workspace, owner, ok := api.verifyExtendToken(rw, r, token)
+	if !ok {
+		return
+	}
+
+	site.RenderExtendWorkspacePage(rw, r, site.RenderExtendWorkspaceData{
+		WorkspaceName: workspace.Name,
+		Username:      owner.Username,
+		Extension:     autobuild.HumanizeDuration(tok.Deadline.Sub(tok.PreviousDeadline)),
+		Token:         token,
+		CancelURI:     workspacePageURL(owner, workspace),
+	})
+}
+
+// extendWorkspaceByToken extends the deadline of a workspace with a token from
+// an autostop warning, then redirects to the workspace page. Requests are
+// authenticated by the token's signature rather than an API key so that the
+// link works from any device. Each token can only be used once.
+//
+// @Summary Extend workspace deadline by signed token
+// @ID extend-workspace-deadline-by-signed-token
+// @Tags Workspaces
+// @Param token formData string true "Signed extend token"
+// @Success 303
+// @Router /extend-workspace [post]
+// @x-apidocgen {"skip": true}
+func (api *API) extendWorkspaceByToken(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tok, workspace, owner, ok := api.verifyExtendToken(rw, r, r.PostFormValue("token"))
+	if !ok {
+		return
+	}
+
+	// nolint:gocritic // The request is authenticated by the token's signature.
+	code, resp := api.extendWorkspaceDeadline(dbauthz.AsSystemRestricted(ctx), workspace, tok.PreviousDeadline, tok.Deadline)
+	if code != http.StatusOK {
+		renderExtendWorkspaceError(rw, r, api.AccessURL.String(), code, "Unable to extend workspace", resp.Message)
+		return
+	}
+
+	http.Redirect(rw, r, workspacePageURL(owner, workspace), http.StatusSeeOther)
+}
+
+// verifyExtendToken verifies a token from an autostop warning, and checks that
+// it hasn't been used yet. If the token can't be used, an error page is
+// rendered and false is returned.
+func (api *API) verifyExtendToken(rw http.ResponseWriter, r *http.Request, token string) (autobuild.ExtendToken, database.Workspace, database.User, bool) {
+	ctx := r.Context()
+	renderError := func(status int, title, description string) {
+		renderExtendWorkspaceError(rw, r, api.AccessURL.String(), status, title, description)
+	}
+
+	tok, err := autobuild.VerifyExtendToken(api.AppSecurityKey, token)
+	if err != nil {
+		renderError(http.StatusBadRequest, "Invalid link", "This link is invalid or has expired.")
+		return autobuild.ExtendToken{}, database.Workspace{}, database.User{}, false
+	}
+
+	// nolint:gocritic // The request is authenticated by the token's signature.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	workspace, err := api.Database.GetWorkspaceByID(ctx, tok.WorkspaceID)
+	if err != nil {
+		renderError(http.StatusNotFound, "Workspace not found", "The workspace may have been deleted.")
+		return autobuild.ExtendToken{}, database.Workspace{}, database.User{}, false
+	}
+	owner, err := api.Database.GetUserByID(ctx, workspace.OwnerID)
+	if err != nil {
+		renderError(http.StatusInternalServerError, "Internal error fetching workspace owner", err.Error())
+		return autobuild.ExtendToken{}, database.Workspace{}, database.User{}, false
+	}
+	build, err := api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		renderError(http.StatusInternalServerError, "Internal error fetching workspace build", err.Error())
+		return autobuild.ExtendToken{}, database.Workspace{}, database.User{}, false
+	}
+	// The deadline changes once the token is used, or when it has been
+	// changed by other means since the warning was sent.
+	if !build.Deadline.Equal(tok.PreviousDeadline) {
+		renderError(http.StatusConflict, "Link already used", "The deadline of this workspace has already been changed.")
+		return autobuild.ExtendToken{}, database.Workspace{}, database.User{}, false
+	}
+	return tok, workspace, owner, true
+}
+
+func workspacePageURL(owner database.User, workspace database.Workspace) string {
+	return fmt.Sprintf("/@%s/%s", owner.Username, workspace.Name)
+}
+
+func renderExtendWorkspaceError(rw http.ResponseWriter, r *http.Request, dashboardURL string, status int, title, description string) {
+	site.RenderStaticErrorPage(rw, r, site.ErrorPageData{
+		Status:       status,
+		HideStatus:   true,
+		Title:        title,
+		Description:  description,
+		RetryEnabled: false,
+		DashboardURL: dashboardURL,
+	})
 }
 
 // @Summary Post Workspace Usage by ID
@@ -1396,6 +1532,82 @@ func (api *API) putWorkspaceAutoupdates(rw http.ResponseWriter, r *http.Request)
 	rw.WriteHeader(http.StatusNoContent)
 }
 
+// @Summary Update workspace release channel by ID
+// @ID update-workspace-release-channel-by-id
+// @Security CoderSessionToken
+// @Accept json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.UpdateWorkspaceReleaseChannelRequest true "Release channel request"
+// @Success 204
+// @Router /workspaces/{workspace}/release-channel [put]
+func (api *API) putWorkspaceReleaseChannel(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: workspace.OrganizationID,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.UpdateWorkspaceReleaseChannelRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	name := req.ReleaseChannel
+	if name != "" {
+		channel, err := api.Database.GetTemplateReleaseChannelByTemplateIDAndName(ctx, database.GetTemplateReleaseChannelByTemplateIDAndNameParams{
+			TemplateID: workspace.TemplateID,
+			Name:       name,
+		})
+		if httpapi.Is404Error(err) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Invalid request",
+				Validations: []codersdk.ValidationError{{Field: "release_channel", Detail: fmt.Sprintf("release channel %q does not exist for the workspace template", name)}},
+			})
+			return
+		}
+		if err != nil {
+			httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Internal error fetching template release channel.",
+				Detail:  err.Error(),
+			})
+			return
+		}
+		name = channel.Name
+	}
+
+	err := api.Database.UpdateWorkspaceReleaseChannel(ctx, database.UpdateWorkspaceReleaseChannelParams{
+		ID:             workspace.ID,
+		ReleaseChannel: name,
+	})
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Internal error updating workspace release channel",
+			Detail:  err.Error(),
+		})
+		return
+	}
+
+	newWorkspace := workspace
+	newWorkspace.ReleaseChannel = name
+	aReq.New = newWorkspace
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
 // @Summary Resolve workspace autostart by id.
 // @ID resolve-workspace-autostart-by-id
 // @Security CoderSessionToken
@@ -1432,12 +1644,18 @@ func (api *API) resolveAutostart(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if build.TemplateVersionID == template.ActiveVersionID {
+	activeVersionID, err := wsbuilder.ActiveVersionID(ctx, api.Database, workspace, template)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	if build.TemplateVersionID == activeVersionID {
 		httpapi.Write(ctx, rw, http.StatusOK, codersdk.ResolveAutostartResponse{})
 		return
 	}
 
-	version, err := api.Database.GetTemplateVersionByID(ctx, template.ActiveVersionID)
+	version, err := api.Database.GetTemplateVersionByID(ctx, activeVersionID)
 	if err != nil {
 		httpapi.Write(ctx, rw, http.StatusInternalServerError, codersdk.Response{
 			Message: "Internal error fetching template version.",
@@ -1567,6 +1785,7 @@ func (api *API) watchWorkspace(rw http.ResponseWriter, r *http.Request) {
 			workspace,
 			data.builds[0],
 			data.templates[0],
+			data.activeVersionID(workspace, data.templates[0]),
 			owner.Username,
 			owner.AvatarURL,
 			api.Options.AllowWorkspaceRenames,
@@ -1633,10 +1852,25 @@ func (api *API) watchWorkspace(rw http.ResponseWriter, r *http.Request) {
 }
 
 type workspaceData struct {
-	templates    []database.Template
-	builds       []codersdk.WorkspaceBuild
-	users        []database.User
-	allowRenames bool
+	templates       []database.Template
+	builds          []codersdk.WorkspaceBuild
+	users           []database.User
+	releaseChannels []database.TemplateReleaseChannel
+	allowRenames    bool
+}
+
+// activeVersionID returns the version the workspace should be updated to. This
+// matches wsbuilder.ActiveVersionID.
+func (d workspaceData) activeVersionID(workspace database.Workspace, template database.Template) uuid.UUID {
+	if workspace.ReleaseChannel == "" {
+		return template.ActiveVersionID
+	}
+	for _, channel := range d.releaseChannels {
+		if channel.TemplateID == template.ID && strings.EqualFold(channel.Name, workspace.ReleaseChannel) {
+			return channel.TemplateVersionID
+		}
+	}
+	return template.ActiveVersionID
 }
 
 // workspacesData only returns the data the caller can access. If the caller
@@ -1646,9 +1880,13 @@ type workspaceData struct {
 func (api *API) workspaceData(ctx context.Context, workspaces []database.Workspace) (workspaceData, error) {
 	workspaceIDs := make([]uuid.UUID, 0, len(workspaces))
 	templateIDs := make([]uuid.UUID, 0, len(workspaces))
+	channelTemplateIDs := make([]uuid.UUID, 0)
 	for _, workspace := range workspaces {
 		workspaceIDs = append(workspaceIDs, workspace.ID)
 		templateIDs = append(templateIDs, workspace.TemplateID)
+		if workspace.ReleaseChannel != "" {
+			channelTemplateIDs = append(channelTemplateIDs, workspace.TemplateID)
+		}
 	}
 
 	templates, err := api.Database.GetTemplatesWithFilter(ctx, database.GetTemplatesWithFilterParams{
@@ -1665,6 +1903,17 @@ func (api *API) workspaceData(ctx context.Context, workspaces []database.Workspa
 		return workspaceData{}, xerrors.Errorf("get workspace builds: %w", err)
 	}
 
+	var releaseChannels []database.TemplateReleaseChannel
+	if len(channelTemplateIDs) > 0 {
+		// Workspaces can only see the channels of templates they can read,
+		// which are already filtered above.
+		// nolint:gocritic // Getting release channels by template IDs is a system function.
+		releaseChannels, err = api.Database.GetTemplateReleaseChannelsByTemplateIDs(dbauthz.AsSystemRestricted(ctx), channelTemplateIDs)
+		if err != nil {
+			return workspaceData{}, xerrors.Errorf("get template release channels: %w", err)
+		}
+	}
+
 	data, err := api.workspaceBuildsData(ctx, workspaces, builds)
 	if err != nil {
 		return workspaceData{}, xerrors.Errorf("get workspace builds data: %w", err)
@@ -1688,10 +1937,11 @@ func (api *API) workspaceData(ctx context.Context, workspaces []database.Workspa
 	}
 
 	return workspaceData{
-		templates:    templates,
-		builds:       apiBuilds,
-		users:        data.users,
-		allowRenames: api.Options.AllowWorkspaceRenames,
+		templates:       templates,
+		builds:          apiBuilds,
+		users:           data.users,
+		releaseChannels: releaseChannels,
+		allowRenames:    api.Options.AllowWorkspaceRenames,
 	}, nil
 }
 
@@ -1734,6 +1984,7 @@ func convertWorkspaces(requesterID uuid.UUID, workspaces []database.Workspace, d
 			workspace,
 			build,
 			template,
+			data.activeVersionID(workspace, template),
 			owner.Username,
 			owner.AvatarURL,
 			data.allowRenames,
@@ -1752,6 +2003,7 @@ func convertWorkspace(
 	workspace database.Workspace,
 	workspaceBuild codersdk.WorkspaceBuild,
 	template database.Template,
+	activeVersionID uuid.UUID,
 	username string,
 	avatarURL string,
 	allowRenames bool,
@@ -1808,9 +2060,9 @@ func convertWorkspace(
 		TemplateIcon:                         template.Icon,
 		TemplateDisplayName:                  template.DisplayName,
 		TemplateAllowUserCancelWorkspaceJobs: template.AllowUserCancelWorkspaceJobs,
-		TemplateActiveVersionID:              template.ActiveVersionID,
+		TemplateActiveVersionID:              activeVersionID,
 		TemplateRequireActiveVersion:         template.RequireActiveVersion,
-		Outdated:                             workspaceBuild.TemplateVersionID.String() != template.ActiveVersionID.String(),
+		Outdated:                             workspaceBuild.TemplateVersionID.String() != activeVersionID.String(),
 		Name:                                 workspace.Name,
 		AutostartSchedule:                    autostartSchedule,
 		TTLMillis:                            ttlMillis,
@@ -1822,6 +2074,7 @@ func convertWorkspace(
 			FailingAgents: failingAgents,
 		},
 		AutomaticUpdates: codersdk.AutomaticUpdates(workspace.AutomaticUpdates),
+		ReleaseChannel:   workspace.ReleaseChannel,
 		AllowRenames:     allowRenames,
 		Favorite:         requesterFavorite,
 	}, nil
@@ -6,8 +6,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"slices"
 	"strings"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/coder/coder/v2/agent/agenttest"
 	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/autobuild"
 	"github.com/coder/coder/v2/coderd/coderdtest"
 	"github.com/coder/coder/v2/coderd/database"
 	"github.com/coder/coder/v2/coderd/database/dbauthz"
@@ -37,6 +40,7 @@ import (
 	"github.com/coder/coder/v2/coderd/schedule"
 	"github.com/coder/coder/v2/coderd/schedule/cron"
 	"github.com/coder/coder/v2/coderd/util/ptr"
+	"github.com/coder/coder/v2/coderd/workspaceapps"
 	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/cryptorand"
 	"github.com/coder/coder/v2/provisioner/echo"
@@ -2356,6 +2360,129 @@ func TestWorkspaceExtend(t *testing.T) {
 	require.WithinDuration(t, oldDeadline.Add(-time.Hour), updated.LatestBuild.Deadline.Time, time.Minute)
 }
 
+func TestWorkspaceExtendByToken(t *testing.T) {
+	t.Parallel()
+	var (
+		ttl       = 8 * time.Hour
+		client    = coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+		user      = coderdtest.CreateFirstUser(t, client)
+		version   = coderdtest.CreateTemplateVersion(t, client, user.OrganizationID, nil)
+		_         = coderdtest.AwaitTemplateVersionJobCompleted(t, client, version.ID)
+		template  = coderdtest.CreateTemplate(t, client, user.OrganizationID, version.ID)
+		workspace = coderdtest.CreateWorkspace(t, client, user.OrganizationID, template.ID, func(cwr *codersdk.CreateWorkspaceRequest) {
+			cwr.TTLMillis = ptr.Ref(ttl.Milliseconds())
+		})
+		_ = coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+	)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	workspace, err := client.Workspace(ctx, workspace.ID)
+	require.NoError(t, err, "fetch provisioned workspace")
+	oldDeadline := workspace.LatestBuild.Deadline.Time
+
+	// The link is followed without a session token, and must not follow the
+	// redirect to the dashboard.
+	anonClient := codersdk.New(client.URL)
+	anonClient.HTTPClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	followLink := func(t *testing.T, token string) *http.Response {
+		t.Helper()
+		res, err := anonClient.Request(ctx, http.MethodGet, "/api/v2/extend-workspace?token="+token, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		return res
+	}
+	confirm := func(t *testing.T, token string) *http.Response {
+		t.Helper()
+		form := url.Values{"token": {token}}
+		res, err := anonClient.Request(ctx, http.MethodPost, "/api/v2/extend-workspace", strings.NewReader(form.Encode()), func(r *http.Request) {
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = res.Body.Close() })
+		return res
+	}
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+
+		newDeadline := oldDeadline.Add(time.Hour)
+		token, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, autobuild.ExtendToken{
+			WorkspaceID:      workspace.ID,
+			PreviousDeadline: oldDeadline,
+			Deadline:         newDeadline,
+			Expiry:           oldDeadline,
+		})
+		require.NoError(t, err)
+
+		// Following the link only asks for confirmation.
+		res := followLink(t, token)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		require.Contains(t, string(body), `method="POST"`)
+		require.Contains(t, string(body), token)
+		updated, err := client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.WithinDuration(t, oldDeadline, updated.LatestBuild.Deadline.Time, time.Second)
+
+		res = confirm(t, token)
+		require.Equal(t, http.StatusSeeOther, res.StatusCode)
+		require.Equal(t, fmt.Sprintf("/@%s/%s", workspace.OwnerName, workspace.Name), res.Header.Get("Location"))
+
+		updated, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.WithinDuration(t, newDeadline, updated.LatestBuild.Deadline.Time, time.Second)
+
+		// The token can't be used again.
+		res = followLink(t, token)
+		require.Equal(t, http.StatusConflict, res.StatusCode)
+		res = confirm(t, token)
+		require.Equal(t, http.StatusConflict, res.StatusCode)
+		updated, err = client.Workspace(ctx, workspace.ID)
+		require.NoError(t, err)
+		require.WithinDuration(t, newDeadline, updated.LatestBuild.Deadline.Time, time.Second)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		t.Parallel()
+
+		var otherKey workspaceapps.SecurityKey
+		copy(otherKey[:], "not the deployment key")
+		token, err := autobuild.SignExtendToken(otherKey, autobuild.ExtendToken{
+			WorkspaceID:      workspace.ID,
+			PreviousDeadline: oldDeadline,
+			Deadline:         oldDeadline.Add(24 * time.Hour),
+			Expiry:           oldDeadline,
+		})
+		require.NoError(t, err)
+
+		res := followLink(t, token)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+		res = confirm(t, token)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		t.Parallel()
+
+		token, err := autobuild.SignExtendToken(coderdtest.AppSecurityKey, autobuild.ExtendToken{
+			WorkspaceID:      workspace.ID,
+			PreviousDeadline: oldDeadline,
+			Deadline:         oldDeadline.Add(24 * time.Hour),
+			Expiry:           time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		res := followLink(t, token)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+		res = confirm(t, token)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+}
+
 func TestWorkspaceUpdateAutomaticUpdates_OK(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,264 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get workspace ACL
+// @ID get-workspace-acl
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {object} codersdk.WorkspaceACL
+// @Router /workspaces/{workspace}/acl [get]
+func (api *API) workspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	userIDs := make([]uuid.UUID, 0, len(workspace.UserACL))
+	for id := range workspace.UserACL {
+		if userID, err := uuid.Parse(id); err == nil {
+			userIDs = append(userIDs, userID)
+		}
+	}
+	// The caller can read the workspace, so they may see who it is shared
+	// with even if they cannot otherwise read those users and groups.
+	// nolint:gocritic
+	sysCtx := dbauthz.AsSystemRestricted(ctx)
+	users, err := api.Database.GetUsersByIDs(sysCtx, userIDs)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	acl := codersdk.WorkspaceACL{
+		Users:  make([]codersdk.WorkspaceUser, 0, len(users)),
+		Groups: make([]codersdk.WorkspaceGroup, 0, len(workspace.GroupACL)),
+	}
+	for _, user := range users {
+		if user.Deleted {
+			continue
+		}
+		acl.Users = append(acl.Users, codersdk.WorkspaceUser{
+			MinimalUser: codersdk.MinimalUser{
+				ID:        user.ID,
+				Username:  user.Username,
+				AvatarURL: user.AvatarURL,
+			},
+			Role: convertToWorkspaceRole(workspace.UserACL[user.ID.String()]),
+		})
+	}
+	for id, actions := range workspace.GroupACL {
+		groupID, err := uuid.Parse(id)
+		if err != nil {
+			continue
+		}
+		group, err := api.Database.GetGroupByID(sysCtx, groupID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// The group was deleted after the workspace was shared with it.
+			continue
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		acl.Groups = append(acl.Groups, codersdk.WorkspaceGroup{
+			Group: db2sdk.Group(group, nil),
+			Role:  convertToWorkspaceRole(actions),
+		})
+	}
+	sort.Slice(acl.Users, func(i, j int) bool {
+		return acl.Users[i].Username < acl.Users[j].Username
+	})
+	sort.Slice(acl.Groups, func(i, j int) bool {
+		return acl.Groups[i].Name < acl.Groups[j].Name
+	})
+
+	httpapi.Write(ctx, rw, http.StatusOK, acl)
+}
+
+// @Summary Update workspace ACL
+// @ID update-workspace-acl
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.UpdateWorkspaceACL true "Update workspace ACL request"
+// @Success 200 {object} codersdk.Response
+// @Router /workspaces/{workspace}/acl [patch]
+func (api *API) patchWorkspaceACL(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		workspace         = httpmw.WorkspaceParam(r)
+		auditor           = api.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.Workspace](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: workspace.OrganizationID,
+		})
+	)
+	defer commitAudit()
+	aReq.Old = workspace
+
+	var req codersdk.UpdateWorkspaceACL
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	validErrs := api.validateWorkspaceACLRoles(ctx, workspace, req.UserRoles, "user_roles", true)
+	validErrs = append(validErrs,
+		api.validateWorkspaceACLRoles(ctx, workspace, req.GroupRoles, "group_roles", false)...)
+	if len(validErrs) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid request to update workspace ACL.",
+			Validations: validErrs,
+		})
+		return
+	}
+
+	err := api.Database.InTx(func(tx database.Store) error {
+		var err error
+		workspace, err = tx.GetWorkspaceByID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get workspace by ID: %w", err)
+		}
+
+		userACL := applyWorkspaceRoles(workspace.UserACL, req.UserRoles)
+		groupACL := applyWorkspaceRoles(workspace.GroupACL, req.GroupRoles)
+		err = tx.UpdateWorkspaceACLByID(ctx, database.UpdateWorkspaceACLByIDParams{
+			ID:       workspace.ID,
+			UserACL:  userACL,
+			GroupACL: groupACL,
+		})
+		if err != nil {
+			return xerrors.Errorf("update workspace ACL by ID: %w", err)
+		}
+		workspace, err = tx.GetWorkspaceByID(ctx, workspace.ID)
+		if err != nil {
+			return xerrors.Errorf("get updated workspace by ID: %w", err)
+		}
+		return nil
+	}, nil)
+	if dbauthz.IsNotAuthorizedError(err) {
+		httpapi.Forbidden(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	aReq.New = workspace
+
+	httpapi.Write(ctx, rw, http.StatusOK, codersdk.Response{
+		Message: "Successfully updated workspace ACL.",
+	})
+}
+
+func (api *API) validateWorkspaceACLRoles(ctx context.Context, workspace database.Workspace, roles map[string]codersdk.WorkspaceRole, field string, isUser bool) []codersdk.ValidationError {
+	// Validation requires read access to users and groups the caller may not
+	// otherwise be able to see.
+	// nolint:gocritic
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	var validErrs []codersdk.ValidationError
+	for k, role := range roles {
+		if role != codersdk.WorkspaceRoleDeleted && convertSDKWorkspaceRole(role) == nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Role %q is not a valid workspace role.", role)})
+			continue
+		}
+		id, err := uuid.Parse(k)
+		if err != nil {
+			validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("ID %q must be a valid UUID.", k)})
+			continue
+		}
+		if role == codersdk.WorkspaceRoleDeleted {
+			// Removing users and groups which no longer exist is allowed.
+			continue
+		}
+
+		if isUser {
+			if id == workspace.OwnerID {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: "The workspace owner already has full access to the workspace."})
+				continue
+			}
+			user, err := api.Database.GetUserByID(ctx, id)
+			if err != nil || user.Deleted {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Failed to find user with ID %q.", k)})
+				continue
+			}
+		} else {
+			group, err := api.Database.GetGroupByID(ctx, id)
+			if err != nil {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Failed to find group with ID %q.", k)})
+				continue
+			}
+			if group.OrganizationID != workspace.OrganizationID {
+				validErrs = append(validErrs, codersdk.ValidationError{Field: field, Detail: fmt.Sprintf("Group %q is not in the organization of the workspace.", group.Name)})
+				continue
+			}
+		}
+	}
+	return validErrs
+}
+
+// applyWorkspaceRoles returns a copy of acl with the given roles set. An empty
+// role removes the entry.
+func applyWorkspaceRoles(acl database.WorkspaceACL, roles map[string]codersdk.WorkspaceRole) database.WorkspaceACL {
+	updated := make(database.WorkspaceACL, len(acl))
+	for id, actions := range acl {
+		updated[id] = actions
+	}
+	for id, role := range roles {
+		if role == codersdk.WorkspaceRoleDeleted {
+			delete(updated, id)
+			continue
+		}
+		updated[id] = convertSDKWorkspaceRole(role)
+	}
+	return updated
+}
+
+func convertToWorkspaceRole(actions []policy.Action) codersdk.WorkspaceRole {
+	switch {
+	case slices.Contains(actions, policy.ActionUpdate):
+		return codersdk.WorkspaceRoleAdmin
+	case slices.Contains(actions, policy.ActionSSH):
+		return codersdk.WorkspaceRoleUse
+	}
+	return ""
+}
+
+func convertSDKWorkspaceRole(role codersdk.WorkspaceRole) []policy.Action {
+	use := []policy.Action{policy.ActionRead, policy.ActionSSH, policy.ActionApplicationConnect}
+	switch role {
+	case codersdk.WorkspaceRoleAdmin:
+		return append(use, policy.ActionUpdate, policy.ActionWorkspaceStart, policy.ActionWorkspaceStop)
+	case codersdk.WorkspaceRoleUse:
+		return use
+	}
+	return nil
+}
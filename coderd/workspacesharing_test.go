@@ -0,0 +1,78 @@
+package coderd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceACL(t *testing.T) {
+	t.Parallel()
+
+	ownerClient, db := coderdtest.NewWithDatabase(t, nil)
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	client, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	otherClient, other := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: owner.OrganizationID,
+		OwnerID:        user.ID,
+	}).Do()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// The workspace is not shared yet.
+	_, err := otherClient.Workspace(ctx, r.Workspace.ID)
+	require.Error(t, err)
+
+	// Invalid roles are rejected.
+	err = client.UpdateWorkspaceACL(ctx, r.Workspace.ID, codersdk.UpdateWorkspaceACL{
+		UserRoles: map[string]codersdk.WorkspaceRole{other.ID.String(): "superuser"},
+	})
+	require.Error(t, err)
+
+	// The owner already has access.
+	err = client.UpdateWorkspaceACL(ctx, r.Workspace.ID, codersdk.UpdateWorkspaceACL{
+		UserRoles: map[string]codersdk.WorkspaceRole{user.ID.String(): codersdk.WorkspaceRoleUse},
+	})
+	require.Error(t, err)
+
+	err = client.UpdateWorkspaceACL(ctx, r.Workspace.ID, codersdk.UpdateWorkspaceACL{
+		UserRoles: map[string]codersdk.WorkspaceRole{other.ID.String(): codersdk.WorkspaceRoleUse},
+	})
+	require.NoError(t, err)
+
+	acl, err := client.WorkspaceACL(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, acl.Users, 1)
+	require.Equal(t, other.ID, acl.Users[0].ID)
+	require.Equal(t, codersdk.WorkspaceRoleUse, acl.Users[0].Role)
+
+	// The workspace can now be read by the user it is shared with...
+	_, err = otherClient.Workspace(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+
+	// ...but they cannot share it further.
+	err = otherClient.UpdateWorkspaceACL(ctx, r.Workspace.ID, codersdk.UpdateWorkspaceACL{
+		UserRoles: map[string]codersdk.WorkspaceRole{other.ID.String(): codersdk.WorkspaceRoleAdmin},
+	})
+	require.Error(t, err)
+
+	err = client.UpdateWorkspaceACL(ctx, r.Workspace.ID, codersdk.UpdateWorkspaceACL{
+		UserRoles: map[string]codersdk.WorkspaceRole{other.ID.String(): codersdk.WorkspaceRoleDeleted},
+	})
+	require.NoError(t, err)
+
+	acl, err = client.WorkspaceACL(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+	require.Empty(t, acl.Users)
+
+	_, err = otherClient.Workspace(ctx, r.Workspace.ID)
+	require.Error(t, err)
+}
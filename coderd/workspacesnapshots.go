@@ -0,0 +1,283 @@
+package coderd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/moby/moby/pkg/namesgenerator"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/coderd/wsbuilder"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// @Summary Get workspace snapshots
+// @ID get-workspace-snapshots
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Success 200 {array} codersdk.WorkspaceSnapshot
+// @Router /workspaces/{workspace}/snapshots [get]
+func (api *API) workspaceSnapshots(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	snapshots, err := api.Database.GetWorkspaceSnapshotsByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	versionIDs := make([]uuid.UUID, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		versionIDs = append(versionIDs, snapshot.TemplateVersionID)
+	}
+	// nolint:gocritic // Getting template versions by ID is a system function.
+	versions, err := api.Database.GetTemplateVersionsByIDs(dbauthz.AsSystemRestricted(ctx), versionIDs)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	versionNames := make(map[uuid.UUID]string, len(versions))
+	for _, version := range versions {
+		versionNames[version.ID] = version.Name
+	}
+
+	resp := make([]codersdk.WorkspaceSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		apiSnapshot, err := convertWorkspaceSnapshot(snapshot, versionNames[snapshot.TemplateVersionID])
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		resp = append(resp, apiSnapshot)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// @Summary Create workspace snapshot
+// @ID create-workspace-snapshot
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param request body codersdk.CreateWorkspaceSnapshotRequest true "Create workspace snapshot request"
+// @Success 201 {object} codersdk.WorkspaceSnapshot
+// @Router /workspaces/{workspace}/snapshots [post]
+func (api *API) postWorkspaceSnapshot(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		apiKey    = httpmw.APIKey(r)
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	var req codersdk.CreateWorkspaceSnapshotRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	template, err := api.Database.GetTemplateByID(ctx, workspace.TemplateID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	// Snapshots contain the Terraform state of the build, so you must have
+	// update permissions on the template to create one. This matches a state
+	// pull!
+	if !api.Authorize(r, policy.ActionUpdate, template.RBACObject()) {
+		httpapi.Write(ctx, rw, http.StatusForbidden, codersdk.Response{
+			Message: "Only template managers may snapshot workspaces.",
+		})
+		return
+	}
+
+	build, ok := api.workspaceSnapshotBuild(ctx, rw, workspace.ID, req.BuildNumber)
+	if !ok {
+		return
+	}
+	if build.Transition == database.WorkspaceTransitionDelete {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Builds that delete the workspace cannot be snapshotted.",
+		})
+		return
+	}
+	job, err := api.Database.GetProvisionerJobByID(ctx, build.JobID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	if job.JobStatus != database.ProvisionerJobStatusSucceeded {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Only successful builds can be snapshotted.",
+			Detail:  "The state of unfinished or failed builds may not match the workspace's resources.",
+		})
+		return
+	}
+
+	parameters, err := api.Database.GetWorkspaceBuildParameters(ctx, build.ID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	richParameters, err := json.Marshal(db2sdk.WorkspaceBuildParameters(parameters))
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	name := req.Name
+	if name == "" {
+		name = namesgenerator.GetRandomName(1)
+	}
+	snapshot, err := api.Database.InsertWorkspaceSnapshot(ctx, database.InsertWorkspaceSnapshotParams{
+		ID:                uuid.New(),
+		WorkspaceID:       workspace.ID,
+		WorkspaceBuildID:  build.ID,
+		BuildNumber:       build.BuildNumber,
+		TemplateVersionID: build.TemplateVersionID,
+		Name:              name,
+		Transition:        build.Transition,
+		ProvisionerState:  build.ProvisionerState,
+		RichParameters:    richParameters,
+		CreatedBy:         apiKey.UserID,
+		CreatedAt:         dbtime.Now(),
+	})
+	if database.IsUniqueViolation(err, database.UniqueIndexWorkspaceSnapshotsWorkspaceIDName) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "A snapshot with this name already exists.",
+			Validations: []codersdk.ValidationError{{
+				Field:  "name",
+				Detail: "This value is already in use and should be unique.",
+			}},
+		})
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	version, err := api.Database.GetTemplateVersionByID(ctx, snapshot.TemplateVersionID)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	apiSnapshot, err := convertWorkspaceSnapshot(snapshot, version.Name)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusCreated, apiSnapshot)
+}
+
+// @Summary Restore workspace snapshot
+// @ID restore-workspace-snapshot
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Workspaces
+// @Param workspace path string true "Workspace ID" format(uuid)
+// @Param snapshot path string true "Snapshot ID" format(uuid)
+// @Success 201 {object} codersdk.WorkspaceBuild
+// @Router /workspaces/{workspace}/snapshots/{snapshot}/restore [post]
+func (api *API) postWorkspaceSnapshotRestore(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx       = r.Context()
+		apiKey    = httpmw.APIKey(r)
+		workspace = httpmw.WorkspaceParam(r)
+	)
+
+	snapshotID, ok := httpmw.ParseUUIDParam(rw, r, "snapshot")
+	if !ok {
+		return
+	}
+	snapshot, err := api.Database.GetWorkspaceSnapshotByID(ctx, snapshotID)
+	if httpapi.Is404Error(err) || (err == nil && snapshot.WorkspaceID != workspace.ID) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	var parameters []codersdk.WorkspaceBuildParameter
+	err = json.Unmarshal(snapshot.RichParameters, &parameters)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	// Providing state requires update permissions on the template, which
+	// the builder checks.
+	builder := wsbuilder.New(workspace, snapshot.Transition).
+		Initiator(apiKey.UserID).
+		VersionID(snapshot.TemplateVersionID).
+		State(snapshot.ProvisionerState).
+		RichParameterValues(parameters).
+		DeploymentValues(api.Options.DeploymentValues)
+
+	api.buildWorkspace(rw, r, workspace, builder)
+}
+
+// workspaceSnapshotBuild returns the build of a workspace with the given
+// number, or the latest build if the number is 0.
+func (api *API) workspaceSnapshotBuild(ctx context.Context, rw http.ResponseWriter, workspaceID uuid.UUID, buildNumber int32) (database.WorkspaceBuild, bool) {
+	var (
+		build database.WorkspaceBuild
+		err   error
+	)
+	if buildNumber == 0 {
+		build, err = api.Database.GetLatestWorkspaceBuildByWorkspaceID(ctx, workspaceID)
+	} else {
+		build, err = api.Database.GetWorkspaceBuildByWorkspaceIDAndBuildNumber(ctx, database.GetWorkspaceBuildByWorkspaceIDAndBuildNumberParams{
+			WorkspaceID: workspaceID,
+			BuildNumber: buildNumber,
+		})
+	}
+	if httpapi.Is404Error(err) {
+		httpapi.Write(ctx, rw, http.StatusNotFound, codersdk.Response{
+			Message: "Workspace build not found.",
+		})
+		return database.WorkspaceBuild{}, false
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return database.WorkspaceBuild{}, false
+	}
+	return build, true
+}
+
+func convertWorkspaceSnapshot(snapshot database.WorkspaceSnapshot, templateVersionName string) (codersdk.WorkspaceSnapshot, error) {
+	var parameters []codersdk.WorkspaceBuildParameter
+	err := json.Unmarshal(snapshot.RichParameters, &parameters)
+	if err != nil {
+		return codersdk.WorkspaceSnapshot{}, err
+	}
+	return codersdk.WorkspaceSnapshot{
+		ID:                  snapshot.ID,
+		WorkspaceID:         snapshot.WorkspaceID,
+		WorkspaceBuildID:    snapshot.WorkspaceBuildID,
+		BuildNumber:         snapshot.BuildNumber,
+		TemplateVersionID:   snapshot.TemplateVersionID,
+		TemplateVersionName: templateVersionName,
+		Name:                snapshot.Name,
+		Transition:          codersdk.WorkspaceTransition(snapshot.Transition),
+		RichParameterValues: parameters,
+		CreatedBy:           snapshot.CreatedBy,
+		CreatedAt:           snapshot.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package coderd_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestWorkspaceSnapshots(t *testing.T) {
+	t.Parallel()
+
+	ownerClient := coderdtest.New(t, &coderdtest.Options{IncludeProvisionerDaemon: true})
+	owner := coderdtest.CreateFirstUser(t, ownerClient)
+	client, _ := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+
+	version := coderdtest.CreateTemplateVersion(t, ownerClient, owner.OrganizationID, nil)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, ownerClient, version.ID)
+	template := coderdtest.CreateTemplate(t, ownerClient, owner.OrganizationID, version.ID)
+	workspace := coderdtest.CreateWorkspace(t, client, owner.OrganizationID, template.ID)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, workspace.LatestBuild.ID)
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+
+	// Workspace owners cannot snapshot their own workspaces, as the snapshot
+	// contains the Terraform state.
+	_, err := client.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+		Name: "before-update",
+	})
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+	snapshot, err := ownerClient.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+		Name: "before-update",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "before-update", snapshot.Name)
+	require.EqualValues(t, 1, snapshot.BuildNumber)
+	require.Equal(t, version.ID, snapshot.TemplateVersionID)
+	require.Equal(t, version.Name, snapshot.TemplateVersionName)
+	require.Equal(t, codersdk.WorkspaceTransitionStart, snapshot.Transition)
+
+	// Names are unique within a workspace.
+	_, err = ownerClient.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+		Name: "Before-Update",
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+
+	// Snapshots of builds that don't exist fail.
+	_, err = ownerClient.CreateWorkspaceSnapshot(ctx, workspace.ID, codersdk.CreateWorkspaceSnapshotRequest{
+		BuildNumber: 10,
+	})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+
+	snapshots, err := client.WorkspaceSnapshots(ctx, workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	require.Equal(t, snapshot.ID, snapshots[0].ID)
+
+	// Update the workspace to a new template version.
+	newVersion := coderdtest.UpdateTemplateVersion(t, ownerClient, owner.OrganizationID, nil, template.ID)
+	coderdtest.AwaitTemplateVersionJobCompleted(t, ownerClient, newVersion.ID)
+	build, err := client.CreateWorkspaceBuild(ctx, workspace.ID, codersdk.CreateWorkspaceBuildRequest{
+		TemplateVersionID: newVersion.ID,
+		Transition:        codersdk.WorkspaceTransitionStart,
+	})
+	require.NoError(t, err)
+	coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, build.ID)
+
+	// Restoring requires providing state, which only template managers may
+	// do.
+	_, err = client.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+	build, err = ownerClient.RestoreWorkspaceSnapshot(ctx, workspace.ID, snapshot.ID)
+	require.NoError(t, err)
+	require.Equal(t, version.ID, build.TemplateVersionID)
+	require.EqualValues(t, 3, build.BuildNumber)
+	build = coderdtest.AwaitWorkspaceBuildJobCompleted(t, client, build.ID)
+	require.Equal(t, codersdk.WorkspaceStatusRunning, build.Status)
+}
@@ -22,6 +22,15 @@ import (
 	"github.com/coder/coder/v2/codersdk"
 )
 
+// AgentCPUUsagePercentMetric is the name of the agent metric reporting the
+// percentage of the CPU available to the workspace that is in use.
+const AgentCPUUsagePercentMetric = "agent_stats_cpu_usage_percent"
+
+// activeNetworkBytes is the traffic in a single stats report above which a
+// workspace is in use. Less traffic than this is expected from keepalives and
+// latency checks alone.
+const activeNetworkBytes = 1 << 20 // 1 MiB
+
 type ReporterOptions struct {
 	Database              database.Store
 	Logger                slog.Logger
@@ -160,6 +169,19 @@ func (r *Reporter) ReportAgentStats(ctx context.Context, now time.Time, workspac
 		}
 		return nil
 	})
+	errGroup.Go(func() error {
+		if !r.workspaceActive(ctx, workspace, stats) {
+			return nil
+		}
+		err := r.opts.Database.UpdateWorkspaceLastActiveAt(ctx, database.UpdateWorkspaceLastActiveAtParams{
+			ID:           workspace.ID,
+			LastActiveAt: now,
+		})
+		if err != nil {
+			return xerrors.Errorf("update workspace LastActiveAt: %w", err)
+		}
+		return nil
+	})
 	if r.opts.UpdateAgentMetricsFn != nil {
 		errGroup.Go(func() error {
 			user, err := r.opts.Database.GetUserByID(ctx, workspace.OwnerID)
@@ -190,6 +212,46 @@ func (r *Reporter) ReportAgentStats(ctx context.Context, now time.Time, workspac
 	return nil
 }
 
+// workspaceActive returns whether agent stats show that a workspace is in use,
+// either because it has open sessions, because it sent or received more than a
+// trickle of network traffic, or because its CPU usage is at or above the idle
+// threshold of its template.
+func (r *Reporter) workspaceActive(ctx context.Context, workspace database.Workspace, stats *agentproto.Stats) bool {
+	if stats.SessionCountSsh > 0 ||
+		stats.SessionCountVscode > 0 ||
+		stats.SessionCountJetbrains > 0 ||
+		stats.SessionCountReconnectingPty > 0 {
+		return true
+	}
+	if stats.RxBytes+stats.TxBytes > activeNetworkBytes {
+		return true
+	}
+
+	cpuUsage := -1.0
+	for _, metric := range stats.Metrics {
+		if metric.Name == AgentCPUUsagePercentMetric {
+			cpuUsage = metric.Value
+			break
+		}
+	}
+	if cpuUsage < 0 {
+		return false
+	}
+	templateSchedule, err := (*(r.opts.TemplateScheduleStore.Load())).Get(ctx, r.opts.Database, workspace.TemplateID)
+	if err != nil {
+		r.opts.Logger.Error(ctx, "failed to load template schedule to check workspace activity",
+			slog.F("workspace_id", workspace.ID),
+			slog.F("template_id", workspace.TemplateID),
+			slog.Error(err),
+		)
+		return false
+	}
+	if templateSchedule.IdleTimeout <= 0 || templateSchedule.IdleCPUThreshold <= 0 {
+		return false
+	}
+	return cpuUsage >= float64(templateSchedule.IdleCPUThreshold)
+}
+
 type UpdateTemplateWorkspacesLastUsedAtFunc func(ctx context.Context, db database.Store, templateID uuid.UUID, lastUsedAt time.Time) error
 
 func UpdateTemplateWorkspacesLastUsedAt(ctx context.Context, db database.Store, templateID uuid.UUID, lastUsedAt time.Time) error {
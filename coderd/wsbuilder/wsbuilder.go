@@ -452,7 +452,7 @@ func (b *Builder) getTemplateVersionID() (uuid.UUID, error) {
 		if err != nil {
 			return uuid.Nil, xerrors.Errorf("get template so we can get active version: %w", err)
 		}
-		return t.ActiveVersionID, nil
+		return ActiveVersionID(b.ctx, b.store, b.workspace, *t)
 	}
 	// default is prior version
 	bld, err := b.getLastBuild()
@@ -462,6 +462,27 @@ func (b *Builder) getTemplateVersionID() (uuid.UUID, error) {
 	return bld.TemplateVersionID, nil
 }
 
+// ActiveVersionID returns the template version a workspace should be updated
+// to. This is the version of the release channel the workspace follows, or the
+// active version of the template if the workspace follows no channel or the
+// channel does not exist.
+func ActiveVersionID(ctx context.Context, store database.Store, workspace database.Workspace, template database.Template) (uuid.UUID, error) {
+	if workspace.ReleaseChannel == "" {
+		return template.ActiveVersionID, nil
+	}
+	channel, err := store.GetTemplateReleaseChannelByTemplateIDAndName(ctx, database.GetTemplateReleaseChannelByTemplateIDAndNameParams{
+		TemplateID: template.ID,
+		Name:       workspace.ReleaseChannel,
+	})
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return template.ActiveVersionID, nil
+	}
+	if err != nil {
+		return uuid.Nil, xerrors.Errorf("get release channel %q: %w", workspace.ReleaseChannel, err)
+	}
+	return channel.TemplateVersionID, nil
+}
+
 func (b *Builder) getLastBuild() (*database.WorkspaceBuild, error) {
 	if b.lastBuild != nil {
 		return b.lastBuild, nil
@@ -22,6 +22,11 @@ type APIKey struct {
 	Scope           APIKeyScope `json:"scope" validate:"required" enums:"all,application_connect"`
 	TokenName       string      `json:"token_name" validate:"required"`
 	LifetimeSeconds int64       `json:"lifetime_seconds" validate:"required"`
+	// Scopes are the permissions the key is restricted to. See
+	// CreateTokenRequest.Scopes.
+	Scopes []string `json:"scopes,omitempty"`
+	// AllowList are the IDs of the resources the key is restricted to.
+	AllowList []uuid.UUID `json:"allow_list,omitempty" format:"uuid"`
 }
 
 // LoginType is the type of login used to create the API key.
@@ -54,6 +59,15 @@ type CreateTokenRequest struct {
 	Lifetime  time.Duration `json:"lifetime"`
 	Scope     APIKeyScope   `json:"scope" enums:"all,application_connect"`
 	TokenName string        `json:"token_name"`
+	// Scopes restrict the token to permissions of the form "resource:action",
+	// e.g. "workspace:read" or "workspace:start". The action may be "*" to
+	// allow every action on the resource, and "template:push" allows
+	// creating and updating templates. Scopes cannot be combined with the
+	// application_connect scope.
+	Scopes []string `json:"scopes,omitempty"`
+	// AllowList restricts a token with scopes to the resources with these
+	// IDs, such as a workspace or a template.
+	AllowList []uuid.UUID `json:"allow_list,omitempty" format:"uuid"`
 }
 
 // GenerateAPIKeyResponse contains an API key for a user.
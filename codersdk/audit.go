@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/netip"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,6 +34,10 @@ const (
 	ResourceTypeOAuth2ProviderAppSecret ResourceType = "oauth2_provider_app_secret"
 	ResourceTypeCustomRole              ResourceType = "custom_role"
 	ResourceTypeOrganizationMember                   = "organization_member"
+	ResourceTypeNotificationTemplate    ResourceType = "notification_template"
+	ResourceTypeQuotaBudget             ResourceType = "quota_budget"
+	ResourceTypeTemplateReleaseChannel  ResourceType = "template_release_channel"
+	ResourceTypeTemplateGitSync         ResourceType = "template_git_sync"
 )
 
 func (r ResourceType) FriendlyString() string {
@@ -75,6 +80,14 @@ func (r ResourceType) FriendlyString() string {
 		return "custom role"
 	case ResourceTypeOrganizationMember:
 		return "organization member"
+	case ResourceTypeNotificationTemplate:
+		return "notification template"
+	case ResourceTypeQuotaBudget:
+		return "quota budget"
+	case ResourceTypeTemplateReleaseChannel:
+		return "template release channel"
+	case ResourceTypeTemplateGitSync:
+		return "template git sync"
 	default:
 		return "unknown"
 	}
@@ -215,3 +228,75 @@ func (c *Client) CreateTestAuditLog(ctx context.Context, req CreateTestAuditLogR
 
 	return nil
 }
+
+type AuditLogChainProblemType string
+
+const (
+	// AuditLogChainProblemGap is reported when entries are missing from the
+	// chain.
+	AuditLogChainProblemGap AuditLogChainProblemType = "gap"
+	// AuditLogChainProblemModified is reported when an entry doesn't match
+	// its hash.
+	AuditLogChainProblemModified AuditLogChainProblemType = "modified"
+)
+
+type AuditLogChainProblem struct {
+	Type AuditLogChainProblemType `json:"type" enums:"gap,modified"`
+	// FromIndex and ToIndex are the inclusive range of chain indexes the
+	// problem applies to.
+	FromIndex int64 `json:"from_index"`
+	ToIndex   int64 `json:"to_index"`
+	// AuditLogID is the modified entry. It's empty for gaps.
+	AuditLogID uuid.UUID `json:"audit_log_id,omitempty" format:"uuid"`
+}
+
+// AuditLogChain is the result of verifying the audit logs of an organization.
+// Audit logs that aren't in an organization are in the chain with the nil
+// organization ID.
+type AuditLogChain struct {
+	OrganizationID uuid.UUID `json:"organization_id" format:"uuid"`
+	// Length is the number of entries in the chain.
+	Length int64 `json:"length"`
+	// Hash is the hex encoded hash of the last entry in the chain.
+	Hash string `json:"hash"`
+	// FromIndex and ToIndex are the inclusive range of chain indexes that
+	// were verified.
+	FromIndex int64                  `json:"from_index"`
+	ToIndex   int64                  `json:"to_index"`
+	Problems  []AuditLogChainProblem `json:"problems"`
+}
+
+type AuditLogVerification struct {
+	// Valid is true if no chain has a problem in the verified range.
+	Valid bool `json:"valid"`
+	// Chains are the chains with entries after the requested index.
+	Chains []AuditLogChain `json:"chains"`
+}
+
+type VerifyAuditLogsRequest struct {
+	// AfterIndex is the chain index after which entries are verified. Only a
+	// limited number of entries of each chain are verified at a time, so
+	// continue from the largest ToIndex until no chain is returned.
+	AfterIndex int64 `json:"after_index"`
+}
+
+// VerifyAuditLogs walks the hash chains of the audit logs, and reports entries
+// that are missing or were modified.
+func (c *Client) VerifyAuditLogs(ctx context.Context, req VerifyAuditLogsRequest) (AuditLogVerification, error) {
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/audit/verify", nil, func(r *http.Request) {
+		q := r.URL.Query()
+		q.Set("after_index", strconv.FormatInt(req.AfterIndex, 10))
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return AuditLogVerification{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return AuditLogVerification{}, ReadBodyAsError(res)
+	}
+
+	var verification AuditLogVerification
+	return verification, json.NewDecoder(res.Body).Decode(&verification)
+}
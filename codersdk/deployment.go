@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"slices"
@@ -367,6 +368,7 @@ type DeploymentValues struct {
 	DisablePasswordAuth             serpent.Bool                         `json:"disable_password_auth,omitempty" typescript:",notnull"`
 	Support                         SupportConfig                        `json:"support,omitempty" typescript:",notnull"`
 	ExternalAuthConfigs             serpent.Struct[[]ExternalAuthConfig] `json:"external_auth,omitempty" typescript:",notnull"`
+	TemplatePolicyRules             serpent.Struct[[]TemplatePolicyRule] `json:"template_policy_rules,omitempty" typescript:",notnull"`
 	SSHConfig                       SSHConfig                            `json:"config_ssh,omitempty" typescript:",notnull"`
 	WgtunnelHost                    serpent.String                       `json:"wgtunnel_host,omitempty" typescript:",notnull"`
 	DisableOwnerWorkspaceExec       serpent.Bool                         `json:"disable_owner_workspace_exec,omitempty" typescript:",notnull"`
@@ -379,6 +381,7 @@ type DeploymentValues struct {
 	CLIUpgradeMessage               serpent.String                       `json:"cli_upgrade_message,omitempty" typescript:",notnull"`
 	TermsOfServiceURL               serpent.String                       `json:"terms_of_service_url,omitempty" typescript:",notnull"`
 	Notifications                   NotificationsConfig                  `json:"notifications,omitempty" typescript:",notnull"`
+	AuditLogging                    AuditLoggingConfig                   `json:"audit_logging,omitempty" typescript:",notnull"`
 
 	Config      serpent.YAMLConfigPath `json:"config,omitempty" typescript:",notnull"`
 	WriteConfig serpent.Bool           `json:"write_config,omitempty" typescript:",notnull"`
@@ -659,14 +662,27 @@ type NotificationsConfig struct {
 	// How often to query the database for queued notifications.
 	FetchInterval serpent.Duration `json:"fetch_interval"`
 
-	// Which delivery method to use (available options: 'smtp', 'webhook').
+	// Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').
 	Method serpent.String `json:"method"`
 	// How long to wait while a notification is being sent before giving up.
 	DispatchTimeout serpent.Duration `json:"dispatch_timeout"`
+	// How long before a workspace's autostop deadline to warn its owner. Zero disables the warning.
+	AutostopWarning serpent.Duration `json:"autostop_warning"`
+	// Identical messages enqueued for the same user within this window are only delivered once. Zero disables deduplication.
+	DedupeWindow serpent.Duration `json:"dedupe_window"`
+	// How long messages of digest templates are held back so that they can be delivered to each user as a single
+	// message. Zero disables digests.
+	DigestWindow serpent.Duration `json:"digest_window"`
+	// The maximum number of notifications delivered to a single user per hour. Zero disables the limit.
+	PerUserRateLimit serpent.Int64 `json:"per_user_rate_limit"`
 	// SMTP settings.
 	SMTP NotificationsEmailConfig `json:"email" typescript:",notnull"`
 	// Webhook settings.
 	Webhook NotificationsWebhookConfig `json:"webhook" typescript:",notnull"`
+	// Slack settings.
+	Slack NotificationsSlackConfig `json:"slack" typescript:",notnull"`
+	// Microsoft Teams settings.
+	Teams NotificationsTeamsConfig `json:"teams" typescript:",notnull"`
 }
 
 type NotificationsEmailConfig struct {
@@ -724,6 +740,66 @@ type NotificationsWebhookConfig struct {
 	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
 }
 
+type NotificationsSlackConfig struct {
+	// The Slack incoming webhook URL to which messages will be posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// The Slack bot token used to send direct messages to users, who are looked up by their email address.
+	// If set, this takes precedence over the incoming webhook.
+	BotToken serpent.String `json:"bot_token" typescript:",notnull"`
+}
+
+type NotificationsTeamsConfig struct {
+	// The Microsoft Teams incoming webhook URL to which Adaptive Cards will be posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+}
+
+// AuditLoggingConfig configures how audit logs stored in the database are
+// protected, and the systems they are streamed to.
+type AuditLoggingConfig struct {
+	// The secret used to sign the hash chain of the audit logs.
+	ChainKey serpent.String `json:"chain_key" typescript:",notnull"`
+	// Syslog settings.
+	Syslog AuditLoggingSyslogConfig `json:"syslog" typescript:",notnull"`
+	// HTTP settings.
+	HTTP AuditLoggingHTTPConfig `json:"http" typescript:",notnull"`
+}
+
+type AuditLoggingSyslogFormat string
+
+const (
+	AuditLoggingSyslogFormatJSON AuditLoggingSyslogFormat = "json"
+	AuditLoggingSyslogFormatCEF  AuditLoggingSyslogFormat = "cef"
+	AuditLoggingSyslogFormatLEEF AuditLoggingSyslogFormat = "leef"
+)
+
+var AuditLoggingSyslogFormats = []string{
+	string(AuditLoggingSyslogFormatJSON),
+	string(AuditLoggingSyslogFormatCEF),
+	string(AuditLoggingSyslogFormatLEEF),
+}
+
+type AuditLoggingSyslogConfig struct {
+	// The address of the syslog server, with a udp, tcp or tls scheme.
+	Address serpent.URL `json:"address" typescript:",notnull"`
+	// The format of the message of each syslog entry.
+	Format string `json:"format" typescript:",notnull"`
+	// The CA certificate used to verify the server with the tls scheme.
+	CAFile serpent.String `json:"ca_file" typescript:",notnull"`
+}
+
+type AuditLoggingHTTPConfig struct {
+	// The endpoint to which batches of audit logs are posted.
+	Endpoint serpent.URL `json:"endpoint" typescript:",notnull"`
+	// Headers added to every request, in the "Name: Value" format.
+	Headers serpent.StringArray `json:"headers" typescript:",notnull"`
+	// The maximum number of audit logs sent in a request.
+	BatchSize serpent.Int64 `json:"batch_size" typescript:",notnull"`
+	// How often buffered audit logs are sent.
+	FlushInterval serpent.Duration `json:"flush_interval" typescript:",notnull"`
+	// The directory in which audit logs are buffered until they are sent.
+	BufferDirectory serpent.String `json:"buffer_directory" typescript:",notnull"`
+}
+
 const (
 	annotationFormatDuration = "format_duration"
 	annotationEnterpriseKey  = "enterprise"
@@ -897,6 +973,35 @@ when required by your organization's security policy.`,
 			Parent: &deploymentGroupNotifications,
 			YAML:   "webhook",
 		}
+		deploymentGroupNotificationsSlack = serpent.Group{
+			Name:        "Slack",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Slack notifications are sent.",
+			YAML:        "slack",
+		}
+		deploymentGroupNotificationsTeams = serpent.Group{
+			Name:        "Microsoft Teams",
+			Parent:      &deploymentGroupNotifications,
+			Description: "Configure how Microsoft Teams notifications are sent.",
+			YAML:        "teams",
+		}
+		deploymentGroupAuditLogging = serpent.Group{
+			Name:        "Audit Logging",
+			YAML:        "auditLogging",
+			Description: "Protect the audit logs stored in the database, and stream them to external systems.",
+		}
+		deploymentGroupAuditLoggingSyslog = serpent.Group{
+			Name:        "Syslog",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure streaming audit logs to a syslog server using RFC 5424.",
+			YAML:        "syslog",
+		}
+		deploymentGroupAuditLoggingHTTP = serpent.Group{
+			Name:        "HTTP",
+			Parent:      &deploymentGroupAuditLogging,
+			Description: "Configure streaming audit logs to an HTTP endpoint in batches.",
+			YAML:        "http",
+		}
 	)
 
 	httpAddress := serpent.Option{
@@ -2240,6 +2345,14 @@ Write out the current server config as YAML to stdout.`,
 			Value:       &c.ExternalAuthConfigs,
 			Hidden:      true,
 		},
+		{
+			Name:        "Template Policy Rules",
+			Description: "Rules that every imported template version must satisfy, checked against its Terraform source and planned resources. Versions that violate a rule fail to import.",
+			Env:         "CODER_TEMPLATE_POLICY_RULES",
+			Flag:        "template-policy-rules",
+			YAML:        "templatePolicyRules",
+			Value:       &c.TemplatePolicyRules,
+		},
 		{
 			Name:        "Custom wgtunnel Host",
 			Description: `Hostname of HTTPS server that runs https://github.com/coder/wgtunnel. By default, this will pick the best available wgtunnel server hosted by Coder. e.g. "tunnel.example.com".`,
@@ -2326,7 +2439,7 @@ Write out the current server config as YAML to stdout.`,
 		// Notifications Options
 		{
 			Name:        "Notifications: Method",
-			Description: "Which delivery method to use (available options: 'smtp', 'webhook').",
+			Description: "Which delivery method to use (available options: 'smtp', 'webhook', 'slack', 'teams', 'inbox').",
 			Flag:        "notifications-method",
 			Env:         "CODER_NOTIFICATIONS_METHOD",
 			Value:       &c.Notifications.Method,
@@ -2345,6 +2458,52 @@ Write out the current server config as YAML to stdout.`,
 			YAML:        "dispatchTimeout",
 			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
 		},
+		{
+			Name:        "Notifications: Autostop Warning",
+			Description: "How long before a workspace is automatically stopped to warn its owner, both with a notification and with a banner in SSH sessions. Set to 0 to disable the warning.",
+			Flag:        "notifications-autostop-warning",
+			Env:         "CODER_NOTIFICATIONS_AUTOSTOP_WARNING",
+			Value:       &c.Notifications.AutostopWarning,
+			Default:     (30 * time.Minute).String(),
+			Group:       &deploymentGroupNotifications,
+			YAML:        "autostopWarning",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Notifications: Dedupe Window",
+			Description: "Identical notifications (same template, labels and targets) are only delivered to the same user once within this window of the last one that was delivered. Set to a duration such as 24h to enable deduplication.",
+			Flag:        "notifications-dedupe-window",
+			Env:         "CODER_NOTIFICATIONS_DEDUPE_WINDOW",
+			Value:       &c.Notifications.DedupeWindow,
+			Default:     "0s",
+			Group:       &deploymentGroupNotifications,
+			YAML:        "dedupeWindow",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name: "Notifications: Digest Window",
+			Description: "How long to hold back notifications of digest templates (such as workspace dormancy and automatic updates), " +
+				"so that each user receives a single message summarizing all of them per window instead of one message per event. " +
+				"Set to 0 to deliver these notifications individually.",
+			Flag:        "notifications-digest-window",
+			Env:         "CODER_NOTIFICATIONS_DIGEST_WINDOW",
+			Value:       &c.Notifications.DigestWindow,
+			Default:     "0s",
+			Group:       &deploymentGroupNotifications,
+			YAML:        "digestWindow",
+			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name: "Notifications: Per-User Rate Limit",
+			Description: "The maximum number of notifications which will be delivered to a single user per hour, across all replicas. " +
+				"Notifications exceeding this limit stay queued until the user falls below it again. Set to 0 to disable the limit.",
+			Flag:    "notifications-per-user-rate-limit",
+			Env:     "CODER_NOTIFICATIONS_PER_USER_RATE_LIMIT",
+			Value:   &c.Notifications.PerUserRateLimit,
+			Default: "0",
+			Group:   &deploymentGroupNotifications,
+			YAML:    "perUserRateLimit",
+		},
 		{
 			Name:        "Notifications: Email: From Address",
 			Description: "The sender's address to use.",
@@ -2483,6 +2642,33 @@ Write out the current server config as YAML to stdout.`,
 			Group:       &deploymentGroupNotificationsWebhook,
 			YAML:        "endpoint",
 		},
+		{
+			Name:        "Notifications: Slack: Endpoint",
+			Description: "The Slack incoming webhook URL to which to post messages.",
+			Flag:        "notifications-slack-endpoint",
+			Env:         "CODER_NOTIFICATIONS_SLACK_ENDPOINT",
+			Value:       &c.Notifications.Slack.Endpoint,
+			Group:       &deploymentGroupNotificationsSlack,
+			YAML:        "endpoint",
+		},
+		{
+			Name:        "Notifications: Slack: Bot Token",
+			Description: "The Slack bot token used to send direct messages to users by their email address. Takes precedence over the endpoint.",
+			Flag:        "notifications-slack-bot-token",
+			Env:         "CODER_NOTIFICATIONS_SLACK_BOT_TOKEN",
+			Annotations: serpent.Annotations{}.Mark(annotationSecretKey, "true"),
+			Value:       &c.Notifications.Slack.BotToken,
+			Group:       &deploymentGroupNotificationsSlack,
+		},
+		{
+			Name:        "Notifications: Microsoft Teams: Endpoint",
+			Description: "The Microsoft Teams incoming webhook URL to which to post Adaptive Cards.",
+			Flag:        "notifications-teams-endpoint",
+			Env:         "CODER_NOTIFICATIONS_TEAMS_ENDPOINT",
+			Value:       &c.Notifications.Teams.Endpoint,
+			Group:       &deploymentGroupNotificationsTeams,
+			YAML:        "endpoint",
+		},
 		{
 			Name:        "Notifications: Max Send Attempts",
 			Description: "The upper limit of attempts to send a notification.",
@@ -2573,6 +2759,97 @@ Write out the current server config as YAML to stdout.`,
 			Annotations: serpent.Annotations{}.Mark(annotationFormatDuration, "true"),
 			Hidden:      true, // Hidden because most operators should not need to modify this.
 		},
+		{
+			Name:        "Audit Logging: Chain Key",
+			Description: "A secret used to sign the hash chain of the audit logs stored in the database, so changes made directly to the database are detected by 'coder audit verify'. It isn't stored in the database, must be the same on every replica, and must be at least 32 characters long. Audit logs aren't chained when it's unset.",
+			Flag:        "audit-logging-chain-key",
+			Env:         "CODER_AUDIT_LOGGING_CHAIN_KEY",
+			Value:       &c.AuditLogging.ChainKey,
+			Group:       &deploymentGroupAuditLogging,
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true").Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: Address",
+			Description: "The address of a syslog server to stream audit logs to, e.g. tls://syslog.example.com:6514. The scheme must be udp, tcp or tls.",
+			Flag:        "audit-logging-syslog-address",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_ADDRESS",
+			Value:       &c.AuditLogging.Syslog.Address,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "address",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: Format",
+			Description: "The format of the message of each syslog entry. Use cef or leef for SIEMs that parse ArcSight Common Event Format or IBM QRadar Log Event Extended Format.",
+			Flag:        "audit-logging-syslog-format",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_FORMAT",
+			Default:     string(AuditLoggingSyslogFormatJSON),
+			Value:       serpent.EnumOf(&c.AuditLogging.Syslog.Format, AuditLoggingSyslogFormats...),
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "format",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: Syslog: CA File",
+			Description: "The CA certificate file used to verify the syslog server with the tls scheme. The system's certificates are used by default.",
+			Flag:        "audit-logging-syslog-ca-file",
+			Env:         "CODER_AUDIT_LOGGING_SYSLOG_CA_FILE",
+			Value:       &c.AuditLogging.Syslog.CAFile,
+			Group:       &deploymentGroupAuditLoggingSyslog,
+			YAML:        "caFile",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Endpoint",
+			Description: "The endpoint to which batches of audit logs are posted as a JSON array. Failed requests are retried until they succeed.",
+			Flag:        "audit-logging-http-endpoint",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_ENDPOINT",
+			Value:       &c.AuditLogging.HTTP.Endpoint,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "endpoint",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Headers",
+			Description: "Headers added to every request to the endpoint, e.g. 'Authorization: Bearer <token>'.",
+			Flag:        "audit-logging-http-headers",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_HEADERS",
+			Value:       &c.AuditLogging.HTTP.Headers,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true").Mark(annotationSecretKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Batch Size",
+			Description: "The maximum number of audit logs sent in a single request.",
+			Flag:        "audit-logging-http-batch-size",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_BATCH_SIZE",
+			Value:       &c.AuditLogging.HTTP.BatchSize,
+			Default:     "100",
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "batchSize",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Flush Interval",
+			Description: "How often buffered audit logs are sent, unless a full batch is sent sooner.",
+			Flag:        "audit-logging-http-flush-interval",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_FLUSH_INTERVAL",
+			Value:       &c.AuditLogging.HTTP.FlushInterval,
+			Default:     (time.Second * 5).String(),
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "flushInterval",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true").Mark(annotationFormatDuration, "true"),
+		},
+		{
+			Name:        "Audit Logging: HTTP: Buffer Directory",
+			Description: "The directory in which audit logs are buffered until they are sent, so that they are not lost while the endpoint is unavailable or when the server restarts. Defaults to a directory in the cache directory.",
+			Flag:        "audit-logging-http-buffer-directory",
+			Env:         "CODER_AUDIT_LOGGING_HTTP_BUFFER_DIRECTORY",
+			Value:       &c.AuditLogging.HTTP.BufferDirectory,
+			Group:       &deploymentGroupAuditLoggingHTTP,
+			YAML:        "bufferDirectory",
+			Annotations: serpent.Annotations{}.Mark(annotationEnterpriseKey, "true"),
+		},
 	}
 
 	return opts
@@ -2588,6 +2865,70 @@ type LinkConfig struct {
 	Icon   string `json:"icon" yaml:"icon" enums:"bug,chat,docs"`
 }
 
+type TemplatePolicyRuleType string
+
+const (
+	// TemplatePolicyRuleRequiredMetadata requires every resource with an agent
+	// to have `coder_metadata`.
+	TemplatePolicyRuleRequiredMetadata TemplatePolicyRuleType = "required_metadata"
+	// TemplatePolicyRuleForbiddenProviders forbids the use of Terraform
+	// providers.
+	TemplatePolicyRuleForbiddenProviders TemplatePolicyRuleType = "forbidden_providers"
+	// TemplatePolicyRuleAllowedInstanceTypes limits the instance types of
+	// planned resources, e.g. to cap instance sizes.
+	TemplatePolicyRuleAllowedInstanceTypes TemplatePolicyRuleType = "allowed_instance_types"
+	// TemplatePolicyRuleRequiredShutdownScript requires every agent to run a
+	// script when the workspace stops.
+	TemplatePolicyRuleRequiredShutdownScript TemplatePolicyRuleType = "required_shutdown_script"
+)
+
+// TemplatePolicyRule is a check that template versions must pass to be
+// imported.
+type TemplatePolicyRule struct {
+	// Name identifies the rule in diagnostics. Defaults to the type.
+	Name string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Type TemplatePolicyRuleType `json:"type" yaml:"type" enums:"required_metadata,forbidden_providers,allowed_instance_types,required_shutdown_script"`
+	// Providers are the forbidden providers, by local name (e.g. "aws") or
+	// source address (e.g. "hashicorp/aws").
+	Providers []string `json:"providers,omitempty" yaml:"providers,omitempty"`
+	// MetadataKeys are the metadata items that are required. If empty, any
+	// metadata satisfies the rule.
+	MetadataKeys []string `json:"metadata_keys,omitempty" yaml:"metadata_keys,omitempty"`
+	// InstanceTypes are glob patterns, e.g. "t3.*", that instance types must
+	// match.
+	InstanceTypes []string `json:"instance_types,omitempty" yaml:"instance_types,omitempty"`
+}
+
+func (r TemplatePolicyRule) Validate() error {
+	switch r.Type {
+	case TemplatePolicyRuleRequiredMetadata, TemplatePolicyRuleRequiredShutdownScript:
+	case TemplatePolicyRuleForbiddenProviders:
+		if len(r.Providers) == 0 {
+			return xerrors.Errorf("rule %q must list providers", r.DisplayName())
+		}
+	case TemplatePolicyRuleAllowedInstanceTypes:
+		if len(r.InstanceTypes) == 0 {
+			return xerrors.Errorf("rule %q must list instance types", r.DisplayName())
+		}
+		for _, pattern := range r.InstanceTypes {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return xerrors.Errorf("rule %q has invalid instance type pattern %q: %w", r.DisplayName(), pattern, err)
+			}
+		}
+	default:
+		return xerrors.Errorf("unknown template policy rule type %q", r.Type)
+	}
+	return nil
+}
+
+// DisplayName returns the name of the rule, or its type if it has no name.
+func (r TemplatePolicyRule) DisplayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return string(r.Type)
+}
+
 // DeploymentOptionsWithoutSecrets returns a copy of the OptionSet with secret values omitted.
 func DeploymentOptionsWithoutSecrets(set serpent.OptionSet) serpent.OptionSet {
 	cpy := make(serpent.OptionSet, 0, len(set))
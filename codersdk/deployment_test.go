@@ -77,6 +77,15 @@ func TestDeploymentValues_HighlyConfigurable(t *testing.T) {
 		"Provisioner Daemon Pre-shared Key (PSK)": {
 			yaml: true,
 		},
+		"Notifications: Slack: Bot Token": {
+			yaml: true,
+		},
+		"Audit Logging: HTTP: Headers": {
+			yaml: true,
+		},
+		"Audit Logging: Chain Key": {
+			yaml: true,
+		},
 	}
 
 	set := (&codersdk.DeploymentValues{}).Options()
@@ -561,3 +570,15 @@ func TestPremiumSuperSet(t *testing.T) {
 	require.NotContains(t, enterprise.Features(), "", "enterprise should not contain empty string")
 	require.NotContains(t, premium.Features(), "", "premium should not contain empty string")
 }
+
+func TestTemplatePolicyRuleValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, codersdk.TemplatePolicyRule{Type: codersdk.TemplatePolicyRuleRequiredShutdownScript}.Validate())
+	require.Error(t, codersdk.TemplatePolicyRule{Type: "unknown"}.Validate())
+	require.Error(t, codersdk.TemplatePolicyRule{Type: codersdk.TemplatePolicyRuleForbiddenProviders}.Validate())
+	require.Error(t, codersdk.TemplatePolicyRule{
+		Type:          codersdk.TemplatePolicyRuleAllowedInstanceTypes,
+		InstanceTypes: []string{"["},
+	}.Validate())
+}
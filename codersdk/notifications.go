@@ -3,7 +3,12 @@ package codersdk
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type NotificationsSettings struct {
@@ -38,3 +43,328 @@ func (c *Client) PutNotificationsSettings(ctx context.Context, settings Notifica
 	}
 	return nil
 }
+
+// NotificationTemplate describes a notification which can be sent to users.
+type NotificationTemplate struct {
+	ID            uuid.UUID `json:"id" format:"uuid"`
+	Name          string    `json:"name"`
+	TitleTemplate string    `json:"title_template"`
+	BodyTemplate  string    `json:"body_template"`
+	Actions       string    `json:"actions"`
+	Group         string    `json:"group"`
+	// Mandatory templates cannot be disabled by users.
+	Mandatory bool `json:"mandatory"`
+	// Digest templates are delivered as a single message per user when the deployment has a digest window configured.
+	Digest bool `json:"digest"`
+	// Customized is true when an administrator has overridden the template's title, body, or actions. The template
+	// fields always hold the templates in use.
+	Customized bool `json:"customized"`
+}
+
+// NotificationPreference is a user's preference for a single notification template.
+type NotificationPreference struct {
+	NotificationTemplateID uuid.UUID `json:"notification_template_id" format:"uuid"`
+	Disabled               bool      `json:"disabled"`
+	// Method is the delivery method chosen by the user. If empty, the deployment's default method is used.
+	Method    string    `json:"method,omitempty"`
+	UpdatedAt time.Time `json:"updated_at" format:"date-time"`
+}
+
+type UpdateNotificationPreference struct {
+	NotificationTemplateID uuid.UUID `json:"notification_template_id" format:"uuid" validate:"required"`
+	Disabled               bool      `json:"disabled"`
+	// Method is the delivery method to use for the template. An empty value resets it to the deployment's default.
+	Method string `json:"method,omitempty"`
+}
+
+type UpdateUserNotificationPreferences struct {
+	Preferences []UpdateNotificationPreference `json:"preferences" validate:"required"`
+}
+
+type UpdateNotificationTemplateMandatory struct {
+	Mandatory bool `json:"mandatory"`
+}
+
+// UpdateNotificationTemplateRequest overrides the wording of a notification template. Templates use Go's templating
+// syntax, and may refer to the recipient (e.g. {{.UserName}}) and to the labels of the notification
+// (e.g. {{.Labels.name}}). Fields left empty use the template's default.
+type UpdateNotificationTemplateRequest struct {
+	TitleTemplate string `json:"title_template,omitempty"`
+	BodyTemplate  string `json:"body_template,omitempty"`
+	// Actions is a JSON array of objects with "label" and "url" fields, e.g. to link to internal runbooks.
+	Actions string `json:"actions,omitempty"`
+}
+
+type TestNotificationTemplateRequest struct {
+	// Labels are substituted into the template. Labels which the template refers to but which are not given are
+	// filled with sample values.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NotificationTemplatePreview is a notification rendered from a template.
+type NotificationTemplatePreview struct {
+	Title string `json:"title"`
+	// Body is formatted as Markdown.
+	Body    string                       `json:"body"`
+	Actions []NotificationTemplateAction `json:"actions"`
+	// Labels are the labels the template was rendered with, including sample values.
+	Labels map[string]string `json:"labels"`
+}
+
+type NotificationTemplateAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// GetSystemNotificationTemplates returns all the notification templates defined by the system.
+func (c *Client) GetSystemNotificationTemplates(ctx context.Context) ([]NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodGet, "/api/v2/notifications/templates/system", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var templates []NotificationTemplate
+	return templates, json.NewDecoder(res.Body).Decode(&templates)
+}
+
+// UpdateNotificationTemplateMandatory marks a notification template as mandatory, or not. Mandatory templates cannot be
+// disabled by users.
+func (c *Client) UpdateNotificationTemplateMandatory(ctx context.Context, templateID uuid.UUID, req UpdateNotificationTemplateMandatory) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/notifications/templates/%s/mandatory", templateID), req)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// GetNotificationTemplate returns a single notification template.
+func (c *Client) GetNotificationTemplate(ctx context.Context, templateID uuid.UUID) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/notifications/templates/%s", templateID), nil)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// UpdateNotificationTemplate overrides the title, body, and actions of a notification template.
+func (c *Client) UpdateNotificationTemplate(ctx context.Context, templateID uuid.UUID, req UpdateNotificationTemplateRequest) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/notifications/templates/%s", templateID), req)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// ResetNotificationTemplate removes any overrides of a notification template, restoring its default wording.
+func (c *Client) ResetNotificationTemplate(ctx context.Context, templateID uuid.UUID) (NotificationTemplate, error) {
+	res, err := c.Request(ctx, http.MethodDelete, fmt.Sprintf("/api/v2/notifications/templates/%s/override", templateID), nil)
+	if err != nil {
+		return NotificationTemplate{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplate{}, ReadBodyAsError(res)
+	}
+	var template NotificationTemplate
+	return template, json.NewDecoder(res.Body).Decode(&template)
+}
+
+// TestNotificationTemplate renders a notification template against the given labels, and sends the resulting
+// notification to the authenticated user.
+func (c *Client) TestNotificationTemplate(ctx context.Context, templateID uuid.UUID, req TestNotificationTemplateRequest) (NotificationTemplatePreview, error) {
+	res, err := c.Request(ctx, http.MethodPost, fmt.Sprintf("/api/v2/notifications/templates/%s/test", templateID), req)
+	if err != nil {
+		return NotificationTemplatePreview{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return NotificationTemplatePreview{}, ReadBodyAsError(res)
+	}
+	var preview NotificationTemplatePreview
+	return preview, json.NewDecoder(res.Body).Decode(&preview)
+}
+
+// GetUserNotificationPreferences returns the notification preferences of the given user. Templates which the user has
+// never changed are not included.
+func (c *Client) GetUserNotificationPreferences(ctx context.Context, user string) ([]NotificationPreference, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/preferences", user), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var prefs []NotificationPreference
+	return prefs, json.NewDecoder(res.Body).Decode(&prefs)
+}
+
+// UpdateUserNotificationPreferences updates the given user's preferences for the notification templates in the
+// request, and returns all of their preferences.
+func (c *Client) UpdateUserNotificationPreferences(ctx context.Context, user string, req UpdateUserNotificationPreferences) ([]NotificationPreference, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/preferences", user), req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var prefs []NotificationPreference
+	return prefs, json.NewDecoder(res.Body).Decode(&prefs)
+}
+
+// InboxNotification is a notification delivered to a user's in-app inbox.
+type InboxNotification struct {
+	ID     uuid.UUID `json:"id" format:"uuid"`
+	UserID uuid.UUID `json:"user_id" format:"uuid"`
+	Title  string    `json:"title"`
+	// Content is the body of the notification, formatted as Markdown.
+	Content   string                    `json:"content"`
+	Actions   []InboxNotificationAction `json:"actions"`
+	ReadAt    *time.Time                `json:"read_at,omitempty" format:"date-time"`
+	CreatedAt time.Time                 `json:"created_at" format:"date-time"`
+}
+
+type InboxNotificationAction struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+type ListInboxNotificationsRequest struct {
+	// UnreadOnly only returns notifications which have not been marked as read.
+	UnreadOnly bool `json:"unread_only,omitempty"`
+	// CreatedBefore only returns notifications created before the given time, which allows paging through the inbox.
+	CreatedBefore time.Time `json:"created_before,omitempty" format:"date-time"`
+	Limit         int       `json:"limit,omitempty"`
+}
+
+type ListInboxNotificationsResponse struct {
+	Notifications []InboxNotification `json:"notifications"`
+	UnreadCount   int                 `json:"unread_count"`
+}
+
+type UpdateInboxNotificationReadStatusRequest struct {
+	IsRead bool `json:"is_read"`
+}
+
+// ListInboxNotifications returns the notifications in the given user's inbox, newest first.
+func (c *Client) ListInboxNotifications(ctx context.Context, user string, req ListInboxNotificationsRequest) (ListInboxNotificationsResponse, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox", user), nil, func(r *http.Request) {
+		q := r.URL.Query()
+		if req.UnreadOnly {
+			q.Set("unread_only", "true")
+		}
+		if !req.CreatedBefore.IsZero() {
+			q.Set("created_before", req.CreatedBefore.Format(time.RFC3339Nano))
+		}
+		if req.Limit > 0 {
+			q.Set("limit", strconv.Itoa(req.Limit))
+		}
+		r.URL.RawQuery = q.Encode()
+	})
+	if err != nil {
+		return ListInboxNotificationsResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ListInboxNotificationsResponse{}, ReadBodyAsError(res)
+	}
+	var resp ListInboxNotificationsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// UpdateInboxNotificationReadStatus marks an inbox notification as read or unread.
+func (c *Client) UpdateInboxNotificationReadStatus(ctx context.Context, user string, notificationID uuid.UUID, req UpdateInboxNotificationReadStatusRequest) (InboxNotification, error) {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/%s/read-status", user, notificationID), req)
+	if err != nil {
+		return InboxNotification{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return InboxNotification{}, ReadBodyAsError(res)
+	}
+	var notification InboxNotification
+	return notification, json.NewDecoder(res.Body).Decode(&notification)
+}
+
+// MarkAllInboxNotificationsAsRead marks all the notifications in the given user's inbox as read.
+func (c *Client) MarkAllInboxNotificationsAsRead(ctx context.Context, user string) error {
+	res, err := c.Request(ctx, http.MethodPut, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/mark-all-read", user), nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// WatchInboxNotifications streams the notifications delivered to the given user's inbox as they arrive. The channel is
+// closed when the context is canceled or the stream ends.
+func (c *Client) WatchInboxNotifications(ctx context.Context, user string) (<-chan InboxNotification, error) {
+	//nolint:bodyclose
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/users/%s/notifications/inbox/watch", user), nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	nextEvent := ServerSentEventReader(ctx, res.Body)
+
+	nc := make(chan InboxNotification, 256)
+	go func() {
+		defer close(nc)
+		defer res.Body.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				sse, err := nextEvent()
+				if err != nil {
+					return
+				}
+				if sse.Type != ServerSentEventTypeData {
+					continue
+				}
+				b, ok := sse.Data.([]byte)
+				if !ok {
+					return
+				}
+				var notification InboxNotification
+				if err := json.Unmarshal(b, &notification); err != nil {
+					return
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case nc <- notification:
+				}
+			}
+		}
+	}()
+
+	return nc, nil
+}
@@ -9,11 +9,25 @@ import (
 	"github.com/google/uuid"
 )
 
+type OAuth2ProviderAppClientType string
+
+const (
+	// OAuth2ProviderAppClientTypeConfidential apps authenticate with a client
+	// secret when exchanging tokens.
+	OAuth2ProviderAppClientTypeConfidential OAuth2ProviderAppClientType = "confidential"
+	// OAuth2ProviderAppClientTypePublic apps have no client secret and must
+	// prove possession of the authorization code with PKCE instead.
+	OAuth2ProviderAppClientTypePublic OAuth2ProviderAppClientType = "public"
+)
+
 type OAuth2ProviderApp struct {
 	ID          uuid.UUID `json:"id" format:"uuid"`
 	Name        string    `json:"name"`
 	CallbackURL string    `json:"callback_url"`
 	Icon        string    `json:"icon"`
+	// ClientType is "public" for apps that cannot keep a secret, like
+	// native and command-line tools. Public apps must use PKCE.
+	ClientType OAuth2ProviderAppClientType `json:"client_type"`
 
 	// Endpoints are included in the app response for easier discovery. The OAuth2
 	// spec does not have a defined place to find these (for comparison, OIDC has
@@ -70,9 +84,10 @@ func (c *Client) OAuth2ProviderApp(ctx context.Context, id uuid.UUID) (OAuth2Pro
 }
 
 type PostOAuth2ProviderAppRequest struct {
-	Name        string `json:"name" validate:"required,oauth2_app_name"`
-	CallbackURL string `json:"callback_url" validate:"required,http_url"`
-	Icon        string `json:"icon" validate:"omitempty"`
+	Name        string                      `json:"name" validate:"required,oauth2_app_name"`
+	CallbackURL string                      `json:"callback_url" validate:"required,http_url"`
+	Icon        string                      `json:"icon" validate:"omitempty"`
+	ClientType  OAuth2ProviderAppClientType `json:"client_type,omitempty" validate:"omitempty,oneof=confidential public"`
 }
 
 // PostOAuth2ProviderApp adds an application that can authenticate using Coder
@@ -91,9 +106,10 @@ func (c *Client) PostOAuth2ProviderApp(ctx context.Context, app PostOAuth2Provid
 }
 
 type PutOAuth2ProviderAppRequest struct {
-	Name        string `json:"name" validate:"required,oauth2_app_name"`
-	CallbackURL string `json:"callback_url" validate:"required,http_url"`
-	Icon        string `json:"icon" validate:"omitempty"`
+	Name        string                      `json:"name" validate:"required,oauth2_app_name"`
+	CallbackURL string                      `json:"callback_url" validate:"required,http_url"`
+	Icon        string                      `json:"icon" validate:"omitempty"`
+	ClientType  OAuth2ProviderAppClientType `json:"client_type,omitempty" validate:"omitempty,oneof=confidential public"`
 }
 
 // PutOAuth2ProviderApp updates an application that can authenticate using Coder
@@ -185,11 +201,12 @@ type OAuth2ProviderGrantType string
 const (
 	OAuth2ProviderGrantTypeAuthorizationCode OAuth2ProviderGrantType = "authorization_code"
 	OAuth2ProviderGrantTypeRefreshToken      OAuth2ProviderGrantType = "refresh_token"
+	OAuth2ProviderGrantTypeDeviceCode        OAuth2ProviderGrantType = "urn:ietf:params:oauth:grant-type:device_code"
 )
 
 func (e OAuth2ProviderGrantType) Valid() bool {
 	switch e {
-	case OAuth2ProviderGrantTypeAuthorizationCode, OAuth2ProviderGrantTypeRefreshToken:
+	case OAuth2ProviderGrantTypeAuthorizationCode, OAuth2ProviderGrantTypeRefreshToken, OAuth2ProviderGrantTypeDeviceCode:
 		return true
 	}
 	return false
@@ -210,6 +227,62 @@ func (e OAuth2ProviderResponseType) Valid() bool {
 	return false
 }
 
+// OAuth2DeviceAuthorizationResponse is returned to a device that starts the
+// device authorization grant from RFC 8628. The user enters the user code at
+// the verification URI while the device polls the token endpoint with the
+// device code.
+type OAuth2DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// ExpiresIn is the lifetime of the device code in seconds.
+	ExpiresIn int64 `json:"expires_in"`
+	// Interval is the minimum number of seconds between polls.
+	Interval int64 `json:"interval"`
+}
+
+// OAuth2ClientRegistrationRequest registers an OAuth2 application on behalf of
+// the authenticated user, following RFC 7591.
+type OAuth2ClientRegistrationRequest struct {
+	ClientName   string   `json:"client_name" validate:"required,oauth2_app_name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	LogoURI      string   `json:"logo_uri,omitempty"`
+	// GrantTypes defaults to authorization_code and refresh_token. The client
+	// can only use the grant types it registered with.
+	GrantTypes []OAuth2ProviderGrantType `json:"grant_types,omitempty"`
+	// TokenEndpointAuthMethod is "none" for public clients, or
+	// "client_secret_post" (the default) for confidential clients.
+	TokenEndpointAuthMethod string `json:"token_endpoint_auth_method,omitempty"`
+}
+
+type OAuth2ClientRegistrationResponse struct {
+	ClientID                string                    `json:"client_id" format:"uuid"`
+	ClientSecret            string                    `json:"client_secret,omitempty"`
+	ClientIDIssuedAt        int64                     `json:"client_id_issued_at"`
+	ClientSecretExpiresAt   int64                     `json:"client_secret_expires_at"`
+	ClientName              string                    `json:"client_name"`
+	RedirectURIs            []string                  `json:"redirect_uris"`
+	LogoURI                 string                    `json:"logo_uri,omitempty"`
+	GrantTypes              []OAuth2ProviderGrantType `json:"grant_types"`
+	TokenEndpointAuthMethod string                    `json:"token_endpoint_auth_method"`
+}
+
+// RegisterOAuth2Client dynamically registers an OAuth2 application. The client
+// secret of a confidential client is only revealed in the response.
+func (c *Client) RegisterOAuth2Client(ctx context.Context, req OAuth2ClientRegistrationRequest) (OAuth2ClientRegistrationResponse, error) {
+	res, err := c.Request(ctx, http.MethodPost, "/oauth2/register", req)
+	if err != nil {
+		return OAuth2ClientRegistrationResponse{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return OAuth2ClientRegistrationResponse{}, ReadBodyAsError(res)
+	}
+	var resp OAuth2ClientRegistrationResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
 // RevokeOAuth2ProviderApp completely revokes an app's access for the
 // authenticated user.
 func (c *Client) RevokeOAuth2ProviderApp(ctx context.Context, appID uuid.UUID) error {
@@ -74,6 +74,7 @@ type JobErrorCode string
 
 const (
 	RequiredTemplateVariables JobErrorCode = "REQUIRED_TEMPLATE_VARIABLES"
+	TemplatePolicyViolation   JobErrorCode = "TEMPLATE_POLICY_VIOLATION"
 )
 
 // JobIsMissingParameterErrorCode returns whether the error is a missing parameter error.
@@ -90,7 +91,7 @@ type ProvisionerJob struct {
 	CompletedAt   *time.Time           `json:"completed_at,omitempty" format:"date-time"`
 	CanceledAt    *time.Time           `json:"canceled_at,omitempty" format:"date-time"`
 	Error         string               `json:"error,omitempty"`
-	ErrorCode     JobErrorCode         `json:"error_code,omitempty" enums:"REQUIRED_TEMPLATE_VARIABLES"`
+	ErrorCode     JobErrorCode         `json:"error_code,omitempty" enums:"REQUIRED_TEMPLATE_VARIABLES,TEMPLATE_POLICY_VIOLATION"`
 	Status        ProvisionerJobStatus `json:"status" enums:"pending,running,succeeded,canceling,canceled,failed"`
 	WorkerID      *uuid.UUID           `json:"worker_id,omitempty" format:"uuid"`
 	FileID        uuid.UUID            `json:"file_id" format:"uuid"`
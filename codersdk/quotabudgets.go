@@ -0,0 +1,133 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+type QuotaBudgetPeriod string
+
+const (
+	QuotaBudgetPeriodDay   QuotaBudgetPeriod = "day"
+	QuotaBudgetPeriodMonth QuotaBudgetPeriod = "month"
+)
+
+// QuotaBudget limits the cost accrued by running workspaces within a day or
+// month. A budget applies either to a single user, or to all members of a
+// group combined. Cost accrues at the daily cost of a workspace's latest build,
+// prorated over the time it was running.
+type QuotaBudget struct {
+	ID             uuid.UUID         `json:"id" format:"uuid"`
+	OrganizationID uuid.UUID         `json:"organization_id" format:"uuid"`
+	GroupID        *uuid.UUID        `json:"group_id,omitempty" format:"uuid"`
+	UserID         *uuid.UUID        `json:"user_id,omitempty" format:"uuid"`
+	Period         QuotaBudgetPeriod `json:"period" enums:"day,month"`
+	// SoftLimit is the accrued cost at which users are notified. 0 disables
+	// the soft limit.
+	SoftLimit int64 `json:"soft_limit"`
+	// HardLimit is the accrued cost at which workspace starts are rejected. 0
+	// disables the hard limit.
+	HardLimit int64 `json:"hard_limit"`
+	// StopWorkspaces stops running workspaces once the hard limit is reached.
+	StopWorkspaces bool      `json:"stop_workspaces"`
+	CreatedAt      time.Time `json:"created_at" format:"date-time"`
+	UpdatedAt      time.Time `json:"updated_at" format:"date-time"`
+}
+
+// QuotaBudgetStatus is a budget along with the cost accrued against it in the
+// current period.
+type QuotaBudgetStatus struct {
+	QuotaBudget
+	PeriodStart time.Time `json:"period_start" format:"date-time"`
+	PeriodEnd   time.Time `json:"period_end" format:"date-time"`
+	Spend       int64     `json:"spend"`
+}
+
+type CreateQuotaBudgetRequest struct {
+	// Exactly one of GroupID and UserID must be set.
+	GroupID        *uuid.UUID        `json:"group_id,omitempty" format:"uuid"`
+	UserID         *uuid.UUID        `json:"user_id,omitempty" format:"uuid"`
+	Period         QuotaBudgetPeriod `json:"period" validate:"required,oneof=day month" enums:"day,month"`
+	SoftLimit      int64             `json:"soft_limit" validate:"min=0"`
+	HardLimit      int64             `json:"hard_limit" validate:"min=0"`
+	StopWorkspaces bool              `json:"stop_workspaces"`
+}
+
+type UpdateQuotaBudgetRequest struct {
+	SoftLimit      *int64 `json:"soft_limit,omitempty" validate:"omitempty,min=0"`
+	HardLimit      *int64 `json:"hard_limit,omitempty" validate:"omitempty,min=0"`
+	StopWorkspaces *bool  `json:"stop_workspaces,omitempty"`
+}
+
+func (c *Client) QuotaBudgets(ctx context.Context, orgID uuid.UUID) ([]QuotaBudgetStatus, error) {
+	res, err := c.Request(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v2/organizations/%s/quota-budgets", orgID.String()),
+		nil,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var budgets []QuotaBudgetStatus
+	return budgets, json.NewDecoder(res.Body).Decode(&budgets)
+}
+
+func (c *Client) CreateQuotaBudget(ctx context.Context, orgID uuid.UUID, req CreateQuotaBudgetRequest) (QuotaBudget, error) {
+	res, err := c.Request(ctx, http.MethodPost,
+		fmt.Sprintf("/api/v2/organizations/%s/quota-budgets", orgID.String()),
+		req,
+	)
+	if err != nil {
+		return QuotaBudget{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return QuotaBudget{}, ReadBodyAsError(res)
+	}
+	var budget QuotaBudget
+	return budget, json.NewDecoder(res.Body).Decode(&budget)
+}
+
+func (c *Client) UpdateQuotaBudget(ctx context.Context, orgID, budgetID uuid.UUID, req UpdateQuotaBudgetRequest) (QuotaBudget, error) {
+	res, err := c.Request(ctx, http.MethodPatch,
+		fmt.Sprintf("/api/v2/organizations/%s/quota-budgets/%s", orgID.String(), budgetID.String()),
+		req,
+	)
+	if err != nil {
+		return QuotaBudget{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return QuotaBudget{}, ReadBodyAsError(res)
+	}
+	var budget QuotaBudget
+	return budget, json.NewDecoder(res.Body).Decode(&budget)
+}
+
+func (c *Client) DeleteQuotaBudget(ctx context.Context, orgID, budgetID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete,
+		fmt.Sprintf("/api/v2/organizations/%s/quota-budgets/%s", orgID.String(), budgetID.String()),
+		nil,
+	)
+	if err != nil {
+		return xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
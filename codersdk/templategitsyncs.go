@@ -0,0 +1,129 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// TemplateGitSync links a template to a directory in a Git repository. New
+// commits on the branch create template versions.
+type TemplateGitSync struct {
+	TemplateID    uuid.UUID `json:"template_id" format:"uuid"`
+	RepositoryURL string    `json:"repository_url"`
+	Branch        string    `json:"branch"`
+	Directory     string    `json:"directory"`
+	// ExternalAuthProviderID is the external auth provider whose token, of
+	// the user that configured the sync, is used to fetch the repository.
+	ExternalAuthProviderID string `json:"external_auth_provider_id"`
+	// AutoActivate promotes synced versions to the active version when
+	// their import succeeds.
+	AutoActivate bool `json:"auto_activate"`
+	// WebhookURL receives push events from the Git provider to sync
+	// without waiting for the next poll.
+	WebhookURL string `json:"webhook_url"`
+	// WebhookSecret signs or authenticates push events. It is only
+	// returned when the sync is created or updated.
+	WebhookSecret         string     `json:"webhook_secret,omitempty"`
+	CreatedBy             uuid.UUID  `json:"created_by" format:"uuid"`
+	LastCommitSHA         string     `json:"last_commit_sha"`
+	LastTemplateVersionID *uuid.UUID `json:"last_template_version_id,omitempty" format:"uuid"`
+	LastSyncedAt          *time.Time `json:"last_synced_at,omitempty" format:"date-time"`
+	LastError             string     `json:"last_error,omitempty"`
+	CreatedAt             time.Time  `json:"created_at" format:"date-time"`
+	UpdatedAt             time.Time  `json:"updated_at" format:"date-time"`
+}
+
+// UpdateTemplateGitSyncRequest links a template to a Git repository, or
+// changes the repository it is linked to.
+type UpdateTemplateGitSyncRequest struct {
+	// RepositoryURL is the HTTP or HTTPS clone URL of the repository. It must
+	// be HTTPS when an external auth provider is used.
+	RepositoryURL string `json:"repository_url" validate:"required"`
+	// Branch defaults to "main".
+	Branch string `json:"branch,omitempty"`
+	// Directory is the path of the template in the repository. Defaults to
+	// the root of the repository.
+	Directory              string `json:"directory,omitempty"`
+	ExternalAuthProviderID string `json:"external_auth_provider_id,omitempty"`
+	AutoActivate           bool   `json:"auto_activate,omitempty"`
+}
+
+// TemplateGitSync returns the Git repository a template is synced from.
+func (c *Client) TemplateGitSync(ctx context.Context, templateID uuid.UUID) (TemplateGitSync, error) {
+	res, err := c.Request(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v2/templates/%s/git-sync", templateID.String()),
+		nil,
+	)
+	if err != nil {
+		return TemplateGitSync{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return TemplateGitSync{}, ReadBodyAsError(res)
+	}
+	var sync TemplateGitSync
+	return sync, json.NewDecoder(res.Body).Decode(&sync)
+}
+
+// UpdateTemplateGitSync links a template to a Git repository. The response
+// includes the webhook secret.
+func (c *Client) UpdateTemplateGitSync(ctx context.Context, templateID uuid.UUID, req UpdateTemplateGitSyncRequest) (TemplateGitSync, error) {
+	res, err := c.Request(ctx, http.MethodPut,
+		fmt.Sprintf("/api/v2/templates/%s/git-sync", templateID.String()),
+		req,
+	)
+	if err != nil {
+		return TemplateGitSync{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return TemplateGitSync{}, ReadBodyAsError(res)
+	}
+	var sync TemplateGitSync
+	return sync, json.NewDecoder(res.Body).Decode(&sync)
+}
+
+// DeleteTemplateGitSync unlinks a template from its Git repository. Existing
+// versions are kept.
+func (c *Client) DeleteTemplateGitSync(ctx context.Context, templateID uuid.UUID) error {
+	res, err := c.Request(ctx, http.MethodDelete,
+		fmt.Sprintf("/api/v2/templates/%s/git-sync", templateID.String()),
+		nil,
+	)
+	if err != nil {
+		return xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
+// SyncTemplateGitSync syncs a template from its Git repository immediately.
+// A template version is created if the branch has new commits.
+func (c *Client) SyncTemplateGitSync(ctx context.Context, templateID uuid.UUID) (TemplateGitSync, error) {
+	res, err := c.Request(ctx, http.MethodPost,
+		fmt.Sprintf("/api/v2/templates/%s/git-sync/sync", templateID.String()),
+		nil,
+	)
+	if err != nil {
+		return TemplateGitSync{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return TemplateGitSync{}, ReadBodyAsError(res)
+	}
+	var sync TemplateGitSync
+	return sync, json.NewDecoder(res.Body).Decode(&sync)
+}
@@ -0,0 +1,69 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// TemplateReleaseChannel is a named pointer to a version of a template, such
+// as "canary" or "stable". Workspaces that follow a channel are updated to the
+// channel's version instead of the active version of the template.
+type TemplateReleaseChannel struct {
+	ID                  uuid.UUID `json:"id" format:"uuid"`
+	TemplateID          uuid.UUID `json:"template_id" format:"uuid"`
+	Name                string    `json:"name"`
+	TemplateVersionID   uuid.UUID `json:"template_version_id" format:"uuid"`
+	TemplateVersionName string    `json:"template_version_name"`
+	CreatedAt           time.Time `json:"created_at" format:"date-time"`
+	UpdatedAt           time.Time `json:"updated_at" format:"date-time"`
+}
+
+// PromoteTemplateVersionRequest points a release channel at a template
+// version. The channel is created if it does not exist.
+type PromoteTemplateVersionRequest struct {
+	TemplateVersionID uuid.UUID `json:"template_version_id" validate:"required" format:"uuid"`
+}
+
+// TemplateReleaseChannels lists the release channels of a template.
+func (c *Client) TemplateReleaseChannels(ctx context.Context, templateID uuid.UUID) ([]TemplateReleaseChannel, error) {
+	res, err := c.Request(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v2/templates/%s/channels", templateID.String()),
+		nil,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var channels []TemplateReleaseChannel
+	return channels, json.NewDecoder(res.Body).Decode(&channels)
+}
+
+// PromoteTemplateVersion points the named release channel of a template at a
+// version. Workspaces following the channel are updated on their next start
+// if they have automatic updates enabled.
+func (c *Client) PromoteTemplateVersion(ctx context.Context, templateID uuid.UUID, channel string, req PromoteTemplateVersionRequest) (TemplateReleaseChannel, error) {
+	res, err := c.Request(ctx, http.MethodPut,
+		fmt.Sprintf("/api/v2/templates/%s/channels/%s", templateID.String(), channel),
+		req,
+	)
+	if err != nil {
+		return TemplateReleaseChannel{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return TemplateReleaseChannel{}, ReadBodyAsError(res)
+	}
+	var releaseChannel TemplateReleaseChannel
+	return releaseChannel, json.NewDecoder(res.Body).Decode(&releaseChannel)
+}
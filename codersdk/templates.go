@@ -57,6 +57,13 @@ type Template struct {
 	TimeTilDormantMillis           int64 `json:"time_til_dormant_ms"`
 	TimeTilDormantAutoDeleteMillis int64 `json:"time_til_dormant_autodelete_ms"`
 
+	// IdleTimeoutMillis and IdleCPUThreshold are enterprise-only. Running
+	// workspaces with no open sessions and CPU usage below IdleCPUThreshold
+	// percent for IdleTimeoutMillis are stopped. A timeout of 0 disables idle
+	// stop, and a threshold of 0 ignores CPU usage.
+	IdleTimeoutMillis int64 `json:"idle_timeout_ms"`
+	IdleCPUThreshold  int32 `json:"idle_cpu_threshold"`
+
 	// RequireActiveVersion mandates that workspaces are built with the active
 	// template version.
 	RequireActiveVersion bool                         `json:"require_active_version"`
@@ -232,6 +239,11 @@ type UpdateTemplateMeta struct {
 	// from the template. This is useful for preventing dormant workspaces being immediately
 	// deleted when updating the dormant_ttl field to a new, shorter value.
 	UpdateWorkspaceDormantAt bool `json:"update_workspace_dormant_at"`
+	// IdleTimeoutMillis and IdleCPUThreshold can only be set if your license
+	// includes the advanced template scheduling feature. They are left
+	// unchanged if not provided, and a timeout of 0 disables idle stop.
+	IdleTimeoutMillis *int64 `json:"idle_timeout_ms,omitempty"`
+	IdleCPUThreshold  *int32 `json:"idle_cpu_threshold,omitempty" validate:"omitempty,min=0,max=100"`
 	// RequireActiveVersion mandates workspaces built using this template
 	// use the active version of the template. This option has no
 	// effect on template admins.
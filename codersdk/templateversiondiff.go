@@ -0,0 +1,97 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+type TemplateVersionDiffStatus string
+
+const (
+	TemplateVersionDiffStatusAdded    TemplateVersionDiffStatus = "added"
+	TemplateVersionDiffStatusRemoved  TemplateVersionDiffStatus = "removed"
+	TemplateVersionDiffStatusModified TemplateVersionDiffStatus = "modified"
+)
+
+// TemplateVersionDiff describes the changes between two template versions.
+// Only entries that differ are included.
+type TemplateVersionDiff struct {
+	FromVersionID uuid.UUID                      `json:"from_version_id" format:"uuid"`
+	ToVersionID   uuid.UUID                      `json:"to_version_id" format:"uuid"`
+	Files         []TemplateVersionFileDiff      `json:"files"`
+	Parameters    []TemplateVersionParameterDiff `json:"parameters"`
+	Variables     []TemplateVersionVariableDiff  `json:"variables"`
+	Resources     []TemplateVersionResourceDiff  `json:"resources"`
+}
+
+// Empty returns true if the versions have no differences.
+func (d TemplateVersionDiff) Empty() bool {
+	return len(d.Files) == 0 && len(d.Parameters) == 0 && len(d.Variables) == 0 && len(d.Resources) == 0
+}
+
+// TemplateVersionFileDiff is a file of the template source archive that was
+// added, removed or modified.
+type TemplateVersionFileDiff struct {
+	Path   string                    `json:"path"`
+	Status TemplateVersionDiffStatus `json:"status" enums:"added,removed,modified"`
+	// Binary is true if either side of the file is not text. Binary files do
+	// not have a diff.
+	Binary bool `json:"binary"`
+	// Diff is a unified diff of the file contents.
+	Diff string `json:"diff,omitempty"`
+}
+
+type TemplateVersionParameterDiff struct {
+	Name   string                    `json:"name"`
+	Status TemplateVersionDiffStatus `json:"status" enums:"added,removed,modified"`
+	// Changes describes what changed for modified parameters, e.g.
+	// "default_value".
+	Changes []string                  `json:"changes,omitempty"`
+	From    *TemplateVersionParameter `json:"from,omitempty"`
+	To      *TemplateVersionParameter `json:"to,omitempty"`
+}
+
+// TemplateVersionVariableDiff describes a changed Terraform variable. The
+// values of sensitive variables are redacted.
+type TemplateVersionVariableDiff struct {
+	Name    string                    `json:"name"`
+	Status  TemplateVersionDiffStatus `json:"status" enums:"added,removed,modified"`
+	Changes []string                  `json:"changes,omitempty"`
+	From    *TemplateVersionVariable  `json:"from,omitempty"`
+	To      *TemplateVersionVariable  `json:"to,omitempty"`
+}
+
+// TemplateVersionResourceDiff describes a change in the resources planned by
+// the template import of each version. Resources are matched by type and
+// name.
+type TemplateVersionResourceDiff struct {
+	Type    string                    `json:"type"`
+	Name    string                    `json:"name"`
+	Status  TemplateVersionDiffStatus `json:"status" enums:"added,removed,modified"`
+	Changes []string                  `json:"changes,omitempty"`
+}
+
+// TemplateVersionDiff compares two template versions. The versions may belong
+// to different templates, but reading the source archives requires
+// permission to update the templates.
+func (c *Client) TemplateVersionDiff(ctx context.Context, from, to uuid.UUID) (TemplateVersionDiff, error) {
+	res, err := c.Request(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v2/templateversions/%s/diff?from=%s", to.String(), from.String()),
+		nil,
+	)
+	if err != nil {
+		return TemplateVersionDiff{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return TemplateVersionDiff{}, ReadBodyAsError(res)
+	}
+	var diff TemplateVersionDiff
+	return diff, json.NewDecoder(res.Body).Decode(&diff)
+}
@@ -4,18 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
 
 	"github.com/google/uuid"
+	"nhooyr.io/websocket"
 )
 
 const (
-	WorkspaceAgentPortShareLevelOwner         WorkspaceAgentPortShareLevel = "owner"
+	WorkspaceAgentPortShareLevelOwner WorkspaceAgentPortShareLevel = "owner"
+	// WorkspaceAgentPortShareLevelAllowlist shares the port with the users and
+	// groups listed on the share.
+	WorkspaceAgentPortShareLevelAllowlist WorkspaceAgentPortShareLevel = "allowlist"
+	// WorkspaceAgentPortShareLevelOrganization shares the port with members of
+	// the workspace's organization.
+	WorkspaceAgentPortShareLevelOrganization  WorkspaceAgentPortShareLevel = "organization"
 	WorkspaceAgentPortShareLevelAuthenticated WorkspaceAgentPortShareLevel = "authenticated"
 	WorkspaceAgentPortShareLevelPublic        WorkspaceAgentPortShareLevel = "public"
 
 	WorkspaceAgentPortShareProtocolHTTP  WorkspaceAgentPortShareProtocol = "http"
 	WorkspaceAgentPortShareProtocolHTTPS WorkspaceAgentPortShareProtocol = "https"
+	// WorkspaceAgentPortShareProtocolH2C proxies requests to the port over
+	// HTTP/2 without TLS, for gRPC services.
+	WorkspaceAgentPortShareProtocolH2C WorkspaceAgentPortShareProtocol = "h2c"
+	// WorkspaceAgentPortShareProtocolTCP tunnels raw TCP connections to the
+	// port over a WebSocket. See DialWorkspaceAgentPortShareTCP.
+	WorkspaceAgentPortShareProtocolTCP WorkspaceAgentPortShareProtocol = "tcp"
 )
 
 type (
@@ -24,18 +40,24 @@ type (
 	UpsertWorkspaceAgentPortShareRequest struct {
 		AgentName  string                          `json:"agent_name"`
 		Port       int32                           `json:"port"`
-		ShareLevel WorkspaceAgentPortShareLevel    `json:"share_level" enums:"owner,authenticated,public"`
-		Protocol   WorkspaceAgentPortShareProtocol `json:"protocol" enums:"http,https"`
+		ShareLevel WorkspaceAgentPortShareLevel    `json:"share_level" enums:"owner,allowlist,organization,authenticated,public"`
+		Protocol   WorkspaceAgentPortShareProtocol `json:"protocol" enums:"http,https,h2c,tcp"`
+		// AllowedUserIDs and AllowedGroupIDs are the users and groups that can
+		// access the port. They must only be set with the "allowlist" level.
+		AllowedUserIDs  []uuid.UUID `json:"allowed_user_ids,omitempty" format:"uuid"`
+		AllowedGroupIDs []uuid.UUID `json:"allowed_group_ids,omitempty" format:"uuid"`
 	}
 	WorkspaceAgentPortShares struct {
 		Shares []WorkspaceAgentPortShare `json:"shares"`
 	}
 	WorkspaceAgentPortShare struct {
-		WorkspaceID uuid.UUID                       `json:"workspace_id" format:"uuid"`
-		AgentName   string                          `json:"agent_name"`
-		Port        int32                           `json:"port"`
-		ShareLevel  WorkspaceAgentPortShareLevel    `json:"share_level" enums:"owner,authenticated,public"`
-		Protocol    WorkspaceAgentPortShareProtocol `json:"protocol" enums:"http,https"`
+		WorkspaceID     uuid.UUID                       `json:"workspace_id" format:"uuid"`
+		AgentName       string                          `json:"agent_name"`
+		Port            int32                           `json:"port"`
+		ShareLevel      WorkspaceAgentPortShareLevel    `json:"share_level" enums:"owner,allowlist,organization,authenticated,public"`
+		Protocol        WorkspaceAgentPortShareProtocol `json:"protocol" enums:"http,https,h2c,tcp"`
+		AllowedUserIDs  []uuid.UUID                     `json:"allowed_user_ids" format:"uuid"`
+		AllowedGroupIDs []uuid.UUID                     `json:"allowed_group_ids" format:"uuid"`
 	}
 	DeleteWorkspaceAgentPortShareRequest struct {
 		AgentName string `json:"agent_name"`
@@ -43,20 +65,44 @@ type (
 	}
 )
 
+// workspaceAgentPortShareLevels are the port sharing levels, from the
+// narrowest to the widest.
+var workspaceAgentPortShareLevels = []WorkspaceAgentPortShareLevel{
+	WorkspaceAgentPortShareLevelOwner,
+	WorkspaceAgentPortShareLevelAllowlist,
+	WorkspaceAgentPortShareLevelOrganization,
+	WorkspaceAgentPortShareLevelAuthenticated,
+	WorkspaceAgentPortShareLevelPublic,
+}
+
 func (l WorkspaceAgentPortShareLevel) ValidMaxLevel() bool {
-	return l == WorkspaceAgentPortShareLevelOwner ||
-		l == WorkspaceAgentPortShareLevelAuthenticated ||
-		l == WorkspaceAgentPortShareLevelPublic
+	return slices.Contains(workspaceAgentPortShareLevels, l)
 }
 
 func (l WorkspaceAgentPortShareLevel) ValidPortShareLevel() bool {
-	return l == WorkspaceAgentPortShareLevelAuthenticated ||
-		l == WorkspaceAgentPortShareLevelPublic
+	return l.ValidMaxLevel() && l != WorkspaceAgentPortShareLevelOwner
+}
+
+// Compare returns a negative number if l is narrower than other, zero if they
+// are the same and a positive number if l is wider than other.
+func (l WorkspaceAgentPortShareLevel) Compare(other WorkspaceAgentPortShareLevel) int {
+	return slices.Index(workspaceAgentPortShareLevels, l) - slices.Index(workspaceAgentPortShareLevels, other)
+}
+
+// WiderLevels returns the port sharing levels which are wider than l.
+func (l WorkspaceAgentPortShareLevel) WiderLevels() []WorkspaceAgentPortShareLevel {
+	i := slices.Index(workspaceAgentPortShareLevels, l)
+	if i < 0 {
+		return nil
+	}
+	return slices.Clone(workspaceAgentPortShareLevels[i+1:])
 }
 
 func (p WorkspaceAgentPortShareProtocol) ValidPortProtocol() bool {
 	return p == WorkspaceAgentPortShareProtocolHTTP ||
-		p == WorkspaceAgentPortShareProtocolHTTPS
+		p == WorkspaceAgentPortShareProtocolHTTPS ||
+		p == WorkspaceAgentPortShareProtocolH2C ||
+		p == WorkspaceAgentPortShareProtocolTCP
 }
 
 func (c *Client) GetWorkspaceAgentPortShares(ctx context.Context, workspaceID uuid.UUID) (WorkspaceAgentPortShares, error) {
@@ -98,3 +144,26 @@ func (c *Client) DeleteWorkspaceAgentPortShare(ctx context.Context, workspaceID
 	}
 	return nil
 }
+
+// DialWorkspaceAgentPortShareTCP connects to a port shared with the "tcp"
+// protocol. portURL is the subdomain app URL of the port, e.g.
+// https://5432--agent--workspace--user.apps.example.com. The connection is
+// tunneled over a WebSocket, so it works through the same proxies as HTTP.
+func (c *Client) DialWorkspaceAgentPortShareTCP(ctx context.Context, portURL *url.URL) (net.Conn, error) {
+	headers := http.Header{}
+	headers.Set(SessionTokenHeader, c.SessionToken())
+	conn, res, err := websocket.Dial(ctx, portURL.String(), &websocket.DialOptions{
+		HTTPClient:      c.HTTPClient,
+		HTTPHeader:      headers,
+		CompressionMode: websocket.CompressionDisabled,
+	})
+	if err != nil {
+		if res == nil {
+			return nil, err
+		}
+		return nil, ReadBodyAsError(res)
+	}
+	// Use background context because the caller should close the conn.
+	_, netConn := WebsocketNetConn(context.Background(), conn, websocket.MessageBinary)
+	return netConn, nil
+}
@@ -46,6 +46,10 @@ const (
 	// "autostop" is used when a build to stop a workspace is triggered by Autostop.
 	// The initiator id/username in this case is the workspace owner and can be ignored.
 	BuildReasonAutostop BuildReason = "autostop"
+	// "idle" is used when a build to stop a workspace is triggered because it
+	// has been idle for longer than its template's idle timeout.
+	// The initiator id/username in this case is the workspace owner and can be ignored.
+	BuildReasonIdle BuildReason = "idle"
 )
 
 // WorkspaceBuild is an at-point representation of a workspace state.
@@ -66,7 +70,7 @@ type WorkspaceBuild struct {
 	InitiatorID             uuid.UUID           `json:"initiator_id" format:"uuid"`
 	InitiatorUsername       string              `json:"initiator_name"`
 	Job                     ProvisionerJob      `json:"job"`
-	Reason                  BuildReason         `db:"reason" json:"reason" enums:"initiator,autostart,autostop"`
+	Reason                  BuildReason         `db:"reason" json:"reason" enums:"initiator,autostart,autostop,idle"`
 	Resources               []WorkspaceResource `json:"resources"`
 	Deadline                NullTime            `json:"deadline,omitempty" format:"date-time"`
 	MaxDeadline             NullTime            `json:"max_deadline,omitempty" format:"date-time"`
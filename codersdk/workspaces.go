@@ -61,8 +61,12 @@ type Workspace struct {
 	// what is causing an unhealthy status.
 	Health           WorkspaceHealth  `json:"health"`
 	AutomaticUpdates AutomaticUpdates `json:"automatic_updates" enums:"always,never"`
-	AllowRenames     bool             `json:"allow_renames"`
-	Favorite         bool             `json:"favorite"`
+	// ReleaseChannel is the name of the template release channel the
+	// workspace follows. If set, TemplateActiveVersionID and Outdated refer
+	// to the version of the channel.
+	ReleaseChannel string `json:"release_channel,omitempty"`
+	AllowRenames   bool   `json:"allow_renames"`
+	Favorite       bool   `json:"favorite"`
 }
 
 func (w Workspace) FullName() string {
@@ -480,6 +484,27 @@ func (c *Client) UpdateWorkspaceAutomaticUpdates(ctx context.Context, id uuid.UU
 	return nil
 }
 
+// UpdateWorkspaceReleaseChannelRequest is a request to change the template
+// release channel a workspace follows. An empty channel follows the active
+// version of the template.
+type UpdateWorkspaceReleaseChannelRequest struct {
+	ReleaseChannel string `json:"release_channel"`
+}
+
+// UpdateWorkspaceReleaseChannel sets the template release channel for workspace by id.
+func (c *Client) UpdateWorkspaceReleaseChannel(ctx context.Context, id uuid.UUID, req UpdateWorkspaceReleaseChannelRequest) error {
+	path := fmt.Sprintf("/api/v2/workspaces/%s/release-channel", id.String())
+	res, err := c.Request(ctx, http.MethodPut, path, req)
+	if err != nil {
+		return xerrors.Errorf("update workspace release channel: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
+
 type WorkspaceFilter struct {
 	// Owner can be "me" or a username
 	Owner string `json:"owner,omitempty" typescript:"-"`
@@ -570,6 +595,9 @@ func (c *Client) WorkspaceByOwnerAndName(ctx context.Context, owner string, name
 type WorkspaceQuota struct {
 	CreditsConsumed int `json:"credits_consumed"`
 	Budget          int `json:"budget"`
+	// Budgets are the cost-based budgets which apply to the user, across all
+	// of their organizations.
+	Budgets []QuotaBudgetStatus `json:"budgets"`
 }
 
 func (c *Client) WorkspaceQuota(ctx context.Context, userID string) (WorkspaceQuota, error) {
@@ -92,6 +92,42 @@ type AgentReconnectingPTYInit struct {
 	Height  uint16
 	Width   uint16
 	Command string
+	// Name is an optional human readable name for the session, shown when
+	// listing sessions. It is only used when the session is created.
+	Name string `json:",omitempty"`
+}
+
+// AgentReconnectingPTYInitOption is a functional option for
+// AgentReconnectingPTYInit.
+type AgentReconnectingPTYInitOption func(*AgentReconnectingPTYInit)
+
+// AgentReconnectingPTYInitWithName sets the name of the session.
+func AgentReconnectingPTYInitWithName(name string) AgentReconnectingPTYInitOption {
+	return func(init *AgentReconnectingPTYInit) {
+		init.Name = name
+	}
+}
+
+// ReconnectingPTYSession describes a reconnecting PTY session that is running
+// in the agent.
+// @typescript-ignore ReconnectingPTYSession
+type ReconnectingPTYSession struct {
+	ID      uuid.UUID `json:"id" format:"uuid"`
+	Name    string    `json:"name,omitempty"`
+	Command string    `json:"command"`
+	// CreatedAt is when the session was started.
+	CreatedAt time.Time `json:"created_at" format:"date-time"`
+	// LastAttachedAt is when a client last connected to the session.
+	LastAttachedAt time.Time `json:"last_attached_at" format:"date-time"`
+	// AttachedClients is the number of clients currently connected.
+	AttachedClients int `json:"attached_clients"`
+}
+
+// ReconnectingPTYSessionsResponse is returned by the agent when listing
+// reconnecting PTY sessions.
+// @typescript-ignore ReconnectingPTYSessionsResponse
+type ReconnectingPTYSessionsResponse struct {
+	Sessions []ReconnectingPTYSession `json:"sessions"`
 }
 
 // ReconnectingPTYRequest is sent from the client to the server
@@ -106,7 +142,7 @@ type ReconnectingPTYRequest struct {
 // ReconnectingPTY spawns a new reconnecting terminal session.
 // `ReconnectingPTYRequest` should be JSON marshaled and written to the returned net.Conn.
 // Raw terminal output will be read from the returned net.Conn.
-func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, width uint16, command string) (net.Conn, error) {
+func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, width uint16, command string, initOpts ...AgentReconnectingPTYInitOption) (net.Conn, error) {
 	ctx, span := tracing.StartSpan(ctx)
 	defer span.End()
 
@@ -118,12 +154,16 @@ func (c *AgentConn) ReconnectingPTY(ctx context.Context, id uuid.UUID, height, w
 	if err != nil {
 		return nil, err
 	}
-	data, err := json.Marshal(AgentReconnectingPTYInit{
+	rptyInit := AgentReconnectingPTYInit{
 		ID:      id,
 		Height:  height,
 		Width:   width,
 		Command: command,
-	})
+	}
+	for _, o := range initOpts {
+		o(&rptyInit)
+	}
+	data, err := json.Marshal(rptyInit)
 	if err != nil {
 		_ = conn.Close()
 		return nil, err
@@ -241,6 +281,40 @@ func (c *AgentConn) ListeningPorts(ctx context.Context) (codersdk.WorkspaceAgent
 	return resp, json.NewDecoder(res.Body).Decode(&resp)
 }
 
+// ReconnectingPTYSessions returns the reconnecting PTY sessions that are
+// running in the agent.
+func (c *AgentConn) ReconnectingPTYSessions(ctx context.Context) (ReconnectingPTYSessionsResponse, error) {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	res, err := c.apiRequest(ctx, http.MethodGet, "/api/v0/reconnecting-ptys", nil)
+	if err != nil {
+		return ReconnectingPTYSessionsResponse{}, xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReconnectingPTYSessionsResponse{}, codersdk.ReadBodyAsError(res)
+	}
+
+	var resp ReconnectingPTYSessionsResponse
+	return resp, json.NewDecoder(res.Body).Decode(&resp)
+}
+
+// CloseReconnectingPTYSession terminates a reconnecting PTY session and
+// disconnects its clients.
+func (c *AgentConn) CloseReconnectingPTYSession(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.StartSpan(ctx)
+	defer span.End()
+	res, err := c.apiRequest(ctx, http.MethodDelete, "/api/v0/reconnecting-ptys/"+id.String(), nil)
+	if err != nil {
+		return xerrors.Errorf("do request: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return codersdk.ReadBodyAsError(res)
+	}
+	return nil
+}
+
 // DebugMagicsock makes a request to the workspace agent's magicsock debug endpoint.
 func (c *AgentConn) DebugMagicsock(ctx context.Context) ([]byte, error) {
 	ctx, span := tracing.StartSpan(ctx)
@@ -0,0 +1,77 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceRole is the access a user or group has been granted to a workspace
+// they do not own.
+type WorkspaceRole string
+
+const (
+	// WorkspaceRoleAdmin allows starting, stopping and updating the
+	// workspace, on top of WorkspaceRoleUse.
+	WorkspaceRoleAdmin WorkspaceRole = "admin"
+	// WorkspaceRoleUse allows connecting to the workspace over SSH, apps
+	// and port forwarding.
+	WorkspaceRoleUse WorkspaceRole = "use"
+	// WorkspaceRoleDeleted removes a user or group from the ACL.
+	WorkspaceRoleDeleted WorkspaceRole = ""
+)
+
+type WorkspaceACL struct {
+	Users  []WorkspaceUser  `json:"users"`
+	Groups []WorkspaceGroup `json:"groups"`
+}
+
+type WorkspaceUser struct {
+	MinimalUser
+	Role WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type WorkspaceGroup struct {
+	Group
+	Role WorkspaceRole `json:"role" enums:"admin,use"`
+}
+
+type UpdateWorkspaceACL struct {
+	// UserRoles is a mapping of user IDs to roles. An empty role removes the
+	// user.
+	UserRoles map[string]WorkspaceRole `json:"user_roles,omitempty" example:"4df59e74-c027-470b-ab4d-cbba8963a5e9:use"`
+	// GroupRoles is a mapping of group IDs to roles. An empty role removes
+	// the group.
+	GroupRoles map[string]WorkspaceRole `json:"group_roles,omitempty" example:"8bd26b20-f3e8-48be-a903-46bb920cf671:admin"`
+}
+
+// WorkspaceACL returns the users and groups a workspace is shared with.
+func (c *Client) WorkspaceACL(ctx context.Context, workspaceID uuid.UUID) (WorkspaceACL, error) {
+	res, err := c.Request(ctx, http.MethodGet, fmt.Sprintf("/api/v2/workspaces/%s/acl", workspaceID), nil)
+	if err != nil {
+		return WorkspaceACL{}, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return WorkspaceACL{}, ReadBodyAsError(res)
+	}
+	var acl WorkspaceACL
+	return acl, json.NewDecoder(res.Body).Decode(&acl)
+}
+
+// UpdateWorkspaceACL shares a workspace with, or stops sharing it with, users
+// and groups. Users and groups which are not in the request are unchanged.
+func (c *Client) UpdateWorkspaceACL(ctx context.Context, workspaceID uuid.UUID, req UpdateWorkspaceACL) error {
+	res, err := c.Request(ctx, http.MethodPatch, fmt.Sprintf("/api/v2/workspaces/%s/acl", workspaceID), req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return ReadBodyAsError(res)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package codersdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+)
+
+// WorkspaceSnapshot is a point in time copy of a workspace build. Restoring a
+// snapshot creates a new build from the same template version, Terraform state
+// and rich parameter values. The Terraform state includes the identity of
+// persistent volumes, so a restored workspace keeps the disks it had when the
+// snapshot was taken.
+type WorkspaceSnapshot struct {
+	ID                  uuid.UUID                 `json:"id" format:"uuid"`
+	WorkspaceID         uuid.UUID                 `json:"workspace_id" format:"uuid"`
+	WorkspaceBuildID    uuid.UUID                 `json:"workspace_build_id" format:"uuid"`
+	BuildNumber         int32                     `json:"build_number"`
+	TemplateVersionID   uuid.UUID                 `json:"template_version_id" format:"uuid"`
+	TemplateVersionName string                    `json:"template_version_name"`
+	Name                string                    `json:"name"`
+	Transition          WorkspaceTransition       `json:"transition" enums:"start,stop"`
+	RichParameterValues []WorkspaceBuildParameter `json:"rich_parameter_values"`
+	CreatedBy           uuid.UUID                 `json:"created_by" format:"uuid"`
+	CreatedAt           time.Time                 `json:"created_at" format:"date-time"`
+}
+
+type CreateWorkspaceSnapshotRequest struct {
+	// Name must be unique within the workspace. A random name is generated if
+	// it is empty.
+	Name string `json:"name,omitempty" validate:"omitempty,max=64"`
+	// BuildNumber is the workspace build to snapshot. Defaults to the latest
+	// build.
+	BuildNumber int32 `json:"build_number,omitempty" validate:"omitempty,min=1"`
+}
+
+func (c *Client) WorkspaceSnapshots(ctx context.Context, workspaceID uuid.UUID) ([]WorkspaceSnapshot, error) {
+	res, err := c.Request(ctx, http.MethodGet,
+		fmt.Sprintf("/api/v2/workspaces/%s/snapshots", workspaceID.String()),
+		nil,
+	)
+	if err != nil {
+		return nil, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, ReadBodyAsError(res)
+	}
+	var snapshots []WorkspaceSnapshot
+	return snapshots, json.NewDecoder(res.Body).Decode(&snapshots)
+}
+
+// CreateWorkspaceSnapshot records the Terraform state and rich parameter
+// values of a workspace build. Only template admins may create snapshots, as
+// the state may contain secrets.
+func (c *Client) CreateWorkspaceSnapshot(ctx context.Context, workspaceID uuid.UUID, req CreateWorkspaceSnapshotRequest) (WorkspaceSnapshot, error) {
+	res, err := c.Request(ctx, http.MethodPost,
+		fmt.Sprintf("/api/v2/workspaces/%s/snapshots", workspaceID.String()),
+		req,
+	)
+	if err != nil {
+		return WorkspaceSnapshot{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceSnapshot{}, ReadBodyAsError(res)
+	}
+	var snapshot WorkspaceSnapshot
+	return snapshot, json.NewDecoder(res.Body).Decode(&snapshot)
+}
+
+// RestoreWorkspaceSnapshot creates a new workspace build from a snapshot.
+func (c *Client) RestoreWorkspaceSnapshot(ctx context.Context, workspaceID, snapshotID uuid.UUID) (WorkspaceBuild, error) {
+	res, err := c.Request(ctx, http.MethodPost,
+		fmt.Sprintf("/api/v2/workspaces/%s/snapshots/%s/restore", workspaceID.String(), snapshotID.String()),
+		nil,
+	)
+	if err != nil {
+		return WorkspaceBuild{}, xerrors.Errorf("make request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated {
+		return WorkspaceBuild{}, ReadBodyAsError(res)
+	}
+	var build WorkspaceBuild
+	return build, json.NewDecoder(res.Body).Decode(&build)
+}
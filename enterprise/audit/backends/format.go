@@ -0,0 +1,208 @@
+package backends
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/buildinfo"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+// event is the JSON representation of an audit log sent to external systems.
+// Unlike database.AuditLog, nullable columns are flattened so consumers don't
+// have to understand the database types.
+type event struct {
+	ID               uuid.UUID             `json:"id"`
+	Time             time.Time             `json:"time"`
+	UserID           uuid.UUID             `json:"user_id"`
+	OrganizationID   uuid.UUID             `json:"organization_id"`
+	IP               string                `json:"ip"`
+	UserAgent        string                `json:"user_agent"`
+	ResourceType     database.ResourceType `json:"resource_type"`
+	ResourceID       uuid.UUID             `json:"resource_id"`
+	ResourceTarget   string                `json:"resource_target"`
+	ResourceIcon     string                `json:"resource_icon"`
+	Action           database.AuditAction  `json:"action"`
+	Diff             json.RawMessage       `json:"diff"`
+	StatusCode       int32                 `json:"status_code"`
+	AdditionalFields json.RawMessage       `json:"additional_fields"`
+	RequestID        uuid.UUID             `json:"request_id"`
+	Actor            *audit.Actor          `json:"actor,omitempty"`
+}
+
+func newEvent(alog database.AuditLog, details audit.BackendDetails) event {
+	e := event{
+		ID:               alog.ID,
+		Time:             alog.Time,
+		UserID:           alog.UserID,
+		OrganizationID:   alog.OrganizationID,
+		UserAgent:        alog.UserAgent.String,
+		ResourceType:     alog.ResourceType,
+		ResourceID:       alog.ResourceID,
+		ResourceTarget:   alog.ResourceTarget,
+		ResourceIcon:     alog.ResourceIcon,
+		Action:           alog.Action,
+		Diff:             alog.Diff,
+		StatusCode:       alog.StatusCode,
+		AdditionalFields: alog.AdditionalFields,
+		RequestID:        alog.RequestID,
+		Actor:            details.Actor,
+	}
+	if alog.Ip.Valid {
+		e.IP = alog.Ip.IPNet.IP.String()
+	}
+	// An empty json.RawMessage is invalid JSON and fails to marshal.
+	if len(e.Diff) == 0 {
+		e.Diff = nil
+	}
+	if len(e.AdditionalFields) == 0 {
+		e.AdditionalFields = nil
+	}
+	return e
+}
+
+func (e event) failed() bool {
+	return e.StatusCode >= http.StatusBadRequest
+}
+
+func (e event) username() string {
+	if e.Actor == nil {
+		return ""
+	}
+	return e.Actor.Username
+}
+
+// formatJSON formats an audit log as a single line of JSON.
+func formatJSON(e event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// formatCEF formats an audit log in the ArcSight Common Event Format.
+func formatCEF(e event) []byte {
+	severity := 3
+	outcome := "success"
+	if e.failed() {
+		severity = 6
+		outcome = "failure"
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "CEF:0|Coder|Coder|%s|%s|%s|%d|",
+		cefHeader(buildinfo.Version()),
+		cefHeader(fmt.Sprintf("%s.%s", e.ResourceType, e.Action)),
+		cefHeader(fmt.Sprintf("%s %s", e.Action, e.ResourceType)),
+		severity,
+	)
+	extensions := [][2]string{
+		{"rt", strconv.FormatInt(e.Time.UnixMilli(), 10)},
+		{"externalId", e.ID.String()},
+		{"act", string(e.Action)},
+		{"outcome", outcome},
+		{"suid", e.UserID.String()},
+		{"suser", e.username()},
+		{"src", e.IP},
+		{"requestClientApplication", e.UserAgent},
+		{"cs1Label", "resourceTarget"},
+		{"cs1", e.ResourceTarget},
+		{"cs2Label", "resourceId"},
+		{"cs2", e.ResourceID.String()},
+		{"cs3Label", "organizationId"},
+		{"cs3", e.OrganizationID.String()},
+		{"cs4Label", "requestId"},
+		{"cs4", e.RequestID.String()},
+		{"cn1Label", "statusCode"},
+		{"cn1", strconv.Itoa(int(e.StatusCode))},
+	}
+	first := true
+	for _, ext := range extensions {
+		if ext[1] == "" {
+			continue
+		}
+		if !first {
+			sb.WriteByte(' ')
+		}
+		first = false
+		sb.WriteString(ext[0])
+		sb.WriteByte('=')
+		sb.WriteString(cefExtension(ext[1]))
+	}
+	return []byte(sb.String())
+}
+
+var (
+	cefHeaderReplacer    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionReplacer = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func cefHeader(s string) string {
+	return cefHeaderReplacer.Replace(s)
+}
+
+func cefExtension(s string) string {
+	return cefExtensionReplacer.Replace(s)
+}
+
+// formatLEEF formats an audit log in the IBM QRadar Log Event Extended Format
+// version 1.0, whose attributes are separated by tabs.
+func formatLEEF(e event) []byte {
+	severity := 3
+	if e.failed() {
+		severity = 6
+	}
+
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "LEEF:1.0|Coder|Coder|%s|%s|",
+		leefValue(strings.ReplaceAll(buildinfo.Version(), "|", "")),
+		leefValue(fmt.Sprintf("%s.%s", e.ResourceType, e.Action)),
+	)
+	attributes := [][2]string{
+		{"devTime", e.Time.UTC().Format(leefTimeFormat)},
+		{"devTimeFormat", leefTimeFormatJava},
+		{"cat", string(e.ResourceType)},
+		{"sev", strconv.Itoa(severity)},
+		{"src", e.IP},
+		{"usrName", e.username()},
+		{"userId", e.UserID.String()},
+		{"action", string(e.Action)},
+		{"resourceId", e.ResourceID.String()},
+		{"resourceTarget", e.ResourceTarget},
+		{"organizationId", e.OrganizationID.String()},
+		{"statusCode", strconv.Itoa(int(e.StatusCode))},
+		{"userAgent", e.UserAgent},
+		{"requestId", e.RequestID.String()},
+		{"id", e.ID.String()},
+	}
+	first := true
+	for _, attr := range attributes {
+		if attr[1] == "" {
+			continue
+		}
+		if !first {
+			sb.WriteByte('\t')
+		}
+		first = false
+		sb.WriteString(attr[0])
+		sb.WriteByte('=')
+		sb.WriteString(leefValue(attr[1]))
+	}
+	return []byte(sb.String())
+}
+
+const (
+	leefTimeFormat     = "Jan 02 2006 15:04:05.000 UTC"
+	leefTimeFormatJava = "MMM dd yyyy HH:mm:ss.SSS z"
+)
+
+// LEEF 1.0 has no escaping, so the delimiters are replaced.
+var leefReplacer = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+func leefValue(s string) string {
+	return leefReplacer.Replace(s)
+}
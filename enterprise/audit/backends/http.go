@@ -0,0 +1,458 @@
+package backends
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+const (
+	httpDefaultBatchSize     = 100
+	httpDefaultFlushInterval = 5 * time.Second
+	httpRequestTimeout       = 30 * time.Second
+	httpMaxRetryInterval     = 5 * time.Minute
+	// httpQueueSize bounds the memory used while audit logs are waiting to be
+	// written to the buffer directory.
+	httpQueueSize = 10_000
+	// httpSegmentLogs is the number of audit logs written to a segment file
+	// before a new one is started. Segment files are deleted once all of
+	// their audit logs have been sent.
+	httpSegmentLogs = 10_000
+	// httpMaxBufferedLogs bounds the disk used while the endpoint is
+	// unavailable. Audit logs are still stored in the database when it's
+	// reached.
+	httpMaxBufferedLogs = 1_000_000
+)
+
+type HTTPOptions struct {
+	Endpoint *url.URL
+	// Header is added to every request.
+	Header http.Header
+	// BatchSize is the maximum number of audit logs sent in a request.
+	BatchSize int
+	// FlushInterval is how often buffered audit logs are sent, unless a full
+	// batch is sent sooner.
+	FlushInterval time.Duration
+	// BufferDirectory is where audit logs are stored until they are sent.
+	BufferDirectory string
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+	Logger slog.Logger
+}
+
+// HTTPBackend posts batches of audit logs to an HTTP endpoint as a JSON
+// array. Export only queues audit logs in memory, so disk and endpoint latency
+// never hold up requests. Queued audit logs are appended in batches to segment
+// files in the buffer directory, and sent from there, so audit logs survive
+// the endpoint being unavailable and the server restarting. Failed requests
+// are retried with an exponential backoff until they succeed. Audit logs are
+// dropped when the queue or the buffer directory is full.
+type HTTPBackend struct {
+	opts    HTTPOptions
+	queue   chan []byte
+	dropped atomic.Int64
+	// buffered is the number of audit logs that are queued or written, but
+	// haven't been sent.
+	buffered atomic.Int64
+	// flush is signaled when a full batch is written.
+	flush chan struct{}
+
+	mu sync.Mutex
+	// segments are oldest first. Audit logs are only appended to the last
+	// one, and only sent from the first one.
+	segments []*httpSegment
+	nextSeq  int64
+	// written is the number of audit logs in segments that haven't been
+	// sent.
+	written int
+
+	cancel context.CancelFunc
+	// drained is closed once the queue has been written on Close.
+	drained chan struct{}
+	closed  chan struct{}
+}
+
+// httpSegment is a file in the buffer directory with an audit log on each
+// line, and a file with the offset up to which they have been sent.
+type httpSegment struct {
+	path string
+	// size is the number of bytes of complete lines in the file.
+	size int64
+	// logs is the number of lines in the file.
+	logs int
+	// sent is the offset up to which audit logs have been sent.
+	sent int64
+}
+
+func (s *httpSegment) sentPath() string {
+	return strings.TrimSuffix(s.path, ".jsonl") + ".sent"
+}
+
+func NewHTTP(opts HTTPOptions) (*HTTPBackend, error) {
+	if opts.Endpoint == nil {
+		return nil, xerrors.New("endpoint is required")
+	}
+	if opts.BufferDirectory == "" {
+		return nil, xerrors.New("buffer directory is required")
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = httpDefaultBatchSize
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = httpDefaultFlushInterval
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	err := os.MkdirAll(opts.BufferDirectory, 0o700)
+	if err != nil {
+		return nil, xerrors.Errorf("create buffer directory: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &HTTPBackend{
+		opts:    opts,
+		queue:   make(chan []byte, httpQueueSize),
+		flush:   make(chan struct{}, 1),
+		cancel:  cancel,
+		drained: make(chan struct{}),
+		closed:  make(chan struct{}),
+	}
+	// Audit logs that weren't sent before the server stopped are sent first.
+	err = b.loadSegments()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go b.write(ctx)
+	go b.run(ctx)
+	return b, nil
+}
+
+// loadSegments reads the segments left in the buffer directory by a previous
+// backend.
+func (b *HTTPBackend) loadSegments() error {
+	// Temporary files are left behind if the server crashed while writing
+	// them.
+	tmps, err := filepath.Glob(filepath.Join(b.opts.BufferDirectory, "*.tmp"))
+	if err != nil {
+		return xerrors.Errorf("find temporary buffer files: %w", err)
+	}
+	for _, tmp := range tmps {
+		_ = os.Remove(tmp)
+	}
+
+	// The names of the segments sort in the order they were created.
+	paths, err := filepath.Glob(filepath.Join(b.opts.BufferDirectory, "*.jsonl"))
+	if err != nil {
+		return xerrors.Errorf("find buffer files: %w", err)
+	}
+	for _, path := range paths {
+		seq, err := strconv.ParseInt(strings.TrimSuffix(filepath.Base(path), ".jsonl"), 10, 64)
+		if err != nil {
+			continue
+		}
+		b.nextSeq = max(b.nextSeq, seq+1)
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return xerrors.Errorf("read buffer file: %w", err)
+		}
+		seg := &httpSegment{path: path}
+		// A line that is only partially written is dropped, since the server
+		// stopped before the write was synced.
+		seg.size = int64(bytes.LastIndexByte(data, '\n') + 1)
+		if seg.size < int64(len(data)) {
+			err = os.Truncate(path, seg.size)
+			if err != nil {
+				return xerrors.Errorf("truncate buffer file: %w", err)
+			}
+		}
+		data = data[:seg.size]
+		seg.logs = bytes.Count(data, []byte{'\n'})
+		sent, err := os.ReadFile(seg.sentPath())
+		if err == nil {
+			seg.sent, _ = strconv.ParseInt(strings.TrimSpace(string(sent)), 10, 64)
+			seg.sent = min(max(seg.sent, 0), seg.size)
+		}
+		unsent := bytes.Count(data[seg.sent:], []byte{'\n'})
+		b.written += unsent
+		b.buffered.Add(int64(unsent))
+		b.segments = append(b.segments, seg)
+	}
+	return nil
+}
+
+func (*HTTPBackend) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (b *HTTPBackend) Export(ctx context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	data, err := formatJSON(newEvent(alog, details))
+	if err != nil {
+		return xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	// Returning an error would stop the audit log from being exported to the
+	// backends after this one.
+	if b.buffered.Load() >= httpMaxBufferedLogs {
+		b.opts.Logger.Warn(ctx, "too many audit logs are waiting to be sent, dropping audit log",
+			slog.F("endpoint", b.opts.Endpoint.String()),
+			slog.F("dropped", b.dropped.Add(1)),
+		)
+		return nil
+	}
+	b.buffered.Add(1)
+	select {
+	case b.queue <- data:
+	default:
+		b.buffered.Add(-1)
+		b.opts.Logger.Warn(ctx, "http audit log queue is full, dropping audit log",
+			slog.F("endpoint", b.opts.Endpoint.String()),
+			slog.F("dropped", b.dropped.Add(1)),
+		)
+	}
+	return nil
+}
+
+// Dropped returns the number of audit logs that were never sent to the
+// endpoint.
+func (b *HTTPBackend) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close stops sending audit logs. Queued audit logs are written to the buffer
+// directory, and audit logs that haven't been sent are sent when the backend
+// is created again.
+func (b *HTTPBackend) Close() error {
+	b.cancel()
+	<-b.drained
+	<-b.closed
+	return nil
+}
+
+// write appends queued audit logs to the last segment, as many as are queued
+// at once.
+func (b *HTTPBackend) write(ctx context.Context) {
+	defer close(b.drained)
+
+	for {
+		var data []byte
+		select {
+		case <-ctx.Done():
+			// Write what's left in the queue, so it's sent by the next
+			// backend.
+			select {
+			case data = <-b.queue:
+			default:
+				return
+			}
+		case data = <-b.queue:
+		}
+
+		lines := [][]byte{data}
+	drain:
+		for len(lines) < httpSegmentLogs {
+			select {
+			case data = <-b.queue:
+				lines = append(lines, data)
+			default:
+				break drain
+			}
+		}
+
+		err := b.append(lines)
+		if err != nil {
+			b.buffered.Add(-int64(len(lines)))
+			b.opts.Logger.Error(ctx, "failed to write audit logs to the buffer directory, dropping them",
+				slog.F("directory", b.opts.BufferDirectory),
+				slog.F("dropped", b.dropped.Add(int64(len(lines)))),
+				slog.Error(err),
+			)
+			continue
+		}
+		if b.unsent() >= b.opts.BatchSize {
+			select {
+			case b.flush <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// append writes audit logs to the last segment, and syncs it.
+func (b *HTTPBackend) append(lines [][]byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var seg *httpSegment
+	if len(b.segments) > 0 {
+		seg = b.segments[len(b.segments)-1]
+	}
+	if seg == nil || seg.logs >= httpSegmentLogs {
+		seg = &httpSegment{
+			path: filepath.Join(b.opts.BufferDirectory, fmt.Sprintf("%020d.jsonl", b.nextSeq)),
+		}
+		b.nextSeq++
+		b.segments = append(b.segments, seg)
+	}
+
+	f, err := os.OpenFile(seg.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return xerrors.Errorf("open buffer file: %w", err)
+	}
+	defer f.Close()
+	var buf bytes.Buffer
+	for _, line := range lines {
+		_, _ = buf.Write(line)
+		_ = buf.WriteByte('\n')
+	}
+	_, err = f.Write(buf.Bytes())
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		// Don't leave a partial line for the next write to append to.
+		_ = f.Truncate(seg.size)
+		return xerrors.Errorf("write buffer file: %w", err)
+	}
+	seg.size += int64(buf.Len())
+	seg.logs += len(lines)
+	b.written += len(lines)
+	return nil
+}
+
+// unsent returns the number of written audit logs that haven't been sent.
+func (b *HTTPBackend) unsent() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.written
+}
+
+// send posts the oldest batch of written audit logs, and returns how many
+// were sent.
+func (b *HTTPBackend) send(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	var seg *httpSegment
+	for len(b.segments) > 0 {
+		seg = b.segments[0]
+		if seg.sent < seg.size || len(b.segments) == 1 {
+			break
+		}
+		// Nothing more is written to a segment once there's a newer one.
+		err := b.removeSegment(seg)
+		if err != nil {
+			b.mu.Unlock()
+			return 0, err
+		}
+		b.segments = b.segments[1:]
+		seg = nil
+	}
+	if seg == nil || seg.sent >= seg.size {
+		b.mu.Unlock()
+		return 0, nil
+	}
+	path, offset, size := seg.path, seg.sent, seg.size
+	b.mu.Unlock()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, xerrors.Errorf("open buffer file: %w", err)
+	}
+	defer f.Close()
+	reader := bufio.NewReader(io.NewSectionReader(f, offset, size-offset))
+	batch := make([]json.RawMessage, 0, b.opts.BatchSize)
+	end := offset
+	for len(batch) < b.opts.BatchSize {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if xerrors.Is(err, io.EOF) {
+				break
+			}
+			return 0, xerrors.Errorf("read buffer file: %w", err)
+		}
+		end += int64(len(line))
+		batch = append(batch, bytes.TrimSuffix(line, []byte{'\n'}))
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return 0, xerrors.Errorf("marshal batch: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.opts.Endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return 0, xerrors.Errorf("create request: %w", err)
+	}
+	for name, values := range b.opts.Header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := b.opts.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return 0, xerrors.Errorf("unexpected status code %d: %s", res.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	b.mu.Lock()
+	seg.sent = end
+	b.written -= len(batch)
+	b.mu.Unlock()
+	b.buffered.Add(-int64(len(batch)))
+	// The offset is written to a temporary file first, so a crash never
+	// leaves a partially written offset. Audit logs are sent again if the
+	// server stops before it's written.
+	tmp := seg.sentPath() + ".tmp"
+	err = os.WriteFile(tmp, []byte(strconv.FormatInt(end, 10)), 0o600)
+	if err == nil {
+		err = os.Rename(tmp, seg.sentPath())
+	}
+	if err != nil {
+		// The audit logs would be sent again, so stop sending until the
+		// directory is fixed.
+		return 0, xerrors.Errorf("write sent offset: %w", err)
+	}
+	return len(batch), nil
+}
+
+// removeSegment deletes the files of a segment whose audit logs have all been
+// sent.
+func (*HTTPBackend) removeSegment(seg *httpSegment) error {
+	err := os.Remove(seg.path)
+	if err != nil && !os.IsNotExist(err) {
+		return xerrors.Errorf("remove sent buffer file: %w", err)
+	}
+	err = os.Remove(seg.sentPath())
+	if err != nil && !os.IsNotExist(err) {
+		return xerrors.Errorf("remove sent offset: %w", err)
+	}
+	return nil
+}
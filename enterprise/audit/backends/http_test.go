@@ -0,0 +1,242 @@
+package backends_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestHTTPBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("FullBatch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		endpoint := newAuditEndpoint(t)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint: endpoint.url,
+			Header:   http.Header{"Authorization": {"Bearer secret"}},
+			// Only a full batch is sent before the test times out.
+			BatchSize:       2,
+			FlushInterval:   time.Hour,
+			BufferDirectory: t.TempDir(),
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		first, second := audittest.RandomLog(), audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, first, audit.BackendDetails{}))
+		require.NoError(t, backend.Export(ctx, second, audit.BackendDetails{}))
+
+		req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, "Bearer secret", req.header.Get("Authorization"))
+		require.Equal(t, "application/json", req.header.Get("Content-Type"))
+		require.Equal(t, []string{first.ID.String(), second.ID.String()}, req.ids)
+	})
+
+	t.Run("FlushInterval", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		endpoint := newAuditEndpoint(t)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			BatchSize:       100,
+			FlushInterval:   testutil.IntervalFast,
+			BufferDirectory: t.TempDir(),
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+
+		req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, []string{alog.ID.String()}, req.ids)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		endpoint := newAuditEndpoint(t)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			BatchSize:       2,
+			FlushInterval:   testutil.IntervalFast,
+			BufferDirectory: t.TempDir(),
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		var want []string
+		for i := 0; i < 5; i++ {
+			alog := audittest.RandomLog()
+			want = append(want, alog.ID.String())
+			require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		}
+
+		var got []string
+		for len(got) < len(want) {
+			req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+			require.LessOrEqual(t, len(req.ids), 2)
+			got = append(got, req.ids...)
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitMedium)
+		endpoint := newAuditEndpoint(t)
+		endpoint.failures.Store(1)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			BatchSize:       1,
+			FlushInterval:   time.Hour,
+			BufferDirectory: t.TempDir(),
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+
+		// The failed request is sent again.
+		req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, []string{alog.ID.String()}, req.ids)
+	})
+
+	t.Run("Restart", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		unavailable, err := url.Parse("http://127.0.0.1:0")
+		require.NoError(t, err)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        unavailable,
+			FlushInterval:   time.Hour,
+			BufferDirectory: dir,
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		alog := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, alog, audit.BackendDetails{}))
+		require.NoError(t, backend.Close())
+
+		// The audit log that wasn't sent is sent by the next backend.
+		endpoint := newAuditEndpoint(t)
+		backend, err = backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			FlushInterval:   testutil.IntervalFast,
+			BufferDirectory: dir,
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, []string{alog.ID.String()}, req.ids)
+	})
+
+	t.Run("RestartAfterSending", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		dir := t.TempDir()
+		endpoint := newAuditEndpoint(t)
+		backend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			BatchSize:       1,
+			FlushInterval:   time.Hour,
+			BufferDirectory: dir,
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		sent := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, sent, audit.BackendDetails{}))
+		req := testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, []string{sent.ID.String()}, req.ids)
+		require.NoError(t, backend.Close())
+
+		// Only the audit log that wasn't sent is sent by the next backend.
+		backend, err = backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        endpoint.url,
+			FlushInterval:   testutil.IntervalFast,
+			BufferDirectory: dir,
+			Logger:          slogtest.Make(t, nil),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+		unsent := audittest.RandomLog()
+		require.NoError(t, backend.Export(ctx, unsent, audit.BackendDetails{}))
+
+		req = testutil.RequireRecvCtx(ctx, t, endpoint.requests)
+		require.Equal(t, []string{unsent.ID.String()}, req.ids)
+	})
+}
+
+type auditRequest struct {
+	header http.Header
+	ids    []string
+}
+
+type auditEndpoint struct {
+	url      *url.URL
+	requests chan auditRequest
+	// failures is the number of requests to fail before succeeding.
+	failures atomic.Int64
+}
+
+func newAuditEndpoint(t *testing.T) *auditEndpoint {
+	t.Helper()
+
+	endpoint := &auditEndpoint{
+		requests: make(chan auditRequest, 16),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if endpoint.failures.Add(-1) >= 0 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var logs []struct {
+			ID string `json:"id"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&logs)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		req := auditRequest{header: r.Header}
+		for _, l := range logs {
+			req.ids = append(req.ids, l.ID)
+		}
+		endpoint.requests <- req
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	var err error
+	endpoint.url, err = url.Parse(srv.URL)
+	require.NoError(t, err)
+	return endpoint
+}
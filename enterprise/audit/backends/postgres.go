@@ -2,10 +2,13 @@ package backends
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"golang.org/x/xerrors"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/enterprise/audit"
 )
 
@@ -16,10 +19,13 @@ type postgresBackend struct {
 	// pointing to the Coderd database.
 	internal bool
 	db       database.Store
+	// chainKey signs the hash chain of the audit logs. Audit logs aren't
+	// chained when it's empty.
+	chainKey []byte
 }
 
-func NewPostgres(db database.Store, internal bool) audit.Backend {
-	return &postgresBackend{db: db, internal: internal}
+func NewPostgres(db database.Store, internal bool, chainKey []byte) audit.Backend {
+	return &postgresBackend{db: db, internal: internal, chainKey: chainKey}
 }
 
 func (b *postgresBackend) Decision() audit.FilterDecision {
@@ -30,11 +36,49 @@ func (b *postgresBackend) Decision() audit.FilterDecision {
 	return audit.FilterDecisionExport
 }
 
+// Export stores the audit log. With a chain key, it's chained to the previous
+// audit log in its organization, so changes made to stored audit logs can be
+// detected.
 func (b *postgresBackend) Export(ctx context.Context, alog database.AuditLog, _ audit.BackendDetails) error {
-	_, err := b.db.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
-	if err != nil {
-		return xerrors.Errorf("insert audit log: %w", err)
+	if len(b.chainKey) == 0 {
+		_, err := b.db.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
+		if err != nil {
+			return xerrors.Errorf("insert audit log: %w", err)
+		}
+		return nil
 	}
 
-	return nil
+	// The hash must match the time that's read back from the database.
+	alog.Time = alog.Time.Round(time.Microsecond)
+
+	return b.db.InTx(func(tx database.Store) error {
+		// The head is locked until the transaction ends, so audit logs in the
+		// same organization are chained one at a time.
+		head, err := tx.AcquireAuditLogChainHead(ctx, alog.OrganizationID)
+		if err != nil {
+			return xerrors.Errorf("acquire audit log chain head: %w", err)
+		}
+
+		alog.ChainIndex = sql.NullInt64{Int64: head.ChainIndex + 1, Valid: true}
+		alog.Hash, err = audit.ChainHash(b.chainKey, head.Hash, alog)
+		if err != nil {
+			return xerrors.Errorf("hash audit log: %w", err)
+		}
+
+		_, err = tx.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
+		if err != nil {
+			return xerrors.Errorf("insert audit log: %w", err)
+		}
+
+		err = tx.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+			ChainIndex:     alog.ChainIndex.Int64,
+			Hash:           alog.Hash,
+			UpdatedAt:      dbtime.Now(),
+			OrganizationID: alog.OrganizationID,
+		})
+		if err != nil {
+			return xerrors.Errorf("update audit log chain head: %w", err)
+		}
+		return nil
+	}, nil)
 }
@@ -21,7 +21,7 @@ func TestPostgresBackend(t *testing.T) {
 		var (
 			ctx, cancel = context.WithCancel(context.Background())
 			db          = dbmem.New()
-			pgb         = backends.NewPostgres(db, true)
+			pgb         = backends.NewPostgres(db, true, nil)
 			alog        = audittest.RandomLog()
 		)
 		defer cancel()
@@ -36,5 +36,49 @@ func TestPostgresBackend(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, got, 1)
 		require.Equal(t, alog.ID, got[0].ID)
+		// Audit logs aren't chained without a key.
+		require.False(t, got[0].ChainIndex.Valid)
+	})
+
+	t.Run("Chained", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			ctx, cancel = context.WithCancel(context.Background())
+			db          = dbmem.New()
+			key         = []byte("chain key")
+			pgb         = backends.NewPostgres(db, true, key)
+			first       = audittest.RandomLog()
+			second      = audittest.RandomLog()
+		)
+		defer cancel()
+		second.OrganizationID = first.OrganizationID
+
+		err := pgb.Export(ctx, first, audit.BackendDetails{})
+		require.NoError(t, err)
+		err = pgb.Export(ctx, second, audit.BackendDetails{})
+		require.NoError(t, err)
+
+		got, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{
+			OrganizationID: first.OrganizationID,
+			LimitOpt:       10,
+		})
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.Equal(t, first.ID, got[0].ID)
+		require.EqualValues(t, 1, got[0].ChainIndex.Int64)
+		require.Equal(t, second.ID, got[1].ID)
+		require.EqualValues(t, 2, got[1].ChainIndex.Int64)
+
+		// The second audit log is chained to the first.
+		hash, err := audit.ChainHash(key, got[0].Hash, got[1])
+		require.NoError(t, err)
+		require.Equal(t, got[1].Hash, hash)
+
+		heads, err := db.GetAuditLogChainHeads(ctx)
+		require.NoError(t, err)
+		require.Len(t, heads, 1)
+		require.EqualValues(t, 2, heads[0].ChainIndex)
+		require.Equal(t, got[1].Hash, heads[0].Hash)
 	})
 }
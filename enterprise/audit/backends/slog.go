@@ -32,6 +32,10 @@ func (b *slogBackend) Export(ctx context.Context, alog database.AuditLog, detail
 	sfs := structs.Fields(alog)
 	var fields []any
 	for _, sf := range sfs {
+		// The hash chain is only assigned when the audit log is stored.
+		if sf.Name() == "ChainIndex" || sf.Name() == "Hash" {
+			continue
+		}
 		fields = append(fields, b.fieldToSlog(sf))
 	}
 
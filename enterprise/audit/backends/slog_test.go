@@ -44,7 +44,8 @@ func TestSlogBackend(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, sink.entries, 1)
 		require.Equal(t, sink.entries[0].Message, "audit_log")
-		require.Len(t, sink.entries[0].Fields, len(structs.Fields(alog)))
+		// The hash chain fields are omitted.
+		require.Len(t, sink.entries[0].Fields, len(structs.Fields(alog))-2)
 	})
 
 	t.Run("FormatsCorrectly", func(t *testing.T) {
@@ -0,0 +1,252 @@
+package backends
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+const (
+	// syslogFacility is the "log audit" facility from RFC 5424.
+	syslogFacility          = 13
+	syslogSeverityWarning   = 4
+	syslogSeverityInfo      = 6
+	syslogAppName           = "coder"
+	syslogMsgID             = "audit"
+	syslogTimestampFormat   = "2006-01-02T15:04:05.000000Z07:00"
+	syslogWriteTimeout      = 10 * time.Second
+	syslogMaxHostnameLength = 255
+	syslogDialTimeout       = 10 * time.Second
+	// syslogQueueSize bounds the memory used while the syslog server is slow
+	// or unavailable. Audit logs are still stored in the database when the
+	// queue is full.
+	syslogQueueSize = 10_000
+)
+
+type SyslogOptions struct {
+	// Network is one of "udp", "tcp" or "tls".
+	Network string
+	// Address is the host and port of the syslog server.
+	Address string
+	// TLSConfig is used to connect with the tls network.
+	TLSConfig *tls.Config
+	// Format is the format of the message of each entry.
+	Format codersdk.AuditLoggingSyslogFormat
+	// Hostname is sent in each entry. It defaults to the hostname of the
+	// machine.
+	Hostname string
+	Logger   slog.Logger
+}
+
+// SyslogBackend streams audit logs to a syslog server using RFC 5424. Entries
+// sent over tcp and tls are framed by octet counting as described in RFC 6587.
+// Export only queues entries, so a slow or unavailable server never holds up
+// requests. Entries are dropped when the queue is full or can't be written.
+type SyslogBackend struct {
+	opts    SyslogOptions
+	queue   chan []byte
+	dropped atomic.Int64
+	// conn is only used by run.
+	conn net.Conn
+
+	cancel context.CancelFunc
+	closed chan struct{}
+}
+
+func NewSyslog(opts SyslogOptions) (*SyslogBackend, error) {
+	switch opts.Network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, xerrors.Errorf("unsupported syslog network %q, must be udp, tcp or tls", opts.Network)
+	}
+	switch opts.Format {
+	case "":
+		opts.Format = codersdk.AuditLoggingSyslogFormatJSON
+	case codersdk.AuditLoggingSyslogFormatJSON, codersdk.AuditLoggingSyslogFormatCEF, codersdk.AuditLoggingSyslogFormatLEEF:
+	default:
+		return nil, xerrors.Errorf("unsupported syslog format %q", opts.Format)
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	if opts.Hostname == "" {
+		opts.Hostname = "-"
+	}
+	if len(opts.Hostname) > syslogMaxHostnameLength {
+		opts.Hostname = opts.Hostname[:syslogMaxHostnameLength]
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &SyslogBackend{
+		opts:   opts,
+		queue:  make(chan []byte, syslogQueueSize),
+		cancel: cancel,
+		closed: make(chan struct{}),
+	}
+	go b.run(ctx)
+	return b, nil
+}
+
+func (*SyslogBackend) Decision() audit.FilterDecision {
+	return audit.FilterDecisionExport
+}
+
+func (b *SyslogBackend) Export(ctx context.Context, alog database.AuditLog, details audit.BackendDetails) error {
+	msg, err := b.message(newEvent(alog, details))
+	if err != nil {
+		return xerrors.Errorf("format syslog message: %w", err)
+	}
+
+	select {
+	case b.queue <- msg:
+	default:
+		// Returning an error would stop the audit log from being exported to
+		// the backends after this one.
+		b.opts.Logger.Warn(ctx, "syslog audit log queue is full, dropping audit log",
+			slog.F("address", b.opts.Address),
+			slog.F("dropped", b.dropped.Add(1)),
+		)
+	}
+	return nil
+}
+
+// Dropped returns the number of audit logs that were never written to the
+// syslog server.
+func (b *SyslogBackend) Dropped() int64 {
+	return b.dropped.Load()
+}
+
+// Close stops writing audit logs and closes the connection to the syslog
+// server. Audit logs that are still queued are dropped.
+func (b *SyslogBackend) Close() error {
+	b.cancel()
+	<-b.closed
+	return nil
+}
+
+func (b *SyslogBackend) run(ctx context.Context) {
+	defer close(b.closed)
+	defer func() {
+		if b.conn != nil {
+			_ = b.conn.Close()
+		}
+	}()
+
+	for {
+		var msg []byte
+		select {
+		case <-ctx.Done():
+			return
+		case msg = <-b.queue:
+		}
+
+		err := b.send(ctx, msg)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.opts.Logger.Warn(ctx, "failed to write audit log to syslog server, dropping it",
+				slog.F("address", b.opts.Address),
+				slog.F("dropped", b.dropped.Add(1)),
+				slog.Error(err),
+			)
+		}
+	}
+}
+
+// send writes a message, redialing once since a connection that was closed by
+// the server is only noticed when writing to it.
+func (b *SyslogBackend) send(ctx context.Context, msg []byte) error {
+	for attempt := 0; ; attempt++ {
+		err := b.write(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if b.conn != nil {
+			_ = b.conn.Close()
+			b.conn = nil
+		}
+		if attempt > 0 || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func (b *SyslogBackend) write(ctx context.Context, msg []byte) error {
+	if b.conn == nil {
+		conn, err := b.dial(ctx)
+		if err != nil {
+			return err
+		}
+		b.conn = conn
+	}
+
+	deadline := time.Now().Add(syslogWriteTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	err := b.conn.SetWriteDeadline(deadline)
+	if err != nil {
+		return err
+	}
+	_, err = b.conn.Write(msg)
+	return err
+}
+
+func (b *SyslogBackend) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: syslogDialTimeout}
+	if b.opts.Network == "tls" {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: b.opts.TLSConfig}
+		return tlsDialer.DialContext(ctx, "tcp", b.opts.Address)
+	}
+	return dialer.DialContext(ctx, b.opts.Network, b.opts.Address)
+}
+
+// message returns a framed RFC 5424 syslog entry for the audit log.
+func (b *SyslogBackend) message(e event) ([]byte, error) {
+	var (
+		body []byte
+		err  error
+	)
+	switch b.opts.Format {
+	case codersdk.AuditLoggingSyslogFormatCEF:
+		body = formatCEF(e)
+	case codersdk.AuditLoggingSyslogFormatLEEF:
+		body = formatLEEF(e)
+	default:
+		body, err = formatJSON(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	severity := syslogSeverityInfo
+	if e.failed() {
+		severity = syslogSeverityWarning
+	}
+	// There's no structured data, so it's the nil value "-".
+	msg := fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogFacility*8+severity,
+		e.Time.UTC().Format(syslogTimestampFormat),
+		b.opts.Hostname,
+		syslogAppName,
+		syslogMsgID,
+		body,
+	)
+	if b.opts.Network == "udp" {
+		return []byte(msg), nil
+	}
+	return []byte(strconv.Itoa(len(msg)) + " " + msg), nil
+}
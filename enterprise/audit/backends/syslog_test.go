@@ -0,0 +1,246 @@
+package backends_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestSyslogBackend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TCP", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		messages := listenSyslogTCP(t)
+		backend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network:  "tcp",
+			Address:  messages.addr,
+			Hostname: "coder-test",
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		err = backend.Export(ctx, alog, audit.BackendDetails{Actor: &audit.Actor{
+			ID:       alog.UserID,
+			Username: "coadler",
+			Email:    "doug@coder.com",
+		}})
+		require.NoError(t, err)
+
+		msg := testutil.RequireRecvCtx(ctx, t, messages.ch)
+		// 13 is the log audit facility and 6 is the informational severity.
+		require.True(t, strings.HasPrefix(msg, "<110>1 "), msg)
+		parts := strings.SplitN(msg, " ", 8)
+		require.Len(t, parts, 8)
+		assert.Equal(t, "coder-test", parts[2])
+		assert.Equal(t, "coder", parts[3])
+		assert.Equal(t, "audit", parts[5])
+
+		var body struct {
+			ID             string       `json:"id"`
+			IP             string       `json:"ip"`
+			ResourceTarget string       `json:"resource_target"`
+			Actor          *audit.Actor `json:"actor"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(parts[7]), &body))
+		assert.Equal(t, alog.ID.String(), body.ID)
+		assert.Equal(t, "127.0.0.1", body.IP)
+		assert.Equal(t, alog.ResourceTarget, body.ResourceTarget)
+		require.NotNil(t, body.Actor)
+		assert.Equal(t, "coadler", body.Actor.Username)
+
+		// The connection is reused.
+		err = backend.Export(ctx, audittest.RandomLog(), audit.BackendDetails{})
+		require.NoError(t, err)
+		_ = testutil.RequireRecvCtx(ctx, t, messages.ch)
+	})
+
+	t.Run("UDP", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		backend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network: "udp",
+			Address: conn.LocalAddr().String(),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		alog.StatusCode = http.StatusForbidden
+		err = backend.Export(ctx, alog, audit.BackendDetails{})
+		require.NoError(t, err)
+
+		buf := make([]byte, 64*1024)
+		err = conn.SetReadDeadline(time.Now().Add(testutil.WaitShort))
+		require.NoError(t, err)
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		// Datagrams aren't framed, and failures have the warning severity.
+		msg := string(buf[:n])
+		require.True(t, strings.HasPrefix(msg, "<108>1 "), msg)
+		require.Contains(t, msg, alog.ID.String())
+	})
+
+	t.Run("CEF", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		messages := listenSyslogTCP(t)
+		backend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network: "tcp",
+			Address: messages.addr,
+			Format:  codersdk.AuditLoggingSyslogFormatCEF,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		alog.ResourceTarget = `a=b\c`
+		err = backend.Export(ctx, alog, audit.BackendDetails{})
+		require.NoError(t, err)
+
+		msg := testutil.RequireRecvCtx(ctx, t, messages.ch)
+		_, cef, ok := strings.Cut(msg, " - audit - ")
+		require.True(t, ok, msg)
+		require.True(t, strings.HasPrefix(cef, "CEF:0|Coder|Coder|"), cef)
+		require.Contains(t, cef, "|organization.delete|delete organization|3|")
+		require.Contains(t, cef, "externalId="+alog.ID.String())
+		require.Contains(t, cef, `cs1=a\=b\\c`)
+		require.Contains(t, cef, "src=127.0.0.1")
+	})
+
+	t.Run("LEEF", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		messages := listenSyslogTCP(t)
+		backend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network: "tcp",
+			Address: messages.addr,
+			Format:  codersdk.AuditLoggingSyslogFormatLEEF,
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		alog := audittest.RandomLog()
+		alog.ResourceTarget = "tab\tseparated"
+		err = backend.Export(ctx, alog, audit.BackendDetails{})
+		require.NoError(t, err)
+
+		msg := testutil.RequireRecvCtx(ctx, t, messages.ch)
+		_, leef, ok := strings.Cut(msg, " - audit - ")
+		require.True(t, ok, msg)
+		require.True(t, strings.HasPrefix(leef, "LEEF:1.0|Coder|Coder|"), leef)
+		attrs := strings.Split(leef[strings.LastIndex(leef, "|")+1:], "\t")
+		require.Contains(t, attrs, "cat=organization")
+		require.Contains(t, attrs, "resourceTarget=tab separated")
+		require.Contains(t, attrs, "id="+alog.ID.String())
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitShort)
+		// Nothing is listening on the address once the listener is closed.
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		require.NoError(t, l.Close())
+		backend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network: "tcp",
+			Address: l.Addr().String(),
+		})
+		require.NoError(t, err)
+		defer backend.Close()
+
+		// Audit logs are dropped instead of failing the request.
+		err = backend.Export(ctx, audittest.RandomLog(), audit.BackendDetails{})
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return backend.Dropped() == 1
+		}, testutil.WaitShort, testutil.IntervalFast)
+	})
+
+	t.Run("InvalidNetwork", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := backends.NewSyslog(backends.SyslogOptions{
+			Network: "http",
+			Address: "127.0.0.1:514",
+		})
+		require.Error(t, err)
+	})
+}
+
+type syslogMessages struct {
+	addr string
+	ch   chan string
+}
+
+// listenSyslogTCP accepts syslog connections, and sends the messages read from
+// them after removing the octet counting frames.
+func listenSyslogTCP(t *testing.T) syslogMessages {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = l.Close()
+	})
+
+	messages := syslogMessages{
+		addr: l.Addr().String(),
+		ch:   make(chan string, 16),
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				for {
+					length, err := r.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+					if err != nil {
+						return
+					}
+					msg := make([]byte, n)
+					_, err = io.ReadFull(r, msg)
+					if err != nil {
+						return
+					}
+					messages.ch <- string(msg)
+				}
+			}()
+		}
+	}()
+	return messages
+}
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
+)
+
+// chainPageSize is the number of audit logs read at a time when verifying a
+// chain.
+const chainPageSize = 1000
+
+// chainedAuditLog is hashed to chain an audit log to the previous one in its
+// organization. Every column is included in a fixed order, using the
+// representation that's read back from the database, so the hash can be
+// computed again when verifying.
+type chainedAuditLog struct {
+	ChainIndex       int64                 `json:"chain_index"`
+	ID               uuid.UUID             `json:"id"`
+	Time             int64                 `json:"time"`
+	UserID           uuid.UUID             `json:"user_id"`
+	OrganizationID   uuid.UUID             `json:"organization_id"`
+	IP               string                `json:"ip"`
+	UserAgent        string                `json:"user_agent"`
+	ResourceType     database.ResourceType `json:"resource_type"`
+	ResourceID       uuid.UUID             `json:"resource_id"`
+	ResourceTarget   string                `json:"resource_target"`
+	Action           database.AuditAction  `json:"action"`
+	Diff             json.RawMessage       `json:"diff"`
+	StatusCode       int32                 `json:"status_code"`
+	AdditionalFields json.RawMessage       `json:"additional_fields"`
+	RequestID        uuid.UUID             `json:"request_id"`
+	ResourceIcon     string                `json:"resource_icon"`
+}
+
+// ChainHash returns the HMAC-SHA256 of the audit log chained to the hash of
+// the previous audit log in its organization. The key isn't stored in the
+// database, so someone who can only change the database can't compute the
+// hash of a modified entry. The time must already be rounded to the
+// microsecond precision of the database.
+func ChainHash(key, previous []byte, alog database.AuditLog) ([]byte, error) {
+	diff, err := canonicalJSON(alog.Diff)
+	if err != nil {
+		return nil, xerrors.Errorf("canonicalize diff: %w", err)
+	}
+	additionalFields, err := canonicalJSON(alog.AdditionalFields)
+	if err != nil {
+		return nil, xerrors.Errorf("canonicalize additional fields: %w", err)
+	}
+	c := chainedAuditLog{
+		ChainIndex:       alog.ChainIndex.Int64,
+		ID:               alog.ID,
+		Time:             alog.Time.UnixMicro(),
+		UserID:           alog.UserID,
+		OrganizationID:   alog.OrganizationID,
+		UserAgent:        alog.UserAgent.String,
+		ResourceType:     alog.ResourceType,
+		ResourceID:       alog.ResourceID,
+		ResourceTarget:   alog.ResourceTarget,
+		Action:           alog.Action,
+		Diff:             diff,
+		StatusCode:       alog.StatusCode,
+		AdditionalFields: additionalFields,
+		RequestID:        alog.RequestID,
+		ResourceIcon:     alog.ResourceIcon,
+	}
+	// The mask of an address isn't always read back the way it was written.
+	if alog.Ip.Valid {
+		c.IP = alog.Ip.IPNet.IP.String()
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, xerrors.Errorf("marshal audit log: %w", err)
+	}
+
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write(previous)
+	_, _ = h.Write(data)
+	return h.Sum(nil), nil
+}
+
+// canonicalJSON re-encodes JSON, since jsonb columns don't preserve
+// whitespace or the order of keys.
+func canonicalJSON(data []byte) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// VerifyChain walks up to limit audit logs chained to the head after the
+// index, and reports entries that are missing or don't match their hash. When
+// an entry was changed and its hash was computed again, the entry after it is
+// reported instead. The index must be less than the index of the head.
+func VerifyChain(ctx context.Context, db database.Store, key []byte, head database.AuditLogChainHead, after, limit int64) (codersdk.AuditLogChain, error) {
+	to := min(after+limit, head.ChainIndex)
+	chain := codersdk.AuditLogChain{
+		OrganizationID: head.OrganizationID,
+		Length:         head.ChainIndex,
+		Hash:           hex.EncodeToString(head.Hash),
+		FromIndex:      after + 1,
+		ToIndex:        to,
+		Problems:       []codersdk.AuditLogChainProblem{},
+	}
+
+	var (
+		expected = after + 1
+		previous []byte
+		lastID   uuid.UUID
+		// anchored is false when the hash of the previous entry is unknown.
+		anchored = after == 0
+		done     bool
+	)
+	if !anchored {
+		// The first entry is chained to the last entry verified by the
+		// previous call. If that entry is missing, it was already reported.
+		alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{
+			OrganizationID:  head.OrganizationID,
+			AfterChainIndex: after - 1,
+			LimitOpt:        1,
+		})
+		if err != nil {
+			return codersdk.AuditLogChain{}, xerrors.Errorf("get chained audit logs: %w", err)
+		}
+		if len(alogs) > 0 && alogs[0].ChainIndex.Int64 == after {
+			previous = alogs[0].Hash
+			anchored = true
+		}
+	}
+
+	for !done && expected <= to {
+		pageSize := min(chainPageSize, to-expected+1)
+		alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{
+			OrganizationID:  head.OrganizationID,
+			AfterChainIndex: expected - 1,
+			LimitOpt:        int32(pageSize),
+		})
+		if err != nil {
+			return codersdk.AuditLogChain{}, xerrors.Errorf("get chained audit logs: %w", err)
+		}
+		if len(alogs) == 0 {
+			break
+		}
+
+		for _, alog := range alogs {
+			index := alog.ChainIndex.Int64
+			// Entries may be added to the chain after the head was read.
+			if index > to {
+				done = true
+				break
+			}
+			switch {
+			case index != expected:
+				chain.Problems = append(chain.Problems, codersdk.AuditLogChainProblem{
+					Type:      codersdk.AuditLogChainProblemGap,
+					FromIndex: expected,
+					ToIndex:   index - 1,
+				})
+				// The hash of the missing entry is unknown, so the entry
+				// after the gap can't be verified.
+			case !anchored:
+				// The entry before the first one is missing, so it can't be
+				// verified either.
+			default:
+				hash, err := ChainHash(key, previous, alog)
+				if err != nil {
+					return codersdk.AuditLogChain{}, xerrors.Errorf("hash audit log %s: %w", alog.ID, err)
+				}
+				if !hmac.Equal(hash, alog.Hash) {
+					chain.Problems = append(chain.Problems, codersdk.AuditLogChainProblem{
+						Type:       codersdk.AuditLogChainProblemModified,
+						FromIndex:  index,
+						ToIndex:    index,
+						AuditLogID: alog.ID,
+					})
+				}
+			}
+			// Continue from the stored hash, so a single change isn't
+			// reported for every entry after it.
+			previous = alog.Hash
+			lastID = alog.ID
+			anchored = true
+			expected = index + 1
+		}
+		if int64(len(alogs)) < pageSize {
+			done = true
+		}
+	}
+
+	switch {
+	case expected <= to:
+		chain.Problems = append(chain.Problems, codersdk.AuditLogChainProblem{
+			Type:      codersdk.AuditLogChainProblemGap,
+			FromIndex: expected,
+			ToIndex:   to,
+		})
+	case to == head.ChainIndex && !bytes.Equal(previous, head.Hash):
+		// The last entry was replaced.
+		chain.Problems = append(chain.Problems, codersdk.AuditLogChainProblem{
+			Type:       codersdk.AuditLogChainProblemModified,
+			FromIndex:  head.ChainIndex,
+			ToIndex:    head.ChainIndex,
+			AuditLogID: lastID,
+		})
+	}
+	return chain, nil
+}
@@ -0,0 +1,242 @@
+package audit_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+)
+
+// chainKey is the key the audit logs are chained with in tests.
+var chainKey = []byte("chain key")
+
+func TestChainHash(t *testing.T) {
+	t.Parallel()
+
+	// jsonb doesn't preserve whitespace or the order of keys.
+	alog := audittest.RandomLog()
+	reordered := alog
+	alog.Diff = []byte(`{"a": 1, "b": {"c": "d"}}`)
+	reordered.Diff = []byte(`{"b":{"c":"d"},"a":1}`)
+	hash, err := audit.ChainHash(chainKey, nil, alog)
+	require.NoError(t, err)
+	reorderedHash, err := audit.ChainHash(chainKey, nil, reordered)
+	require.NoError(t, err)
+	require.Equal(t, hash, reorderedHash)
+
+	modified := alog
+	modified.ResourceTarget = "someone else's organization"
+	modifiedHash, err := audit.ChainHash(chainKey, nil, modified)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, modifiedHash)
+
+	chainedHash, err := audit.ChainHash(chainKey, []byte("previous"), alog)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, chainedHash)
+
+	otherKeyHash, err := audit.ChainHash([]byte("other key"), nil, alog)
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherKeyHash)
+}
+
+func TestVerifyChain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 3)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, chainHead(t, db, orgID), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, orgID, chain.OrganizationID)
+		require.EqualValues(t, 3, chain.Length)
+		require.EqualValues(t, 1, chain.FromIndex)
+		require.EqualValues(t, 3, chain.ToIndex)
+		require.Empty(t, chain.Problems)
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 5)
+		head := chainHead(t, db, orgID)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, head, 0, 2)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, chain.FromIndex)
+		require.EqualValues(t, 2, chain.ToIndex)
+		require.Empty(t, chain.Problems)
+
+		// Later ranges are chained to the last entry of the previous one.
+		chain, err = audit.VerifyChain(ctx, db, chainKey, head, 2, 2)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, chain.FromIndex)
+		require.EqualValues(t, 4, chain.ToIndex)
+		require.Empty(t, chain.Problems)
+
+		chain, err = audit.VerifyChain(ctx, db, chainKey, head, 4, 2)
+		require.NoError(t, err)
+		require.EqualValues(t, 5, chain.FromIndex)
+		require.EqualValues(t, 5, chain.ToIndex)
+		require.Empty(t, chain.Problems)
+	})
+
+	t.Run("Gap", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 2)
+		// The third entry is missing.
+		forged := forgeChainedLog(t, db, orgID, 4)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, chainHead(t, db, orgID), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:      codersdk.AuditLogChainProblemGap,
+			FromIndex: 3,
+			ToIndex:   3,
+		}}, chain.Problems)
+		require.Equal(t, forged.ChainIndex.Int64, chain.Length)
+	})
+
+	t.Run("Modified", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 2)
+		forged := forgeChainedLog(t, db, orgID, 3)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, chainHead(t, db, orgID), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:       codersdk.AuditLogChainProblemModified,
+			FromIndex:  3,
+			ToIndex:    3,
+			AuditLogID: forged.ID,
+		}}, chain.Problems)
+	})
+
+	t.Run("OtherKey", func(t *testing.T) {
+		t.Parallel()
+
+		// Someone with access to the database can read the hash of the
+		// previous entry, but not the key.
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 2)
+		head := chainHead(t, db, orgID)
+		alog := audittest.RandomLog()
+		alog.OrganizationID = orgID
+		alog.Time = alog.Time.Round(time.Microsecond)
+		alog.ChainIndex = sql.NullInt64{Int64: 3, Valid: true}
+		var err error
+		alog.Hash, err = audit.ChainHash([]byte("guessed key"), head.Hash, alog)
+		require.NoError(t, err)
+		_, err = db.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
+		require.NoError(t, err)
+		err = db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+			ChainIndex:     3,
+			Hash:           alog.Hash,
+			UpdatedAt:      dbtime.Now(),
+			OrganizationID: orgID,
+		})
+		require.NoError(t, err)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, chainHead(t, db, orgID), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:       codersdk.AuditLogChainProblemModified,
+			FromIndex:  3,
+			ToIndex:    3,
+			AuditLogID: alog.ID,
+		}}, chain.Problems)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		db, orgID := chainedDB(t, 2)
+		err := db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+			ChainIndex:     4,
+			Hash:           []byte("missing"),
+			UpdatedAt:      dbtime.Now(),
+			OrganizationID: orgID,
+		})
+		require.NoError(t, err)
+
+		chain, err := audit.VerifyChain(ctx, db, chainKey, chainHead(t, db, orgID), 0, 100)
+		require.NoError(t, err)
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:      codersdk.AuditLogChainProblemGap,
+			FromIndex: 3,
+			ToIndex:   4,
+		}}, chain.Problems)
+	})
+}
+
+// chainedDB returns a database with the given number of chained audit logs in
+// an organization.
+func chainedDB(t *testing.T, count int) (database.Store, uuid.UUID) {
+	t.Helper()
+
+	db := dbmem.New()
+	pgb := backends.NewPostgres(db, true, chainKey)
+	orgID := uuid.New()
+	for i := 0; i < count; i++ {
+		alog := audittest.RandomLog()
+		alog.OrganizationID = orgID
+		err := pgb.Export(context.Background(), alog, audit.BackendDetails{})
+		require.NoError(t, err)
+	}
+	return db, orgID
+}
+
+// forgeChainedLog inserts an audit log at the chain index without knowing the
+// hash of the previous entry, like someone with access to the database would.
+func forgeChainedLog(t *testing.T, db database.Store, orgID uuid.UUID, index int64) database.AuditLog {
+	t.Helper()
+
+	ctx := context.Background()
+	alog := audittest.RandomLog()
+	alog.OrganizationID = orgID
+	alog.ChainIndex = sql.NullInt64{Int64: index, Valid: true}
+	alog.Hash = []byte("forged")
+	_, err := db.InsertAuditLog(ctx, database.InsertAuditLogParams(alog))
+	require.NoError(t, err)
+	err = db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+		ChainIndex:     index,
+		Hash:           alog.Hash,
+		UpdatedAt:      dbtime.Now(),
+		OrganizationID: orgID,
+	})
+	require.NoError(t, err)
+	return alog
+}
+
+func chainHead(t *testing.T, db database.Store, orgID uuid.UUID) database.AuditLogChainHead {
+	t.Helper()
+
+	heads, err := db.GetAuditLogChainHeads(context.Background())
+	require.NoError(t, err)
+	for _, head := range heads {
+		if head.OrganizationID == orgID {
+			return head
+		}
+	}
+	require.FailNow(t, "audit log chain head not found")
+	return database.AuditLogChainHead{}
+}
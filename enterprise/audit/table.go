@@ -16,15 +16,19 @@ import (
 // AuditableResources map (below) as our documentation - generated in scripts/auditdocgen/main.go -
 // depends upon it.
 var AuditActionMap = map[string][]codersdk.AuditAction{
-	"GitSSHKey":       {codersdk.AuditActionCreate},
-	"Template":        {codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"TemplateVersion": {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
-	"User":            {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"Workspace":       {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"WorkspaceBuild":  {codersdk.AuditActionStart, codersdk.AuditActionStop},
-	"Group":           {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
-	"APIKey":          {codersdk.AuditActionLogin, codersdk.AuditActionLogout, codersdk.AuditActionRegister, codersdk.AuditActionCreate, codersdk.AuditActionDelete},
-	"License":         {codersdk.AuditActionCreate, codersdk.AuditActionDelete},
+	"GitSSHKey":              {codersdk.AuditActionCreate},
+	"Template":               {codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"TemplateVersion":        {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
+	"User":                   {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"Workspace":              {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"WorkspaceBuild":         {codersdk.AuditActionStart, codersdk.AuditActionStop},
+	"Group":                  {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"APIKey":                 {codersdk.AuditActionLogin, codersdk.AuditActionLogout, codersdk.AuditActionRegister, codersdk.AuditActionCreate, codersdk.AuditActionDelete},
+	"License":                {codersdk.AuditActionCreate, codersdk.AuditActionDelete},
+	"NotificationTemplate":   {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
+	"QuotaBudget":            {codersdk.AuditActionCreate, codersdk.AuditActionWrite, codersdk.AuditActionDelete},
+	"TemplateReleaseChannel": {codersdk.AuditActionCreate, codersdk.AuditActionWrite},
+	"TemplateGitSync":        {codersdk.AuditActionWrite, codersdk.AuditActionDelete},
 }
 
 type Action string
@@ -161,6 +165,10 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"deleting_at":        ActionTrack,
 		"automatic_updates":  ActionTrack,
 		"favorite":           ActionTrack,
+		"user_acl":           ActionTrack,
+		"group_acl":          ActionTrack,
+		"last_active_at":     ActionIgnore,
+		"release_channel":    ActionTrack,
 	},
 	&database.WorkspaceBuild{}: {
 		"id":                      ActionIgnore,
@@ -203,6 +211,8 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"ip_address":       ActionIgnore,
 		"scope":            ActionIgnore,
 		"token_name":       ActionIgnore,
+		"scopes":           ActionTrack,
+		"allow_list":       ActionTrack,
 	},
 	&database.AuditOAuthConvertState{}: {
 		"created_at":      ActionTrack,
@@ -219,6 +229,19 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"id":              ActionIgnore,
 		"notifier_paused": ActionTrack,
 	},
+	&database.NotificationTemplate{}: {
+		"id":                      ActionIgnore,
+		"name":                    ActionIgnore, // Never changes.
+		"title_template":          ActionIgnore, // Never changes through the API.
+		"body_template":           ActionIgnore, // Never changes through the API.
+		"actions":                 ActionIgnore, // Never changes through the API.
+		"group":                   ActionIgnore, // Never changes through the API.
+		"mandatory":               ActionTrack,
+		"digest":                  ActionIgnore, // Never changes through the API.
+		"title_template_override": ActionTrack,
+		"body_template_override":  ActionTrack,
+		"actions_override":        ActionTrack,
+	},
 	// TODO: track an ID here when the below ticket is completed:
 	// https://github.com/coder/coder/pull/6012
 	&database.License{}: {
@@ -251,6 +274,8 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"name":         ActionTrack,
 		"icon":         ActionTrack,
 		"callback_url": ActionTrack,
+		"client_type":  ActionTrack,
+		"grant_types":  ActionTrack,
 	},
 	&database.OAuth2ProviderAppSecret{}: {
 		"id":             ActionIgnore,
@@ -271,6 +296,42 @@ var auditableResourcesTypes = map[any]map[string]Action{
 		"display_name": ActionTrack,
 		"icon":         ActionTrack,
 	},
+	&database.QuotaBudget{}: {
+		"id":              ActionIgnore,
+		"organization_id": ActionIgnore, // Never changes.
+		"group_id":        ActionTrack,
+		"user_id":         ActionTrack,
+		"period":          ActionTrack,
+		"soft_limit":      ActionTrack,
+		"hard_limit":      ActionTrack,
+		"stop_workspaces": ActionTrack,
+		"created_at":      ActionIgnore, // Never changes.
+		"updated_at":      ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
+	&database.TemplateReleaseChannel{}: {
+		"id":                  ActionIgnore,
+		"template_id":         ActionIgnore, // Never changes.
+		"name":                ActionTrack,
+		"template_version_id": ActionTrack,
+		"created_at":          ActionIgnore, // Never changes.
+		"updated_at":          ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
+	&database.TemplateGitSync{}: {
+		"template_id":               ActionIgnore, // Never changes.
+		"repository_url":            ActionTrack,
+		"branch":                    ActionTrack,
+		"directory":                 ActionTrack,
+		"external_auth_provider_id": ActionTrack,
+		"auto_activate":             ActionTrack,
+		"webhook_secret":            ActionSecret,
+		"created_by":                ActionTrack,
+		"last_commit_sha":           ActionIgnore, // Changes on every sync.
+		"last_template_version_id":  ActionIgnore, // Changes on every sync.
+		"last_synced_at":            ActionIgnore, // Changes on every sync.
+		"last_error":                ActionIgnore, // Changes on every sync.
+		"created_at":                ActionIgnore, // Never changes.
+		"updated_at":                ActionIgnore, // Changes, but is implicit and not helpful in a diff.
+	},
 }
 
 // auditMap converts a map of struct pointers to a map of struct names as
@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/cli/cliui"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/serpent"
+)
+
+func (r *RootCmd) audit() *serpent.Command {
+	cmd := &serpent.Command{
+		Use:   "audit",
+		Short: "Manage audit logs",
+		Handler: func(inv *serpent.Invocation) error {
+			return inv.Command.HelpHandler(inv)
+		},
+		Children: []*serpent.Command{
+			r.auditVerify(),
+		},
+	}
+
+	return cmd
+}
+
+type auditChainTableRow struct {
+	OrganizationID uuid.UUID `table:"organization_id,default_sort"`
+	Length         int64     `table:"length"`
+	Hash           string    `table:"hash"`
+	Problems       string    `table:"problems"`
+}
+
+func (r *RootCmd) auditVerify() *serpent.Command {
+	formatter := cliui.NewOutputFormatter(
+		cliui.ChangeFormatterData(
+			cliui.TableFormat([]auditChainTableRow{}, nil),
+			func(data any) (any, error) {
+				verification, ok := data.(codersdk.AuditLogVerification)
+				if !ok {
+					return nil, xerrors.Errorf("expected codersdk.AuditLogVerification got %T", data)
+				}
+
+				rows := make([]auditChainTableRow, 0, len(verification.Chains))
+				for _, chain := range verification.Chains {
+					rows = append(rows, auditChainTableRow{
+						OrganizationID: chain.OrganizationID,
+						Length:         chain.Length,
+						Hash:           chain.Hash,
+						Problems:       auditChainProblems(chain.Problems),
+					})
+				}
+				return rows, nil
+			},
+		),
+		cliui.JSONFormat(),
+	)
+
+	client := new(codersdk.Client)
+	cmd := &serpent.Command{
+		Use:   "verify",
+		Short: "Verify that audit logs haven't been modified",
+		Long: "Walks the hash chain of the audit logs of each organization, and reports " +
+			"entries that are missing or don't match their hash. The command fails " +
+			"if any problem is found. Audit logs that aren't in an organization are " +
+			"in the chain with the nil organization ID. Audit logs are only chained " +
+			"when the server is started with --audit-logging-chain-key.",
+		Middleware: serpent.Chain(
+			serpent.RequireNArgs(0),
+			r.InitClient(client),
+		),
+		Handler: func(inv *serpent.Invocation) error {
+			ctx := inv.Context()
+
+			verification, err := verifyAuditLogs(ctx, client)
+			if err != nil {
+				return err
+			}
+
+			out, err := formatter.Format(ctx, verification)
+			if err != nil {
+				return xerrors.Errorf("display audit log verification: %w", err)
+			}
+			_, _ = fmt.Fprintln(inv.Stdout, out)
+
+			if !verification.Valid {
+				return xerrors.New("audit logs failed verification")
+			}
+			return nil
+		},
+	}
+	formatter.AttachOptions(&cmd.Options)
+
+	return cmd
+}
+
+// verifyAuditLogs verifies every chain, a range of entries at a time.
+func verifyAuditLogs(ctx context.Context, client *codersdk.Client) (codersdk.AuditLogVerification, error) {
+	verification := codersdk.AuditLogVerification{
+		Valid:  true,
+		Chains: []codersdk.AuditLogChain{},
+	}
+	chains := map[uuid.UUID]int{}
+	var afterIndex int64
+	for {
+		page, err := client.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{
+			AfterIndex: afterIndex,
+		})
+		if err != nil {
+			return codersdk.AuditLogVerification{}, xerrors.Errorf("verify audit logs: %w", err)
+		}
+		if len(page.Chains) == 0 {
+			return verification, nil
+		}
+		if !page.Valid {
+			verification.Valid = false
+		}
+
+		for _, chain := range page.Chains {
+			afterIndex = max(afterIndex, chain.ToIndex)
+			i, ok := chains[chain.OrganizationID]
+			if !ok {
+				chains[chain.OrganizationID] = len(verification.Chains)
+				verification.Chains = append(verification.Chains, chain)
+				continue
+			}
+			existing := &verification.Chains[i]
+			// The chain may have grown since the previous range.
+			existing.Length = chain.Length
+			existing.Hash = chain.Hash
+			existing.ToIndex = chain.ToIndex
+			existing.Problems = append(existing.Problems, chain.Problems...)
+		}
+	}
+}
+
+// auditChainProblems describes the problems of a chain on a single line.
+func auditChainProblems(problems []codersdk.AuditLogChainProblem) string {
+	if len(problems) == 0 {
+		return "none"
+	}
+	descriptions := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		indexes := fmt.Sprintf("%d", problem.FromIndex)
+		if problem.ToIndex != problem.FromIndex {
+			indexes = fmt.Sprintf("%d-%d", problem.FromIndex, problem.ToIndex)
+		}
+		switch problem.Type {
+		case codersdk.AuditLogChainProblemModified:
+			descriptions = append(descriptions, fmt.Sprintf("%s %s (%s)", problem.Type, indexes, problem.AuditLogID))
+		default:
+			descriptions = append(descriptions, fmt.Sprintf("%s %s", problem.Type, indexes))
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,98 @@
+package cli_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/coder/coder/v2/cli/clitest"
+	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
+	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
+	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/testutil"
+	"github.com/coder/serpent"
+)
+
+func TestAuditVerify(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	db, pubsub := dbtestutil.NewDB(t)
+	chainKey := []byte("0123456789abcdef0123456789abcdef")
+	dv := coderdtest.DeploymentValues(t)
+	dv.AuditLogging.ChainKey = serpent.String(chainKey)
+	client, user := coderdenttest.New(t, &coderdenttest.Options{
+		Options: &coderdtest.Options{
+			DeploymentValues: dv,
+			Database:         db,
+			Pubsub:           pubsub,
+			Auditor:          audit.NewAuditor(db, audit.DefaultFilter, backends.NewPostgres(db, true, chainKey)),
+		},
+		AuditLogging: true,
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureAuditLog: 1,
+			},
+		},
+	})
+
+	// Chain an audit log without going through the API.
+	orgID := user.OrganizationID
+	alog := audittest.RandomLog()
+	alog.OrganizationID = orgID
+	err := backends.NewPostgres(db, true, chainKey).Export(ctx, alog, audit.BackendDetails{})
+	require.NoError(t, err)
+
+	inv, conf := newCLI(t, "audit", "verify", "--output", "json")
+	out := new(bytes.Buffer)
+	inv.Stdout = out
+	clitest.SetupConfig(t, client, conf)
+	err = inv.WithContext(ctx).Run()
+	require.NoError(t, err)
+
+	var verification codersdk.AuditLogVerification
+	require.NoError(t, json.Unmarshal(out.Bytes(), &verification))
+	require.True(t, verification.Valid)
+
+	// Replace the hash of the last entry in the chain.
+	heads, err := db.GetAuditLogChainHeads(ctx)
+	require.NoError(t, err)
+	var head database.AuditLogChainHead
+	for _, h := range heads {
+		if h.OrganizationID == orgID {
+			head = h
+		}
+	}
+	require.Positive(t, head.ChainIndex)
+	forged := audittest.RandomLog()
+	forged.OrganizationID = orgID
+	forged.ChainIndex = sql.NullInt64{Int64: head.ChainIndex + 1, Valid: true}
+	forged.Hash = []byte("forged")
+	_, err = db.InsertAuditLog(ctx, database.InsertAuditLogParams(forged))
+	require.NoError(t, err)
+	err = db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+		ChainIndex:     forged.ChainIndex.Int64,
+		Hash:           forged.Hash,
+		UpdatedAt:      dbtime.Now(),
+		OrganizationID: orgID,
+	})
+	require.NoError(t, err)
+
+	inv, conf = newCLI(t, "audit", "verify")
+	out = new(bytes.Buffer)
+	inv.Stdout = out
+	clitest.SetupConfig(t, client, conf)
+	err = inv.WithContext(ctx).Run()
+	require.ErrorContains(t, err, "audit logs failed verification")
+	require.Contains(t, out.String(), forged.ID.String())
+}
@@ -4,17 +4,24 @@ package cli
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"database/sql"
 	"encoding/base64"
 	"errors"
 	"io"
+	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"golang.org/x/xerrors"
 	"tailscale.com/derp"
 	"tailscale.com/types/key"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/codersdk"
 	"github.com/coder/coder/v2/cryptorand"
 	"github.com/coder/coder/v2/enterprise/audit"
 	"github.com/coder/coder/v2/enterprise/audit/backends"
@@ -74,12 +81,20 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 			options.DERPServer.SetMeshKey(meshKey)
 		}
 
-		options.Auditor = audit.NewAuditor(
-			options.Database,
-			audit.DefaultFilter,
-			backends.NewPostgres(options.Database, true),
+		chainKey := options.DeploymentValues.AuditLogging.ChainKey.Value()
+		if chainKey != "" && len(chainKey) < 32 {
+			return nil, nil, xerrors.New("audit-logging-chain-key must be at least 32 characters long")
+		}
+		auditBackends := []audit.Backend{
+			backends.NewPostgres(options.Database, true, []byte(chainKey)),
 			backends.NewSlog(options.Logger),
-		)
+		}
+		streamingBackends, closeStreamingBackends, err := auditStreamingBackends(options)
+		if err != nil {
+			return nil, nil, err
+		}
+		auditBackends = append(auditBackends, streamingBackends...)
+		options.Auditor = audit.NewAuditor(options.Database, audit.DefaultFilter, auditBackends...)
 
 		options.TrialGenerator = trialer.New(options.Database, "https://v2-licensor.coder.com/trial", coderd.Keys)
 
@@ -116,9 +131,15 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 
 		api, err := coderd.New(ctx, o)
 		if err != nil {
+			closeStreamingBackends()
 			return nil, nil, err
 		}
-		return api.AGPL, api, nil
+		return api.AGPL, closerFunc(func() error {
+			err := api.Close()
+			// Audit logs are exported until the API is closed.
+			closeStreamingBackends()
+			return err
+		}), nil
 	})
 
 	cmd.AddSubcommands(
@@ -126,3 +147,92 @@ func (r *RootCmd) Server(_ func()) *serpent.Command {
 	)
 	return cmd
 }
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error {
+	return f()
+}
+
+// auditStreamingBackends returns the audit backends that stream audit logs to
+// external systems, and a function that closes them.
+func auditStreamingBackends(options *agplcoderd.Options) ([]audit.Backend, func(), error) {
+	cfg := options.DeploymentValues.AuditLogging
+	var (
+		auditBackends []audit.Backend
+		closers       []io.Closer
+	)
+	closeAll := func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}
+
+	if cfg.Syslog.Address.String() != "" {
+		address := cfg.Syslog.Address.Value()
+		if address.Host == "" {
+			return nil, nil, xerrors.New("audit-logging-syslog-address must have a host and port, e.g. tls://syslog.example.com:6514")
+		}
+		var tlsConfig *tls.Config
+		if address.Scheme == "tls" {
+			tlsConfig = &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				ServerName: address.Hostname(),
+			}
+			if caFile := cfg.Syslog.CAFile.String(); caFile != "" {
+				caPEM, err := os.ReadFile(caFile)
+				if err != nil {
+					return nil, nil, xerrors.Errorf("read audit-logging-syslog-ca-file: %w", err)
+				}
+				tlsConfig.RootCAs = x509.NewCertPool()
+				if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+					return nil, nil, xerrors.Errorf("audit-logging-syslog-ca-file %q has no PEM certificates", caFile)
+				}
+			}
+		}
+		syslogBackend, err := backends.NewSyslog(backends.SyslogOptions{
+			Network:   address.Scheme,
+			Address:   address.Host,
+			TLSConfig: tlsConfig,
+			Format:    codersdk.AuditLoggingSyslogFormat(cfg.Syslog.Format),
+			Logger:    options.Logger.Named("audit_syslog"),
+		})
+		if err != nil {
+			return nil, nil, xerrors.Errorf("create syslog audit backend: %w", err)
+		}
+		auditBackends = append(auditBackends, syslogBackend)
+		closers = append(closers, syslogBackend)
+	}
+
+	if cfg.HTTP.Endpoint.String() != "" {
+		header := http.Header{}
+		for _, h := range cfg.HTTP.Headers.Value() {
+			name, value, ok := strings.Cut(h, ":")
+			if !ok {
+				closeAll()
+				return nil, nil, xerrors.Errorf("audit-logging-http-headers must be in the format 'Name: Value', got %q", h)
+			}
+			header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+		}
+		bufferDir := cfg.HTTP.BufferDirectory.String()
+		if bufferDir == "" {
+			bufferDir = filepath.Join(options.DeploymentValues.CacheDir.String(), "audit")
+		}
+		httpBackend, err := backends.NewHTTP(backends.HTTPOptions{
+			Endpoint:        cfg.HTTP.Endpoint.Value(),
+			Header:          header,
+			BatchSize:       int(cfg.HTTP.BatchSize.Value()),
+			FlushInterval:   cfg.HTTP.FlushInterval.Value(),
+			BufferDirectory: bufferDir,
+			Logger:          options.Logger.Named("audit_http"),
+		})
+		if err != nil {
+			closeAll()
+			return nil, nil, xerrors.Errorf("create http audit backend: %w", err)
+		}
+		auditBackends = append(auditBackends, httpBackend)
+		closers = append(closers, httpBackend)
+	}
+
+	return auditBackends, closeAll, nil
+}
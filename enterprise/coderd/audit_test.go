@@ -2,15 +2,25 @@ package coderd_test
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbtestutil"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/codersdk"
+	entaudit "github.com/coder/coder/v2/enterprise/audit"
+	"github.com/coder/coder/v2/enterprise/audit/audittest"
+	"github.com/coder/coder/v2/enterprise/audit/backends"
 	"github.com/coder/coder/v2/enterprise/coderd/coderdenttest"
 	"github.com/coder/coder/v2/enterprise/coderd/license"
+	"github.com/coder/coder/v2/testutil"
 )
 
 func TestEnterpriseAuditLogs(t *testing.T) {
@@ -112,4 +122,177 @@ func TestEnterpriseAuditLogs(t *testing.T) {
 		// OrganizationID is deprecated, but make sure it is empty.
 		require.Equal(t, uuid.Nil, alogs.AuditLogs[0].OrganizationID)
 	})
+	t.Run("Verify", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		db, pubsub := dbtestutil.NewDB(t)
+		dv := coderdtest.DeploymentValues(t)
+		dv.AuditLogging.ChainKey = testAuditLogChainKey
+		client, user := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				DeploymentValues: dv,
+				Database:         db,
+				Pubsub:           pubsub,
+				Auditor:          entaudit.NewAuditor(db, entaudit.DefaultFilter, backends.NewPostgres(db, true, []byte(testAuditLogChainKey))),
+			},
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureAuditLog: 1,
+				},
+			},
+		})
+		// Creating a user is audited.
+		memberClient, _ := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+		// Audit logs are committed after the response is written.
+		var verification codersdk.AuditLogVerification
+		require.Eventually(t, func() bool {
+			var err error
+			verification, err = client.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{})
+			return assert.NoError(t, err) && len(verification.Chains) > 0
+		}, testutil.WaitShort, testutil.IntervalFast)
+		require.True(t, verification.Valid)
+		for _, chain := range verification.Chains {
+			require.Positive(t, chain.Length)
+			require.Empty(t, chain.Problems)
+		}
+
+		// Members can't read every audit log.
+		_, err := memberClient.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusForbidden, apiErr.StatusCode())
+
+		// Append an audit log directly to the database, without knowing the
+		// hash of the previous entry.
+		chain := verification.Chains[0]
+		forged := audittest.RandomLog()
+		forged.OrganizationID = chain.OrganizationID
+		forged.ChainIndex = sql.NullInt64{Int64: chain.Length + 1, Valid: true}
+		forged.Hash = []byte("forged")
+		_, err = db.InsertAuditLog(ctx, database.InsertAuditLogParams(forged))
+		require.NoError(t, err)
+		err = db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+			ChainIndex:     forged.ChainIndex.Int64,
+			Hash:           forged.Hash,
+			UpdatedAt:      dbtime.Now(),
+			OrganizationID: forged.OrganizationID,
+		})
+		require.NoError(t, err)
+
+		verification, err = client.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{})
+		require.NoError(t, err)
+		require.False(t, verification.Valid)
+		var problems []codersdk.AuditLogChainProblem
+		for _, c := range verification.Chains {
+			if c.OrganizationID == chain.OrganizationID {
+				problems = c.Problems
+			}
+		}
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:       codersdk.AuditLogChainProblemModified,
+			FromIndex:  forged.ChainIndex.Int64,
+			ToIndex:    forged.ChainIndex.Int64,
+			AuditLogID: forged.ID,
+		}}, problems)
+	})
+
+	t.Run("VerifyRewritten", func(t *testing.T) {
+		t.Parallel()
+		if !dbtestutil.WillUsePostgres() {
+			t.Skip("audit logs can only be rewritten in PostgreSQL")
+		}
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		db, pubsub, sqlDB := dbtestutil.NewDBWithSQLDB(t)
+		dv := coderdtest.DeploymentValues(t)
+		dv.AuditLogging.ChainKey = testAuditLogChainKey
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			Options: &coderdtest.Options{
+				DeploymentValues: dv,
+				Database:         db,
+				Pubsub:           pubsub,
+				Auditor:          entaudit.NewAuditor(db, entaudit.DefaultFilter, backends.NewPostgres(db, true, []byte(testAuditLogChainKey))),
+			},
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureAuditLog: 1,
+				},
+			},
+		})
+
+		orgID := uuid.New()
+		pgb := backends.NewPostgres(db, true, []byte(testAuditLogChainKey))
+		for i := 0; i < 2; i++ {
+			alog := audittest.RandomLog()
+			alog.OrganizationID = orgID
+			err := pgb.Export(ctx, alog, entaudit.BackendDetails{})
+			require.NoError(t, err)
+		}
+		alogs, err := db.GetChainedAuditLogs(ctx, database.GetChainedAuditLogsParams{
+			OrganizationID: orgID,
+			LimitOpt:       2,
+		})
+		require.NoError(t, err)
+		require.Len(t, alogs, 2)
+
+		// Change the last entry and compute its hash again from the hash of
+		// the previous entry, like someone with write access to the database
+		// would. They don't have the chain key.
+		rewritten := alogs[1]
+		rewritten.ResourceTarget = "someone else's workspace"
+		rewritten.Hash, err = entaudit.ChainHash([]byte("guessed key"), alogs[0].Hash, rewritten)
+		require.NoError(t, err)
+		_, err = sqlDB.ExecContext(ctx, "UPDATE audit_logs SET resource_target = $1, hash = $2 WHERE id = $3",
+			rewritten.ResourceTarget, rewritten.Hash, rewritten.ID)
+		require.NoError(t, err)
+		err = db.UpdateAuditLogChainHead(ctx, database.UpdateAuditLogChainHeadParams{
+			ChainIndex:     rewritten.ChainIndex.Int64,
+			Hash:           rewritten.Hash,
+			UpdatedAt:      dbtime.Now(),
+			OrganizationID: orgID,
+		})
+		require.NoError(t, err)
+
+		verification, err := client.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{})
+		require.NoError(t, err)
+		require.False(t, verification.Valid)
+		var problems []codersdk.AuditLogChainProblem
+		for _, c := range verification.Chains {
+			if c.OrganizationID == orgID {
+				problems = c.Problems
+			}
+		}
+		require.Equal(t, []codersdk.AuditLogChainProblem{{
+			Type:       codersdk.AuditLogChainProblemModified,
+			FromIndex:  rewritten.ChainIndex.Int64,
+			ToIndex:    rewritten.ChainIndex.Int64,
+			AuditLogID: rewritten.ID,
+		}}, problems)
+	})
+
+	t.Run("VerifyNotChained", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := testutil.Context(t, testutil.WaitLong)
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				Features: license.Features{
+					codersdk.FeatureAuditLog: 1,
+				},
+			},
+		})
+
+		_, err := client.VerifyAuditLogs(ctx, codersdk.VerifyAuditLogsRequest{})
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusBadRequest, apiErr.StatusCode())
+	})
 }
+
+// testAuditLogChainKey is the key audit logs are chained with in tests.
+const testAuditLogChainKey = "0123456789abcdef0123456789abcdef"
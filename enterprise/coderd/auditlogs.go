@@ -0,0 +1,84 @@
+package coderd
+
+import (
+	"net/http"
+
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/rbac"
+	"github.com/coder/coder/v2/coderd/rbac/policy"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/audit"
+)
+
+// auditLogVerifyLimit is the maximum number of entries of each chain that are
+// verified in a request.
+const auditLogVerifyLimit = 10_000
+
+// @Summary Verify audit log hash chains
+// @ID verify-audit-log-hash-chains
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param after_index query int false "Chain index after which entries are verified"
+// @Success 200 {object} codersdk.AuditLogVerification
+// @Router /audit/verify [get]
+func (api *API) verifyAuditLogs(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx        = r.Context()
+		vals       = r.URL.Query()
+		p          = httpapi.NewQueryParamParser()
+		afterIndex = int64(p.UInt(vals, 0, "after_index"))
+	)
+
+	// Only members that can read the audit logs of every organization can
+	// verify them.
+	if !api.AGPL.Authorize(r, policy.ActionRead, rbac.ResourceAuditLog) {
+		httpapi.Forbidden(rw)
+		return
+	}
+
+	p.ErrorExcessParams(vals)
+	if len(p.Errors) > 0 {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message:     "Invalid query params.",
+			Validations: p.Errors,
+		})
+		return
+	}
+
+	chainKey := api.DeploymentValues.AuditLogging.ChainKey.Value()
+	if chainKey == "" {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Audit logs aren't chained.",
+			Detail:  "Set --audit-logging-chain-key to chain audit logs.",
+		})
+		return
+	}
+
+	heads, err := api.Database.GetAuditLogChainHeads(ctx)
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	verification := codersdk.AuditLogVerification{
+		Valid:  true,
+		Chains: make([]codersdk.AuditLogChain, 0, len(heads)),
+	}
+	for _, head := range heads {
+		if head.ChainIndex <= afterIndex {
+			continue
+		}
+		chain, err := audit.VerifyChain(ctx, api.Database, []byte(chainKey), head, afterIndex, auditLogVerifyLimit)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		if len(chain.Problems) > 0 {
+			verification.Valid = false
+		}
+		verification.Chains = append(verification.Chains, chain)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, verification)
+}
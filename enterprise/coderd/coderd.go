@@ -40,6 +40,7 @@ import (
 	"github.com/coder/coder/v2/enterprise/coderd/dbauthz"
 	"github.com/coder/coder/v2/enterprise/coderd/license"
 	"github.com/coder/coder/v2/enterprise/coderd/proxyhealth"
+	"github.com/coder/coder/v2/enterprise/coderd/quotabudget"
 	"github.com/coder/coder/v2/enterprise/coderd/schedule"
 	"github.com/coder/coder/v2/enterprise/dbcrypt"
 	"github.com/coder/coder/v2/enterprise/derpmesh"
@@ -277,6 +278,17 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 				r.Get("/", api.groupByOrganization)
 			})
 		})
+		r.Route("/organizations/{organization}/quota-budgets", func(r chi.Router) {
+			r.Use(
+				apiKeyMiddleware,
+				api.templateRBACEnabledMW,
+				httpmw.ExtractOrganizationParam(api.Database),
+			)
+			r.Get("/", api.quotaBudgets)
+			r.Post("/", api.postQuotaBudget)
+			r.Patch("/{budget}", api.patchQuotaBudget)
+			r.Delete("/{budget}", api.deleteQuotaBudget)
+		})
 		r.Route("/organizations/{organization}/provisionerkeys", func(r chi.Router) {
 			r.Use(
 				apiKeyMiddleware,
@@ -369,6 +381,13 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 			})
 		})
 
+		r.Route("/audit/verify", func(r chi.Router) {
+			r.Use(
+				api.RequireFeatureMW(codersdk.FeatureAuditLog),
+				apiKeyMiddleware,
+			)
+			r.Get("/", api.verifyAuditLogs)
+		})
 		r.Route("/users/{user}/quiet-hours", func(r chi.Router) {
 			r.Use(
 				api.autostopRequirementEnabledMW,
@@ -391,10 +410,10 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 	})
 
 	if len(options.SCIMAPIKey) != 0 {
-		api.AGPL.RootHandler.Route("/scim/v2", func(r chi.Router) {
-			r.Use(
-				api.scimEnabledMW,
-			)
+		scimRoutes := func(r chi.Router) {
+			r.Get("/ServiceProviderConfig", api.scimServiceProviderConfig)
+			r.Get("/Schemas", api.scimGetSchemas)
+			r.Get("/Schemas/{id}", api.scimGetSchema)
 			r.Post("/Users", api.scimPostUser)
 			r.Route("/Users", func(r chi.Router) {
 				r.Get("/", api.scimGetUsers)
@@ -402,6 +421,22 @@ func New(ctx context.Context, options *Options) (_ *API, err error) {
 				r.Get("/{id}", api.scimGetUser)
 				r.Patch("/{id}", api.scimPatchUser)
 			})
+			r.Route("/Groups", func(r chi.Router) {
+				r.Get("/", api.scimGetGroups)
+				r.Post("/", api.scimPostGroup)
+				r.Get("/{id}", api.scimGetGroup)
+				r.Put("/{id}", api.scimPutGroup)
+				r.Patch("/{id}", api.scimPatchGroup)
+				r.Delete("/{id}", api.scimDeleteGroup)
+			})
+		}
+		api.AGPL.RootHandler.Route("/scim/v2", func(r chi.Router) {
+			r.Use(
+				api.scimEnabledMW,
+			)
+			scimRoutes(r)
+			// Each organization has its own base URL, see scimOrganization.
+			r.Route("/organizations/{organization}", scimRoutes)
 		})
 	}
 
@@ -521,6 +556,10 @@ type API struct {
 
 	licenseMetricsCollector license.MetricsCollector
 	tailnetService          *tailnet.ClientService
+
+	// stopQuotaBudgetEnforcer stops enforcing quota budgets. It is nil if
+	// they are not being enforced. Protected by entitlementsUpdateMu.
+	stopQuotaBudgetEnforcer func()
 }
 
 // writeEntitlementWarningsHeader writes the entitlement warnings to the response header
@@ -570,6 +609,12 @@ func (api *API) Close() error {
 	if api.Options.CheckInactiveUsersCancelFunc != nil {
 		api.Options.CheckInactiveUsersCancelFunc()
 	}
+	api.entitlementsUpdateMu.Lock()
+	if api.stopQuotaBudgetEnforcer != nil {
+		api.stopQuotaBudgetEnforcer()
+		api.stopQuotaBudgetEnforcer = nil
+	}
+	api.entitlementsUpdateMu.Unlock()
 	return api.AGPL.Close()
 }
 
@@ -666,8 +711,18 @@ func (api *API) updateEntitlements(ctx context.Context) error {
 			}
 			qcPtr := proto.QuotaCommitter(&committer)
 			api.AGPL.QuotaCommitter.Store(&qcPtr)
+
+			if api.stopQuotaBudgetEnforcer == nil {
+				enforcer := quotabudget.NewEnforcer(api.Database, api.Pubsub, api.NotificationsEnqueuer, api.Logger.Named("quota_budget_enforcer"))
+				api.stopQuotaBudgetEnforcer = enforcer.Run(api.ctx)
+			}
 		} else {
 			api.AGPL.QuotaCommitter.Store(nil)
+
+			if api.stopQuotaBudgetEnforcer != nil {
+				api.stopQuotaBudgetEnforcer()
+				api.stopQuotaBudgetEnforcer = nil
+			}
 		}
 	}
 
@@ -14,26 +14,20 @@ func NewEnterprisePortSharer() *EnterprisePortSharer {
 }
 
 func (EnterprisePortSharer) AuthorizedLevel(template database.Template, level codersdk.WorkspaceAgentPortShareLevel) error {
-	max := codersdk.WorkspaceAgentPortShareLevel(template.MaxPortSharingLevel)
-	switch level {
-	case codersdk.WorkspaceAgentPortShareLevelPublic:
-		if max != codersdk.WorkspaceAgentPortShareLevelPublic {
-			return xerrors.Errorf("port sharing level not allowed. Max level is '%s'", max)
-		}
-	case codersdk.WorkspaceAgentPortShareLevelAuthenticated:
-		if max == codersdk.WorkspaceAgentPortShareLevelOwner {
-			return xerrors.Errorf("port sharing level not allowed. Max level is '%s'", max)
-		}
-	default:
+	if !level.ValidPortShareLevel() {
 		return xerrors.New("port sharing level is invalid.")
 	}
+	max := codersdk.WorkspaceAgentPortShareLevel(template.MaxPortSharingLevel)
+	if level.Compare(max) > 0 {
+		return xerrors.Errorf("port sharing level not allowed. Max level is '%s'", max)
+	}
 
 	return nil
 }
 
 func (EnterprisePortSharer) ValidateTemplateMaxLevel(level codersdk.WorkspaceAgentPortShareLevel) error {
 	if !level.ValidMaxLevel() {
-		return xerrors.New("invalid max port sharing level, value must be 'authenticated' or 'public'.")
+		return xerrors.New("invalid max port sharing level, value must be 'owner', 'allowlist', 'organization', 'authenticated' or 'public'.")
 	}
 
 	return nil
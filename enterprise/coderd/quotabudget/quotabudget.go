@@ -0,0 +1,364 @@
+// Package quotabudget enforces limits on the cost accrued by running
+// workspaces within a day or month.
+package quotabudget
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/xerrors"
+
+	"cdr.dev/slog"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/provisionerjobs"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/coderd/wsbuilder"
+)
+
+// Time interval between consecutive enforcement runs.
+const enforceInterval = 5 * time.Minute
+
+// Period returns the bounds of the day or month containing t. Periods are
+// always aligned to UTC so that a budget resets at the same time for everyone
+// it applies to.
+func Period(period database.QuotaBudgetPeriod, t time.Time) (start time.Time, end time.Time) {
+	t = t.UTC()
+	if period == database.QuotaBudgetPeriodDay {
+		start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 0, 1)
+	}
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Members returns the IDs of the users a budget applies to.
+func Members(ctx context.Context, db database.Store, budget database.QuotaBudget) ([]uuid.UUID, error) {
+	if budget.UserID.Valid {
+		return []uuid.UUID{budget.UserID.UUID}, nil
+	}
+	users, err := db.GetGroupMembersByGroupID(ctx, budget.GroupID.UUID)
+	if err != nil {
+		return nil, xerrors.Errorf("get group members: %w", err)
+	}
+	members := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		members = append(members, user.ID)
+	}
+	return members, nil
+}
+
+// Spend returns the cost accrued against a budget between the start of the
+// current period and now.
+func Spend(ctx context.Context, db database.Store, budget database.QuotaBudget, members []uuid.UUID, now time.Time) (int64, error) {
+	if len(members) == 0 {
+		return 0, nil
+	}
+	start, _ := Period(budget.Period, now)
+	spend, err := db.GetQuotaSpend(ctx, database.GetQuotaSpendParams{
+		OrganizationID: budget.OrganizationID,
+		OwnerIDs:       members,
+		PeriodStart:    start,
+		PeriodEnd:      now,
+	})
+	if err != nil {
+		return 0, xerrors.Errorf("get quota spend: %w", err)
+	}
+	return spend, nil
+}
+
+// HardLimitReached returns the first budget applying to a user in an
+// organization whose hard limit has been reached, along with the cost accrued
+// against it. ok is false if no hard limit has been reached.
+func HardLimitReached(ctx context.Context, db database.Store, organizationID, userID uuid.UUID, now time.Time) (budget database.QuotaBudget, spend int64, ok bool, err error) {
+	budgets, err := db.GetQuotaBudgetsForUser(ctx, database.GetQuotaBudgetsForUserParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		return database.QuotaBudget{}, 0, false, xerrors.Errorf("get quota budgets: %w", err)
+	}
+	for _, budget := range budgets {
+		if budget.HardLimit <= 0 {
+			continue
+		}
+		members, err := Members(ctx, db, budget)
+		if err != nil {
+			return database.QuotaBudget{}, 0, false, err
+		}
+		spend, err := Spend(ctx, db, budget, members, now)
+		if err != nil {
+			return database.QuotaBudget{}, 0, false, err
+		}
+		if spend >= budget.HardLimit {
+			return budget, spend, true, nil
+		}
+	}
+	return database.QuotaBudget{}, 0, false, nil
+}
+
+// Enforcer periodically checks every budget, notifying the users it applies
+// to when a limit is reached and stopping their workspaces when the hard
+// limit is reached if the budget asks for it. It runs on every replica, but
+// only one of them checks budgets at a time.
+type Enforcer struct {
+	db       database.Store
+	ps       pubsub.Pubsub
+	enqueuer notifications.Enqueuer
+	log      slog.Logger
+}
+
+func NewEnforcer(db database.Store, ps pubsub.Pubsub, enqueuer notifications.Enqueuer, log slog.Logger) *Enforcer {
+	return &Enforcer{
+		db:       db,
+		ps:       ps,
+		enqueuer: enqueuer,
+		log:      log,
+	}
+}
+
+// Run enforces budgets until the returned function is called.
+func (e *Enforcer) Run(ctx context.Context) func() {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	done := make(chan struct{})
+	ticker := time.NewTicker(enforceInterval)
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			err := e.Enforce(ctx, dbtime.Now())
+			if err != nil && ctx.Err() == nil {
+				e.log.Error(ctx, "enforce quota budgets", slog.Error(err))
+			}
+		}
+	}()
+
+	return func() {
+		cancelFunc()
+		<-done
+	}
+}
+
+// enforcement holds the notifications and stop builds of an enforcement run.
+// They're only sent and posted once the run's transaction has committed.
+type enforcement struct {
+	notifications []pendingNotification
+	jobs          []database.ProvisionerJob
+}
+
+type pendingNotification struct {
+	budget     database.QuotaBudget
+	userID     uuid.UUID
+	templateID uuid.UUID
+	labels     map[string]string
+}
+
+// Enforce checks every budget once. Only one replica enforces budgets at a
+// time, so that users aren't notified and workspaces aren't stopped twice.
+func (e *Enforcer) Enforce(ctx context.Context, now time.Time) error {
+	//nolint:gocritic // The enforcer needs to read every budget and stop
+	// workspaces on behalf of their owners.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+
+	var run enforcement
+	err := e.db.InTx(func(tx database.Store) error {
+		ok, err := tx.TryAcquireLock(ctx, database.LockIDQuotaBudgetEnforcer)
+		if err != nil {
+			return xerrors.Errorf("acquire lock: %w", err)
+		}
+		if !ok {
+			e.log.Debug(ctx, "unable to acquire lock for enforcing quota budgets, skipping")
+			return nil
+		}
+
+		budgets, err := tx.GetQuotaBudgets(ctx)
+		if err != nil {
+			return xerrors.Errorf("get quota budgets: %w", err)
+		}
+		for _, budget := range budgets {
+			err := e.enforce(ctx, tx, &run, budget, now)
+			if err != nil {
+				e.log.Error(ctx, "enforce quota budget", slog.F("budget_id", budget.ID), slog.Error(err))
+			}
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range run.notifications {
+		_, err := e.enqueuer.Enqueue(ctx, n.userID, n.templateID, n.labels, "quotabudget",
+			// Associate this notification with all the related entities.
+			n.budget.ID, n.userID, n.budget.OrganizationID,
+		)
+		if err != nil {
+			notifications.LogEnqueueError(ctx, e.log, "failed to notify of quota budget limit", err, slog.F("budget_id", n.budget.ID))
+		}
+	}
+	for _, job := range run.jobs {
+		err := provisionerjobs.PostJob(e.ps, job)
+		if err != nil {
+			e.log.Error(ctx, "post provisioner job to pubsub", slog.F("job_id", job.ID), slog.Error(err))
+		}
+	}
+	return nil
+}
+
+func (e *Enforcer) enforce(ctx context.Context, tx database.Store, run *enforcement, budget database.QuotaBudget, now time.Time) error {
+	if budget.SoftLimit <= 0 && budget.HardLimit <= 0 {
+		return nil
+	}
+	members, err := Members(ctx, tx, budget)
+	if err != nil {
+		return err
+	}
+	spend, err := Spend(ctx, tx, budget, members, now)
+	if err != nil {
+		return err
+	}
+	hardLimitReached := budget.HardLimit > 0 && spend >= budget.HardLimit
+	softLimitReached := budget.SoftLimit > 0 && spend >= budget.SoftLimit
+	if !hardLimitReached && !softLimitReached {
+		return nil
+	}
+
+	description, err := describe(ctx, tx, budget)
+	if err != nil {
+		return err
+	}
+	start, end := Period(budget.Period, now)
+	for _, member := range members {
+		if softLimitReached {
+			e.notify(ctx, tx, run, budget, member, start, false, map[string]string{
+				"spend":      strconv.FormatInt(spend, 10),
+				"budget":     description,
+				"limit":      strconv.FormatInt(budget.SoftLimit, 10),
+				"hard_limit": formatLimit(budget.HardLimit),
+			})
+		}
+		if hardLimitReached {
+			e.notify(ctx, tx, run, budget, member, start, true, map[string]string{
+				"spend":           strconv.FormatInt(spend, 10),
+				"budget":          description,
+				"limit":           strconv.FormatInt(budget.HardLimit, 10),
+				"period_end":      end.Format(time.RFC1123),
+				"stop_workspaces": strconv.FormatBool(budget.StopWorkspaces),
+			})
+		}
+	}
+
+	if !hardLimitReached || !budget.StopWorkspaces {
+		return nil
+	}
+	for _, member := range members {
+		err := e.stopWorkspaces(ctx, tx, run, budget, member)
+		if err != nil {
+			e.log.Error(ctx, "stop workspaces over quota budget",
+				slog.F("budget_id", budget.ID),
+				slog.F("user_id", member),
+				slog.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+// notify queues a notification of a reached limit to a user, unless they have
+// already been notified of it in this period.
+func (e *Enforcer) notify(ctx context.Context, tx database.Store, run *enforcement, budget database.QuotaBudget, userID uuid.UUID, periodStart time.Time, hardLimit bool, labels map[string]string) {
+	rows, err := tx.InsertQuotaBudgetAlert(ctx, database.InsertQuotaBudgetAlertParams{
+		BudgetID:    budget.ID,
+		UserID:      userID,
+		PeriodStart: periodStart,
+		HardLimit:   hardLimit,
+		CreatedAt:   dbtime.Now(),
+	})
+	if err != nil {
+		e.log.Error(ctx, "insert quota budget alert", slog.F("budget_id", budget.ID), slog.Error(err))
+		return
+	}
+	if rows == 0 {
+		return
+	}
+
+	templateID := notifications.TemplateQuotaBudgetSoftLimitReached
+	if hardLimit {
+		templateID = notifications.TemplateQuotaBudgetHardLimitReached
+	}
+	run.notifications = append(run.notifications, pendingNotification{
+		budget:     budget,
+		userID:     userID,
+		templateID: templateID,
+		labels:     labels,
+	})
+}
+
+// stopWorkspaces stops the running workspaces a user owns in the budget's
+// organization.
+func (e *Enforcer) stopWorkspaces(ctx context.Context, tx database.Store, run *enforcement, budget database.QuotaBudget, userID uuid.UUID) error {
+	rows, err := tx.GetWorkspaces(ctx, database.GetWorkspacesParams{
+		OwnerID: userID,
+		Status:  "running",
+	})
+	if err != nil {
+		return xerrors.Errorf("get workspaces: %w", err)
+	}
+	for _, ws := range database.ConvertWorkspaceRows(rows) {
+		if ws.OrganizationID != budget.OrganizationID {
+			continue
+		}
+		builder := wsbuilder.New(ws, database.WorkspaceTransitionStop).
+			Reason(database.BuildReasonQuota)
+		_, job, err := builder.Build(ctx, tx, nil, audit.WorkspaceBuildBaggage{IP: "127.0.0.1"})
+		if err != nil {
+			return xerrors.Errorf("stop workspace %q: %w", ws.Name, err)
+		}
+		run.jobs = append(run.jobs, *job)
+		e.log.Info(ctx, "stopping workspace over quota budget",
+			slog.F("budget_id", budget.ID),
+			slog.F("workspace_id", ws.ID),
+		)
+	}
+	return nil
+}
+
+// describe returns a description of a budget for notifications.
+func describe(ctx context.Context, db database.Store, budget database.QuotaBudget) (string, error) {
+	period := "monthly"
+	if budget.Period == database.QuotaBudgetPeriodDay {
+		period = "daily"
+	}
+	if budget.UserID.Valid {
+		return fmt.Sprintf("your %s budget", period), nil
+	}
+	group, err := db.GetGroupByID(ctx, budget.GroupID.UUID)
+	if err != nil {
+		return "", xerrors.Errorf("get group: %w", err)
+	}
+	name := group.DisplayName
+	if name == "" {
+		name = group.Name
+	}
+	return fmt.Sprintf("the %s budget of the %s group", period, name), nil
+}
+
+func formatLimit(limit int64) string {
+	if limit <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(limit, 10)
+}
@@ -0,0 +1,118 @@
+package quotabudget_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"cdr.dev/slog/sloggers/slogtest"
+
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbfake"
+	"github.com/coder/coder/v2/coderd/database/dbgen"
+	"github.com/coder/coder/v2/coderd/database/dbmem"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/database/pubsub"
+	"github.com/coder/coder/v2/coderd/notifications"
+	"github.com/coder/coder/v2/enterprise/coderd/quotabudget"
+	"github.com/coder/coder/v2/testutil"
+)
+
+func TestPeriod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, time.February, 29, 13, 45, 0, 0, time.FixedZone("UTC+10", 10*60*60))
+
+	start, end := quotabudget.Period(database.QuotaBudgetPeriodDay, now)
+	require.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = quotabudget.Period(database.QuotaBudgetPeriodMonth, now)
+	require.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), start)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestEnforcer(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitShort)
+	db, ps := dbmem.New(), pubsub.NewInMemory()
+	org := dbgen.Organization(t, db, database.Organization{})
+	user := dbgen.User(t, db, database.User{})
+	_ = dbgen.OrganizationMember(t, db, database.OrganizationMember{OrganizationID: org.ID, UserID: user.ID})
+
+	// The workspace has been running for an hour at a cost of 48 credits per
+	// day, so it has accrued 2 credits.
+	start, _ := quotabudget.Period(database.QuotaBudgetPeriodMonth, dbtime.Now())
+	now := start.Add(2 * time.Hour)
+	r := dbfake.WorkspaceBuild(t, db, database.Workspace{
+		OrganizationID: org.ID,
+		OwnerID:        user.ID,
+	}).Seed(database.WorkspaceBuild{DailyCost: 48}).Do()
+	completeJob(ctx, t, db, r.Build.JobID, start.Add(time.Hour))
+
+	budget := dbgen.QuotaBudget(t, db, database.QuotaBudget{
+		OrganizationID: org.ID,
+		UserID:         uuid.NullUUID{UUID: user.ID, Valid: true},
+		Period:         database.QuotaBudgetPeriodMonth,
+		SoftLimit:      1,
+		HardLimit:      2,
+		StopWorkspaces: true,
+	})
+
+	_, spend, reached, err := quotabudget.HardLimitReached(ctx, db, org.ID, user.ID, start.Add(90*time.Minute))
+	require.NoError(t, err)
+	require.False(t, reached)
+	require.EqualValues(t, 1, spend)
+
+	got, spend, reached, err := quotabudget.HardLimitReached(ctx, db, org.ID, user.ID, now)
+	require.NoError(t, err)
+	require.True(t, reached)
+	require.Equal(t, budget.ID, got.ID)
+	require.EqualValues(t, 2, spend)
+
+	enqueuer := &testutil.FakeNotificationsEnqueuer{}
+	// Budgets aren't enforced while another replica holds the lock.
+	err = db.InTx(func(tx database.Store) error {
+		ok, err := tx.TryAcquireLock(ctx, database.LockIDQuotaBudgetEnforcer)
+		require.NoError(t, err)
+		require.True(t, ok)
+		return quotabudget.NewEnforcer(tx, ps, enqueuer, slogtest.Make(t, nil)).Enforce(ctx, now)
+	}, nil)
+	require.NoError(t, err)
+	require.Empty(t, enqueuer.Sent)
+
+	enforcer := quotabudget.NewEnforcer(db, ps, enqueuer, slogtest.Make(t, nil))
+	require.NoError(t, enforcer.Enforce(ctx, now))
+
+	require.Len(t, enqueuer.Sent, 2)
+	require.Equal(t, notifications.TemplateQuotaBudgetSoftLimitReached, enqueuer.Sent[0].TemplateID)
+	require.Equal(t, notifications.TemplateQuotaBudgetHardLimitReached, enqueuer.Sent[1].TemplateID)
+	require.Equal(t, user.ID, enqueuer.Sent[1].UserID)
+	require.Equal(t, "2", enqueuer.Sent[1].Labels["spend"])
+	require.Equal(t, "your monthly budget", enqueuer.Sent[1].Labels["budget"])
+
+	build, err := db.GetLatestWorkspaceBuildByWorkspaceID(ctx, r.Workspace.ID)
+	require.NoError(t, err)
+	require.Equal(t, database.WorkspaceTransitionStop, build.Transition)
+	require.Equal(t, database.BuildReasonQuota, build.Reason)
+
+	// Users are only notified once per period.
+	require.NoError(t, enforcer.Enforce(ctx, now.Add(time.Minute)))
+	require.Len(t, enqueuer.Sent, 2)
+}
+
+func completeJob(ctx context.Context, t *testing.T, db database.Store, jobID uuid.UUID, completedAt time.Time) {
+	t.Helper()
+
+	err := db.UpdateProvisionerJobWithCompleteByID(ctx, database.UpdateProvisionerJobWithCompleteByIDParams{
+		ID:          jobID,
+		UpdatedAt:   completedAt,
+		CompletedAt: sql.NullTime{Time: completedAt, Valid: true},
+	})
+	require.NoError(t, err)
+}
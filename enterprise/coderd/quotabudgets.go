@@ -0,0 +1,311 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/db2sdk"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
+	"github.com/coder/coder/v2/coderd/httpapi"
+	"github.com/coder/coder/v2/coderd/httpmw"
+	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd/quotabudget"
+)
+
+// @Summary Get quota budgets by organization
+// @ID get-quota-budgets-by-organization
+// @Security CoderSessionToken
+// @Produce json
+// @Tags Enterprise
+// @Param organization path string true "Organization ID" format(uuid)
+// @Success 200 {array} codersdk.QuotaBudgetStatus
+// @Router /organizations/{organization}/quota-budgets [get]
+func (api *API) quotaBudgets(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx = r.Context()
+		org = httpmw.OrganizationParam(r)
+		now = dbtime.Now()
+	)
+
+	budgets, err := api.Database.GetQuotaBudgetsByOrganizationID(ctx, org.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	resp := make([]codersdk.QuotaBudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		status, err := api.quotaBudgetStatus(ctx, budget, now)
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		resp = append(resp, status)
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, resp)
+}
+
+// @Summary Create quota budget for organization
+// @ID create-quota-budget-for-organization
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param request body codersdk.CreateQuotaBudgetRequest true "Create quota budget request"
+// @Param organization path string true "Organization ID" format(uuid)
+// @Success 201 {object} codersdk.QuotaBudget
+// @Router /organizations/{organization}/quota-budgets [post]
+func (api *API) postQuotaBudget(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		org               = httpmw.OrganizationParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.QuotaBudget](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionCreate,
+			OrganizationID: org.ID,
+		})
+	)
+	defer commitAudit()
+
+	var req codersdk.CreateQuotaBudgetRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+	if (req.GroupID == nil) == (req.UserID == nil) {
+		httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+			Message: "Exactly one of group_id and user_id must be provided.",
+		})
+		return
+	}
+
+	var groupID, userID uuid.NullUUID
+	if req.GroupID != nil {
+		group, err := api.Database.GetGroupByID(ctx, *req.GroupID)
+		if httpapi.Is404Error(err) || (err == nil && group.OrganizationID != org.ID) {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "Group not found.",
+				Validations: []codersdk.ValidationError{{Field: "group_id", Detail: "The group must belong to the organization."}},
+			})
+			return
+		}
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		groupID = uuid.NullUUID{UUID: group.ID, Valid: true}
+	}
+	if req.UserID != nil {
+		members, err := api.Database.OrganizationMembers(ctx, database.OrganizationMembersParams{
+			OrganizationID: org.ID,
+			UserID:         *req.UserID,
+		})
+		if err != nil {
+			httpapi.InternalServerError(rw, err)
+			return
+		}
+		if len(members) == 0 {
+			httpapi.Write(ctx, rw, http.StatusBadRequest, codersdk.Response{
+				Message:     "User not found.",
+				Validations: []codersdk.ValidationError{{Field: "user_id", Detail: "The user must be a member of the organization."}},
+			})
+			return
+		}
+		userID = uuid.NullUUID{UUID: *req.UserID, Valid: true}
+	}
+
+	now := dbtime.Now()
+	budget, err := api.Database.InsertQuotaBudget(ctx, database.InsertQuotaBudgetParams{
+		ID:             uuid.New(),
+		OrganizationID: org.ID,
+		GroupID:        groupID,
+		UserID:         userID,
+		Period:         database.QuotaBudgetPeriod(req.Period),
+		SoftLimit:      req.SoftLimit,
+		HardLimit:      req.HardLimit,
+		StopWorkspaces: req.StopWorkspaces,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if database.IsUniqueViolation(err) {
+		httpapi.Write(ctx, rw, http.StatusConflict, codersdk.Response{
+			Message: "A budget with this period already exists.",
+			Detail:  err.Error(),
+		})
+		return
+	}
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.New = budget
+
+	httpapi.Write(ctx, rw, http.StatusCreated, db2sdk.QuotaBudget(budget))
+}
+
+// @Summary Update quota budget
+// @ID update-quota-budget
+// @Security CoderSessionToken
+// @Accept json
+// @Produce json
+// @Tags Enterprise
+// @Param organization path string true "Organization ID" format(uuid)
+// @Param budget path string true "Budget ID" format(uuid)
+// @Param request body codersdk.UpdateQuotaBudgetRequest true "Update quota budget request"
+// @Success 200 {object} codersdk.QuotaBudget
+// @Router /organizations/{organization}/quota-budgets/{budget} [patch]
+func (api *API) patchQuotaBudget(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		org               = httpmw.OrganizationParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.QuotaBudget](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionWrite,
+			OrganizationID: org.ID,
+		})
+	)
+	defer commitAudit()
+
+	budget, ok := api.quotaBudgetParam(rw, r, org.ID)
+	if !ok {
+		return
+	}
+	aReq.Old = budget
+
+	var req codersdk.UpdateQuotaBudgetRequest
+	if !httpapi.Read(ctx, rw, r, &req) {
+		return
+	}
+
+	params := database.UpdateQuotaBudgetByIDParams{
+		ID:             budget.ID,
+		SoftLimit:      budget.SoftLimit,
+		HardLimit:      budget.HardLimit,
+		StopWorkspaces: budget.StopWorkspaces,
+		UpdatedAt:      dbtime.Now(),
+	}
+	if req.SoftLimit != nil {
+		params.SoftLimit = *req.SoftLimit
+	}
+	if req.HardLimit != nil {
+		params.HardLimit = *req.HardLimit
+	}
+	if req.StopWorkspaces != nil {
+		params.StopWorkspaces = *req.StopWorkspaces
+	}
+
+	budget, err := api.Database.UpdateQuotaBudgetByID(ctx, params)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+	aReq.New = budget
+
+	httpapi.Write(ctx, rw, http.StatusOK, db2sdk.QuotaBudget(budget))
+}
+
+// @Summary Delete quota budget
+// @ID delete-quota-budget
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param organization path string true "Organization ID" format(uuid)
+// @Param budget path string true "Budget ID" format(uuid)
+// @Success 204
+// @Router /organizations/{organization}/quota-budgets/{budget} [delete]
+func (api *API) deleteQuotaBudget(rw http.ResponseWriter, r *http.Request) {
+	var (
+		ctx               = r.Context()
+		org               = httpmw.OrganizationParam(r)
+		auditor           = api.AGPL.Auditor.Load()
+		aReq, commitAudit = audit.InitRequest[database.QuotaBudget](rw, &audit.RequestParams{
+			Audit:          *auditor,
+			Log:            api.Logger,
+			Request:        r,
+			Action:         database.AuditActionDelete,
+			OrganizationID: org.ID,
+		})
+	)
+	defer commitAudit()
+
+	budget, ok := api.quotaBudgetParam(rw, r, org.ID)
+	if !ok {
+		return
+	}
+	aReq.Old = budget
+
+	err := api.Database.DeleteQuotaBudgetByID(ctx, budget.ID)
+	if httpapi.Is404Error(err) {
+		httpapi.ResourceNotFound(rw)
+		return
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// quotaBudgetParam fetches the budget in the URL, which must belong to the
+// organization.
+func (api *API) quotaBudgetParam(rw http.ResponseWriter, r *http.Request, organizationID uuid.UUID) (database.QuotaBudget, bool) {
+	budgetID, ok := httpmw.ParseUUIDParam(rw, r, "budget")
+	if !ok {
+		return database.QuotaBudget{}, false
+	}
+	budget, err := api.Database.GetQuotaBudgetByID(r.Context(), budgetID)
+	if httpapi.Is404Error(err) || (err == nil && budget.OrganizationID != organizationID) {
+		httpapi.ResourceNotFound(rw)
+		return database.QuotaBudget{}, false
+	}
+	if err != nil {
+		httpapi.InternalServerError(rw, err)
+		return database.QuotaBudget{}, false
+	}
+	return budget, true
+}
+
+// quotaBudgetStatus returns a budget with the cost accrued against it in the
+// current period. The caller must already be authorized to read the budget.
+func (api *API) quotaBudgetStatus(ctx context.Context, budget database.QuotaBudget, now time.Time) (codersdk.QuotaBudgetStatus, error) {
+	//nolint:gocritic // Spend is accrued by workspaces and group members the
+	// caller may not be able to read individually.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	members, err := quotabudget.Members(ctx, api.Database, budget)
+	if err != nil {
+		return codersdk.QuotaBudgetStatus{}, err
+	}
+	spend, err := quotabudget.Spend(ctx, api.Database, budget, members, now)
+	if err != nil {
+		return codersdk.QuotaBudgetStatus{}, err
+	}
+	start, end := quotabudget.Period(budget.Period, now)
+	return codersdk.QuotaBudgetStatus{
+		QuotaBudget: db2sdk.QuotaBudget(budget),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Spend:       spend,
+	}, nil
+}
@@ -95,6 +95,8 @@ func (*EnterpriseTemplateScheduleStore) Get(ctx context.Context, db database.Sto
 		FailureTTL:               time.Duration(tpl.FailureTTL),
 		TimeTilDormant:           time.Duration(tpl.TimeTilDormant),
 		TimeTilDormantAutoDelete: time.Duration(tpl.TimeTilDormantAutoDelete),
+		IdleTimeout:              time.Duration(tpl.IdleTimeout),
+		IdleCPUThreshold:         tpl.IdleCPUThreshold,
 	}, nil
 }
 
@@ -118,6 +120,8 @@ func (s *EnterpriseTemplateScheduleStore) Set(ctx context.Context, db database.S
 		int64(opts.FailureTTL) == tpl.FailureTTL &&
 		int64(opts.TimeTilDormant) == tpl.TimeTilDormant &&
 		int64(opts.TimeTilDormantAutoDelete) == tpl.TimeTilDormantAutoDelete &&
+		int64(opts.IdleTimeout) == tpl.IdleTimeout &&
+		opts.IdleCPUThreshold == tpl.IdleCPUThreshold &&
 		opts.UserAutostartEnabled == tpl.AllowUserAutostart &&
 		opts.UserAutostopEnabled == tpl.AllowUserAutostop {
 		// Avoid updating the UpdatedAt timestamp if nothing will be changed.
@@ -157,6 +161,8 @@ func (s *EnterpriseTemplateScheduleStore) Set(ctx context.Context, db database.S
 			FailureTTL:               int64(opts.FailureTTL),
 			TimeTilDormant:           int64(opts.TimeTilDormant),
 			TimeTilDormantAutoDelete: int64(opts.TimeTilDormantAutoDelete),
+			IdleTimeout:              int64(opts.IdleTimeout),
+			IdleCPUThreshold:         opts.IdleCPUThreshold,
 		})
 		if err != nil {
 			return xerrors.Errorf("update template schedule: %w", err)
@@ -216,7 +222,7 @@ func (s *EnterpriseTemplateScheduleStore) Set(ctx context.Context, db database.S
 			},
 		)
 		if err != nil {
-			s.logger.Warn(ctx, "failed to notify of workspace marked for deletion", slog.Error(err), slog.F("workspace_id", workspace.ID))
+			notifications.LogEnqueueError(ctx, s.logger, "failed to notify of workspace marked for deletion", err, slog.F("workspace_id", workspace.ID))
 		}
 	}
 
@@ -1,16 +1,16 @@
 package coderd
 
 import (
+	"context"
 	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/imulab/go-scim/pkg/v2/handlerutil"
-	scimjson "github.com/imulab/go-scim/pkg/v2/json"
-	"github.com/imulab/go-scim/pkg/v2/service"
 	"github.com/imulab/go-scim/pkg/v2/spec"
 	"golang.org/x/xerrors"
 
@@ -39,41 +39,186 @@ func (api *API) scimEnabledMW(next http.Handler) http.Handler {
 }
 
 func (api *API) scimVerifyAuthHeader(r *http.Request) bool {
-	hdr := []byte(r.Header.Get("Authorization"))
+	// Okta sends the key as is, and Entra ID sends it as a bearer token.
+	hdr := []byte(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
 
 	return len(api.SCIMAPIKey) != 0 && subtle.ConstantTimeCompare(hdr, api.SCIMAPIKey) == 1
 }
 
-// scimGetUsers intentionally always returns no users. This is done to always force
-// Okta to try and create each user individually, this way we don't need to
-// implement fetching users twice.
+// scimGetUsers returns the users matching the SCIM filter. Identity providers
+// look up a user with a filter like `userName eq "alice"` before creating it,
+// and link to the existing user when one is found.
 //
 // @Summary SCIM 2.0: Get users
 // @ID scim-get-users
 // @Security CoderSessionToken
 // @Produce application/scim+json
 // @Tags Enterprise
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Maximum number of results"
 // @Success 200
 // @Router /scim/v2/Users [get]
 //
 //nolint:revive
 func (api *API) scimGetUsers(rw http.ResponseWriter, r *http.Request) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
 	if !api.scimVerifyAuthHeader(r) {
 		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
 		return
 	}
 
-	_ = handlerutil.WriteSearchResultToResponse(rw, &service.QueryResponse{
-		TotalResults: 0,
-		StartIndex:   1,
-		ItemsPerPage: 0,
-		Resources:    []scimjson.Serializable{},
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	err = filter.supports("username", "emails", "emails.value", "id", "active")
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	startIndex, count, err := scimPage(r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	// Requests to an organization's base URL only see the organization's
+	// members, so identity providers create users that are missing from it.
+	var orgID uuid.UUID
+	if chi.URLParam(r, "organization") != "" {
+		org, err := api.scimOrganization(ctx, r)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+		orgID = org.ID
+	}
+
+	var (
+		users []database.User
+		total int
+	)
+	switch {
+	case filter["id"] != "" || filter["username"] != "" || filter.email() != "":
+		user, ok, err := api.scimLookupUser(ctx, filter, orgID)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+		if ok && filter.matchesUser(scimUserFromDatabase(user)) {
+			users = scimPaginate([]database.User{user}, startIndex, count)
+			total = 1
+		}
+	default:
+		params := database.GetUsersParams{
+			OrganizationID: orgID,
+			OffsetOpt:      int32(startIndex - 1),
+			// A limit of zero returns every user, but only the total is
+			// needed when no users are requested.
+			LimitOpt: int32(max(count, 1)),
+		}
+		if active, ok := filter["active"]; ok {
+			switch {
+			case strings.EqualFold(active, "true"):
+				params.Status = []database.UserStatus{database.UserStatusActive, database.UserStatusDormant}
+			case strings.EqualFold(active, "false"):
+				params.Status = []database.UserStatus{database.UserStatusSuspended}
+			default:
+				// No user matches an active value that isn't a boolean.
+				params.LimitOpt = 0
+			}
+		}
+		if params.LimitOpt == 0 {
+			break
+		}
+
+		rows, err := api.Database.GetUsers(ctx, params)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+		if len(rows) == 0 && params.OffsetOpt > 0 {
+			// The total is only returned with a row, so it must be
+			// fetched separately when the page is past the last user.
+			params.OffsetOpt, params.LimitOpt = 0, 1
+			totalRows, err := api.Database.GetUsers(ctx, params)
+			if err != nil {
+				_ = handlerutil.WriteError(rw, err)
+				return
+			}
+			if len(totalRows) > 0 {
+				total = int(totalRows[0].Count)
+			}
+		} else if len(rows) > 0 {
+			total = int(rows[0].Count)
+		}
+		if count > 0 {
+			users = database.ConvertUserRows(rows)
+		}
+	}
+
+	resources := make([]SCIMUser, 0, len(users))
+	for _, user := range users {
+		resources = append(resources, scimUserFromDatabase(user))
+	}
+	httpapi.Write(ctx, rw, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
 	})
 }
 
-// scimGetUser intentionally always returns an error saying the user wasn't found.
-// This is done to always force Okta to try and create the user, this way we
-// don't need to implement fetching users twice.
+// scimLookupUser returns the user matching the id, username or email of the
+// filter, if the user is a member of the organization with the given ID. An
+// empty organization ID matches users of every organization.
+func (api *API) scimLookupUser(ctx context.Context, filter scimFilter, orgID uuid.UUID) (database.User, bool, error) {
+	var (
+		user database.User
+		err  error
+	)
+	if filter["id"] != "" {
+		id, parseErr := uuid.Parse(filter["id"])
+		if parseErr != nil {
+			return database.User{}, false, nil
+		}
+		user, err = api.Database.GetUserByID(ctx, id)
+		if err == nil && user.Deleted {
+			err = sql.ErrNoRows
+		}
+	} else {
+		user, err = api.Database.GetUserByEmailOrUsername(ctx, database.GetUserByEmailOrUsernameParams{
+			Username: filter["username"],
+			Email:    filter.email(),
+		})
+	}
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return database.User{}, false, nil
+	}
+	if err != nil {
+		return database.User{}, false, err
+	}
+
+	if orgID != uuid.Nil {
+		members, err := api.Database.OrganizationMembers(ctx, database.OrganizationMembersParams{
+			OrganizationID: orgID,
+			UserID:         user.ID,
+		})
+		if err != nil {
+			return database.User{}, false, err
+		}
+		if len(members) == 0 {
+			return database.User{}, false, nil
+		}
+	}
+	return user, true, nil
+}
+
+// scimGetUser returns a user by ID.
 //
 // @Summary SCIM 2.0: Get user by ID
 // @ID scim-get-user-by-id
@@ -81,17 +226,35 @@ func (api *API) scimGetUsers(rw http.ResponseWriter, r *http.Request) {
 // @Produce application/scim+json
 // @Tags Enterprise
 // @Param id path string true "User ID" format(uuid)
+// @Success 200 {object} coderd.SCIMUser
 // @Failure 404
 // @Router /scim/v2/Users/{id} [get]
 //
 //nolint:revive
 func (api *API) scimGetUser(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	if !api.scimVerifyAuthHeader(r) {
 		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
 		return
 	}
 
-	_ = handlerutil.WriteError(rw, spec.ErrNotFound)
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, spec.ErrNotFound)
+		return
+	}
+	//nolint:gocritic // needed for SCIM
+	dbUser, err := api.Database.GetUserByID(dbauthz.AsSystemRestricted(ctx), id)
+	if xerrors.Is(err, sql.ErrNoRows) || (err == nil && dbUser.Deleted) {
+		_ = handlerutil.WriteError(rw, spec.ErrNotFound)
+		return
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimUserFromDatabase(dbUser))
 }
 
 // We currently use our own struct instead of using the SCIM package. This was
@@ -171,6 +334,15 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Users are created in the organization of the base URL the identity
+	// provider is configured with, see scimOrganization.
+	//nolint:gocritic // needed for SCIM
+	org, err := api.scimOrganization(dbauthz.AsSystemRestricted(ctx), r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
 	//nolint:gocritic
 	dbUser, err := api.Database.GetUserByEmailOrUsername(dbauthz.AsSystemRestricted(ctx), database.GetUserByEmailOrUsernameParams{
 		Email:    email,
@@ -184,6 +356,13 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 		sUser.ID = dbUser.ID.String()
 		sUser.UserName = dbUser.Username
 
+		//nolint:gocritic // needed for SCIM
+		err = scimEnsureOrganizationMember(dbauthz.AsSystemRestricted(ctx), api.Database, org.ID, dbUser.ID)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+
 		if sUser.Active && dbUser.Status == database.UserStatusSuspended {
 			//nolint:gocritic
 			newUser, err := api.Database.UpdateUserStatus(dbauthz.AsSystemRestricted(r.Context()), database.UpdateUserStatusParams{
@@ -221,22 +400,12 @@ func (api *API) scimPostUser(rw http.ResponseWriter, r *http.Request) {
 		sUser.UserName = httpapi.UsernameFrom(sUser.UserName)
 	}
 
-	// TODO: This is a temporary solution that does not support multi-org
-	// 	deployments. This assumption places all new SCIM users into the
-	//	default organization.
-	//nolint:gocritic
-	defaultOrganization, err := api.Database.GetDefaultOrganization(dbauthz.AsSystemRestricted(ctx))
-	if err != nil {
-		_ = handlerutil.WriteError(rw, err)
-		return
-	}
-
 	//nolint:gocritic // needed for SCIM
 	dbUser, _, err = api.AGPL.CreateUser(dbauthz.AsSystemRestricted(ctx), api.Database, agpl.CreateUserRequest{
 		CreateUserRequest: codersdk.CreateUserRequest{
 			Username:       sUser.UserName,
 			Email:          email,
-			OrganizationID: defaultOrganization.ID,
+			OrganizationID: org.ID,
 		},
 		LoginType: database.LoginTypeOIDC,
 	})
@@ -344,3 +513,251 @@ func (api *API) scimPatchUser(rw http.ResponseWriter, r *http.Request) {
 	aReq.New = dbUser
 	httpapi.Write(ctx, rw, http.StatusOK, sUser)
 }
+
+// scimOrganization returns the organization that users and groups are
+// provisioned in. Requests to /scim/v2 use the default organization, and
+// requests to /scim/v2/organizations/{organization} use the given organization,
+// so an application can be configured in the identity provider for each
+// organization.
+func (api *API) scimOrganization(ctx context.Context, r *http.Request) (database.Organization, error) {
+	arg := chi.URLParam(r, "organization")
+	if arg == "" {
+		return api.Database.GetDefaultOrganization(ctx)
+	}
+
+	var (
+		org database.Organization
+		err error
+	)
+	if id, parseErr := uuid.Parse(arg); parseErr == nil {
+		org, err = api.Database.GetOrganizationByID(ctx, id)
+	} else {
+		org, err = api.Database.GetOrganizationByName(ctx, arg)
+	}
+	if xerrors.Is(err, sql.ErrNoRows) {
+		return database.Organization{}, spec.ErrNotFound
+	}
+	return org, err
+}
+
+// scimEnsureOrganizationMember adds the user to the organization if they are
+// not a member of it already.
+func scimEnsureOrganizationMember(ctx context.Context, db database.Store, orgID uuid.UUID, userID uuid.UUID) error {
+	_, err := database.ExpectOne(db.OrganizationMembers(ctx, database.OrganizationMembersParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	}))
+	if err == nil {
+		return nil
+	}
+	if !xerrors.Is(err, sql.ErrNoRows) {
+		return xerrors.Errorf("get organization member: %w", err)
+	}
+	_, err = db.InsertOrganizationMember(ctx, database.InsertOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+		CreatedAt:      dbtime.Now(),
+		UpdatedAt:      dbtime.Now(),
+		Roles:          []string{},
+	})
+	if err != nil {
+		return xerrors.Errorf("insert organization member: %w", err)
+	}
+	return nil
+}
+
+func scimUserFromDatabase(user database.User) SCIMUser {
+	var sUser SCIMUser
+	sUser.Schemas = []string{scimUserSchema}
+	sUser.ID = user.ID.String()
+	sUser.UserName = user.Username
+	sUser.Emails = make([]struct {
+		Primary bool   `json:"primary"`
+		Value   string `json:"value" format:"email"`
+		Type    string `json:"type"`
+		Display string `json:"display"`
+	}, 1)
+	sUser.Emails[0].Primary = true
+	sUser.Emails[0].Value = user.Email
+	sUser.Emails[0].Type = "work"
+	sUser.Active = user.Status != database.UserStatusSuspended
+	sUser.Groups = []interface{}{}
+	sUser.Meta.ResourceType = "User"
+	return sUser
+}
+
+// scimServiceProviderConfig describes the SCIM features Coder supports.
+//
+// @Summary SCIM 2.0: Service provider config
+// @ID scim-get-service-provider-config
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Success 200
+// @Router /scim/v2/ServiceProviderConfig [get]
+func (api *API) scimServiceProviderConfig(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	unsupported := map[string]bool{"supported": false}
+	httpapi.Write(ctx, rw, http.StatusOK, map[string]any{
+		"schemas": []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":   map[string]bool{"supported": true},
+		"bulk": map[string]any{
+			"supported":      false,
+			"maxOperations":  0,
+			"maxPayloadSize": 0,
+		},
+		"filter": map[string]any{
+			"supported":  true,
+			"maxResults": scimMaxResults,
+		},
+		"changePassword": unsupported,
+		"sort":           unsupported,
+		"etag":           unsupported,
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "API key",
+			"description": "The key configured with CODER_SCIM_API_KEY, sent in the Authorization header with or without the Bearer prefix.",
+			"primary":     true,
+		}},
+		"meta": map[string]string{"resourceType": "ServiceProviderConfig"},
+	})
+}
+
+type scimSchemaAttribute struct {
+	Name          string                `json:"name"`
+	Type          string                `json:"type"`
+	MultiValued   bool                  `json:"multiValued"`
+	Required      bool                  `json:"required"`
+	CaseExact     bool                  `json:"caseExact"`
+	Mutability    string                `json:"mutability"`
+	Returned      string                `json:"returned"`
+	Uniqueness    string                `json:"uniqueness"`
+	SubAttributes []scimSchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+type scimSchema struct {
+	Schemas     []string              `json:"schemas"`
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Attributes  []scimSchemaAttribute `json:"attributes"`
+	Meta        struct {
+		ResourceType string `json:"resourceType"`
+	} `json:"meta"`
+}
+
+func scimAttribute(name, typ string, subAttributes ...scimSchemaAttribute) scimSchemaAttribute {
+	return scimSchemaAttribute{
+		Name:          name,
+		Type:          typ,
+		Mutability:    "readWrite",
+		Returned:      "default",
+		Uniqueness:    "none",
+		SubAttributes: subAttributes,
+	}
+}
+
+// scimSchemas only lists the attributes of users and groups that Coder
+// stores. Other attributes are accepted but ignored.
+func scimSchemas() []scimSchema {
+	userName := scimAttribute("userName", "string")
+	userName.Required = true
+	userName.Uniqueness = "server"
+	emails := scimAttribute("emails", "complex",
+		scimAttribute("value", "string"),
+		scimAttribute("type", "string"),
+		scimAttribute("primary", "boolean"),
+	)
+	emails.MultiValued = true
+	displayName := scimAttribute("displayName", "string")
+	displayName.Required = true
+	members := scimAttribute("members", "complex",
+		scimAttribute("value", "string"),
+		scimAttribute("display", "string"),
+	)
+	members.MultiValued = true
+
+	schemas := []scimSchema{{
+		ID:          scimUserSchema,
+		Name:        "User",
+		Description: "User Account",
+		Attributes: []scimSchemaAttribute{
+			userName,
+			scimAttribute("name", "complex",
+				scimAttribute("givenName", "string"),
+				scimAttribute("familyName", "string"),
+			),
+			emails,
+			scimAttribute("active", "boolean"),
+		},
+	}, {
+		ID:          scimGroupSchema,
+		Name:        "Group",
+		Description: "Group",
+		Attributes:  []scimSchemaAttribute{displayName, members},
+	}}
+	for i := range schemas {
+		schemas[i].Schemas = []string{"urn:ietf:params:scim:schemas:core:2.0:Schema"}
+		schemas[i].Meta.ResourceType = "Schema"
+	}
+	return schemas
+}
+
+// scimGetSchemas returns the schemas of the resources Coder supports.
+//
+// @Summary SCIM 2.0: Get schemas
+// @ID scim-get-schemas
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Success 200
+// @Router /scim/v2/Schemas [get]
+func (api *API) scimGetSchemas(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	schemas := scimSchemas()
+	httpapi.Write(ctx, rw, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: len(schemas),
+		StartIndex:   1,
+		ItemsPerPage: len(schemas),
+		Resources:    schemas,
+	})
+}
+
+// scimGetSchema returns a schema by its URN.
+//
+// @Summary SCIM 2.0: Get schema by ID
+// @ID scim-get-schema-by-id
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param id path string true "Schema URN"
+// @Success 200
+// @Failure 404
+// @Router /scim/v2/Schemas/{id} [get]
+func (api *API) scimGetSchema(rw http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	for _, schema := range scimSchemas() {
+		if schema.ID == id {
+			httpapi.Write(ctx, rw, http.StatusOK, schema)
+			return
+		}
+	}
+	_ = handlerutil.WriteError(rw, spec.ErrNotFound)
+}
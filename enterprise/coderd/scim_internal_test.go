@@ -0,0 +1,157 @@
+package coderd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSCIMFilter(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		Name     string
+		Filter   string
+		Expected scimFilter
+		Error    bool
+	}{
+		{
+			Name:     "Empty",
+			Filter:   "",
+			Expected: scimFilter{},
+		},
+		{
+			Name:     "Eq",
+			Filter:   `userName eq "alice"`,
+			Expected: scimFilter{"username": "alice"},
+		},
+		{
+			Name:     "And",
+			Filter:   `userName EQ "alice" and active eq true`,
+			Expected: scimFilter{"username": "alice", "active": "true"},
+		},
+		{
+			Name:     "Escaped",
+			Filter:   `displayName eq "say \"hi\" and bye"`,
+			Expected: scimFilter{"displayname": `say "hi" and bye`},
+		},
+		{
+			Name:   "Or",
+			Filter: `userName eq "alice" or userName eq "bob"`,
+			Error:  true,
+		},
+		{
+			Name:   "StartsWith",
+			Filter: `userName sw "a"`,
+			Error:  true,
+		},
+		{
+			Name:   "Unterminated",
+			Filter: `userName eq "alice`,
+			Error:  true,
+		},
+		{
+			Name:   "Repeated",
+			Filter: `userName eq "alice" and userName eq "bob"`,
+			Error:  true,
+		},
+	}
+
+	for _, tt := range testcases {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := parseSCIMFilter(tt.Filter)
+			if tt.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.Expected, filter)
+		})
+	}
+}
+
+func Test_applySCIMGroupPatch(t *testing.T) {
+	t.Parallel()
+
+	const (
+		alice = "0b5f1ff6-3c4d-4a5e-9a4b-2a3c5e1f7d01"
+		bob   = "8e8c6d9a-7f41-4f5a-bb43-6f0d2e9b4c02"
+	)
+
+	testcases := []struct {
+		Name        string
+		Operations  string
+		DisplayName string
+		Members     map[string]bool
+		Error       bool
+	}{
+		{
+			Name:        "AddMembers",
+			Operations:  `[{"op":"add","path":"members","value":[{"value":"` + bob + `"}]}]`,
+			DisplayName: "group",
+			Members:     map[string]bool{alice: true, bob: true},
+		},
+		{
+			Name:        "RemoveMemberByFilter",
+			Operations:  `[{"op":"Remove","path":"members[value eq \"` + alice + `\"]"}]`,
+			DisplayName: "group",
+			Members:     map[string]bool{},
+		},
+		{
+			Name:        "RemoveAllMembers",
+			Operations:  `[{"op":"remove","path":"members"}]`,
+			DisplayName: "group",
+			Members:     map[string]bool{},
+		},
+		{
+			Name:        "ReplaceWithoutPath",
+			Operations:  `[{"op":"replace","value":{"id":"ignored","displayName":"renamed","members":[{"value":"` + bob + `"}]}}]`,
+			DisplayName: "renamed",
+			Members:     map[string]bool{bob: true},
+		},
+		{
+			Name:        "ReplaceDisplayName",
+			Operations:  `[{"op":"Replace","path":"displayName","value":"renamed"}]`,
+			DisplayName: "renamed",
+			Members:     map[string]bool{alice: true},
+		},
+		{
+			Name:       "RemoveDisplayName",
+			Operations: `[{"op":"remove","path":"displayName"}]`,
+			Error:      true,
+		},
+		{
+			Name:       "UnknownPath",
+			Operations: `[{"op":"add","path":"externalId","value":"x"}]`,
+			Error:      true,
+		},
+		{
+			Name:       "UnknownOp",
+			Operations: `[{"op":"move","path":"members"}]`,
+			Error:      true,
+		},
+	}
+
+	for _, tt := range testcases {
+		tt := tt
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+
+			var ops []SCIMPatchOperation
+			require.NoError(t, json.Unmarshal([]byte(tt.Operations), &ops))
+			members := map[string]bool{alice: true}
+			displayName, err := applySCIMGroupPatch(ops, "group", members)
+			if tt.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.DisplayName, displayName)
+			require.Equal(t, tt.Members, members)
+		})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -444,4 +446,240 @@ func TestScim(t *testing.T) {
 			require.Equal(t, codersdk.UserStatusActive, scimUser.Status, "user is still active")
 		})
 	})
+
+	t.Run("getUsers", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			SCIMAPIKey: scimAPIKey,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM: 1,
+				},
+			},
+		})
+
+		sUser := makeScimUser(t)
+		res, err := client.Request(ctx, "POST", "/scim/v2/Users", sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&sUser)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+
+		var list scimListResponse[coderd.SCIMUser]
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?filter="+url.QueryEscape(fmt.Sprintf("userName eq %q", sUser.UserName)), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 1, list.TotalResults)
+		require.Equal(t, sUser.ID, list.Resources[0].ID)
+		require.True(t, list.Resources[0].Active)
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`userName eq "nobody"`), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 0, list.TotalResults)
+
+		// The first user and the SCIM user are paged through one at a time.
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?startIndex=2&count=1", nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 2, list.TotalResults)
+		require.Len(t, list.Resources, 1)
+
+		list = scimListResponse[coderd.SCIMUser]{}
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?startIndex=3", nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 2, list.TotalResults)
+		require.Empty(t, list.Resources)
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users?filter="+url.QueryEscape(`name.givenName sw "a"`), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		var got coderd.SCIMUser
+		res, err = client.Request(ctx, "GET", "/scim/v2/Users/"+sUser.ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		err = json.NewDecoder(res.Body).Decode(&got)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, sUser.UserName, got.UserName)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		mockAudit := audit.NewMock()
+		client, owner := coderdenttest.New(t, &coderdenttest.Options{
+			Options:      &coderdtest.Options{Auditor: mockAudit},
+			SCIMAPIKey:   scimAPIKey,
+			AuditLogging: true,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM:         1,
+					codersdk.FeatureAuditLog:     1,
+					codersdk.FeatureTemplateRBAC: 1,
+				},
+			},
+		})
+
+		sUser := makeScimUser(t)
+		res, err := client.Request(ctx, "POST", "/scim/v2/Users", sUser, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&sUser)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		mockAudit.ResetLogs()
+
+		// Display names that are not valid group names are converted to one.
+		sGroup := coderd.SCIMGroup{
+			DisplayName: "Platform Engineers",
+			Members:     []coderd.SCIMGroupMember{{Value: sUser.ID}},
+		}
+		res, err = client.Request(ctx, "POST", "/scim/v2/Groups", sGroup, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, res.StatusCode)
+		err = json.NewDecoder(res.Body).Decode(&sGroup)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Len(t, sGroup.Members, 1)
+
+		aLogs := mockAudit.AuditLogs()
+		require.Len(t, aLogs, 1)
+		assert.Equal(t, database.AuditActionCreate, aLogs[0].Action)
+		assert.Equal(t, database.ResourceTypeGroup, aLogs[0].ResourceType)
+
+		groupID := uuid.MustParse(sGroup.ID)
+		group, err := client.Group(ctx, groupID)
+		require.NoError(t, err)
+		require.Equal(t, "platform-engineers", group.Name)
+		require.Equal(t, "Platform Engineers", group.DisplayName)
+		require.Equal(t, owner.OrganizationID, group.OrganizationID)
+		require.Len(t, group.Members, 1)
+
+		res, err = client.Request(ctx, "POST", "/scim/v2/Groups", coderd.SCIMGroup{DisplayName: "platform-engineers"}, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusConflict, res.StatusCode)
+
+		var list scimListResponse[coderd.SCIMGroup]
+		res, err = client.Request(ctx, "GET", "/scim/v2/Groups?filter="+url.QueryEscape(`displayName eq "Platform Engineers"`), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 1, list.TotalResults)
+		require.Equal(t, sGroup.ID, list.Resources[0].ID)
+
+		// Remove the member the way Entra ID does.
+		mockAudit.ResetLogs()
+		res, err = client.Request(ctx, "PATCH", "/scim/v2/Groups/"+sGroup.ID, map[string]any{
+			"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
+			"Operations": []map[string]any{{
+				"op":   "Remove",
+				"path": fmt.Sprintf("members[value eq %q]", sUser.ID),
+			}, {
+				"op":    "Replace",
+				"path":  "displayName",
+				"value": "Platform",
+			}},
+		}, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		aLogs = mockAudit.AuditLogs()
+		require.Len(t, aLogs, 1)
+		assert.Equal(t, database.AuditActionWrite, aLogs[0].Action)
+
+		group, err = client.Group(ctx, groupID)
+		require.NoError(t, err)
+		require.Equal(t, "Platform", group.Name)
+		require.Empty(t, group.Members)
+
+		// The "Everyone" group is managed by Coder.
+		res, err = client.Request(ctx, "GET", "/scim/v2/Groups/"+owner.OrganizationID.String(), nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		res, err = client.Request(ctx, "DELETE", "/scim/v2/Groups/"+sGroup.ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusNoContent, res.StatusCode)
+
+		_, err = client.Group(ctx, groupID)
+		var apiErr *codersdk.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode())
+	})
+
+	t.Run("discovery", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(context.Background(), testutil.WaitLong)
+		defer cancel()
+
+		scimAPIKey := []byte("hi")
+		client, _ := coderdenttest.New(t, &coderdenttest.Options{
+			SCIMAPIKey: scimAPIKey,
+			LicenseOptions: &coderdenttest.LicenseOptions{
+				AccountID: "coolin",
+				Features: license.Features{
+					codersdk.FeatureSCIM: 1,
+				},
+			},
+		})
+
+		res, err := client.Request(ctx, "GET", "/scim/v2/ServiceProviderConfig", nil)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.NotEqual(t, http.StatusOK, res.StatusCode)
+
+		// Entra ID sends the key as a bearer token.
+		res, err = client.Request(ctx, "GET", "/scim/v2/ServiceProviderConfig", nil, setScimAuth([]byte("Bearer hi")))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var list scimListResponse[struct {
+			ID string `json:"id"`
+		}]
+		res, err = client.Request(ctx, "GET", "/scim/v2/Schemas", nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		err = json.NewDecoder(res.Body).Decode(&list)
+		_ = res.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 2, list.TotalResults)
+
+		res, err = client.Request(ctx, "GET", "/scim/v2/Schemas/"+list.Resources[1].ID, nil, setScimAuth(scimAPIKey))
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+}
+
+type scimListResponse[T any] struct {
+	TotalResults int `json:"totalResults"`
+	Resources    []T `json:"Resources"`
 }
@@ -0,0 +1,193 @@
+package coderd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/imulab/go-scim/pkg/v2/spec"
+)
+
+const (
+	scimUserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimGroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	// scimMaxResults is the maximum number of resources returned by a query.
+	scimMaxResults = 100
+)
+
+type scimListResponse struct {
+	Schemas      []string    `json:"schemas"`
+	TotalResults int         `json:"totalResults"`
+	StartIndex   int         `json:"startIndex"`
+	ItemsPerPage int         `json:"itemsPerPage"`
+	Resources    interface{} `json:"Resources"`
+}
+
+// scimPage parses the 1-based startIndex and the count query parameters of a
+// SCIM query. Out of range values are clamped as described in RFC 7644.
+func scimPage(r *http.Request) (startIndex int, count int, err error) {
+	startIndex, count = 1, scimMaxResults
+	if v := r.URL.Query().Get("startIndex"); v != "" {
+		startIndex, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+		}
+	}
+	if v := r.URL.Query().Get("count"); v != "" {
+		count, err = strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+		}
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	if count < 0 {
+		count = 0
+	}
+	if count > scimMaxResults {
+		count = scimMaxResults
+	}
+	return startIndex, count, nil
+}
+
+func scimPaginate[T any](resources []T, startIndex, count int) []T {
+	if startIndex > len(resources) {
+		return []T{}
+	}
+	resources = resources[startIndex-1:]
+	if count < len(resources) {
+		resources = resources[:count]
+	}
+	return resources
+}
+
+// scimFilter is a SCIM filter keyed by lowercase attribute name. Identity
+// providers only filter by equality when looking up a resource before creating
+// it, so only "eq" comparisons joined by "and" are supported.
+type scimFilter map[string]string
+
+func parseSCIMFilter(filter string) (scimFilter, error) {
+	invalid := &spec.Error{Status: http.StatusBadRequest, Type: "invalidFilter"}
+	parsed := scimFilter{}
+	rest := strings.TrimSpace(filter)
+	for rest != "" {
+		if len(parsed) > 0 {
+			var and string
+			and, rest = scimFilterToken(rest)
+			if !strings.EqualFold(and, "and") {
+				return nil, invalid
+			}
+		}
+
+		var attr, op, value string
+		attr, rest = scimFilterToken(rest)
+		op, rest = scimFilterToken(rest)
+		if attr == "" || !strings.EqualFold(op, "eq") {
+			return nil, invalid
+		}
+		if strings.HasPrefix(rest, `"`) {
+			var ok bool
+			value, rest, ok = scimFilterString(rest)
+			if !ok {
+				return nil, invalid
+			}
+		} else {
+			// Unquoted values are booleans, numbers, or null.
+			value, rest = scimFilterToken(rest)
+			if value == "" {
+				return nil, invalid
+			}
+		}
+
+		attr = strings.ToLower(attr)
+		if _, ok := parsed[attr]; ok {
+			return nil, invalid
+		}
+		parsed[attr] = value
+	}
+	return parsed, nil
+}
+
+// scimFilterToken splits s at the first space.
+func scimFilterToken(s string) (token string, rest string) {
+	token, rest, _ = strings.Cut(s, " ")
+	return token, strings.TrimSpace(rest)
+}
+
+// scimFilterString reads the JSON string at the start of s.
+func scimFilterString(s string) (value string, rest string, ok bool) {
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i == len(s) {
+				return "", "", false
+			}
+			sb.WriteByte(s[i])
+		case '"':
+			return sb.String(), strings.TrimSpace(s[i+1:]), true
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return "", "", false
+}
+
+// supports returns an error if the filter has an attribute other than the
+// given ones.
+func (f scimFilter) supports(attrs ...string) error {
+	for attr := range f {
+		supported := false
+		for _, a := range attrs {
+			if attr == a {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return &spec.Error{Status: http.StatusBadRequest, Type: "invalidFilter"}
+		}
+	}
+	return nil
+}
+
+func (f scimFilter) email() string {
+	if v, ok := f["emails.value"]; ok {
+		return v
+	}
+	return f["emails"]
+}
+
+func (f scimFilter) matchesUser(user SCIMUser) bool {
+	if v, ok := f["id"]; ok && v != user.ID {
+		return false
+	}
+	if v, ok := f["username"]; ok && !strings.EqualFold(v, user.UserName) {
+		return false
+	}
+	if v, ok := f["active"]; ok && !strings.EqualFold(v, strconv.FormatBool(user.Active)) {
+		return false
+	}
+	if email := f.email(); email != "" {
+		for _, e := range user.Emails {
+			if strings.EqualFold(email, e.Value) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (f scimFilter) matchesGroup(group SCIMGroup) bool {
+	if v, ok := f["id"]; ok && v != group.ID {
+		return false
+	}
+	if v, ok := f["displayname"]; ok && !strings.EqualFold(v, group.DisplayName) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,668 @@
+package coderd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/imulab/go-scim/pkg/v2/handlerutil"
+	"github.com/imulab/go-scim/pkg/v2/spec"
+	"golang.org/x/xerrors"
+
+	"github.com/coder/coder/v2/coderd/audit"
+	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/httpapi"
+)
+
+// SCIMGroup is a group as represented by SCIM. Like SCIMUser, only the fields
+// Coder uses are included.
+type SCIMGroup struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	DisplayName string            `json:"displayName"`
+	Members     []SCIMGroupMember `json:"members,omitempty"`
+	Meta        struct {
+		ResourceType string `json:"resourceType"`
+	} `json:"meta"`
+}
+
+// SCIMGroupMember is a member of a SCIMGroup.
+type SCIMGroupMember struct {
+	// Value is the ID of the user.
+	Value   string `json:"value" format:"uuid"`
+	Display string `json:"display,omitempty"`
+}
+
+// SCIMPatchOp is a SCIM PATCH request as described in RFC 7644 section 3.5.2.
+type SCIMPatchOp struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+var scimGroupNameReplace = regexp.MustCompile("[^a-z0-9]+")
+
+// scimGroupName returns the Coder group name for a SCIM display name. Display
+// names are often not valid names, e.g. "Platform Engineers", so they are
+// converted to one, e.g. "platform-engineers". The display name is kept as the
+// group's display name.
+func scimGroupName(displayName string) (string, error) {
+	name := displayName
+	if httpapi.NameValid(name) != nil {
+		name = scimGroupNameReplace.ReplaceAllString(strings.ToLower(name), "-")
+		name = strings.Trim(name, "-")
+		if len(name) > 32 {
+			name = strings.TrimRight(name[:32], "-")
+		}
+	}
+	if httpapi.NameValid(name) != nil {
+		return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+	}
+	if name == database.EveryoneGroup {
+		return "", &spec.Error{Status: http.StatusConflict, Type: "uniqueness"}
+	}
+	return name, nil
+}
+
+func scimGroupFromDatabase(group database.Group, members []database.User) SCIMGroup {
+	sGroup := SCIMGroup{
+		Schemas:     []string{scimGroupSchema},
+		ID:          group.ID.String(),
+		DisplayName: group.DisplayName,
+	}
+	if sGroup.DisplayName == "" {
+		sGroup.DisplayName = group.Name
+	}
+	for _, member := range members {
+		sGroup.Members = append(sGroup.Members, SCIMGroupMember{
+			Value:   member.ID.String(),
+			Display: member.Username,
+		})
+	}
+	sGroup.Meta.ResourceType = "Group"
+	return sGroup
+}
+
+// scimGroup returns the group in the URL. The "Everyone" group is managed by
+// Coder, so it is hidden from SCIM.
+func (api *API) scimGroup(ctx context.Context, r *http.Request, org database.Organization) (database.Group, error) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		return database.Group{}, spec.ErrNotFound
+	}
+	group, err := api.Database.GetGroupByID(ctx, id)
+	if xerrors.Is(err, sql.ErrNoRows) || (err == nil && (group.OrganizationID != org.ID || group.IsEveryone())) {
+		return database.Group{}, spec.ErrNotFound
+	}
+	return group, err
+}
+
+// scimGroupMemberIDs returns the IDs of the users in members, or an error if
+// any of them does not exist.
+func (api *API) scimGroupMemberIDs(ctx context.Context, members map[string]bool) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(members))
+	for member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			return nil, &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	users, err := api.Database.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != len(ids) {
+		return nil, &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+	}
+	return ids, nil
+}
+
+// scimSetGroupMembers adds and removes members of the group so that the given
+// users are its only members. Users are added to the group's organization if
+// they are not members of it.
+func scimSetGroupMembers(ctx context.Context, tx database.Store, group database.Group, current []database.User, members []uuid.UUID) error {
+	remove := make(map[uuid.UUID]bool, len(current))
+	for _, user := range current {
+		remove[user.ID] = true
+	}
+	for _, id := range members {
+		if remove[id] {
+			delete(remove, id)
+			continue
+		}
+		err := scimEnsureOrganizationMember(ctx, tx, group.OrganizationID, id)
+		if err != nil {
+			return err
+		}
+		err = tx.InsertGroupMember(ctx, database.InsertGroupMemberParams{
+			GroupID: group.ID,
+			UserID:  id,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert group member %q: %w", id, err)
+		}
+	}
+	for id := range remove {
+		err := tx.DeleteGroupMemberFromGroup(ctx, database.DeleteGroupMemberFromGroupParams{
+			UserID:  id,
+			GroupID: group.ID,
+		})
+		if err != nil {
+			return xerrors.Errorf("delete group member %q: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// scimMemberKey normalizes a member value so the same user is not added to a
+// set of members twice.
+func scimMemberKey(value string) string {
+	if id, err := uuid.Parse(value); err == nil {
+		return id.String()
+	}
+	return value
+}
+
+// applySCIMGroupPatch applies PATCH operations to the display name and members
+// of a group. Okta and Entra ID both send operations with and without a path,
+// and Entra ID capitalizes the names of operations.
+func applySCIMGroupPatch(ops []SCIMPatchOperation, displayName string, members map[string]bool) (string, error) {
+	for _, op := range ops {
+		opName := strings.ToLower(op.Op)
+		if opName != "add" && opName != "remove" && opName != "replace" {
+			return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidSyntax"}
+		}
+		path := strings.TrimSpace(op.Path)
+		lowerPath := strings.ToLower(path)
+
+		switch {
+		case path == "":
+			if opName == "remove" {
+				return "", &spec.Error{Status: http.StatusBadRequest, Type: "noTarget"}
+			}
+			var value struct {
+				DisplayName *string            `json:"displayName"`
+				Members     *[]SCIMGroupMember `json:"members"`
+			}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+			}
+			if value.DisplayName != nil {
+				displayName = *value.DisplayName
+			}
+			if value.Members != nil {
+				if opName == "replace" {
+					clear(members)
+				}
+				for _, member := range *value.Members {
+					members[scimMemberKey(member.Value)] = true
+				}
+			}
+		case lowerPath == "displayname":
+			if opName == "remove" {
+				return "", &spec.Error{Status: http.StatusBadRequest, Type: "mutability"}
+			}
+			if err := json.Unmarshal(op.Value, &displayName); err != nil {
+				return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+			}
+		case lowerPath == "members":
+			var value []SCIMGroupMember
+			if len(op.Value) > 0 {
+				if err := json.Unmarshal(op.Value, &value); err != nil {
+					return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidValue"}
+				}
+			}
+			if opName == "replace" || (opName == "remove" && len(value) == 0) {
+				clear(members)
+			}
+			for _, member := range value {
+				if opName == "remove" {
+					delete(members, scimMemberKey(member.Value))
+					continue
+				}
+				members[scimMemberKey(member.Value)] = true
+			}
+		case strings.HasPrefix(lowerPath, "members[") && strings.HasSuffix(lowerPath, "]"):
+			// e.g. members[value eq "8f1a..."], which is only valid for
+			// removing a member.
+			filter, err := parseSCIMFilter(path[len("members[") : len(path)-1])
+			if err != nil || opName != "remove" || len(filter) != 1 || filter["value"] == "" {
+				return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidPath"}
+			}
+			delete(members, scimMemberKey(filter["value"]))
+		default:
+			return "", &spec.Error{Status: http.StatusBadRequest, Type: "invalidPath"}
+		}
+	}
+	return displayName, nil
+}
+
+// scimGetGroups returns the groups of the organization matching the SCIM
+// filter.
+//
+// @Summary SCIM 2.0: Get groups
+// @ID scim-get-groups
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param filter query string false "SCIM filter"
+// @Param startIndex query int false "1-based index of the first result"
+// @Param count query int false "Maximum number of results"
+// @Param excludedAttributes query string false "Set to members to omit group members"
+// @Success 200
+// @Router /scim/v2/Groups [get]
+func (api *API) scimGetGroups(rw http.ResponseWriter, r *http.Request) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	filter, err := parseSCIMFilter(r.URL.Query().Get("filter"))
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	err = filter.supports("displayname", "id")
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	startIndex, count, err := scimPage(r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	excludeMembers := strings.EqualFold(r.URL.Query().Get("excludedAttributes"), "members")
+
+	org, err := api.scimOrganization(ctx, r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	groups, err := api.Database.GetGroupsByOrganizationID(ctx, org.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	matched := make([]database.Group, 0, len(groups))
+	for _, group := range groups {
+		if group.IsEveryone() || !filter.matchesGroup(scimGroupFromDatabase(group, nil)) {
+			continue
+		}
+		matched = append(matched, group)
+	}
+	page := scimPaginate(matched, startIndex, count)
+
+	resources := make([]SCIMGroup, 0, len(page))
+	for _, group := range page {
+		var members []database.User
+		if !excludeMembers {
+			members, err = api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+			if err != nil {
+				_ = handlerutil.WriteError(rw, err)
+				return
+			}
+		}
+		resources = append(resources, scimGroupFromDatabase(group, members))
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimListResponseSchema},
+		TotalResults: len(matched),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+// scimGetGroup returns a group by ID.
+//
+// @Summary SCIM 2.0: Get group by ID
+// @ID scim-get-group-by-id
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Success 200 {object} coderd.SCIMGroup
+// @Failure 404
+// @Router /scim/v2/Groups/{id} [get]
+func (api *API) scimGetGroup(rw http.ResponseWriter, r *http.Request) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	org, err := api.scimOrganization(ctx, r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	group, err := api.scimGroup(ctx, r, org)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	members, err := api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimGroupFromDatabase(group, members))
+}
+
+// scimPostGroup creates a group in the organization.
+//
+// @Summary SCIM 2.0: Create new group
+// @ID scim-create-new-group
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param request body coderd.SCIMGroup true "New group"
+// @Success 201 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups [post]
+func (api *API) scimPostGroup(rw http.ResponseWriter, r *http.Request) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	org, err := api.scimOrganization(ctx, r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	auditor := *api.AGPL.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.AuditableGroup](rw, &audit.RequestParams{
+		Audit:            auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionCreate,
+		OrganizationID:   org.ID,
+		AdditionalFields: SCIMAuditAdditionalFields,
+	})
+	defer commitAudit()
+
+	var sGroup SCIMGroup
+	err = json.NewDecoder(r.Body).Decode(&sGroup)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	name, err := scimGroupName(sGroup.DisplayName)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	members := make(map[string]bool, len(sGroup.Members))
+	for _, member := range sGroup.Members {
+		members[scimMemberKey(member.Value)] = true
+	}
+	memberIDs, err := api.scimGroupMemberIDs(ctx, members)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	var group database.Group
+	err = api.Database.InTx(func(tx database.Store) error {
+		group, err = tx.InsertGroup(ctx, database.InsertGroupParams{
+			ID:             uuid.New(),
+			Name:           name,
+			DisplayName:    sGroup.DisplayName,
+			OrganizationID: org.ID,
+		})
+		if err != nil {
+			return xerrors.Errorf("insert group: %w", err)
+		}
+		return scimSetGroupMembers(ctx, tx, group, nil, memberIDs)
+	}, nil)
+	if database.IsUniqueViolation(err) {
+		_ = handlerutil.WriteError(rw, &spec.Error{Status: http.StatusConflict, Type: "uniqueness"})
+		return
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	groupMembers, err := api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	aReq.New = group.Auditable(groupMembers)
+
+	httpapi.Write(ctx, rw, http.StatusCreated, scimGroupFromDatabase(group, groupMembers))
+}
+
+// scimPutGroup replaces the display name and members of a group.
+//
+// @Summary SCIM 2.0: Replace group
+// @ID scim-replace-group
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Param request body coderd.SCIMGroup true "Replace group request"
+// @Success 200 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups/{id} [put]
+func (api *API) scimPutGroup(rw http.ResponseWriter, r *http.Request) {
+	api.scimUpdateGroup(rw, r, func(_ string, members map[string]bool) (string, error) {
+		var sGroup SCIMGroup
+		err := json.NewDecoder(r.Body).Decode(&sGroup)
+		if err != nil {
+			return "", err
+		}
+		clear(members)
+		for _, member := range sGroup.Members {
+			members[scimMemberKey(member.Value)] = true
+		}
+		return sGroup.DisplayName, nil
+	})
+}
+
+// scimPatchGroup renames a group, or adds and removes its members.
+//
+// @Summary SCIM 2.0: Update group
+// @ID scim-update-group
+// @Security CoderSessionToken
+// @Produce application/scim+json
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Param request body coderd.SCIMPatchOp true "Patch group request"
+// @Success 200 {object} coderd.SCIMGroup
+// @Router /scim/v2/Groups/{id} [patch]
+func (api *API) scimPatchGroup(rw http.ResponseWriter, r *http.Request) {
+	api.scimUpdateGroup(rw, r, func(displayName string, members map[string]bool) (string, error) {
+		var patch SCIMPatchOp
+		err := json.NewDecoder(r.Body).Decode(&patch)
+		if err != nil {
+			return "", err
+		}
+		return applySCIMGroupPatch(patch.Operations, displayName, members)
+	})
+}
+
+// scimUpdateGroup updates the group in the URL to the display name and members
+// returned by update, which is given and modifies the current ones.
+func (api *API) scimUpdateGroup(rw http.ResponseWriter, r *http.Request, update func(displayName string, members map[string]bool) (string, error)) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	org, err := api.scimOrganization(ctx, r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	group, err := api.scimGroup(ctx, r, org)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	auditor := *api.AGPL.Auditor.Load()
+	aReq, commitAudit := audit.InitRequestWithCancel[database.AuditableGroup](rw, &audit.RequestParams{
+		Audit:            auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionWrite,
+		OrganizationID:   org.ID,
+		AdditionalFields: SCIMAuditAdditionalFields,
+	})
+	defer commitAudit(true)
+
+	currentMembers, err := api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	aReq.Old = group.Auditable(currentMembers)
+
+	current := scimGroupFromDatabase(group, currentMembers)
+	members := make(map[string]bool, len(currentMembers))
+	for _, member := range current.Members {
+		members[member.Value] = true
+	}
+	displayName, err := update(current.DisplayName, members)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	memberIDs, err := api.scimGroupMemberIDs(ctx, members)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	name := group.Name
+	if displayName != current.DisplayName {
+		name, err = scimGroupName(displayName)
+		if err != nil {
+			_ = handlerutil.WriteError(rw, err)
+			return
+		}
+	}
+	unchanged := displayName == current.DisplayName && len(memberIDs) == len(currentMembers)
+	for _, member := range current.Members {
+		unchanged = unchanged && members[member.Value]
+	}
+	if unchanged {
+		// Do not push an audit log if there is no change.
+		commitAudit(false)
+		httpapi.Write(ctx, rw, http.StatusOK, current)
+		return
+	}
+
+	err = api.Database.InTx(func(tx database.Store) error {
+		group, err = tx.UpdateGroupByID(ctx, database.UpdateGroupByIDParams{
+			ID:             group.ID,
+			Name:           name,
+			DisplayName:    displayName,
+			AvatarURL:      group.AvatarURL,
+			QuotaAllowance: group.QuotaAllowance,
+		})
+		if err != nil {
+			return xerrors.Errorf("update group by ID: %w", err)
+		}
+		return scimSetGroupMembers(ctx, tx, group, currentMembers, memberIDs)
+	}, nil)
+	if database.IsUniqueViolation(err) {
+		_ = handlerutil.WriteError(rw, &spec.Error{Status: http.StatusConflict, Type: "uniqueness"})
+		return
+	}
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	groupMembers, err := api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	aReq.New = group.Auditable(groupMembers)
+
+	httpapi.Write(ctx, rw, http.StatusOK, scimGroupFromDatabase(group, groupMembers))
+}
+
+// scimDeleteGroup deletes a group. Its members lose the access it granted
+// immediately, without having to log in again.
+//
+// @Summary SCIM 2.0: Delete group
+// @ID scim-delete-group
+// @Security CoderSessionToken
+// @Tags Enterprise
+// @Param id path string true "Group ID" format(uuid)
+// @Success 204
+// @Router /scim/v2/Groups/{id} [delete]
+func (api *API) scimDeleteGroup(rw http.ResponseWriter, r *http.Request) {
+	//nolint:gocritic // SCIM authenticates via a header and is not a user.
+	ctx := dbauthz.AsSystemRestricted(r.Context())
+	if !api.scimVerifyAuthHeader(r) {
+		_ = handlerutil.WriteError(rw, spec.Error{Status: http.StatusUnauthorized, Type: "invalidAuthorization"})
+		return
+	}
+
+	org, err := api.scimOrganization(ctx, r)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	group, err := api.scimGroup(ctx, r, org)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	auditor := *api.AGPL.Auditor.Load()
+	aReq, commitAudit := audit.InitRequest[database.AuditableGroup](rw, &audit.RequestParams{
+		Audit:            auditor,
+		Log:              api.Logger,
+		Request:          r,
+		Action:           database.AuditActionDelete,
+		OrganizationID:   org.ID,
+		AdditionalFields: SCIMAuditAdditionalFields,
+	})
+	defer commitAudit()
+
+	groupMembers, err := api.Database.GetGroupMembersByGroupID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+	aReq.Old = group.Auditable(groupMembers)
+
+	err = api.Database.DeleteGroupByID(ctx, group.ID)
+	if err != nil {
+		_ = handlerutil.WriteError(rw, err)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/coder/coder/v2/coderd/coderdtest"
@@ -60,3 +61,77 @@ func TestWorkspacePortShare(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, codersdk.WorkspaceAgentPortShareLevelPublic, ps.ShareLevel)
 }
+
+func TestWorkspacePortShareMaxLevel(t *testing.T) {
+	t.Parallel()
+
+	ownerClient, owner := coderdenttest.New(t, &coderdenttest.Options{
+		Options: &coderdtest.Options{
+			IncludeProvisionerDaemon: true,
+		},
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureControlSharedPorts: 1,
+				codersdk.FeatureTemplateRBAC:       1,
+			},
+		},
+	})
+	client, user := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID, rbac.RoleTemplateAdmin())
+	_, other := coderdtest.CreateAnotherUser(t, ownerClient, owner.OrganizationID)
+	r := setupWorkspaceAgent(t, client, codersdk.CreateFirstUserResponse{
+		UserID:         user.ID,
+		OrganizationID: owner.OrganizationID,
+	}, 0)
+	ctx := testutil.Context(t, testutil.WaitShort)
+
+	level := codersdk.WorkspaceAgentPortShareLevelOrganization
+	_, err := client.UpdateTemplateMeta(ctx, r.workspace.TemplateID, codersdk.UpdateTemplateMeta{
+		MaxPortShareLevel: &level,
+	})
+	require.NoError(t, err)
+
+	// Levels wider than the maximum are rejected.
+	_, err = client.UpsertWorkspaceAgentPortShare(ctx, r.workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+		AgentName:  r.sdkAgent.Name,
+		Port:       8080,
+		ShareLevel: codersdk.WorkspaceAgentPortShareLevelAuthenticated,
+		Protocol:   codersdk.WorkspaceAgentPortShareProtocolHTTP,
+	})
+	require.Error(t, err)
+
+	ps, err := client.UpsertWorkspaceAgentPortShare(ctx, r.workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+		AgentName:  r.sdkAgent.Name,
+		Port:       8080,
+		ShareLevel: codersdk.WorkspaceAgentPortShareLevelOrganization,
+		Protocol:   codersdk.WorkspaceAgentPortShareProtocolHTTP,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, codersdk.WorkspaceAgentPortShareLevelOrganization, ps.ShareLevel)
+
+	group, err := ownerClient.CreateGroup(ctx, owner.OrganizationID, codersdk.CreateGroupRequest{Name: "port-viewers"})
+	require.NoError(t, err)
+	ps, err = client.UpsertWorkspaceAgentPortShare(ctx, r.workspace.ID, codersdk.UpsertWorkspaceAgentPortShareRequest{
+		AgentName:       r.sdkAgent.Name,
+		Port:            8081,
+		ShareLevel:      codersdk.WorkspaceAgentPortShareLevelAllowlist,
+		Protocol:        codersdk.WorkspaceAgentPortShareProtocolHTTP,
+		AllowedUserIDs:  []uuid.UUID{other.ID},
+		AllowedGroupIDs: []uuid.UUID{group.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{other.ID}, ps.AllowedUserIDs)
+	require.Equal(t, []uuid.UUID{group.ID}, ps.AllowedGroupIDs)
+
+	// Lowering the maximum narrows shares above it.
+	level = codersdk.WorkspaceAgentPortShareLevelAllowlist
+	_, err = client.UpdateTemplateMeta(ctx, r.workspace.TemplateID, codersdk.UpdateTemplateMeta{
+		MaxPortShareLevel: &level,
+	})
+	require.NoError(t, err)
+	shares, err := client.GetWorkspaceAgentPortShares(ctx, r.workspace.ID)
+	require.NoError(t, err)
+	require.Len(t, shares.Shares, 2)
+	for _, share := range shares.Shares {
+		require.EqualValues(t, codersdk.WorkspaceAgentPortShareLevelAllowlist, share.ShareLevel)
+	}
+}
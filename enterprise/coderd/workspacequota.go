@@ -11,10 +11,13 @@ import (
 	"cdr.dev/slog"
 
 	"github.com/coder/coder/v2/coderd/database"
+	"github.com/coder/coder/v2/coderd/database/dbauthz"
+	"github.com/coder/coder/v2/coderd/database/dbtime"
 	"github.com/coder/coder/v2/coderd/httpapi"
 	"github.com/coder/coder/v2/coderd/httpmw"
 	"github.com/coder/coder/v2/coderd/rbac/policy"
 	"github.com/coder/coder/v2/codersdk"
+	"github.com/coder/coder/v2/enterprise/coderd/quotabudget"
 	"github.com/coder/coder/v2/provisionerd/proto"
 )
 
@@ -41,6 +44,31 @@ func (c *committer) CommitQuota(
 		return nil, err
 	}
 
+	// Starts are rejected outright once a budget's hard limit has been
+	// reached, regardless of the cost of the new build. The spend is read
+	// outside of the transaction below, since it scans the builds of every
+	// member of a budget and would make serialization failures far more
+	// likely.
+	if nextBuild.Transition == database.WorkspaceTransitionStart {
+		quotaBudget, spend, reached, err := quotabudget.HardLimitReached(ctx, c.Database, workspace.OrganizationID, workspace.OwnerID, dbtime.Now())
+		if err != nil {
+			return nil, err
+		}
+		if reached {
+			c.Log.Debug(
+				ctx, "over quota budget, rejecting",
+				slog.F("budget_id", quotaBudget.ID),
+				slog.F("spend", spend),
+				slog.F("hard_limit", quotaBudget.HardLimit),
+			)
+			return &proto.CommitQuotaResponse{
+				Ok:              false,
+				CreditsConsumed: int32(spend),
+				Budget:          int32(quotaBudget.HardLimit),
+			}, nil
+		}
+	}
+
 	var (
 		consumed int64
 		budget   int64
@@ -134,6 +162,7 @@ func (api *API) workspaceQuota(rw http.ResponseWriter, r *http.Request) {
 
 	// There are no groups and thus no allowance if RBAC isn't licensed.
 	var quotaAllowance int64 = -1
+	budgets := []codersdk.QuotaBudgetStatus{}
 	if licensed {
 		var err error
 		quotaAllowance, err = api.Database.GetQuotaAllowanceForUser(r.Context(), user.ID)
@@ -144,6 +173,15 @@ func (api *API) workspaceQuota(rw http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+
+		budgets, err = api.userQuotaBudgets(r.Context(), user.ID)
+		if err != nil {
+			httpapi.Write(r.Context(), rw, http.StatusInternalServerError, codersdk.Response{
+				Message: "Failed to get budgets",
+				Detail:  err.Error(),
+			})
+			return
+		}
 	}
 
 	quotaConsumed, err := api.Database.GetQuotaConsumedForUser(r.Context(), user.ID)
@@ -158,5 +196,38 @@ func (api *API) workspaceQuota(rw http.ResponseWriter, r *http.Request) {
 	httpapi.Write(r.Context(), rw, http.StatusOK, codersdk.WorkspaceQuota{
 		CreditsConsumed: int(quotaConsumed),
 		Budget:          int(quotaAllowance),
+		Budgets:         budgets,
 	})
 }
+
+// userQuotaBudgets returns the budgets which apply to a user in every
+// organization they are a member of.
+func (api *API) userQuotaBudgets(ctx context.Context, userID uuid.UUID) ([]codersdk.QuotaBudgetStatus, error) {
+	//nolint:gocritic // The caller is authorized to read the user, and
+	// budgets apply to them regardless of whether they can read the groups.
+	ctx = dbauthz.AsSystemRestricted(ctx)
+	organizations, err := api.Database.GetOrganizationsByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	now := dbtime.Now()
+	statuses := []codersdk.QuotaBudgetStatus{}
+	for _, organization := range organizations {
+		budgets, err := api.Database.GetQuotaBudgetsForUser(ctx, database.GetQuotaBudgetsForUserParams{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, budget := range budgets {
+			status, err := api.quotaBudgetStatus(ctx, budget, now)
+			if err != nil {
+				return nil, err
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
@@ -2,6 +2,7 @@ package coderd_test
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"testing"
 
@@ -25,10 +26,8 @@ func verifyQuota(ctx context.Context, t *testing.T, client *codersdk.Client, con
 
 	got, err := client.WorkspaceQuota(ctx, codersdk.Me)
 	require.NoError(t, err)
-	require.EqualValues(t, codersdk.WorkspaceQuota{
-		Budget:          total,
-		CreditsConsumed: consumed,
-	}, got)
+	require.Equal(t, total, got.Budget)
+	require.Equal(t, consumed, got.CreditsConsumed)
 }
 
 func TestWorkspaceQuota(t *testing.T) {
@@ -235,6 +234,91 @@ func TestWorkspaceQuota(t *testing.T) {
 	})
 }
 
+func TestQuotaBudgets(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutil.Context(t, testutil.WaitLong)
+	client, user := coderdenttest.New(t, &coderdenttest.Options{
+		LicenseOptions: &coderdenttest.LicenseOptions{
+			Features: license.Features{
+				codersdk.FeatureTemplateRBAC: 1,
+			},
+		},
+	})
+	memberClient, member := coderdtest.CreateAnotherUser(t, client, user.OrganizationID)
+
+	// Exactly one of a group or a user must be given.
+	_, err := client.CreateQuotaBudget(ctx, user.OrganizationID, codersdk.CreateQuotaBudgetRequest{
+		Period:    codersdk.QuotaBudgetPeriodDay,
+		HardLimit: 10,
+	})
+	require.Error(t, err)
+
+	// Budgets can't be created by regular members.
+	_, err = memberClient.CreateQuotaBudget(ctx, user.OrganizationID, codersdk.CreateQuotaBudgetRequest{
+		UserID:    &member.ID,
+		Period:    codersdk.QuotaBudgetPeriodDay,
+		HardLimit: 10,
+	})
+	require.Error(t, err)
+
+	userBudget, err := client.CreateQuotaBudget(ctx, user.OrganizationID, codersdk.CreateQuotaBudgetRequest{
+		UserID:    &member.ID,
+		Period:    codersdk.QuotaBudgetPeriodDay,
+		HardLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, member.ID, *userBudget.UserID)
+
+	// Only one budget per period is allowed.
+	_, err = client.CreateQuotaBudget(ctx, user.OrganizationID, codersdk.CreateQuotaBudgetRequest{
+		UserID:    &member.ID,
+		Period:    codersdk.QuotaBudgetPeriodDay,
+		HardLimit: 20,
+	})
+	var apiErr *codersdk.Error
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusConflict, apiErr.StatusCode())
+
+	// The "Everyone" group budget applies to every member.
+	groupBudget, err := client.CreateQuotaBudget(ctx, user.OrganizationID, codersdk.CreateQuotaBudgetRequest{
+		GroupID:        &user.OrganizationID,
+		Period:         codersdk.QuotaBudgetPeriodMonth,
+		SoftLimit:      800,
+		HardLimit:      1000,
+		StopWorkspaces: true,
+	})
+	require.NoError(t, err)
+
+	budgets, err := client.QuotaBudgets(ctx, user.OrganizationID)
+	require.NoError(t, err)
+	require.Len(t, budgets, 2)
+	require.EqualValues(t, 0, budgets[0].Spend)
+
+	quota, err := memberClient.WorkspaceQuota(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Len(t, quota.Budgets, 2)
+
+	quota, err = client.WorkspaceQuota(ctx, codersdk.Me)
+	require.NoError(t, err)
+	require.Len(t, quota.Budgets, 1)
+	require.Equal(t, groupBudget.ID, quota.Budgets[0].ID)
+
+	updated, err := client.UpdateQuotaBudget(ctx, user.OrganizationID, groupBudget.ID, codersdk.UpdateQuotaBudgetRequest{
+		StopWorkspaces: ptr.Ref(false),
+	})
+	require.NoError(t, err)
+	require.False(t, updated.StopWorkspaces)
+	require.EqualValues(t, 1000, updated.HardLimit)
+
+	err = client.DeleteQuotaBudget(ctx, user.OrganizationID, userBudget.ID)
+	require.NoError(t, err)
+
+	budgets, err = client.QuotaBudgets(ctx, user.OrganizationID)
+	require.NoError(t, err)
+	require.Len(t, budgets, 1)
+}
+
 func planWithCost(cost int32) []*proto.Response {
 	return []*proto.Response{{
 		Type: &proto.Response_Plan{
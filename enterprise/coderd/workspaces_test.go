@@ -385,7 +385,7 @@ func TestWorkspaceAutobuild(t *testing.T) {
 		// Set MaxOpenConns so we can ensure we aren't inadvertently acquiring
 		// another connection from within a transaction.
 		sdb.SetMaxOpenConns(maxConns)
-		auditor := entaudit.NewAuditor(db, entaudit.DefaultFilter, backends.NewPostgres(db, true))
+		auditor := entaudit.NewAuditor(db, entaudit.DefaultFilter, backends.NewPostgres(db, true, nil))
 		logger := slogtest.Make(t, &slogtest.Options{IgnoreErrors: true}).Leveled(slog.LevelDebug)
 
 		client, user := coderdenttest.New(t, &coderdenttest.Options{
@@ -55,6 +55,11 @@ var (
 	oauthHTML string
 
 	oauthTemplate *htmltemplate.Template
+
+	//go:embed static/extendworkspace.html
+	extendWorkspaceHTML string
+
+	extendWorkspaceTemplate *htmltemplate.Template
 )
 
 func init() {
@@ -68,6 +73,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	extendWorkspaceTemplate, err = htmltemplate.New("extendworkspace").Parse(extendWorkspaceHTML)
+	if err != nil {
+		panic(err)
+	}
 }
 
 type Options struct {
@@ -931,6 +941,12 @@ type RenderOAuthAllowData struct {
 	CancelURI   string
 	RedirectURI string
 	Username    string
+	// Scopes are the permissions the app requests. No scopes means full
+	// access to the account.
+	Scopes []string
+	// UserCodeForm renders a form to enter the code shown by a device instead
+	// of the allow button.
+	UserCodeForm bool
 }
 
 // RenderOAuthAllowPage renders the static page for a user to "Allow" an create
@@ -950,3 +966,30 @@ func RenderOAuthAllowPage(rw http.ResponseWriter, r *http.Request, data RenderOA
 		return
 	}
 }
+
+// RenderExtendWorkspaceData contains the variables that are found in
+// site/static/extendworkspace.html.
+type RenderExtendWorkspaceData struct {
+	WorkspaceName string
+	Username      string
+	// Extension is how much longer the workspace will run, e.g. "1 hour".
+	Extension string
+	// Token is posted back to extend the deadline of the workspace.
+	Token     string
+	CancelURI string
+}
+
+// RenderExtendWorkspacePage renders the static page for a workspace owner to
+// confirm the extension of their workspace's deadline from an autostop
+// warning. The page can be opened from any device, without signing in.
+func RenderExtendWorkspacePage(rw http.ResponseWriter, r *http.Request, data RenderExtendWorkspaceData) {
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	err := extendWorkspaceTemplate.Execute(rw, data)
+	if err != nil {
+		httpapi.Write(r.Context(), rw, http.StatusInternalServerError, codersdk.Response{
+			Message: "Failed to render extend workspace page: " + err.Error(),
+		})
+		return
+	}
+}
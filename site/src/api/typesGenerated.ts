@@ -20,6 +20,8 @@ export interface APIKey {
   readonly scope: APIKeyScope;
   readonly token_name: string;
   readonly lifetime_seconds: number;
+  readonly scopes?: string[];
+  readonly allow_list?: string[];
 }
 
 // From codersdk/apikey.go
@@ -109,12 +111,59 @@ export interface AuditLog {
   readonly user?: User;
 }
 
+// From codersdk/audit.go
+export interface AuditLogChain {
+  readonly organization_id: string;
+  readonly length: number;
+  readonly hash: string;
+  readonly from_index: number;
+  readonly to_index: number;
+  readonly problems: readonly AuditLogChainProblem[];
+}
+
+// From codersdk/audit.go
+export interface AuditLogChainProblem {
+  readonly type: AuditLogChainProblemType;
+  readonly from_index: number;
+  readonly to_index: number;
+  readonly audit_log_id?: string;
+}
+
 // From codersdk/audit.go
 export interface AuditLogResponse {
   readonly audit_logs: readonly AuditLog[];
   readonly count: number;
 }
 
+// From codersdk/audit.go
+export interface AuditLogVerification {
+  readonly valid: boolean;
+  readonly chains: readonly AuditLogChain[];
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingConfig {
+  readonly chain_key: string;
+  readonly syslog: AuditLoggingSyslogConfig;
+  readonly http: AuditLoggingHTTPConfig;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingHTTPConfig {
+  readonly endpoint: string;
+  readonly headers: string[];
+  readonly batch_size: number;
+  readonly flush_interval: number;
+  readonly buffer_directory: string;
+}
+
+// From codersdk/deployment.go
+export interface AuditLoggingSyslogConfig {
+  readonly address: string;
+  readonly format: string;
+  readonly ca_file: string;
+}
+
 // From codersdk/audit.go
 export interface AuditLogsRequest extends Pagination {
   readonly q?: string;
@@ -245,6 +294,16 @@ export interface CreateProvisionerKeyResponse {
   readonly key: string;
 }
 
+// From codersdk/quotabudgets.go
+export interface CreateQuotaBudgetRequest {
+  readonly group_id?: string;
+  readonly user_id?: string;
+  readonly period: QuotaBudgetPeriod;
+  readonly soft_limit: number;
+  readonly hard_limit: number;
+  readonly stop_workspaces: boolean;
+}
+
 // From codersdk/organizations.go
 export interface CreateTemplateRequest {
   readonly name: string;
@@ -302,6 +361,8 @@ export interface CreateTokenRequest {
   readonly lifetime: number;
   readonly scope: APIKeyScope;
   readonly token_name: string;
+  readonly scopes?: string[];
+  readonly allow_list?: string[];
 }
 
 // From codersdk/users.go
@@ -344,6 +405,12 @@ export interface CreateWorkspaceRequest {
   readonly automatic_updates?: AutomaticUpdates;
 }
 
+// From codersdk/workspacesnapshots.go
+export interface CreateWorkspaceSnapshotRequest {
+  readonly name?: string;
+  readonly build_number?: number;
+}
+
 // From codersdk/deployment.go
 export interface DAUEntry {
   readonly date: string;
@@ -465,6 +532,7 @@ export interface DeploymentValues {
   readonly disable_password_auth?: boolean;
   readonly support?: SupportConfig;
   readonly external_auth?: readonly ExternalAuthConfig[];
+  readonly template_policy_rules?: readonly TemplatePolicyRule[];
   readonly config_ssh?: SSHConfig;
   readonly wgtunnel_host?: string;
   readonly disable_owner_workspace_exec?: boolean;
@@ -477,6 +545,7 @@ export interface DeploymentValues {
   readonly cli_upgrade_message?: string;
   readonly terms_of_service_url?: string;
   readonly notifications?: NotificationsConfig;
+  readonly audit_logging?: AuditLoggingConfig;
   readonly config?: string;
   readonly write_config?: boolean;
   readonly address?: string;
@@ -629,6 +698,23 @@ export interface HealthcheckConfig {
   readonly threshold_database: number;
 }
 
+// From codersdk/notifications.go
+export interface InboxNotification {
+  readonly id: string;
+  readonly user_id: string;
+  readonly title: string;
+  readonly content: string;
+  readonly actions: readonly InboxNotificationAction[];
+  readonly read_at?: string;
+  readonly created_at: string;
+}
+
+// From codersdk/notifications.go
+export interface InboxNotificationAction {
+  readonly label: string;
+  readonly url: string;
+}
+
 // From codersdk/workspaceagents.go
 export interface IssueReconnectingPTYSignedTokenRequest {
   readonly url: string;
@@ -667,6 +753,19 @@ export interface LinkConfig {
   readonly icon: string;
 }
 
+// From codersdk/notifications.go
+export interface ListInboxNotificationsRequest {
+  readonly unread_only?: boolean;
+  readonly created_before?: string;
+  readonly limit?: number;
+}
+
+// From codersdk/notifications.go
+export interface ListInboxNotificationsResponse {
+  readonly notifications: readonly InboxNotification[];
+  readonly unread_count: number;
+}
+
 // From codersdk/externalauth.go
 export interface ListUserExternalAuthResponse {
   readonly providers: readonly ExternalAuthLinkProvider[];
@@ -707,6 +806,41 @@ export interface MinimalUser {
   readonly avatar_url: string;
 }
 
+// From codersdk/notifications.go
+export interface NotificationPreference {
+  readonly notification_template_id: string;
+  readonly disabled: boolean;
+  readonly method?: string;
+  readonly updated_at: string;
+}
+
+// From codersdk/notifications.go
+export interface NotificationTemplate {
+  readonly id: string;
+  readonly name: string;
+  readonly title_template: string;
+  readonly body_template: string;
+  readonly actions: string;
+  readonly group: string;
+  readonly mandatory: boolean;
+  readonly digest: boolean;
+  readonly customized: boolean;
+}
+
+// From codersdk/notifications.go
+export interface NotificationTemplateAction {
+  readonly label: string;
+  readonly url: string;
+}
+
+// From codersdk/notifications.go
+export interface NotificationTemplatePreview {
+  readonly title: string;
+  readonly body: string;
+  readonly actions: readonly NotificationTemplateAction[];
+  readonly labels: Record<string, string>;
+}
+
 // From codersdk/deployment.go
 export interface NotificationsConfig {
   readonly max_send_attempts: number;
@@ -718,8 +852,14 @@ export interface NotificationsConfig {
   readonly fetch_interval: number;
   readonly method: string;
   readonly dispatch_timeout: number;
+  readonly autostop_warning: number;
+  readonly dedupe_window: number;
+  readonly digest_window: number;
+  readonly per_user_rate_limit: number;
   readonly email: NotificationsEmailConfig;
   readonly webhook: NotificationsWebhookConfig;
+  readonly slack: NotificationsSlackConfig;
+  readonly teams: NotificationsTeamsConfig;
 }
 
 // From codersdk/deployment.go
@@ -755,6 +895,17 @@ export interface NotificationsSettings {
   readonly notifier_paused: boolean;
 }
 
+// From codersdk/deployment.go
+export interface NotificationsSlackConfig {
+  readonly endpoint: string;
+  readonly bot_token: string;
+}
+
+// From codersdk/deployment.go
+export interface NotificationsTeamsConfig {
+  readonly endpoint: string;
+}
+
 // From codersdk/deployment.go
 export interface NotificationsWebhookConfig {
   readonly endpoint: string;
@@ -767,11 +918,43 @@ export interface OAuth2AppEndpoints {
   readonly device_authorization: string;
 }
 
+// From codersdk/oauth2.go
+export interface OAuth2ClientRegistrationRequest {
+  readonly client_name: string;
+  readonly redirect_uris: string[];
+  readonly logo_uri?: string;
+  readonly grant_types?: OAuth2ProviderGrantType[];
+  readonly token_endpoint_auth_method?: string;
+}
+
+// From codersdk/oauth2.go
+export interface OAuth2ClientRegistrationResponse {
+  readonly client_id: string;
+  readonly client_secret?: string;
+  readonly client_id_issued_at: number;
+  readonly client_secret_expires_at: number;
+  readonly client_name: string;
+  readonly redirect_uris: string[];
+  readonly logo_uri?: string;
+  readonly grant_types: OAuth2ProviderGrantType[];
+  readonly token_endpoint_auth_method: string;
+}
+
 // From codersdk/deployment.go
 export interface OAuth2Config {
   readonly github: OAuth2GithubConfig;
 }
 
+// From codersdk/oauth2.go
+export interface OAuth2DeviceAuthorizationResponse {
+  readonly device_code: string;
+  readonly user_code: string;
+  readonly verification_uri: string;
+  readonly verification_uri_complete: string;
+  readonly expires_in: number;
+  readonly interval: number;
+}
+
 // From codersdk/deployment.go
 export interface OAuth2GithubConfig {
   readonly client_id: string;
@@ -789,6 +972,7 @@ export interface OAuth2ProviderApp {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly client_type: OAuth2ProviderAppClientType;
   readonly endpoints: OAuth2AppEndpoints;
 }
 
@@ -924,6 +1108,7 @@ export interface PostOAuth2ProviderAppRequest {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly client_type?: OAuth2ProviderAppClientType;
 }
 
 // From codersdk/workspaces.go
@@ -947,6 +1132,11 @@ export interface PrometheusConfig {
   readonly aggregate_agent_stats_by: string[];
 }
 
+// From codersdk/templatereleasechannels.go
+export interface PromoteTemplateVersionRequest {
+  readonly template_version_id: string;
+}
+
 // From codersdk/deployment.go
 export interface ProvisionerConfig {
   readonly daemons: number;
@@ -1022,6 +1212,28 @@ export interface PutOAuth2ProviderAppRequest {
   readonly name: string;
   readonly callback_url: string;
   readonly icon: string;
+  readonly client_type?: OAuth2ProviderAppClientType;
+}
+
+// From codersdk/quotabudgets.go
+export interface QuotaBudget {
+  readonly id: string;
+  readonly organization_id: string;
+  readonly group_id?: string;
+  readonly user_id?: string;
+  readonly period: QuotaBudgetPeriod;
+  readonly soft_limit: number;
+  readonly hard_limit: number;
+  readonly stop_workspaces: boolean;
+  readonly created_at: string;
+  readonly updated_at: string;
+}
+
+// From codersdk/quotabudgets.go
+export interface QuotaBudgetStatus extends QuotaBudget {
+  readonly period_start: string;
+  readonly period_end: string;
+  readonly spend: number;
 }
 
 // From codersdk/deployment.go
@@ -1204,6 +1416,8 @@ export interface Template {
   readonly failure_ttl_ms: number;
   readonly time_til_dormant_ms: number;
   readonly time_til_dormant_autodelete_ms: number;
+  readonly idle_timeout_ms: number;
+  readonly idle_cpu_threshold: number;
   readonly require_active_version: boolean;
   readonly max_port_share_level: WorkspaceAgentPortShareLevel;
 }
@@ -1259,6 +1473,25 @@ export interface TemplateFilter {
   readonly ExactName: string;
 }
 
+// From codersdk/templategitsyncs.go
+export interface TemplateGitSync {
+  readonly template_id: string;
+  readonly repository_url: string;
+  readonly branch: string;
+  readonly directory: string;
+  readonly external_auth_provider_id: string;
+  readonly auto_activate: boolean;
+  readonly webhook_url: string;
+  readonly webhook_secret?: string;
+  readonly created_by: string;
+  readonly last_commit_sha: string;
+  readonly last_template_version_id?: string;
+  readonly last_synced_at?: string;
+  readonly last_error?: string;
+  readonly created_at: string;
+  readonly updated_at: string;
+}
+
 // From codersdk/templates.go
 export interface TemplateGroup extends Group {
   readonly role: TemplateRole;
@@ -1315,6 +1548,26 @@ export interface TemplateParameterValue {
   readonly count: number;
 }
 
+// From codersdk/deployment.go
+export interface TemplatePolicyRule {
+  readonly name?: string;
+  readonly type: TemplatePolicyRuleType;
+  readonly providers?: readonly string[];
+  readonly metadata_keys?: readonly string[];
+  readonly instance_types?: readonly string[];
+}
+
+// From codersdk/templatereleasechannels.go
+export interface TemplateReleaseChannel {
+  readonly id: string;
+  readonly template_id: string;
+  readonly name: string;
+  readonly template_version_id: string;
+  readonly template_version_name: string;
+  readonly created_at: string;
+  readonly updated_at: string;
+}
+
 // From codersdk/templates.go
 export interface TemplateUser extends User {
   readonly role: TemplateRole;
@@ -1336,6 +1589,16 @@ export interface TemplateVersion {
   readonly warnings?: readonly TemplateVersionWarning[];
 }
 
+// From codersdk/templateversiondiff.go
+export interface TemplateVersionDiff {
+  readonly from_version_id: string;
+  readonly to_version_id: string;
+  readonly files: readonly TemplateVersionFileDiff[];
+  readonly parameters: readonly TemplateVersionParameterDiff[];
+  readonly variables: readonly TemplateVersionVariableDiff[];
+  readonly resources: readonly TemplateVersionResourceDiff[];
+}
+
 // From codersdk/templateversions.go
 export interface TemplateVersionExternalAuth {
   readonly id: string;
@@ -1347,6 +1610,14 @@ export interface TemplateVersionExternalAuth {
   readonly optional?: boolean;
 }
 
+// From codersdk/templateversiondiff.go
+export interface TemplateVersionFileDiff {
+  readonly path: string;
+  readonly status: TemplateVersionDiffStatus;
+  readonly binary: boolean;
+  readonly diff?: string;
+}
+
 // From codersdk/templateversions.go
 export interface TemplateVersionParameter {
   readonly name: string;
@@ -1367,6 +1638,15 @@ export interface TemplateVersionParameter {
   readonly ephemeral: boolean;
 }
 
+// From codersdk/templateversiondiff.go
+export interface TemplateVersionParameterDiff {
+  readonly name: string;
+  readonly status: TemplateVersionDiffStatus;
+  readonly changes?: readonly string[];
+  readonly from?: TemplateVersionParameter;
+  readonly to?: TemplateVersionParameter;
+}
+
 // From codersdk/templateversions.go
 export interface TemplateVersionParameterOption {
   readonly name: string;
@@ -1375,6 +1655,14 @@ export interface TemplateVersionParameterOption {
   readonly icon: string;
 }
 
+// From codersdk/templateversiondiff.go
+export interface TemplateVersionResourceDiff {
+  readonly type: string;
+  readonly name: string;
+  readonly status: TemplateVersionDiffStatus;
+  readonly changes?: readonly string[];
+}
+
 // From codersdk/templateversions.go
 export interface TemplateVersionVariable {
   readonly name: string;
@@ -1386,12 +1674,26 @@ export interface TemplateVersionVariable {
   readonly sensitive: boolean;
 }
 
+// From codersdk/templateversiondiff.go
+export interface TemplateVersionVariableDiff {
+  readonly name: string;
+  readonly status: TemplateVersionDiffStatus;
+  readonly changes?: readonly string[];
+  readonly from?: TemplateVersionVariable;
+  readonly to?: TemplateVersionVariable;
+}
+
 // From codersdk/templates.go
 export interface TemplateVersionsByTemplateRequest extends Pagination {
   readonly template_id: string;
   readonly include_archived: boolean;
 }
 
+// From codersdk/notifications.go
+export interface TestNotificationTemplateRequest {
+  readonly labels?: Record<string, string>;
+}
+
 // From codersdk/apikey.go
 export interface TokenConfig {
   readonly max_token_lifetime: number;
@@ -1436,6 +1738,30 @@ export interface UpdateCheckResponse {
   readonly url: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateInboxNotificationReadStatusRequest {
+  readonly is_read: boolean;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationPreference {
+  readonly notification_template_id: string;
+  readonly disabled: boolean;
+  readonly method?: string;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationTemplateMandatory {
+  readonly mandatory: boolean;
+}
+
+// From codersdk/notifications.go
+export interface UpdateNotificationTemplateRequest {
+  readonly title_template?: string;
+  readonly body_template?: string;
+  readonly actions?: string;
+}
+
 // From codersdk/organizations.go
 export interface UpdateOrganizationRequest {
   readonly name?: string;
@@ -1444,6 +1770,13 @@ export interface UpdateOrganizationRequest {
   readonly icon?: string;
 }
 
+// From codersdk/quotabudgets.go
+export interface UpdateQuotaBudgetRequest {
+  readonly soft_limit?: number;
+  readonly hard_limit?: number;
+  readonly stop_workspaces?: boolean;
+}
+
 // From codersdk/users.go
 export interface UpdateRoles {
   readonly roles: readonly string[];
@@ -1455,6 +1788,15 @@ export interface UpdateTemplateACL {
   readonly group_perms?: Record<string, TemplateRole>;
 }
 
+// From codersdk/templategitsyncs.go
+export interface UpdateTemplateGitSyncRequest {
+  readonly repository_url: string;
+  readonly branch?: string;
+  readonly directory?: string;
+  readonly external_auth_provider_id?: string;
+  readonly auto_activate?: boolean;
+}
+
 // From codersdk/templates.go
 export interface UpdateTemplateMeta {
   readonly name?: string;
@@ -1473,6 +1815,8 @@ export interface UpdateTemplateMeta {
   readonly time_til_dormant_autodelete_ms?: number;
   readonly update_workspace_last_used_at: boolean;
   readonly update_workspace_dormant_at: boolean;
+  readonly idle_timeout_ms?: number;
+  readonly idle_cpu_threshold?: number;
   readonly require_active_version?: boolean;
   readonly deprecation_message?: string;
   readonly disable_everyone_group_access: boolean;
@@ -1484,6 +1828,11 @@ export interface UpdateUserAppearanceSettingsRequest {
   readonly theme_preference: string;
 }
 
+// From codersdk/notifications.go
+export interface UpdateUserNotificationPreferences {
+  readonly preferences: readonly UpdateNotificationPreference[];
+}
+
 // From codersdk/users.go
 export interface UpdateUserPasswordRequest {
   readonly old_password: string;
@@ -1501,6 +1850,12 @@ export interface UpdateUserQuietHoursScheduleRequest {
   readonly schedule: string;
 }
 
+// From codersdk/workspacesharing.go
+export interface UpdateWorkspaceACL {
+  readonly user_roles?: Record<string, WorkspaceRole>;
+  readonly group_roles?: Record<string, WorkspaceRole>;
+}
+
 // From codersdk/workspaces.go
 export interface UpdateWorkspaceAutomaticUpdatesRequest {
   readonly automatic_updates: AutomaticUpdates;
@@ -1522,6 +1877,11 @@ export interface UpdateWorkspaceProxyResponse {
   readonly proxy_token: string;
 }
 
+// From codersdk/workspaces.go
+export interface UpdateWorkspaceReleaseChannelRequest {
+  readonly release_channel: string;
+}
+
 // From codersdk/workspaces.go
 export interface UpdateWorkspaceRequest {
   readonly name?: string;
@@ -1543,6 +1903,8 @@ export interface UpsertWorkspaceAgentPortShareRequest {
   readonly port: number;
   readonly share_level: WorkspaceAgentPortShareLevel;
   readonly protocol: WorkspaceAgentPortShareProtocol;
+  readonly allowed_user_ids?: readonly string[];
+  readonly allowed_group_ids?: readonly string[];
 }
 
 // From codersdk/users.go
@@ -1659,6 +2021,11 @@ export interface VariableValue {
   readonly value: string;
 }
 
+// From codersdk/audit.go
+export interface VerifyAuditLogsRequest {
+  readonly after_index: number;
+}
+
 // From codersdk/workspaces.go
 export interface Workspace {
   readonly id: string;
@@ -1686,10 +2053,17 @@ export interface Workspace {
   readonly dormant_at?: string;
   readonly health: WorkspaceHealth;
   readonly automatic_updates: AutomaticUpdates;
+  readonly release_channel?: string;
   readonly allow_renames: boolean;
   readonly favorite: boolean;
 }
 
+// From codersdk/workspacesharing.go
+export interface WorkspaceACL {
+  readonly users: readonly WorkspaceUser[];
+  readonly groups: readonly WorkspaceGroup[];
+}
+
 // From codersdk/workspaceagents.go
 export interface WorkspaceAgent {
   readonly id: string;
@@ -1792,6 +2166,8 @@ export interface WorkspaceAgentPortShare {
   readonly port: number;
   readonly share_level: WorkspaceAgentPortShareLevel;
   readonly protocol: WorkspaceAgentPortShareProtocol;
+  readonly allowed_user_ids: readonly string[];
+  readonly allowed_group_ids: readonly string[];
 }
 
 // From codersdk/workspaceagentportshare.go
@@ -1886,6 +2262,11 @@ export interface WorkspaceFilter {
   readonly q?: string;
 }
 
+// From codersdk/workspacesharing.go
+export interface WorkspaceGroup extends Group {
+  readonly role: WorkspaceRole;
+}
+
 // From codersdk/workspaces.go
 export interface WorkspaceHealth {
   readonly healthy: boolean;
@@ -1925,6 +2306,7 @@ export interface WorkspaceProxyStatus {
 export interface WorkspaceQuota {
   readonly credits_consumed: number;
   readonly budget: number;
+  readonly budgets: readonly QuotaBudgetStatus[];
 }
 
 // From codersdk/workspacebuilds.go
@@ -1949,6 +2331,26 @@ export interface WorkspaceResourceMetadata {
   readonly sensitive: boolean;
 }
 
+// From codersdk/workspacesnapshots.go
+export interface WorkspaceSnapshot {
+  readonly id: string;
+  readonly workspace_id: string;
+  readonly workspace_build_id: string;
+  readonly build_number: number;
+  readonly template_version_id: string;
+  readonly template_version_name: string;
+  readonly name: string;
+  readonly transition: WorkspaceTransition;
+  readonly rich_parameter_values: readonly WorkspaceBuildParameter[];
+  readonly created_by: string;
+  readonly created_at: string;
+}
+
+// From codersdk/workspacesharing.go
+export interface WorkspaceUser extends MinimalUser {
+  readonly role: WorkspaceRole;
+}
+
 // From codersdk/workspaces.go
 export interface WorkspacesRequest extends Pagination {
   readonly q?: string;
@@ -1993,15 +2395,31 @@ export const AuditActions: AuditAction[] = [
   "write",
 ];
 
+// From codersdk/audit.go
+export type AuditLogChainProblemType = "gap" | "modified";
+export const AuditLogChainProblemTypes: AuditLogChainProblemType[] = [
+  "gap",
+  "modified",
+];
+
+// From codersdk/deployment.go
+export type AuditLoggingSyslogFormat = "cef" | "json" | "leef";
+export const AuditLoggingSyslogFormats: AuditLoggingSyslogFormat[] = [
+  "cef",
+  "json",
+  "leef",
+];
+
 // From codersdk/workspaces.go
 export type AutomaticUpdates = "always" | "never";
 export const AutomaticUpdateses: AutomaticUpdates[] = ["always", "never"];
 
 // From codersdk/workspacebuilds.go
-export type BuildReason = "autostart" | "autostop" | "initiator";
+export type BuildReason = "autostart" | "autostop" | "idle" | "initiator";
 export const BuildReasons: BuildReason[] = [
   "autostart",
   "autostop",
+  "idle",
   "initiator",
 ];
 
@@ -2125,8 +2543,13 @@ export const InsightsReportIntervals: InsightsReportInterval[] = [
 ];
 
 // From codersdk/provisionerdaemons.go
-export type JobErrorCode = "REQUIRED_TEMPLATE_VARIABLES";
-export const JobErrorCodes: JobErrorCode[] = ["REQUIRED_TEMPLATE_VARIABLES"];
+export type JobErrorCode =
+  | "REQUIRED_TEMPLATE_VARIABLES"
+  | "TEMPLATE_POLICY_VIOLATION";
+export const JobErrorCodes: JobErrorCode[] = [
+  "REQUIRED_TEMPLATE_VARIABLES",
+  "TEMPLATE_POLICY_VIOLATION",
+];
 
 // From codersdk/provisionerdaemons.go
 export type LogLevel = "debug" | "error" | "info" | "trace" | "warn";
@@ -2154,10 +2577,21 @@ export const LoginTypes: LoginType[] = [
 ];
 
 // From codersdk/oauth2.go
-export type OAuth2ProviderGrantType = "authorization_code" | "refresh_token";
+export type OAuth2ProviderAppClientType = "confidential" | "public";
+export const OAuth2ProviderAppClientTypes: OAuth2ProviderAppClientType[] = [
+  "confidential",
+  "public",
+];
+
+// From codersdk/oauth2.go
+export type OAuth2ProviderGrantType =
+  | "authorization_code"
+  | "refresh_token"
+  | "urn:ietf:params:oauth:grant-type:device_code";
 export const OAuth2ProviderGrantTypes: OAuth2ProviderGrantType[] = [
   "authorization_code",
   "refresh_token",
+  "urn:ietf:params:oauth:grant-type:device_code",
 ];
 
 // From codersdk/oauth2.go
@@ -2201,6 +2635,10 @@ export const ProvisionerStorageMethods: ProvisionerStorageMethod[] = ["file"];
 export type ProvisionerType = "echo" | "terraform";
 export const ProvisionerTypes: ProvisionerType[] = ["echo", "terraform"];
 
+// From codersdk/quotabudgets.go
+export type QuotaBudgetPeriod = "day" | "month";
+export const QuotaBudgetPeriods: QuotaBudgetPeriod[] = ["day", "month"];
+
 // From codersdk/workspaceproxy.go
 export type ProxyHealthStatus =
   | "ok"
@@ -2311,11 +2749,15 @@ export type ResourceType =
   | "group"
   | "health_settings"
   | "license"
+  | "notification_template"
   | "notifications_settings"
   | "oauth2_provider_app"
   | "oauth2_provider_app_secret"
   | "organization"
+  | "quota_budget"
   | "template"
+  | "template_git_sync"
+  | "template_release_channel"
   | "template_version"
   | "user"
   | "workspace"
@@ -2329,11 +2771,15 @@ export const ResourceTypes: ResourceType[] = [
   "group",
   "health_settings",
   "license",
+  "notification_template",
   "notifications_settings",
   "oauth2_provider_app",
   "oauth2_provider_app_secret",
   "organization",
+  "quota_budget",
   "template",
+  "template_git_sync",
+  "template_release_channel",
   "template_version",
   "user",
   "workspace",
@@ -2360,10 +2806,31 @@ export const TemplateInsightsSections: TemplateInsightsSection[] = [
   "report",
 ];
 
+// From codersdk/deployment.go
+export type TemplatePolicyRuleType =
+  | "allowed_instance_types"
+  | "forbidden_providers"
+  | "required_metadata"
+  | "required_shutdown_script";
+export const TemplatePolicyRuleTypes: TemplatePolicyRuleType[] = [
+  "allowed_instance_types",
+  "forbidden_providers",
+  "required_metadata",
+  "required_shutdown_script",
+];
+
 // From codersdk/templates.go
 export type TemplateRole = "" | "admin" | "use";
 export const TemplateRoles: TemplateRole[] = ["", "admin", "use"];
 
+// From codersdk/templateversiondiff.go
+export type TemplateVersionDiffStatus = "added" | "modified" | "removed";
+export const TemplateVersionDiffStatuses: TemplateVersionDiffStatus[] = [
+  "added",
+  "modified",
+  "removed",
+];
+
 // From codersdk/templateversions.go
 export type TemplateVersionWarning = "UNSUPPORTED_WORKSPACES";
 export const TemplateVersionWarnings: TemplateVersionWarning[] = [
@@ -2414,17 +2881,24 @@ export const WorkspaceAgentLifecycles: WorkspaceAgentLifecycle[] = [
 ];
 
 // From codersdk/workspaceagentportshare.go
-export type WorkspaceAgentPortShareLevel = "authenticated" | "owner" | "public";
+export type WorkspaceAgentPortShareLevel =
+  | "allowlist"
+  | "authenticated"
+  | "organization"
+  | "owner"
+  | "public";
 export const WorkspaceAgentPortShareLevels: WorkspaceAgentPortShareLevel[] = [
+  "allowlist",
   "authenticated",
+  "organization",
   "owner",
   "public",
 ];
 
 // From codersdk/workspaceagentportshare.go
-export type WorkspaceAgentPortShareProtocol = "http" | "https";
+export type WorkspaceAgentPortShareProtocol = "h2c" | "http" | "https" | "tcp";
 export const WorkspaceAgentPortShareProtocols: WorkspaceAgentPortShareProtocol[] =
-  ["http", "https"];
+  ["h2c", "http", "https", "tcp"];
 
 // From codersdk/workspaceagents.go
 export type WorkspaceAgentStartupScriptBehavior = "blocking" | "non-blocking";
@@ -2465,6 +2939,10 @@ export const WorkspaceAppSharingLevels: WorkspaceAppSharingLevel[] = [
   "public",
 ];
 
+// From codersdk/workspacesharing.go
+export type WorkspaceRole = "" | "admin" | "use";
+export const WorkspaceRoles: WorkspaceRole[] = ["", "admin", "use"];
+
 // From codersdk/workspacebuilds.go
 export type WorkspaceStatus =
   | "canceled"
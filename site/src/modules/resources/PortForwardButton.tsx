@@ -455,6 +455,8 @@ export const PortForwardPopoverView: FC<PortForwardPopoverViewProps> = ({
                     >
                       <MenuItem value="http">HTTP</MenuItem>
                       <MenuItem value="https">HTTPS</MenuItem>
+                      <MenuItem value="h2c">HTTP/2 (h2c)</MenuItem>
+                      <MenuItem value="tcp">TCP</MenuItem>
                     </Select>
                   </FormControl>
 
@@ -477,6 +479,7 @@ export const PortForwardPopoverView: FC<PortForwardPopoverViewProps> = ({
                           await sharedPortsQuery.refetch();
                         }}
                       >
+                        <MenuItem value="organization">Organization</MenuItem>
                         <MenuItem value="authenticated">Authenticated</MenuItem>
                         {canSharePortsPublic ? (
                           <MenuItem value="public">Public</MenuItem>
@@ -537,6 +540,8 @@ export const PortForwardPopoverView: FC<PortForwardPopoverViewProps> = ({
                 >
                   <MenuItem value="http">HTTP</MenuItem>
                   <MenuItem value="https">HTTPS</MenuItem>
+                  <MenuItem value="h2c">HTTP/2 (h2c)</MenuItem>
+                  <MenuItem value="tcp">TCP</MenuItem>
                 </TextField>
                 <TextField
                   {...getFieldHelpers("share_level")}
@@ -546,6 +551,7 @@ export const PortForwardPopoverView: FC<PortForwardPopoverViewProps> = ({
                   value={form.values.share_level}
                   label="Sharing Level"
                 >
+                  <MenuItem value="organization">Organization</MenuItem>
                   <MenuItem value="authenticated">Authenticated</MenuItem>
                   {canSharePortsPublic ? (
                     <MenuItem value="public">Public</MenuItem>
@@ -276,6 +276,8 @@ export const TemplateSettingsForm: FC<TemplateSettingsForm> = ({
             label="Maximum Port Sharing Level"
           >
             <MenuItem value="owner">Owner</MenuItem>
+            <MenuItem value="allowlist">Allowed users and groups</MenuItem>
+            <MenuItem value="organization">Organization</MenuItem>
             <MenuItem value="authenticated">Authenticated</MenuItem>
             <MenuItem value="public">Public</MenuItem>
           </TextField>
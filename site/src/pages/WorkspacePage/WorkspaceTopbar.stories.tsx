@@ -270,6 +270,7 @@ export const WithQuota: Story = {
         data: {
           credits_consumed: 2,
           budget: 40,
+          budgets: [],
         },
       },
     ],
@@ -596,6 +596,8 @@ export const MockTemplate: TypesGen.Template = {
   failure_ttl_ms: 0,
   time_til_dormant_ms: 0,
   time_til_dormant_autodelete_ms: 0,
+  idle_timeout_ms: 0,
+  idle_cpu_threshold: 0,
   allow_user_autostart: true,
   allow_user_autostop: true,
   require_active_version: false,
@@ -2412,6 +2414,7 @@ export const MockAuditLogUnsuccessfulLoginKnownUser: TypesGen.AuditLog = {
 export const MockWorkspaceQuota: TypesGen.WorkspaceQuota = {
   credits_consumed: 0,
   budget: 100,
+  budgets: [],
 };
 
 export const MockGroup: TypesGen.Group = {
@@ -3459,6 +3462,8 @@ export const MockSharedPortsResponse: TypesGen.WorkspaceAgentPortShares = {
       port: 4000,
       share_level: "authenticated",
       protocol: "http",
+      allowed_user_ids: [],
+      allowed_group_ids: [],
     },
     {
       workspace_id: MockWorkspace.id,
@@ -3466,6 +3471,8 @@ export const MockSharedPortsResponse: TypesGen.WorkspaceAgentPortShares = {
       port: 65535,
       share_level: "authenticated",
       protocol: "https",
+      allowed_user_ids: [],
+      allowed_group_ids: [],
     },
     {
       workspace_id: MockWorkspace.id,
@@ -3473,6 +3480,8 @@ export const MockSharedPortsResponse: TypesGen.WorkspaceAgentPortShares = {
       port: 8081,
       share_level: "public",
       protocol: "http",
+      allowed_user_ids: [],
+      allowed_group_ids: [],
     },
   ],
 };
@@ -3619,10 +3628,11 @@ export const MockOAuth2ProviderApps: TypesGen.OAuth2ProviderApp[] = [
     name: "foo",
     callback_url: "http://localhost:3001",
     icon: "/icon/github.svg",
+    client_type: "confidential",
     endpoints: {
       authorization: "http://localhost:3001/oauth2/authorize",
       token: "http://localhost:3001/oauth2/token",
-      device_authorization: "",
+      device_authorization: "http://localhost:3001/oauth2/device/authorize",
     },
   },
 ];
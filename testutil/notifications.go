@@ -35,6 +35,15 @@ func (f *FakeNotificationsEnqueuer) Enqueue(_ context.Context, userID, templateI
 	return &id, nil
 }
 
+// Notifications returns the notifications enqueued so far. Unlike Sent, it is
+// safe to call while notifications are being enqueued.
+func (f *FakeNotificationsEnqueuer) Notifications() []*Notification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*Notification{}, f.Sent...)
+}
+
 func (f *FakeNotificationsEnqueuer) Clear() {
 	f.mu.Lock()
 	defer f.mu.Unlock()